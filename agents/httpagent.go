@@ -101,6 +101,12 @@ func (ha *HTTPAgent) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 				utils.HTTPAgent, err.Error()))
 		return
 	}
+	if err = haWriteReplyHeader(w, rplyNM, ha.rplyPayload); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s writing reply header %s",
+				utils.HTTPAgent, err.Error(), utils.ToJSON(rplyNM)))
+		return
+	}
 	if err = encdr.Encode(rplyNM); err != nil {
 		utils.Logger.Warning(
 			fmt.Sprintf("<%s> error: %s encoding out %s",
@@ -254,6 +254,9 @@ func processRequest(ctx *context.Context, reqProcessor *config.RequestProcessor,
 	case utils.DNSAgent:
 		rawStatIDs = reqProcessor.Flags.ParamValue(utils.MetaDNSStats)
 		rawThIDs = reqProcessor.Flags.ParamValue(utils.MetaDNSThresholds)
+	case utils.SMPPAgent:
+		rawStatIDs = reqProcessor.Flags.ParamValue(utils.MetaSMPPStats)
+		rawThIDs = reqProcessor.Flags.ParamValue(utils.MetaSMPPThresholds)
 	}
 
 	// Return early if nothing to process.
@@ -19,11 +19,14 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package agents
 
 import (
+	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httputil"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -41,7 +44,8 @@ func newHADataProvider(reqPayload string,
 		return newHTTPUrlDP(req)
 	case utils.MetaXml:
 		return newHTTPXmlDP(req)
-
+	case utils.MetaJSON:
+		return newHTTPJSONDP(req)
 	}
 }
 
@@ -177,6 +181,72 @@ func (hU *httpXmlDP) FieldAsString(fldPath []string) (data string, err error) {
 	return utils.IfaceAsString(valIface), nil
 }
 
+func newHTTPJSONDP(req *http.Request) (dP utils.DataProvider, err error) {
+	body := make(map[string]any)
+	dec := json.NewDecoder(req.Body)
+	dec.UseNumber() // do not lose precision on large integers
+	if err = dec.Decode(&body); err != nil &&
+		!errors.Is(err, io.EOF) { // empty body is accepted
+		return nil, err
+	}
+	haJSONNumbers(body)
+	return &httpJSONDP{req: req, body: body}, nil
+}
+
+// haJSONNumbers converts the json.Number values decoded into int64 when
+// possible, float64 otherwise, keeping the original text for the numbers
+// which would lose precision as float64
+func haJSONNumbers(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, itm := range val {
+			val[k] = haJSONNumbers(itm)
+		}
+	case []any:
+		for i, itm := range val {
+			val[i] = haJSONNumbers(itm)
+		}
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		if strings.ContainsAny(val.String(), ".eE") {
+			if f, err := val.Float64(); err == nil {
+				return f
+			}
+		}
+		return val.String() // integer out of int64 range
+	}
+	return v
+}
+
+// httpJSONDP implements utils.DataProvider, serving as JSON data decoder
+// nested fields and slice elements are reached with paths like Subscriber.Devices[0].IMEI
+type httpJSONDP struct {
+	req  *http.Request
+	body utils.MapStorage
+}
+
+// String is part of utils.DataProvider interface
+func (hJ *httpJSONDP) String() string {
+	return utils.ToJSON(hJ.body)
+}
+
+// FieldAsInterface is part of utils.DataProvider interface
+func (hJ *httpJSONDP) FieldAsInterface(fldPath []string) (data any, err error) {
+	return hJ.body.FieldAsInterface(fldPath)
+}
+
+// FieldAsString is part of utils.DataProvider interface
+func (hJ *httpJSONDP) FieldAsString(fldPath []string) (data string, err error) {
+	var valIface any
+	valIface, err = hJ.FieldAsInterface(fldPath)
+	if err != nil {
+		return
+	}
+	return utils.IfaceAsString(valIface), nil
+}
+
 // httpAgentReplyEncoder will encode  []*engine.NMElement
 // and write content to http writer
 type httpAgentReplyEncoder interface {
@@ -193,7 +263,39 @@ func newHAReplyEncoder(encType string,
 		return newHAXMLEncoder(w)
 	case utils.MetaTextPlain:
 		return newHATextPlainEncoder(w)
+	case utils.MetaJSON:
+		return newHAJSONEncoder(w)
+	}
+}
+
+// haWriteReplyHeader writes the HTTP header of the reply, using the special
+// *httpStatusCode and *httpContentType reply fields which are removed from
+// the fields to be encoded in body
+func haWriteReplyHeader(w http.ResponseWriter, nM *utils.OrderedNavigableMap, encType string) (err error) {
+	if encType == utils.MetaJSON {
+		w.Header().Set(utils.ContentType, utils.JsonBody)
+	}
+	if cType, err := nM.FieldAsString([]string{utils.MetaHTTPContentType, "0"}); err == nil {
+		w.Header().Set(utils.ContentType, cType)
+		if err = nM.Remove(&utils.FullPath{PathSlice: []string{utils.MetaHTTPContentType},
+			Path: utils.MetaHTTPContentType}); err != nil {
+			return err
+		}
+	}
+	codeStr, err := nM.FieldAsString([]string{utils.MetaHTTPStatusCode, "0"})
+	if err != nil {
+		return nil // status code not set from template, let the writer use the default one
+	}
+	var code int
+	if code, err = strconv.Atoi(codeStr); err != nil {
+		return fmt.Errorf("invalid %s <%s>: %w", utils.MetaHTTPStatusCode, codeStr, err)
+	}
+	if err = nM.Remove(&utils.FullPath{PathSlice: []string{utils.MetaHTTPStatusCode},
+		Path: utils.MetaHTTPStatusCode}); err != nil {
+		return
 	}
+	w.WriteHeader(code)
+	return
 }
 
 func newHAXMLEncoder(w http.ResponseWriter) (xE httpAgentReplyEncoder, err error) {
@@ -250,3 +352,58 @@ func (xE *haTextPlainEncoder) Encode(nM *utils.OrderedNavigableMap) (err error)
 	_, err = xE.w.Write([]byte(str))
 	return
 }
+
+func newHAJSONEncoder(w http.ResponseWriter) (jE httpAgentReplyEncoder, err error) {
+	return &haJSONEncoder{w: w}, nil
+}
+
+type haJSONEncoder struct {
+	w http.ResponseWriter
+}
+
+// Encode implements httpAgentReplyEncoder
+func (jE *haJSONEncoder) Encode(nM *utils.OrderedNavigableMap) (err error) {
+	if nM.Empty() {
+		return
+	}
+	var jsnOut []byte
+	fldPaths := make(utils.StringSet) // paths of the slices populated by the template fields
+	for el := nM.GetFirstElement(); el != nil; el = el.Next() {
+		fldPaths.Add(strings.Join(el.Value[:len(el.Value)-1], utils.NestingSep))
+	}
+	if jsnOut, err = json.Marshal(haJSONValue(nM.Interface().(*utils.DataNode), nil, fldPaths)); err != nil {
+		return
+	}
+	_, err = jE.w.Write(jsnOut)
+	return
+}
+
+// haJSONValue converts the DataNode into a value ready for JSON marshaling,
+// unwrapping the single leaf slices created by the reply templates for each field;
+// fldPaths holds the paths of the template fields so the slices created with
+// indexed paths(ie: Items[0]) are kept as JSON arrays
+func haJSONValue(n *utils.DataNode, path []string, fldPaths utils.StringSet) any {
+	switch n.Type {
+	case utils.NMMapType:
+		m := make(map[string]any, len(n.Map))
+		for key, val := range n.Map {
+			m[key] = haJSONValue(val, append(slices.Clone(path), key), fldPaths)
+		}
+		return m
+	case utils.NMSliceType:
+		if len(n.Slice) == 1 &&
+			n.Slice[0].Type == utils.NMDataType &&
+			fldPaths.Has(strings.Join(path, utils.NestingSep)) { // value of a template field
+			return haJSONValue(n.Slice[0], append(slices.Clone(path), "0"), fldPaths)
+		}
+		s := make([]any, len(n.Slice))
+		for i, val := range n.Slice {
+			s[i] = haJSONValue(val, append(slices.Clone(path), strconv.Itoa(i)), fldPaths)
+		}
+		return s
+	}
+	if n.Value == nil {
+		return nil
+	}
+	return n.Value.Data
+}
@@ -29,6 +29,8 @@ import (
 	"testing"
 
 	"github.com/antchfx/xmlquery"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -236,6 +238,12 @@ func TestLibhttpagentNewHAReplyEncoder(t *testing.T) {
 			wantType: "*agents.haTextPlainEncoder",
 			wantErr:  false,
 		},
+		{
+			name:     "json_encoder",
+			encType:  utils.MetaJSON,
+			wantType: "*agents.haJSONEncoder",
+			wantErr:  false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -344,3 +352,158 @@ func TestLibHttpAgentTextPlainEncoderEncode(t *testing.T) {
 		t.Errorf("Expected output:\n%s\n\nBut got:\n%s", expectedOutput, actualOutput)
 	}
 }
+
+func TestHttpJSONDPFieldAsInterface(t *testing.T) {
+	body := `{"Subscriber":{"MSISDN":"4986517174963","Devices":[{"IMEI":"3520990017614823"},{"IMEI":"3520990017614824"}]},"Usage":60,"SessionID":9007199254740993,"Rate":0.5}`
+	req, err := http.NewRequest(http.MethodPost, "http://cgrates.org", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dP, err := newHADataProvider(utils.MetaJSON, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := dP.FieldAsString([]string{"Subscriber", "MSISDN"}); err != nil {
+		t.Error(err)
+	} else if data != "4986517174963" {
+		t.Errorf("expecting: 4986517174963, received: <%s>", data)
+	}
+	if data, err := dP.FieldAsString([]string{"Subscriber", "Devices[1]", "IMEI"}); err != nil {
+		t.Error(err)
+	} else if data != "3520990017614824" {
+		t.Errorf("expecting: 3520990017614824, received: <%s>", data)
+	}
+	if data, err := dP.FieldAsString([]string{"Usage"}); err != nil {
+		t.Error(err)
+	} else if data != "60" {
+		t.Errorf("expecting: 60, received: <%s>", data)
+	}
+	if data, err := dP.FieldAsInterface([]string{"SessionID"}); err != nil {
+		t.Error(err)
+	} else if data != int64(9007199254740993) {
+		t.Errorf("expecting: 9007199254740993, received: <%v>", data)
+	}
+	if data, err := dP.FieldAsInterface([]string{"Rate"}); err != nil {
+		t.Error(err)
+	} else if data != 0.5 {
+		t.Errorf("expecting: 0.5, received: <%v>", data)
+	}
+	if _, err := dP.FieldAsString([]string{"Subscriber", "IMSI"}); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+
+	req, err = http.NewRequest(http.MethodPost, "http://cgrates.org", strings.NewReader("{invalid"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newHADataProvider(utils.MetaJSON, req); err == nil {
+		t.Error("expected error for invalid JSON body")
+	}
+}
+
+func TestLibHttpAgentJSONEncoderEncode(t *testing.T) {
+	nm := utils.NewOrderedNavigableMap()
+	for _, fld := range []struct {
+		path []string
+		val  any
+	}{
+		{[]string{"Result", "Code"}, 2001},
+		{[]string{"Result", "Message"}, "OK"},
+		{[]string{"MaxUsage"}, "3600"},
+		{[]string{utils.MetaHTTPStatusCode}, "201"},
+		{[]string{utils.MetaHTTPContentType}, "application/vnd.cgrates+json"},
+	} {
+		if err := nm.Append(&utils.FullPath{PathSlice: fld.path, Path: strings.Join(fld.path, utils.NestingSep)},
+			&utils.DataLeaf{Data: fld.val}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := nm.Append(&utils.FullPath{PathSlice: []string{"Routes"}, Path: "Routes"},
+		&utils.DataLeaf{Data: "route1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nm.Append(&utils.FullPath{PathSlice: []string{"Routes"}, Path: "Routes"},
+		&utils.DataLeaf{Data: "route2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nm.SetAsSlice(&utils.FullPath{PathSlice: []string{"Bundles", "0"}, Path: "Bundles[0]"},
+		[]*utils.DataNode{utils.NewLeafNode("bundle1")}); err != nil {
+		t.Fatal(err)
+	}
+	recorder := httptest.NewRecorder()
+	if err := haWriteReplyHeader(recorder, nm, utils.MetaJSON); err != nil {
+		t.Fatal(err)
+	}
+	encoder, err := newHAReplyEncoder(utils.MetaJSON, recorder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := encoder.Encode(nm); err != nil {
+		t.Fatal(err)
+	}
+	if recorder.Code != http.StatusCreated {
+		t.Errorf("expected status code %d, received %d", http.StatusCreated, recorder.Code)
+	}
+	if cType := recorder.Header().Get(utils.ContentType); cType != "application/vnd.cgrates+json" {
+		t.Errorf("expected content type %q, received %q", "application/vnd.cgrates+json", cType)
+	}
+	exp := `{"Bundles":["bundle1"],"MaxUsage":"3600","Result":{"Code":2001,"Message":"OK"},"Routes":["route1","route2"]}`
+	if rcv := recorder.Body.String(); rcv != exp {
+		t.Errorf("expected %s, received %s", exp, rcv)
+	}
+}
+
+func TestLibHttpAgentWriteReplyHeader(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	if err := haWriteReplyHeader(recorder, utils.NewOrderedNavigableMap(), utils.MetaJSON); err != nil {
+		t.Fatal(err)
+	}
+	if cType := recorder.Header().Get(utils.ContentType); cType != utils.JsonBody {
+		t.Errorf("expected content type %q, received %q", utils.JsonBody, cType)
+	}
+
+	nm := utils.NewOrderedNavigableMap()
+	if err := nm.Append(&utils.FullPath{PathSlice: []string{utils.MetaHTTPStatusCode}, Path: utils.MetaHTTPStatusCode},
+		&utils.DataLeaf{Data: "notANumber"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := haWriteReplyHeader(httptest.NewRecorder(), nm, utils.MetaXml); err == nil {
+		t.Error("expected error for invalid status code")
+	}
+}
+
+func TestLibHttpAgentJSONEncoderIndexedFields(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data, err := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	agReq := NewAgentRequest(nil, nil, nil, nil, nil, nil, "cgrates.org", "", engine.NewFilterS(cfg, nil, dm), nil)
+	tplFlds := []*config.FCTemplate{
+		{Tag: "Result", Path: utils.MetaRep + utils.NestingSep + "Result", Type: utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("OK", utils.InfieldSep)},
+		{Tag: "Items", Path: utils.MetaRep + utils.NestingSep + "Items[0]", Type: utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("item1", utils.InfieldSep)},
+		{Tag: "Codes", Path: utils.MetaRep + utils.NestingSep + "Codes[0]", Type: utils.MetaComposed,
+			Value: config.NewRSRParsersMustCompile("code1", utils.InfieldSep)},
+	}
+	for _, v := range tplFlds {
+		v.ComputePath()
+	}
+	if err := agReq.SetFields(tplFlds); err != nil {
+		t.Fatal(err)
+	}
+	recorder := httptest.NewRecorder()
+	encoder, err := newHAReplyEncoder(utils.MetaJSON, recorder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := encoder.Encode(agReq.Reply); err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"Codes":["code1"],"Items":["item1"],"Result":"OK"}`
+	if rcv := recorder.Body.String(); rcv != exp {
+		t.Errorf("expected %s, received %s", exp, rcv)
+	}
+}
@@ -0,0 +1,495 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SMPP 3.4 command IDs
+const (
+	smppGenericNack         uint32 = 0x80000000
+	smppBindReceiver        uint32 = 0x00000001
+	smppBindReceiverResp    uint32 = 0x80000001
+	smppBindTransmitter     uint32 = 0x00000002
+	smppBindTransmitterResp uint32 = 0x80000002
+	smppSubmitSM            uint32 = 0x00000004
+	smppSubmitSMResp        uint32 = 0x80000004
+	smppDeliverSM           uint32 = 0x00000005
+	smppDeliverSMResp       uint32 = 0x80000005
+	smppUnbind              uint32 = 0x00000006
+	smppUnbindResp          uint32 = 0x80000006
+	smppBindTransceiver     uint32 = 0x00000009
+	smppBindTransceiverResp uint32 = 0x80000009
+	smppEnquireLink         uint32 = 0x00000015
+	smppEnquireLinkResp     uint32 = 0x80000015
+)
+
+// SMPP 3.4 command_status values used by the agent
+const (
+	smppStatusOK          uint32 = 0x00000000 // ESME_ROK
+	smppStatusInvCmdLen   uint32 = 0x00000002 // ESME_RINVCMDLEN
+	smppStatusInvCmdID    uint32 = 0x00000003 // ESME_RINVCMDID
+	smppStatusInvBndSts   uint32 = 0x00000004 // ESME_RINVBNDSTS
+	smppStatusAlyBnd      uint32 = 0x00000005 // ESME_RALYBND
+	smppStatusSysErr      uint32 = 0x00000008 // ESME_RSYSERR
+	smppStatusInvPaswd    uint32 = 0x0000000E // ESME_RINVPASWD
+	smppStatusInvSysID    uint32 = 0x0000000F // ESME_RINVSYSID
+	smppStatusSubmitFail  uint32 = 0x00000045 // ESME_RSUBMITFAIL
+	smppStatusThrottled   uint32 = 0x00000058 // ESME_RTHROTTLED
+	smppStatusRxRejectApp uint32 = 0x00000065 // ESME_RX_R_APPN
+)
+
+const (
+	smppHeaderLen    = 16
+	smppMaxPDULen    = 64 * 1024 // protects against broken or malicious peers
+	smppUDHIndicator = 0x40      // esm_class bit signaling the UDH presence
+
+	smppInterfaceVersion = 0x34
+	smppTagSCIfaceVer    = 0x0210
+)
+
+// smppCommandNames is used to populate *smppCommand variable
+var smppCommandNames = map[uint32]string{
+	smppSubmitSM:  "submit_sm",
+	smppDeliverSM: "deliver_sm",
+}
+
+// smppTLVs lists the optional parameters decoded by name, with the value
+// being true for the ones with integer values.
+var smppTLVs = map[uint16]struct {
+	name  string
+	isInt bool
+}{
+	0x0005: {"dest_addr_subunit", true},
+	0x0006: {"dest_network_type", true},
+	0x0007: {"dest_bearer_type", true},
+	0x0008: {"dest_telematics_id", true},
+	0x000D: {"source_addr_subunit", true},
+	0x000E: {"source_network_type", true},
+	0x000F: {"source_bearer_type", true},
+	0x0010: {"source_telematics_id", true},
+	0x0017: {"qos_time_to_live", true},
+	0x0019: {"payload_type", true},
+	0x001D: {"additional_status_info_text", false},
+	0x001E: {"receipted_message_id", false},
+	0x0030: {"ms_msg_wait_facilities", true},
+	0x0201: {"privacy_indicator", true},
+	0x0204: {"user_message_reference", true},
+	0x0205: {"user_response_code", true},
+	0x020A: {"source_port", true},
+	0x020B: {"destination_port", true},
+	0x020C: {"sar_msg_ref_num", true},
+	0x020D: {"language_indicator", true},
+	0x020E: {"sar_total_segments", true},
+	0x020F: {"sar_segment_seqnum", true},
+	0x0381: {"callback_num", false},
+	0x0423: {"network_error_code", false},
+	0x0424: {"message_payload", false},
+	0x0427: {"message_state", true},
+	0x1501: {"ussd_service_op", true},
+}
+
+const (
+	smppTagSARMsgRefNum     = 0x020C
+	smppTagSARTotalSegments = 0x020E
+	smppTagSARSegmentSeqNum = 0x020F
+	smppTagMessagePayload   = 0x0424
+)
+
+// smppPDU is one SMPP protocol data unit with the body left undecoded.
+type smppPDU struct {
+	CommandID      uint32
+	CommandStatus  uint32
+	SequenceNumber uint32
+	Body           []byte
+}
+
+// readSMPPPDU reads one PDU out of r.
+func readSMPPPDU(r io.Reader) (*smppPDU, error) {
+	var hdr [smppHeaderLen]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, err
+	}
+	cmdLen := binary.BigEndian.Uint32(hdr[0:4])
+	if cmdLen < smppHeaderLen || cmdLen > smppMaxPDULen {
+		return nil, fmt.Errorf("invalid SMPP command_length: %d", cmdLen)
+	}
+	pdu := &smppPDU{
+		CommandID:      binary.BigEndian.Uint32(hdr[4:8]),
+		CommandStatus:  binary.BigEndian.Uint32(hdr[8:12]),
+		SequenceNumber: binary.BigEndian.Uint32(hdr[12:16]),
+		Body:           make([]byte, cmdLen-smppHeaderLen),
+	}
+	if _, err := io.ReadFull(r, pdu.Body); err != nil {
+		return nil, err
+	}
+	return pdu, nil
+}
+
+// encode returns the PDU in wire format.
+func (pdu *smppPDU) encode() []byte {
+	b := make([]byte, smppHeaderLen, smppHeaderLen+len(pdu.Body))
+	binary.BigEndian.PutUint32(b[0:4], uint32(smppHeaderLen+len(pdu.Body)))
+	binary.BigEndian.PutUint32(b[4:8], pdu.CommandID)
+	binary.BigEndian.PutUint32(b[8:12], pdu.CommandStatus)
+	binary.BigEndian.PutUint32(b[12:16], pdu.SequenceNumber)
+	return append(b, pdu.Body...)
+}
+
+// response builds the response PDU for pdu, with the given status and body.
+func (pdu *smppPDU) response(status uint32, body []byte) *smppPDU {
+	return &smppPDU{
+		CommandID:      pdu.CommandID | smppGenericNack,
+		CommandStatus:  status,
+		SequenceNumber: pdu.SequenceNumber,
+		Body:           body,
+	}
+}
+
+// smppBodyReader decodes the PDU body fields sequentially, remembering the
+// first error so the callers can check it only once at the end.
+type smppBodyReader struct {
+	b   []byte
+	err error
+}
+
+var errSMPPShortBody = errors.New("SMPP PDU body too short")
+
+func (r *smppBodyReader) uint8() uint8 {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.b) < 1 {
+		r.err = errSMPPShortBody
+		return 0
+	}
+	v := r.b[0]
+	r.b = r.b[1:]
+	return v
+}
+
+func (r *smppBodyReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.b) < n {
+		r.err = errSMPPShortBody
+		return nil
+	}
+	v := r.b[:n:n]
+	r.b = r.b[n:]
+	return v
+}
+
+// cString reads a NULL terminated string of at most maxLen octets, NULL
+// included.
+func (r *smppBodyReader) cString(maxLen int) string {
+	if r.err != nil {
+		return utils.EmptyString
+	}
+	idx := strings.IndexByte(string(r.b[:min(len(r.b), maxLen)]), 0)
+	if idx == -1 {
+		r.err = fmt.Errorf("SMPP C-Octet String longer than %d octets", maxLen)
+		return utils.EmptyString
+	}
+	v := string(r.b[:idx])
+	r.b = r.b[idx+1:]
+	return v
+}
+
+// tlvs decodes the optional parameters found at the end of the body.
+func (r *smppBodyReader) tlvs() map[uint16][]byte {
+	if r.err != nil || len(r.b) == 0 {
+		return nil
+	}
+	tlvs := make(map[uint16][]byte)
+	for len(r.b) != 0 {
+		if len(r.b) < 4 {
+			r.err = errSMPPShortBody
+			return nil
+		}
+		tag := binary.BigEndian.Uint16(r.b[0:2])
+		length := int(binary.BigEndian.Uint16(r.b[2:4]))
+		r.b = r.b[4:]
+		tlvs[tag] = r.bytes(length)
+	}
+	return tlvs
+}
+
+// smppBind is the decoded body of bind_transmitter, bind_receiver and
+// bind_transceiver PDUs.
+type smppBind struct {
+	SystemID         string
+	Password         string
+	SystemType       string
+	InterfaceVersion uint8
+	AddrTON          uint8
+	AddrNPI          uint8
+	AddressRange     string
+}
+
+func decodeSMPPBind(body []byte) (*smppBind, error) {
+	r := &smppBodyReader{b: body}
+	bind := &smppBind{
+		SystemID:         r.cString(16),
+		Password:         r.cString(9),
+		SystemType:       r.cString(13),
+		InterfaceVersion: r.uint8(),
+		AddrTON:          r.uint8(),
+		AddrNPI:          r.uint8(),
+		AddressRange:     r.cString(41),
+	}
+	return bind, r.err
+}
+
+// smppBindRespBody returns the bind_*_resp body carrying the SMSC system_id.
+func smppBindRespBody(systemID string) []byte {
+	b := append([]byte(systemID), 0)
+	return append(b, 0x02, 0x10, 0x00, 0x01, smppInterfaceVersion) // sc_interface_version TLV
+}
+
+// smppShortMessage is the decoded body of submit_sm and deliver_sm PDUs,
+// which share the same layout.
+type smppShortMessage struct {
+	ServiceType          string
+	SourceAddrTON        uint8
+	SourceAddrNPI        uint8
+	SourceAddr           string
+	DestAddrTON          uint8
+	DestAddrNPI          uint8
+	DestinationAddr      string
+	ESMClass             uint8
+	ProtocolID           uint8
+	PriorityFlag         uint8
+	ScheduleDeliveryTime string
+	ValidityPeriod       string
+	RegisteredDelivery   uint8
+	ReplaceIfPresentFlag uint8
+	DataCoding           uint8
+	SMDefaultMsgID       uint8
+	ShortMessage         []byte
+	TLVs                 map[uint16][]byte
+}
+
+func decodeSMPPShortMessage(body []byte) (*smppShortMessage, error) {
+	r := &smppBodyReader{b: body}
+	sm := &smppShortMessage{
+		ServiceType:          r.cString(6),
+		SourceAddrTON:        r.uint8(),
+		SourceAddrNPI:        r.uint8(),
+		SourceAddr:           r.cString(21),
+		DestAddrTON:          r.uint8(),
+		DestAddrNPI:          r.uint8(),
+		DestinationAddr:      r.cString(21),
+		ESMClass:             r.uint8(),
+		ProtocolID:           r.uint8(),
+		PriorityFlag:         r.uint8(),
+		ScheduleDeliveryTime: r.cString(17),
+		ValidityPeriod:       r.cString(17),
+		RegisteredDelivery:   r.uint8(),
+		ReplaceIfPresentFlag: r.uint8(),
+		DataCoding:           r.uint8(),
+		SMDefaultMsgID:       r.uint8(),
+	}
+	sm.ShortMessage = r.bytes(int(r.uint8()))
+	sm.TLVs = r.tlvs()
+	return sm, r.err
+}
+
+// payload returns the user data out of short_message or, when that one is
+// empty, out of the message_payload TLV.
+func (sm *smppShortMessage) payload() []byte {
+	if len(sm.ShortMessage) == 0 {
+		return sm.TLVs[smppTagMessagePayload]
+	}
+	return sm.ShortMessage
+}
+
+// smppConcatInfo identifies one part of a concatenated message.
+type smppConcatInfo struct {
+	Ref    uint16
+	Total  uint8
+	SeqNum uint8
+}
+
+// concatInfo returns the concatenation details out of the UDH or out of the
+// SAR TLVs, together with the user data stripped of its UDH.
+func (sm *smppShortMessage) concatInfo() (ci *smppConcatInfo, userData []byte) {
+	userData = sm.payload()
+	if sm.ESMClass&smppUDHIndicator != 0 && len(userData) != 0 {
+		udhLen := int(userData[0]) + 1
+		if udhLen > len(userData) {
+			return nil, userData
+		}
+		ci = smppUDHConcatInfo(userData[1:udhLen])
+		userData = userData[udhLen:]
+	}
+	if ci != nil {
+		return
+	}
+	ref, hasRef := sm.TLVs[smppTagSARMsgRefNum]
+	total, hasTotal := sm.TLVs[smppTagSARTotalSegments]
+	seqNum, hasSeqNum := sm.TLVs[smppTagSARSegmentSeqNum]
+	if hasRef && hasTotal && hasSeqNum &&
+		len(ref) == 2 && len(total) == 1 && len(seqNum) == 1 {
+		ci = &smppConcatInfo{
+			Ref:    binary.BigEndian.Uint16(ref),
+			Total:  total[0],
+			SeqNum: seqNum[0],
+		}
+	}
+	return
+}
+
+// smppUDHConcatInfo searches the concatenation information elements within
+// the UDH, both the 8-bit (0x00) and 16-bit (0x08) reference variants.
+func smppUDHConcatInfo(udh []byte) *smppConcatInfo {
+	for len(udh) >= 2 {
+		iei, ieLen := udh[0], int(udh[1])
+		if len(udh) < 2+ieLen {
+			return nil
+		}
+		ie := udh[2 : 2+ieLen]
+		switch {
+		case iei == 0x00 && ieLen == 3:
+			return &smppConcatInfo{Ref: uint16(ie[0]), Total: ie[1], SeqNum: ie[2]}
+		case iei == 0x08 && ieLen == 4:
+			return &smppConcatInfo{Ref: binary.BigEndian.Uint16(ie[0:2]), Total: ie[2], SeqNum: ie[3]}
+		}
+		udh = udh[2+ieLen:]
+	}
+	return nil
+}
+
+// smppGSM7Basic is the GSM 03.38 default alphabet, indexed by septet value.
+var smppGSM7Basic = []rune("@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞ\x1bÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà")
+
+// smppGSM7Ext is the GSM 03.38 extension table, reached via the escape septet.
+var smppGSM7Ext = map[byte]rune{
+	0x0A: '\f', 0x14: '^', 0x28: '{', 0x29: '}', 0x2F: '\\',
+	0x3C: '[', 0x3D: '~', 0x3E: ']', 0x40: '|', 0x65: '€',
+}
+
+// smppDecodeText decodes the user data based on data_coding. Codings which
+// are not text based are returned hex encoded.
+func smppDecodeText(data []byte, dataCoding uint8) string {
+	switch dataCoding {
+	case 0x00: // SMSC default alphabet, GSM 03.38 unpacked
+		var sb strings.Builder
+		for i := 0; i < len(data); i++ {
+			septet := data[i] & 0x7F
+			if septet == 0x1B && i+1 < len(data) {
+				if r, has := smppGSM7Ext[data[i+1]&0x7F]; has {
+					sb.WriteRune(r)
+					i++
+					continue
+				}
+			}
+			sb.WriteRune(smppGSM7Basic[septet])
+		}
+		return sb.String()
+	case 0x01: // IA5 (CCITT T.50)/ASCII
+		return string(data)
+	case 0x03: // Latin 1 (ISO-8859-1)
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	case 0x08: // UCS2 (ISO/IEC-10646)
+		u16 := make([]uint16, len(data)/2)
+		for i := range u16 {
+			u16[i] = binary.BigEndian.Uint16(data[2*i:])
+		}
+		return string(utf16.Decode(u16))
+	case 0x02, 0x04: // 8-bit binary
+		return hex.EncodeToString(data)
+	default:
+		return string(data)
+	}
+}
+
+// smppMessageParts returns the number of SMS needed to carry the user data
+// of one non-concatenated message, based on its data_coding.
+func smppMessageParts(userData []byte, dataCoding uint8) int {
+	units, single, multi := len(userData), 160, 153 // 7-bit alphabets
+	switch dataCoding {
+	case 0x02, 0x04: // 8-bit binary
+		single, multi = 140, 134
+	case 0x08: // UCS2
+		units, single, multi = len(userData)/2, 70, 67
+	}
+	if units <= single {
+		return 1
+	}
+	return (units + multi - 1) / multi
+}
+
+// newSMPPDataProvider returns the DataProvider of a submit_sm/deliver_sm,
+// with the fields named after the SMPP specification.
+func newSMPPDataProvider(sm *smppShortMessage) utils.DataProvider {
+	_, userData := sm.concatInfo()
+	dP := utils.MapStorage{
+		"service_type":            sm.ServiceType,
+		"source_addr_ton":         int(sm.SourceAddrTON),
+		"source_addr_npi":         int(sm.SourceAddrNPI),
+		"source_addr":             sm.SourceAddr,
+		"dest_addr_ton":           int(sm.DestAddrTON),
+		"dest_addr_npi":           int(sm.DestAddrNPI),
+		"destination_addr":        sm.DestinationAddr,
+		"esm_class":               int(sm.ESMClass),
+		"protocol_id":             int(sm.ProtocolID),
+		"priority_flag":           int(sm.PriorityFlag),
+		"schedule_delivery_time":  sm.ScheduleDeliveryTime,
+		"validity_period":         sm.ValidityPeriod,
+		"registered_delivery":     int(sm.RegisteredDelivery),
+		"replace_if_present_flag": int(sm.ReplaceIfPresentFlag),
+		"data_coding":             int(sm.DataCoding),
+		"sm_default_msg_id":       int(sm.SMDefaultMsgID),
+		"short_message":           smppDecodeText(userData, sm.DataCoding),
+	}
+	for tag, val := range sm.TLVs {
+		tlv, known := smppTLVs[tag]
+		switch {
+		case !known:
+			dP[fmt.Sprintf("0x%04x", tag)] = hex.EncodeToString(val)
+		case tag == smppTagMessagePayload:
+			continue // already decoded as short_message
+		case tlv.isInt:
+			var v int
+			for _, b := range val {
+				v = v<<8 | int(b)
+			}
+			dP[tlv.name] = v
+		default:
+			dP[tlv.name] = strings.TrimRight(string(val), "\x00")
+		}
+	}
+	return dP
+}
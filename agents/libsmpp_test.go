@@ -0,0 +1,219 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// smppTestShortMessage encodes a submit_sm/deliver_sm body
+func smppTestShortMessage(src, dst string, esmClass, dataCoding uint8,
+	shortMsg []byte, tlvs ...[]byte) []byte {
+	b := []byte{0}          // service_type
+	b = append(b, 1, 1)     // source_addr_ton, source_addr_npi
+	b = append(b, src...)   // source_addr
+	b = append(b, 0, 1, 1)  // NULL, dest_addr_ton, dest_addr_npi
+	b = append(b, dst...)   // destination_addr
+	b = append(b, 0)        // NULL
+	b = append(b, esmClass) // esm_class
+	b = append(b, 0, 0)     // protocol_id, priority_flag
+	b = append(b, 0, 0)     // schedule_delivery_time, validity_period
+	b = append(b, 1, 0)     // registered_delivery, replace_if_present_flag
+	b = append(b, dataCoding, 0, byte(len(shortMsg)))
+	b = append(b, shortMsg...)
+	for _, tlv := range tlvs {
+		b = append(b, tlv...)
+	}
+	return b
+}
+
+func TestSMPPPDUEncodeRead(t *testing.T) {
+	pdu := &smppPDU{
+		CommandID:      smppSubmitSM,
+		SequenceNumber: 7,
+		Body:           smppTestShortMessage("1001", "1002", 0, 0, []byte("hello")),
+	}
+	rcv, err := readSMPPPDU(bytes.NewReader(pdu.encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pdu, rcv) {
+		t.Errorf("expected %+v, received %+v", pdu, rcv)
+	}
+	rsp := rcv.response(smppStatusOK, []byte("ID\x00"))
+	if rsp.CommandID != smppSubmitSMResp || rsp.SequenceNumber != 7 {
+		t.Errorf("unexpected response: %+v", rsp)
+	}
+	if _, err := readSMPPPDU(bytes.NewReader([]byte{0, 0, 0, 8, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 1})); err == nil {
+		t.Error("expected error for invalid command_length")
+	}
+}
+
+func TestDecodeSMPPBind(t *testing.T) {
+	body := []byte("esme1\x00secret\x00VMS\x00\x34\x01\x01\x00")
+	exp := &smppBind{
+		SystemID:         "esme1",
+		Password:         "secret",
+		SystemType:       "VMS",
+		InterfaceVersion: 0x34,
+		AddrTON:          1,
+		AddrNPI:          1,
+	}
+	if rcv, err := decodeSMPPBind(body); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %+v, received %+v", exp, rcv)
+	}
+	if _, err := decodeSMPPBind([]byte("system_id_too_long_for_bind\x00")); err == nil {
+		t.Error("expected error for system_id too long")
+	}
+	if _, err := decodeSMPPBind([]byte("esme1\x00secret\x00")); err == nil {
+		t.Error("expected error for short body")
+	}
+}
+
+func TestSMPPConcatInfo(t *testing.T) {
+	// 8-bit reference UDH
+	sm, err := decodeSMPPShortMessage(smppTestShortMessage("1001", "1002", smppUDHIndicator, 0,
+		[]byte{0x05, 0x00, 0x03, 0x2A, 0x03, 0x02, 'h', 'i'}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ci, userData := sm.concatInfo()
+	if exp := (&smppConcatInfo{Ref: 0x2A, Total: 3, SeqNum: 2}); !reflect.DeepEqual(exp, ci) {
+		t.Errorf("expected %+v, received %+v", exp, ci)
+	}
+	if string(userData) != "hi" {
+		t.Errorf("expected hi, received %q", userData)
+	}
+
+	// 16-bit reference UDH, after a port addressing IE
+	sm, err = decodeSMPPShortMessage(smppTestShortMessage("1001", "1002", smppUDHIndicator, 0,
+		[]byte{0x0A, 0x04, 0x02, 0x0B, 0x84, 0x08, 0x04, 0x01, 0x02, 0x02, 0x01, 'h', 'i'}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ci, _ = sm.concatInfo(); !reflect.DeepEqual(&smppConcatInfo{Ref: 0x0102, Total: 2, SeqNum: 1}, ci) {
+		t.Errorf("unexpected concat info: %+v", ci)
+	}
+
+	// SAR TLVs
+	sm, err = decodeSMPPShortMessage(smppTestShortMessage("1001", "1002", 0, 0, []byte("hi"),
+		[]byte{0x02, 0x0C, 0x00, 0x02, 0x00, 0x07},
+		[]byte{0x02, 0x0E, 0x00, 0x01, 0x02},
+		[]byte{0x02, 0x0F, 0x00, 0x01, 0x01}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ci, _ = sm.concatInfo(); !reflect.DeepEqual(&smppConcatInfo{Ref: 7, Total: 2, SeqNum: 1}, ci) {
+		t.Errorf("unexpected concat info: %+v", ci)
+	}
+
+	// not concatenated
+	sm, err = decodeSMPPShortMessage(smppTestShortMessage("1001", "1002", 0, 0, []byte("hi")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ci, _ = sm.concatInfo(); ci != nil {
+		t.Errorf("expected nil, received %+v", ci)
+	}
+}
+
+func TestSMPPDecodeText(t *testing.T) {
+	if len(smppGSM7Basic) != 128 {
+		t.Fatalf("expected 128 characters in GSM 03.38 table, received %d", len(smppGSM7Basic))
+	}
+	tests := []struct {
+		name       string
+		data       []byte
+		dataCoding uint8
+		exp        string
+	}{
+		{"GSM7", []byte{0x00, 0x48, 0x69, 0x20, 0x1B, 0x65, 0x02, 0x11}, 0x00, "@Hi €$_"},
+		{"IA5", []byte("Hello"), 0x01, "Hello"},
+		{"Latin1", []byte{0x63, 0x61, 0x66, 0xE9}, 0x03, "café"},
+		{"UCS2", []byte{0x04, 0x1F, 0x04, 0x40, 0x04, 0x38}, 0x08, "При"},
+		{"binary", []byte{0xCA, 0xFE}, 0x04, "cafe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rcv := smppDecodeText(tt.data, tt.dataCoding); rcv != tt.exp {
+				t.Errorf("expected %q, received %q", tt.exp, rcv)
+			}
+		})
+	}
+}
+
+func TestSMPPMessageParts(t *testing.T) {
+	tests := []struct {
+		length     int
+		dataCoding uint8
+		exp        int
+	}{
+		{0, 0x00, 1},
+		{160, 0x00, 1},
+		{161, 0x00, 2},
+		{306, 0x00, 2},
+		{307, 0x00, 3},
+		{140, 0x08, 1}, // 70 UCS2 characters
+		{142, 0x08, 2},
+		{140, 0x04, 1},
+		{141, 0x04, 2},
+	}
+	for _, tt := range tests {
+		if rcv := smppMessageParts(make([]byte, tt.length), tt.dataCoding); rcv != tt.exp {
+			t.Errorf("expected %d parts for %d octets with data_coding %d, received %d",
+				tt.exp, tt.length, tt.dataCoding, rcv)
+		}
+	}
+}
+
+func TestNewSMPPDataProvider(t *testing.T) {
+	sm, err := decodeSMPPShortMessage(smppTestShortMessage("1001", "1002", 0, 0x01, nil,
+		[]byte{0x04, 0x24, 0x00, 0x05, 'h', 'e', 'l', 'l', 'o'}, // message_payload
+		[]byte{0x02, 0x04, 0x00, 0x02, 0x01, 0x00},              // user_message_reference
+		[]byte{0x14, 0x01, 0x00, 0x01, 0xFF},                    // vendor specific
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dP := newSMPPDataProvider(sm)
+	for fld, exp := range map[string]any{
+		"source_addr":            "1001",
+		"destination_addr":       "1002",
+		"registered_delivery":    1,
+		"data_coding":            1,
+		"short_message":          "hello",
+		"user_message_reference": 256,
+		"0x1401":                 "ff",
+	} {
+		if rcv, err := dP.FieldAsInterface([]string{fld}); err != nil {
+			t.Errorf("field %s: %v", fld, err)
+		} else if rcv != exp {
+			t.Errorf("field %s: expected %v, received %v", fld, exp, rcv)
+		}
+	}
+	if _, err := dP.FieldAsInterface([]string{"message_payload"}); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
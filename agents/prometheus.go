@@ -67,6 +67,8 @@ const (
 	promGoSchedGomaxprocsThreads     = "go_sched_gomaxprocs_threads"
 	promGoInfo                       = "go_info"
 	promGoGCDurationSeconds          = "go_gc_duration_seconds"
+	promGuardianLockWaitSeconds      = "cgrates_guardian_lock_wait_seconds"
+	promGuardianLockHoldSeconds      = "cgrates_guardian_lock_hold_seconds"
 
 	// Counter metrics
 	promProcessCPUSecondsTotal          = "process_cpu_seconds_total"
@@ -133,6 +135,11 @@ var (
 		promGoMemstatsMallocsTotal:    {},
 		promGoMemstatsFreesTotal:      {},
 	}
+
+	lockStatsMetricsMapping = map[string]string{
+		promGuardianLockWaitSeconds: utils.MetricLockWait,
+		promGuardianLockHoldSeconds: utils.MetricLockHold,
+	}
 )
 
 // PrometheusAgent handles metrics collection for Prometheus.
@@ -367,6 +374,18 @@ func newCoreMetricsCollector(cfg *config.CGRConfig, cm *engine.ConnManager) *cor
 		[]string{"node_id"},
 		nil,
 	)
+	c.descs[promGuardianLockWaitSeconds] = prometheus.NewDesc(
+		promGuardianLockWaitSeconds,
+		"A histogram of the time spent waiting to acquire the guardian locks.",
+		[]string{"node_id"},
+		nil,
+	)
+	c.descs[promGuardianLockHoldSeconds] = prometheus.NewDesc(
+		promGuardianLockHoldSeconds,
+		"A histogram of the time the guardian locks were held.",
+		[]string{"node_id"},
+		nil,
+	)
 	return c
 }
 
@@ -466,5 +485,45 @@ func (c *coreMetricsCollector) Collect(ch chan<- prometheus.Metric) {
 				nodeID,
 			)
 		}
+		if lockStats, ok := reply[utils.FieldLockStats].(map[string]any); ok {
+			for metricName, key := range lockStatsMetricsMapping {
+				if histogram, ok := lockStats[key].(map[string]any); ok {
+					ch <- newLockHistogram(c.descs[metricName], histogram, nodeID)
+				}
+			}
+		}
+	}
+}
+
+// newLockHistogram builds the Prometheus histogram out of the lock durations
+// reported by CoreSv1.Status.
+func newLockHistogram(desc *prometheus.Desc, histogram map[string]any, nodeID string) prometheus.Metric {
+	var count uint64
+	var sum float64
+	if c, ok := histogram[utils.MetricGCCount].(float64); ok {
+		count = uint64(c)
+	}
+	if s, ok := histogram[utils.MetricGCSum].(float64); ok {
+		sum = s
+	}
+	buckets := make(map[float64]uint64)
+
+	// Same as for the GC quantiles, the type depends on the connection
+	switch bkts := histogram[utils.MetricLockBuckets].(type) {
+	case []any:
+		for _, b := range bkts {
+			if bMap, ok := b.(map[string]any); ok {
+				if upperBound, ok := bMap[utils.MetricLockUpperBound].(float64); ok {
+					if cnt, ok := bMap[utils.MetricGCCount].(float64); ok {
+						buckets[upperBound] = uint64(cnt)
+					}
+				}
+			}
+		}
+	case []cores.Bucket:
+		for _, b := range bkts {
+			buckets[b.UpperBound] = uint64(b.Count)
+		}
 	}
+	return prometheus.MustNewConstHistogram(desc, count, sum, buckets, nodeID)
 }
@@ -0,0 +1,80 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// lockHistogramCollector exposes a single lock histogram
+type lockHistogramCollector struct {
+	desc      *prometheus.Desc
+	histogram map[string]any
+}
+
+func (c *lockHistogramCollector) Describe(ch chan<- *prometheus.Desc) { ch <- c.desc }
+
+func (c *lockHistogramCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- newLockHistogram(c.desc, c.histogram, "node1")
+}
+
+func TestPrometheusNewLockHistogram(t *testing.T) {
+	desc := prometheus.NewDesc(promGuardianLockWaitSeconds, "Lock wait.", []string{"node_id"}, nil)
+	exp := `# HELP cgrates_guardian_lock_wait_seconds Lock wait.
+# TYPE cgrates_guardian_lock_wait_seconds histogram
+cgrates_guardian_lock_wait_seconds_bucket{node_id="node1",le="0.001"} 2
+cgrates_guardian_lock_wait_seconds_bucket{node_id="node1",le="0.1"} 3
+cgrates_guardian_lock_wait_seconds_bucket{node_id="node1",le="+Inf"} 4
+cgrates_guardian_lock_wait_seconds_sum{node_id="node1"} 1.5
+cgrates_guardian_lock_wait_seconds_count{node_id="node1"} 4
+`
+	for name, histogram := range map[string]map[string]any{
+		"internal": { // direct calls keep the type of the buckets
+			utils.MetricLockBuckets: []cores.Bucket{{UpperBound: 0.001, Count: 2}, {UpperBound: 0.1, Count: 3}},
+			utils.MetricGCSum:       1.5,
+			utils.MetricGCCount:     4.,
+		},
+		"serialized": {
+			utils.MetricLockBuckets: []any{
+				map[string]any{utils.MetricLockUpperBound: 0.001, utils.MetricGCCount: 2.},
+				map[string]any{utils.MetricLockUpperBound: 0.1, utils.MetricGCCount: 3.},
+			},
+			utils.MetricGCSum:   1.5,
+			utils.MetricGCCount: 4.,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			reg := prometheus.NewRegistry()
+			reg.MustRegister(&lockHistogramCollector{desc: desc, histogram: histogram})
+			rec := httptest.NewRecorder()
+			promhttp.HandlerFor(reg, promhttp.HandlerOpts{}).ServeHTTP(rec,
+				httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			if rcv := rec.Body.String(); rcv != exp {
+				t.Errorf("expected:\n%s\nreceived:\n%s", exp, rcv)
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	MetaSMPPCommand       = "*smppCommand"
+	MetaSMPPSystemID      = "*smppSystemID"
+	MetaSMPPMessageParts  = "*smppMessageParts"
+	MetaSMPPConcatRef     = "*smppConcatRef"
+	MetaSMPPConcatTotal   = "*smppConcatTotal"
+	MetaSMPPConcatSeqNum  = "*smppConcatSeqNum"
+	MetaSMPPCommandStatus = "*smppCommandStatus"
+	SMPPMessageID         = "message_id"
+)
+
+// NewSMPPAgent is the constructor for SMPPAgent
+func NewSMPPAgent(cgrCfg *config.CGRConfig, fltrS *engine.FilterS,
+	connMgr *engine.ConnManager, caps *engine.Caps) *SMPPAgent {
+	return &SMPPAgent{
+		cgrCfg:  cgrCfg,
+		fltrS:   fltrS,
+		connMgr: connMgr,
+		caps:    caps,
+		conns:   make(map[*smppConn]struct{}),
+	}
+}
+
+// SMPPAgent is a SMPP 3.4 server translating the short messages submitted
+// by the bound ESMEs towards CGRateS infrastructure
+type SMPPAgent struct {
+	sync.RWMutex
+	cgrCfg  *config.CGRConfig // loaded CGRateS configuration
+	connMgr *engine.ConnManager
+	caps    *engine.Caps
+	fltrS   *engine.FilterS
+
+	connsMux sync.Mutex
+	conns    map[*smppConn]struct{} // active ESME connections
+	wg       sync.WaitGroup
+}
+
+// smppConn is one ESME connection
+type smppConn struct {
+	net.Conn
+	wMux     sync.Mutex // serializes the PDU writes
+	bindCmd  uint32     // command used to bind, 0 if not bound
+	systemID string     // system_id of the bound ESME
+}
+
+// writePDU sends one PDU towards the ESME
+func (c *smppConn) writePDU(pdu *smppPDU) (err error) {
+	c.wMux.Lock()
+	_, err = c.Write(pdu.encode())
+	c.wMux.Unlock()
+	return
+}
+
+// ListenAndServe accepts the ESME connections until stopChan is closed
+func (sa *SMPPAgent) ListenAndServe(stopChan chan struct{}) (err error) {
+	lstn, err := net.Listen(utils.TCP, sa.cgrCfg.SMPPAgentCfg().Listen)
+	if err != nil {
+		return
+	}
+	utils.Logger.Info(fmt.Sprintf("<%s> start listening on <%s>",
+		utils.SMPPAgent, sa.cgrCfg.SMPPAgentCfg().Listen))
+	errChan := make(chan error, 1)
+	go func() {
+		for {
+			conn, err := lstn.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					errChan <- err
+				}
+				return
+			}
+			c := &smppConn{Conn: conn}
+			sa.connsMux.Lock()
+			sa.conns[c] = struct{}{}
+			sa.connsMux.Unlock()
+			sa.wg.Add(1)
+			go sa.serveConn(c)
+		}
+	}()
+	select {
+	case <-stopChan:
+	case err = <-errChan:
+	}
+	lstn.Close()
+	sa.connsMux.Lock()
+	for c := range sa.conns {
+		c.Close()
+	}
+	sa.connsMux.Unlock()
+	sa.wg.Wait()
+	return
+}
+
+// serveConn reads the PDUs out of one connection until it is closed
+func (sa *SMPPAgent) serveConn(c *smppConn) {
+	defer func() {
+		c.Close()
+		sa.connsMux.Lock()
+		delete(sa.conns, c)
+		sa.connsMux.Unlock()
+		sa.wg.Done()
+	}()
+	var handlers sync.WaitGroup // replies are written on c
+	defer handlers.Wait()
+	for {
+		pdu, err := readSMPPPDU(c)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				utils.Logger.Warning(fmt.Sprintf("<%s> error: %s reading PDU from %s",
+					utils.SMPPAgent, err.Error(), c.RemoteAddr()))
+				c.writePDU(&smppPDU{CommandID: smppGenericNack, CommandStatus: smppStatusInvCmdLen})
+			}
+			return
+		}
+		switch pdu.CommandID {
+		case smppBindTransmitter, smppBindReceiver, smppBindTransceiver:
+			err = c.writePDU(sa.handleBind(c, pdu))
+		case smppEnquireLink:
+			err = c.writePDU(pdu.response(smppStatusOK, nil))
+		case smppUnbind:
+			c.writePDU(pdu.response(smppStatusOK, nil))
+			return
+		case smppSubmitSM, smppDeliverSM:
+			if c.bindCmd == 0 ||
+				(pdu.CommandID == smppSubmitSM && c.bindCmd == smppBindReceiver) {
+				err = c.writePDU(pdu.response(smppStatusInvBndSts, nil))
+				break
+			}
+			handlers.Add(1)
+			go func() {
+				defer handlers.Done()
+				if err := c.writePDU(sa.handleShortMessage(c, pdu)); err != nil {
+					utils.Logger.Warning(fmt.Sprintf("<%s> error: %s replying to %s",
+						utils.SMPPAgent, err.Error(), c.RemoteAddr()))
+				}
+			}()
+		default:
+			if pdu.CommandID&smppGenericNack != 0 {
+				continue // responses to our enquire_link or generic_nack, nothing to do
+			}
+			err = c.writePDU(&smppPDU{CommandID: smppGenericNack,
+				CommandStatus: smppStatusInvCmdID, SequenceNumber: pdu.SequenceNumber})
+		}
+		if err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> error: %s replying to %s",
+				utils.SMPPAgent, err.Error(), c.RemoteAddr()))
+			return
+		}
+	}
+}
+
+// handleBind authenticates the ESME, returning the bind response
+func (sa *SMPPAgent) handleBind(c *smppConn, pdu *smppPDU) *smppPDU {
+	if c.bindCmd != 0 {
+		return pdu.response(smppStatusAlyBnd, nil)
+	}
+	bind, err := decodeSMPPBind(pdu.Body)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error: %s decoding bind from %s",
+			utils.SMPPAgent, err.Error(), c.RemoteAddr()))
+		return pdu.response(smppStatusInvCmdLen, nil)
+	}
+	if creds := sa.cgrCfg.SMPPAgentCfg().BindCredentials; len(creds) != 0 {
+		passwd, has := creds[bind.SystemID]
+		if !has {
+			return pdu.response(smppStatusInvSysID, nil)
+		}
+		if passwd != bind.Password {
+			return pdu.response(smppStatusInvPaswd, nil)
+		}
+	}
+	c.bindCmd = pdu.CommandID
+	c.systemID = bind.SystemID
+	return pdu.response(smppStatusOK, smppBindRespBody(sa.cgrCfg.SMPPAgentCfg().SystemID))
+}
+
+// handleShortMessage processes a submit_sm or deliver_sm, returning its
+// response
+func (sa *SMPPAgent) handleShortMessage(c *smppConn, pdu *smppPDU) *smppPDU {
+	if sa.caps.IsLimited() {
+		if err := sa.caps.Allocate(); err != nil {
+			return pdu.response(smppStatusThrottled, nil)
+		}
+		defer sa.caps.Deallocate()
+	}
+	sm, err := decodeSMPPShortMessage(pdu.Body)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error: %s decoding %s from %s",
+			utils.SMPPAgent, err.Error(), smppCommandNames[pdu.CommandID], c.RemoteAddr()))
+		return pdu.response(smppStatusInvCmdLen, nil)
+	}
+	status, msgID := sa.processShortMessage(sm, pdu.CommandID,
+		c.systemID, c.RemoteAddr().String())
+	if status != smppStatusOK {
+		return pdu.response(status, nil)
+	}
+	return pdu.response(status, append([]byte(msgID), 0))
+}
+
+// processShortMessage passes the message through the request processors,
+// returning the command_status and the message_id to reply with
+func (sa *SMPPAgent) processShortMessage(sm *smppShortMessage, cmdID uint32,
+	systemID, rmtAddr string) (status uint32, msgID string) {
+	smppDP := newSMPPDataProvider(sm)
+	reqVars := &utils.DataNode{
+		Type: utils.NMMapType,
+		Map: map[string]*utils.DataNode{
+			utils.RemoteHost: utils.NewLeafNode(rmtAddr),
+			MetaSMPPCommand:  utils.NewLeafNode(smppCommandNames[cmdID]),
+			MetaSMPPSystemID: utils.NewLeafNode(systemID),
+		},
+	}
+	ci, userData := sm.concatInfo()
+	if ci != nil { // every part is charged on its own
+		reqVars.Map[MetaSMPPMessageParts] = utils.NewLeafNode(1)
+		reqVars.Map[MetaSMPPConcatRef] = utils.NewLeafNode(int(ci.Ref))
+		reqVars.Map[MetaSMPPConcatTotal] = utils.NewLeafNode(int(ci.Total))
+		reqVars.Map[MetaSMPPConcatSeqNum] = utils.NewLeafNode(int(ci.SeqNum))
+	} else {
+		reqVars.Map[MetaSMPPMessageParts] = utils.NewLeafNode(
+			smppMessageParts(userData, sm.DataCoding))
+	}
+	cgrRplyNM := &utils.DataNode{Type: utils.NMMapType, Map: make(map[string]*utils.DataNode)}
+	rplyNM := utils.NewOrderedNavigableMap()
+	opts := utils.MapStorage{}
+	smppCfg := sa.cgrCfg.SMPPAgentCfg()
+	var processed bool
+	for _, reqProcessor := range smppCfg.RequestProcessors {
+		lclProcessed, err := processRequest(
+			context.TODO(),
+			reqProcessor,
+			NewAgentRequest(
+				smppDP, reqVars, cgrRplyNM, rplyNM,
+				opts, reqProcessor.Tenant,
+				sa.cgrCfg.GeneralCfg().DefaultTenant,
+				utils.FirstNonEmpty(
+					reqProcessor.Timezone,
+					smppCfg.Timezone,
+					sa.cgrCfg.GeneralCfg().DefaultTimezone,
+				),
+				sa.fltrS, nil),
+			utils.SMPPAgent, sa.connMgr,
+			smppCfg.SessionSConns,
+			smppCfg.StatSConns,
+			smppCfg.ThresholdSConns,
+			sa.fltrS)
+		if err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s processing message: %s from %s",
+					utils.SMPPAgent, err.Error(), smppDP, rmtAddr))
+			return smppStatusSysErr, utils.EmptyString
+		}
+		processed = processed || lclProcessed
+		if lclProcessed && !reqProcessor.Flags.GetBool(utils.MetaContinue) {
+			break
+		}
+	}
+	if !processed {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> no request processor enabled, ignoring message %s from %s",
+				utils.SMPPAgent, smppDP, rmtAddr))
+		return smppStatusSysErr, utils.EmptyString
+	}
+	return smppReplyStatus(cmdID, cgrRplyNM, rplyNM)
+}
+
+// smppReplyStatus returns the command_status and message_id out of the reply
+// fields, defaulting to the result of the CGRateS processing
+func smppReplyStatus(cmdID uint32, cgrRplyNM *utils.DataNode,
+	rplyNM *utils.OrderedNavigableMap) (status uint32, msgID string) {
+	if cmdID == smppSubmitSM {
+		msgID = utils.GenUUID()
+	}
+	if id, err := rplyNM.FieldAsString([]string{SMPPMessageID, "0"}); err == nil {
+		msgID = id
+	}
+	if statusStr, err := rplyNM.FieldAsString([]string{MetaSMPPCommandStatus, "0"}); err == nil {
+		st, err := strconv.ParseUint(statusStr, 0, 32)
+		if err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> invalid %s <%s>",
+				utils.SMPPAgent, MetaSMPPCommandStatus, statusStr))
+			return smppStatusSysErr, msgID
+		}
+		return uint32(st), msgID
+	}
+	if errNode, has := cgrRplyNM.Map[utils.Error]; has && errNode.Value != nil &&
+		utils.IfaceAsString(errNode.Value.Data) != utils.EmptyString {
+		if cmdID == smppSubmitSM {
+			return smppStatusSubmitFail, msgID
+		}
+		return smppStatusRxRejectApp, msgID
+	}
+	return smppStatusOK, msgID
+}
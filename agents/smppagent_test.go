@@ -0,0 +1,150 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"net"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestSMPPAgentServeConn(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data, err := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	cfg.SMPPAgentCfg().BindCredentials = map[string]string{"esme1": "secret"}
+	reqProcessor := &config.RequestProcessor{
+		ID:      "SMS",
+		Tenant:  config.NewRSRParsersMustCompile("cgrates.org", utils.InfieldSep),
+		Filters: []string{"*string:~*vars.*smppCommand:submit_sm"},
+		Flags:   utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+		ReplyFields: []*config.FCTemplate{
+			{Tag: "MessageID", Type: utils.MetaComposed, Path: utils.MetaRep + utils.NestingSep + SMPPMessageID,
+				Value: config.NewRSRParsersMustCompile("~*req.source_addr;-;~*vars.*smppMessageParts", utils.InfieldSep)},
+			{Tag: "CommandStatus", Type: utils.MetaConstant, Path: utils.MetaRep + utils.NestingSep + MetaSMPPCommandStatus,
+				Filters: []string{"*string:~*req.destination_addr:1003"},
+				Value:   config.NewRSRParsersMustCompile("0x45", utils.InfieldSep)},
+		},
+	}
+	for _, v := range reqProcessor.ReplyFields {
+		v.ComputePath()
+	}
+	cfg.SMPPAgentCfg().RequestProcessors = []*config.RequestProcessor{reqProcessor}
+	sa := NewSMPPAgent(cfg, engine.NewFilterS(cfg, nil, dm), nil, engine.NewCaps(0, utils.MetaBusy))
+
+	srvConn, clntConn := net.Pipe()
+	defer clntConn.Close()
+	c := &smppConn{Conn: srvConn}
+	sa.wg.Add(1)
+	go sa.serveConn(c)
+	exchange := func(req *smppPDU) *smppPDU {
+		t.Helper()
+		if _, err := clntConn.Write(req.encode()); err != nil {
+			t.Fatal(err)
+		}
+		rsp, err := readSMPPPDU(clntConn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rsp.SequenceNumber != req.SequenceNumber {
+			t.Fatalf("expected sequence_number %d, received %d", req.SequenceNumber, rsp.SequenceNumber)
+		}
+		return rsp
+	}
+	submit := func(seqNo uint32, body []byte) *smppPDU {
+		return &smppPDU{CommandID: smppSubmitSM, SequenceNumber: seqNo, Body: body}
+	}
+
+	if rsp := exchange(submit(1, smppTestShortMessage("1001", "1002", 0, 0, []byte("hi")))); rsp.CommandStatus != smppStatusInvBndSts {
+		t.Errorf("expected ESME_RINVBNDSTS before bind, received %#x", rsp.CommandStatus)
+	}
+	if rsp := exchange(&smppPDU{CommandID: smppBindTransceiver, SequenceNumber: 2,
+		Body: []byte("esme1\x00wrong\x00\x00\x34\x00\x00\x00")}); rsp.CommandStatus != smppStatusInvPaswd {
+		t.Errorf("expected ESME_RINVPASWD, received %#x", rsp.CommandStatus)
+	}
+	if rsp := exchange(&smppPDU{CommandID: smppBindTransceiver, SequenceNumber: 3,
+		Body: []byte("esme1\x00secret\x00\x00\x34\x00\x00\x00")}); rsp.CommandStatus != smppStatusOK ||
+		rsp.CommandID != smppBindTransceiverResp {
+		t.Errorf("unexpected bind response: %+v", rsp)
+	} else if exp := smppBindRespBody("cgrates"); string(rsp.Body) != string(exp) {
+		t.Errorf("expected body %q, received %q", exp, rsp.Body)
+	}
+	if rsp := exchange(&smppPDU{CommandID: smppEnquireLink, SequenceNumber: 4}); rsp.CommandID != smppEnquireLinkResp {
+		t.Errorf("unexpected enquire_link response: %+v", rsp)
+	}
+
+	// a long message carried in one PDU is charged for each of its parts
+	longMsg := make([]byte, 200)
+	if rsp := exchange(submit(5, smppTestShortMessage("1001", "1002", 0, 0, longMsg[:0],
+		append([]byte{0x04, 0x24, 0x00, 200}, longMsg...)))); rsp.CommandStatus != smppStatusOK {
+		t.Errorf("unexpected submit_sm response: %+v", rsp)
+	} else if string(rsp.Body) != "1001-2\x00" {
+		t.Errorf("expected message_id 1001-2, received %q", rsp.Body)
+	}
+	// one part of a concatenated message is charged alone
+	if rsp := exchange(submit(6, smppTestShortMessage("1001", "1002", smppUDHIndicator, 0,
+		append([]byte{0x05, 0x00, 0x03, 0x2A, 0x03, 0x01}, make([]byte, 153)...)))); rsp.CommandStatus != smppStatusOK {
+		t.Errorf("unexpected submit_sm response: %+v", rsp)
+	} else if string(rsp.Body) != "1001-1\x00" {
+		t.Errorf("expected message_id 1001-1, received %q", rsp.Body)
+	}
+	if rsp := exchange(submit(7, smppTestShortMessage("1001", "1003", 0, 0, []byte("hi")))); rsp.CommandStatus != smppStatusSubmitFail ||
+		len(rsp.Body) != 0 {
+		t.Errorf("unexpected submit_sm response: %+v", rsp)
+	}
+	if rsp := exchange(&smppPDU{CommandID: smppDeliverSM, SequenceNumber: 8,
+		Body: smppTestShortMessage("1002", "1001", 0, 0, []byte("hi"))}); rsp.CommandStatus != smppStatusSysErr ||
+		rsp.CommandID != smppDeliverSMResp {
+		t.Errorf("expected ESME_RSYSERR for unprocessed deliver_sm, received %+v", rsp)
+	}
+	if rsp := exchange(&smppPDU{CommandID: 0x00000103, SequenceNumber: 9}); rsp.CommandID != smppGenericNack ||
+		rsp.CommandStatus != smppStatusInvCmdID {
+		t.Errorf("expected generic_nack, received %+v", rsp)
+	}
+	if rsp := exchange(&smppPDU{CommandID: smppUnbind, SequenceNumber: 10}); rsp.CommandID != smppUnbindResp {
+		t.Errorf("unexpected unbind response: %+v", rsp)
+	}
+	sa.wg.Wait() // connection closed after unbind
+}
+
+func TestSMPPReplyStatus(t *testing.T) {
+	cgrRplyNM := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+		utils.Error: utils.NewLeafNode(utils.ErrInsufficientCredit.Error()),
+	}}
+	rplyNM := utils.NewOrderedNavigableMap()
+	if status, msgID := smppReplyStatus(smppSubmitSM, cgrRplyNM, rplyNM); status != smppStatusSubmitFail {
+		t.Errorf("expected ESME_RSUBMITFAIL, received %#x", status)
+	} else if msgID == utils.EmptyString {
+		t.Error("expected generated message_id")
+	}
+	if status, msgID := smppReplyStatus(smppDeliverSM, cgrRplyNM, rplyNM); status != smppStatusRxRejectApp ||
+		msgID != utils.EmptyString {
+		t.Errorf("unexpected status %#x and message_id %q", status, msgID)
+	}
+	cgrRplyNM.Map[utils.Error] = utils.NewLeafNode(utils.EmptyString)
+	if status, _ := smppReplyStatus(smppSubmitSM, cgrRplyNM, rplyNM); status != smppStatusOK {
+		t.Errorf("expected ESME_ROK, received %#x", status)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/blevesearch/bleve/v2"
@@ -39,7 +40,8 @@ import (
 // NewAnalyzerService initializes a AnalyzerService
 func NewAnalyzerService(cfg *config.CGRConfig) (aS *AnalyzerService, err error) {
 	aS = &AnalyzerService{
-		cfg: cfg,
+		cfg:     cfg,
+		replays: make(map[string]*replayJob),
 	}
 	err = aS.initDB()
 	return
@@ -47,10 +49,20 @@ func NewAnalyzerService(cfg *config.CGRConfig) (aS *AnalyzerService, err error)
 
 // AnalyzerService is the service handling analyzer
 type AnalyzerService struct {
-	db  bleve.Index
-	cfg *config.CGRConfig
+	db      bleve.Index
+	cfg     *config.CGRConfig
+	connMgr *engine.ConnManager // used to replay the captured traffic
 
 	filterS *engine.FilterS
+
+	replays    map[string]*replayJob
+	replaysMux sync.RWMutex
+}
+
+// SetConnManager will set the connManager used to replay the captured traffic
+// this function is called before the API is registerd
+func (aS *AnalyzerService) SetConnManager(connMgr *engine.ConnManager) {
+	aS.connMgr = connMgr
 }
 
 // SetFilterS will set the filterS used in APIs
@@ -121,6 +133,7 @@ func (aS *AnalyzerService) ListenAndServe(stopChan <-chan struct{}) (err error)
 // Shutdown is called to shutdown the service
 func (aS *AnalyzerService) Shutdown() error {
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown initialized", utils.AnalyzerS))
+	aS.stopReplays()
 	aS.db.Close()
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown complete", utils.AnalyzerS))
 	return nil
@@ -128,12 +141,12 @@ func (aS *AnalyzerService) Shutdown() error {
 
 func (aS *AnalyzerService) logTrafic(id uint64, method string,
 	params, result, err any,
-	enc, from, to string, sTime, eTime time.Time) error {
+	enc, from, to, identity string, sTime, eTime time.Time) error {
 	if strings.HasPrefix(method, utils.AnalyzerSv1) {
 		return nil
 	}
 	return aS.db.Index(utils.ConcatenatedKey(enc, from, to, method, strconv.FormatInt(sTime.Unix(), 10)),
-		NewInfoRPC(id, method, params, result, err, enc, from, to, sTime, eTime))
+		NewInfoRPC(id, method, params, result, err, enc, from, to, identity, sTime, eTime))
 }
 
 // QueryArgs the structure that we use to filter the API calls
@@ -144,22 +157,38 @@ type QueryArgs struct {
 	ContentFilters []string
 }
 
-// V1StringQuery returns a list of API that match the query
-func (aS *AnalyzerService) V1StringQuery(ctx *context.Context, args *QueryArgs, reply *[]map[string]any) error {
+// newSearchRequest returns the search request for the given header filters
+func newSearchRequest(headerFilters string) *bleve.SearchRequest {
 	var q query.Query
-	if args.HeaderFilters == utils.EmptyString {
+	if headerFilters == utils.EmptyString {
 		q = bleve.NewMatchAllQuery()
 	} else {
-		q = bleve.NewQueryStringQuery(args.HeaderFilters)
+		q = bleve.NewQueryStringQuery(headerFilters)
 	}
 	s := bleve.NewSearchRequest(q)
 	s.Fields = []string{utils.Meta} // return all fields
-	searchResults, err := aS.db.Search(s)
+	return s
+}
+
+// V1StringQuery returns a list of API that match the query
+func (aS *AnalyzerService) V1StringQuery(ctx *context.Context, args *QueryArgs, reply *[]map[string]any) error {
+	rply, _, err := aS.search(newSearchRequest(args.HeaderFilters), args.ContentFilters)
 	if err != nil {
 		return err
 	}
+	*reply = rply
+	return nil
+}
+
+// search returns the fields of the API calls matching the search request and the content filters
+// together with the number of hits before applying the content filters
+func (aS *AnalyzerService) search(s *bleve.SearchRequest, contentFltrs []string) ([]map[string]any, int, error) {
+	searchResults, err := aS.db.Search(s)
+	if err != nil {
+		return nil, 0, err
+	}
 	rply := make([]map[string]any, 0, searchResults.Hits.Len())
-	lenContentFltrs := len(args.ContentFilters)
+	lenContentFltrs := len(contentFltrs)
 	for _, obj := range searchResults.Hits {
 		// make sure that the result is corectly marshaled
 		rep := json.RawMessage(utils.IfaceAsString(obj.Fields[utils.Reply]))
@@ -176,17 +205,16 @@ func (aS *AnalyzerService) V1StringQuery(ctx *context.Context, args *QueryArgs,
 		if lenContentFltrs != 0 {
 			dp, err := getDPFromSearchresult(req, rep, obj.Fields)
 			if err != nil {
-				return err
+				return nil, 0, err
 			}
 			if pass, err := aS.filterS.Pass(aS.cfg.GeneralCfg().DefaultTenant,
-				args.ContentFilters, dp); err != nil {
-				return err
+				contentFltrs, dp); err != nil {
+				return nil, 0, err
 			} else if !pass {
 				continue
 			}
 		}
 		rply = append(rply, obj.Fields)
 	}
-	*reply = rply
-	return nil
+	return rply, searchResults.Hits.Len(), nil
 }
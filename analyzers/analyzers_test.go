@@ -83,16 +83,16 @@ func TestAnalyzerSLogTraffic(t *testing.T) {
 	}
 	t1 := time.Now().Add(-time.Hour)
 	if err = anz.logTrafic(0, utils.AnalyzerSv1Ping, "status", "result", "error",
-		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", t1, t1.Add(time.Second)); err != nil {
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", utils.EmptyString, t1, t1.Add(time.Second)); err != nil {
 		t.Fatal(err)
 	}
 	if err = anz.logTrafic(0, utils.CoreSv1Status, "status", "result", "error",
-		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", t1, t1.Add(time.Second)); err != nil {
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", utils.EmptyString, t1, t1.Add(time.Second)); err != nil {
 		t.Fatal(err)
 	}
 	t1 = time.Now().Add(-10 * time.Minute)
 	if err = anz.logTrafic(0, utils.CoreSv1Status, "status", "result", "error",
-		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", t1, t1.Add(time.Second)); err != nil {
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", utils.EmptyString, t1, t1.Add(time.Second)); err != nil {
 		t.Fatal(err)
 	}
 	if cnt, err := anz.db.DocCount(); err != nil {
@@ -207,7 +207,7 @@ func TestAnalyzersV1Search(t *testing.T) {
 				utils.EventSource: utils.MetaCDRs,
 			},
 		}, utils.Pong, nil, utils.MetaJSON, "127.0.0.1:5565",
-		"127.0.0.1:2012", t1, t1.Add(time.Second)); err != nil {
+		"127.0.0.1:2012", utils.EmptyString, t1, t1.Add(time.Second)); err != nil {
 		t.Fatal(err)
 	}
 
@@ -218,7 +218,7 @@ func TestAnalyzersV1Search(t *testing.T) {
 			},
 		}, utils.Pong, nil,
 
-		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012",
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", utils.EmptyString,
 		t1.Add(time.Second), t1.Add(20*time.Second)); err != nil {
 		t.Fatal(err)
 	}
@@ -230,7 +230,7 @@ func TestAnalyzersV1Search(t *testing.T) {
 			},
 		}, utils.Pong, nil,
 
-		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012",
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", utils.EmptyString,
 		t1.Add(2*time.Second), t1.Add(10*time.Second)); err != nil {
 		t.Fatal(err)
 	}
@@ -242,7 +242,7 @@ func TestAnalyzersV1Search(t *testing.T) {
 			},
 		}, utils.Pong, nil,
 
-		utils.MetaGOB, "127.0.0.1:5566", "127.0.0.1:2013",
+		utils.MetaGOB, "127.0.0.1:5566", "127.0.0.1:2013", utils.EmptyString,
 		t1.Add(-24*time.Hour), t1.Add(-23*time.Hour)); err != nil {
 		t.Fatal(err)
 	}
@@ -253,7 +253,7 @@ func TestAnalyzersV1Search(t *testing.T) {
 			},
 		}, utils.Pong, nil,
 
-		rpcclient.BiRPCJSON, "127.0.0.1:5566", "127.0.0.1:2013",
+		rpcclient.BiRPCJSON, "127.0.0.1:5566", "127.0.0.1:2013", utils.EmptyString,
 		t1.Add(-11*time.Hour), t1.Add(-10*time.Hour-30*time.Minute)); err != nil {
 		t.Fatal(err)
 	}
@@ -278,6 +278,7 @@ func TestAnalyzersV1Search(t *testing.T) {
 
 	expRply := []map[string]any{{
 		"RequestDestination": "127.0.0.1:2013",
+		"RequestIdentity":    "",
 		"RequestDuration":    "1h0m0s",
 		"RequestEncoding":    "*gob",
 		"RequestID":          3.,
@@ -359,6 +360,7 @@ func TestAnalyzersV1Search(t *testing.T) {
 	}
 	expRply = []map[string]any{{
 		"RequestDestination": "127.0.0.1:2013",
+		"RequestIdentity":    "",
 		"RequestDuration":    "30m0s",
 		"RequestEncoding":    "*birpc_json",
 		"RequestID":          3.,
@@ -485,16 +487,16 @@ func TestAnalyzerSLogTrafficInternalDB(t *testing.T) {
 	}
 	t1 := time.Now().Add(-time.Hour)
 	if err = anz.logTrafic(0, utils.AnalyzerSv1Ping, "status", "result", "error",
-		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", t1, t1.Add(time.Second)); err != nil {
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", utils.EmptyString, t1, t1.Add(time.Second)); err != nil {
 		t.Fatal(err)
 	}
 	if err = anz.logTrafic(0, utils.CoreSv1Status, "status", "result", "error",
-		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", t1, t1.Add(time.Second)); err != nil {
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", utils.EmptyString, t1, t1.Add(time.Second)); err != nil {
 		t.Fatal(err)
 	}
 	t1 = time.Now().Add(-10 * time.Minute)
 	if err = anz.logTrafic(0, utils.CoreSv1Status, "status", "result", "error",
-		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", t1, t1.Add(time.Second)); err != nil {
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", utils.EmptyString, t1, t1.Add(time.Second)); err != nil {
 		t.Fatal(err)
 	}
 	if cnt, err := anz.db.DocCount(); err != nil {
@@ -23,8 +23,22 @@ import (
 	"time"
 
 	"github.com/cgrates/birpc"
+	"github.com/cgrates/cgrates/utils"
 )
 
+// identityCodec is implemented by the codecs authenticating the callers
+type identityCodec interface {
+	RequestIdentity() string
+}
+
+// requestIdentity returns the caller authenticated by the wrapped codec, if any
+func requestIdentity(sc any) string {
+	if idc, canCast := sc.(identityCodec); canCast {
+		return idc.RequestIdentity()
+	}
+	return utils.EmptyString
+}
+
 func NewAnalyzerServerCodec(sc birpc.ServerCodec, aS *AnalyzerService, enc, from, to string) birpc.ServerCodec {
 	return &AnalyzerServerCodec{
 		sc:   sc,
@@ -66,6 +80,7 @@ func (c *AnalyzerServerCodec) ReadRequestBody(x any) (err error) {
 	err = c.sc.ReadRequestBody(x)
 	c.reqsLk.Lock()
 	c.reqs[c.reqIdx].Params = x
+	c.reqs[c.reqIdx].Identity = requestIdentity(c.sc)
 	c.reqsLk.Unlock()
 	return
 }
@@ -75,7 +90,7 @@ func (c *AnalyzerServerCodec) WriteResponse(r *birpc.Response, x any) error {
 	api := c.reqs[r.Seq]
 	delete(c.reqs, r.Seq)
 	c.reqsLk.Unlock()
-	go c.aS.logTrafic(api.ID, api.Method, api.Params, x, r.Error, c.enc, c.from, c.to, api.StartTime, time.Now())
+	go c.aS.logTrafic(api.ID, api.Method, api.Params, x, r.Error, c.enc, c.from, c.to, api.Identity, api.StartTime, time.Now())
 	return c.sc.WriteResponse(r, x)
 }
 
@@ -137,6 +152,7 @@ func (c *AnalyzerBiRPCCodec) ReadRequestBody(x any) (err error) {
 	err = c.sc.ReadRequestBody(x)
 	c.reqsLk.Lock()
 	c.reqs[c.reqIdx].Params = x
+	c.reqs[c.reqIdx].Identity = requestIdentity(c.sc)
 	c.reqsLk.Unlock()
 	return
 }
@@ -148,7 +164,7 @@ func (c *AnalyzerBiRPCCodec) ReadResponseBody(x any) (err error) {
 	api := c.reps[c.repIdx]
 	delete(c.reps, c.repIdx)
 	c.repsLk.Unlock()
-	go c.aS.logTrafic(api.ID, api.Method, api.Params, x, api.Error, c.enc, c.to, c.from, api.StartTime, time.Now())
+	go c.aS.logTrafic(api.ID, api.Method, api.Params, x, api.Error, c.enc, c.to, c.from, utils.EmptyString, api.StartTime, time.Now())
 	return
 }
 
@@ -171,7 +187,7 @@ func (c *AnalyzerBiRPCCodec) WriteResponse(r *birpc.Response, x any) error {
 	api := c.reqs[r.Seq]
 	delete(c.reqs, r.Seq)
 	c.reqsLk.Unlock()
-	go c.aS.logTrafic(api.ID, api.Method, api.Params, x, r.Error, c.enc, c.from, c.to, api.StartTime, time.Now())
+	go c.aS.logTrafic(api.ID, api.Method, api.Params, x, r.Error, c.enc, c.from, c.to, api.Identity, api.StartTime, time.Now())
 	return c.sc.WriteResponse(r, x)
 }
 
@@ -98,6 +98,28 @@ func TestNewServerCodec(t *testing.T) {
 	}
 }
 
+type mockIdentityCodec struct {
+	mockServerCodec
+}
+
+func (*mockIdentityCodec) RequestIdentity() string { return "reseller1" }
+
+func TestServerCodecRequestIdentity(t *testing.T) {
+	codec := NewAnalyzerServerCodec(new(mockIdentityCodec), nil, utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012").(*AnalyzerServerCodec)
+	if err := codec.ReadRequestHeader(new(birpc.Request)); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.ReadRequestBody("args"); err != nil {
+		t.Fatal(err)
+	}
+	if idt := codec.reqs[0].Identity; idt != "reseller1" {
+		t.Errorf("expected identity %q, received %q", "reseller1", idt)
+	}
+	if idt := requestIdentity(new(mockServerCodec)); idt != utils.EmptyString {
+		t.Errorf("expected no identity, received %q", idt)
+	}
+}
+
 type mockBiRPCCodec struct{}
 
 func (mockBiRPCCodec) ReadHeader(r *birpc.Request, _ *birpc.Response) error {
@@ -23,6 +23,7 @@ import (
 
 	"github.com/cgrates/birpc"
 	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/utils"
 )
 
 func (aS *AnalyzerService) NewAnalyzerConnector(sc birpc.ClientConnector, enc, from, to string) birpc.ClientConnector {
@@ -47,6 +48,6 @@ type AnalyzerConnector struct {
 func (c *AnalyzerConnector) Call(ctx *context.Context, serviceMethod string, args, reply any) (err error) {
 	sTime := time.Now()
 	err = c.conn.Call(ctx, serviceMethod, args, reply)
-	go c.aS.logTrafic(0, serviceMethod, args, reply, err, c.enc, c.from, c.to, sTime, time.Now())
+	go c.aS.logTrafic(0, serviceMethod, args, reply, err, c.enc, c.from, c.to, utils.EmptyString, sTime, time.Now())
 	return
 }
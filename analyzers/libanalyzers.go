@@ -34,7 +34,7 @@ import (
 // NewInfoRPC returns a structure to be indexed
 func NewInfoRPC(id uint64, method string,
 	params, result, err any,
-	enc, from, to string, sTime, eTime time.Time) *InfoRPC {
+	enc, from, to, identity string, sTime, eTime time.Time) *InfoRPC {
 	var e any
 	switch val := err.(type) {
 	default:
@@ -52,6 +52,7 @@ func NewInfoRPC(id uint64, method string,
 		RequestEncoding:    enc,
 		RequestSource:      from,
 		RequestDestination: to,
+		RequestIdentity:    identity,
 
 		RequestID:     id,
 		RequestMethod: method,
@@ -70,6 +71,7 @@ type InfoRPC struct {
 	RequestEncoding    string
 	RequestSource      string
 	RequestDestination string
+	RequestIdentity    string // the caller authenticated by the APIAuth
 
 	RequestID     uint64
 	RequestMethod string
@@ -84,6 +86,7 @@ type rpcAPI struct {
 	Params any    `json:"params"`
 	Error  string `json:"err,omitempty"`
 
+	Identity  string
 	StartTime time.Time
 }
 
@@ -0,0 +1,470 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package analyzers
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	replayPageSize           = 100       // the number of captures read at once from the index
+	replayDefaultConcurrency = 10        // the requests waiting for replies when replaying at Speed
+	replayJobTTL             = time.Hour // the finished replays are kept as long for their report
+)
+
+// ReplayArgs selects the captured API calls to be replayed and how
+type ReplayArgs struct {
+	QueryArgs
+	// the connections from rpc_conns where the requests are replayed, need to use the *json codec
+	ConnIDs []string
+	// 1 keeps the original timing, 2 replays twice as fast and so on,
+	// 0 replays the requests one after the other without waiting
+	Speed float64
+	// request fields overwritten before replay, the values being RSRParsers
+	// over the capture( ie. {"Event.OriginID": "~*req.Event.OriginID;_replay"})
+	Rewrite map[string]string
+	// request fields holding timestamps, moved forward with the time passed since the capture
+	ShiftTimes []string
+	// reply fields not compared( ie. generated IDs)
+	IgnoreFields []string
+	// maximum number of requests waiting for their reply when replaying at Speed,
+	// 10 if not specified
+	Concurrency int
+}
+
+// ReplayIDArgs identifies a replay started with V1Replay
+type ReplayIDArgs struct {
+	ID string
+}
+
+// ReplayChange is a field which changed in the replayed reply
+type ReplayChange struct {
+	Path     string
+	Expected any
+	Received any
+}
+
+// ReplayDiff is a replayed request whose reply changed
+type ReplayDiff struct {
+	RequestID        uint64
+	RequestMethod    string
+	RequestStartTime time.Time
+	RequestParams    json.RawMessage // as replayed, after rewrite
+	ExpectedError    string
+	ReceivedError    string
+	Changes          []*ReplayChange
+}
+
+// ReplayReport is the progress and the result of replaying the captured traffic
+type ReplayReport struct {
+	ID        string
+	Status    string // *running, *done, *failed or *canceled
+	Error     string
+	StartTime time.Time
+	EndTime   time.Time
+	Replayed  int
+	Matched   int
+	Diffs     []*ReplayDiff
+}
+
+// replayJob is a replay running in the background
+type replayJob struct {
+	sync.RWMutex
+	report ReplayReport
+	cancel context.CancelFunc
+}
+
+// add counts the replayed request, keeping its diff
+func (job *replayJob) add(diff *ReplayDiff) {
+	job.Lock()
+	job.report.Replayed++
+	if diff == nil {
+		job.report.Matched++
+	} else {
+		job.report.Diffs = append(job.report.Diffs, diff)
+	}
+	job.Unlock()
+}
+
+// finish sets the final status of the replay, ordering the diffs as captured
+func (job *replayJob) finish(err error) {
+	job.Lock()
+	defer job.Unlock()
+	job.report.EndTime = time.Now()
+	switch {
+	case err == nil:
+		job.report.Status = utils.MetaDone
+	case errors.Is(err, context.Canceled):
+		job.report.Status = utils.MetaCanceled
+	default:
+		job.report.Status = utils.MetaFailed
+		job.report.Error = err.Error()
+	}
+	slices.SortFunc(job.report.Diffs, func(a, b *ReplayDiff) int {
+		if c := a.RequestStartTime.Compare(b.RequestStartTime); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.RequestID, b.RequestID)
+	})
+}
+
+// getReport returns a copy of the report
+func (job *replayJob) getReport() ReplayReport {
+	job.RLock()
+	defer job.RUnlock()
+	rply := job.report
+	rply.Diffs = slices.Clone(job.report.Diffs)
+	return rply
+}
+
+// capturedCall is an API call read from the index
+type capturedCall struct {
+	id        uint64
+	method    string
+	startTime time.Time
+	params    json.RawMessage
+	reply     json.RawMessage
+	err       string
+	fields    map[string]any
+}
+
+func newCapturedCall(fields map[string]any) (c *capturedCall, err error) {
+	c = &capturedCall{
+		method: utils.IfaceAsString(fields[utils.RequestMethod]),
+		params: json.RawMessage(utils.IfaceAsString(fields[utils.RequestParams])),
+		reply:  json.RawMessage(utils.IfaceAsString(fields[utils.Reply])),
+		fields: fields,
+	}
+	if val, has := fields[utils.ReplyError]; has && val != nil {
+		c.err = utils.IfaceAsString(val)
+	}
+	var id int64
+	if id, err = utils.IfaceAsTInt64(fields[utils.RequestID]); err != nil {
+		return
+	}
+	c.id = uint64(id)
+	c.startTime, err = utils.IfaceAsTime(fields[utils.RequestStartTime], utils.EmptyString)
+	return
+}
+
+// forEachCapture calls f with the API calls matching the query, ordered by their start time,
+// reading them one page at a time and stopping at the ones captured after until
+func (aS *AnalyzerService) forEachCapture(args *QueryArgs, until time.Time,
+	f func(*capturedCall) error) (err error) {
+	for from := 0; ; from += replayPageSize {
+		s := newSearchRequest(args.HeaderFilters)
+		s.SortBy([]string{utils.RequestStartTime, utils.RequestID})
+		s.From, s.Size = from, replayPageSize
+		var flds []map[string]any
+		var hits int
+		if flds, hits, err = aS.search(s, args.ContentFilters); err != nil {
+			return
+		}
+		for _, fld := range flds {
+			var c *capturedCall
+			if c, err = newCapturedCall(fld); err != nil {
+				return
+			}
+			if !c.startTime.Before(until) { // captured while replaying
+				return
+			}
+			if err = f(c); err != nil {
+				return
+			}
+		}
+		if hits < replayPageSize {
+			return
+		}
+	}
+}
+
+// replayOpts are the ReplayArgs compiled once for all the requests
+type replayOpts struct {
+	connIDs      []string
+	rewrite      map[string]config.RSRParsers
+	shiftTimes   [][]string
+	ignoreFields [][]string
+}
+
+func newReplayOpts(args *ReplayArgs) (opts *replayOpts, err error) {
+	if len(args.ConnIDs) == 0 {
+		return nil, utils.NewErrMandatoryIeMissing("ConnIDs")
+	}
+	if args.Speed < 0 {
+		return nil, fmt.Errorf("invalid Speed: %v", args.Speed)
+	}
+	if args.Concurrency < 0 {
+		return nil, fmt.Errorf("invalid Concurrency: %v", args.Concurrency)
+	}
+	opts = &replayOpts{
+		connIDs:      args.ConnIDs,
+		rewrite:      make(map[string]config.RSRParsers, len(args.Rewrite)),
+		shiftTimes:   make([][]string, len(args.ShiftTimes)),
+		ignoreFields: make([][]string, len(args.IgnoreFields)),
+	}
+	for path, val := range args.Rewrite {
+		if opts.rewrite[path], err = config.NewRSRParsers(val, utils.InfieldSep); err != nil {
+			return nil, fmt.Errorf("invalid Rewrite for <%s>: %w", path, err)
+		}
+	}
+	for i, path := range args.ShiftTimes {
+		opts.shiftTimes[i] = strings.Split(path, utils.NestingSep)
+	}
+	for i, path := range args.IgnoreFields {
+		opts.ignoreFields[i] = strings.Split(path, utils.NestingSep)
+	}
+	return
+}
+
+// V1Replay starts replaying in the background the captured API calls matching the query
+// towards the ConnIDs, in the order they were captured, replying with the ID used to
+// follow the replay with V1ReplayStatus
+func (aS *AnalyzerService) V1Replay(ctx *context.Context, args *ReplayArgs, reply *string) (err error) {
+	var opts *replayOpts
+	if opts, err = newReplayOpts(args); err != nil {
+		return
+	}
+	jobCtx, cancel := context.WithCancel(context.Background())
+	job := &replayJob{
+		report: ReplayReport{
+			ID:        utils.GenUUID(),
+			Status:    utils.MetaRunning,
+			StartTime: time.Now(),
+			Diffs:     make([]*ReplayDiff, 0),
+		},
+		cancel: cancel,
+	}
+	aS.replaysMux.Lock()
+	for id, oldJob := range aS.replays { // drop the reports nobody asked for
+		oldJob.RLock()
+		expired := !oldJob.report.EndTime.IsZero() &&
+			time.Since(oldJob.report.EndTime) > replayJobTTL
+		oldJob.RUnlock()
+		if expired {
+			delete(aS.replays, id)
+		}
+	}
+	id := job.report.ID
+	aS.replays[id] = job
+	aS.replaysMux.Unlock()
+	go func() {
+		job.finish(aS.replay(jobCtx, job, args, opts))
+		cancel()
+	}()
+	*reply = id
+	return
+}
+
+// replay sends the captured calls, waiting for the replies before returning
+func (aS *AnalyzerService) replay(ctx *context.Context, job *replayJob, args *ReplayArgs, opts *replayOpts) (err error) {
+	if args.Speed == 0 {
+		return aS.forEachCapture(&args.QueryArgs, job.report.StartTime, func(c *capturedCall) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			job.add(aS.replayCall(ctx, c, opts))
+			return nil
+		})
+	}
+	concurrency := args.Concurrency
+	if concurrency == 0 {
+		concurrency = replayDefaultConcurrency
+	}
+	guard := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var first time.Time
+	start := time.Now()
+	err = aS.forEachCapture(&args.QueryArgs, job.report.StartTime, func(c *capturedCall) error {
+		if first.IsZero() {
+			first = c.startTime
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Until(start.Add(
+			time.Duration(float64(c.startTime.Sub(first)) / args.Speed)))):
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case guard <- struct{}{}:
+		}
+		wg.Add(1)
+		go func() {
+			job.add(aS.replayCall(ctx, c, opts))
+			<-guard
+			wg.Done()
+		}()
+		return nil
+	})
+	wg.Wait()
+	return
+}
+
+// V1ReplayStatus returns the progress of the replay, with the report once finished
+func (aS *AnalyzerService) V1ReplayStatus(ctx *context.Context, args *ReplayIDArgs, reply *ReplayReport) error {
+	aS.replaysMux.RLock()
+	job, has := aS.replays[args.ID]
+	aS.replaysMux.RUnlock()
+	if !has {
+		return utils.ErrNotFound
+	}
+	*reply = job.getReport()
+	return nil
+}
+
+// V1ReplayCancel stops the replay, the requests already sent being part of its report
+func (aS *AnalyzerService) V1ReplayCancel(ctx *context.Context, args *ReplayIDArgs, reply *string) error {
+	aS.replaysMux.RLock()
+	job, has := aS.replays[args.ID]
+	aS.replaysMux.RUnlock()
+	if !has {
+		return utils.ErrNotFound
+	}
+	job.cancel()
+	*reply = utils.OK
+	return nil
+}
+
+// stopReplays cancels the replays still running
+func (aS *AnalyzerService) stopReplays() {
+	aS.replaysMux.RLock()
+	for _, job := range aS.replays {
+		job.cancel()
+	}
+	aS.replaysMux.RUnlock()
+}
+
+// replayCall sends the captured request and compares the reply with the captured one,
+// returning nil if nothing changed
+func (aS *AnalyzerService) replayCall(ctx *context.Context, c *capturedCall, opts *replayOpts) *ReplayDiff {
+	diff := &ReplayDiff{
+		RequestID:        c.id,
+		RequestMethod:    c.method,
+		RequestStartTime: c.startTime,
+		RequestParams:    c.params,
+		ExpectedError:    c.err,
+	}
+	var err error
+	if diff.RequestParams, err = aS.rewriteParams(c, opts, time.Now()); err != nil {
+		diff.ReceivedError = err.Error()
+		return diff
+	}
+	var rcv json.RawMessage
+	if err = aS.connMgr.Call(ctx, opts.connIDs, c.method, diff.RequestParams, &rcv); err != nil {
+		diff.ReceivedError = err.Error()
+	}
+	if diff.ExpectedError != diff.ReceivedError {
+		return diff
+	}
+	if diff.ReceivedError != utils.EmptyString { // failed the same way
+		return nil
+	}
+	if diff.Changes, err = compareReplies(c.reply, rcv, opts.ignoreFields); err != nil {
+		diff.ReceivedError = err.Error()
+		return diff
+	}
+	if len(diff.Changes) == 0 {
+		return nil
+	}
+	return diff
+}
+
+// rewriteParams applies the Rewrite and ShiftTimes on the captured request params
+func (aS *AnalyzerService) rewriteParams(c *capturedCall, opts *replayOpts, now time.Time) (json.RawMessage, error) {
+	if len(opts.rewrite) == 0 && len(opts.shiftTimes) == 0 {
+		return c.params, nil
+	}
+	var params map[string]any
+	if err := json.Unmarshal(c.params, &params); err != nil || params == nil {
+		return c.params, nil // nothing to rewrite outside of objects
+	}
+	dp, err := getDPFromSearchresult(c.params, c.reply, c.fields)
+	if err != nil {
+		return nil, err
+	}
+	for path, rsr := range opts.rewrite {
+		var val string
+		if val, err = rsr.ParseDataProvider(dp); err != nil {
+			if errors.Is(err, utils.ErrNotFound) { // the field is not part of this request
+				continue
+			}
+			return nil, fmt.Errorf("rewriting <%s>: %w", path, err)
+		}
+		if err = utils.MapStorage(params).Set(strings.Split(path, utils.NestingSep), val); err != nil {
+			return nil, fmt.Errorf("rewriting <%s>: %w", path, err)
+		}
+	}
+	offset := now.Sub(c.startTime)
+	for _, path := range opts.shiftTimes {
+		val, err := utils.MapStorage(params).FieldAsInterface(path)
+		if err != nil {
+			continue // the field is not part of this request
+		}
+		var t time.Time
+		if t, err = utils.IfaceAsTime(val, aS.cfg.GeneralCfg().DefaultTimezone); err != nil || t.IsZero() {
+			continue
+		}
+		if err = utils.MapStorage(params).Set(path, t.Add(offset)); err != nil {
+			return nil, fmt.Errorf("shifting <%s>: %w", strings.Join(path, utils.NestingSep), err)
+		}
+	}
+	return json.Marshal(params)
+}
+
+// compareReplies returns the fields which differ between the captured and the replayed reply
+func compareReplies(expJSON, rcvJSON json.RawMessage, ignoreFields [][]string) (changes []*ReplayChange, err error) {
+	var exp, rcv any
+	if len(expJSON) != 0 {
+		if err = json.Unmarshal(expJSON, &exp); err != nil {
+			return
+		}
+	}
+	if len(rcvJSON) != 0 {
+		if err = json.Unmarshal(rcvJSON, &rcv); err != nil {
+			return
+		}
+	}
+	for _, path := range ignoreFields {
+		for _, val := range []any{exp, rcv} {
+			if mp, isMap := val.(map[string]any); isMap {
+				utils.MapStorage(mp).Remove(path)
+			}
+		}
+	}
+	for _, diff := range utils.DiffJSONValues(exp, rcv) {
+		changes = append(changes, &ReplayChange{
+			Path:     diff.Path,
+			Expected: diff.Before,
+			Received: diff.After,
+		})
+	}
+	return
+}
@@ -0,0 +1,295 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package analyzers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+type replayMock struct {
+	calls map[string]func(args json.RawMessage) (any, error)
+}
+
+func (m *replayMock) Call(_ *context.Context, serviceMethod string, args, reply any) error {
+	call, has := m.calls[serviceMethod]
+	if !has {
+		return rpcclient.ErrUnsupporteServiceMethod
+	}
+	rply, err := call(args.(json.RawMessage))
+	if err != nil {
+		return err
+	}
+	*reply.(*json.RawMessage), err = json.Marshal(rply)
+	return err
+}
+
+func newReplayAnalyzer(t *testing.T, m *replayMock) *AnalyzerService {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AnalyzerSCfg().IndexType = utils.MetaInternal
+	connChan := make(chan birpc.ClientConnector, 1)
+	connChan <- m
+	engine.Cache.Clear([]string{utils.CacheRPCConnections}) // drop the mocks of previous tests
+	anz, err := NewAnalyzerService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	anz.SetConnManager(engine.NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes): connChan,
+	}))
+	return anz
+}
+
+// runReplay starts the replay and waits for it to finish
+func runReplay(t *testing.T, anz *AnalyzerService, args *ReplayArgs) (reply ReplayReport) {
+	t.Helper()
+	var id string
+	if err := anz.V1Replay(context.Background(), args, &id); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		if err := anz.V1ReplayStatus(context.Background(), &ReplayIDArgs{ID: id}, &reply); err != nil {
+			t.Fatal(err)
+		}
+		if reply.Status != utils.MetaRunning {
+			return
+		}
+		if i == 100 {
+			t.Fatal("the replay did not finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestAnalyzersV1Replay(t *testing.T) {
+	sTime := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	var rcvEvents []map[string]any
+	anz := newReplayAnalyzer(t, &replayMock{
+		calls: map[string]func(args json.RawMessage) (any, error){
+			utils.CoreSv1Ping: func(json.RawMessage) (any, error) {
+				return utils.Pong, nil
+			},
+			utils.AttributeSv1ProcessEvent: func(args json.RawMessage) (any, error) {
+				var ev map[string]any
+				if err := json.Unmarshal(args, &ev); err != nil {
+					return nil, err
+				}
+				rcvEvents = append(rcvEvents, ev)
+				return ev, nil
+			},
+		},
+	})
+	ev := map[string]any{
+		utils.Tenant: "cgrates.org",
+		utils.ID:     "ev1",
+		utils.Event: map[string]any{
+			utils.OriginID:   "abc",
+			utils.AnswerTime: sTime.Add(-time.Minute),
+		},
+	}
+	if err := anz.logTrafic(1, utils.CoreSv1Ping, nil, utils.Pong, nil,
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", utils.EmptyString,
+		sTime, sTime.Add(time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if err := anz.logTrafic(2, utils.AttributeSv1ProcessEvent, ev, ev, nil,
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", utils.EmptyString,
+		sTime.Add(time.Second), sTime.Add(time.Second+time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if err := anz.logTrafic(3, utils.CoreSv1Ping, nil, nil, utils.ErrNotFound,
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", utils.EmptyString,
+		sTime.Add(2*time.Second), sTime.Add(2*time.Second+time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+
+	reply := runReplay(t, anz, &ReplayArgs{
+		ConnIDs:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes)},
+		Rewrite:    map[string]string{"Event.OriginID": "~*req.Event.OriginID;_replay"},
+		ShiftTimes: []string{"Event.AnswerTime", "Event.SetupTime"},
+	})
+	if reply.Status != utils.MetaDone || reply.Replayed != 3 || reply.Matched != 1 || len(reply.Diffs) != 2 {
+		t.Fatalf("unexpected report: %s", utils.ToJSON(reply))
+	}
+	if len(rcvEvents) != 1 {
+		t.Fatalf("expected one event replayed, received: %s", utils.ToJSON(rcvEvents))
+	}
+	rcvEv := rcvEvents[0][utils.Event].(map[string]any)
+	if aTime, err := utils.IfaceAsTime(rcvEv[utils.AnswerTime], utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if since := time.Since(aTime); since < time.Minute || since > 2*time.Minute { // captured 61s after AnswerTime
+		t.Errorf("expected AnswerTime shifted, received: %v", aTime)
+	}
+	expChanges := []*ReplayChange{
+		{Path: "Event.AnswerTime", Expected: "2024-03-01T09:59:00Z", Received: rcvEv[utils.AnswerTime]},
+		{Path: "Event.OriginID", Expected: "abc", Received: "abc_replay"},
+	}
+	if diff := reply.Diffs[0]; diff.RequestID != 2 || diff.RequestMethod != utils.AttributeSv1ProcessEvent {
+		t.Errorf("unexpected diff: %s", utils.ToJSON(diff))
+	} else if !reflect.DeepEqual(expChanges, diff.Changes) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expChanges), utils.ToJSON(diff.Changes))
+	}
+	if diff := reply.Diffs[1]; diff.RequestID != 3 ||
+		diff.ExpectedError != utils.ErrNotFound.Error() || diff.ReceivedError != utils.EmptyString {
+		t.Errorf("unexpected diff: %s", utils.ToJSON(diff))
+	}
+
+	// replaying at a speed-up keeps the order of the requests
+	rcvEvents = nil
+	reply = runReplay(t, anz, &ReplayArgs{
+		QueryArgs: QueryArgs{
+			HeaderFilters: utils.RequestMethod + `:"` + utils.AttributeSv1ProcessEvent + `"`,
+		},
+		ConnIDs:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes)},
+		Speed:        100,
+		Concurrency:  1,
+		IgnoreFields: []string{"Event.OriginID"},
+	})
+	if reply.Status != utils.MetaDone || reply.Replayed != 1 || reply.Matched != 1 || len(reply.Diffs) != 0 {
+		t.Errorf("unexpected report: %s", utils.ToJSON(reply))
+	}
+}
+
+func TestAnalyzersV1ReplayErrors(t *testing.T) {
+	anz := newReplayAnalyzer(t, &replayMock{})
+	var reply string
+	if err := anz.V1Replay(context.Background(), &ReplayArgs{},
+		&reply); err == nil || err.Error() != utils.NewErrMandatoryIeMissing("ConnIDs").Error() {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := anz.V1Replay(context.Background(), &ReplayArgs{
+		ConnIDs: []string{utils.MetaInternal},
+		Speed:   -1,
+	}, &reply); err == nil || err.Error() != "invalid Speed: -1" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := anz.V1Replay(context.Background(), &ReplayArgs{
+		ConnIDs: []string{utils.MetaInternal},
+		Rewrite: map[string]string{"Event.OriginID": "~*req.Event.OriginID{*duration_seconds&"},
+	}, &reply); err == nil {
+		t.Error("expected error for invalid Rewrite")
+	}
+	if err := anz.V1Replay(context.Background(), &ReplayArgs{
+		ConnIDs:     []string{utils.MetaInternal},
+		Concurrency: -1,
+	}, &reply); err == nil || err.Error() != "invalid Concurrency: -1" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	var report ReplayReport
+	if err := anz.V1ReplayStatus(context.Background(), &ReplayIDArgs{ID: "unknown"},
+		&report); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err := anz.V1ReplayCancel(context.Background(), &ReplayIDArgs{ID: "unknown"},
+		&reply); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestAnalyzersV1ReplayCancel(t *testing.T) {
+	called := make(chan struct{}, 3)
+	block := make(chan struct{})
+	anz := newReplayAnalyzer(t, &replayMock{
+		calls: map[string]func(args json.RawMessage) (any, error){
+			utils.CoreSv1Ping: func(json.RawMessage) (any, error) {
+				called <- struct{}{}
+				<-block
+				return utils.Pong, nil
+			},
+		},
+	})
+	defer close(block)
+	sTime := time.Now().Add(-time.Hour)
+	for i := range 3 {
+		if err := anz.logTrafic(uint64(i), utils.CoreSv1Ping, nil, utils.Pong, nil,
+			utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", utils.EmptyString,
+			sTime.Add(time.Duration(i)*time.Minute), sTime.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var id string
+	if err := anz.V1Replay(context.Background(), &ReplayArgs{
+		ConnIDs: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes)},
+		Speed:   1, // the second request waits a minute
+	}, &id); err != nil {
+		t.Fatal(err)
+	}
+	<-called // the first request is waiting for its reply
+	var reply ReplayReport
+	if err := anz.V1ReplayStatus(context.Background(), &ReplayIDArgs{ID: id}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply.ID != id || reply.Status != utils.MetaRunning {
+		t.Errorf("unexpected report: %s", utils.ToJSON(reply))
+	}
+	var rply string
+	if err := anz.V1ReplayCancel(context.Background(), &ReplayIDArgs{ID: id}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	block <- struct{}{} // answer the request already sent
+	for i := 0; ; i++ {
+		if err := anz.V1ReplayStatus(context.Background(), &ReplayIDArgs{ID: id}, &reply); err != nil {
+			t.Fatal(err)
+		}
+		if reply.Status != utils.MetaRunning {
+			break
+		}
+		if i == 100 {
+			t.Fatal("the replay was not canceled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if reply.Status != utils.MetaCanceled || reply.Replayed != 1 || reply.EndTime.IsZero() {
+		t.Errorf("unexpected report: %s", utils.ToJSON(reply))
+	}
+}
+
+func TestAnalyzersCompareReplies(t *testing.T) {
+	exp := json.RawMessage(`{"ID":"1","Cost":10,"Rates":[{"Value":1},{"Value":2}],"UUID":"a"}`)
+	rcv := json.RawMessage(`{"ID":"1","Cost":12,"Rates":[{"Value":1},{"Value":3}],"UUID":"b","New":true}`)
+	expChanges := []*ReplayChange{
+		{Path: "Cost", Expected: 10., Received: 12.},
+		{Path: "New", Expected: nil, Received: true},
+		{Path: "Rates[1].Value", Expected: 2., Received: 3.},
+	}
+	if changes, err := compareReplies(exp, rcv, [][]string{{"UUID"}}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expChanges, changes) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expChanges), utils.ToJSON(changes))
+	}
+	expChanges = []*ReplayChange{{Path: utils.EmptyString, Expected: "OK", Received: "NOK"}}
+	if changes, err := compareReplies(json.RawMessage(`"OK"`), json.RawMessage(`"NOK"`), nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expChanges, changes) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expChanges), utils.ToJSON(changes))
+	}
+	if changes, err := compareReplies(exp, exp, nil); err != nil {
+		t.Error(err)
+	} else if len(changes) != 0 {
+		t.Errorf("expected no changes, received %s", utils.ToJSON(changes))
+	}
+}
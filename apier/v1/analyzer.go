@@ -37,3 +37,18 @@ type AnalyzerSv1 struct {
 func (aSv1 *AnalyzerSv1) StringQuery(ctx *context.Context, search *analyzers.QueryArgs, reply *[]map[string]any) error {
 	return aSv1.aS.V1StringQuery(ctx, search, reply)
 }
+
+// Replay starts sending the captured API calls matching the query to other engines, replying with the replay ID
+func (aSv1 *AnalyzerSv1) Replay(ctx *context.Context, args *analyzers.ReplayArgs, reply *string) error {
+	return aSv1.aS.V1Replay(ctx, args, reply)
+}
+
+// ReplayStatus returns the progress of the replay, reporting the replies that changed
+func (aSv1 *AnalyzerSv1) ReplayStatus(ctx *context.Context, args *analyzers.ReplayIDArgs, reply *analyzers.ReplayReport) error {
+	return aSv1.aS.V1ReplayStatus(ctx, args, reply)
+}
+
+// ReplayCancel stops the replay
+func (aSv1 *AnalyzerSv1) ReplayCancel(ctx *context.Context, args *analyzers.ReplayIDArgs, reply *string) error {
+	return aSv1.aS.V1ReplayCancel(ctx, args, reply)
+}
@@ -499,7 +499,8 @@ func (apierSv1 *APIerSv1) SetRatingProfile(ctx *context.Context, attrs *utils.At
 				FallbackKeys: utils.FallbackSubjKeys(tnt,
 					attrs.Category, ra.FallbackSubjects)})
 	}
-	if err := apierSv1.DataManager.SetRatingProfile(rpfl); err != nil {
+	if err := apierSv1.DataManager.WithAudit(AuditActor(apierSv1.Config, attrs.APIOpts),
+		utils.APIerSv1SetRatingProfile).SetRatingProfile(rpfl); err != nil {
 		return utils.NewErrServerError(err)
 	}
 
@@ -1226,7 +1227,8 @@ func (apierSv1 *APIerSv1) RemoveRatingProfile(ctx *context.Context, attr *AttrRe
 	}
 	keyID := attr.GetId()
 	err := guardian.Guardian.Guard(func() error {
-		return apierSv1.DataManager.RemoveRatingProfile(keyID)
+		return apierSv1.DataManager.WithAudit(AuditActor(apierSv1.Config, attr.APIOpts),
+			utils.APIerSv1RemoveRatingProfile).RemoveRatingProfile(keyID)
 	}, config.CgrConfig().GeneralCfg().LockingTimeout, "RemoveRatingProfile")
 	if err != nil {
 		*reply = err.Error()
@@ -1562,7 +1564,8 @@ func (apierSv1 *APIerSv1) ExportToFolder(ctx *context.Context, arg *utils.ArgExp
 	if len(arg.Items) == 0 {
 		arg.Items = []string{utils.MetaAttributes, utils.MetaChargers, utils.MetaDispatchers,
 			utils.MetaDispatcherHosts, utils.MetaFilters, utils.MetaResources, utils.MetaStats,
-			utils.MetaRoutes, utils.MetaThresholds, utils.MetaRankings, utils.MetaTrends}
+			utils.MetaRoutes, utils.MetaThresholds, utils.MetaRankings, utils.MetaTrends,
+			utils.MetaTaxProfiles}
 	}
 	if _, err := os.Stat(arg.Path); os.IsNotExist(err) {
 		os.Mkdir(arg.Path, os.ModeDir)
@@ -1978,6 +1981,44 @@ func (apierSv1 *APIerSv1) ExportToFolder(ctx *context.Context, arg *utils.ArgExp
 				}
 			}
 			csvWriter.Flush()
+		case utils.MetaTaxProfiles:
+			prfx := utils.TaxProfilePrefix
+			keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx, utils.EmptyString)
+			if err != nil {
+				return err
+			}
+			if len(keys) == 0 { // if we don't find items we skip
+				continue
+			}
+			f, err := os.Create(path.Join(arg.Path, utils.TaxProfilesCsv))
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			csvWriter := csv.NewWriter(f)
+			csvWriter.Comma = utils.CSVSep
+			//write the header of the file
+			if err := csvWriter.Write(engine.TaxProfileMdls{}.CSVHeader()); err != nil {
+				return err
+			}
+			for _, key := range keys {
+				tntID := strings.SplitN(key[len(prfx):], utils.InInFieldSep, 2)
+				txp, err := apierSv1.DataManager.GetTaxProfile(tntID[0], tntID[1],
+					true, false, utils.NonTransactional)
+				if err != nil {
+					return err
+				}
+				for _, model := range engine.APItoModelTPTaxProfile(
+					engine.TaxProfileToAPI(txp)) {
+					if record, err := engine.CsvDump(model); err != nil {
+						return err
+					} else if err := csvWriter.Write(record); err != nil {
+						return err
+					}
+				}
+			}
+			csvWriter.Flush()
 		case utils.MetaThresholds:
 			prfx := utils.ThresholdProfilePrefix
 			keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx, utils.EmptyString)
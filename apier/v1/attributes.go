@@ -109,7 +109,8 @@ func (apierSv1 *APIerSv1) SetAttributeProfile(ctx *context.Context, alsWrp *engi
 			}
 		}
 	}
-	if err := apierSv1.DataManager.SetAttributeProfile(alsWrp.AttributeProfile, true); err != nil {
+	if err := apierSv1.DataManager.WithAudit(AuditActor(apierSv1.Config, alsWrp.APIOpts),
+		utils.APIerSv1SetAttributeProfile).SetAttributeProfile(alsWrp.AttributeProfile, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheAttributeProfiles and store it in database
@@ -138,7 +139,8 @@ func (apierSv1 *APIerSv1) RemoveAttributeProfile(ctx *context.Context, arg *util
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.RemoveAttributeProfile(tnt, arg.ID, true); err != nil {
+	if err := apierSv1.DataManager.WithAudit(AuditActor(apierSv1.Config, arg.APIOpts),
+		utils.APIerSv1RemoveAttributeProfile).RemoveAttributeProfile(tnt, arg.ID, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheAttributeProfiles and store it in database
@@ -0,0 +1,241 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// AuditActor returns the actor to be recorded on the audit trail. The *actor APIOpt is
+// only trusted with api_auth enabled, when it is overwritten with the authenticated identity
+func AuditActor(cfg *config.CGRConfig, opts map[string]any) string {
+	if !cfg.APIAuthCfg().Enabled {
+		return utils.EmptyString
+	}
+	return utils.IfaceAsString(opts[utils.OptsActor])
+}
+
+// auditTenant validates the arguments and returns the tenant of the audited profile
+func (apierSv1 *APIerSv1) auditTenant(args *utils.ArgsGetAuditRecords) (tnt string, err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ObjectType, utils.ID}); len(missing) != 0 {
+		return utils.EmptyString, utils.NewErrMandatoryIeMissing(missing...)
+	}
+	switch args.ObjectType {
+	case utils.MetaAttributeProfiles, utils.MetaFilters, utils.MetaRouteProfiles:
+		if tnt = args.Tenant; tnt == utils.EmptyString {
+			tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+		}
+	case utils.MetaRatingProfiles: // the tenant is part of the key, ie. *out:cgrates.org:call:1001
+		if splt := utils.SplitConcatenatedKey(args.ID); len(splt) > 1 {
+			tnt = splt[1]
+		}
+		if tnt == utils.EmptyString ||
+			(args.Tenant != utils.EmptyString && args.Tenant != tnt) { // the api_auth populates the Tenant with the one of the caller
+			err = utils.ErrUnauthorizedTenant
+		}
+	default:
+		err = fmt.Errorf("%s:ObjectType:%s", utils.ErrNotImplemented.Error(), args.ObjectType)
+	}
+	return
+}
+
+// GetAuditRecords returns the changes recorded on the audit trail for one profile
+func (apierSv1 *APIerSv1) GetAuditRecords(ctx *context.Context, args *utils.ArgsGetAuditRecords, reply *[]*engine.AuditRecord) error {
+	tnt, err := apierSv1.auditTenant(args)
+	if err != nil {
+		return err
+	}
+	recs, err := apierSv1.DataManager.GetAuditRecords(args.ObjectType, tnt, args.ID)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = recs
+	return nil
+}
+
+// RestoreAuditVersion brings the profile back to the version it had at the given time,
+// removing it if it did not exist back then
+func (apierSv1 *APIerSv1) RestoreAuditVersion(ctx *context.Context, args *utils.ArgsRestoreAuditVersion, reply *string) error {
+	tnt, err := apierSv1.auditTenant(&args.ArgsGetAuditRecords)
+	if err != nil {
+		return err
+	}
+	if args.Time == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.Time)
+	}
+	at, err := utils.ParseTimeDetectLayout(args.Time, apierSv1.Config.GeneralCfg().DefaultTimezone)
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	recs, err := apierSv1.DataManager.GetAuditRecords(args.ObjectType, tnt, args.ID)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	state := engine.AuditState(recs, at)
+	dm := apierSv1.DataManager.WithAudit(AuditActor(apierSv1.Config, args.APIOpts),
+		utils.APIerSv1RestoreAuditVersion)
+	switch args.ObjectType {
+	case utils.MetaAttributeProfiles:
+		err = apierSv1.restoreAttributeProfile(dm, tnt, args, state)
+	case utils.MetaFilters:
+		err = apierSv1.restoreFilter(dm, tnt, args, state)
+	case utils.MetaRouteProfiles:
+		err = apierSv1.restoreRouteProfile(dm, tnt, args, state)
+	case utils.MetaRatingProfiles:
+		err = apierSv1.restoreRatingProfile(dm, tnt, args, state)
+	}
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+func (apierSv1 *APIerSv1) restoreAttributeProfile(dm *engine.DataManager, tnt string,
+	args *utils.ArgsRestoreAuditVersion, state json.RawMessage) (err error) {
+	var fltrIDs, contexts []string
+	if old, err := dm.GetAttributeProfile(tnt, args.ID, true, false, utils.NonTransactional); err == nil {
+		fltrIDs, contexts = old.FilterIDs, old.Contexts
+	} else if err != utils.ErrNotFound {
+		return err
+	}
+	if state == nil {
+		if err = dm.RemoveAttributeProfile(tnt, args.ID, true); err != nil {
+			return
+		}
+	} else {
+		var attrPrf engine.AttributeProfile
+		if err = json.Unmarshal(state, &attrPrf); err != nil {
+			return
+		}
+		if err = attrPrf.Compile(); err != nil {
+			return
+		}
+		if err = dm.SetAttributeProfile(&attrPrf, true); err != nil {
+			return
+		}
+		fltrIDs = append(fltrIDs, attrPrf.FilterIDs...)
+		contexts = append(contexts, attrPrf.Contexts...)
+	}
+	if err = dm.SetLoadIDs(map[string]int64{utils.CacheAttributeProfiles: time.Now().UnixNano()}); err != nil {
+		return
+	}
+	return apierSv1.CallCache(utils.IfaceAsString(args.APIOpts[utils.CacheOpt]), tnt, utils.CacheAttributeProfiles,
+		utils.ConcatenatedKey(tnt, args.ID), utils.EmptyString, &fltrIDs, contexts, args.APIOpts)
+}
+
+func (apierSv1 *APIerSv1) restoreFilter(dm *engine.DataManager, tnt string,
+	args *utils.ArgsRestoreAuditVersion, state json.RawMessage) (err error) {
+	tntID := utils.ConcatenatedKey(tnt, args.ID)
+	argC := map[string][]string{utils.CacheFilters: {tntID}}
+	if fltr, err := dm.GetFilter(tnt, args.ID, true, false, utils.NonTransactional); err != nil {
+		if err != utils.ErrNotFound {
+			return err
+		}
+	} else if argC, err = composeCacheArgsForFilter(dm, fltr, tnt, tntID, argC); err != nil {
+		return err
+	}
+	if state == nil {
+		if err = dm.RemoveFilter(tnt, args.ID, true); err != nil {
+			return
+		}
+	} else {
+		var fltr engine.Filter
+		if err = json.Unmarshal(state, &fltr); err != nil {
+			return
+		}
+		if err = fltr.Compile(); err != nil {
+			return
+		}
+		if err = dm.SetFilter(&fltr, true); err != nil {
+			return
+		}
+		if argC, err = composeCacheArgsForFilter(dm, &fltr, tnt, tntID, argC); err != nil {
+			return
+		}
+	}
+	if err = dm.SetLoadIDs(map[string]int64{utils.CacheFilters: time.Now().UnixNano()}); err != nil {
+		return
+	}
+	return callCacheForFilter(apierSv1.ConnMgr, apierSv1.Config.ApierCfg().CachesConns,
+		utils.IfaceAsString(args.APIOpts[utils.CacheOpt]),
+		apierSv1.Config.GeneralCfg().DefaultCaching,
+		tnt, argC, args.APIOpts)
+}
+
+func (apierSv1 *APIerSv1) restoreRouteProfile(dm *engine.DataManager, tnt string,
+	args *utils.ArgsRestoreAuditVersion, state json.RawMessage) (err error) {
+	var fltrIDs []string
+	if old, err := dm.GetRouteProfile(tnt, args.ID, true, false, utils.NonTransactional); err == nil {
+		fltrIDs = old.FilterIDs
+	} else if err != utils.ErrNotFound {
+		return err
+	}
+	if state == nil {
+		if err = dm.RemoveRouteProfile(tnt, args.ID, true); err != nil {
+			return
+		}
+	} else {
+		var rtPrf engine.RouteProfile
+		if err = json.Unmarshal(state, &rtPrf); err != nil {
+			return
+		}
+		if err = rtPrf.Compile(); err != nil {
+			return
+		}
+		if err = dm.SetRouteProfile(&rtPrf, true); err != nil {
+			return
+		}
+		fltrIDs = append(fltrIDs, rtPrf.FilterIDs...)
+	}
+	if err = dm.SetLoadIDs(map[string]int64{utils.CacheRouteProfiles: time.Now().UnixNano()}); err != nil {
+		return
+	}
+	return apierSv1.CallCache(utils.IfaceAsString(args.APIOpts[utils.CacheOpt]), tnt, utils.CacheRouteProfiles,
+		utils.ConcatenatedKey(tnt, args.ID), utils.EmptyString, &fltrIDs, nil, args.APIOpts)
+}
+
+func (apierSv1 *APIerSv1) restoreRatingProfile(dm *engine.DataManager, tnt string,
+	args *utils.ArgsRestoreAuditVersion, state json.RawMessage) (err error) {
+	if state == nil {
+		if err = dm.RemoveRatingProfile(args.ID); err != nil {
+			return
+		}
+	} else {
+		var rpf engine.RatingProfile
+		if err = json.Unmarshal(state, &rpf); err != nil {
+			return
+		}
+		if err = dm.SetRatingProfile(&rpf); err != nil {
+			return
+		}
+	}
+	if err = dm.SetLoadIDs(map[string]int64{utils.CacheRatingProfiles: time.Now().UnixNano()}); err != nil {
+		return
+	}
+	return apierSv1.CallCache(utils.IfaceAsString(args.APIOpts[utils.CacheOpt]), tnt, utils.CacheRatingProfiles,
+		args.ID, utils.EmptyString, nil, nil, args.APIOpts)
+}
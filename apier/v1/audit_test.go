@@ -0,0 +1,230 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newAuditTestAPIerSv1(t *testing.T) *APIerSv1 {
+	t.Helper()
+	engine.Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	cfg.GeneralCfg().DefaultCaching = utils.MetaNone
+	cfg.AuditCfg().Enabled = true
+	cfg.APIAuthCfg().Enabled = true
+	config.SetCgrConfig(cfg)
+	t.Cleanup(func() { config.SetCgrConfig(config.NewDefaultCGRConfig()) })
+	dataDB, err := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &APIerSv1{
+		Config:      cfg,
+		DataManager: engine.NewDataManager(dataDB, cfg.CacheCfg(), nil),
+	}
+}
+
+func TestAuditRestoreAttributeProfile(t *testing.T) {
+	apierSv1 := newAuditTestAPIerSv1(t)
+	attrPrf := &engine.AttributeProfileWithAPIOpts{
+		AttributeProfile: &engine.AttributeProfile{
+			Tenant:    "cgrates.org",
+			ID:        "ATTR1",
+			Contexts:  []string{utils.MetaAny},
+			FilterIDs: []string{"*string:~*req.Account:1001"},
+			Attributes: []*engine.Attribute{{
+				Path:  utils.MetaReq + utils.NestingSep + utils.Subject,
+				Value: config.NewRSRParsersMustCompile("1001", utils.InfieldSep),
+			}},
+			Weight: 10,
+		},
+		APIOpts: map[string]any{utils.OptsActor: "admin"},
+	}
+	var reply string
+	if err := apierSv1.SetAttributeProfile(context.Background(), attrPrf, &reply); err != nil {
+		t.Fatal(err)
+	}
+	firstVersion := time.Now()
+	attrPrf = &engine.AttributeProfileWithAPIOpts{ // the internal DB keeps the previous pointer
+		AttributeProfile: attrPrf.AttributeProfile.Clone(),
+		APIOpts:          attrPrf.APIOpts,
+	}
+	attrPrf.FilterIDs = []string{"*string:~*req.Account:1002"}
+	attrPrf.Weight = 20
+	if err := apierSv1.SetAttributeProfile(context.Background(), attrPrf, &reply); err != nil {
+		t.Fatal(err)
+	}
+
+	var recs []*engine.AuditRecord
+	args := utils.ArgsGetAuditRecords{ObjectType: utils.MetaAttributeProfiles, ID: "ATTR1"}
+	if err := apierSv1.GetAuditRecords(context.Background(), &args, &recs); err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 2 {
+		t.Fatalf("expected 2 records, received %s", utils.ToJSON(recs))
+	}
+	if recs[1].Actor != "admin" || recs[1].Method != utils.APIerSv1SetAttributeProfile ||
+		len(recs[1].Changes) != 2 {
+		t.Errorf("unexpected record: %s", utils.ToJSON(recs[1]))
+	}
+
+	if err := apierSv1.RestoreAuditVersion(context.Background(), &utils.ArgsRestoreAuditVersion{
+		ArgsGetAuditRecords: args,
+		Time:                firstVersion.Format(time.RFC3339Nano),
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	rcv, err := apierSv1.DataManager.GetAttributeProfile("cgrates.org", "ATTR1", false, false, utils.NonTransactional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv.Weight != 10 || !reflect.DeepEqual([]string{"*string:~*req.Account:1001"}, rcv.FilterIDs) {
+		t.Errorf("expected the first version, received %s", utils.ToJSON(rcv))
+	}
+	idxKey := utils.ConcatenatedKey("cgrates.org", utils.MetaAny)
+	if idx, err := apierSv1.DataManager.GetIndexes(utils.CacheAttributeFilterIndexes, idxKey,
+		false, false, "*string:*req.Account:1001"); err != nil {
+		t.Error(err)
+	} else if !idx["*string:*req.Account:1001"].Has("ATTR1") {
+		t.Errorf("expected the index restored, received %v", idx)
+	}
+	if _, err := apierSv1.DataManager.GetIndexes(utils.CacheAttributeFilterIndexes, idxKey,
+		false, false, "*string:*req.Account:1002"); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err := apierSv1.GetAuditRecords(context.Background(), &args, &recs); err != nil {
+		t.Fatal(err)
+	} else if len(recs) != 3 || recs[2].Method != utils.APIerSv1RestoreAuditVersion {
+		t.Errorf("expected the restore recorded, received %s", utils.ToJSON(recs))
+	}
+
+	// before the profile existed
+	if err := apierSv1.RestoreAuditVersion(context.Background(), &utils.ArgsRestoreAuditVersion{
+		ArgsGetAuditRecords: args,
+		Time:                recs[0].Time.Add(-time.Second).Format(time.RFC3339Nano),
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apierSv1.DataManager.GetAttributeProfile("cgrates.org", "ATTR1", false, false,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestAuditRestoreRatingProfile(t *testing.T) {
+	apierSv1 := newAuditTestAPIerSv1(t)
+	rpf := &engine.RatingProfile{
+		Id: "*out:cgrates.org:call:1001",
+		RatingPlanActivations: engine.RatingPlanActivations{{
+			ActivationTime: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			RatingPlanId:   "RP1",
+		}},
+	}
+	if err := apierSv1.DataManager.SetRatingProfile(rpf); err != nil {
+		t.Fatal(err)
+	}
+	var reply string
+	if err := apierSv1.RemoveRatingProfile(context.Background(), &AttrRemoveRatingProfile{
+		Tenant: "cgrates.org", Category: "call", Subject: "1001"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	args := utils.ArgsGetAuditRecords{ObjectType: utils.MetaRatingProfiles, ID: rpf.Id}
+	var recs []*engine.AuditRecord
+	if err := apierSv1.GetAuditRecords(context.Background(), &args, &recs); err != nil {
+		t.Fatal(err)
+	} else if len(recs) != 2 || recs[1].Action != utils.MetaRemove || recs[1].Tenant != "cgrates.org" {
+		t.Fatalf("unexpected records: %s", utils.ToJSON(recs))
+	}
+	if err := apierSv1.RestoreAuditVersion(context.Background(), &utils.ArgsRestoreAuditVersion{
+		ArgsGetAuditRecords: args,
+		Time:                recs[0].Time.Format(time.RFC3339Nano),
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := apierSv1.DataManager.GetRatingProfile(rpf.Id, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(rpf, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(rpf), utils.ToJSON(rcv))
+	}
+}
+
+func TestAuditGetRecordsErrors(t *testing.T) {
+	apierSv1 := newAuditTestAPIerSv1(t)
+	var recs []*engine.AuditRecord
+	if err := apierSv1.GetAuditRecords(context.Background(), &utils.ArgsGetAuditRecords{ID: "ATTR1"},
+		&recs); err == nil || err.Error() != utils.NewErrMandatoryIeMissing(utils.ObjectType).Error() {
+		t.Errorf("expected missing ObjectType, received %v", err)
+	}
+	if err := apierSv1.GetAuditRecords(context.Background(), &utils.ArgsGetAuditRecords{
+		ObjectType: utils.MetaResourceProfile, ID: "RES1"}, &recs); err == nil {
+		t.Error("expected error for the unsupported object type")
+	}
+	if err := apierSv1.GetAuditRecords(context.Background(), &utils.ArgsGetAuditRecords{
+		ObjectType: utils.MetaFilters, ID: "FLTR1"}, &recs); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	var reply string
+	if err := apierSv1.RestoreAuditVersion(context.Background(), &utils.ArgsRestoreAuditVersion{
+		ArgsGetAuditRecords: utils.ArgsGetAuditRecords{ObjectType: utils.MetaFilters, ID: "FLTR1"},
+	}, &reply); err == nil || err.Error() != utils.NewErrMandatoryIeMissing(utils.Time).Error() {
+		t.Errorf("expected missing Time, received %v", err)
+	}
+	for _, id := range []string{"*out:cgrates.net:call:1001", "1001"} {
+		if err := apierSv1.GetAuditRecords(context.Background(), &utils.ArgsGetAuditRecords{
+			ObjectType: utils.MetaRatingProfiles, Tenant: "cgrates.org", ID: id},
+			&recs); err != utils.ErrUnauthorizedTenant {
+			t.Errorf("expected %v for %q, received %v", utils.ErrUnauthorizedTenant, id, err)
+		}
+	}
+}
+
+func TestAuditActorWithoutAPIAuth(t *testing.T) {
+	apierSv1 := newAuditTestAPIerSv1(t)
+	apierSv1.Config.APIAuthCfg().Enabled = false
+	var reply string
+	if err := apierSv1.SetFilter(context.Background(), &engine.FilterWithAPIOpts{
+		Filter: &engine.Filter{
+			Tenant: "cgrates.org",
+			ID:     "FLTR1",
+			Rules: []*engine.FilterRule{{
+				Type:    utils.MetaString,
+				Element: "~*req.Account",
+				Values:  []string{"1001"},
+			}},
+		},
+		APIOpts: map[string]any{utils.OptsActor: "admin"},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	var recs []*engine.AuditRecord
+	if err := apierSv1.GetAuditRecords(context.Background(), &utils.ArgsGetAuditRecords{
+		ObjectType: utils.MetaFilters, ID: "FLTR1"}, &recs); err != nil {
+		t.Fatal(err)
+	} else if len(recs) != 1 || recs[0].Actor != utils.EmptyString {
+		t.Errorf("expected the actor ignored, received %s", utils.ToJSON(recs))
+	}
+}
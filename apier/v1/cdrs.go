@@ -115,6 +115,11 @@ func (cdrSv1 *CDRsV1) RateCDRs(ctx *context.Context, arg *engine.ArgRateCDRs, re
 	return cdrSv1.CDRs.V1RateCDRs(ctx, arg, reply)
 }
 
+// SimulateRateCDRs rates the CDRs against a new tariff plan without changing the live data
+func (cdrSv1 *CDRsV1) SimulateRateCDRs(ctx *context.Context, arg *engine.ArgSimulateRateCDRs, reply *engine.RateCDRsSimulation) error {
+	return cdrSv1.CDRs.V1SimulateRateCDRs(ctx, arg, reply)
+}
+
 // ReprocessCDRs can reprocess remotely CDRs
 func (cdrSv1 *CDRsV1) ReprocessCDRs(ctx *context.Context, arg *engine.ArgRateCDRs, reply *string) error {
 	return cdrSv1.CDRs.V1ReprocessCDRs(ctx, arg, reply)
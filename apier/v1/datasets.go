@@ -0,0 +1,177 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// GetDataSet returns a DataSet
+func (apierSv1 *APIerSv1) GetDataSet(ctx *context.Context, arg *utils.TenantID, reply *engine.DataSet) (err error) {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	ds, err := apierSv1.DataManager.GetDataSet(tnt, arg.ID, true, true, utils.NonTransactional)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = *ds
+	return
+}
+
+// GetDataSetIDs returns list of DataSet IDs registered for a tenant
+func (apierSv1 *APIerSv1) GetDataSetIDs(ctx *context.Context, args *utils.PaginatorWithTenant, dsIDs *[]string) (err error) {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	prfx := utils.DataSetPrefix + tnt + utils.ConcatenatedKeySep
+	keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx, args.Search)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return utils.ErrNotFound
+	}
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		ids[i] = key[len(prfx):]
+	}
+	*dsIDs = args.PaginateStringSlice(ids)
+	return
+}
+
+// SetDataSet creates a DataSet or replaces all the entries of an existing one
+func (apierSv1 *APIerSv1) SetDataSet(ctx *context.Context, arg *engine.DataSetEntriesWithAPIOpts, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if arg.Tenant == utils.EmptyString {
+		arg.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	ds := engine.NewDataSet(arg.Tenant, arg.ID)
+	ds.SetEntries(arg.Entries)
+	if err := apierSv1.guardDataSet(arg.Tenant, arg.ID, func() error {
+		return apierSv1.DataManager.SetDataSet(ds)
+	}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if err := apierSv1.callDataSetCache(utils.APIerSv1SetDataSet, arg.Tenant, arg.ID, arg.APIOpts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// AddDataSetEntries adds the entries to a DataSet, creating it if missing
+func (apierSv1 *APIerSv1) AddDataSetEntries(ctx *context.Context, arg *engine.DataSetEntriesWithAPIOpts, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if len(arg.Entries) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.Entries)
+	}
+	if arg.Tenant == utils.EmptyString {
+		arg.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	if err := apierSv1.guardDataSet(arg.Tenant, arg.ID, func() error {
+		return apierSv1.DataManager.AddDataSetEntries(arg.Tenant, arg.ID, arg.Entries)
+	}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if err := apierSv1.callDataSetCache(utils.APIerSv1AddDataSetEntries, arg.Tenant, arg.ID, arg.APIOpts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// RemoveDataSetEntries removes the entries from an existing DataSet
+func (apierSv1 *APIerSv1) RemoveDataSetEntries(ctx *context.Context, arg *engine.DataSetEntriesWithAPIOpts, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if len(arg.Entries) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.Entries)
+	}
+	if arg.Tenant == utils.EmptyString {
+		arg.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	if err := apierSv1.guardDataSet(arg.Tenant, arg.ID, func() error {
+		return apierSv1.DataManager.RemoveDataSetEntries(arg.Tenant, arg.ID, arg.Entries)
+	}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if err := apierSv1.callDataSetCache(utils.APIerSv1RemoveDataSetEntries, arg.Tenant, arg.ID, arg.APIOpts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// RemoveDataSet removes a DataSet with all its entries
+func (apierSv1 *APIerSv1) RemoveDataSet(ctx *context.Context, args *utils.TenantIDWithAPIOpts, reply *string) error {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	if err := apierSv1.guardDataSet(tnt, args.ID, func() error {
+		return apierSv1.DataManager.RemoveDataSet(tnt, args.ID)
+	}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if err := apierSv1.callDataSetCache(utils.APIerSv1RemoveDataSet, tnt, args.ID, args.APIOpts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// guardDataSet locks the DataSet while being updated in DataDB
+func (apierSv1 *APIerSv1) guardDataSet(tnt, id string, update func() error) error {
+	return guardian.Guardian.Guard(update, apierSv1.Config.GeneralCfg().LockingTimeout,
+		utils.DataSetPrefix+utils.ConcatenatedKey(tnt, id))
+}
+
+// callDataSetCache stores a new loadID for DataSets and handles the caching of the modified DataSet
+func (apierSv1 *APIerSv1) callDataSetCache(apiMethod, tnt, id string, opts map[string]any) (err error) {
+	if err = apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheDataSets: time.Now().UnixNano()}); err != nil {
+		return
+	}
+	// delay if needed before cache call
+	if apierSv1.Config.GeneralCfg().CachingDelay != 0 {
+		utils.Logger.Info(fmt.Sprintf("<%s> Delaying cache call for %v", apiMethod, apierSv1.Config.GeneralCfg().CachingDelay))
+		time.Sleep(apierSv1.Config.GeneralCfg().CachingDelay)
+	}
+	return apierSv1.CallCache(utils.IfaceAsString(opts[utils.CacheOpt]), tnt, utils.CacheDataSets,
+		utils.ConcatenatedKey(tnt, id), utils.EmptyString, nil, nil, opts)
+}
@@ -0,0 +1,148 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newDataSetTestAPIerSv1(t *testing.T) *APIerSv1 {
+	t.Helper()
+	engine.Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	cfg.GeneralCfg().DefaultCaching = utils.MetaNone
+	dataDB, err := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { engine.Cache.Clear(nil) })
+	return &APIerSv1{
+		Config:      cfg,
+		DataManager: engine.NewDataManager(dataDB, cfg.CacheCfg(), nil),
+	}
+}
+
+func TestDataSetsAPIs(t *testing.T) {
+	apierSv1 := newDataSetTestAPIerSv1(t)
+	var reply string
+	if err := apierSv1.SetDataSet(context.Background(), &engine.DataSetEntriesWithAPIOpts{},
+		&reply); err == nil || err.Error() != utils.NewErrMandatoryIeMissing(utils.ID).Error() {
+		t.Errorf("expected mandatory ID error, received %v", err)
+	}
+	if err := apierSv1.SetDataSet(context.Background(), &engine.DataSetEntriesWithAPIOpts{
+		ID: "BLOCKLIST",
+		Entries: []*engine.DataSetEntry{
+			{Value: "1001", Metadata: map[string]string{"Reason": "fraud"}},
+			{Value: "+4986", Prefix: true},
+		},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := apierSv1.AddDataSetEntries(context.Background(), &engine.DataSetEntriesWithAPIOpts{
+		Tenant:  "cgrates.org",
+		ID:      "BLOCKLIST",
+		Entries: []*engine.DataSetEntry{{Value: "1002"}, {Value: "+40", Prefix: true}},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := apierSv1.RemoveDataSetEntries(context.Background(), &engine.DataSetEntriesWithAPIOpts{
+		ID:      "BLOCKLIST",
+		Entries: []*engine.DataSetEntry{{Value: "+4986", Prefix: true}},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	exp := engine.DataSet{
+		Tenant: "cgrates.org",
+		ID:     "BLOCKLIST",
+		Exact: map[string]map[string]string{
+			"1001": {"Reason": "fraud"},
+			"1002": nil,
+		},
+		Prefixes: map[string]map[string]string{"+40": nil},
+	}
+	var ds engine.DataSet
+	if err := apierSv1.GetDataSet(context.Background(), &utils.TenantID{ID: "BLOCKLIST"}, &ds); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, ds) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(ds))
+	}
+	// incremental adds create missing sets while removes do not
+	if err := apierSv1.AddDataSetEntries(context.Background(), &engine.DataSetEntriesWithAPIOpts{
+		ID:      "ALLOWLIST",
+		Entries: []*engine.DataSetEntry{{Value: "1003"}},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := apierSv1.RemoveDataSetEntries(context.Background(), &engine.DataSetEntriesWithAPIOpts{
+		ID:      "MISSING",
+		Entries: []*engine.DataSetEntry{{Value: "1003"}},
+	}, &reply); err == nil || err.Error() != utils.ErrNotFound.Error() {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err := apierSv1.AddDataSetEntries(context.Background(), &engine.DataSetEntriesWithAPIOpts{
+		ID: "ALLOWLIST",
+	}, &reply); err == nil || err.Error() != utils.NewErrMandatoryIeMissing(utils.Entries).Error() {
+		t.Errorf("expected mandatory Entries error, received %v", err)
+	}
+	var ids []string
+	if err := apierSv1.GetDataSetIDs(context.Background(), &utils.PaginatorWithTenant{}, &ids); err != nil {
+		t.Error(err)
+	} else if len(ids) != 2 {
+		t.Errorf("expected 2 data sets, received %v", ids)
+	}
+	if err := apierSv1.RemoveDataSet(context.Background(), &utils.TenantIDWithAPIOpts{
+		TenantID: &utils.TenantID{ID: "BLOCKLIST"}}, &reply); err != nil {
+		t.Error(err)
+	}
+	engine.Cache.Clear(nil) // no CacheS behind the API
+	if err := apierSv1.GetDataSet(context.Background(), &utils.TenantID{ID: "BLOCKLIST"},
+		&ds); err == nil || err.Error() != utils.ErrNotFound.Error() {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestDataSetsAddEntriesKeepsCache(t *testing.T) {
+	apierSv1 := newDataSetTestAPIerSv1(t)
+	var reply string
+	if err := apierSv1.SetDataSet(context.Background(), &engine.DataSetEntriesWithAPIOpts{
+		ID:      "BLOCKLIST",
+		Entries: []*engine.DataSetEntry{{Value: "1001"}},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	cached, err := apierSv1.DataManager.GetDataSet("cgrates.org", "BLOCKLIST", true, true, utils.NonTransactional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := apierSv1.AddDataSetEntries(context.Background(), &engine.DataSetEntriesWithAPIOpts{
+		ID:      "BLOCKLIST",
+		Entries: []*engine.DataSetEntry{{Value: "1002"}},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if _, has := cached.Exact["1002"]; has {
+		t.Error("the cached data set was modified in place")
+	}
+}
@@ -899,6 +899,12 @@ func (dSv1 DispatcherSv1) GetProfilesForEvent(ctx *context.Context, ev *utils.CG
 	return dSv1.dS.DispatcherSv1GetProfilesForEvent(ctx, ev, dPrfl)
 }
 
+// GetHostsStats returns the host metrics collected by the *least_conns and *least_latency strategies
+func (dSv1 DispatcherSv1) GetHostsStats(ctx *context.Context, args *utils.TenantIDWithAPIOpts,
+	reply *map[string]*dispatchers.HostStats) error {
+	return dSv1.dS.DispatcherSv1GetHostsStats(ctx, args, reply)
+}
+
 func (dS *DispatcherSv1) RemoteStatus(ctx *context.Context, args *cores.V1StatusParams, reply *map[string]any) (err error) {
 	return dS.dS.DispatcherSv1RemoteStatus(ctx, args, reply)
 }
@@ -952,6 +958,10 @@ func (dS *DispatcherSCDRsV1) RateCDRs(ctx *context.Context, args *engine.ArgRate
 	return dS.dS.CDRsV1RateCDRs(ctx, args, reply)
 }
 
+func (dS *DispatcherSCDRsV1) SimulateRateCDRs(ctx *context.Context, args *engine.ArgSimulateRateCDRs, reply *engine.RateCDRsSimulation) error {
+	return dS.dS.CDRsV1SimulateRateCDRs(ctx, args, reply)
+}
+
 func (dS *DispatcherSCDRsV1) ProcessExternalCDR(ctx *context.Context, args *engine.ExternalCDRWithAPIOpts, reply *string) error {
 	return dS.dS.CDRsV1ProcessExternalCDR(ctx, args, reply)
 }
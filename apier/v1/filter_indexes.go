@@ -66,6 +66,8 @@ func (apierSv1 *APIerSv1) RemoveFilterIndexes(ctx *context.Context, arg *AttrRem
 		arg.ItemType = utils.CacheResourceFilterIndexes
 	case utils.MetaChargers:
 		arg.ItemType = utils.CacheChargerFilterIndexes
+	case utils.MetaTaxProfiles:
+		arg.ItemType = utils.CacheTaxProfileFilterIndexes
 	case utils.MetaDispatchers:
 		if missing := utils.MissingStructFields(arg, []string{"Context"}); len(missing) != 0 { //Params missing
 			return utils.NewErrMandatoryIeMissing(missing...)
@@ -117,6 +119,8 @@ func (apierSv1 *APIerSv1) GetFilterIndexes(ctx *context.Context, arg *AttrGetFil
 		arg.ItemType = utils.CacheResourceFilterIndexes
 	case utils.MetaChargers:
 		arg.ItemType = utils.CacheChargerFilterIndexes
+	case utils.MetaTaxProfiles:
+		arg.ItemType = utils.CacheTaxProfileFilterIndexes
 	case utils.MetaDispatchers:
 		if missing := utils.MissingStructFields(arg, []string{"Context"}); len(missing) != 0 { //Params missing
 			return utils.NewErrMandatoryIeMissing(missing...)
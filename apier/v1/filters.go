@@ -43,7 +43,8 @@ func (apierSv1 *APIerSv1) SetFilter(ctx *context.Context, arg *engine.FilterWith
 	} else if argC, err = composeCacheArgsForFilter(apierSv1.DataManager, fltr, fltr.Tenant, tntID, argC); err != nil {
 		return utils.APIErrorHandler(err)
 	}
-	if err := apierSv1.DataManager.SetFilter(arg.Filter, true); err != nil {
+	if err := apierSv1.DataManager.WithAudit(AuditActor(apierSv1.Config, arg.APIOpts),
+		utils.APIerSv1SetFilter).SetFilter(arg.Filter, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 
@@ -113,7 +114,8 @@ func (apierSv1 *APIerSv1) RemoveFilter(ctx *context.Context, arg *utils.TenantID
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.RemoveFilter(tnt, arg.ID, true); err != nil {
+	if err := apierSv1.DataManager.WithAudit(AuditActor(apierSv1.Config, arg.APIOpts),
+		utils.APIerSv1RemoveFilter).RemoveFilter(tnt, arg.ID, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheFilters and store it in database
@@ -0,0 +1,55 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewFraudSv1 initializes FraudSv1
+func NewFraudSv1(fS *engine.FraudS) *FraudSv1 {
+	return &FraudSv1{fS: fS}
+}
+
+// FraudSv1 exports the RPC methods of FraudS
+type FraudSv1 struct {
+	fS *engine.FraudS
+}
+
+// ScoreEvent scores the event as a new session of the account, without recording it
+func (fSv1 *FraudSv1) ScoreEvent(ctx *context.Context, args *utils.CGREvent, reply *engine.FraudScore) error {
+	return fSv1.fS.V1ScoreEvent(ctx, args, reply)
+}
+
+// ProcessEvent scores the event and records it as an active session of the account
+func (fSv1 *FraudSv1) ProcessEvent(ctx *context.Context, args *utils.CGREvent, reply *engine.FraudScore) error {
+	return fSv1.fS.V1ProcessEvent(ctx, args, reply)
+}
+
+// ReleaseEvent ends the session of the account, recording its cost
+func (fSv1 *FraudSv1) ReleaseEvent(ctx *context.Context, args *utils.CGREvent, reply *string) error {
+	return fSv1.fS.V1ReleaseEvent(ctx, args, reply)
+}
+
+// GetAccountBaselines returns the indicators of an account compared with their baselines
+func (fSv1 *FraudSv1) GetAccountBaselines(ctx *context.Context, args *utils.TenantIDWithAPIOpts, reply *[]*engine.FraudBaselines) error {
+	return fSv1.fS.V1GetAccountBaselines(ctx, args, reply)
+}
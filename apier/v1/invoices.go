@@ -0,0 +1,55 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewInvoiceSv1 initializes InvoiceSv1
+func NewInvoiceSv1(invS *engine.InvoiceS) *InvoiceSv1 {
+	return &InvoiceSv1{invS: invS}
+}
+
+// InvoiceSv1 exports the RPC methods of InvoiceS
+type InvoiceSv1 struct {
+	invS *engine.InvoiceS
+}
+
+// GenerateInvoice issues the invoice of an account for the given period
+func (invSv1 *InvoiceSv1) GenerateInvoice(ctx *context.Context, args *utils.ArgsGenerateInvoice, reply *engine.Invoice) error {
+	return invSv1.invS.V1GenerateInvoice(ctx, args, reply)
+}
+
+// GetInvoice returns an issued invoice
+func (invSv1 *InvoiceSv1) GetInvoice(ctx *context.Context, args *utils.TenantIDWithAPIOpts, reply *engine.Invoice) error {
+	return invSv1.invS.V1GetInvoice(ctx, args, reply)
+}
+
+// GetInvoices returns the invoices issued for an account
+func (invSv1 *InvoiceSv1) GetInvoices(ctx *context.Context, args *utils.ArgsGetInvoices, reply *[]*engine.Invoice) error {
+	return invSv1.invS.V1GetInvoices(ctx, args, reply)
+}
+
+// RenderInvoice returns the document of an issued invoice in the requested format
+func (invSv1 *InvoiceSv1) RenderInvoice(ctx *context.Context, args *utils.ArgsRenderInvoice, reply *string) error {
+	return invSv1.invS.V1RenderInvoice(ctx, args, reply)
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/cgrates/birpc/context"
 	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/guardian"
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -142,6 +143,28 @@ func (rplSv1 *ReplicatorSv1) GetRankingProfile(ctx *context.Context, tntID *util
 	return nil
 }
 
+// GetDataSet is the remote method coresponding to the dataDb driver method
+func (rplSv1 *ReplicatorSv1) GetDataSet(ctx *context.Context, tntID *utils.TenantIDWithAPIOpts, reply *engine.DataSet) error {
+	engine.UpdateReplicationFilters(utils.DataSetPrefix, tntID.TenantID.TenantID(), utils.IfaceAsString(tntID.APIOpts[utils.RemoteHostOpt]))
+	rcv, err := rplSv1.dm.DataDB().GetDataSetDrv(tntID.Tenant, tntID.ID)
+	if err != nil {
+		return err
+	}
+	*reply = *rcv
+	return nil
+}
+
+// GetTaxProfile is the remote method coresponding to the dataDb driver method
+func (rplSv1 *ReplicatorSv1) GetTaxProfile(ctx *context.Context, tntID *utils.TenantIDWithAPIOpts, reply *engine.TaxProfile) error {
+	engine.UpdateReplicationFilters(utils.TaxProfilePrefix, tntID.TenantID.TenantID(), utils.IfaceAsString(tntID.APIOpts[utils.RemoteHostOpt]))
+	rcv, err := rplSv1.dm.DataDB().GetTaxProfileDrv(tntID.Tenant, tntID.ID)
+	if err != nil {
+		return err
+	}
+	*reply = *rcv
+	return nil
+}
+
 // GetRanking is the remote method coresponding to the dataDb driver method
 func (rplSv1 *ReplicatorSv1) GetRanking(ctx *context.Context, tntID *utils.TenantIDWithAPIOpts, reply *engine.Ranking) error {
 	engine.UpdateReplicationFilters(utils.RankingPrefix, tntID.TenantID.TenantID(), utils.IfaceAsString(tntID.APIOpts[utils.RemoteHostOpt]))
@@ -518,6 +541,58 @@ func (rplSv1 *ReplicatorSv1) SetRankingProfile(ctx *context.Context, sg *engine.
 	return
 }
 
+// SetTaxProfile is the replication method coresponding to the dataDb driver method
+func (rplSv1 *ReplicatorSv1) SetTaxProfile(ctx *context.Context, txp *engine.TaxProfileWithAPIOpts, reply *string) (err error) {
+	if err = rplSv1.dm.DataDB().SetTaxProfileDrv(txp.TaxProfile); err != nil {
+		return
+	}
+	if err = rplSv1.v1.CallCache(utils.IfaceAsString(txp.APIOpts[utils.CacheOpt]),
+		txp.Tenant, utils.CacheTaxProfiles, txp.TenantID(), utils.EmptyString, &txp.FilterIDs, nil, txp.APIOpts); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// SetDataSet is the replication method coresponding to the dataDb driver method
+func (rplSv1 *ReplicatorSv1) SetDataSet(ctx *context.Context, ds *engine.DataSetWithAPIOpts, reply *string) (err error) {
+	if err = rplSv1.dm.DataDB().SetDataSetDrv(ds.DataSet); err != nil {
+		return
+	}
+	if err = rplSv1.v1.CallCache(utils.IfaceAsString(ds.APIOpts[utils.CacheOpt]),
+		ds.Tenant, utils.CacheDataSets, ds.TenantID(), utils.EmptyString, nil, nil, ds.APIOpts); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// AddDataSetEntries is the replication method coresponding to the dataDb driver method
+func (rplSv1 *ReplicatorSv1) AddDataSetEntries(ctx *context.Context, args *engine.DataSetEntriesWithAPIOpts, reply *string) (err error) {
+	if err = rplSv1.dm.DataDB().AddDataSetEntriesDrv(args.Tenant, args.ID, args.Entries); err != nil {
+		return
+	}
+	if err = rplSv1.v1.CallCache(utils.IfaceAsString(args.APIOpts[utils.CacheOpt]),
+		args.Tenant, utils.CacheDataSets, utils.ConcatenatedKey(args.Tenant, args.ID), utils.EmptyString, nil, nil, args.APIOpts); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// RemoveDataSetEntries is the replication method coresponding to the dataDb driver method
+func (rplSv1 *ReplicatorSv1) RemoveDataSetEntries(ctx *context.Context, args *engine.DataSetEntriesWithAPIOpts, reply *string) (err error) {
+	if err = rplSv1.dm.DataDB().RemoveDataSetEntriesDrv(args.Tenant, args.ID, args.Entries); err != nil {
+		return
+	}
+	if err = rplSv1.v1.CallCache(utils.IfaceAsString(args.APIOpts[utils.CacheOpt]),
+		args.Tenant, utils.CacheDataSets, utils.ConcatenatedKey(args.Tenant, args.ID), utils.EmptyString, nil, nil, args.APIOpts); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
 // SetTrendProfile is the replication method coresponding to the dataDb driver method
 func (rplSv1 *ReplicatorSv1) SetTrendProfile(ctx *context.Context, sg *engine.TrendProfileWithAPIOpts, reply *string) (err error) {
 	if err = rplSv1.dm.DataDB().SetTrendProfileDrv(sg.TrendProfile); err != nil {
@@ -869,6 +944,24 @@ func (rplSv1 *ReplicatorSv1) SetBackupSessions(ctx *context.Context, args *engin
 	return
 }
 
+// SetInvoice is the replication method coresponding to the dataDB driver method
+func (rplSv1 *ReplicatorSv1) SetInvoice(ctx *context.Context, args *engine.InvoiceWithAPIOpts, reply *string) (err error) {
+	if err = rplSv1.dm.DataDB().SetInvoiceDrv(args.Invoice); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// SetAuditRecord is the replication method coresponding to the dataDB driver method
+func (rplSv1 *ReplicatorSv1) SetAuditRecord(ctx *context.Context, args *engine.AuditRecordWithAPIOpts, reply *string) (err error) {
+	if err = rplSv1.dm.DataDB().SetAuditRecordDrv(args.AuditRecord); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
 // RemoveSessionBackup is the replication method coresponding to the dataDB driver method
 func (rplSv1 *ReplicatorSv1) RemoveSessionBackup(ctx *context.Context, args *engine.RemoveSessionBackupArgs, reply *string) (err error) {
 	if err = rplSv1.dm.DataDB().RemoveSessionsBackupDrv(args.NodeID, args.Tenant, args.CGRID); err != nil {
@@ -990,6 +1083,32 @@ func (rplSv1 *ReplicatorSv1) RemoveRankingProfile(ctx *context.Context, args *ut
 	return
 }
 
+// RemoveTaxProfile is the replication method coresponding to the dataDb driver method
+func (rplSv1 *ReplicatorSv1) RemoveTaxProfile(ctx *context.Context, args *utils.TenantIDWithAPIOpts, reply *string) (err error) {
+	if err = rplSv1.dm.DataDB().RemoveTaxProfileDrv(args.Tenant, args.ID); err != nil {
+		return
+	}
+	if err = rplSv1.v1.CallCache(utils.IfaceAsString(args.APIOpts[utils.CacheOpt]),
+		args.Tenant, utils.CacheTaxProfiles, args.TenantID.TenantID(), utils.EmptyString, nil, nil, args.APIOpts); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// RemoveDataSet is the replication method coresponding to the dataDb driver method
+func (rplSv1 *ReplicatorSv1) RemoveDataSet(ctx *context.Context, args *utils.TenantIDWithAPIOpts, reply *string) (err error) {
+	if err = rplSv1.dm.DataDB().RemoveDataSetDrv(args.Tenant, args.ID); err != nil {
+		return
+	}
+	if err = rplSv1.v1.CallCache(utils.IfaceAsString(args.APIOpts[utils.CacheOpt]),
+		args.Tenant, utils.CacheDataSets, args.TenantID.TenantID(), utils.EmptyString, nil, nil, args.APIOpts); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
 // RemoveRanking is the replication method coresponding to the dataDb driver method
 func (rplSv1 *ReplicatorSv1) RemoveRanking(ctx *context.Context, args *utils.TenantIDWithAPIOpts, reply *string) (err error) {
 	if err = rplSv1.dm.DataDB().RemoveRankingDrv(args.Tenant, args.ID); err != nil {
@@ -1284,3 +1403,54 @@ func (rplSv1 *ReplicatorSv1) RemoveIndexes(ctx *context.Context, args *utils.Get
 	*reply = utils.OK
 	return
 }
+
+// distributedLocker returns the dataDB holding the locks of the replicating engines
+func (rplSv1 *ReplicatorSv1) distributedLocker() (guardian.DistributedLocker, error) {
+	dl, canLock := rplSv1.dm.DataDB().(guardian.DistributedLocker)
+	if !canLock {
+		return nil, utils.ErrNotImplemented
+	}
+	return dl, nil
+}
+
+// TryLock attempts to acquire the distributed lock on behalf of a replicating engine,
+// replying with the lease token or 0 if the lock is held by someone else
+func (rplSv1 *ReplicatorSv1) TryLock(ctx *context.Context, args *utils.ArgsDistributedLock, reply *int64) (err error) {
+	var dl guardian.DistributedLocker
+	if dl, err = rplSv1.distributedLocker(); err != nil {
+		return
+	}
+	var token int64
+	var acquired bool
+	if token, acquired, err = dl.TryLock(args.LockID, args.TTL); err != nil {
+		return
+	}
+	*reply = 0
+	if acquired {
+		*reply = token
+	}
+	return
+}
+
+// RefreshLock extends the distributed lock of a replicating engine, as long as it still holds it
+func (rplSv1 *ReplicatorSv1) RefreshLock(ctx *context.Context, args *utils.ArgsDistributedLock, reply *bool) (err error) {
+	var dl guardian.DistributedLocker
+	if dl, err = rplSv1.distributedLocker(); err != nil {
+		return
+	}
+	*reply, err = dl.RefreshLock(args.LockID, args.Token, args.TTL)
+	return
+}
+
+// Unlock releases the distributed lock of a replicating engine, as long as it still holds it
+func (rplSv1 *ReplicatorSv1) Unlock(ctx *context.Context, args *utils.ArgsDistributedLock, reply *string) (err error) {
+	var dl guardian.DistributedLocker
+	if dl, err = rplSv1.distributedLocker(); err != nil {
+		return
+	}
+	if err = dl.Unlock(args.LockID, args.Token); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
@@ -0,0 +1,76 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ReserveBalance holds an amount on the account balances until it is committed, released
+// or the reservation expires, replying with the reservation ID
+func (apierSv1 *APIerSv1) ReserveBalance(ctx *context.Context, args *utils.ArgsReserveBalance, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.AccountField, utils.BalanceType, utils.ExpiryTime}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if args.Amount <= 0 {
+		return fmt.Errorf("invalid reservation amount: %v", args.Amount)
+	}
+	if args.Tenant == utils.EmptyString {
+		args.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	var rsv *engine.Reservation
+	if rsv, err = engine.ReserveBalance(apierSv1.DataManager, args,
+		apierSv1.Config.GeneralCfg().DefaultTimezone); err != nil {
+		return
+	}
+	*reply = rsv.ID
+	return
+}
+
+// CommitReservation debits the reservation, returning the amount not committed to the balances
+func (apierSv1 *APIerSv1) CommitReservation(ctx *context.Context, args *utils.ArgsReservation, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.AccountField, utils.ReservationID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err = engine.CommitReservation(apierSv1.DataManager,
+		utils.FirstNonEmpty(args.Tenant, apierSv1.Config.GeneralCfg().DefaultTenant),
+		args.Account, args.ReservationID, args.Amount); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// ReleaseReservation returns the entire reserved amount to the balances
+func (apierSv1 *APIerSv1) ReleaseReservation(ctx *context.Context, args *utils.ArgsReservation, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.AccountField, utils.ReservationID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err = engine.ReleaseReservation(apierSv1.DataManager,
+		utils.FirstNonEmpty(args.Tenant, apierSv1.Config.GeneralCfg().DefaultTenant),
+		args.Account, args.ReservationID); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
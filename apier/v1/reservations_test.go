@@ -0,0 +1,110 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAPIerSv1Reservations(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dataDB, err := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm := engine.NewDataManager(dataDB, cfg.CacheCfg(), nil)
+	apierSv1 := &APIerSv1{
+		DataManager: dm,
+		Config:      cfg,
+	}
+	if err = dm.SetAccount(&engine.Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]engine.Balances{
+			utils.MetaMonetary: {{Uuid: "uuid1", ID: "main", Value: 10}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var rsvID string
+	if err = apierSv1.ReserveBalance(context.Background(), &utils.ArgsReserveBalance{
+		Account:     "1001",
+		BalanceType: utils.MetaMonetary,
+		Amount:      4,
+	}, &rsvID); err == nil || err.Error() != utils.NewErrMandatoryIeMissing("ExpiryTime").Error() {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err = apierSv1.ReserveBalance(context.Background(), &utils.ArgsReserveBalance{
+		Account:     "1001",
+		BalanceType: utils.MetaMonetary,
+		ExpiryTime:  "+1h",
+	}, &rsvID); err == nil || err.Error() != "invalid reservation amount: 0" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err = apierSv1.ReserveBalance(context.Background(), &utils.ArgsReserveBalance{
+		Account:     "1001",
+		BalanceType: utils.MetaMonetary,
+		Amount:      4,
+		ExpiryTime:  "+1h",
+	}, &rsvID); err != nil {
+		t.Fatal(err)
+	} else if rsvID == utils.EmptyString {
+		t.Error("expected generated reservation ID")
+	}
+	acc, err := dm.GetAccount("cgrates.org:1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val := acc.BalanceMap[utils.MetaMonetary].GetTotalValue(); val != 6 {
+		t.Errorf("expected 6, received %v", val)
+	}
+
+	var reply string
+	if err = apierSv1.CommitReservation(context.Background(), &utils.ArgsReservation{
+		Account: "1001",
+	}, &reply); err == nil || err.Error() != utils.NewErrMandatoryIeMissing("ReservationID").Error() {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err = apierSv1.CommitReservation(context.Background(), &utils.ArgsReservation{
+		Account:       "1001",
+		ReservationID: rsvID,
+		Amount:        utils.Float64Pointer(1),
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("expected OK, received %s", reply)
+	}
+	if err = apierSv1.ReleaseReservation(context.Background(), &utils.ArgsReservation{
+		Account:       "1001",
+		ReservationID: rsvID,
+	}, &reply); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if acc, err = dm.GetAccount("cgrates.org:1001"); err != nil {
+		t.Fatal(err)
+	}
+	if val := acc.BalanceMap[utils.MetaMonetary].GetTotalValue(); val != 9 {
+		t.Errorf("expected 9, received %v", val)
+	}
+}
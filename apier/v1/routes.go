@@ -74,7 +74,8 @@ func (apierSv1 *APIerSv1) SetRouteProfile(ctx *context.Context, args *engine.Rou
 	if args.Tenant == utils.EmptyString {
 		args.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.SetRouteProfile(args.RouteProfile, true); err != nil {
+	if err := apierSv1.DataManager.WithAudit(AuditActor(apierSv1.Config, args.APIOpts),
+		utils.APIerSv1SetRouteProfile).SetRouteProfile(args.RouteProfile, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheRouteProfiles and store it in database
@@ -104,7 +105,8 @@ func (apierSv1 *APIerSv1) RemoveRouteProfile(ctx *context.Context, args *utils.T
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.RemoveRouteProfile(tnt, args.ID, true); err != nil {
+	if err := apierSv1.DataManager.WithAudit(AuditActor(apierSv1.Config, args.APIOpts),
+		utils.APIerSv1RemoveRouteProfile).RemoveRouteProfile(tnt, args.ID, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheRouteProfiles and store it in database
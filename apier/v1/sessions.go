@@ -171,3 +171,8 @@ func (ssv1 *SessionSv1) RegisterInternalBiJSONConn(ctx *context.Context, args st
 func (ssv1 *SessionSv1) BackupActiveSessions(ctx *context.Context, args string, rply *int) (err error) {
 	return ssv1.sS.BiRPCv1BackupActiveSessions(ctx, args, rply)
 }
+
+// HandoffSessions hands over the active sessions to another SessionS
+func (ssv1 *SessionSv1) HandoffSessions(ctx *context.Context, args *sessions.ArgsHandoffSessions, rply *string) (err error) {
+	return ssv1.sS.BiRPCv1HandoffSessions(ctx, args, rply)
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/cgrates/birpc/context"
+	v1 "github.com/cgrates/cgrates/apier/v1"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
@@ -44,7 +45,8 @@ func (APIerSv2 *APIerSv2) SetAttributeProfile(ctx *context.Context, arg *Attribu
 	if err != nil {
 		return utils.APIErrorHandler(err)
 	}
-	if err := APIerSv2.DataManager.SetAttributeProfile(alsPrf, true); err != nil {
+	if err := APIerSv2.DataManager.WithAudit(v1.AuditActor(APIerSv2.Config, arg.APIOpts),
+		utils.APIerSv2SetAttributeProfile).SetAttributeProfile(alsPrf, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheAttributeProfiles and store it in database
@@ -1891,7 +1891,7 @@ func testConsoleItStordbVersions(t *testing.T) {
 		"TpActionTriggers":    1.,
 		"TpActions":           1.,
 		"TpChargers":          1.,
-		"TpDestinationRates":  1.,
+		"TpDestinationRates":  2.,
 		"TpDestinations":      1.,
 		"TpDispatchers":       1.,
 		"TpFilters":           1.,
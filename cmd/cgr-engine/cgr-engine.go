@@ -435,6 +435,8 @@ func main() {
 	internalStatSChan := make(chan birpc.ClientConnector, 1)
 	internalTrendSChan := make(chan birpc.ClientConnector, 1)
 	internalRankingSChan := make(chan birpc.ClientConnector, 1)
+	internalInvoiceSChan := make(chan birpc.ClientConnector, 1)
+	internalFraudSChan := make(chan birpc.ClientConnector, 1)
 	internalResourceSChan := make(chan birpc.ClientConnector, 1)
 	internalIPsChan := make(chan birpc.ClientConnector, 1)
 	internalRouteSChan := make(chan birpc.ClientConnector, 1)
@@ -467,6 +469,8 @@ func main() {
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes):         internalRouteSChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaTrends):         internalTrendSChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRankings):       internalRankingSChan,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaInvoices):       internalInvoiceSChan,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFraud):          internalFraudSChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds):     internalThresholdSChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaServiceManager): internalServeManagerChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaConfig):         internalConfigChan,
@@ -508,9 +512,12 @@ func main() {
 		utils.SchedulerS:      new(sync.WaitGroup),
 		utils.SessionS:        new(sync.WaitGroup),
 		utils.SIPAgent:        new(sync.WaitGroup),
+		utils.SMPPAgent:       new(sync.WaitGroup),
 		utils.StatS:           new(sync.WaitGroup),
 		utils.TrendS:          new(sync.WaitGroup),
 		utils.RankingS:        new(sync.WaitGroup),
+		utils.InvoiceS:        new(sync.WaitGroup),
+		utils.FraudS:          new(sync.WaitGroup),
 		utils.StorDB:          new(sync.WaitGroup),
 		utils.ThresholdS:      new(sync.WaitGroup),
 		utils.AccountS:        new(sync.WaitGroup),
@@ -538,6 +545,11 @@ func main() {
 
 	// Rpc/http server
 	server := cores.NewServer(caps)
+	server.SetAPIAuth(cores.NewAPIAuth(cfg))
+	if cfg.HTTPCfg().BiRPCWSURL != "" {
+		server.RegisterBiRPCWebSocket(cfg.HTTPCfg().BiRPCWSURL,
+			cfg.HTTPCfg().BiRPCWSPing, cfg.HTTPCfg().BiRPCWSOrigins, cfg.HTTPCfg().BiRPCWSReadLimit)
+	}
 	if len(cfg.HTTPCfg().RegistrarSURL) != 0 {
 		server.RegisterHttpFunc(cfg.HTTPCfg().RegistrarSURL, registrarc.Registrar)
 	}
@@ -550,6 +562,7 @@ func main() {
 
 	// init AnalyzerS
 	anz := services.NewAnalyzerService(cfg, server, filterSChan, shdChan, internalAnalyzerSChan, srvDep)
+	anz.SetConnManager(connManager)
 	if anz.ShouldRun() {
 		shdWg.Add(1)
 		if err := anz.Start(); err != nil {
@@ -620,9 +633,16 @@ func main() {
 	ldrs := services.NewLoaderService(cfg, dmService, filterSChan, server,
 		internalLoaderSChan, connManager, anz, srvDep)
 
+	invS := services.NewInvoiceService(cfg, dmService, server,
+		internalInvoiceSChan, connManager, anz, srvDep)
+
+	fraudS := services.NewFraudService(cfg, filterSChan, server,
+		internalFraudSChan, connManager, anz, srvDep)
+
 	srvManager.AddServices(gvService, attrS, chrS, tS, stS, trS, rnS, reS, ips, routeS, schS, rals,
-		apiSv1, apiSv2, cdrS, smg, coreS,
+		apiSv1, apiSv2, cdrS, smg, coreS, invS, fraudS,
 		services.NewDNSAgent(cfg, filterSChan, shdChan, connManager, caps, srvDep),
+		services.NewSMPPAgent(cfg, filterSChan, shdChan, connManager, caps, srvDep),
 		services.NewFreeswitchAgent(cfg, shdChan, connManager, srvDep),
 		services.NewKamailioAgent(cfg, shdChan, connManager, srvDep),
 		services.NewAsteriskAgent(cfg, shdChan, connManager, srvDep),                    // partial reload
@@ -668,6 +688,8 @@ func main() {
 	engine.IntRPC.AddInternalRPCClient(utils.StatSv1, internalStatSChan)
 	engine.IntRPC.AddInternalRPCClient(utils.TrendSv1, internalTrendSChan)
 	engine.IntRPC.AddInternalRPCClient(utils.RankingSv1, internalRankingSChan)
+	engine.IntRPC.AddInternalRPCClient(utils.InvoiceSv1, internalInvoiceSChan)
+	engine.IntRPC.AddInternalRPCClient(utils.FraudSv1, internalFraudSChan)
 	engine.IntRPC.AddInternalRPCClient(utils.RouteSv1, internalRouteSChan)
 	engine.IntRPC.AddInternalRPCClient(utils.ThresholdSv1, internalThresholdSChan)
 	engine.IntRPC.AddInternalRPCClient(utils.ServiceManagerV1, internalServeManagerChan)
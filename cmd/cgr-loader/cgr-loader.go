@@ -88,7 +88,7 @@ var (
 		"Scheme for MongoDB connection <mongodb|mongodb+srv>")
 
 	storDBType = cgrLoaderFlags.String(utils.StorDBTypeCgr, dfltCfg.StorDbCfg().Type,
-		"The type of the storDb database <*mysql|*postgres|*mongo>")
+		"The type of the storDb database <*mysql|*postgres|*sqlite|*mongo>")
 	storDBHost = cgrLoaderFlags.String(utils.StorDBHostCgr, dfltCfg.StorDbCfg().Host,
 		"The storDb host to connect to.")
 	storDBPort = cgrLoaderFlags.String(utils.StorDBPortCgr, dfltCfg.StorDbCfg().Port,
@@ -112,7 +112,7 @@ var (
 		"the name of redis sentinel")
 
 	inStorDBType = cgrMigratorFlags.String(utils.StorDBTypeCgr, dfltCfg.StorDbCfg().Type,
-		"the type of the StorDB Database <*mysql|*postgres|*mongo>")
+		"the type of the StorDB Database <*mysql|*postgres|*sqlite|*mongo>")
 	inStorDBHost = cgrMigratorFlags.String(utils.StorDBHostCgr, dfltCfg.StorDbCfg().Host,
 		"the StorDB host")
 	inStorDBPort = cgrMigratorFlags.String(utils.StorDBPortCgr, dfltCfg.StorDbCfg().Port,
@@ -125,7 +125,7 @@ var (
 		"the StorDB password")
 
 	outStorDBType = cgrMigratorFlags.String(utils.OutStorDBTypeCfg, utils.MetaStorDB,
-		"output StorDB type for move mode <*mysql|*postgres|*mongo>")
+		"output StorDB type for move mode <*mysql|*postgres|*sqlite|*mongo>")
 	outStorDBHost = cgrMigratorFlags.String(utils.OutStorDBHostCfg, utils.MetaStorDB,
 		"output StorDB host")
 	outStorDBPort = cgrMigratorFlags.String(utils.OutStorDBPortCfg, utils.MetaStorDB,
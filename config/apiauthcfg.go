@@ -0,0 +1,136 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"slices"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// APIAuthCfg is the configuration of the API authentication and role based authorization
+type APIAuthCfg struct {
+	Enabled   bool
+	JWTSecret string
+	Roles     map[string][]string   // allowed ServiceMethod patterns per role
+	APIKeys   map[string]*APIKeyCfg // indexed on the key ID
+}
+
+// APIKeyCfg is an API key together with the tenant and the roles it grants
+type APIKeyCfg struct {
+	Key    string
+	Tenant string // *any to access all the tenants
+	Roles  []string
+}
+
+func (aa *APIAuthCfg) loadFromJSONCfg(jsnCfg *APIAuthJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		aa.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.JWTSecret != nil {
+		aa.JWTSecret = *jsnCfg.JWTSecret
+	}
+	if jsnCfg.Roles != nil {
+		if aa.Roles == nil {
+			aa.Roles = make(map[string][]string)
+		}
+		for role, patterns := range *jsnCfg.Roles {
+			aa.Roles[role] = slices.Clone(patterns)
+		}
+	}
+	if jsnCfg.APIKeys != nil {
+		if aa.APIKeys == nil {
+			aa.APIKeys = make(map[string]*APIKeyCfg)
+		}
+		for id, jsnKey := range *jsnCfg.APIKeys {
+			if jsnKey == nil {
+				continue
+			}
+			key, has := aa.APIKeys[id]
+			if !has {
+				key = new(APIKeyCfg)
+				aa.APIKeys[id] = key
+			}
+			key.loadFromJSONCfg(jsnKey)
+		}
+	}
+	return
+}
+
+func (ak *APIKeyCfg) loadFromJSONCfg(jsnCfg *APIKeyJsonCfg) {
+	if jsnCfg.Key != nil {
+		ak.Key = *jsnCfg.Key
+	}
+	if jsnCfg.Tenant != nil {
+		ak.Tenant = *jsnCfg.Tenant
+	}
+	if jsnCfg.Roles != nil {
+		ak.Roles = slices.Clone(*jsnCfg.Roles)
+	}
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (aa *APIAuthCfg) AsMapInterface() map[string]any {
+	roles := make(map[string]any, len(aa.Roles))
+	for role, patterns := range aa.Roles {
+		roles[role] = slices.Clone(patterns)
+	}
+	keys := make(map[string]any, len(aa.APIKeys))
+	for id, key := range aa.APIKeys {
+		keys[id] = map[string]any{
+			utils.KeyCfg:    key.Key,
+			utils.TenantCfg: key.Tenant,
+			utils.RolesCfg:  slices.Clone(key.Roles),
+		}
+	}
+	return map[string]any{
+		utils.EnabledCfg:   aa.Enabled,
+		utils.JWTSecretCfg: aa.JWTSecret,
+		utils.RolesCfg:     roles,
+		utils.APIKeysCfg:   keys,
+	}
+}
+
+// Clone returns a deep copy of APIAuthCfg
+func (aa APIAuthCfg) Clone() (cln *APIAuthCfg) {
+	cln = &APIAuthCfg{
+		Enabled:   aa.Enabled,
+		JWTSecret: aa.JWTSecret,
+	}
+	if aa.Roles != nil {
+		cln.Roles = make(map[string][]string, len(aa.Roles))
+		for role, patterns := range aa.Roles {
+			cln.Roles[role] = slices.Clone(patterns)
+		}
+	}
+	if aa.APIKeys != nil {
+		cln.APIKeys = make(map[string]*APIKeyCfg, len(aa.APIKeys))
+		for id, key := range aa.APIKeys {
+			cln.APIKeys[id] = &APIKeyCfg{
+				Key:    key.Key,
+				Tenant: key.Tenant,
+				Roles:  slices.Clone(key.Roles),
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,141 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAPIAuthCfgloadFromJsonCfg(t *testing.T) {
+	var aaCfg, expected APIAuthCfg
+	if err := aaCfg.loadFromJSONCfg(nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(aaCfg, expected) {
+		t.Errorf("Expected: %+v ,received: %+v", expected, aaCfg)
+	}
+	cfgJSONStr := `{
+		"api_auth": {
+			"enabled": true,
+			"jwt_secret": "secret",
+			"roles": {"reseller": ["APIerSv1.Get*"], "admin": ["*any"]},
+			"api_keys": {
+				"reseller1": {"key": "key1", "tenant": "reseller1.com", "roles": ["reseller"]},
+			},
+		},
+}`
+	expected = APIAuthCfg{
+		Enabled:   true,
+		JWTSecret: "secret",
+		Roles:     map[string][]string{"reseller": {"APIerSv1.Get*"}, "admin": {utils.MetaAny}},
+		APIKeys: map[string]*APIKeyCfg{
+			"reseller1": {Key: "key1", Tenant: "reseller1.com", Roles: []string{"reseller"}},
+		},
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnAACfg, err := jsnCfg.APIAuthCfgJson(); err != nil {
+		t.Error(err)
+	} else if err = aaCfg.loadFromJSONCfg(jsnAACfg); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, aaCfg) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(expected), utils.ToJSON(aaCfg))
+	}
+
+	// the keys are updated by ID
+	tnt := "reseller2.com"
+	if err := aaCfg.loadFromJSONCfg(&APIAuthJsonCfg{
+		APIKeys: &map[string]*APIKeyJsonCfg{"reseller1": {Tenant: &tnt}},
+	}); err != nil {
+		t.Error(err)
+	} else if key := aaCfg.APIKeys["reseller1"]; key.Tenant != tnt || key.Key != "key1" {
+		t.Errorf("unexpected API key: %s", utils.ToJSON(key))
+	}
+}
+
+func TestAPIAuthCfgAsMapInterface(t *testing.T) {
+	aaCfg := &APIAuthCfg{
+		Enabled: true,
+		Roles:   map[string][]string{"admin": {utils.MetaAny}},
+		APIKeys: map[string]*APIKeyCfg{
+			"noc": {Key: "key2", Tenant: utils.MetaAny, Roles: []string{"admin"}},
+		},
+	}
+	eMap := map[string]any{
+		utils.EnabledCfg:   true,
+		utils.JWTSecretCfg: "",
+		utils.RolesCfg:     map[string]any{"admin": []string{utils.MetaAny}},
+		utils.APIKeysCfg: map[string]any{
+			"noc": map[string]any{
+				utils.KeyCfg:    "key2",
+				utils.TenantCfg: utils.MetaAny,
+				utils.RolesCfg:  []string{"admin"},
+			},
+		},
+	}
+	if rcv := aaCfg.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("Expected: %s\nReceived: %s", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
+
+func TestAPIAuthCfgClone(t *testing.T) {
+	aaCfg := &APIAuthCfg{
+		Enabled:   true,
+		JWTSecret: "secret",
+		Roles:     map[string][]string{"admin": {utils.MetaAny}},
+		APIKeys: map[string]*APIKeyCfg{
+			"noc": {Key: "key2", Tenant: utils.MetaAny, Roles: []string{"admin"}},
+		},
+	}
+	rcv := aaCfg.Clone()
+	if !reflect.DeepEqual(aaCfg, rcv) {
+		t.Errorf("Expected: %s\nReceived: %s", utils.ToJSON(aaCfg), utils.ToJSON(rcv))
+	}
+	if rcv.APIKeys["noc"].Roles[0] = ""; aaCfg.APIKeys["noc"].Roles[0] != "admin" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
+
+func TestAPIAuthCfgSanity(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.apiAuthCfg.Enabled = true
+	if err := cfg.checkConfigSanity(); err == nil ||
+		err.Error() != "<APIAuth> enabled without any api_keys or jwt_secret" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	cfg.apiAuthCfg.APIKeys = map[string]*APIKeyCfg{
+		"reseller1": {Key: "key1", Tenant: "reseller1.com", Roles: []string{"reseller"}},
+	}
+	if err := cfg.checkConfigSanity(); err == nil ||
+		err.Error() != "<APIAuth> role <reseller> of API key <reseller1> not defined" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	cfg.apiAuthCfg.Roles = map[string][]string{"reseller": {"APIerSv1.Get*"}}
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+	cfg.apiAuthCfg.APIKeys["reseller2"] = &APIKeyCfg{Key: "key1", Tenant: "reseller2.com"}
+	if err := cfg.checkConfigSanity(); err == nil ||
+		!strings.HasPrefix(err.Error(), "<APIAuth> duplicated key for API key") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package config
+
+import "github.com/cgrates/cgrates/utils"
+
+// auditObjectTypes are the dataDB profiles which can be recorded on the audit trail
+var auditObjectTypes = utils.NewStringSet([]string{utils.MetaRatingProfiles,
+	utils.MetaAttributeProfiles, utils.MetaFilters, utils.MetaRouteProfiles})
+
+// AuditCfg is the configuration of the audit trail kept for the dataDB profiles
+type AuditCfg struct {
+	Enabled     bool
+	ObjectTypes utils.StringSet
+}
+
+func (aCfg *AuditCfg) loadFromJSONCfg(jsnCfg *AuditJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		aCfg.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Object_types != nil {
+		aCfg.ObjectTypes = utils.NewStringSet(*jsnCfg.Object_types)
+	}
+	return
+}
+
+// Audited returns true if the changes of the objType profiles are recorded
+func (aCfg *AuditCfg) Audited(objType string) bool {
+	return aCfg.Enabled && aCfg.ObjectTypes.Has(objType)
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (aCfg *AuditCfg) AsMapInterface() map[string]any {
+	return map[string]any{
+		utils.EnabledCfg:     aCfg.Enabled,
+		utils.ObjectTypesCfg: aCfg.ObjectTypes.AsOrderedSlice(),
+	}
+}
+
+// Clone returns a deep copy of AuditCfg
+func (aCfg AuditCfg) Clone() *AuditCfg {
+	return &AuditCfg{
+		Enabled:     aCfg.Enabled,
+		ObjectTypes: aCfg.ObjectTypes.Clone(),
+	}
+}
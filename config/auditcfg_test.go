@@ -0,0 +1,102 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAuditCfgloadFromJsonCfg(t *testing.T) {
+	aCfg := new(AuditCfg)
+	if err := aCfg.loadFromJSONCfg(nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(new(AuditCfg), aCfg) {
+		t.Errorf("Expected: %+v ,received: %+v", new(AuditCfg), aCfg)
+	}
+	cfgJSONStr := `{
+		"audit": {
+			"enabled": true,
+			"object_types": ["*attribute_profiles", "*filters"],
+		},
+}`
+	expected := &AuditCfg{
+		Enabled:     true,
+		ObjectTypes: utils.NewStringSet([]string{utils.MetaAttributeProfiles, utils.MetaFilters}),
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnACfg, err := jsnCfg.AuditCfgJson(); err != nil {
+		t.Error(err)
+	} else if err = aCfg.loadFromJSONCfg(jsnACfg); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, aCfg) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(expected), utils.ToJSON(aCfg))
+	}
+	if !aCfg.Audited(utils.MetaFilters) {
+		t.Error("expected the filters to be audited")
+	}
+	if aCfg.Audited(utils.MetaRouteProfiles) {
+		t.Error("expected the route profiles not to be audited")
+	}
+	if aCfg.Enabled = false; aCfg.Audited(utils.MetaFilters) {
+		t.Error("expected nothing audited when disabled")
+	}
+}
+
+func TestAuditCfgAsMapInterface(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	eMap := map[string]any{
+		utils.EnabledCfg: false,
+		utils.ObjectTypesCfg: []string{utils.MetaAttributeProfiles, utils.MetaFilters,
+			utils.MetaRatingProfiles, utils.MetaRouteProfiles},
+	}
+	if rcv := cfg.AuditCfg().AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("Expected: %s\nReceived: %s", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
+
+func TestAuditCfgClone(t *testing.T) {
+	aCfg := &AuditCfg{
+		Enabled:     true,
+		ObjectTypes: utils.NewStringSet([]string{utils.MetaRatingProfiles}),
+	}
+	rcv := aCfg.Clone()
+	if !reflect.DeepEqual(aCfg, rcv) {
+		t.Errorf("Expected: %s\nReceived: %s", utils.ToJSON(aCfg), utils.ToJSON(rcv))
+	}
+	if rcv.ObjectTypes.Add(utils.MetaFilters); aCfg.ObjectTypes.Has(utils.MetaFilters) {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
+
+func TestAuditCfgSanity(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.auditCfg.Enabled = true
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+	cfg.auditCfg.ObjectTypes.Add(utils.MetaAccounts)
+	if err := cfg.checkConfigSanity(); err == nil ||
+		err.Error() != "<audit> unsupported object_types: <*accounts>" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
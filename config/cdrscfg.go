@@ -28,6 +28,7 @@ type CdrsCfg struct {
 	ExtraFields        RSRParsers // Extra fields to store in CDRs
 	StoreCdrs          bool       // store cdrs in storDb
 	CompressStoredCost bool       // compress cost details in cdrs
+	ApplyTaxes         bool       // apply the tax profiles on the rated cdrs
 	SMCostRetries      int
 	ChargerSConns      []string
 	RaterConns         []string
@@ -58,6 +59,9 @@ func (cdrscfg *CdrsCfg) loadFromJSONCfg(jsnCdrsCfg *CdrsJsonCfg) (err error) {
 	if jsnCdrsCfg.Compress_stored_cost != nil {
 		cdrscfg.CompressStoredCost = *jsnCdrsCfg.Compress_stored_cost
 	}
+	if jsnCdrsCfg.Apply_taxes != nil {
+		cdrscfg.ApplyTaxes = *jsnCdrsCfg.Apply_taxes
+	}
 	if jsnCdrsCfg.Session_cost_retries != nil {
 		cdrscfg.SMCostRetries = *jsnCdrsCfg.Session_cost_retries
 	}
@@ -144,6 +148,7 @@ func (cdrscfg *CdrsCfg) AsMapInterface() (initialMP map[string]any) {
 		utils.EnabledCfg:            cdrscfg.Enabled,
 		utils.StoreCdrsCfg:          cdrscfg.StoreCdrs,
 		utils.CompressStoredCostCfg: cdrscfg.CompressStoredCost,
+		utils.ApplyTaxesCfg:         cdrscfg.ApplyTaxes,
 		utils.SMCostRetriesCfg:      cdrscfg.SMCostRetries,
 	}
 
@@ -239,6 +244,7 @@ func (cdrscfg CdrsCfg) Clone() (cln *CdrsCfg) {
 		StoreCdrs:          cdrscfg.StoreCdrs,
 		SMCostRetries:      cdrscfg.SMCostRetries,
 		CompressStoredCost: cdrscfg.CompressStoredCost,
+		ApplyTaxes:         cdrscfg.ApplyTaxes,
 	}
 	if cdrscfg.ChargerSConns != nil {
 		cln.ChargerSConns = make([]string, len(cdrscfg.ChargerSConns))
@@ -94,6 +94,7 @@ func TestCdrsCfgAsMapInterface(t *testing.T) {
 		utils.StoreCdrsCfg:          true,
 		utils.SessionCostRetires:    5,
 		utils.CompressStoredCostCfg: false,
+		utils.ApplyTaxesCfg:         false,
 		utils.ChargerSConnsCfg:      []string{utils.MetaInternal, "*conn1"},
 		utils.RALsConnsCfg:          []string{utils.MetaInternal, "*conn1"},
 		utils.AttributeSConnsCfg:    []string{utils.MetaInternal, "*conn1"},
@@ -124,6 +125,7 @@ func TestCdrsCfgAsMapInterface2(t *testing.T) {
 		utils.ExtraFieldsCfg:        []string{},
 		utils.StoreCdrsCfg:          true,
 		utils.CompressStoredCostCfg: false,
+		utils.ApplyTaxesCfg:         false,
 		utils.SessionCostRetires:    5,
 		utils.ChargerSConnsCfg:      []string{"conn1", "conn2"},
 		utils.RALsConnsCfg:          []string{},
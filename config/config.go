@@ -59,6 +59,10 @@ func newDbDefaults() dbDefaults {
 			"DbName": "cgrates",
 			"DbPort": "5432",
 		},
+		utils.MetaSQLite: map[string]string{
+			"DbName": "/var/lib/cgrates/sqlite/cgrates.db",
+			"DbPort": "",
+		},
 		utils.MetaMongo: map[string]string{
 			"DbName": "cgrates",
 			"DbPort": "27017",
@@ -156,6 +160,8 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 	cfg.resourceSCfg = &ResourceSConfig{Opts: &ResourcesOpts{}}
 	cfg.statsCfg = &StatSCfg{Opts: &StatsOpts{}}
 	cfg.trendsCfg = new(TrendSCfg)
+	cfg.invoiceSCfg = new(InvoiceSCfg)
+	cfg.fraudSCfg = new(FraudSCfg)
 	cfg.rankingsCfg = new(RankingSCfg)
 	cfg.thresholdSCfg = &ThresholdSCfg{Opts: &ThresholdsOpts{}}
 	cfg.routeSCfg = &RouteSCfg{Opts: &RoutesOpts{}}
@@ -179,28 +185,36 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 		FailedPosts: &FailedPostsCfg{},
 	}
 	cfg.sipAgentCfg = new(SIPAgentCfg)
+	cfg.smppAgentCfg = new(SMPPAgentCfg)
 	cfg.janusAgentCfg = new(JanusAgentCfg)
 	cfg.configSCfg = new(ConfigSCfg)
 	cfg.apiBanCfg = new(APIBanCfg)
+	cfg.apiAuthCfg = new(APIAuthCfg)
+	cfg.auditCfg = &AuditCfg{ObjectTypes: make(utils.StringSet)}
 	cfg.sentryPeerCfg = new(SentryPeerCfg)
 	cfg.coreSCfg = new(CoreSCfg)
 	cfg.ipsCfg = &IPsCfg{Opts: &IPsOpts{}}
 	cfg.dfltEvExp = &EventExporterCfg{Opts: &EventExporterOpts{
-		Els:   new(ElsOpts),
-		SQL:   new(SQLOpts),
-		AMQP:  new(AMQPOpts),
-		AWS:   new(AWSOpts),
-		NATS:  new(NATSOpts),
-		RPC:   new(RPCOpts),
-		Kafka: new(KafkaOpts),
+		Els:         new(ElsOpts),
+		SQL:         new(SQLOpts),
+		AMQP:        new(AMQPOpts),
+		AWS:         new(AWSOpts),
+		NATS:        new(NATSOpts),
+		RPC:         new(RPCOpts),
+		Kafka:       new(KafkaOpts),
+		MQTT:        new(MQTTOpts),
+		RedisStream: new(RedisStreamOpts),
+		Webhook:     new(WebhookOpts),
 	}}
 	cfg.dfltEvRdr = &EventReaderCfg{Opts: &EventReaderOpts{
-		SQL:   new(SQLROpts),
-		CSV:   new(CSVROpts),
-		AWS:   new(AWSROpts),
-		AMQP:  new(AMQPROpts),
-		Kafka: new(KafkaROpts),
-		NATS:  new(NATSROpts),
+		SQL:         new(SQLROpts),
+		CSV:         new(CSVROpts),
+		AWS:         new(AWSROpts),
+		AMQP:        new(AMQPROpts),
+		Kafka:       new(KafkaROpts),
+		NATS:        new(NATSROpts),
+		MQTT:        new(MQTTROpts),
+		RedisStream: new(RedisStreamROpts),
 	}}
 
 	cfg.cacheDP = make(map[string]utils.MapStorage)
@@ -321,6 +335,8 @@ type CGRConfig struct {
 	resourceSCfg       *ResourceSConfig    // ResourceS config
 	statsCfg           *StatSCfg           // StatS config
 	trendsCfg          *TrendSCfg          // TrendS config
+	invoiceSCfg        *InvoiceSCfg        // InvoiceS config
+	fraudSCfg          *FraudSCfg          // FraudS config
 	rankingsCfg        *RankingSCfg        // Rankings config
 	thresholdSCfg      *ThresholdSCfg      // ThresholdS config
 	routeSCfg          *RouteSCfg          // RouteS config
@@ -335,9 +351,12 @@ type CGRConfig struct {
 	ersCfg             *ERsCfg             // EventReader config
 	eesCfg             *EEsCfg             // EventExporter config
 	sipAgentCfg        *SIPAgentCfg        // SIPAgent config
+	smppAgentCfg       *SMPPAgentCfg       // SMPPAgent config
 	janusAgentCfg      *JanusAgentCfg      // JanusAgent config
 	configSCfg         *ConfigSCfg         // ConfigS config
 	apiBanCfg          *APIBanCfg          // APIBan config
+	apiAuthCfg         *APIAuthCfg         // APIAuth config
+	auditCfg           *AuditCfg           // audit trail config
 	sentryPeerCfg      *SentryPeerCfg      //SentryPeer config
 	coreSCfg           *CoreSCfg           // CoreS config
 	ipsCfg             *IPsCfg             // IPs config
@@ -354,12 +373,13 @@ var possibleLoaderTypes = utils.NewStringSet([]string{utils.MetaAttributes,
 var possibleReaderTypes = utils.NewStringSet([]string{utils.MetaFileCSV,
 	utils.MetaKafkajsonMap, utils.MetaFileXML, utils.MetaSQL, utils.MetaFileFWV,
 	utils.MetaFileJSON, utils.MetaNone, utils.MetaAMQPjsonMap, utils.MetaS3jsonMap,
-	utils.MetaSQSjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaNatsjsonMap})
+	utils.MetaSQSjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaNatsjsonMap, utils.MetaMQTTjsonMap,
+	utils.MetaRedisStreamjsonMap})
 
 var possibleExporterTypes = utils.NewStringSet([]string{utils.MetaFileCSV, utils.MetaNone, utils.MetaFileFWV,
 	utils.MetaHTTPPost, utils.MetaHTTPjsonMap, utils.MetaAMQPjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaSQSjsonMap,
 	utils.MetaKafkajsonMap, utils.MetaS3jsonMap, utils.MetaElastic, utils.MetaVirt, utils.MetaSQL, utils.MetaNatsjsonMap,
-	utils.MetaLog, utils.MetaRPC})
+	utils.MetaLog, utils.MetaRPC, utils.MetaMQTTjsonMap, utils.MetaWebhook, utils.MetaRedisStreamjsonMap})
 
 // Loads from json configuration object, will be used for defaults, config from file and reload, might need lock
 func (cfg *CGRConfig) loadFromJSONCfg(jsnCfg *CgrJsonCfg) (err error) {
@@ -373,13 +393,13 @@ func (cfg *CGRConfig) loadFromJSONCfg(jsnCfg *CgrJsonCfg) (err error) {
 		cfg.loadFreeswitchAgentCfg, cfg.loadKamAgentCfg,
 		cfg.loadAsteriskAgentCfg, cfg.loadDiameterAgentCfg, cfg.loadRadiusAgentCfg,
 		cfg.loadDNSAgentCfg, cfg.loadHTTPAgentCfg, cfg.loadPrometheusAgentCfg, cfg.loadAttributeSCfg,
-		cfg.loadChargerSCfg, cfg.loadResourceSCfg, cfg.loadStatSCfg, cfg.loadTrendSCfg,
+		cfg.loadChargerSCfg, cfg.loadResourceSCfg, cfg.loadStatSCfg, cfg.loadTrendSCfg, cfg.loadInvoiceSCfg, cfg.loadFraudSCfg,
 		cfg.loadRankingSCfg, cfg.loadThresholdSCfg, cfg.loadRouteSCfg, cfg.loadLoaderSCfg,
 		cfg.loadMailerCfg, cfg.loadSureTaxCfg, cfg.loadDispatcherSCfg,
 		cfg.loadLoaderCgrCfg, cfg.loadMigratorCgrCfg, cfg.loadTLSCgrCfg,
 		cfg.loadAnalyzerCgrCfg, cfg.loadApierCfg, cfg.loadErsCfg, cfg.loadEesCfg,
-		cfg.loadSIPAgentCfg, cfg.loadRegistrarCCfg, cfg.loadJanusAgentCfg,
-		cfg.loadConfigSCfg, cfg.loadAPIBanCgrCfg, cfg.loadSentryPeerCgrCfg,
+		cfg.loadSIPAgentCfg, cfg.loadSMPPAgentCfg, cfg.loadRegistrarCCfg, cfg.loadJanusAgentCfg,
+		cfg.loadConfigSCfg, cfg.loadAPIBanCgrCfg, cfg.loadAPIAuthCfg, cfg.loadAuditCfg, cfg.loadSentryPeerCgrCfg,
 		cfg.loadCoreSCfg, cfg.loadIPsCfg,
 	} {
 		if err = loadFunc(jsnCfg); err != nil {
@@ -663,6 +683,24 @@ func (cfg *CGRConfig) loadTrendSCfg(jsnCfg *CgrJsonCfg) (err error) {
 	return cfg.trendsCfg.loadFromJSONCfg(jsnTrendSCfg)
 }
 
+// loadFraudSCfg loads the FraudS section of the configuration
+func (cfg *CGRConfig) loadFraudSCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnFraudSCfg *FraudSJsonCfg
+	if jsnFraudSCfg, err = jsnCfg.FraudSJsonCfg(); err != nil {
+		return
+	}
+	return cfg.fraudSCfg.loadFromJSONCfg(jsnFraudSCfg)
+}
+
+// loadInvoiceSCfg loads the InvoiceS section of the configuration
+func (cfg *CGRConfig) loadInvoiceSCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnInvoiceSCfg *InvoiceSJsonCfg
+	if jsnInvoiceSCfg, err = jsnCfg.InvoiceSJsonCfg(); err != nil {
+		return
+	}
+	return cfg.invoiceSCfg.loadFromJSONCfg(jsnInvoiceSCfg)
+}
+
 // loadRankingSCfg loads the RankingS section of the configuration
 func (cfg *CGRConfig) loadRankingSCfg(jsnCfg *CgrJsonCfg) (err error) {
 	var jsnRankingSCfg *RankingsJsonCfg
@@ -787,6 +825,24 @@ func (cfg *CGRConfig) loadAPIBanCgrCfg(jsnCfg *CgrJsonCfg) (err error) {
 	}
 	return cfg.apiBanCfg.loadFromJSONCfg(jsnAPIBanCfg)
 }
+
+// loadAPIAuthCfg loads the api_auth section of the configuration
+func (cfg *CGRConfig) loadAPIAuthCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnAPIAuthCfg *APIAuthJsonCfg
+	if jsnAPIAuthCfg, err = jsnCfg.APIAuthCfgJson(); err != nil {
+		return
+	}
+	return cfg.apiAuthCfg.loadFromJSONCfg(jsnAPIAuthCfg)
+}
+
+// loadAuditCfg loads the audit section of the configuration
+func (cfg *CGRConfig) loadAuditCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnAuditCfg *AuditJsonCfg
+	if jsnAuditCfg, err = jsnCfg.AuditCfgJson(); err != nil {
+		return
+	}
+	return cfg.auditCfg.loadFromJSONCfg(jsnAuditCfg)
+}
 func (cfg *CGRConfig) loadSentryPeerCgrCfg(jsnCfg *CgrJsonCfg) (err error) {
 	var jsnSentryPeerCfg *SentryPeerJsonCfg
 	if jsnSentryPeerCfg, err = jsnCfg.SentryPeerJson(); err != nil {
@@ -840,6 +896,15 @@ func (cfg *CGRConfig) loadSIPAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
 	return cfg.sipAgentCfg.loadFromJSONCfg(jsnSIPAgentCfg, cfg.generalCfg.RSRSep)
 }
 
+// loadSMPPAgentCfg loads the smpp_agent section of the configuration
+func (cfg *CGRConfig) loadSMPPAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnSMPPAgentCfg *SMPPAgentJsonCfg
+	if jsnSMPPAgentCfg, err = jsnCfg.SMPPAgentJsonCfg(); err != nil {
+		return
+	}
+	return cfg.smppAgentCfg.loadFromJSONCfg(jsnSMPPAgentCfg, cfg.generalCfg.RSRSep)
+}
+
 func (cfg *CGRConfig) loadJanusAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
 	var jsnJanusAgentCfg *JanusAgentJsonCfg
 	if jsnJanusAgentCfg, err = jsnCfg.JanusAgentCfgJson(); err != nil {
@@ -949,6 +1014,20 @@ func (cfg *CGRConfig) TrendSCfg() *TrendSCfg {
 	return cfg.trendsCfg
 }
 
+// FraudSCfg returns the config for FraudS
+func (cfg *CGRConfig) FraudSCfg() *FraudSCfg {
+	cfg.lks[FraudSJson].Lock()
+	defer cfg.lks[FraudSJson].Unlock()
+	return cfg.fraudSCfg
+}
+
+// InvoiceSCfg returns the config for InvoiceS
+func (cfg *CGRConfig) InvoiceSCfg() *InvoiceSCfg {
+	cfg.lks[InvoiceSJson].Lock()
+	defer cfg.lks[InvoiceSJson].Unlock()
+	return cfg.invoiceSCfg
+}
+
 // RankingSCfg returns the config for RankingS
 func (cfg *CGRConfig) RankingSCfg() *RankingSCfg {
 	cfg.lks[RANKINGS_JSON].Lock()
@@ -1164,6 +1243,13 @@ func (cfg *CGRConfig) SIPAgentCfg() *SIPAgentCfg {
 	return cfg.sipAgentCfg
 }
 
+// SMPPAgentCfg reads the SMPPAgent configuration
+func (cfg *CGRConfig) SMPPAgentCfg() *SMPPAgentCfg {
+	cfg.lks[SMPPAgentJson].Lock()
+	defer cfg.lks[SMPPAgentJson].Unlock()
+	return cfg.smppAgentCfg
+}
+
 // JanusAgentCfg reads the JanusAgent configuration
 func (cfg *CGRConfig) JanusAgentCfg() *JanusAgentCfg {
 	cfg.lks[JanusAgentJson].Lock()
@@ -1198,6 +1284,20 @@ func (cfg *CGRConfig) APIBanCfg() *APIBanCfg {
 	defer cfg.lks[APIBanCfgJson].Unlock()
 	return cfg.apiBanCfg
 }
+
+// APIAuthCfg reads the APIAuth configuration
+func (cfg *CGRConfig) APIAuthCfg() *APIAuthCfg {
+	cfg.lks[APIAuthCfgJson].Lock()
+	defer cfg.lks[APIAuthCfgJson].Unlock()
+	return cfg.apiAuthCfg
+}
+
+// AuditCfg reads the audit trail configuration
+func (cfg *CGRConfig) AuditCfg() *AuditCfg {
+	cfg.lks[AuditCfgJson].Lock()
+	defer cfg.lks[AuditCfgJson].Unlock()
+	return cfg.auditCfg
+}
 func (cfg *CGRConfig) SentryPeerCfg() *SentryPeerCfg {
 	cfg.lks[SentryPeerCfgJson].Lock()
 	defer cfg.lks[SentryPeerCfgJson].Unlock()
@@ -1308,6 +1408,8 @@ func (cfg *CGRConfig) getLoadFunctions() map[string]func(*CgrJsonCfg) error {
 		RESOURCES_JSON:      cfg.loadResourceSCfg,
 		STATS_JSON:          cfg.loadStatSCfg,
 		TRENDS_JSON:         cfg.loadTrendSCfg,
+		InvoiceSJson:        cfg.loadInvoiceSCfg,
+		FraudSJson:          cfg.loadFraudSCfg,
 		RANKINGS_JSON:       cfg.loadRankingSCfg,
 		THRESHOLDS_JSON:     cfg.loadThresholdSCfg,
 		RouteSJson:          cfg.loadRouteSCfg,
@@ -1322,10 +1424,13 @@ func (cfg *CGRConfig) getLoadFunctions() map[string]func(*CgrJsonCfg) error {
 		ApierS:              cfg.loadApierCfg,
 		RPCConnsJsonName:    cfg.loadRPCConns,
 		SIPAgentJson:        cfg.loadSIPAgentCfg,
+		SMPPAgentJson:       cfg.loadSMPPAgentCfg,
 		JanusAgentJson:      cfg.loadJanusAgentCfg,
 		TemplatesJson:       cfg.loadTemplateSCfg,
 		ConfigSJson:         cfg.loadConfigSCfg,
 		APIBanCfgJson:       cfg.loadAPIBanCgrCfg,
+		APIAuthCfgJson:      cfg.loadAPIAuthCfg,
+		AuditCfgJson:        cfg.loadAuditCfg,
 		SentryPeerCfgJson:   cfg.loadSentryPeerCgrCfg,
 		CoreSCfgJson:        cfg.loadCoreSCfg,
 		IPsJSON:             cfg.loadIPsCfg,
@@ -1524,6 +1629,8 @@ func (cfg *CGRConfig) reloadSections(sections ...string) {
 		case TemplatesJson:
 		case TlsCfgJson: // nothing to reload
 		case APIBanCfgJson: // nothing to reload
+		case APIAuthCfgJson: // nothing to reload
+		case AuditCfgJson: // nothing to reload
 		case SentryPeerCfgJson:
 		case CoreSCfgJson: // nothing to reload
 		case HTTP_JSN:
@@ -1564,6 +1671,10 @@ func (cfg *CGRConfig) reloadSections(sections ...string) {
 			cfg.rldChans[STATS_JSON] <- struct{}{}
 		case TRENDS_JSON:
 			cfg.rldChans[TRENDS_JSON] <- struct{}{}
+		case InvoiceSJson:
+			cfg.rldChans[InvoiceSJson] <- struct{}{}
+		case FraudSJson:
+			cfg.rldChans[FraudSJson] <- struct{}{}
 		case RANKINGS_JSON:
 			cfg.rldChans[RANKINGS_JSON] <- struct{}{}
 		case THRESHOLDS_JSON:
@@ -1584,6 +1695,8 @@ func (cfg *CGRConfig) reloadSections(sections ...string) {
 			cfg.rldChans[EEsJson] <- struct{}{}
 		case SIPAgentJson:
 			cfg.rldChans[SIPAgentJson] <- struct{}{}
+		case SMPPAgentJson:
+			cfg.rldChans[SMPPAgentJson] <- struct{}{}
 		case RegistrarCJson:
 			cfg.rldChans[RegistrarCJson] <- struct{}{}
 		case IPsJSON:
@@ -1622,6 +1735,8 @@ func (cfg *CGRConfig) AsMapInterface(separator string) (mp map[string]any) {
 		RESOURCES_JSON:      cfg.resourceSCfg.AsMapInterface(),
 		STATS_JSON:          cfg.statsCfg.AsMapInterface(),
 		TRENDS_JSON:         cfg.trendsCfg.AsMapInterface(),
+		InvoiceSJson:        cfg.invoiceSCfg.AsMapInterface(),
+		FraudSJson:          cfg.fraudSCfg.AsMapInterface(),
 		RANKINGS_JSON:       cfg.rankingsCfg.AsMapInterface(),
 		THRESHOLDS_JSON:     cfg.thresholdSCfg.AsMapInterface(),
 		RouteSJson:          cfg.routeSCfg.AsMapInterface(),
@@ -1635,9 +1750,12 @@ func (cfg *CGRConfig) AsMapInterface(separator string) (mp map[string]any) {
 		ApierS:              cfg.apier.AsMapInterface(),
 		ERsJson:             cfg.ersCfg.AsMapInterface(separator),
 		APIBanCfgJson:       cfg.apiBanCfg.AsMapInterface(),
+		APIAuthCfgJson:      cfg.apiAuthCfg.AsMapInterface(),
+		AuditCfgJson:        cfg.auditCfg.AsMapInterface(),
 		SentryPeerCfgJson:   cfg.sentryPeerCfg.AsMapInterface(),
 		EEsJson:             cfg.eesCfg.AsMapInterface(separator),
 		SIPAgentJson:        cfg.sipAgentCfg.AsMapInterface(separator),
+		SMPPAgentJson:       cfg.smppAgentCfg.AsMapInterface(separator),
 		TemplatesJson:       cfg.templates.AsMapInterface(separator),
 		ConfigSJson:         cfg.configSCfg.AsMapInterface(),
 		CoreSCfgJson:        cfg.coreSCfg.AsMapInterface(),
@@ -1766,6 +1884,10 @@ func (cfg *CGRConfig) V1GetConfig(ctx *context.Context, args *SectionWithAPIOpts
 		mp = cfg.StatSCfg().AsMapInterface()
 	case TRENDS_JSON:
 		mp = cfg.TrendSCfg().AsMapInterface()
+	case InvoiceSJson:
+		mp = cfg.InvoiceSCfg().AsMapInterface()
+	case FraudSJson:
+		mp = cfg.FraudSCfg().AsMapInterface()
 	case RANKINGS_JSON:
 		mp = cfg.RankingSCfg().AsMapInterface()
 	case THRESHOLDS_JSON:
@@ -1794,12 +1916,18 @@ func (cfg *CGRConfig) V1GetConfig(ctx *context.Context, args *SectionWithAPIOpts
 		mp = cfg.RPCConns().AsMapInterface()
 	case SIPAgentJson:
 		mp = cfg.SIPAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
+	case SMPPAgentJson:
+		mp = cfg.SMPPAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case TemplatesJson:
 		mp = cfg.TemplatesCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case ConfigSJson:
 		mp = cfg.ConfigSCfg().AsMapInterface()
 	case APIBanCfgJson:
 		mp = cfg.APIBanCfg().AsMapInterface()
+	case APIAuthCfgJson:
+		mp = cfg.APIAuthCfg().AsMapInterface()
+	case AuditCfgJson:
+		mp = cfg.AuditCfg().AsMapInterface()
 	case SentryPeerCfgJson:
 		mp = cfg.SentryPeerCfg().AsMapInterface()
 	case HttpAgentJson:
@@ -1942,6 +2070,10 @@ func (cfg *CGRConfig) V1GetConfigAsJSON(ctx *context.Context, args *SectionWithA
 		mp = cfg.StatSCfg().AsMapInterface()
 	case TRENDS_JSON:
 		mp = cfg.TrendSCfg().AsMapInterface()
+	case InvoiceSJson:
+		mp = cfg.InvoiceSCfg().AsMapInterface()
+	case FraudSJson:
+		mp = cfg.FraudSCfg().AsMapInterface()
 	case RANKINGS_JSON:
 		mp = cfg.RankingSCfg().AsMapInterface()
 	case THRESHOLDS_JSON:
@@ -1968,10 +2100,16 @@ func (cfg *CGRConfig) V1GetConfigAsJSON(ctx *context.Context, args *SectionWithA
 		mp = cfg.ERsCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case SIPAgentJson:
 		mp = cfg.SIPAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
+	case SMPPAgentJson:
+		mp = cfg.SMPPAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case ConfigSJson:
 		mp = cfg.ConfigSCfg().AsMapInterface()
 	case APIBanCfgJson:
 		mp = cfg.APIBanCfg().AsMapInterface()
+	case APIAuthCfgJson:
+		mp = cfg.APIAuthCfg().AsMapInterface()
+	case AuditCfgJson:
+		mp = cfg.AuditCfg().AsMapInterface()
 	case SentryPeerCfgJson:
 		mp = cfg.SentryPeerCfg().AsMapInterface()
 	case RPCConnsJsonName:
@@ -2071,6 +2209,8 @@ func (cfg *CGRConfig) Clone() (cln *CGRConfig) {
 		resourceSCfg:       cfg.resourceSCfg.Clone(),
 		statsCfg:           cfg.statsCfg.Clone(),
 		trendsCfg:          cfg.trendsCfg.Clone(),
+		invoiceSCfg:        cfg.invoiceSCfg.Clone(),
+		fraudSCfg:          cfg.fraudSCfg.Clone(),
 		rankingsCfg:        cfg.rankingsCfg.Clone(),
 		thresholdSCfg:      cfg.thresholdSCfg.Clone(),
 		routeSCfg:          cfg.routeSCfg.Clone(),
@@ -2085,8 +2225,11 @@ func (cfg *CGRConfig) Clone() (cln *CGRConfig) {
 		ersCfg:             cfg.ersCfg.Clone(),
 		eesCfg:             cfg.eesCfg.Clone(),
 		sipAgentCfg:        cfg.sipAgentCfg.Clone(),
+		smppAgentCfg:       cfg.smppAgentCfg.Clone(),
 		configSCfg:         cfg.configSCfg.Clone(),
 		apiBanCfg:          cfg.apiBanCfg.Clone(),
+		apiAuthCfg:         cfg.apiAuthCfg.Clone(),
+		auditCfg:           cfg.auditCfg.Clone(),
 		sentryPeerCfg:      cfg.sentryPeerCfg.Clone(),
 		coreSCfg:           cfg.coreSCfg.Clone(),
 		ipsCfg:             cfg.ipsCfg.Clone(),
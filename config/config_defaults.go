@@ -49,6 +49,8 @@ const CGRATES_CFG_JSON = `
 	"connect_timeout": "1s",				// consider connection unsuccessful on timeout, 0 to disable the feature
 	"reply_timeout": "2s",					// consider connection down for replies taking longer than this value
 	"locking_timeout": "0",					// timeout internal locks to avoid deadlocks
+	"distributed_locking": false,			// share the locks with the engines using the same data_db, <*internal> requires replication_conns
+	"locking_lease_ttl": "5s",				// lease duration of the distributed locks, refreshed while they are held
 	"digest_separator": ",",				// separator to use in replies containing data digests
 	"digest_equal": ":",					// equal symbol used in case of digests
 	"rsr_separator": ";",					// separator used within RSR fields
@@ -117,6 +119,8 @@ const CGRATES_CFG_JSON = `
 		"*ip_allocations": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*ranking_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*rankings": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
+		"*data_sets": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
+		"*tax_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*trend_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*trends": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*statqueue_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
@@ -139,8 +143,11 @@ const CGRATES_CFG_JSON = `
 		"*attribute_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*charger_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*dispatcher_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
+		"*tax_profile_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*reverse_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*sessions_backup": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
+		"*invoices": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
+		"*audit_records": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 	},
 	"opts":{
 		"internalDBDumpPath": "/var/lib/cgrates/internal_db/datadb",		// the path where datadb will be dumped
@@ -149,6 +156,8 @@ const CGRATES_CFG_JSON = `
 		"internalDBDumpInterval": "0s",		// dump datadb regularly to a file: "0" - disables it; "-1" - dump on each set/remove; <""|$dur>
 		"internalDBRewriteInterval": "0s",	// rewrite dump files regularly: "0" - disables it; "-1" - rewrite on engine start; "-2" - rewrite on engine shutdown; <""|$dur>
 		"internalDBFileSizeLimit": "1GB",	// maximum size that can be written in a singular dump file 
+		"internalDBWAL": false,			// log each set/remove in a write-ahead log, replayed and compacted into the dump on start-up
+		"internalDBWALSyncInterval": "0s",	// fsync the write-ahead log: "0" - left to the OS; "-1" - on each set/remove; <""|$dur>
 		"redisMaxConns": 10,			// the connection pool size
 		"redisConnectAttempts": 20,		// the maximum amount of dial attempts
 		"redisSentinel": "",			// the name of sentinel when used
@@ -171,10 +180,10 @@ const CGRATES_CFG_JSON = `
 
 
 "stor_db": {					// database used to store offline tariff plans and CDRs
-	"db_type": "*mysql",			// stor database type to use: <*mongo|*mysql|*postgres|*internal>
+	"db_type": "*mysql",			// stor database type to use: <*mongo|*mysql|*postgres|*sqlite|*internal>
 	"db_host": "127.0.0.1",			// the host to connect to
 	"db_port": 3306,			// the port to reach the stor_db
-	"db_name": "cgrates",			// stor database name
+	"db_name": "cgrates",			// stor database name, the database file path for *sqlite
 	"db_user": "cgrates",			// username to use when connecting to stor_db
 	"db_password": "CGRateS.org",		// password to use when connecting to stor_db
 	"string_indexed_fields": [],		// indexes on cdrs table to speed up queries, used in case of *mongo and *internal
@@ -186,6 +195,8 @@ const CGRATES_CFG_JSON = `
 		"internalDBDumpInterval": "0s",		// dump datadb regularly to a file: "0" - disables it; "-1" - dump on each set/remove; <""|$dur>
 		"internalDBRewriteInterval": "0s",	// rewrite dump files regularly: "0" - disables it; "-1" - rewrite on engine start; "-2" - rewrite on engine shutdown; <""|$dur>
 		"internalDBFileSizeLimit": "1GB",	// maximum size that can be written in a singular dump file 
+		"internalDBWAL": false,			// log each set/remove in a write-ahead log, replayed and compacted into the dump on start-up
+		"internalDBWALSyncInterval": "0s",	// fsync the write-ahead log: "0" - left to the OS; "-1" - on each set/remove; <""|$dur>
 		"sqlMaxOpenConns": 100,		// maximum database connections opened, not applying for mongo
 		"sqlMaxIdleConns": 10,		// maximum database connections idle, not applying for mongo
 		"sqlLogLevel": 3,	        // sql logger verbosity: 1=Silent, 2=Error, 3=Warn, 4=Info
@@ -220,6 +231,7 @@ const CGRATES_CFG_JSON = `
 		"*tp_ips": {"limit": -1, "ttl": "", "static_ttl": false, "remote": false, "replicate": false},
 		"*tp_stats": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*tp_rankings": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
+		"*tp_tax_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*tp_trends": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*tp_thresholds": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*tp_filters": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
@@ -258,6 +270,10 @@ const CGRATES_CFG_JSON = `
 	"json_rpc_url": "/jsonrpc",			// JSON RPC relative URL ("" to disable)
 	"registrars_url": "/registrar",			// registrar service relative URL
 	"ws_url": "/ws",				// WebSockets relative URL ("" to disable)
+	"birpc_ws_url": "",				// BiRPC over WebSockets relative URL, served while SessionS is running ("" to disable)
+	"birpc_ws_ping": "30s",				// interval between the pings sent on the BiRPC WebSocket connections (0 to disable)
+	"birpc_ws_origins": [],				// host patterns allowed to open cross-origin BiRPC WebSocket connections
+	"birpc_ws_read_limit": 1048576,			// maximum size in bytes of a message read from the BiRPC WebSocket connections
 	"freeswitch_cdrs_url": "/freeswitch_json",	// Freeswitch CDRS relative URL ("" to disable)
 	"http_cdrs": "/cdr_http",			// CDRS relative URL ("" to disable)
 	"pprof_path": "/debug/pprof/",			// endpoint for serving runtime profiling data for pprof visualization
@@ -291,6 +307,7 @@ const CGRATES_CFG_JSON = `
 	"cdrs_conns": [],		// connections to CDRs for *cdrlog actions <""|*internal|$rpc_conns_id>
 	"thresholds_conns": [],		// connections to ThresholdS for *reset_threshold action <""|*internal|$rpc_conns_id>
 	"stats_conns": [],		// connections to StatS for *reset_stat_queue action: <""|*internal|$rpc_conns_id>
+	"invoices_conns": [],		// connections to InvoiceS for *generate_invoice action: <""|*internal|$rpc_conns_id>
 	"filters": [],			// only execute actions matching these filters
 	"dynaprepaid_actionplans": []	// actionPlans to be executed in case of *dynaprepaid request type
 },
@@ -318,6 +335,8 @@ const CGRATES_CFG_JSON = `
 		"*trends": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "remote":false, "replicate": false},		// control trends caching
 		"*ranking_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "remote":false, "replicate": false},	// ranking profiles
 		"*rankings": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "remote":false, "replicate": false},	 // control rankings caching
+		"*data_sets": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "remote":false, "replicate": false},	// data sets used by *in_set filters and *data_set attributes
+		"*tax_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "remote":false, "replicate": false},	// tax profiles applied by CDRs
 		"*statqueue_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "remote":false, "replicate": false},	// statqueue profiles
 		"*statqueues": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "remote":false, "replicate": false},		// statqueues with metrics
 		"*threshold_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "remote":false, "replicate": false},	// control threshold profiles caching
@@ -336,6 +355,7 @@ const CGRATES_CFG_JSON = `
 		"*attribute_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate": false}, 		// control attribute filter indexes caching
 		"*charger_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate": false}, 			// control charger filter indexes caching
 		"*dispatcher_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate": false}, 		// control dispatcher filter indexes caching
+		"*tax_profile_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate": false}, 	// control tax profile filter indexes caching
 		"*reverse_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate": false}, 			// control reverse filter indexes caching used only for set and remove filters 
 		"*dispatcher_routes": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate": false}, 			// control dispatcher routes caching
 		"*dispatcher_loads": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate": false},				// control dispatcher load( in case of *ratio ConnParams is present)
@@ -398,6 +418,7 @@ const CGRATES_CFG_JSON = `
 	"extra_fields": [],		// extra fields to store in CDRs for non-generic CDRs (ie: FreeSWITCH JSON)
 	"store_cdrs": true,		// store cdrs in StorDB
 	"compress_stored_cost": false,	// compress CostDetails before storing
+	"apply_taxes": false,		// apply the tax profiles stored in DataDB on the rated CDRs
 	"session_cost_retries": 5,	// number of queries to session_costs before recalculating CDR
 	"chargers_conns": [],		// connection to ChargerS for CDR forking, empty to disable billing for CDRs: <""|*internal|$rpc_conns_id>
 	"rals_conns": [],		// connections to RALs for cost calculation: <""|*internal|$rpc_conns_id>
@@ -502,6 +523,33 @@ const CGRATES_CFG_JSON = `
 				// "natsClientCertificate": "",			// the path to a client certificate( used by tls)
 				// "natsClientKey": "",				// the path to a client key( used by tls)
 				// "natsJetStreamMaxWait": "5s",		// the maximum amount of time to wait for a response
+
+				// mqtt
+				// "mqttTopic": "cgrates_cdrs",			// the topic filter the reader subscribes to, wildcards + and # are supported
+				// "mqttQoS": 1,				// the QoS level of the subscription <0|1|2>
+				// "mqttSharedGroup": "",			// when set the subscription is shared between the readers in the same group($share/{group}/{topic})
+				// "mqttClientID": "",				// the client identifier, defaults to cgrates_{node_id}_{reader_id}
+				// "mqttUsername": "",				// the username used to authenticate to the broker
+				// "mqttPassword": "",				// the password used to authenticate to the broker
+				// "mqttTLS": false,				// connect to the broker over TLS( also enabled by ssl:// or mqtts:// source_path)
+				// "mqttCAPath": "",				// the path to a custom certificate authority file( used by tls)
+				// "mqttSkipTLSVerify": false,			// if enabled the broker certificate is not verified
+				// "mqttClientCertificate": "",			// the path to a client certificate( used by tls)
+				// "mqttClientKey": "",				// the path to a client key( used by tls)
+				// redis streams
+				// "redisStream": "cgrates_cdrs",		// the stream key from where the events are read
+				// "redisGroup": "cgrates",			// the consumer group shared by the readers, created at the beginning of the stream if missing
+				// "redisConsumer": "",			// the consumer name within the group, defaults to cgrates_{node_id}_{reader_id}
+				// "redisField": "event",			// the entry field holding the JSON encoded event
+				// "redisCount": 10,				// the maximum number of entries read with one XREADGROUP
+				// "redisBlock": "1s",				// how long XREADGROUP blocks waiting for new entries
+				// "redisClaimMinIdle": "1m",			// pending entries idle for longer are claimed from crashed consumers, 0 disables the reclaim
+				// "redisClaimInterval": "30s",		// how often the pending entries are checked for reclaim
+				// "redisMaxDeliveries": 5,			// failed entries delivered as many times are acknowledged and sent to ees_failed_ids, 0 to retry forever
+				// "redisTLS": false,				// connect to the server over TLS( also enabled by rediss:// source_path)
+				// "redisClientCertificate": "",		// the path to a client certificate( used by tls)
+				// "redisClientKey": "",			// the path to a client key( used by tls)
+				// "redisCACertificate": "",			// the path to a custom certificate authority file( used by tls)
 			},
 			"fields":[						// import fields template, tag will match internally CDR field, in case of .csv value will be represented by index of the field value
 				{"tag": "ToR", "path": "*cgreq.ToR", "type": "*variable", "value": "~*req.2", "mandatory": true},
@@ -594,6 +642,10 @@ const CGRATES_CFG_JSON = `
 				// "sqlMaxOpenConns": 0,	// SQLMaxOpenConns
 				// "sqlConnMaxLifetime": "0",	// SQLConnMaxLifetime
 				// "sqlUpdateIndexedFields": [], // list of field names used for indexing UPDATE queries from the table
+				// "sqlBatchSize": 0,		// number of rows inserted with one query, 0 or 1 to insert each row on export
+				// "sqlBatchInterval": "0",	// interval after which the incomplete batch is inserted, 0 to wait for a full batch
+				// "sqlConflictFields": [],	// columns of the unique key used to update the existing rows on insert (upsert)
+				// "sqlCreateTable": false,	// create the table if missing, with the columns built out of the fields template
 				// "mysqlDSNParams": {},        // DSN params
 
 
@@ -646,6 +698,38 @@ const CGRATES_CFG_JSON = `
 				// "natsClientKey": "",			// the path to a client key( used by tls)
 				// "natsJetStreamMaxWait": "5s",	// the maximum amount of time to wait for a response
 
+				// MQTT
+				// "mqttTopic": "cgrates_cdrs",		// the topic were the events are exported
+				// "mqttQoS": 1,			// the QoS level used when publishing <0|1|2>
+				// "mqttRetain": false,			// publish the events as retained messages
+				// "mqttClientID": "",			// the client identifier, defaults to cgrates_{node_id}_{exporter_id}
+				// "mqttUsername": "",			// the username used to authenticate to the broker
+				// "mqttPassword": "",			// the password used to authenticate to the broker
+				// "mqttTLS": false,			// connect to the broker over TLS( also enabled by ssl:// or mqtts:// export_path)
+				// "mqttCAPath": "",			// the path to a custom certificate authority file( used by tls)
+				// "mqttSkipTLSVerify": false,		// if enabled the broker certificate is not verified
+				// "mqttClientCertificate": "",		// the path to a client certificate( used by tls)
+				// "mqttClientKey": "",			// the path to a client key( used by tls)
+				// Redis Streams
+				// "redisStream": "cgrates_cdrs",	// the stream key where the events are added
+				// "redisField": "event",		// the entry field holding the JSON encoded event
+				// "redisMaxLen": 0,			// trim the stream to this length on each XADD, 0 disables trimming
+				// "redisMaxLenApprox": true,		// trim with ~ allowing Redis to keep a few more entries for efficiency
+				// "redisTLS": false,			// connect to the server over TLS( also enabled by rediss:// export_path)
+				// "redisClientCertificate": "",	// the path to a client certificate( used by tls)
+				// "redisClientKey": "",		// the path to a client key( used by tls)
+				// "redisCACertificate": "",		// the path to a custom certificate authority file( used by tls)
+
+				// Webhook
+				// "webhookSecret": "",			// the secret used to sign the requests with HMAC-SHA256, empty disables signing
+				// "webhookSignatureHeader": "X-CGR-Signature",	// the header carrying the signature( sha256={hex})
+				// "webhookTimestampHeader": "X-CGR-Timestamp",	// the header carrying the unix timestamp included in the signature
+				// "webhookAuthHeaders": {},		// static authentication headers added to each request( ie. {"Authorization": "Bearer token"})
+				// "webhookBackoff": "1s",		// the initial delay between retries, doubled on each attempt and randomized with jitter
+				// "webhookMaxBackoff": "30s",		// the maximum delay between retries, also the maximum Retry-After that is honored
+				// "webhookBreakerThreshold": 5,	// consecutive failures that open the circuit breaker, 0 disables it
+				// "webhookBreakerCooldown": "30s",	// how long the circuit breaker stays open before allowing a probe request
+
 				//RPC
 				// "rpcCodec": "",  		// for compression, encoding and decoding <internalRPC | BIRPC | JSON/HTTP/GOB>
 				// "serviceMethod": "", 	// the method that should be called trough RPC
@@ -672,6 +756,7 @@ const CGRATES_CFG_JSON = `
 	"rals_conns": [],			// connections to RALs for rating/accounting <""|*internal|$rpc_conns_id>
 	"cdrs_conns": [],			// connections to CDRs for CDR posting <""|*internal|$rpc_conns_id>
 	"ips_conns": [],			// connections to IPs for monitoring ip usage <""|*internal|$rpc_conns_id>
+	"fraud_conns": [],			// connections to FraudS for scoring the authorized and initiated sessions <""|*internal|$rpc_conns_id>
 	"resources_conns": [],			// connections to ResourceS for resources monitoring <""|*internal|$rpc_conns_id>
 	"thresholds_conns": [],			// connections to ThresholdS for reporting session events <""|*internal|$rpc_conns_id>
 	"stats_conns": [],			// connections to StatS for reporting session events <""|*internal|$rpc_conns_id>
@@ -960,6 +1045,48 @@ const CGRATES_CFG_JSON = `
 },
 
 
+"invoices": {					// InvoiceS config
+	"enabled": false,			// starts InvoiceS service: <true|false>.
+	"cdrs_conns": [],			// connections to CDRs for querying the invoiced CDRs: <""|*internal|$rpc_conns_id>
+	"ees_conns": [],			// connections to EEs for delivering the issued invoices, empty to disable delivery: <""|*internal|$rpc_conns_id>
+	"ees_exporter_ids": [],		// list of EventExporter profiles delivering the invoices
+	"run_ids": ["*default"],	// only the CDRs of these runs are invoiced
+	"number_prefix": "INV",		// prefix of the invoice numbers, followed by the per tenant sequence
+	"tax_rates": {},			// tax percentage per destination ID, *any for the rest, eg: {"DST_DE": 19, "*any": 20}
+	"html_template": "",		// path to the html/template used for *html invoices, empty for the built-in one
+	"document_format": "*json"	// format of the invoice document delivered through EEs: <*json|*csv|*html>
+},
+
+
+"fraud": {					// FraudS
+	"enabled": false,			// starts FraudS service: <true|false>
+	"thresholds_conns": [],			// connections to ThresholdS for the fraud alerts, empty to disable alerts: <""|*internal|$rpc_conns_id>
+	"session_ttl": "3h",			// active sessions not released within this interval are no longer counted
+	"cleanup_interval": "1h",		// interval to remove the accounts without recent activity, 0 to disable: <""|$dur>
+	"profiles": [				// the matching profile with the highest weight scores the account
+		// {
+		// 	"id": "DEFAULT",			// unique profile id
+		// 	"filters": [],				// filters selecting the events scored by the profile
+		// 	"weight": 0,				// used to choose between the matching profiles
+		// 	"window": "1h",				// sliding window the indicators are computed on
+		// 	"baseline": "168h",			// history the window values are compared with, multiple of window
+		// 	"flag_score": 50,			// score flagging the event and raising an alert, 0 to disable
+		// 	"block_score": 100,			// score blocking the event, 0 to disable
+		// 	"threshold_ids": [],			// thresholds processing the alerts, empty for the matching ones
+		// 	"indicators": [
+		// 		{
+		// 			"type": "*cost_per_hour",	// <*cost_per_hour|*high_risk_calls|*concurrent_calls|*new_destinations>
+		// 			"filters": [],			// only the events passing them are counted by the indicator
+		// 			"factor": 3,			// triggers when the value exceeds the baseline multiplied by this
+		// 			"min_value": 10,		// the value needs to reach this before triggering
+		// 			"score": 50			// added to the event score when triggered
+		// 		}
+		// 	]
+		// }
+	]
+},
+
+
 "thresholds": {					// ThresholdS
 	"enabled": false,			// starts ThresholdS service: <true|false>.
 	"store_interval": "",			// dump cache regularly to dataDB, 0 - dump at start/shutdown: <""|$dur>
@@ -1343,6 +1470,19 @@ const CGRATES_CFG_JSON = `
 },
 
 
+"smpp_agent": {
+	"enabled": false,				// enables the SMPP agent: <true|false>
+	"listen": "127.0.0.1:2775",			// address where to listen for ESME connections <x.y.z.y:1234>
+	"system_id": "cgrates",				// system_id sent back to the ESMEs on bind
+	"bind_credentials": {},				// passwords per ESME system_id, empty to accept all binds <{"$system_id": "$password"}>
+	"sessions_conns": ["*internal"],
+	"stats_conns": [],				// connections to StatS, empty to disable: <""|*internal|$rpc_conns_id>
+	"thresholds_conns": [],				// connections to ThresholdS, empty to disable: <""|*internal|$rpc_conns_id>
+	"timezone": "",					// timezone of the events if not specified  <UTC|Local|$IANA_TZ_DB>
+	"request_processors": []			// request processors to be applied to submit_sm/deliver_sm PDUs
+},
+
+
 "janus_agent": {
 	"enabled": false,				// enables the Janus agent: <true|false>
 	"url": "/janus",
@@ -1479,6 +1619,23 @@ const CGRATES_CFG_JSON = `
 },
 
 
+"api_auth": {
+	"enabled": false,				// enables the API authentication on the HTTP, WebSocket, *json and *gob listeners: <true|false>
+	"jwt_secret": "",				// HMAC secret verifying the HS256 JWTs, empty to disable the JWT authentication
+	"roles": {},					// allowed ServiceMethod patterns per role <{"$role": ["APIerSv1.Get*", "SessionSv1.*"]}>, *any allows all of them
+	"api_keys": {},					// API keys indexed on their ID <{"$id": {"key": "$key", "tenant": "$tenant", "roles": ["$role"]}}>, *any tenant allows all of them
+},
+
+
+"audit": {
+	"enabled": false,				// records the changes of the dataDB profiles on the audit trail: <true|false>
+	"object_types": [				// the profiles recorded <*rating_profiles|*attribute_profiles|*filters|*route_profiles>
+		"*rating_profiles", "*attribute_profiles",
+		"*filters", "*route_profiles",
+	],
+},
+
+
 "sentrypeer":{
 	 "client_id":"",
 	 "client_secret":"",
@@ -641,6 +641,8 @@ func testCGRConfigReloadERs(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 			{
@@ -675,6 +677,8 @@ func testCGRConfigReloadERs(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 		},
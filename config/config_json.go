@@ -47,6 +47,8 @@ const (
 	THRESHOLDS_JSON     = "thresholds"
 	TRENDS_JSON         = "trends"
 	RANKINGS_JSON       = "rankings"
+	InvoiceSJson        = "invoices"
+	FraudSJson          = "fraud"
 	RouteSJson          = "routes"
 	LoaderJson          = "loaders"
 	MAILER_JSN          = "mailer"
@@ -65,9 +67,12 @@ const (
 	RPCConnsJsonName    = "rpc_conns"
 	SIPAgentJson        = "sip_agent"
 	JanusAgentJson      = "janus_agent"
+	SMPPAgentJson       = "smpp_agent"
 	TemplatesJson       = "templates"
 	ConfigSJson         = "configs"
 	APIBanCfgJson       = "apiban"
+	APIAuthCfgJson      = "api_auth"
+	AuditCfgJson        = "audit"
 	SentryPeerCfgJson   = "sentrypeer"
 	CoreSCfgJson        = "cores"
 	IPsJSON             = "ips"
@@ -76,9 +81,9 @@ const (
 var (
 	sortedCfgSections = []string{GENERAL_JSN, RPCConnsJsonName, DATADB_JSN, STORDB_JSN, LISTEN_JSN, TlsCfgJson, HTTP_JSN, SCHEDULER_JSN,
 		CACHE_JSN, FilterSjsn, RALS_JSN, CDRS_JSN, ERsJson, SessionSJson, AsteriskAgentJSN, FreeSWITCHAgentJSN, KamailioAgentJSN,
-		DA_JSN, RA_JSN, HttpAgentJson, DNSAgentJson, PrometheusAgentJSON, ATTRIBUTE_JSN, ChargerSCfgJson, RESOURCES_JSON, STATS_JSON, TRENDS_JSON, RANKINGS_JSON,
+		DA_JSN, RA_JSN, HttpAgentJson, DNSAgentJson, PrometheusAgentJSON, ATTRIBUTE_JSN, ChargerSCfgJson, RESOURCES_JSON, STATS_JSON, TRENDS_JSON, RANKINGS_JSON, InvoiceSJson, FraudSJson,
 		THRESHOLDS_JSON, RouteSJson, LoaderJson, MAILER_JSN, SURETAX_JSON, CgrLoaderCfgJson, CgrMigratorCfgJson, DispatcherSJson, JanusAgentJson,
-		AnalyzerCfgJson, ApierS, EEsJson, SIPAgentJson, RegistrarCJson, TemplatesJson, ConfigSJson, APIBanCfgJson, SentryPeerCfgJson, CoreSCfgJson, IPsJSON}
+		AnalyzerCfgJson, ApierS, EEsJson, SIPAgentJson, SMPPAgentJson, RegistrarCJson, TemplatesJson, ConfigSJson, APIBanCfgJson, APIAuthCfgJson, AuditCfgJson, SentryPeerCfgJson, CoreSCfgJson, IPsJSON}
 )
 
 // Loads the json config out of io.Reader, eg other sources than file, maybe over http
@@ -409,6 +414,30 @@ func (jsnCfg CgrJsonCfg) RankingsJsonCfg() (*RankingsJsonCfg, error) {
 	return cfg, nil
 }
 
+func (jsnCfg CgrJsonCfg) FraudSJsonCfg() (*FraudSJsonCfg, error) {
+	rawCfg, hasKey := jsnCfg[FraudSJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(FraudSJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (jsnCfg CgrJsonCfg) InvoiceSJsonCfg() (*InvoiceSJsonCfg, error) {
+	rawCfg, hasKey := jsnCfg[InvoiceSJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(InvoiceSJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func (jsnCfg CgrJsonCfg) ThresholdSJsonCfg() (*ThresholdSJsonCfg, error) {
 	rawCfg, hasKey := jsnCfg[THRESHOLDS_JSON]
 	if !hasKey {
@@ -565,6 +594,18 @@ func (jsnCfg CgrJsonCfg) SIPAgentJsonCfg() (*SIPAgentJsonCfg, error) {
 	return sipAgnt, nil
 }
 
+func (jsnCfg CgrJsonCfg) SMPPAgentJsonCfg() (*SMPPAgentJsonCfg, error) {
+	rawCfg, hasKey := jsnCfg[SMPPAgentJson]
+	if !hasKey {
+		return nil, nil
+	}
+	smppAgnt := new(SMPPAgentJsonCfg)
+	if err := json.Unmarshal(*rawCfg, smppAgnt); err != nil {
+		return nil, err
+	}
+	return smppAgnt, nil
+}
+
 func (jsnCfg CgrJsonCfg) JanusAgentCfgJson() (*JanusAgentJsonCfg, error) {
 	raw, haskey := jsnCfg[JanusAgentJson]
 	if !haskey {
@@ -613,6 +654,30 @@ func (jsnCfg CgrJsonCfg) ApiBanCfgJson() (*APIBanJsonCfg, error) {
 	return cfg, nil
 }
 
+func (jsnCfg CgrJsonCfg) AuditCfgJson() (*AuditJsonCfg, error) {
+	rawCfg, hasKey := jsnCfg[AuditCfgJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(AuditJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (jsnCfg CgrJsonCfg) APIAuthCfgJson() (*APIAuthJsonCfg, error) {
+	rawCfg, hasKey := jsnCfg[APIAuthCfgJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(APIAuthJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func (jsnCfg CgrJsonCfg) SentryPeerJson() (*SentryPeerJsonCfg, error) {
 	rawCfg, hasKey := jsnCfg[SentryPeerCfgJson]
 	if !hasKey {
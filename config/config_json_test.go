@@ -48,6 +48,8 @@ func TestDfGeneralJsonCfg(t *testing.T) {
 		Connect_timeout:        utils.StringPointer("1s"),
 		Reply_timeout:          utils.StringPointer("2s"),
 		Locking_timeout:        utils.StringPointer("0"),
+		Distributed_locking:    utils.BoolPointer(false),
+		Locking_lease_ttl:      utils.StringPointer("5s"),
 		Digest_separator:       utils.StringPointer(","),
 		Digest_equal:           utils.StringPointer(":"),
 		Rsr_separator:          utils.StringPointer(";"),
@@ -148,6 +150,12 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheRankings: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Remote: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheDataSets: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Precache: utils.BoolPointer(false), Remote: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheTaxProfiles: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Precache: utils.BoolPointer(false), Remote: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
 			utils.CacheStatQueues: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Remote: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
@@ -199,6 +207,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheDispatcherFilterIndexes: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Remote: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheTaxProfileFilterIndexes: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Remote: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
 			utils.CacheReverseFilterIndexes: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Remote: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
@@ -312,6 +323,8 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 			InternalDBDumpInterval:    utils.StringPointer("0s"),
 			InternalDBRewriteInterval: utils.StringPointer("0s"),
 			InternalDBFileSizeLimit:   utils.StringPointer("1GB"),
+			InternalDBWAL:             utils.BoolPointer(false),
+			InternalDBWALSyncInterval: utils.StringPointer("0s"),
 			RedisMaxConns:             utils.IntPointer(10),
 			RedisConnectAttempts:      utils.IntPointer(20),
 			RedisSentinel:             utils.StringPointer(utils.EmptyString),
@@ -478,6 +491,20 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Static_ttl: utils.BoolPointer(false),
 			},
+			utils.MetaDataSets: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
+			utils.MetaTaxProfiles: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
 			utils.MetaThresholds: {
 				Replicate:  utils.BoolPointer(false),
 				Remote:     utils.BoolPointer(false),
@@ -605,6 +632,13 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Static_ttl: utils.BoolPointer(false),
 			},
+			utils.CacheTaxProfileFilterIndexes: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
 			utils.CacheReverseFilterIndexes: {
 				Replicate:  utils.BoolPointer(false),
 				Remote:     utils.BoolPointer(false),
@@ -619,6 +653,20 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Static_ttl: utils.BoolPointer(false),
 			},
+			utils.MetaInvoices: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
+			utils.MetaAuditRecords: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
 		},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
@@ -649,6 +697,8 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 			InternalDBDumpInterval:    utils.StringPointer("0s"),
 			InternalDBRewriteInterval: utils.StringPointer("0s"),
 			InternalDBFileSizeLimit:   utils.StringPointer("1GB"),
+			InternalDBWAL:             utils.BoolPointer(false),
+			InternalDBWALSyncInterval: utils.StringPointer("0s"),
 			SQLMaxOpenConns:           utils.IntPointer(100),
 			SQLMaxIdleConns:           utils.IntPointer(10),
 			SQLLogLevel:               utils.IntPointer(3),
@@ -766,6 +816,13 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Static_ttl: utils.BoolPointer(false),
 			},
+			utils.CacheTBLTPTaxProfiles: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
 			utils.CacheTBLTPThresholds: {
 				Replicate:  utils.BoolPointer(false),
 				Remote:     utils.BoolPointer(false),
@@ -895,6 +952,7 @@ func TestDfSchedulerJsonCfg(t *testing.T) {
 		Cdrs_conns:              &[]string{},
 		Thresholds_conns:        &[]string{},
 		Stats_conns:             &[]string{},
+		Invoices_conns:          &[]string{},
 		Filters:                 &[]string{},
 		Dynaprepaid_actionplans: &[]string{},
 	}
@@ -915,6 +973,7 @@ func TestDfCdrsJsonCfg(t *testing.T) {
 		Extra_fields:         &[]string{},
 		Store_cdrs:           utils.BoolPointer(true),
 		Compress_stored_cost: utils.BoolPointer(false),
+		Apply_taxes:          utils.BoolPointer(false),
 		Session_cost_retries: utils.IntPointer(5),
 		Chargers_conns:       &[]string{},
 		Rals_conns:           &[]string{},
@@ -945,6 +1004,7 @@ func TestSmgJsonCfg(t *testing.T) {
 		RALsConns:              &[]string{},
 		CDRsConns:              &[]string{},
 		IPsConns:               &[]string{},
+		FraudSConns:            &[]string{},
 		ResourceSConns:         &[]string{},
 		ThresholdSConns:        &[]string{},
 		StatSConns:             &[]string{},
@@ -2005,6 +2065,10 @@ func TestDfHttpJsonCfg(t *testing.T) {
 		Json_rpc_url:        utils.StringPointer("/jsonrpc"),
 		Registrars_url:      utils.StringPointer("/registrar"),
 		Ws_url:              utils.StringPointer("/ws"),
+		Birpc_ws_url:        utils.StringPointer(""),
+		Birpc_ws_ping:       utils.StringPointer("30s"),
+		Birpc_ws_origins:    &[]string{},
+		Birpc_ws_read_limit: utils.Int64Pointer(1048576),
 		Freeswitch_cdrs_url: utils.StringPointer("/freeswitch_json"),
 		Http_Cdrs:           utils.StringPointer("/cdr_http"),
 		PprofPath:           utils.StringPointer("/debug/pprof/"),
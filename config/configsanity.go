@@ -193,6 +193,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SessionS, connID)
 			}
 		}
+		for _, connID := range cfg.sessionSCfg.FraudSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.fraudSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.FraudS, utils.SessionS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SessionS, connID)
+			}
+		}
 		for _, connID := range cfg.sessionSCfg.ResourceSConns {
 			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.resourceSCfg.Enabled {
 				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.ResourceS, utils.SessionS)
@@ -578,10 +586,10 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> template with ID <%s> has connection with id: <%s> not defined", utils.HTTPAgent, httpAgentCfg.ID, connID)
 			}
 		}
-		if !slices.Contains([]string{utils.MetaUrl, utils.MetaXml}, httpAgentCfg.RequestPayload) {
+		if !slices.Contains([]string{utils.MetaUrl, utils.MetaXml, utils.MetaJSON}, httpAgentCfg.RequestPayload) {
 			return fmt.Errorf("<%s> unsupported request payload %s", utils.HTTPAgent, httpAgentCfg.RequestPayload)
 		}
-		if !slices.Contains([]string{utils.MetaTextPlain, utils.MetaXml}, httpAgentCfg.ReplyPayload) {
+		if !slices.Contains([]string{utils.MetaTextPlain, utils.MetaXml, utils.MetaJSON}, httpAgentCfg.ReplyPayload) {
 			return fmt.Errorf("<%s> unsupported reply payload %s", utils.HTTPAgent, httpAgentCfg.ReplyPayload)
 		}
 		for _, req := range httpAgentCfg.RequestProcessors {
@@ -692,6 +700,75 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		}
 	}
 
+	// SMPP Agent
+	if cfg.smppAgentCfg.Enabled {
+		if len(cfg.smppAgentCfg.SessionSConns) == 0 {
+			return fmt.Errorf("<%s> no %s connections defined",
+				utils.SMPPAgent, utils.SessionS)
+		}
+		for _, connID := range cfg.smppAgentCfg.SessionSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.sessionSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.SessionS, utils.SMPPAgent)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SMPPAgent, connID)
+			}
+		}
+		for _, connID := range cfg.smppAgentCfg.StatSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.statsCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.StatS, utils.SMPPAgent)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SMPPAgent, connID)
+			}
+		}
+		for _, connID := range cfg.smppAgentCfg.ThresholdSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.thresholdSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.ThresholdS, utils.SMPPAgent)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SMPPAgent, connID)
+			}
+		}
+		for _, req := range cfg.smppAgentCfg.RequestProcessors {
+			for _, field := range req.RequestFields {
+				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, utils.NewErrMandatoryIeMissing(utils.Path), req.ID, field.Tag)
+				}
+				if err := utils.IsPathValidForExporters(field.Path); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, err, field.Path, utils.Path)
+				}
+				for _, val := range field.Value {
+					if err := utils.IsPathValidForExporters(val.path); err != nil {
+						return fmt.Errorf("<%s> %s for %s at %s of %s", utils.SMPPAgent, err, val.path, utils.Values, utils.RequestFieldsCfg)
+					}
+				}
+				if err := utils.CheckInLineFilter(field.Filters); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, err, field.Filters, utils.RequestFieldsCfg)
+				}
+			}
+			for _, field := range req.ReplyFields {
+				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, utils.NewErrMandatoryIeMissing(utils.Path), req.ID, field.Tag)
+				}
+				if err := utils.IsPathValidForExporters(field.Path); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, err, field.Path, utils.Path)
+				}
+				for _, val := range field.Value {
+					if err := utils.IsPathValidForExporters(val.path); err != nil {
+						return fmt.Errorf("<%s> %s for %s at %s of %s", utils.SMPPAgent, err, val.path, utils.Values, utils.ReplyFieldsCfg)
+					}
+				}
+				if err := utils.CheckInLineFilter(field.Filters); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, err, field.Filters, utils.ReplyFieldsCfg)
+				}
+			}
+			if err := utils.CheckInLineFilter(req.Filters); err != nil {
+				return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, err, req.Filters, utils.RequestProcessorsCfg)
+			}
+		}
+	}
+
 	if cfg.attributeSCfg.Enabled {
 		if cfg.attributeSCfg.Opts.ProcessRuns < 1 {
 			return fmt.Errorf("<%s> process_runs needs to be bigger than 0", utils.AttributeS)
@@ -751,6 +828,69 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	// FraudS checks
+	if cfg.fraudSCfg.Enabled {
+		for _, connID := range cfg.fraudSCfg.ThresholdSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.thresholdSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.ThresholdS, utils.FraudS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.FraudS, connID)
+			}
+		}
+		fraudPrfIDs := utils.NewStringSet(nil)
+		for _, prf := range cfg.fraudSCfg.Profiles {
+			if prf.ID == utils.EmptyString {
+				return fmt.Errorf("<%s> profile without %s", utils.FraudS, utils.IDCfg)
+			}
+			if fraudPrfIDs.Has(prf.ID) {
+				return fmt.Errorf("<%s> duplicated profile <%s>", utils.FraudS, prf.ID)
+			}
+			fraudPrfIDs.Add(prf.ID)
+			if prf.Window <= 0 {
+				return fmt.Errorf("<%s> %s needs to be positive for profile <%s>", utils.FraudS, utils.WindowCfg, prf.ID)
+			}
+			if prf.Baseline < prf.Window || prf.Baseline%prf.Window != 0 {
+				return fmt.Errorf("<%s> %s needs to be a multiple of %s for profile <%s>", utils.FraudS, utils.BaselineCfg, utils.WindowCfg, prf.ID)
+			}
+			for _, ind := range prf.Indicators {
+				if !slices.Contains([]string{utils.MetaCostPerHour, utils.MetaHighRiskCalls,
+					utils.MetaConcurrentCalls, utils.MetaNewDestinations}, ind.Type) {
+					return fmt.Errorf("<%s> unsupported indicator type <%s> for profile <%s>", utils.FraudS, ind.Type, prf.ID)
+				}
+			}
+		}
+	}
+	// InvoiceS checks
+	if cfg.invoiceSCfg.Enabled {
+		if len(cfg.invoiceSCfg.CDRsConns) == 0 {
+			return fmt.Errorf("<%s> no %s connections defined", utils.InvoiceS, utils.CDRs)
+		}
+		for _, connID := range cfg.invoiceSCfg.CDRsConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.cdrsCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.CDRs, utils.InvoiceS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.InvoiceS, connID)
+			}
+		}
+		for _, connID := range cfg.invoiceSCfg.EEsConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.eesCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.EEs, utils.InvoiceS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.InvoiceS, connID)
+			}
+		}
+		if !slices.Contains([]string{utils.MetaJSON, utils.MetaCSV, utils.MetaHTML}, cfg.invoiceSCfg.DocumentFormat) {
+			return fmt.Errorf("<%s> unsupported %s: <%s>", utils.InvoiceS, utils.DocumentFormatCfg, cfg.invoiceSCfg.DocumentFormat)
+		}
+		for destID, rate := range cfg.invoiceSCfg.TaxRates {
+			if rate < 0 {
+				return fmt.Errorf("<%s> negative tax rate for destination <%s>", utils.InvoiceS, destID)
+			}
+		}
+	}
 	// RouteS checks
 	if cfg.routeSCfg.Enabled {
 		for _, connID := range cfg.routeSCfg.AttributeSConns {
@@ -812,6 +952,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SchedulerS, connID)
 			}
 		}
+		for _, connID := range cfg.schedulerCfg.InvoiceSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.invoiceSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.InvoiceS, utils.SchedulerS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SchedulerS, connID)
+			}
+		}
 		if err := utils.CheckInLineFilter(cfg.schedulerCfg.Filters); err != nil {
 			return fmt.Errorf("<%s> got %s in %s", utils.SchedulerS, err, utils.Filters)
 		}
@@ -928,6 +1076,41 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				if rdr.RunDelay > 0 {
 					return fmt.Errorf("<%s> the RunDelay field can not be bigger than zero for reader with ID: %s", utils.ERs, rdr.ID)
 				}
+			case utils.MetaMQTTjsonMap:
+				if mqttOpts := rdr.Opts.MQTT; mqttOpts != nil {
+					if mqttOpts.QoS != nil && (*mqttOpts.QoS < 0 || *mqttOpts.QoS > 2) {
+						return fmt.Errorf("<%s> invalid %s for reader with ID: %s", utils.ERs, utils.MQTTQoS, rdr.ID)
+					}
+					if (mqttOpts.ClientCertificate == nil) != (mqttOpts.ClientKey == nil) {
+						return fmt.Errorf("<%s> %s and %s need to be set together for reader with ID: %s",
+							utils.ERs, utils.MQTTClientCertificate, utils.MQTTClientKey, rdr.ID)
+					}
+				}
+			case utils.MetaRedisStreamjsonMap:
+				if redisOpts := rdr.Opts.RedisStream; redisOpts != nil {
+					if redisOpts.Group != nil && *redisOpts.Group == utils.EmptyString {
+						return fmt.Errorf("<%s> empty %s for reader with ID: %s", utils.ERs, utils.RedisGroup, rdr.ID)
+					}
+					if redisOpts.Count != nil && *redisOpts.Count < 1 {
+						return fmt.Errorf("<%s> invalid %s for reader with ID: %s", utils.ERs, utils.RedisCount, rdr.ID)
+					}
+					if redisOpts.Block != nil && *redisOpts.Block < 0 {
+						return fmt.Errorf("<%s> invalid %s for reader with ID: %s", utils.ERs, utils.RedisBlock, rdr.ID)
+					}
+					if redisOpts.ClaimMinIdle != nil && *redisOpts.ClaimMinIdle < 0 {
+						return fmt.Errorf("<%s> invalid %s for reader with ID: %s", utils.ERs, utils.RedisClaimMinIdle, rdr.ID)
+					}
+					if redisOpts.ClaimInterval != nil && *redisOpts.ClaimInterval <= 0 {
+						return fmt.Errorf("<%s> invalid %s for reader with ID: %s", utils.ERs, utils.RedisClaimInterval, rdr.ID)
+					}
+					if redisOpts.MaxDeliveries != nil && *redisOpts.MaxDeliveries < 0 {
+						return fmt.Errorf("<%s> invalid %s for reader with ID: %s", utils.ERs, utils.RedisMaxDeliveries, rdr.ID)
+					}
+					if (redisOpts.ClientCertificate == nil) != (redisOpts.ClientKey == nil) {
+						return fmt.Errorf("<%s> %s and %s need to be set together for reader with ID: %s",
+							utils.ERs, utils.RedisClientCertificate, utils.RedisClientKey, rdr.ID)
+					}
+				}
 			case utils.MetaFileXML, utils.MetaFileFWV, utils.MetaFileJSON:
 				for _, dir := range []string{rdr.ProcessedPath, rdr.SourcePath} {
 					if _, err := os.Stat(dir); err != nil && os.IsNotExist(err) {
@@ -1102,6 +1285,72 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 						return fmt.Errorf("<%s> CA certificate file not found at path: %s for exporter with ID: %s", utils.EEs, *elsOpts.CAPath, exp.ID)
 					}
 				}
+			case utils.MetaMQTTjsonMap:
+				if mqttOpts := exp.Opts.MQTT; mqttOpts != nil {
+					if mqttOpts.QoS != nil && (*mqttOpts.QoS < 0 || *mqttOpts.QoS > 2) {
+						return fmt.Errorf("<%s> invalid %s for exporter with ID: %s", utils.EEs, utils.MQTTQoS, exp.ID)
+					}
+					if (mqttOpts.ClientCertificate == nil) != (mqttOpts.ClientKey == nil) {
+						return fmt.Errorf("<%s> %s and %s need to be set together for exporter with ID: %s",
+							utils.EEs, utils.MQTTClientCertificate, utils.MQTTClientKey, exp.ID)
+					}
+				}
+			case utils.MetaRedisStreamjsonMap:
+				if redisOpts := exp.Opts.RedisStream; redisOpts != nil {
+					if redisOpts.MaxLen != nil && *redisOpts.MaxLen < 0 {
+						return fmt.Errorf("<%s> invalid %s for exporter with ID: %s", utils.EEs, utils.RedisMaxLen, exp.ID)
+					}
+					if (redisOpts.ClientCertificate == nil) != (redisOpts.ClientKey == nil) {
+						return fmt.Errorf("<%s> %s and %s need to be set together for exporter with ID: %s",
+							utils.EEs, utils.RedisClientCertificate, utils.RedisClientKey, exp.ID)
+					}
+				}
+			case utils.MetaSQL:
+				if sqlOpts := exp.Opts.SQL; sqlOpts != nil {
+					if sqlOpts.BatchSize != nil && *sqlOpts.BatchSize < 0 {
+						return fmt.Errorf("<%s> invalid %s for exporter with ID: %s", utils.EEs, utils.SQLBatchSize, exp.ID)
+					}
+					if sqlOpts.BatchInterval != nil && *sqlOpts.BatchInterval < 0 {
+						return fmt.Errorf("<%s> invalid %s for exporter with ID: %s", utils.EEs, utils.SQLBatchIntervalOpt, exp.ID)
+					}
+					if sqlOpts.ConflictFields != nil && len(*sqlOpts.ConflictFields) != 0 &&
+						sqlOpts.UpdateIndexedFields != nil {
+						return fmt.Errorf("<%s> %s cannot be used together with %s for exporter with ID: %s",
+							utils.EEs, utils.SQLConflictFieldsOpt, utils.SQLUpdateIndexedFieldsOpt, exp.ID)
+					}
+					if sqlOpts.CreateTable != nil && *sqlOpts.CreateTable {
+						if len(exp.Fields) == 0 {
+							return fmt.Errorf("<%s> %s requires the fields template for exporter with ID: %s",
+								utils.EEs, utils.SQLCreateTableOpt, exp.ID)
+						}
+						for _, field := range exp.Fields {
+							if field.Path == utils.MetaExp+utils.NestingSep+utils.MetaRow {
+								return fmt.Errorf("<%s> %s cannot be used with %s fields for exporter with ID: %s",
+									utils.EEs, utils.SQLCreateTableOpt, utils.MetaRow, exp.ID)
+							}
+						}
+					}
+				}
+			case utils.MetaWebhook:
+				if whOpts := exp.Opts.Webhook; whOpts != nil {
+					if whOpts.Backoff != nil && *whOpts.Backoff <= 0 {
+						return fmt.Errorf("<%s> %s must be greater than 0 for exporter with ID: %s", utils.EEs, utils.WebhookBackoff, exp.ID)
+					}
+					backoff := utils.WebhookDefaultBackoff
+					if whOpts.Backoff != nil {
+						backoff = *whOpts.Backoff
+					}
+					if whOpts.MaxBackoff != nil && *whOpts.MaxBackoff < backoff {
+						return fmt.Errorf("<%s> %s must not be lower than %s for exporter with ID: %s",
+							utils.EEs, utils.WebhookMaxBackoff, utils.WebhookBackoff, exp.ID)
+					}
+					if whOpts.BreakerThreshold != nil && *whOpts.BreakerThreshold < 0 {
+						return fmt.Errorf("<%s> invalid %s for exporter with ID: %s", utils.EEs, utils.WebhookBreakerThreshold, exp.ID)
+					}
+					if whOpts.BreakerCooldown != nil && *whOpts.BreakerCooldown <= 0 {
+						return fmt.Errorf("<%s> %s must be greater than 0 for exporter with ID: %s", utils.EEs, utils.WebhookBreakerCooldown, exp.ID)
+					}
+				}
 			}
 			for _, field := range exp.Fields {
 				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
@@ -1145,6 +1394,11 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		return fmt.Errorf("<%s> internalDBFileSizeLimit field cannot be equal or smaller than 0: <%v>", utils.StorDB,
 			cfg.storDbCfg.Opts.InternalDBFileSizeLimit)
 	}
+	if cfg.storDbCfg.Type == utils.MetaInternal &&
+		cfg.storDbCfg.Opts.InternalDBWAL &&
+		cfg.storDbCfg.Opts.InternalDBDumpInterval == 0 {
+		return fmt.Errorf("<%s> internalDBWAL requires the internalDBDumpInterval to be enabled", utils.StorDB)
+	}
 	if cfg.storDbCfg.Type == utils.MetaPostgres {
 		if !slices.Contains([]string{utils.PgSSLModeDisable, utils.PgSSLModeAllow,
 			utils.PgSSLModePrefer, utils.PgSSLModeRequire, utils.PgSSLModeVerifyCA,
@@ -1169,6 +1423,20 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			return fmt.Errorf("<%s> internalDBFileSizeLimit field cannot be equal or smaller than 0: <%v>", utils.DataDB,
 				cfg.dataDbCfg.Opts.InternalDBFileSizeLimit)
 		}
+		if cfg.dataDbCfg.Opts.InternalDBWAL &&
+			cfg.dataDbCfg.Opts.InternalDBDumpInterval == 0 {
+			return fmt.Errorf("<%s> internalDBWAL requires the internalDBDumpInterval to be enabled", utils.DataDB)
+		}
+		if cfg.generalCfg.DistributedLocking && len(cfg.dataDbCfg.RplConns) == 0 {
+			return fmt.Errorf("<%s> replication_conns required by distributed_locking", utils.DataDB)
+		}
+	}
+	if cfg.httpCfg.BiRPCWSURL != utils.EmptyString && cfg.httpCfg.BiRPCWSReadLimit <= 0 {
+		return fmt.Errorf("<%s> %s needs to be greater than 0 when %s is enabled", HTTP_JSN,
+			utils.BiRPCWSReadLimitCfg, utils.BiRPCWSURLCfg)
+	}
+	if cfg.generalCfg.DistributedLocking && cfg.generalCfg.LockingLeaseTTL <= 0 {
+		return fmt.Errorf("<%s> locking_lease_ttl needs to be greater than 0 when distributed_locking is enabled", GENERAL_JSN)
 	}
 	for item, val := range cfg.dataDbCfg.Items {
 		if val.Remote && len(cfg.dataDbCfg.RmtConns) == 0 {
@@ -1396,5 +1664,39 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		}
 	}
 
+	// API authentication
+	if cfg.apiAuthCfg.Enabled {
+		if len(cfg.apiAuthCfg.APIKeys) == 0 && cfg.apiAuthCfg.JWTSecret == utils.EmptyString {
+			return fmt.Errorf("<%s> enabled without any %s or %s", utils.APIAuth, utils.APIKeysCfg, utils.JWTSecretCfg)
+		}
+		keys := make(utils.StringSet)
+		for id, key := range cfg.apiAuthCfg.APIKeys {
+			if key.Key == utils.EmptyString {
+				return fmt.Errorf("<%s> missing %s for API key <%s>", utils.APIAuth, utils.KeyCfg, id)
+			}
+			if keys.Has(key.Key) {
+				return fmt.Errorf("<%s> duplicated %s for API key <%s>", utils.APIAuth, utils.KeyCfg, id)
+			}
+			keys.Add(key.Key)
+			if key.Tenant == utils.EmptyString {
+				return fmt.Errorf("<%s> missing %s for API key <%s>", utils.APIAuth, utils.TenantCfg, id)
+			}
+			for _, role := range key.Roles {
+				if _, has := cfg.apiAuthCfg.Roles[role]; !has {
+					return fmt.Errorf("<%s> role <%s> of API key <%s> not defined", utils.APIAuth, role, id)
+				}
+			}
+		}
+	}
+
+	// audit trail
+	if cfg.auditCfg.Enabled {
+		for objType := range cfg.auditCfg.ObjectTypes {
+			if !auditObjectTypes.Has(objType) {
+				return fmt.Errorf("<%s> unsupported %s: <%s>", AuditCfgJson, utils.ObjectTypesCfg, objType)
+			}
+		}
+	}
+
 	return nil
 }
@@ -19,6 +19,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -1336,6 +1337,71 @@ func TestConfigSanityEventReader(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 
+	cfg.ersCfg.Readers[0] = &EventReaderCfg{
+		ID:   "test6",
+		Type: utils.MetaMQTTjsonMap,
+		Opts: &EventReaderOpts{
+			PartialCacheAction: utils.StringPointer(utils.MetaNone),
+			MQTT: &MQTTROpts{
+				QoS: utils.IntPointer(3),
+			},
+		},
+	}
+	expected = "<ERs> invalid mqttQoS for reader with ID: test6"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.ersCfg.Readers[0].Opts.MQTT = &MQTTROpts{
+		ClientCertificate: utils.StringPointer("/tmp/client.crt"),
+	}
+	expected = "<ERs> mqttClientCertificate and mqttClientKey need to be set together for reader with ID: test6"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+
+	cfg.ersCfg.Readers[0] = &EventReaderCfg{
+		ID:   "test7",
+		Type: utils.MetaRedisStreamjsonMap,
+		Opts: &EventReaderOpts{
+			PartialCacheAction: utils.StringPointer(utils.MetaNone),
+			RedisStream: &RedisStreamROpts{
+				Group: utils.StringPointer(utils.EmptyString),
+			},
+		},
+	}
+	expected = "<ERs> empty redisGroup for reader with ID: test7"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.ersCfg.Readers[0].Opts.RedisStream = &RedisStreamROpts{
+		Count: utils.IntPointer(0),
+	}
+	expected = "<ERs> invalid redisCount for reader with ID: test7"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.ersCfg.Readers[0].Opts.RedisStream = &RedisStreamROpts{
+		ClaimInterval: utils.DurationPointer(0),
+	}
+	expected = "<ERs> invalid redisClaimInterval for reader with ID: test7"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.ersCfg.Readers[0].Opts.RedisStream = &RedisStreamROpts{
+		MaxDeliveries: utils.IntPointer(-1),
+	}
+	expected = "<ERs> invalid redisMaxDeliveries for reader with ID: test7"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.ersCfg.Readers[0].Opts.RedisStream = &RedisStreamROpts{
+		ClientKey: utils.StringPointer("/tmp/client.key"),
+	}
+	expected = "<ERs> redisClientCertificate and redisClientKey need to be set together for reader with ID: test7"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+
 	cfg.ersCfg = &ERsCfg{
 		Enabled: true,
 		Readers: []*EventReaderCfg{
@@ -1713,6 +1779,28 @@ func TestConfigSanityStorDB(t *testing.T) {
 	}
 }
 
+func TestConfigSanityInternalDBWAL(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.dataDbCfg.Type = utils.MetaInternal
+	cfg.dataDbCfg.Opts.InternalDBWAL = true
+	cfg.cacheCfg.Partitions = make(map[string]*CacheParamCfg)
+	expected := "<" + utils.DataDB + "> internalDBWAL requires the internalDBDumpInterval to be enabled"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.dataDbCfg.Opts.InternalDBDumpInterval = -1
+	cfg.storDbCfg.Type = utils.MetaInternal
+	cfg.storDbCfg.Opts.InternalDBWAL = true
+	expected = "<" + utils.StorDB + "> internalDBWAL requires the internalDBDumpInterval to be enabled"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.storDbCfg.Opts.InternalDBDumpInterval = time.Minute
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestConfigSanityAnalyzer(t *testing.T) {
 	cfg := NewDefaultCGRConfig()
 
@@ -1830,6 +1918,26 @@ func TestConfigSanityDataDB(t *testing.T) {
 	}
 }
 
+func TestConfigSanityDistributedLocking(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.generalCfg.DistributedLocking = true
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+	cfg.generalCfg.LockingLeaseTTL = 0
+	expected := "<general> locking_lease_ttl needs to be greater than 0 when distributed_locking is enabled"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.generalCfg.LockingLeaseTTL = 5 * time.Second
+	cfg.dataDbCfg.Type = utils.MetaInternal
+	cfg.cacheCfg = &CacheCfg{Partitions: map[string]*CacheParamCfg{}}
+	expected = "<data_db> replication_conns required by distributed_locking"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+}
+
 func TestConfigSanityAPIer(t *testing.T) {
 	cfg := NewDefaultCGRConfig()
 	cfg.apier.AttributeSConns = []string{utils.MetaInternal}
@@ -2106,3 +2214,162 @@ func TestConfigSanityERsXmlRootPath(t *testing.T) {
 		t.Errorf("expected: %s, received: %s", experr, err)
 	}
 }
+
+func TestConfigSanityWebhookExporter(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.eesCfg = &EEsCfg{
+		Enabled: true,
+		Exporters: []*EventExporterCfg{{
+			ID:   "webhook",
+			Type: utils.MetaWebhook,
+			Opts: &EventExporterOpts{
+				Webhook: &WebhookOpts{
+					Backoff: utils.DurationPointer(0),
+				},
+			},
+		}},
+	}
+	expected := "<EEs> webhookBackoff must be greater than 0 for exporter with ID: webhook"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Opts.Webhook = &WebhookOpts{
+		MaxBackoff: utils.DurationPointer(time.Millisecond),
+	}
+	expected = "<EEs> webhookMaxBackoff must not be lower than webhookBackoff for exporter with ID: webhook"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Opts.Webhook = &WebhookOpts{
+		BreakerThreshold: utils.IntPointer(-1),
+	}
+	expected = "<EEs> invalid webhookBreakerThreshold for exporter with ID: webhook"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Opts.Webhook = &WebhookOpts{
+		BreakerCooldown: utils.DurationPointer(0),
+	}
+	expected = "<EEs> webhookBreakerCooldown must be greater than 0 for exporter with ID: webhook"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Opts.Webhook = &WebhookOpts{
+		Backoff:    utils.DurationPointer(time.Second),
+		MaxBackoff: utils.DurationPointer(time.Minute),
+	}
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConfigSanitySQLExporter(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.eesCfg = &EEsCfg{
+		Enabled: true,
+		Exporters: []*EventExporterCfg{{
+			ID:   "sql",
+			Type: utils.MetaSQL,
+			Opts: &EventExporterOpts{
+				SQL: &SQLOpts{
+					BatchSize: utils.IntPointer(-1),
+				},
+			},
+		}},
+	}
+	expected := "<EEs> invalid sqlBatchSize for exporter with ID: sql"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Opts.SQL = &SQLOpts{
+		BatchInterval: utils.DurationPointer(-time.Second),
+	}
+	expected = "<EEs> invalid sqlBatchInterval for exporter with ID: sql"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Opts.SQL = &SQLOpts{
+		ConflictFields:      &[]string{"cgrid"},
+		UpdateIndexedFields: &[]string{"cgrid"},
+	}
+	expected = "<EEs> sqlConflictFields cannot be used together with sqlUpdateIndexedFields for exporter with ID: sql"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Opts.SQL = &SQLOpts{
+		CreateTable: utils.BoolPointer(true),
+	}
+	expected = "<EEs> sqlCreateTable requires the fields template for exporter with ID: sql"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Fields = []*FCTemplate{{
+		Tag:   "row",
+		Path:  utils.MetaExp + utils.NestingSep + utils.MetaRow,
+		Type:  utils.MetaVariable,
+		Value: NewRSRParsersMustCompile("~*req.CGRID", utils.InfieldSep),
+	}}
+	expected = "<EEs> sqlCreateTable cannot be used with *row fields for exporter with ID: sql"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Fields[0].Path = utils.MetaExp + utils.NestingSep + "cgrid"
+	for _, fld := range cfg.eesCfg.Exporters[0].Fields {
+		fld.ComputePath()
+	}
+	cfg.eesCfg.Exporters[0].Opts.SQL = &SQLOpts{
+		BatchSize:      utils.IntPointer(100),
+		BatchInterval:  utils.DurationPointer(time.Second),
+		ConflictFields: &[]string{"cgrid"},
+		CreateTable:    utils.BoolPointer(true),
+	}
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConfigSanityRedisStreamExporter(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.eesCfg = &EEsCfg{
+		Enabled: true,
+		Exporters: []*EventExporterCfg{{
+			ID:   "redis",
+			Type: utils.MetaRedisStreamjsonMap,
+			Opts: &EventExporterOpts{
+				RedisStream: &RedisStreamOpts{
+					MaxLen: utils.IntPointer(-1),
+				},
+			},
+		}},
+	}
+	expected := "<EEs> invalid redisMaxLen for exporter with ID: redis"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Opts.RedisStream = &RedisStreamOpts{
+		ClientCertificate: utils.StringPointer("/tmp/client.crt"),
+	}
+	expected = "<EEs> redisClientCertificate and redisClientKey need to be set together for exporter with ID: redis"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Opts.RedisStream = &RedisStreamOpts{
+		MaxLen: utils.IntPointer(1000),
+	}
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConfigSanityBiRPCWSReadLimit(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.httpCfg.BiRPCWSURL = "/birpc"
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+	cfg.httpCfg.BiRPCWSReadLimit = -1
+	expected := "<http> birpc_ws_read_limit needs to be greater than 0 when birpc_ws_url is enabled"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+}
@@ -35,6 +35,8 @@ type DataDBOpts struct {
 	InternalDBDumpInterval    time.Duration // Regurarly dump database to file
 	InternalDBRewriteInterval time.Duration // Regurarly rewrite dump files
 	InternalDBFileSizeLimit   int64         // maximum size that can be written in a singular dump file
+	InternalDBWAL             bool          // log the changes in a write-ahead log replayed on start-up
+	InternalDBWALSyncInterval time.Duration // fsync the write-ahead log: -1 on each change, 0 left to the OS
 	RedisMaxConns             int
 	RedisConnectAttempts      int
 	RedisSentinel             string
@@ -103,6 +105,14 @@ func (dbOpts *DataDBOpts) loadFromJSONCfg(jsnCfg *DBOptsJson) (err error) {
 			return err
 		}
 	}
+	if jsnCfg.InternalDBWAL != nil {
+		dbOpts.InternalDBWAL = *jsnCfg.InternalDBWAL
+	}
+	if jsnCfg.InternalDBWALSyncInterval != nil {
+		if dbOpts.InternalDBWALSyncInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.InternalDBWALSyncInterval); err != nil {
+			return err
+		}
+	}
 	if jsnCfg.RedisMaxConns != nil {
 		dbOpts.RedisMaxConns = *jsnCfg.RedisMaxConns
 	}
@@ -266,6 +276,8 @@ func (dbOpts *DataDBOpts) Clone() *DataDBOpts {
 		InternalDBDumpInterval:    dbOpts.InternalDBDumpInterval,
 		InternalDBRewriteInterval: dbOpts.InternalDBRewriteInterval,
 		InternalDBFileSizeLimit:   dbOpts.InternalDBFileSizeLimit,
+		InternalDBWAL:             dbOpts.InternalDBWAL,
+		InternalDBWALSyncInterval: dbOpts.InternalDBWALSyncInterval,
 		RedisMaxConns:             dbOpts.RedisMaxConns,
 		RedisConnectAttempts:      dbOpts.RedisConnectAttempts,
 		RedisSentinel:             dbOpts.RedisSentinel,
@@ -326,6 +338,8 @@ func (dbcfg *DataDbCfg) AsMapInterface() (mp map[string]any) {
 		utils.InternalDBDumpIntervalCfg:    dbcfg.Opts.InternalDBDumpInterval.String(),
 		utils.InternalDBRewriteIntervalCfg: dbcfg.Opts.InternalDBRewriteInterval.String(),
 		utils.InternalDBFileSizeLimitCfg:   dbcfg.Opts.InternalDBFileSizeLimit,
+		utils.InternalDBWALCfg:             dbcfg.Opts.InternalDBWAL,
+		utils.InternalDBWALSyncIntervalCfg: dbcfg.Opts.InternalDBWALSyncInterval.String(),
 		utils.RedisMaxConnsCfg:             dbcfg.Opts.RedisMaxConns,
 		utils.RedisConnectAttemptsCfg:      dbcfg.Opts.RedisConnectAttempts,
 		utils.RedisSentinelNameCfg:         dbcfg.Opts.RedisSentinel,
@@ -209,6 +209,10 @@ type SQLOpts struct {
 	DBName              *string
 	UpdateIndexedFields *[]string
 	PgSSLMode           *string
+	BatchSize           *int           // rows inserted with one query
+	BatchInterval       *time.Duration // flush the incomplete batches after this interval
+	ConflictFields      *[]string      // columns of the unique key used to upsert the rows
+	CreateTable         *bool          // create the table out of the fields template
 }
 
 type AMQPOpts struct {
@@ -245,6 +249,42 @@ type NATSOpts struct {
 	JetStreamMaxWait     *time.Duration
 }
 
+type MQTTOpts struct {
+	Topic             *string
+	QoS               *int
+	Retain            *bool
+	ClientID          *string
+	Username          *string
+	Password          *string
+	TLS               *bool
+	CAPath            *string
+	SkipTLSVerify     *bool
+	ClientCertificate *string
+	ClientKey         *string
+}
+
+type RedisStreamOpts struct {
+	Stream            *string
+	Field             *string
+	MaxLen            *int
+	MaxLenApprox      *bool
+	TLS               *bool
+	ClientCertificate *string
+	ClientKey         *string
+	CACertificate     *string
+}
+
+type WebhookOpts struct {
+	Secret           *string
+	SignatureHeader  *string
+	TimestampHeader  *string
+	AuthHeaders      map[string]string
+	Backoff          *time.Duration
+	MaxBackoff       *time.Duration
+	BreakerThreshold *int
+	BreakerCooldown  *time.Duration
+}
+
 type RPCOpts struct {
 	RPCCodec        *string
 	ServiceMethod   *string
@@ -273,6 +313,9 @@ type EventExporterOpts struct {
 	AMQP              *AMQPOpts
 	AWS               *AWSOpts
 	NATS              *NATSOpts
+	MQTT              *MQTTOpts
+	RedisStream       *RedisStreamOpts
+	Webhook           *WebhookOpts
 	RPC               *RPCOpts
 	Kafka             *KafkaOpts
 }
@@ -446,6 +489,24 @@ func (sqlOpts *SQLOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson) (err erro
 	if jsnCfg.PgSSLMode != nil {
 		sqlOpts.PgSSLMode = jsnCfg.PgSSLMode
 	}
+	if jsnCfg.SQLBatchSize != nil {
+		sqlOpts.BatchSize = jsnCfg.SQLBatchSize
+	}
+	if jsnCfg.SQLBatchInterval != nil {
+		var batchInterval time.Duration
+		if batchInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.SQLBatchInterval); err != nil {
+			return
+		}
+		sqlOpts.BatchInterval = utils.DurationPointer(batchInterval)
+	}
+	if jsnCfg.SQLConflictFields != nil {
+		cf := make([]string, len(*jsnCfg.SQLConflictFields))
+		copy(cf, *jsnCfg.SQLConflictFields)
+		sqlOpts.ConflictFields = &cf
+	}
+	if jsnCfg.SQLCreateTable != nil {
+		sqlOpts.CreateTable = jsnCfg.SQLCreateTable
+	}
 	return
 }
 
@@ -508,6 +569,114 @@ func (awsOpts *AWSOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson) (err erro
 	}
 	return
 }
+func (redisOpts *RedisStreamOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson) (err error) {
+	if jsnCfg.RedisStream != nil {
+		redisOpts.Stream = jsnCfg.RedisStream
+	}
+	if jsnCfg.RedisField != nil {
+		redisOpts.Field = jsnCfg.RedisField
+	}
+	if jsnCfg.RedisMaxLen != nil {
+		redisOpts.MaxLen = jsnCfg.RedisMaxLen
+	}
+	if jsnCfg.RedisMaxLenApprox != nil {
+		redisOpts.MaxLenApprox = jsnCfg.RedisMaxLenApprox
+	}
+	if jsnCfg.RedisTLS != nil {
+		redisOpts.TLS = jsnCfg.RedisTLS
+	}
+	if jsnCfg.RedisClientCertificate != nil {
+		redisOpts.ClientCertificate = jsnCfg.RedisClientCertificate
+	}
+	if jsnCfg.RedisClientKey != nil {
+		redisOpts.ClientKey = jsnCfg.RedisClientKey
+	}
+	if jsnCfg.RedisCACertificate != nil {
+		redisOpts.CACertificate = jsnCfg.RedisCACertificate
+	}
+	return
+}
+
+func (mqttOpts *MQTTOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson) (err error) {
+	if jsnCfg.MQTTTopic != nil {
+		mqttOpts.Topic = jsnCfg.MQTTTopic
+	}
+	if jsnCfg.MQTTQoS != nil {
+		mqttOpts.QoS = jsnCfg.MQTTQoS
+	}
+	if jsnCfg.MQTTRetain != nil {
+		mqttOpts.Retain = jsnCfg.MQTTRetain
+	}
+	if jsnCfg.MQTTClientID != nil {
+		mqttOpts.ClientID = jsnCfg.MQTTClientID
+	}
+	if jsnCfg.MQTTUsername != nil {
+		mqttOpts.Username = jsnCfg.MQTTUsername
+	}
+	if jsnCfg.MQTTPassword != nil {
+		mqttOpts.Password = jsnCfg.MQTTPassword
+	}
+	if jsnCfg.MQTTTLS != nil {
+		mqttOpts.TLS = jsnCfg.MQTTTLS
+	}
+	if jsnCfg.MQTTCAPath != nil {
+		mqttOpts.CAPath = jsnCfg.MQTTCAPath
+	}
+	if jsnCfg.MQTTSkipTLSVerify != nil {
+		mqttOpts.SkipTLSVerify = jsnCfg.MQTTSkipTLSVerify
+	}
+	if jsnCfg.MQTTClientCertificate != nil {
+		mqttOpts.ClientCertificate = jsnCfg.MQTTClientCertificate
+	}
+	if jsnCfg.MQTTClientKey != nil {
+		mqttOpts.ClientKey = jsnCfg.MQTTClientKey
+	}
+	return
+}
+
+func (whOpts *WebhookOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson) (err error) {
+	if jsnCfg.WebhookSecret != nil {
+		whOpts.Secret = jsnCfg.WebhookSecret
+	}
+	if jsnCfg.WebhookSignatureHeader != nil {
+		whOpts.SignatureHeader = jsnCfg.WebhookSignatureHeader
+	}
+	if jsnCfg.WebhookTimestampHeader != nil {
+		whOpts.TimestampHeader = jsnCfg.WebhookTimestampHeader
+	}
+	if jsnCfg.WebhookAuthHeaders != nil {
+		whOpts.AuthHeaders = make(map[string]string)
+		for hdr, val := range jsnCfg.WebhookAuthHeaders {
+			whOpts.AuthHeaders[hdr] = val
+		}
+	}
+	if jsnCfg.WebhookBackoff != nil {
+		var backoff time.Duration
+		if backoff, err = utils.ParseDurationWithNanosecs(*jsnCfg.WebhookBackoff); err != nil {
+			return
+		}
+		whOpts.Backoff = utils.DurationPointer(backoff)
+	}
+	if jsnCfg.WebhookMaxBackoff != nil {
+		var maxBackoff time.Duration
+		if maxBackoff, err = utils.ParseDurationWithNanosecs(*jsnCfg.WebhookMaxBackoff); err != nil {
+			return
+		}
+		whOpts.MaxBackoff = utils.DurationPointer(maxBackoff)
+	}
+	if jsnCfg.WebhookBreakerThreshold != nil {
+		whOpts.BreakerThreshold = jsnCfg.WebhookBreakerThreshold
+	}
+	if jsnCfg.WebhookBreakerCooldown != nil {
+		var cooldown time.Duration
+		if cooldown, err = utils.ParseDurationWithNanosecs(*jsnCfg.WebhookBreakerCooldown); err != nil {
+			return
+		}
+		whOpts.BreakerCooldown = utils.DurationPointer(cooldown)
+	}
+	return
+}
+
 func (natsOpts *NATSOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson) (err error) {
 	if jsnCfg.NATSJetStream != nil {
 		natsOpts.JetStream = jsnCfg.NATSJetStream
@@ -608,6 +777,15 @@ func (eeOpts *EventExporterOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson)
 	if err = eeOpts.NATS.loadFromJSONCfg(jsnCfg); err != nil {
 		return
 	}
+	if err = eeOpts.MQTT.loadFromJSONCfg(jsnCfg); err != nil {
+		return
+	}
+	if err = eeOpts.RedisStream.loadFromJSONCfg(jsnCfg); err != nil {
+		return
+	}
+	if err = eeOpts.Webhook.loadFromJSONCfg(jsnCfg); err != nil {
+		return
+	}
 	if err = eeOpts.RPC.loadFromJSONCfg(jsnCfg); err != nil {
 		return
 	}
@@ -869,6 +1047,23 @@ func (sqlOpts *SQLOpts) Clone() *SQLOpts {
 		cln.PgSSLMode = new(string)
 		*cln.PgSSLMode = *sqlOpts.PgSSLMode
 	}
+	if sqlOpts.BatchSize != nil {
+		cln.BatchSize = new(int)
+		*cln.BatchSize = *sqlOpts.BatchSize
+	}
+	if sqlOpts.BatchInterval != nil {
+		cln.BatchInterval = new(time.Duration)
+		*cln.BatchInterval = *sqlOpts.BatchInterval
+	}
+	if sqlOpts.ConflictFields != nil {
+		cf := make([]string, len(*sqlOpts.ConflictFields))
+		copy(cf, *sqlOpts.ConflictFields)
+		cln.ConflictFields = &cf
+	}
+	if sqlOpts.CreateTable != nil {
+		cln.CreateTable = new(bool)
+		*cln.CreateTable = *sqlOpts.CreateTable
+	}
 	return cln
 }
 
@@ -950,6 +1145,131 @@ func (awsOpts *AWSOpts) Clone() *AWSOpts {
 	return cln
 }
 
+func (mqttOpts *MQTTOpts) Clone() *MQTTOpts {
+	cln := &MQTTOpts{}
+	if mqttOpts.Topic != nil {
+		cln.Topic = new(string)
+		*cln.Topic = *mqttOpts.Topic
+	}
+	if mqttOpts.QoS != nil {
+		cln.QoS = new(int)
+		*cln.QoS = *mqttOpts.QoS
+	}
+	if mqttOpts.Retain != nil {
+		cln.Retain = new(bool)
+		*cln.Retain = *mqttOpts.Retain
+	}
+	if mqttOpts.ClientID != nil {
+		cln.ClientID = new(string)
+		*cln.ClientID = *mqttOpts.ClientID
+	}
+	if mqttOpts.Username != nil {
+		cln.Username = new(string)
+		*cln.Username = *mqttOpts.Username
+	}
+	if mqttOpts.Password != nil {
+		cln.Password = new(string)
+		*cln.Password = *mqttOpts.Password
+	}
+	if mqttOpts.TLS != nil {
+		cln.TLS = new(bool)
+		*cln.TLS = *mqttOpts.TLS
+	}
+	if mqttOpts.CAPath != nil {
+		cln.CAPath = new(string)
+		*cln.CAPath = *mqttOpts.CAPath
+	}
+	if mqttOpts.SkipTLSVerify != nil {
+		cln.SkipTLSVerify = new(bool)
+		*cln.SkipTLSVerify = *mqttOpts.SkipTLSVerify
+	}
+	if mqttOpts.ClientCertificate != nil {
+		cln.ClientCertificate = new(string)
+		*cln.ClientCertificate = *mqttOpts.ClientCertificate
+	}
+	if mqttOpts.ClientKey != nil {
+		cln.ClientKey = new(string)
+		*cln.ClientKey = *mqttOpts.ClientKey
+	}
+	return cln
+}
+
+func (whOpts *WebhookOpts) Clone() *WebhookOpts {
+	cln := &WebhookOpts{}
+	if whOpts.Secret != nil {
+		cln.Secret = new(string)
+		*cln.Secret = *whOpts.Secret
+	}
+	if whOpts.SignatureHeader != nil {
+		cln.SignatureHeader = new(string)
+		*cln.SignatureHeader = *whOpts.SignatureHeader
+	}
+	if whOpts.TimestampHeader != nil {
+		cln.TimestampHeader = new(string)
+		*cln.TimestampHeader = *whOpts.TimestampHeader
+	}
+	if whOpts.AuthHeaders != nil {
+		cln.AuthHeaders = make(map[string]string)
+		for hdr, val := range whOpts.AuthHeaders {
+			cln.AuthHeaders[hdr] = val
+		}
+	}
+	if whOpts.Backoff != nil {
+		cln.Backoff = new(time.Duration)
+		*cln.Backoff = *whOpts.Backoff
+	}
+	if whOpts.MaxBackoff != nil {
+		cln.MaxBackoff = new(time.Duration)
+		*cln.MaxBackoff = *whOpts.MaxBackoff
+	}
+	if whOpts.BreakerThreshold != nil {
+		cln.BreakerThreshold = new(int)
+		*cln.BreakerThreshold = *whOpts.BreakerThreshold
+	}
+	if whOpts.BreakerCooldown != nil {
+		cln.BreakerCooldown = new(time.Duration)
+		*cln.BreakerCooldown = *whOpts.BreakerCooldown
+	}
+	return cln
+}
+
+func (redisOpts *RedisStreamOpts) Clone() *RedisStreamOpts {
+	cln := &RedisStreamOpts{}
+	if redisOpts.Stream != nil {
+		cln.Stream = new(string)
+		*cln.Stream = *redisOpts.Stream
+	}
+	if redisOpts.Field != nil {
+		cln.Field = new(string)
+		*cln.Field = *redisOpts.Field
+	}
+	if redisOpts.MaxLen != nil {
+		cln.MaxLen = new(int)
+		*cln.MaxLen = *redisOpts.MaxLen
+	}
+	if redisOpts.MaxLenApprox != nil {
+		cln.MaxLenApprox = new(bool)
+		*cln.MaxLenApprox = *redisOpts.MaxLenApprox
+	}
+	if redisOpts.TLS != nil {
+		cln.TLS = new(bool)
+		*cln.TLS = *redisOpts.TLS
+	}
+	if redisOpts.ClientCertificate != nil {
+		cln.ClientCertificate = new(string)
+		*cln.ClientCertificate = *redisOpts.ClientCertificate
+	}
+	if redisOpts.ClientKey != nil {
+		cln.ClientKey = new(string)
+		*cln.ClientKey = *redisOpts.ClientKey
+	}
+	if redisOpts.CACertificate != nil {
+		cln.CACertificate = new(string)
+		*cln.CACertificate = *redisOpts.CACertificate
+	}
+	return cln
+}
+
 func (natsOpts *NATSOpts) Clone() *NATSOpts {
 	cln := &NATSOpts{}
 	if natsOpts.JetStream != nil {
@@ -1056,6 +1376,15 @@ func (eeOpts *EventExporterOpts) Clone() *EventExporterOpts {
 	if eeOpts.NATS != nil {
 		cln.NATS = eeOpts.NATS.Clone()
 	}
+	if eeOpts.MQTT != nil {
+		cln.MQTT = eeOpts.MQTT.Clone()
+	}
+	if eeOpts.RedisStream != nil {
+		cln.RedisStream = eeOpts.RedisStream.Clone()
+	}
+	if eeOpts.Webhook != nil {
+		cln.Webhook = eeOpts.Webhook.Clone()
+	}
 	if eeOpts.RPC != nil {
 		cln.RPC = eeOpts.RPC.Clone()
 	}
@@ -1211,6 +1540,20 @@ func (eeC *EventExporterCfg) AsMapInterface(separator string) (initialMP map[str
 		if sqlOpts.PgSSLMode != nil {
 			opts[utils.PgSSLModeCfg] = *sqlOpts.PgSSLMode
 		}
+		if sqlOpts.BatchSize != nil {
+			opts[utils.SQLBatchSize] = *sqlOpts.BatchSize
+		}
+		if sqlOpts.BatchInterval != nil {
+			opts[utils.SQLBatchIntervalOpt] = sqlOpts.BatchInterval.String()
+		}
+		if sqlOpts.ConflictFields != nil {
+			conflictFields := make([]string, len(*sqlOpts.ConflictFields))
+			copy(conflictFields, *sqlOpts.ConflictFields)
+			opts[utils.SQLConflictFieldsOpt] = conflictFields
+		}
+		if sqlOpts.CreateTable != nil {
+			opts[utils.SQLCreateTableOpt] = *sqlOpts.CreateTable
+		}
 	}
 	if kafkaOpts := eeC.Opts.Kafka; kafkaOpts != nil {
 		if kafkaOpts.Topic != nil {
@@ -1310,6 +1653,97 @@ func (eeC *EventExporterCfg) AsMapInterface(separator string) (initialMP map[str
 			opts[utils.NatsJetStreamMaxWait] = natOpts.JetStreamMaxWait.String()
 		}
 	}
+	if mqttOpts := eeC.Opts.MQTT; mqttOpts != nil {
+		if mqttOpts.Topic != nil {
+			opts[utils.MQTTTopic] = *mqttOpts.Topic
+		}
+		if mqttOpts.QoS != nil {
+			opts[utils.MQTTQoS] = *mqttOpts.QoS
+		}
+		if mqttOpts.Retain != nil {
+			opts[utils.MQTTRetain] = *mqttOpts.Retain
+		}
+		if mqttOpts.ClientID != nil {
+			opts[utils.MQTTClientID] = *mqttOpts.ClientID
+		}
+		if mqttOpts.Username != nil {
+			opts[utils.MQTTUsername] = *mqttOpts.Username
+		}
+		if mqttOpts.Password != nil {
+			opts[utils.MQTTPassword] = *mqttOpts.Password
+		}
+		if mqttOpts.TLS != nil {
+			opts[utils.MQTTTLS] = *mqttOpts.TLS
+		}
+		if mqttOpts.CAPath != nil {
+			opts[utils.MQTTCAPath] = *mqttOpts.CAPath
+		}
+		if mqttOpts.SkipTLSVerify != nil {
+			opts[utils.MQTTSkipTLSVerify] = *mqttOpts.SkipTLSVerify
+		}
+		if mqttOpts.ClientCertificate != nil {
+			opts[utils.MQTTClientCertificate] = *mqttOpts.ClientCertificate
+		}
+		if mqttOpts.ClientKey != nil {
+			opts[utils.MQTTClientKey] = *mqttOpts.ClientKey
+		}
+	}
+	if redisOpts := eeC.Opts.RedisStream; redisOpts != nil {
+		if redisOpts.Stream != nil {
+			opts[utils.RedisStreamOpt] = *redisOpts.Stream
+		}
+		if redisOpts.Field != nil {
+			opts[utils.RedisField] = *redisOpts.Field
+		}
+		if redisOpts.MaxLen != nil {
+			opts[utils.RedisMaxLen] = *redisOpts.MaxLen
+		}
+		if redisOpts.MaxLenApprox != nil {
+			opts[utils.RedisMaxLenApprox] = *redisOpts.MaxLenApprox
+		}
+		if redisOpts.TLS != nil {
+			opts[utils.RedisTLS] = *redisOpts.TLS
+		}
+		if redisOpts.ClientCertificate != nil {
+			opts[utils.RedisClientCertificate] = *redisOpts.ClientCertificate
+		}
+		if redisOpts.ClientKey != nil {
+			opts[utils.RedisClientKey] = *redisOpts.ClientKey
+		}
+		if redisOpts.CACertificate != nil {
+			opts[utils.RedisCACertificate] = *redisOpts.CACertificate
+		}
+	}
+	if whOpts := eeC.Opts.Webhook; whOpts != nil {
+		if whOpts.Secret != nil {
+			opts[utils.WebhookSecret] = *whOpts.Secret
+		}
+		if whOpts.SignatureHeader != nil {
+			opts[utils.WebhookSignatureHeader] = *whOpts.SignatureHeader
+		}
+		if whOpts.TimestampHeader != nil {
+			opts[utils.WebhookTimestampHeader] = *whOpts.TimestampHeader
+		}
+		if whOpts.AuthHeaders != nil {
+			authHdrs := make(map[string]any)
+			for hdr, val := range whOpts.AuthHeaders {
+				authHdrs[hdr] = val
+			}
+			opts[utils.WebhookAuthHeaders] = authHdrs
+		}
+		if whOpts.Backoff != nil {
+			opts[utils.WebhookBackoff] = whOpts.Backoff.String()
+		}
+		if whOpts.MaxBackoff != nil {
+			opts[utils.WebhookMaxBackoff] = whOpts.MaxBackoff.String()
+		}
+		if whOpts.BreakerThreshold != nil {
+			opts[utils.WebhookBreakerThreshold] = *whOpts.BreakerThreshold
+		}
+		if whOpts.BreakerCooldown != nil {
+			opts[utils.WebhookBreakerCooldown] = whOpts.BreakerCooldown.String()
+		}
+	}
 	if rpcOpts := eeC.Opts.RPC; rpcOpts != nil {
 		if rpcOpts.RPCCodec != nil {
 			opts[utils.RpcCodec] = *rpcOpts.RPCCodec
@@ -205,13 +205,16 @@ func TestEESClone(t *testing.T) {
 				headerFields:  []*FCTemplate{},
 				trailerFields: []*FCTemplate{},
 				Opts: &EventExporterOpts{
-					AMQP:  &AMQPOpts{},
-					AWS:   &AWSOpts{},
-					RPC:   &RPCOpts{},
-					Els:   &ElsOpts{},
-					Kafka: &KafkaOpts{},
-					NATS:  &NATSOpts{},
-					SQL:   &SQLOpts{},
+					AMQP:        &AMQPOpts{},
+					AWS:         &AWSOpts{},
+					RPC:         &RPCOpts{},
+					Els:         &ElsOpts{},
+					Kafka:       &KafkaOpts{},
+					NATS:        &NATSOpts{},
+					MQTT:        &MQTTOpts{},
+					RedisStream: &RedisStreamOpts{},
+					Webhook:     &WebhookOpts{},
+					SQL:         &SQLOpts{},
 				},
 				FailedPostsDir: "/var/spool/cgrates/failed_posts",
 			},
@@ -341,6 +344,9 @@ func TestEESClone(t *testing.T) {
 						ClientKey:            utils.StringPointer("key"),
 						JetStreamMaxWait:     utils.DurationPointer(1 * time.Minute),
 					},
+					MQTT:        &MQTTOpts{},
+					RedisStream: &RedisStreamOpts{},
+					Webhook:     &WebhookOpts{},
 					AMQP: &AMQPOpts{
 						RoutingKey:   utils.StringPointer("key"),
 						QueueID:      utils.StringPointer("id"),
@@ -500,16 +506,22 @@ func TestEventExporterOptsloadFromJsonCfg(t *testing.T) {
 			ClientKey:            utils.StringPointer("key"),
 			JetStreamMaxWait:     utils.DurationPointer(1 * time.Minute),
 		},
+		MQTT:        &MQTTOpts{},
+		RedisStream: &RedisStreamOpts{},
+		Webhook:     &WebhookOpts{},
 	}
 	eventExporter := &EventExporterCfg{
 		Opts: &EventExporterOpts{
-			Els:   &ElsOpts{},
-			Kafka: &KafkaOpts{},
-			AMQP:  &AMQPOpts{},
-			NATS:  &NATSOpts{},
-			SQL:   &SQLOpts{},
-			RPC:   &RPCOpts{},
-			AWS:   &AWSOpts{},
+			Els:         &ElsOpts{},
+			Kafka:       &KafkaOpts{},
+			AMQP:        &AMQPOpts{},
+			NATS:        &NATSOpts{},
+			MQTT:        &MQTTOpts{},
+			RedisStream: &RedisStreamOpts{},
+			Webhook:     &WebhookOpts{},
+			SQL:         &SQLOpts{},
+			RPC:         &RPCOpts{},
+			AWS:         &AWSOpts{},
 		},
 	}
 	if err := eventExporter.Opts.loadFromJSONCfg(eventExporterOptsJSON); err != nil {
@@ -622,13 +634,16 @@ func TestEventExporterSameID(t *testing.T) {
 				headerFields:  []*FCTemplate{},
 				trailerFields: []*FCTemplate{},
 				Opts: &EventExporterOpts{
-					AMQP:  &AMQPOpts{},
-					AWS:   &AWSOpts{},
-					RPC:   &RPCOpts{},
-					Els:   &ElsOpts{},
-					Kafka: &KafkaOpts{},
-					NATS:  &NATSOpts{},
-					SQL:   &SQLOpts{},
+					AMQP:        &AMQPOpts{},
+					AWS:         &AWSOpts{},
+					RPC:         &RPCOpts{},
+					Els:         &ElsOpts{},
+					Kafka:       &KafkaOpts{},
+					NATS:        &NATSOpts{},
+					MQTT:        &MQTTOpts{},
+					RedisStream: &RedisStreamOpts{},
+					Webhook:     &WebhookOpts{},
+					SQL:         &SQLOpts{},
 				},
 				FailedPostsDir: "/var/spool/cgrates/failed_posts",
 			},
@@ -652,13 +667,16 @@ func TestEventExporterSameID(t *testing.T) {
 				headerFields:  []*FCTemplate{},
 				trailerFields: []*FCTemplate{},
 				Opts: &EventExporterOpts{
-					AMQP:  &AMQPOpts{},
-					AWS:   &AWSOpts{},
-					RPC:   &RPCOpts{},
-					Els:   &ElsOpts{},
-					Kafka: &KafkaOpts{},
-					NATS:  &NATSOpts{},
-					SQL:   &SQLOpts{},
+					AMQP:        &AMQPOpts{},
+					AWS:         &AWSOpts{},
+					RPC:         &RPCOpts{},
+					Els:         &ElsOpts{},
+					Kafka:       &KafkaOpts{},
+					NATS:        &NATSOpts{},
+					MQTT:        &MQTTOpts{},
+					RedisStream: &RedisStreamOpts{},
+					Webhook:     &WebhookOpts{},
+					SQL:         &SQLOpts{},
 				},
 				FailedPostsDir: "/var/spool/cgrates/failed_posts",
 			},
@@ -802,13 +820,16 @@ func TestEEsCfgloadFromJsonCfgCase1(t *testing.T) {
 				headerFields:  []*FCTemplate{},
 				trailerFields: []*FCTemplate{},
 				Opts: &EventExporterOpts{
-					AMQP:  &AMQPOpts{},
-					AWS:   &AWSOpts{},
-					SQL:   &SQLOpts{},
-					Kafka: &KafkaOpts{},
-					RPC:   &RPCOpts{},
-					Els:   &ElsOpts{},
-					NATS:  &NATSOpts{},
+					AMQP:        &AMQPOpts{},
+					AWS:         &AWSOpts{},
+					SQL:         &SQLOpts{},
+					Kafka:       &KafkaOpts{},
+					RPC:         &RPCOpts{},
+					Els:         &ElsOpts{},
+					NATS:        &NATSOpts{},
+					MQTT:        &MQTTOpts{},
+					RedisStream: &RedisStreamOpts{},
+					Webhook:     &WebhookOpts{},
 				},
 				FailedPostsDir: "/var/spool/cgrates/failed_posts",
 			},
@@ -834,13 +855,16 @@ func TestEEsCfgloadFromJsonCfgCase1(t *testing.T) {
 					},
 				},
 				Opts: &EventExporterOpts{
-					AMQP:  &AMQPOpts{},
-					AWS:   &AWSOpts{},
-					SQL:   &SQLOpts{},
-					Kafka: &KafkaOpts{},
-					RPC:   &RPCOpts{},
-					Els:   &ElsOpts{},
-					NATS:  &NATSOpts{},
+					AMQP:        &AMQPOpts{},
+					AWS:         &AWSOpts{},
+					SQL:         &SQLOpts{},
+					Kafka:       &KafkaOpts{},
+					RPC:         &RPCOpts{},
+					Els:         &ElsOpts{},
+					NATS:        &NATSOpts{},
+					MQTT:        &MQTTOpts{},
+					RedisStream: &RedisStreamOpts{},
+					Webhook:     &WebhookOpts{},
 				},
 				Fields: []*FCTemplate{
 					{Tag: utils.CGRID, Path: "*exp.CGRID", Type: utils.MetaVariable, Value: NewRSRParsersMustCompile("~*req.CGRID", utils.InfieldSep), Layout: time.RFC3339},
@@ -968,13 +992,16 @@ func TestEEsCfgloadFromJsonCfgCase2(t *testing.T) {
 				headerFields:  []*FCTemplate{},
 				trailerFields: []*FCTemplate{},
 				Opts: &EventExporterOpts{
-					Els:   &ElsOpts{},
-					Kafka: &KafkaOpts{},
-					AMQP:  &AMQPOpts{},
-					SQL:   &SQLOpts{},
-					AWS:   &AWSOpts{},
-					NATS:  &NATSOpts{},
-					RPC:   &RPCOpts{},
+					Els:         &ElsOpts{},
+					Kafka:       &KafkaOpts{},
+					AMQP:        &AMQPOpts{},
+					SQL:         &SQLOpts{},
+					AWS:         &AWSOpts{},
+					NATS:        &NATSOpts{},
+					MQTT:        &MQTTOpts{},
+					RedisStream: &RedisStreamOpts{},
+					Webhook:     &WebhookOpts{},
+					RPC:         &RPCOpts{},
 				},
 				FailedPostsDir: "/var/spool/cgrates/failed_posts",
 			},
@@ -1001,13 +1028,16 @@ func TestEEsCfgloadFromJsonCfgCase2(t *testing.T) {
 				},
 				FailedPostsDir: "/var/spool/cgrates/failed_posts",
 				Opts: &EventExporterOpts{
-					AMQP:  &AMQPOpts{},
-					AWS:   &AWSOpts{},
-					SQL:   &SQLOpts{},
-					Kafka: &KafkaOpts{},
-					RPC:   &RPCOpts{},
-					Els:   &ElsOpts{},
-					NATS:  &NATSOpts{},
+					AMQP:        &AMQPOpts{},
+					AWS:         &AWSOpts{},
+					SQL:         &SQLOpts{},
+					Kafka:       &KafkaOpts{},
+					RPC:         &RPCOpts{},
+					Els:         &ElsOpts{},
+					NATS:        &NATSOpts{},
+					MQTT:        &MQTTOpts{},
+					RedisStream: &RedisStreamOpts{},
+					Webhook:     &WebhookOpts{},
 				},
 				Fields: []*FCTemplate{
 					{
@@ -1694,3 +1724,163 @@ func TestKafkaOptsClone(t *testing.T) {
 		t.Errorf("Expected cloned CAPath to be separate, got %s", *clonedOpts.CAPath)
 	}
 }
+
+func TestEEsCfgWebhookOpts(t *testing.T) {
+	whOpts := &WebhookOpts{}
+	jsnCfg := &EventExporterOptsJson{
+		WebhookSecret:           utils.StringPointer("secret"),
+		WebhookSignatureHeader:  utils.StringPointer("X-Signature"),
+		WebhookTimestampHeader:  utils.StringPointer("X-Timestamp"),
+		WebhookAuthHeaders:      map[string]string{"Authorization": "Bearer token"},
+		WebhookBackoff:          utils.StringPointer("500ms"),
+		WebhookMaxBackoff:       utils.StringPointer("1m"),
+		WebhookBreakerThreshold: utils.IntPointer(3),
+		WebhookBreakerCooldown:  utils.StringPointer("10s"),
+	}
+	exp := &WebhookOpts{
+		Secret:           utils.StringPointer("secret"),
+		SignatureHeader:  utils.StringPointer("X-Signature"),
+		TimestampHeader:  utils.StringPointer("X-Timestamp"),
+		AuthHeaders:      map[string]string{"Authorization": "Bearer token"},
+		Backoff:          utils.DurationPointer(500 * time.Millisecond),
+		MaxBackoff:       utils.DurationPointer(time.Minute),
+		BreakerThreshold: utils.IntPointer(3),
+		BreakerCooldown:  utils.DurationPointer(10 * time.Second),
+	}
+	if err := whOpts.loadFromJSONCfg(jsnCfg); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exp, whOpts) {
+		t.Errorf("\nexpected %s\nreceived  %s\n", utils.ToJSON(exp), utils.ToJSON(whOpts))
+	}
+
+	cln := whOpts.Clone()
+	if !reflect.DeepEqual(whOpts, cln) {
+		t.Errorf("\nexpected %s\nreceived  %s\n", utils.ToJSON(whOpts), utils.ToJSON(cln))
+	}
+	cln.AuthHeaders["Authorization"] = "Basic"
+	if whOpts.AuthHeaders["Authorization"] != "Bearer token" {
+		t.Error("expected the auth headers to be cloned")
+	}
+
+	eeC := &EventExporterCfg{Opts: &EventExporterOpts{Webhook: whOpts}}
+	expOpts := map[string]any{
+		utils.WebhookSecret:           "secret",
+		utils.WebhookSignatureHeader:  "X-Signature",
+		utils.WebhookTimestampHeader:  "X-Timestamp",
+		utils.WebhookAuthHeaders:      map[string]any{"Authorization": "Bearer token"},
+		utils.WebhookBackoff:          "500ms",
+		utils.WebhookMaxBackoff:       "1m0s",
+		utils.WebhookBreakerThreshold: 3,
+		utils.WebhookBreakerCooldown:  "10s",
+	}
+	if rcv := eeC.AsMapInterface(utils.EmptyString)[utils.OptsCfg]; !reflect.DeepEqual(expOpts, rcv) {
+		t.Errorf("\nexpected %s\nreceived  %s\n", utils.ToJSON(expOpts), utils.ToJSON(rcv))
+	}
+
+	jsnCfg = &EventExporterOptsJson{WebhookBackoff: utils.StringPointer("1ss")}
+	if err := new(WebhookOpts).loadFromJSONCfg(jsnCfg); err == nil {
+		t.Error("expected error for invalid webhookBackoff")
+	}
+}
+
+func TestEEsCfgSQLBatchOpts(t *testing.T) {
+	sqlOpts := &SQLOpts{}
+	jsnCfg := &EventExporterOptsJson{
+		SQLBatchSize:      utils.IntPointer(500),
+		SQLBatchInterval:  utils.StringPointer("5s"),
+		SQLConflictFields: &[]string{"cgrid", "run_id"},
+		SQLCreateTable:    utils.BoolPointer(true),
+	}
+	exp := &SQLOpts{
+		BatchSize:      utils.IntPointer(500),
+		BatchInterval:  utils.DurationPointer(5 * time.Second),
+		ConflictFields: &[]string{"cgrid", "run_id"},
+		CreateTable:    utils.BoolPointer(true),
+	}
+	if err := sqlOpts.loadFromJSONCfg(jsnCfg); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exp, sqlOpts) {
+		t.Errorf("\nexpected %s\nreceived  %s\n", utils.ToJSON(exp), utils.ToJSON(sqlOpts))
+	}
+
+	cln := sqlOpts.Clone()
+	if !reflect.DeepEqual(sqlOpts, cln) {
+		t.Errorf("\nexpected %s\nreceived  %s\n", utils.ToJSON(sqlOpts), utils.ToJSON(cln))
+	}
+	(*cln.ConflictFields)[0] = "id"
+	if (*sqlOpts.ConflictFields)[0] != "cgrid" {
+		t.Error("expected the conflict fields to be cloned")
+	}
+
+	eeC := &EventExporterCfg{Opts: &EventExporterOpts{SQL: sqlOpts}}
+	expOpts := map[string]any{
+		utils.SQLBatchSize:         500,
+		utils.SQLBatchIntervalOpt:  "5s",
+		utils.SQLConflictFieldsOpt: []string{"cgrid", "run_id"},
+		utils.SQLCreateTableOpt:    true,
+	}
+	if rcv := eeC.AsMapInterface(utils.EmptyString)[utils.OptsCfg]; !reflect.DeepEqual(expOpts, rcv) {
+		t.Errorf("\nexpected %s\nreceived  %s\n", utils.ToJSON(expOpts), utils.ToJSON(rcv))
+	}
+
+	jsnCfg = &EventExporterOptsJson{SQLBatchInterval: utils.StringPointer("1ss")}
+	if err := new(SQLOpts).loadFromJSONCfg(jsnCfg); err == nil {
+		t.Error("expected error for invalid sqlBatchInterval")
+	}
+}
+
+func TestEEsCfgRedisStreamOpts(t *testing.T) {
+	redisOpts := &RedisStreamOpts{}
+	jsnCfg := &EventExporterOptsJson{
+		RedisStream:            utils.StringPointer("cdrs"),
+		RedisField:             utils.StringPointer("cdr"),
+		RedisMaxLen:            utils.IntPointer(1000),
+		RedisMaxLenApprox:      utils.BoolPointer(false),
+		RedisTLS:               utils.BoolPointer(true),
+		RedisClientCertificate: utils.StringPointer("/tmp/client.crt"),
+		RedisClientKey:         utils.StringPointer("/tmp/client.key"),
+		RedisCACertificate:     utils.StringPointer("/tmp/ca.crt"),
+	}
+	exp := &RedisStreamOpts{
+		Stream:            utils.StringPointer("cdrs"),
+		Field:             utils.StringPointer("cdr"),
+		MaxLen:            utils.IntPointer(1000),
+		MaxLenApprox:      utils.BoolPointer(false),
+		TLS:               utils.BoolPointer(true),
+		ClientCertificate: utils.StringPointer("/tmp/client.crt"),
+		ClientKey:         utils.StringPointer("/tmp/client.key"),
+		CACertificate:     utils.StringPointer("/tmp/ca.crt"),
+	}
+	if err := redisOpts.loadFromJSONCfg(jsnCfg); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exp, redisOpts) {
+		t.Errorf("\nexpected %s\nreceived  %s\n", utils.ToJSON(exp), utils.ToJSON(redisOpts))
+	}
+
+	cln := redisOpts.Clone()
+	if !reflect.DeepEqual(redisOpts, cln) {
+		t.Errorf("\nexpected %s\nreceived  %s\n", utils.ToJSON(redisOpts), utils.ToJSON(cln))
+	}
+	*cln.MaxLen = 10
+	if *redisOpts.MaxLen != 1000 {
+		t.Error("expected the max length to be cloned")
+	}
+
+	eeC := &EventExporterCfg{Opts: &EventExporterOpts{RedisStream: redisOpts}}
+	expOpts := map[string]any{
+		utils.RedisStreamOpt:         "cdrs",
+		utils.RedisField:             "cdr",
+		utils.RedisMaxLen:            1000,
+		utils.RedisMaxLenApprox:      false,
+		utils.RedisTLS:               true,
+		utils.RedisClientCertificate: "/tmp/client.crt",
+		utils.RedisClientKey:         "/tmp/client.key",
+		utils.RedisCACertificate:     "/tmp/ca.crt",
+	}
+	if rcv := eeC.AsMapInterface(utils.EmptyString)[utils.OptsCfg]; !reflect.DeepEqual(expOpts, rcv) {
+		t.Errorf("\nexpected %s\nreceived  %s\n", utils.ToJSON(expOpts), utils.ToJSON(rcv))
+	}
+}
@@ -358,6 +358,128 @@ func (natsOpts *NATSROpts) loadFromJSONCfg(jsnCfg *EventReaderOptsJson) (err err
 	return
 }
 
+type MQTTROpts struct {
+	Topic             *string
+	QoS               *int
+	SharedGroup       *string
+	ClientID          *string
+	Username          *string
+	Password          *string
+	TLS               *bool
+	CAPath            *string
+	SkipTLSVerify     *bool
+	ClientCertificate *string
+	ClientKey         *string
+}
+
+func (mqttOpts *MQTTROpts) loadFromJSONCfg(jsnCfg *EventReaderOptsJson) (err error) {
+	if jsnCfg.MQTTTopic != nil {
+		mqttOpts.Topic = jsnCfg.MQTTTopic
+	}
+	if jsnCfg.MQTTQoS != nil {
+		mqttOpts.QoS = jsnCfg.MQTTQoS
+	}
+	if jsnCfg.MQTTSharedGroup != nil {
+		mqttOpts.SharedGroup = jsnCfg.MQTTSharedGroup
+	}
+	if jsnCfg.MQTTClientID != nil {
+		mqttOpts.ClientID = jsnCfg.MQTTClientID
+	}
+	if jsnCfg.MQTTUsername != nil {
+		mqttOpts.Username = jsnCfg.MQTTUsername
+	}
+	if jsnCfg.MQTTPassword != nil {
+		mqttOpts.Password = jsnCfg.MQTTPassword
+	}
+	if jsnCfg.MQTTTLS != nil {
+		mqttOpts.TLS = jsnCfg.MQTTTLS
+	}
+	if jsnCfg.MQTTCAPath != nil {
+		mqttOpts.CAPath = jsnCfg.MQTTCAPath
+	}
+	if jsnCfg.MQTTSkipTLSVerify != nil {
+		mqttOpts.SkipTLSVerify = jsnCfg.MQTTSkipTLSVerify
+	}
+	if jsnCfg.MQTTClientCertificate != nil {
+		mqttOpts.ClientCertificate = jsnCfg.MQTTClientCertificate
+	}
+	if jsnCfg.MQTTClientKey != nil {
+		mqttOpts.ClientKey = jsnCfg.MQTTClientKey
+	}
+	return
+}
+
+type RedisStreamROpts struct {
+	Stream            *string
+	Group             *string
+	Consumer          *string
+	Field             *string
+	Count             *int
+	Block             *time.Duration
+	ClaimMinIdle      *time.Duration
+	ClaimInterval     *time.Duration
+	MaxDeliveries     *int
+	TLS               *bool
+	ClientCertificate *string
+	ClientKey         *string
+	CACertificate     *string
+}
+
+func (redisOpts *RedisStreamROpts) loadFromJSONCfg(jsnCfg *EventReaderOptsJson) (err error) {
+	if jsnCfg.RedisStream != nil {
+		redisOpts.Stream = jsnCfg.RedisStream
+	}
+	if jsnCfg.RedisGroup != nil {
+		redisOpts.Group = jsnCfg.RedisGroup
+	}
+	if jsnCfg.RedisConsumer != nil {
+		redisOpts.Consumer = jsnCfg.RedisConsumer
+	}
+	if jsnCfg.RedisField != nil {
+		redisOpts.Field = jsnCfg.RedisField
+	}
+	if jsnCfg.RedisCount != nil {
+		redisOpts.Count = jsnCfg.RedisCount
+	}
+	if jsnCfg.RedisBlock != nil {
+		var block time.Duration
+		if block, err = utils.ParseDurationWithNanosecs(*jsnCfg.RedisBlock); err != nil {
+			return
+		}
+		redisOpts.Block = utils.DurationPointer(block)
+	}
+	if jsnCfg.RedisClaimMinIdle != nil {
+		var claimMinIdle time.Duration
+		if claimMinIdle, err = utils.ParseDurationWithNanosecs(*jsnCfg.RedisClaimMinIdle); err != nil {
+			return
+		}
+		redisOpts.ClaimMinIdle = utils.DurationPointer(claimMinIdle)
+	}
+	if jsnCfg.RedisClaimInterval != nil {
+		var claimInterval time.Duration
+		if claimInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.RedisClaimInterval); err != nil {
+			return
+		}
+		redisOpts.ClaimInterval = utils.DurationPointer(claimInterval)
+	}
+	if jsnCfg.RedisMaxDeliveries != nil {
+		redisOpts.MaxDeliveries = jsnCfg.RedisMaxDeliveries
+	}
+	if jsnCfg.RedisTLS != nil {
+		redisOpts.TLS = jsnCfg.RedisTLS
+	}
+	if jsnCfg.RedisClientCertificate != nil {
+		redisOpts.ClientCertificate = jsnCfg.RedisClientCertificate
+	}
+	if jsnCfg.RedisClientKey != nil {
+		redisOpts.ClientKey = jsnCfg.RedisClientKey
+	}
+	if jsnCfg.RedisCACertificate != nil {
+		redisOpts.CACertificate = jsnCfg.RedisCACertificate
+	}
+	return
+}
+
 type CSVROpts struct {
 	PartialCSVFieldSeparator *string
 	RowLength                *int
@@ -394,6 +516,8 @@ type EventReaderOpts struct {
 	AMQP               *AMQPROpts
 	AWS                *AWSROpts
 	NATS               *NATSROpts
+	MQTT               *MQTTROpts
+	RedisStream        *RedisStreamROpts
 	Kafka              *KafkaROpts
 	SQL                *SQLROpts
 }
@@ -445,6 +569,12 @@ func (erOpts *EventReaderOpts) loadFromJSONCfg(jsnCfg *EventReaderOptsJson) (err
 	if err = erOpts.NATS.loadFromJSONCfg(jsnCfg); err != nil {
 		return
 	}
+	if err = erOpts.MQTT.loadFromJSONCfg(jsnCfg); err != nil {
+		return
+	}
+	if err = erOpts.RedisStream.loadFromJSONCfg(jsnCfg); err != nil {
+		return
+	}
 	if err = erOpts.SQL.loadFromJSONCfg(jsnCfg); err != nil {
 		return
 	}
@@ -755,6 +885,112 @@ func (natOpts *NATSROpts) Clone() *NATSROpts {
 	return cln
 }
 
+func (mqttOpts *MQTTROpts) Clone() *MQTTROpts {
+	cln := &MQTTROpts{}
+	if mqttOpts.Topic != nil {
+		cln.Topic = new(string)
+		*cln.Topic = *mqttOpts.Topic
+	}
+	if mqttOpts.QoS != nil {
+		cln.QoS = new(int)
+		*cln.QoS = *mqttOpts.QoS
+	}
+	if mqttOpts.SharedGroup != nil {
+		cln.SharedGroup = new(string)
+		*cln.SharedGroup = *mqttOpts.SharedGroup
+	}
+	if mqttOpts.ClientID != nil {
+		cln.ClientID = new(string)
+		*cln.ClientID = *mqttOpts.ClientID
+	}
+	if mqttOpts.Username != nil {
+		cln.Username = new(string)
+		*cln.Username = *mqttOpts.Username
+	}
+	if mqttOpts.Password != nil {
+		cln.Password = new(string)
+		*cln.Password = *mqttOpts.Password
+	}
+	if mqttOpts.TLS != nil {
+		cln.TLS = new(bool)
+		*cln.TLS = *mqttOpts.TLS
+	}
+	if mqttOpts.CAPath != nil {
+		cln.CAPath = new(string)
+		*cln.CAPath = *mqttOpts.CAPath
+	}
+	if mqttOpts.SkipTLSVerify != nil {
+		cln.SkipTLSVerify = new(bool)
+		*cln.SkipTLSVerify = *mqttOpts.SkipTLSVerify
+	}
+	if mqttOpts.ClientCertificate != nil {
+		cln.ClientCertificate = new(string)
+		*cln.ClientCertificate = *mqttOpts.ClientCertificate
+	}
+	if mqttOpts.ClientKey != nil {
+		cln.ClientKey = new(string)
+		*cln.ClientKey = *mqttOpts.ClientKey
+	}
+	return cln
+}
+
+func (redisOpts *RedisStreamROpts) Clone() *RedisStreamROpts {
+	cln := &RedisStreamROpts{}
+	if redisOpts.Stream != nil {
+		cln.Stream = new(string)
+		*cln.Stream = *redisOpts.Stream
+	}
+	if redisOpts.Group != nil {
+		cln.Group = new(string)
+		*cln.Group = *redisOpts.Group
+	}
+	if redisOpts.Consumer != nil {
+		cln.Consumer = new(string)
+		*cln.Consumer = *redisOpts.Consumer
+	}
+	if redisOpts.Field != nil {
+		cln.Field = new(string)
+		*cln.Field = *redisOpts.Field
+	}
+	if redisOpts.Count != nil {
+		cln.Count = new(int)
+		*cln.Count = *redisOpts.Count
+	}
+	if redisOpts.Block != nil {
+		cln.Block = new(time.Duration)
+		*cln.Block = *redisOpts.Block
+	}
+	if redisOpts.ClaimMinIdle != nil {
+		cln.ClaimMinIdle = new(time.Duration)
+		*cln.ClaimMinIdle = *redisOpts.ClaimMinIdle
+	}
+	if redisOpts.ClaimInterval != nil {
+		cln.ClaimInterval = new(time.Duration)
+		*cln.ClaimInterval = *redisOpts.ClaimInterval
+	}
+	if redisOpts.MaxDeliveries != nil {
+		cln.MaxDeliveries = new(int)
+		*cln.MaxDeliveries = *redisOpts.MaxDeliveries
+	}
+	if redisOpts.TLS != nil {
+		cln.TLS = new(bool)
+		*cln.TLS = *redisOpts.TLS
+	}
+	if redisOpts.ClientCertificate != nil {
+		cln.ClientCertificate = new(string)
+		*cln.ClientCertificate = *redisOpts.ClientCertificate
+	}
+	if redisOpts.ClientKey != nil {
+		cln.ClientKey = new(string)
+		*cln.ClientKey = *redisOpts.ClientKey
+	}
+	if redisOpts.CACertificate != nil {
+		cln.CACertificate = new(string)
+		*cln.CACertificate = *redisOpts.CACertificate
+	}
+	return cln
+}
+
 func (erOpts *EventReaderOpts) Clone() *EventReaderOpts {
 	cln := &EventReaderOpts{}
 	if erOpts.PartialPath != nil {
@@ -782,6 +1018,12 @@ func (erOpts *EventReaderOpts) Clone() *EventReaderOpts {
 	if erOpts.NATS != nil {
 		cln.NATS = erOpts.NATS.Clone()
 	}
+	if erOpts.MQTT != nil {
+		cln.MQTT = erOpts.MQTT.Clone()
+	}
+	if erOpts.RedisStream != nil {
+		cln.RedisStream = erOpts.RedisStream.Clone()
+	}
 	if erOpts.Kafka != nil {
 		cln.Kafka = erOpts.Kafka.Clone()
 	}
@@ -992,6 +1234,82 @@ func (er *EventReaderCfg) AsMapInterface(separator string) (initialMP map[string
 			opts[utils.NatsJetStreamMaxWait] = natsOpts.JetStreamMaxWait.String()
 		}
 	}
+	if mqttOpts := er.Opts.MQTT; mqttOpts != nil {
+		if mqttOpts.Topic != nil {
+			opts[utils.MQTTTopic] = *mqttOpts.Topic
+		}
+		if mqttOpts.QoS != nil {
+			opts[utils.MQTTQoS] = *mqttOpts.QoS
+		}
+		if mqttOpts.SharedGroup != nil {
+			opts[utils.MQTTSharedGroup] = *mqttOpts.SharedGroup
+		}
+		if mqttOpts.ClientID != nil {
+			opts[utils.MQTTClientID] = *mqttOpts.ClientID
+		}
+		if mqttOpts.Username != nil {
+			opts[utils.MQTTUsername] = *mqttOpts.Username
+		}
+		if mqttOpts.Password != nil {
+			opts[utils.MQTTPassword] = *mqttOpts.Password
+		}
+		if mqttOpts.TLS != nil {
+			opts[utils.MQTTTLS] = *mqttOpts.TLS
+		}
+		if mqttOpts.CAPath != nil {
+			opts[utils.MQTTCAPath] = *mqttOpts.CAPath
+		}
+		if mqttOpts.SkipTLSVerify != nil {
+			opts[utils.MQTTSkipTLSVerify] = *mqttOpts.SkipTLSVerify
+		}
+		if mqttOpts.ClientCertificate != nil {
+			opts[utils.MQTTClientCertificate] = *mqttOpts.ClientCertificate
+		}
+		if mqttOpts.ClientKey != nil {
+			opts[utils.MQTTClientKey] = *mqttOpts.ClientKey
+		}
+	}
+	if redisOpts := er.Opts.RedisStream; redisOpts != nil {
+		if redisOpts.Stream != nil {
+			opts[utils.RedisStreamOpt] = *redisOpts.Stream
+		}
+		if redisOpts.Group != nil {
+			opts[utils.RedisGroup] = *redisOpts.Group
+		}
+		if redisOpts.Consumer != nil {
+			opts[utils.RedisConsumer] = *redisOpts.Consumer
+		}
+		if redisOpts.Field != nil {
+			opts[utils.RedisField] = *redisOpts.Field
+		}
+		if redisOpts.Count != nil {
+			opts[utils.RedisCount] = *redisOpts.Count
+		}
+		if redisOpts.Block != nil {
+			opts[utils.RedisBlock] = redisOpts.Block.String()
+		}
+		if redisOpts.ClaimMinIdle != nil {
+			opts[utils.RedisClaimMinIdle] = redisOpts.ClaimMinIdle.String()
+		}
+		if redisOpts.ClaimInterval != nil {
+			opts[utils.RedisClaimInterval] = redisOpts.ClaimInterval.String()
+		}
+		if redisOpts.MaxDeliveries != nil {
+			opts[utils.RedisMaxDeliveries] = *redisOpts.MaxDeliveries
+		}
+		if redisOpts.TLS != nil {
+			opts[utils.RedisTLS] = *redisOpts.TLS
+		}
+		if redisOpts.ClientCertificate != nil {
+			opts[utils.RedisClientCertificate] = *redisOpts.ClientCertificate
+		}
+		if redisOpts.ClientKey != nil {
+			opts[utils.RedisClientKey] = *redisOpts.ClientKey
+		}
+		if redisOpts.CACertificate != nil {
+			opts[utils.RedisCACertificate] = *redisOpts.CACertificate
+		}
+	}
 	initialMP = map[string]any{
 		utils.IDCfg:                   er.ID,
 		utils.TypeCfg:                 er.Type,
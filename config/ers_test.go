@@ -127,6 +127,8 @@ func TestERSClone(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 			{
@@ -171,6 +173,8 @@ func TestERSClone(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 		},
@@ -307,6 +311,8 @@ func TestERSLoadFromjsonCfg(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 			{
@@ -366,6 +372,8 @@ func TestERSLoadFromjsonCfg(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 		},
@@ -570,6 +578,8 @@ func TestERSloadFromJsonCase3(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 			{
@@ -613,6 +623,8 @@ func TestERSloadFromJsonCase3(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 		},
@@ -735,6 +747,8 @@ func TestERSloadFromJsonCase4(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 			{
@@ -778,6 +792,8 @@ func TestERSloadFromJsonCase4(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 		},
@@ -893,6 +909,8 @@ func TestEventReaderSameID(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 			{
@@ -932,6 +950,8 @@ func TestEventReaderSameID(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 		},
@@ -1382,6 +1402,8 @@ func TestERsloadFromJsonCfg(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 			{
@@ -1427,6 +1449,8 @@ func TestERsloadFromJsonCfg(t *testing.T) {
 					NATS: &NATSROpts{
 						Subject: utils.StringPointer("cgrates_cdrs"),
 					},
+					MQTT:        &MQTTROpts{},
+					RedisStream: &RedisStreamROpts{},
 				},
 			},
 		},
@@ -1487,12 +1511,14 @@ func TestEventReaderOptsCfg(t *testing.T) {
 	}
 	eventReader := &EventReaderCfg{
 		Opts: &EventReaderOpts{
-			CSV:   &CSVROpts{},
-			AMQP:  &AMQPROpts{},
-			AWS:   &AWSROpts{},
-			NATS:  &NATSROpts{},
-			Kafka: &KafkaROpts{},
-			SQL:   &SQLROpts{},
+			CSV:         &CSVROpts{},
+			AMQP:        &AMQPROpts{},
+			AWS:         &AWSROpts{},
+			NATS:        &NATSROpts{},
+			MQTT:        &MQTTROpts{},
+			RedisStream: &RedisStreamROpts{},
+			Kafka:       &KafkaROpts{},
+			SQL:         &SQLROpts{},
 		},
 	}
 	if err := eventReader.Opts.loadFromJSONCfg(eventReaderOptsJson); err != nil {
@@ -1572,6 +1598,8 @@ func TestEventReaderCfgClone(t *testing.T) {
 				ClientKey:            utils.StringPointer("key5"),
 				JetStreamMaxWait:     utils.DurationPointer(1 * time.Minute),
 			},
+			MQTT:        &MQTTROpts{},
+			RedisStream: &RedisStreamROpts{},
 			Kafka: &KafkaROpts{
 				Topic:   utils.StringPointer("kafka"),
 				MaxWait: utils.DurationPointer(1 * time.Minute),
@@ -1959,3 +1987,82 @@ func TestKafkaROptsClone(t *testing.T) {
 		t.Errorf("Expected cloned CAPath to be separate, got %s", *clonedOpts.CAPath)
 	}
 }
+
+func TestERsCfgRedisStreamROpts(t *testing.T) {
+	redisOpts := &RedisStreamROpts{}
+	jsnCfg := &EventReaderOptsJson{
+		RedisStream:            utils.StringPointer("cdrs"),
+		RedisGroup:             utils.StringPointer("billing"),
+		RedisConsumer:          utils.StringPointer("node1"),
+		RedisField:             utils.StringPointer("cdr"),
+		RedisCount:             utils.IntPointer(50),
+		RedisBlock:             utils.StringPointer("2s"),
+		RedisClaimMinIdle:      utils.StringPointer("5m"),
+		RedisClaimInterval:     utils.StringPointer("1m"),
+		RedisMaxDeliveries:     utils.IntPointer(3),
+		RedisTLS:               utils.BoolPointer(true),
+		RedisClientCertificate: utils.StringPointer("/tmp/client.crt"),
+		RedisClientKey:         utils.StringPointer("/tmp/client.key"),
+		RedisCACertificate:     utils.StringPointer("/tmp/ca.crt"),
+	}
+	exp := &RedisStreamROpts{
+		Stream:            utils.StringPointer("cdrs"),
+		Group:             utils.StringPointer("billing"),
+		Consumer:          utils.StringPointer("node1"),
+		Field:             utils.StringPointer("cdr"),
+		Count:             utils.IntPointer(50),
+		Block:             utils.DurationPointer(2 * time.Second),
+		ClaimMinIdle:      utils.DurationPointer(5 * time.Minute),
+		ClaimInterval:     utils.DurationPointer(time.Minute),
+		MaxDeliveries:     utils.IntPointer(3),
+		TLS:               utils.BoolPointer(true),
+		ClientCertificate: utils.StringPointer("/tmp/client.crt"),
+		ClientKey:         utils.StringPointer("/tmp/client.key"),
+		CACertificate:     utils.StringPointer("/tmp/ca.crt"),
+	}
+	if err := redisOpts.loadFromJSONCfg(jsnCfg); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exp, redisOpts) {
+		t.Errorf("\nexpected %s\nreceived  %s\n", utils.ToJSON(exp), utils.ToJSON(redisOpts))
+	}
+
+	cln := redisOpts.Clone()
+	if !reflect.DeepEqual(redisOpts, cln) {
+		t.Errorf("\nexpected %s\nreceived  %s\n", utils.ToJSON(redisOpts), utils.ToJSON(cln))
+	}
+	*cln.Group = "other"
+	if *redisOpts.Group != "billing" {
+		t.Error("expected the group to be cloned")
+	}
+
+	er := &EventReaderCfg{Opts: &EventReaderOpts{RedisStream: redisOpts}}
+	expOpts := map[string]any{
+		utils.RedisStreamOpt:         "cdrs",
+		utils.RedisGroup:             "billing",
+		utils.RedisConsumer:          "node1",
+		utils.RedisField:             "cdr",
+		utils.RedisCount:             50,
+		utils.RedisBlock:             "2s",
+		utils.RedisClaimMinIdle:      "5m0s",
+		utils.RedisClaimInterval:     "1m0s",
+		utils.RedisMaxDeliveries:     3,
+		utils.RedisTLS:               true,
+		utils.RedisClientCertificate: "/tmp/client.crt",
+		utils.RedisClientKey:         "/tmp/client.key",
+		utils.RedisCACertificate:     "/tmp/ca.crt",
+	}
+	if rcv := er.AsMapInterface(utils.EmptyString)[utils.OptsCfg]; !reflect.DeepEqual(expOpts, rcv) {
+		t.Errorf("\nexpected %s\nreceived  %s\n", utils.ToJSON(expOpts), utils.ToJSON(rcv))
+	}
+
+	for _, jsnCfg := range []*EventReaderOptsJson{
+		{RedisBlock: utils.StringPointer("1ss")},
+		{RedisClaimMinIdle: utils.StringPointer("1ss")},
+		{RedisClaimInterval: utils.StringPointer("1ss")},
+	} {
+		if err := new(RedisStreamROpts).loadFromJSONCfg(jsnCfg); err == nil {
+			t.Errorf("expected error for %s", utils.ToJSON(jsnCfg))
+		}
+	}
+}
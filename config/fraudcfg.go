@@ -0,0 +1,258 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// FraudSCfg is the configuration of the FraudS
+type FraudSCfg struct {
+	Enabled         bool
+	ThresholdSConns []string
+	SessionTTL      time.Duration // active sessions not released within this interval are dropped
+	CleanupInterval time.Duration // interval to remove the accounts without recent activity
+	Profiles        []*FraudProfileCfg
+}
+
+// FraudProfileCfg defines the indicators scored for the accounts matching it. The
+// profile with the highest weight out of the ones matching the event is applied
+type FraudProfileCfg struct {
+	ID           string
+	Filters      []string
+	Weight       float64
+	Window       time.Duration // sliding window the indicators are computed on
+	Baseline     time.Duration // history the window values are compared with
+	FlagScore    float64       // score flagging the event, 0 to disable
+	BlockScore   float64       // score blocking the event, 0 to disable
+	ThresholdIDs []string      // thresholds processing the alerts, empty for the matching ones
+	Indicators   []*FraudIndicatorCfg
+}
+
+// FraudIndicatorCfg is one of the metrics scored by a fraud profile
+type FraudIndicatorCfg struct {
+	Type     string
+	Filters  []string // only the events passing them are counted by the indicator
+	Factor   float64  // triggers when the value exceeds the baseline multiplied by this
+	MinValue float64  // the value needs to reach this before triggering
+	Score    float64  // added to the event score when triggered
+}
+
+func (fCfg *FraudSCfg) loadFromJSONCfg(jsnCfg *FraudSJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		fCfg.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Thresholds_conns != nil {
+		fCfg.ThresholdSConns = tagInternalConns(*jsnCfg.Thresholds_conns, utils.MetaThresholds)
+	}
+	if jsnCfg.Session_ttl != nil {
+		if fCfg.SessionTTL, err = utils.ParseDurationWithNanosecs(*jsnCfg.Session_ttl); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Cleanup_interval != nil {
+		if fCfg.CleanupInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Cleanup_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Profiles == nil {
+		return
+	}
+	for _, jsnPrf := range *jsnCfg.Profiles {
+		prf := new(FraudProfileCfg)
+		var haveID bool
+		for _, prfSet := range fCfg.Profiles {
+			if jsnPrf.Id != nil && prfSet.ID == *jsnPrf.Id {
+				prf = prfSet // Will load data into the one set
+				haveID = true
+				break
+			}
+		}
+		if err = prf.loadFromJSONCfg(jsnPrf); err != nil {
+			return
+		}
+		if !haveID {
+			fCfg.Profiles = append(fCfg.Profiles, prf)
+		}
+	}
+	return
+}
+
+func (prf *FraudProfileCfg) loadFromJSONCfg(jsnCfg *FraudProfileJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Id != nil {
+		prf.ID = *jsnCfg.Id
+	}
+	if jsnCfg.Filters != nil {
+		prf.Filters = slices.Clone(*jsnCfg.Filters)
+	}
+	if jsnCfg.Weight != nil {
+		prf.Weight = *jsnCfg.Weight
+	}
+	if jsnCfg.Window != nil {
+		if prf.Window, err = utils.ParseDurationWithNanosecs(*jsnCfg.Window); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Baseline != nil {
+		if prf.Baseline, err = utils.ParseDurationWithNanosecs(*jsnCfg.Baseline); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Flag_score != nil {
+		prf.FlagScore = *jsnCfg.Flag_score
+	}
+	if jsnCfg.Block_score != nil {
+		prf.BlockScore = *jsnCfg.Block_score
+	}
+	if jsnCfg.Threshold_ids != nil {
+		prf.ThresholdIDs = slices.Clone(*jsnCfg.Threshold_ids)
+	}
+	if jsnCfg.Indicators != nil {
+		prf.Indicators = make([]*FraudIndicatorCfg, len(*jsnCfg.Indicators))
+		for i, jsnInd := range *jsnCfg.Indicators {
+			prf.Indicators[i] = new(FraudIndicatorCfg)
+			prf.Indicators[i].loadFromJSONCfg(jsnInd)
+		}
+	}
+	return
+}
+
+func (ind *FraudIndicatorCfg) loadFromJSONCfg(jsnCfg *FraudIndicatorJsonCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Type != nil {
+		ind.Type = *jsnCfg.Type
+	}
+	if jsnCfg.Filters != nil {
+		ind.Filters = slices.Clone(*jsnCfg.Filters)
+	}
+	if jsnCfg.Factor != nil {
+		ind.Factor = *jsnCfg.Factor
+	}
+	if jsnCfg.Min_value != nil {
+		ind.MinValue = *jsnCfg.Min_value
+	}
+	if jsnCfg.Score != nil {
+		ind.Score = *jsnCfg.Score
+	}
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (fCfg *FraudSCfg) AsMapInterface() map[string]any {
+	profiles := make([]map[string]any, len(fCfg.Profiles))
+	for i, prf := range fCfg.Profiles {
+		profiles[i] = prf.AsMapInterface()
+	}
+	return map[string]any{
+		utils.EnabledCfg:         fCfg.Enabled,
+		utils.ThreshSConnsCfg:    stripInternalConns(fCfg.ThresholdSConns),
+		utils.SessionTTLCfg:      fCfg.SessionTTL.String(),
+		utils.CleanupIntervalCfg: fCfg.CleanupInterval.String(),
+		utils.ProfilesCfg:        profiles,
+	}
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (prf *FraudProfileCfg) AsMapInterface() map[string]any {
+	indicators := make([]map[string]any, len(prf.Indicators))
+	for i, ind := range prf.Indicators {
+		indicators[i] = ind.AsMapInterface()
+	}
+	return map[string]any{
+		utils.IDCfg:           prf.ID,
+		utils.FiltersCfg:      slices.Clone(prf.Filters),
+		utils.WeightCfg:       prf.Weight,
+		utils.WindowCfg:       prf.Window.String(),
+		utils.BaselineCfg:     prf.Baseline.String(),
+		utils.FlagScoreCfg:    prf.FlagScore,
+		utils.BlockScoreCfg:   prf.BlockScore,
+		utils.ThresholdIDsCfg: slices.Clone(prf.ThresholdIDs),
+		utils.IndicatorsCfg:   indicators,
+	}
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (ind *FraudIndicatorCfg) AsMapInterface() map[string]any {
+	return map[string]any{
+		utils.TypeCfg:     ind.Type,
+		utils.FiltersCfg:  slices.Clone(ind.Filters),
+		utils.FactorCfg:   ind.Factor,
+		utils.MinValueCfg: ind.MinValue,
+		utils.ScoreCfg:    ind.Score,
+	}
+}
+
+// Clone returns a deep copy of FraudSCfg
+func (fCfg *FraudSCfg) Clone() (cln *FraudSCfg) {
+	cln = &FraudSCfg{
+		Enabled:         fCfg.Enabled,
+		ThresholdSConns: slices.Clone(fCfg.ThresholdSConns),
+		SessionTTL:      fCfg.SessionTTL,
+		CleanupInterval: fCfg.CleanupInterval,
+	}
+	if fCfg.Profiles != nil {
+		cln.Profiles = make([]*FraudProfileCfg, len(fCfg.Profiles))
+		for i, prf := range fCfg.Profiles {
+			cln.Profiles[i] = prf.Clone()
+		}
+	}
+	return
+}
+
+// Clone returns a deep copy of FraudProfileCfg
+func (prf *FraudProfileCfg) Clone() (cln *FraudProfileCfg) {
+	cln = &FraudProfileCfg{
+		ID:           prf.ID,
+		Filters:      slices.Clone(prf.Filters),
+		Weight:       prf.Weight,
+		Window:       prf.Window,
+		Baseline:     prf.Baseline,
+		FlagScore:    prf.FlagScore,
+		BlockScore:   prf.BlockScore,
+		ThresholdIDs: slices.Clone(prf.ThresholdIDs),
+	}
+	if prf.Indicators != nil {
+		cln.Indicators = make([]*FraudIndicatorCfg, len(prf.Indicators))
+		for i, ind := range prf.Indicators {
+			cln.Indicators[i] = ind.Clone()
+		}
+	}
+	return
+}
+
+// Clone returns a deep copy of FraudIndicatorCfg
+func (ind *FraudIndicatorCfg) Clone() *FraudIndicatorCfg {
+	return &FraudIndicatorCfg{
+		Type:     ind.Type,
+		Filters:  slices.Clone(ind.Filters),
+		Factor:   ind.Factor,
+		MinValue: ind.MinValue,
+		Score:    ind.Score,
+	}
+}
@@ -0,0 +1,253 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestFraudSCfgLoadFromJSONCfg(t *testing.T) {
+	jsnCfg := &FraudSJsonCfg{
+		Enabled:          utils.BoolPointer(true),
+		Thresholds_conns: &[]string{utils.MetaInternal, "*conn1"},
+		Session_ttl:      utils.StringPointer("2h"),
+		Cleanup_interval: utils.StringPointer("30m"),
+		Profiles: &[]*FraudProfileJsonCfg{
+			{
+				Id:            utils.StringPointer("DEFAULT"),
+				Filters:       &[]string{"*string:~*req.Tenant:cgrates.org"},
+				Weight:        utils.Float64Pointer(10),
+				Window:        utils.StringPointer("1h"),
+				Baseline:      utils.StringPointer("168h"),
+				Flag_score:    utils.Float64Pointer(50),
+				Block_score:   utils.Float64Pointer(100),
+				Threshold_ids: &[]string{"THD_FRAUD"},
+				Indicators: &[]*FraudIndicatorJsonCfg{
+					{
+						Type:      utils.StringPointer(utils.MetaCostPerHour),
+						Factor:    utils.Float64Pointer(3),
+						Min_value: utils.Float64Pointer(10),
+						Score:     utils.Float64Pointer(50),
+					},
+					{
+						Type:    utils.StringPointer(utils.MetaHighRiskCalls),
+						Filters: &[]string{"*prefix:~*req.Destination:882"},
+						Score:   utils.Float64Pointer(60),
+					},
+				},
+			},
+		},
+	}
+	expected := &FraudSCfg{
+		Enabled:         true,
+		ThresholdSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds), "*conn1"},
+		SessionTTL:      2 * time.Hour,
+		CleanupInterval: 30 * time.Minute,
+		Profiles: []*FraudProfileCfg{
+			{
+				ID:           "DEFAULT",
+				Filters:      []string{"*string:~*req.Tenant:cgrates.org"},
+				Weight:       10,
+				Window:       time.Hour,
+				Baseline:     168 * time.Hour,
+				FlagScore:    50,
+				BlockScore:   100,
+				ThresholdIDs: []string{"THD_FRAUD"},
+				Indicators: []*FraudIndicatorCfg{
+					{Type: utils.MetaCostPerHour, Factor: 3, MinValue: 10, Score: 50},
+					{Type: utils.MetaHighRiskCalls, Filters: []string{"*prefix:~*req.Destination:882"}, Score: 60},
+				},
+			},
+		},
+	}
+	cfg := NewDefaultCGRConfig()
+	if err := cfg.fraudSCfg.loadFromJSONCfg(jsnCfg); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, cfg.fraudSCfg) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expected), utils.ToJSON(cfg.fraudSCfg))
+	}
+
+	// profiles with the same id are merged
+	if err := cfg.fraudSCfg.loadFromJSONCfg(&FraudSJsonCfg{
+		Profiles: &[]*FraudProfileJsonCfg{
+			{Id: utils.StringPointer("DEFAULT"), Block_score: utils.Float64Pointer(80)},
+		},
+	}); err != nil {
+		t.Error(err)
+	} else if len(cfg.fraudSCfg.Profiles) != 1 || cfg.fraudSCfg.Profiles[0].BlockScore != 80 ||
+		cfg.fraudSCfg.Profiles[0].FlagScore != 50 {
+		t.Errorf("unexpected profiles: %s", utils.ToJSON(cfg.fraudSCfg.Profiles))
+	}
+}
+
+func TestFraudSCfgLoadFromJSONCfgErrors(t *testing.T) {
+	fCfg := new(FraudSCfg)
+	if err := fCfg.loadFromJSONCfg(&FraudSJsonCfg{Session_ttl: utils.StringPointer("1s1")}); err == nil {
+		t.Error("expected error for invalid session_ttl")
+	}
+	if err := fCfg.loadFromJSONCfg(&FraudSJsonCfg{Cleanup_interval: utils.StringPointer("1s1")}); err == nil {
+		t.Error("expected error for invalid cleanup_interval")
+	}
+	if err := fCfg.loadFromJSONCfg(&FraudSJsonCfg{Profiles: &[]*FraudProfileJsonCfg{
+		{Id: utils.StringPointer("P1"), Window: utils.StringPointer("1s1")},
+	}}); err == nil {
+		t.Error("expected error for invalid window")
+	}
+	if err := fCfg.loadFromJSONCfg(&FraudSJsonCfg{Profiles: &[]*FraudProfileJsonCfg{
+		{Id: utils.StringPointer("P1"), Baseline: utils.StringPointer("1s1")},
+	}}); err == nil {
+		t.Error("expected error for invalid baseline")
+	}
+}
+
+func TestFraudSCfgAsMapInterface(t *testing.T) {
+	cfgJSONStr := `{
+	"fraud": {
+		"enabled": true,
+		"thresholds_conns": ["*internal"],
+		"profiles": [
+			{
+				"id": "DEFAULT",
+				"window": "1h",
+				"baseline": "24h",
+				"flag_score": 50,
+				"indicators": [
+					{"type": "*concurrent_calls", "factor": 2, "min_value": 5, "score": 50}
+				]
+			}
+		]
+	}
+}`
+	expected := map[string]any{
+		utils.EnabledCfg:         true,
+		utils.ThreshSConnsCfg:    []string{utils.MetaInternal},
+		utils.SessionTTLCfg:      "3h0m0s",
+		utils.CleanupIntervalCfg: "1h0m0s",
+		utils.ProfilesCfg: []map[string]any{
+			{
+				utils.IDCfg:           "DEFAULT",
+				utils.FiltersCfg:      []string(nil),
+				utils.WeightCfg:       0.,
+				utils.WindowCfg:       "1h0m0s",
+				utils.BaselineCfg:     "24h0m0s",
+				utils.FlagScoreCfg:    50.,
+				utils.BlockScoreCfg:   0.,
+				utils.ThresholdIDsCfg: []string(nil),
+				utils.IndicatorsCfg: []map[string]any{
+					{
+						utils.TypeCfg:     utils.MetaConcurrentCalls,
+						utils.FiltersCfg:  []string(nil),
+						utils.FactorCfg:   2.,
+						utils.MinValueCfg: 5.,
+						utils.ScoreCfg:    50.,
+					},
+				},
+			},
+		},
+	}
+	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
+		t.Error(err)
+	} else if rcv := cgrCfg.fraudSCfg.AsMapInterface(); !reflect.DeepEqual(expected, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expected), utils.ToJSON(rcv))
+	}
+}
+
+func TestFraudSCfgClone(t *testing.T) {
+	fCfg := &FraudSCfg{
+		Enabled:         true,
+		ThresholdSConns: []string{"*conn1"},
+		SessionTTL:      time.Hour,
+		Profiles: []*FraudProfileCfg{
+			{
+				ID:           "DEFAULT",
+				Filters:      []string{"*string:~*req.Tenant:cgrates.org"},
+				Window:       time.Hour,
+				Baseline:     24 * time.Hour,
+				ThresholdIDs: []string{"THD_FRAUD"},
+				Indicators: []*FraudIndicatorCfg{
+					{Type: utils.MetaNewDestinations, Filters: []string{"*gte:~*req.Usage:1s"}, Score: 10},
+				},
+			},
+		},
+	}
+	rcv := fCfg.Clone()
+	if !reflect.DeepEqual(fCfg, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(fCfg), utils.ToJSON(rcv))
+	}
+	if rcv.ThresholdSConns[0] = ""; fCfg.ThresholdSConns[0] != "*conn1" {
+		t.Error("expected clone to not modify the original")
+	}
+	if rcv.Profiles[0].Indicators[0].Filters[0] = ""; fCfg.Profiles[0].Indicators[0].Filters[0] != "*gte:~*req.Usage:1s" {
+		t.Error("expected clone to not modify the original")
+	}
+}
+
+func TestFraudSCfgSanityCheck(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.fraudSCfg.Enabled = true
+	cfg.fraudSCfg.ThresholdSConns = []string{utils.MetaInternal}
+	expected := "<ThresholdS> not enabled but requested by <FraudS> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("expected %q, received %v", expected, err)
+	}
+	cfg.fraudSCfg.ThresholdSConns = []string{"test"}
+	expected = "<FraudS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("expected %q, received %v", expected, err)
+	}
+	cfg.fraudSCfg.ThresholdSConns = nil
+
+	cfg.fraudSCfg.Profiles = []*FraudProfileCfg{{}}
+	expected = "<FraudS> profile without id"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("expected %q, received %v", expected, err)
+	}
+	cfg.fraudSCfg.Profiles = []*FraudProfileCfg{{ID: "P1"}}
+	expected = "<FraudS> window needs to be positive for profile <P1>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("expected %q, received %v", expected, err)
+	}
+	cfg.fraudSCfg.Profiles = []*FraudProfileCfg{{ID: "P1", Window: time.Hour, Baseline: 90 * time.Minute}}
+	expected = "<FraudS> baseline needs to be a multiple of window for profile <P1>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("expected %q, received %v", expected, err)
+	}
+	cfg.fraudSCfg.Profiles = []*FraudProfileCfg{{ID: "P1", Window: time.Hour, Baseline: 2 * time.Hour,
+		Indicators: []*FraudIndicatorCfg{{Type: "*unknown"}}}}
+	expected = "<FraudS> unsupported indicator type <*unknown> for profile <P1>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("expected %q, received %v", expected, err)
+	}
+	cfg.fraudSCfg.Profiles = []*FraudProfileCfg{
+		{ID: "P1", Window: time.Hour, Baseline: 2 * time.Hour},
+		{ID: "P1", Window: time.Hour, Baseline: 2 * time.Hour},
+	}
+	expected = "<FraudS> duplicated profile <P1>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("expected %q, received %v", expected, err)
+	}
+	cfg.fraudSCfg.Profiles = cfg.fraudSCfg.Profiles[:1]
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
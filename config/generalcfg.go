@@ -46,6 +46,8 @@ type GeneralCfg struct {
 	ConnectTimeout       time.Duration // timeout for RPC connection attempts
 	ReplyTimeout         time.Duration // timeout replies if not reaching back
 	LockingTimeout       time.Duration // locking mechanism timeout to avoid deadlocks
+	DistributedLocking   bool          // share the locks with the engines using the same DataDB
+	LockingLeaseTTL      time.Duration // lease duration of the distributed locks
 	DigestSeparator      string        //
 	DigestEqual          string        //
 	RSRSep               string        // separator used to split RSRParser (by default is used ";")
@@ -125,6 +127,14 @@ func (gencfg *GeneralCfg) loadFromJSONCfg(jsnGeneralCfg *GeneralJsonCfg) (err er
 			return err
 		}
 	}
+	if jsnGeneralCfg.Distributed_locking != nil {
+		gencfg.DistributedLocking = *jsnGeneralCfg.Distributed_locking
+	}
+	if jsnGeneralCfg.Locking_lease_ttl != nil {
+		if gencfg.LockingLeaseTTL, err = utils.ParseDurationWithNanosecs(*jsnGeneralCfg.Locking_lease_ttl); err != nil {
+			return err
+		}
+	}
 	if jsnGeneralCfg.Digest_separator != nil {
 		gencfg.DigestSeparator = *jsnGeneralCfg.Digest_separator
 	}
@@ -165,6 +175,8 @@ func (gencfg *GeneralCfg) AsMapInterface() (initialMP map[string]any) {
 		utils.RSRSepCfg:               gencfg.RSRSep,
 		utils.MaxParallelConnsCfg:     gencfg.MaxParallelConns,
 		utils.LockingTimeoutCfg:       "0",
+		utils.DistributedLockingCfg:   gencfg.DistributedLocking,
+		utils.LockingLeaseTTLCfg:      "0",
 		utils.ConnectTimeoutCfg:       "0",
 		utils.ReplyTimeoutCfg:         "0",
 	}
@@ -177,6 +189,10 @@ func (gencfg *GeneralCfg) AsMapInterface() (initialMP map[string]any) {
 		initialMP[utils.LockingTimeoutCfg] = gencfg.LockingTimeout.String()
 	}
 
+	if gencfg.LockingLeaseTTL != 0 {
+		initialMP[utils.LockingLeaseTTLCfg] = gencfg.LockingLeaseTTL.String()
+	}
+
 	if gencfg.ConnectTimeout != 0 {
 		initialMP[utils.ConnectTimeoutCfg] = gencfg.ConnectTimeout.String()
 	}
@@ -212,6 +228,8 @@ func (gencfg GeneralCfg) Clone() *GeneralCfg {
 		ConnectTimeout:       gencfg.ConnectTimeout,
 		ReplyTimeout:         gencfg.ReplyTimeout,
 		LockingTimeout:       gencfg.LockingTimeout,
+		DistributedLocking:   gencfg.DistributedLocking,
+		LockingLeaseTTL:      gencfg.LockingLeaseTTL,
 		DigestSeparator:      gencfg.DigestSeparator,
 		DigestEqual:          gencfg.DigestEqual,
 		RSRSep:               gencfg.RSRSep,
@@ -66,6 +66,7 @@ func TestGeneralCfgloadFromJsonCfg(t *testing.T) {
 		MaxParallelConns: 100,
 		RSRSep:           ";",
 		DefaultCaching:   utils.MetaReload,
+		LockingLeaseTTL:  5 * time.Second,
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err := jsnCfg.generalCfg.loadFromJSONCfg(cfgJSON); err != nil {
@@ -107,6 +108,14 @@ func TestGeneralParseDurationCfgloadFromJsonCfg(t *testing.T) {
 		t.Errorf("Expected %+v, received %v", expected, err)
 	}
 
+	cfgJSON3 := &GeneralJsonCfg{
+		Locking_lease_ttl: utils.StringPointer("1ss"),
+	}
+	jsonCfg = NewDefaultCGRConfig()
+	if err := jsonCfg.generalCfg.loadFromJSONCfg(cfgJSON3); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %v", expected, err)
+	}
+
 }
 
 func TestGeneralCfgAsMapInterface(t *testing.T) {
@@ -157,6 +166,8 @@ func TestGeneralCfgAsMapInterface(t *testing.T) {
 		utils.ConnectTimeoutCfg:       "1s",
 		utils.ReplyTimeoutCfg:         "2s",
 		utils.LockingTimeoutCfg:       "1s",
+		utils.DistributedLockingCfg:   false,
+		utils.LockingLeaseTTLCfg:      "5s",
 		utils.DigestSeparatorCfg:      ",",
 		utils.DigestEqualCfg:          ":",
 		utils.RSRSepCfg:               ";",
@@ -199,6 +210,8 @@ func TestGeneralCfgAsMapInterface1(t *testing.T) {
 		utils.ConnectTimeoutCfg:       "0",
 		utils.ReplyTimeoutCfg:         "0",
 		utils.LockingTimeoutCfg:       "0",
+		utils.DistributedLockingCfg:   false,
+		utils.LockingLeaseTTLCfg:      "5s",
 		utils.DigestSeparatorCfg:      ",",
 		utils.DigestEqualCfg:          ":",
 		utils.RSRSepCfg:               ";",
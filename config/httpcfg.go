@@ -22,6 +22,8 @@ import (
 	"crypto/tls"
 	"net"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -31,6 +33,10 @@ type HTTPCfg struct {
 	HTTPJsonRPCURL        string            // JSON RPC relative URL ("" to disable)
 	RegistrarSURL         string            // registrar service relative URL
 	HTTPWSURL             string            // WebSocket relative URL ("" to disable)
+	BiRPCWSURL            string            // BiRPC over WebSocket relative URL ("" to disable)
+	BiRPCWSPing           time.Duration     // interval between the pings sent on the BiRPC WebSocket connections
+	BiRPCWSOrigins        []string          // host patterns allowed for the cross-origin BiRPC WebSocket connections
+	BiRPCWSReadLimit      int64             // maximum size in bytes of a message read from the BiRPC WebSocket connections
 	HTTPFreeswitchCDRsURL string            // Freeswitch CDRS relative URL ("" to disable)
 	HTTPCDRsURL           string            // CDRS relative URL ("" to disable)
 	PprofPath             string            // runtime profiling url path ("" to disable)
@@ -126,6 +132,20 @@ func (httpcfg *HTTPCfg) loadFromJSONCfg(jsnHTTPCfg *HTTPJsonCfg) (err error) {
 	if jsnHTTPCfg.Ws_url != nil {
 		httpcfg.HTTPWSURL = *jsnHTTPCfg.Ws_url
 	}
+	if jsnHTTPCfg.Birpc_ws_url != nil {
+		httpcfg.BiRPCWSURL = *jsnHTTPCfg.Birpc_ws_url
+	}
+	if jsnHTTPCfg.Birpc_ws_ping != nil {
+		if httpcfg.BiRPCWSPing, err = utils.ParseDurationWithNanosecs(*jsnHTTPCfg.Birpc_ws_ping); err != nil {
+			return
+		}
+	}
+	if jsnHTTPCfg.Birpc_ws_origins != nil {
+		httpcfg.BiRPCWSOrigins = slices.Clone(*jsnHTTPCfg.Birpc_ws_origins)
+	}
+	if jsnHTTPCfg.Birpc_ws_read_limit != nil {
+		httpcfg.BiRPCWSReadLimit = *jsnHTTPCfg.Birpc_ws_read_limit
+	}
 	if jsnHTTPCfg.Freeswitch_cdrs_url != nil {
 		httpcfg.HTTPFreeswitchCDRsURL = *jsnHTTPCfg.Freeswitch_cdrs_url
 	}
@@ -175,6 +195,10 @@ func (httpcfg *HTTPCfg) AsMapInterface() map[string]any {
 		utils.HTTPJsonRPCURLCfg:        httpcfg.HTTPJsonRPCURL,
 		utils.RegistrarSURLCfg:         httpcfg.RegistrarSURL,
 		utils.HTTPWSURLCfg:             httpcfg.HTTPWSURL,
+		utils.BiRPCWSURLCfg:            httpcfg.BiRPCWSURL,
+		utils.BiRPCWSPingCfg:           httpcfg.BiRPCWSPing.String(),
+		utils.BiRPCWSOriginsCfg:        slices.Clone(httpcfg.BiRPCWSOrigins),
+		utils.BiRPCWSReadLimitCfg:      httpcfg.BiRPCWSReadLimit,
 		utils.HTTPFreeswitchCDRsURLCfg: httpcfg.HTTPFreeswitchCDRsURL,
 		utils.HTTPCDRsURLCfg:           httpcfg.HTTPCDRsURL,
 		utils.PprofPathCfg:             httpcfg.PprofPath,
@@ -195,6 +219,10 @@ func (httpcfg HTTPCfg) Clone() (cln *HTTPCfg) {
 		HTTPJsonRPCURL:        httpcfg.HTTPJsonRPCURL,
 		RegistrarSURL:         httpcfg.RegistrarSURL,
 		HTTPWSURL:             httpcfg.HTTPWSURL,
+		BiRPCWSURL:            httpcfg.BiRPCWSURL,
+		BiRPCWSPing:           httpcfg.BiRPCWSPing,
+		BiRPCWSOrigins:        slices.Clone(httpcfg.BiRPCWSOrigins),
+		BiRPCWSReadLimit:      httpcfg.BiRPCWSReadLimit,
 		HTTPFreeswitchCDRsURL: httpcfg.HTTPFreeswitchCDRsURL,
 		HTTPCDRsURL:           httpcfg.HTTPCDRsURL,
 		PprofPath:             httpcfg.PprofPath,
@@ -32,6 +32,10 @@ func TestHTTPCfgloadFromJsonCfg(t *testing.T) {
 	cfgJSONStr := &HTTPJsonCfg{
 		Json_rpc_url:        utils.StringPointer("/jsonrpc"),
 		Ws_url:              utils.StringPointer("/ws"),
+		Birpc_ws_url:        utils.StringPointer("/birpc"),
+		Birpc_ws_ping:       utils.StringPointer("10s"),
+		Birpc_ws_origins:    &[]string{"*.example.com"},
+		Birpc_ws_read_limit: utils.Int64Pointer(4096),
 		Registrars_url:      utils.StringPointer("/randomUrl"),
 		PprofPath:           utils.StringPointer("/pprof/test"),
 		Freeswitch_cdrs_url: utils.StringPointer("/freeswitch_json"),
@@ -42,6 +46,10 @@ func TestHTTPCfgloadFromJsonCfg(t *testing.T) {
 	expected := &HTTPCfg{
 		HTTPJsonRPCURL:        "/jsonrpc",
 		HTTPWSURL:             "/ws",
+		BiRPCWSURL:            "/birpc",
+		BiRPCWSPing:           10 * time.Second,
+		BiRPCWSOrigins:        []string{"*.example.com"},
+		BiRPCWSReadLimit:      4096,
 		RegistrarSURL:         "/randomUrl",
 		HTTPFreeswitchCDRsURL: "/freeswitch_json",
 		HTTPCDRsURL:           "/cdr_http",
@@ -88,6 +96,10 @@ func TestHTTPCfgAsMapInterface(t *testing.T) {
 		utils.HTTPJsonRPCURLCfg:        "/jsonrpc",
 		utils.RegistrarSURLCfg:         "/registrar",
 		utils.HTTPWSURLCfg:             "/ws",
+		utils.BiRPCWSURLCfg:            "",
+		utils.BiRPCWSPingCfg:           "30s",
+		utils.BiRPCWSOriginsCfg:        []string{},
+		utils.BiRPCWSReadLimitCfg:      int64(1048576),
 		utils.HTTPFreeswitchCDRsURLCfg: "/freeswitch_json",
 		utils.HTTPCDRsURLCfg:           "/cdr_http",
 		utils.PprofPathCfg:             "/debug/pprof/",
@@ -122,6 +134,8 @@ func TestHTTPCfgAsMapInterface1(t *testing.T) {
 	"http": {
 		"json_rpc_url": "/rpc",					
 		"ws_url": "",	
+		"birpc_ws_url": "/birpc",
+		"birpc_ws_ping": "0",
 		"pprof_path": "/pprof/test",
 		"use_basic_auth": true,					
 		"auth_users": {"user1": "authenticated", "user2": "authenticated"},
@@ -132,6 +146,10 @@ func TestHTTPCfgAsMapInterface1(t *testing.T) {
 		utils.RegistrarSURLCfg:         "/registrar",
 		utils.PprofPathCfg:             "/pprof/test",
 		utils.HTTPWSURLCfg:             "",
+		utils.BiRPCWSURLCfg:            "/birpc",
+		utils.BiRPCWSPingCfg:           "0s",
+		utils.BiRPCWSOriginsCfg:        []string{},
+		utils.BiRPCWSReadLimitCfg:      int64(1048576),
 		utils.HTTPFreeswitchCDRsURLCfg: "/freeswitch_json",
 		utils.HTTPCDRsURLCfg:           "/cdr_http",
 		utils.HTTPUseBasicAuthCfg:      true,
@@ -167,6 +185,10 @@ func TestHTTPCfgClone(t *testing.T) {
 	ban := &HTTPCfg{
 		HTTPJsonRPCURL:        "/jsonrpc",
 		HTTPWSURL:             "/ws",
+		BiRPCWSURL:            "/birpc",
+		BiRPCWSPing:           30 * time.Second,
+		BiRPCWSOrigins:        []string{"*.example.com"},
+		BiRPCWSReadLimit:      4096,
 		RegistrarSURL:         "/randomUrl",
 		HTTPFreeswitchCDRsURL: "/freeswitch_json",
 		HTTPCDRsURL:           "/cdr_http",
@@ -0,0 +1,137 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// InvoiceSCfg is the configuration of the InvoiceS
+type InvoiceSCfg struct {
+	Enabled        bool
+	CDRsConns      []string
+	EEsConns       []string
+	EEsExporterIDs []string
+	RunIDs         []string           // only the CDRs of these runs are invoiced
+	NumberPrefix   string             // prefix of the invoice numbers
+	TaxRates       map[string]float64 // tax percentage per destination ID, *any applies to everything else
+	HTMLTemplate   string             // path to the html/template rendering the *html invoices
+	DocumentFormat string             // format of the document exported to EEs: <*json|*csv|*html>
+}
+
+func (iCfg *InvoiceSCfg) loadFromJSONCfg(jsnCfg *InvoiceSJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		iCfg.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Cdrs_conns != nil {
+		iCfg.CDRsConns = make([]string, len(*jsnCfg.Cdrs_conns))
+		for idx, connID := range *jsnCfg.Cdrs_conns {
+			iCfg.CDRsConns[idx] = connID
+			if connID == utils.MetaInternal {
+				iCfg.CDRsConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs)
+			}
+		}
+	}
+	if jsnCfg.Ees_conns != nil {
+		iCfg.EEsConns = make([]string, len(*jsnCfg.Ees_conns))
+		for idx, connID := range *jsnCfg.Ees_conns {
+			iCfg.EEsConns[idx] = connID
+			if connID == utils.MetaInternal {
+				iCfg.EEsConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)
+			}
+		}
+	}
+	if jsnCfg.Ees_exporter_ids != nil {
+		iCfg.EEsExporterIDs = slices.Clone(*jsnCfg.Ees_exporter_ids)
+	}
+	if jsnCfg.Run_ids != nil {
+		iCfg.RunIDs = slices.Clone(*jsnCfg.Run_ids)
+	}
+	if jsnCfg.Number_prefix != nil {
+		iCfg.NumberPrefix = *jsnCfg.Number_prefix
+	}
+	if jsnCfg.Tax_rates != nil {
+		if iCfg.TaxRates == nil {
+			iCfg.TaxRates = make(map[string]float64)
+		}
+		maps.Copy(iCfg.TaxRates, jsnCfg.Tax_rates)
+	}
+	if jsnCfg.Html_template != nil {
+		iCfg.HTMLTemplate = *jsnCfg.Html_template
+	}
+	if jsnCfg.Document_format != nil {
+		iCfg.DocumentFormat = *jsnCfg.Document_format
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (iCfg *InvoiceSCfg) AsMapInterface() (initialMP map[string]any) {
+	initialMP = map[string]any{
+		utils.EnabledCfg:        iCfg.Enabled,
+		utils.EEsExporterIDsCfg: slices.Clone(iCfg.EEsExporterIDs),
+		utils.RunIDsCfg:         slices.Clone(iCfg.RunIDs),
+		utils.NumberPrefixCfg:   iCfg.NumberPrefix,
+		utils.TaxRatesCfg:       maps.Clone(iCfg.TaxRates),
+		utils.HTMLTemplateCfg:   iCfg.HTMLTemplate,
+		utils.DocumentFormatCfg: iCfg.DocumentFormat,
+	}
+	if iCfg.CDRsConns != nil {
+		cdrsConns := make([]string, len(iCfg.CDRsConns))
+		for i, item := range iCfg.CDRsConns {
+			cdrsConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs) {
+				cdrsConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.CDRsConnsCfg] = cdrsConns
+	}
+	if iCfg.EEsConns != nil {
+		eesConns := make([]string, len(iCfg.EEsConns))
+		for i, item := range iCfg.EEsConns {
+			eesConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs) {
+				eesConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.EEsConnsCfg] = eesConns
+	}
+	return
+}
+
+// Clone returns a deep copy of InvoiceSCfg
+func (iCfg *InvoiceSCfg) Clone() *InvoiceSCfg {
+	return &InvoiceSCfg{
+		Enabled:        iCfg.Enabled,
+		CDRsConns:      slices.Clone(iCfg.CDRsConns),
+		EEsConns:       slices.Clone(iCfg.EEsConns),
+		EEsExporterIDs: slices.Clone(iCfg.EEsExporterIDs),
+		RunIDs:         slices.Clone(iCfg.RunIDs),
+		NumberPrefix:   iCfg.NumberPrefix,
+		TaxRates:       maps.Clone(iCfg.TaxRates),
+		HTMLTemplate:   iCfg.HTMLTemplate,
+		DocumentFormat: iCfg.DocumentFormat,
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestInvoiceSCfgLoadFromJSONCfg(t *testing.T) {
+	jsnCfg := &InvoiceSJsonCfg{
+		Enabled:          utils.BoolPointer(true),
+		Cdrs_conns:       &[]string{utils.MetaInternal, "conn1"},
+		Ees_conns:        &[]string{utils.MetaInternal},
+		Ees_exporter_ids: &[]string{"exporter1"},
+		Run_ids:          &[]string{utils.MetaDefault, "retail"},
+		Number_prefix:    utils.StringPointer("CGR"),
+		Tax_rates:        map[string]float64{utils.MetaAny: 19, "DST_EU": 21},
+		Html_template:    utils.StringPointer("/tmp/invoice.html"),
+		Document_format:  utils.StringPointer(utils.MetaHTML),
+	}
+	expected := &InvoiceSCfg{
+		Enabled:        true,
+		CDRsConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs), "conn1"},
+		EEsConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)},
+		EEsExporterIDs: []string{"exporter1"},
+		RunIDs:         []string{utils.MetaDefault, "retail"},
+		NumberPrefix:   "CGR",
+		TaxRates:       map[string]float64{utils.MetaAny: 19, "DST_EU": 21},
+		HTMLTemplate:   "/tmp/invoice.html",
+		DocumentFormat: utils.MetaHTML,
+	}
+	cfg := NewDefaultCGRConfig()
+	if err := cfg.invoiceSCfg.loadFromJSONCfg(jsnCfg); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, cfg.invoiceSCfg) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expected), utils.ToJSON(cfg.invoiceSCfg))
+	}
+	if err := cfg.invoiceSCfg.loadFromJSONCfg(nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInvoiceSCfgAsMapInterface(t *testing.T) {
+	cfgJSONStr := `{
+	"invoices": {
+		"enabled": true,
+		"cdrs_conns": ["*internal"],
+		"ees_conns": ["*internal", "conn1"],
+		"tax_rates": {"*any": 20},
+		"document_format": "*csv",
+	},
+}`
+	eMap := map[string]any{
+		utils.EnabledCfg:        true,
+		utils.CDRsConnsCfg:      []string{utils.MetaInternal},
+		utils.EEsConnsCfg:       []string{utils.MetaInternal, "conn1"},
+		utils.EEsExporterIDsCfg: []string{},
+		utils.RunIDsCfg:         []string{utils.MetaDefault},
+		utils.NumberPrefixCfg:   "INV",
+		utils.TaxRatesCfg:       map[string]float64{utils.MetaAny: 20},
+		utils.HTMLTemplateCfg:   "",
+		utils.DocumentFormatCfg: utils.MetaCSV,
+	}
+	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
+		t.Error(err)
+	} else if rcv := cgrCfg.invoiceSCfg.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
+
+func TestInvoiceSCfgClone(t *testing.T) {
+	iCfg := &InvoiceSCfg{
+		Enabled:        true,
+		CDRsConns:      []string{"conn1"},
+		EEsConns:       []string{"conn2"},
+		EEsExporterIDs: []string{"exporter1"},
+		RunIDs:         []string{utils.MetaDefault},
+		NumberPrefix:   "INV",
+		TaxRates:       map[string]float64{utils.MetaAny: 20},
+		DocumentFormat: utils.MetaJSON,
+	}
+	rcv := iCfg.Clone()
+	if !reflect.DeepEqual(iCfg, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(iCfg), utils.ToJSON(rcv))
+	}
+	if rcv.CDRsConns[0] = ""; iCfg.CDRsConns[0] != "conn1" {
+		t.Error("expected clone to not modify the cloned")
+	}
+	if rcv.TaxRates[utils.MetaAny] = 0; iCfg.TaxRates[utils.MetaAny] != 20 {
+		t.Error("expected clone to not modify the cloned")
+	}
+}
+
+func TestInvoiceSCfgSanityCheck(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.invoiceSCfg.Enabled = true
+	if err := cfg.checkConfigSanity(); err == nil ||
+		err.Error() != "<InvoiceS> no CDRs connections defined" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	cfg.invoiceSCfg.CDRsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs)}
+	if err := cfg.checkConfigSanity(); err == nil ||
+		err.Error() != "<CDRs> not enabled but requested by <InvoiceS> component" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	cfg.invoiceSCfg.CDRsConns = []string{"conn1"}
+	if err := cfg.checkConfigSanity(); err == nil ||
+		err.Error() != "<InvoiceS> connection with id: <conn1> not defined" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	cfg.rpcConns["conn1"] = new(RPCConn)
+	cfg.invoiceSCfg.DocumentFormat = utils.MetaXml
+	if err := cfg.checkConfigSanity(); err == nil ||
+		err.Error() != "<InvoiceS> unsupported document_format: <*xml>" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	cfg.invoiceSCfg.DocumentFormat = utils.MetaHTML
+	cfg.invoiceSCfg.TaxRates = map[string]float64{"DST_EU": -1}
+	if err := cfg.checkConfigSanity(); err == nil ||
+		err.Error() != "<InvoiceS> negative tax rate for destination <DST_EU>" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	cfg.invoiceSCfg.TaxRates = map[string]float64{"DST_EU": 21}
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
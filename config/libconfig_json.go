@@ -46,6 +46,8 @@ type GeneralJsonCfg struct {
 	Connect_timeout        *string
 	Reply_timeout          *string
 	Locking_timeout        *string
+	Distributed_locking    *bool
+	Locking_lease_ttl      *string
 	Digest_separator       *string
 	Digest_equal           *string
 	Rsr_separator          *string
@@ -84,6 +86,10 @@ type HTTPJsonCfg struct {
 	Json_rpc_url        *string
 	Registrars_url      *string
 	Ws_url              *string
+	Birpc_ws_url        *string
+	Birpc_ws_ping       *string
+	Birpc_ws_origins    *[]string
+	Birpc_ws_read_limit *int64
 	Freeswitch_cdrs_url *string
 	Http_Cdrs           *string
 	PprofPath           *string `json:"pprof_path"`
@@ -109,6 +115,8 @@ type DBOptsJson struct {
 	InternalDBDumpInterval    *string           `json:"internalDBDumpInterval"`
 	InternalDBRewriteInterval *string           `json:"internalDBRewriteInterval"`
 	InternalDBFileSizeLimit   *string           `json:"internalDBFileSizeLimit"`
+	InternalDBWAL             *bool             `json:"internalDBWAL"`
+	InternalDBWALSyncInterval *string           `json:"internalDBWALSyncInterval"`
 	RedisMaxConns             *int              `json:"redisMaxConns"`
 	RedisConnectAttempts      *int              `json:"redisConnectAttempts"`
 	RedisSentinel             *string           `json:"redisSentinel"`
@@ -202,6 +210,7 @@ type SchedulerJsonCfg struct {
 	Cdrs_conns              *[]string
 	Thresholds_conns        *[]string
 	Stats_conns             *[]string
+	Invoices_conns          *[]string
 	Filters                 *[]string
 	Dynaprepaid_actionplans *[]string
 }
@@ -212,6 +221,7 @@ type CdrsJsonCfg struct {
 	Extra_fields         *[]string
 	Store_cdrs           *bool
 	Compress_stored_cost *bool
+	Apply_taxes          *bool
 	Session_cost_retries *int
 	Chargers_conns       *[]string
 	Rals_conns           *[]string
@@ -280,6 +290,30 @@ type EventReaderOptsJson struct {
 	NATSClientCertificate    *string   `json:"natsClientCertificate"`
 	NATSClientKey            *string   `json:"natsClientKey"`
 	NATSJetStreamMaxWait     *string   `json:"natsJetStreamMaxWait"`
+	MQTTTopic                *string   `json:"mqttTopic"`
+	MQTTQoS                  *int      `json:"mqttQoS"`
+	MQTTSharedGroup          *string   `json:"mqttSharedGroup"`
+	MQTTClientID             *string   `json:"mqttClientID"`
+	MQTTUsername             *string   `json:"mqttUsername"`
+	MQTTPassword             *string   `json:"mqttPassword"`
+	MQTTTLS                  *bool     `json:"mqttTLS"`
+	MQTTCAPath               *string   `json:"mqttCAPath"`
+	MQTTSkipTLSVerify        *bool     `json:"mqttSkipTLSVerify"`
+	MQTTClientCertificate    *string   `json:"mqttClientCertificate"`
+	MQTTClientKey            *string   `json:"mqttClientKey"`
+	RedisStream              *string   `json:"redisStream"`
+	RedisGroup               *string   `json:"redisGroup"`
+	RedisConsumer            *string   `json:"redisConsumer"`
+	RedisField               *string   `json:"redisField"`
+	RedisCount               *int      `json:"redisCount"`
+	RedisBlock               *string   `json:"redisBlock"`
+	RedisClaimMinIdle        *string   `json:"redisClaimMinIdle"`
+	RedisClaimInterval       *string   `json:"redisClaimInterval"`
+	RedisMaxDeliveries       *int      `json:"redisMaxDeliveries"`
+	RedisTLS                 *bool     `json:"redisTLS"`
+	RedisClientCertificate   *string   `json:"redisClientCertificate"`
+	RedisClientKey           *string   `json:"redisClientKey"`
+	RedisCACertificate       *string   `json:"redisCACertificate"`
 }
 
 // EventReaderSJsonCfg is the configuration of a single EventReader
@@ -353,6 +387,10 @@ type EventExporterOptsJson struct {
 	SQLTableName                *string           `json:"sqlTableName"`
 	SQLDBName                   *string           `json:"sqlDBName"`
 	SQLUpdateIndexedFields      *[]string         `json:"sqlUpdateIndexedFields"`
+	SQLBatchSize                *int              `json:"sqlBatchSize"`
+	SQLBatchInterval            *string           `json:"sqlBatchInterval"`
+	SQLConflictFields           *[]string         `json:"sqlConflictFields"`
+	SQLCreateTable              *bool             `json:"sqlCreateTable"`
 	PgSSLMode                   *string           `json:"pgSSLMode"`
 	KafkaTopic                  *string           `json:"kafkaTopic"`
 	KafkaBatchSize              *int              `json:"kafkaBatchSize"`
@@ -384,6 +422,33 @@ type EventExporterOptsJson struct {
 	NATSClientCertificate       *string           `json:"natsClientCertificate"`
 	NATSClientKey               *string           `json:"natsClientKey"`
 	NATSJetStreamMaxWait        *string           `json:"natsJetStreamMaxWait"`
+	MQTTTopic                   *string           `json:"mqttTopic"`
+	MQTTQoS                     *int              `json:"mqttQoS"`
+	MQTTRetain                  *bool             `json:"mqttRetain"`
+	MQTTClientID                *string           `json:"mqttClientID"`
+	MQTTUsername                *string           `json:"mqttUsername"`
+	MQTTPassword                *string           `json:"mqttPassword"`
+	MQTTTLS                     *bool             `json:"mqttTLS"`
+	MQTTCAPath                  *string           `json:"mqttCAPath"`
+	MQTTSkipTLSVerify           *bool             `json:"mqttSkipTLSVerify"`
+	MQTTClientCertificate       *string           `json:"mqttClientCertificate"`
+	MQTTClientKey               *string           `json:"mqttClientKey"`
+	RedisStream                 *string           `json:"redisStream"`
+	RedisField                  *string           `json:"redisField"`
+	RedisMaxLen                 *int              `json:"redisMaxLen"`
+	RedisMaxLenApprox           *bool             `json:"redisMaxLenApprox"`
+	RedisTLS                    *bool             `json:"redisTLS"`
+	RedisClientCertificate      *string           `json:"redisClientCertificate"`
+	RedisClientKey              *string           `json:"redisClientKey"`
+	RedisCACertificate          *string           `json:"redisCACertificate"`
+	WebhookSecret               *string           `json:"webhookSecret"`
+	WebhookSignatureHeader      *string           `json:"webhookSignatureHeader"`
+	WebhookTimestampHeader      *string           `json:"webhookTimestampHeader"`
+	WebhookAuthHeaders          map[string]string `json:"webhookAuthHeaders"`
+	WebhookBackoff              *string           `json:"webhookBackoff"`
+	WebhookMaxBackoff           *string           `json:"webhookMaxBackoff"`
+	WebhookBreakerThreshold     *int              `json:"webhookBreakerThreshold"`
+	WebhookBreakerCooldown      *string           `json:"webhookBreakerCooldown"`
 	RPCCodec                    *string           `json:"rpcCodec"`
 	ServiceMethod               *string           `json:"serviceMethod"`
 	KeyPath                     *string           `json:"keyPath"`
@@ -423,6 +488,7 @@ type SessionSJsonCfg struct {
 	ChargerSConns          *[]string          `json:"chargers_conns"`
 	RALsConns              *[]string          `json:"rals_conns"`
 	IPsConns               *[]string          `json:"ips_conns"`
+	FraudSConns            *[]string          `json:"fraud_conns"`
 	ResourceSConns         *[]string          `json:"resources_conns"`
 	ThresholdSConns        *[]string          `json:"thresholds_conns"`
 	StatSConns             *[]string          `json:"stats_conns"`
@@ -742,6 +808,50 @@ type TrendsJsonCfg struct {
 	Ees_exporter_ids         *[]string
 }
 
+// FraudSJsonCfg is the fraud config section
+type FraudSJsonCfg struct {
+	Enabled          *bool
+	Thresholds_conns *[]string
+	Session_ttl      *string
+	Cleanup_interval *string
+	Profiles         *[]*FraudProfileJsonCfg
+}
+
+// FraudProfileJsonCfg is one profile out of the fraud config section
+type FraudProfileJsonCfg struct {
+	Id            *string
+	Filters       *[]string
+	Weight        *float64
+	Window        *string
+	Baseline      *string
+	Flag_score    *float64
+	Block_score   *float64
+	Threshold_ids *[]string
+	Indicators    *[]*FraudIndicatorJsonCfg
+}
+
+// FraudIndicatorJsonCfg is one indicator out of a fraud profile
+type FraudIndicatorJsonCfg struct {
+	Type      *string
+	Filters   *[]string
+	Factor    *float64
+	Min_value *float64
+	Score     *float64
+}
+
+// InvoiceSJsonCfg is the invoices config section
+type InvoiceSJsonCfg struct {
+	Enabled          *bool
+	Cdrs_conns       *[]string
+	Ees_conns        *[]string
+	Ees_exporter_ids *[]string
+	Run_ids          *[]string
+	Number_prefix    *string
+	Tax_rates        map[string]float64
+	Html_template    *string
+	Document_format  *string
+}
+
 type RankingsJsonCfg struct {
 	Enabled          *bool
 	Stats_conns      *[]string
@@ -972,6 +1082,19 @@ type SIPAgentJsonCfg struct {
 	RequestProcessors   *[]*ReqProcessorJsnCfg `json:"request_processors"`
 }
 
+// SMPPAgentJsonCfg
+type SMPPAgentJsonCfg struct {
+	Enabled           *bool                  `json:"enabled"`
+	Listen            *string                `json:"listen"`
+	SystemID          *string                `json:"system_id"`
+	BindCredentials   *map[string]string     `json:"bind_credentials"`
+	SessionSConns     *[]string              `json:"sessions_conns"`
+	StatSConns        *[]string              `json:"stats_conns"`
+	ThresholdSConns   *[]string              `json:"thresholds_conns"`
+	Timezone          *string                `json:"timezone"`
+	RequestProcessors *[]*ReqProcessorJsnCfg `json:"request_processors"`
+}
+
 type JanusAgentJsonCfg struct {
 	Enabled           *bool                  `json:"enabled"`
 	Url               *string                `json:"url"`
@@ -998,6 +1121,27 @@ type APIBanJsonCfg struct {
 	Keys    *[]string
 }
 
+// APIAuthJsonCfg
+type APIAuthJsonCfg struct {
+	Enabled   *bool                      `json:"enabled"`
+	JWTSecret *string                    `json:"jwt_secret"`
+	Roles     *map[string][]string       `json:"roles"`
+	APIKeys   *map[string]*APIKeyJsonCfg `json:"api_keys"`
+}
+
+// APIKeyJsonCfg
+type APIKeyJsonCfg struct {
+	Key    *string   `json:"key"`
+	Tenant *string   `json:"tenant"`
+	Roles  *[]string `json:"roles"`
+}
+
+// AuditJsonCfg
+type AuditJsonCfg struct {
+	Enabled      *bool     `json:"enabled"`
+	Object_types *[]string `json:"object_types"`
+}
+
 type SentryPeerJsonCfg struct {
 	ClientID     *string `json:"client_id"`
 	ClientSecret *string `json:"client_secret"`
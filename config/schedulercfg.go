@@ -28,6 +28,7 @@ type SchedulerCfg struct {
 	CDRsConns              []string
 	ThreshSConns           []string
 	StatSConns             []string
+	InvoiceSConns          []string
 	Filters                []string
 	DynaprepaidActionPlans []string
 }
@@ -73,6 +74,16 @@ func (schdcfg *SchedulerCfg) loadFromJSONCfg(jsnCfg *SchedulerJsonCfg) error {
 			}
 		}
 	}
+	if jsnCfg.Invoices_conns != nil {
+		schdcfg.InvoiceSConns = make([]string, len(*jsnCfg.Invoices_conns))
+		for idx, connID := range *jsnCfg.Invoices_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			schdcfg.InvoiceSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				schdcfg.InvoiceSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaInvoices)
+			}
+		}
+	}
 	if jsnCfg.Dynaprepaid_actionplans != nil {
 		schdcfg.DynaprepaidActionPlans = make([]string, len(*jsnCfg.Dynaprepaid_actionplans))
 		copy(schdcfg.DynaprepaidActionPlans, *jsnCfg.Dynaprepaid_actionplans)
@@ -117,6 +128,16 @@ func (schdcfg *SchedulerCfg) AsMapInterface() (initialMP map[string]any) {
 		}
 		initialMP[utils.StatSConnsCfg] = stsConns
 	}
+	if schdcfg.InvoiceSConns != nil {
+		invConns := make([]string, len(schdcfg.InvoiceSConns))
+		for i, item := range schdcfg.InvoiceSConns {
+			invConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaInvoices) {
+				invConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.InvoiceSConnsCfg] = invConns
+	}
 	return
 }
 
@@ -137,6 +158,10 @@ func (schdcfg SchedulerCfg) Clone() (cln *SchedulerCfg) {
 		cln.StatSConns = make([]string, len(schdcfg.StatSConns))
 		copy(cln.StatSConns, schdcfg.StatSConns)
 	}
+	if schdcfg.InvoiceSConns != nil {
+		cln.InvoiceSConns = make([]string, len(schdcfg.InvoiceSConns))
+		copy(cln.InvoiceSConns, schdcfg.InvoiceSConns)
+	}
 
 	if schdcfg.Filters != nil {
 		cln.Filters = make([]string, len(schdcfg.Filters))
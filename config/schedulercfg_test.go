@@ -30,6 +30,7 @@ func TestSchedulerCfgloadFromJsonCfg(t *testing.T) {
 		Cdrs_conns:              &[]string{utils.MetaInternal, "*conn1"},
 		Thresholds_conns:        &[]string{utils.MetaInternal, "*conn1"},
 		Stats_conns:             &[]string{utils.MetaInternal, "*conn1"},
+		Invoices_conns:          &[]string{utils.MetaInternal, "*conn1"},
 		Filters:                 &[]string{"randomFilter"},
 		Dynaprepaid_actionplans: &[]string{"randomPlan"},
 	}
@@ -38,6 +39,7 @@ func TestSchedulerCfgloadFromJsonCfg(t *testing.T) {
 		CDRsConns:              []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs), "*conn1"},
 		ThreshSConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds), "*conn1"},
 		StatSConns:             []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "*conn1"},
+		InvoiceSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaInvoices), "*conn1"},
 		Filters:                []string{"randomFilter"},
 		DynaprepaidActionPlans: []string{"randomPlan"},
 	}
@@ -58,6 +60,7 @@ func TestSchedulerCfgAsMapInterface(t *testing.T) {
 		utils.CDRsConnsCfg:              []string{},
 		utils.ThreshSConnsCfg:           []string{},
 		utils.StatSConnsCfg:             []string{},
+		utils.InvoiceSConnsCfg:          []string{},
 		utils.FiltersCfg:                []string{},
 		utils.DynaprepaidActionplansCfg: []string{},
 	}
@@ -76,6 +79,7 @@ func TestSchedulerCfgAsMapInterface1(t *testing.T) {
 	   "cdrs_conns": ["*internal", "*conn1"],
 	   "thresholds_conns": ["*internal", "*conn1"],
 	   "stats_conns": ["*internal", "*conn1"],
+	   "invoices_conns": ["*internal", "*conn1"],
        "filters": ["randomFilter"],
 		"dynaprepaid_actionplans":["randomPlan"],
     },
@@ -85,6 +89,7 @@ func TestSchedulerCfgAsMapInterface1(t *testing.T) {
 		utils.CDRsConnsCfg:              []string{utils.MetaInternal, "*conn1"},
 		utils.ThreshSConnsCfg:           []string{utils.MetaInternal, "*conn1"},
 		utils.StatSConnsCfg:             []string{utils.MetaInternal, "*conn1"},
+		utils.InvoiceSConnsCfg:          []string{utils.MetaInternal, "*conn1"},
 		utils.FiltersCfg:                []string{"randomFilter"},
 		utils.DynaprepaidActionplansCfg: []string{"randomPlan"},
 	}
@@ -102,6 +107,7 @@ func TestSchedulerCfgClone(t *testing.T) {
 		CDRsConns:              []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs), "*conn1"},
 		ThreshSConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds), "*conn1"},
 		StatSConns:             []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "*conn1"},
+		InvoiceSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaInvoices), "*conn1"},
 		Filters:                []string{"randomFilter"},
 		DynaprepaidActionPlans: []string{"plan"},
 	}
@@ -118,6 +124,9 @@ func TestSchedulerCfgClone(t *testing.T) {
 	if rcv.StatSConns[1] = ""; ban.StatSConns[1] != "*conn1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.InvoiceSConns[1] = ""; ban.InvoiceSConns[1] != "*conn1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 	if rcv.Filters[0] = ""; ban.Filters[0] != "randomFilter" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
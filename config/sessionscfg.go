@@ -110,6 +110,7 @@ type SessionSCfg struct {
 	ChargerSConns          []string
 	RALsConns              []string
 	IPsConns               []string
+	FraudSConns            []string
 	ResourceSConns         []string
 	ThresholdSConns        []string
 	StatSConns             []string
@@ -173,6 +174,9 @@ func (scfg *SessionSCfg) loadFromJSONCfg(jsnCfg *SessionSJsonCfg) (err error) {
 	if jsnCfg.IPsConns != nil {
 		scfg.IPsConns = tagInternalConns(*jsnCfg.IPsConns, utils.MetaIPs)
 	}
+	if jsnCfg.FraudSConns != nil {
+		scfg.FraudSConns = tagInternalConns(*jsnCfg.FraudSConns, utils.MetaFraud)
+	}
 	if jsnCfg.ResourceSConns != nil {
 		scfg.ResourceSConns = tagInternalConns(*jsnCfg.ResourceSConns, utils.MetaResources)
 	}
@@ -310,6 +314,7 @@ func (scfg *SessionSCfg) AsMapInterface() (initialMP map[string]any) {
 		utils.ChargerSConnsCfg:          stripInternalConns(scfg.ChargerSConns),
 		utils.RALsConnsCfg:              stripInternalConns(scfg.RALsConns),
 		utils.IPsConnsCfg:               stripInternalConns(scfg.IPsConns),
+		utils.FraudSConnsCfg:            stripInternalConns(scfg.FraudSConns),
 		utils.ResourceSConnsCfg:         stripInternalConns(scfg.ResourceSConns),
 		utils.ThresholdSConnsCfg:        stripInternalConns(scfg.ThresholdSConns),
 		utils.StatSConnsCfg:             stripInternalConns(scfg.StatSConns),
@@ -372,6 +377,7 @@ func (scfg SessionSCfg) Clone() (cln *SessionSCfg) {
 		Enabled:                scfg.Enabled,
 		ListenBiJSON:           scfg.ListenBiJSON,
 		IPsConns:               slices.Clone(scfg.IPsConns),
+		FraudSConns:            slices.Clone(scfg.FraudSConns),
 		DebitInterval:          scfg.DebitInterval,
 		StoreSCosts:            scfg.StoreSCosts,
 		SessionTTL:             scfg.SessionTTL,
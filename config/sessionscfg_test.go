@@ -72,6 +72,7 @@ func TestSessionSCfgloadFromJsonCfgCase1(t *testing.T) {
 		ChargerSConns:       &[]string{utils.MetaInternal, "*conn1"},
 		RALsConns:           &[]string{utils.MetaInternal, "*conn1"},
 		IPsConns:            &[]string{utils.MetaInternal, "*conn1"},
+		FraudSConns:         &[]string{utils.MetaInternal, "*conn1"},
 		ResourceSConns:      &[]string{utils.MetaInternal, "*conn1"},
 		ThresholdSConns:     &[]string{utils.MetaInternal, "*conn1"},
 		StatSConns:          &[]string{utils.MetaInternal, "*conn1"},
@@ -103,6 +104,7 @@ func TestSessionSCfgloadFromJsonCfgCase1(t *testing.T) {
 		ChargerSConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaChargers), "*conn1"},
 		RALsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder), "*conn1"},
 		IPsConns:            []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaIPs), "*conn1"},
+		FraudSConns:         []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFraud), "*conn1"},
 		ResourceSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources), "*conn1"},
 		ThresholdSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds), "*conn1"},
 		StatSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "*conn1"},
@@ -242,6 +244,7 @@ func TestSessionSCfgloadFromJsonCfgCase10(t *testing.T) {
 		ChargerSConns:       []string{},
 		RALsConns:           []string{},
 		IPsConns:            []string{},
+		FraudSConns:         []string{},
 		ResourceSConns:      []string{},
 		ThresholdSConns:     []string{},
 		StatSConns:          []string{},
@@ -355,6 +358,7 @@ func TestSessionSCfgAsMapInterfaceCase1(t *testing.T) {
 		utils.RALsConnsCfg:              []string{},
 		utils.CDRsConnsCfg:              []string{},
 		utils.IPsConnsCfg:               []string{},
+		utils.FraudSConnsCfg:            []string{},
 		utils.ResourceSConnsCfg:         []string{},
 		utils.ThresholdSConnsCfg:        []string{},
 		utils.StatSConnsCfg:             []string{},
@@ -407,6 +411,7 @@ func TestSessionSCfgAsMapInterfaceCase2(t *testing.T) {
 			"rals_conns": ["*internal:*responder", "*conn1"],
 			"cdrs_conns": ["*internal:*cdrs", "*conn1"],
 			"ips_conns": ["*internal:*ips", "*conn1"],
+			"fraud_conns": ["*internal:*fraud", "*conn1"],
 			"resources_conns": ["*internal:*resources", "*conn1"],
 			"thresholds_conns": ["*internal:*thresholds", "*conn1"],
 			"stats_conns": ["*internal:*stats", "*conn1"],
@@ -437,6 +442,7 @@ func TestSessionSCfgAsMapInterfaceCase2(t *testing.T) {
 		utils.RALsConnsCfg:              []string{utils.MetaInternal, "*conn1"},
 		utils.CDRsConnsCfg:              []string{utils.MetaInternal, "*conn1"},
 		utils.IPsConnsCfg:               []string{utils.MetaInternal, "*conn1"},
+		utils.FraudSConnsCfg:            []string{utils.MetaInternal, "*conn1"},
 		utils.ResourceSConnsCfg:         []string{utils.MetaInternal, "*conn1"},
 		utils.ThresholdSConnsCfg:        []string{utils.MetaInternal, "*conn1"},
 		utils.StatSConnsCfg:             []string{utils.MetaInternal, "*conn1"},
@@ -954,6 +960,7 @@ func TestSessionSCfgClone(t *testing.T) {
 		ChargerSConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaChargers), "*conn1"},
 		RALsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder), "*conn1"},
 		IPsConns:            []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaIPs), "*conn1"},
+		FraudSConns:         []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFraud), "*conn1"},
 		ResourceSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources), "*conn1"},
 		ThresholdSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds), "*conn1"},
 		StatSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "*conn1"},
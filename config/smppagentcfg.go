@@ -0,0 +1,130 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SMPPAgentCfg the config section that describes the SMPP Agent
+type SMPPAgentCfg struct {
+	Enabled           bool
+	Listen            string
+	SystemID          string            // system_id sent back on bind responses
+	BindCredentials   map[string]string // password per ESME system_id, empty to accept all binds
+	SessionSConns     []string
+	StatSConns        []string
+	ThresholdSConns   []string
+	Timezone          string
+	RequestProcessors []*RequestProcessor
+}
+
+func (sa *SMPPAgentCfg) loadFromJSONCfg(jsnCfg *SMPPAgentJsonCfg, sep string) (err error) {
+	if jsnCfg == nil {
+		return nil
+	}
+	if jsnCfg.Enabled != nil {
+		sa.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Listen != nil {
+		sa.Listen = *jsnCfg.Listen
+	}
+	if jsnCfg.SystemID != nil {
+		sa.SystemID = *jsnCfg.SystemID
+	}
+	if jsnCfg.BindCredentials != nil {
+		sa.BindCredentials = make(map[string]string, len(*jsnCfg.BindCredentials))
+		maps.Copy(sa.BindCredentials, *jsnCfg.BindCredentials)
+	}
+	if jsnCfg.Timezone != nil {
+		sa.Timezone = *jsnCfg.Timezone
+	}
+	if jsnCfg.SessionSConns != nil {
+		sa.SessionSConns = tagInternalConns(*jsnCfg.SessionSConns, utils.MetaSessionS)
+	}
+	if jsnCfg.StatSConns != nil {
+		sa.StatSConns = tagInternalConns(*jsnCfg.StatSConns, utils.MetaStats)
+	}
+	if jsnCfg.ThresholdSConns != nil {
+		sa.ThresholdSConns = tagInternalConns(*jsnCfg.ThresholdSConns, utils.MetaThresholds)
+	}
+	if jsnCfg.RequestProcessors != nil {
+		for _, reqProcJsn := range *jsnCfg.RequestProcessors {
+			rp := new(RequestProcessor)
+			var haveID bool
+			for _, rpSet := range sa.RequestProcessors {
+				if reqProcJsn.ID != nil && rpSet.ID == *reqProcJsn.ID {
+					rp = rpSet // Will load data into the one set
+					haveID = true
+					break
+				}
+			}
+			if err = rp.loadFromJSONCfg(reqProcJsn, sep); err != nil {
+				return
+			}
+			if !haveID {
+				sa.RequestProcessors = append(sa.RequestProcessors, rp)
+			}
+		}
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (sa *SMPPAgentCfg) AsMapInterface(separator string) map[string]any {
+	requestProcessors := make([]map[string]any, len(sa.RequestProcessors))
+	for i, item := range sa.RequestProcessors {
+		requestProcessors[i] = item.AsMapInterface(separator)
+	}
+	return map[string]any{
+		utils.EnabledCfg:           sa.Enabled,
+		utils.ListenCfg:            sa.Listen,
+		utils.SystemIDCfg:          sa.SystemID,
+		utils.BindCredentialsCfg:   maps.Clone(sa.BindCredentials),
+		utils.SessionSConnsCfg:     stripInternalConns(sa.SessionSConns),
+		utils.StatSConnsCfg:        stripInternalConns(sa.StatSConns),
+		utils.ThresholdSConnsCfg:   stripInternalConns(sa.ThresholdSConns),
+		utils.TimezoneCfg:          sa.Timezone,
+		utils.RequestProcessorsCfg: requestProcessors,
+	}
+}
+
+// Clone returns a deep copy of SMPPAgentCfg
+func (sa SMPPAgentCfg) Clone() *SMPPAgentCfg {
+	clone := &SMPPAgentCfg{
+		Enabled:         sa.Enabled,
+		Listen:          sa.Listen,
+		SystemID:        sa.SystemID,
+		BindCredentials: maps.Clone(sa.BindCredentials),
+		SessionSConns:   slices.Clone(sa.SessionSConns),
+		StatSConns:      slices.Clone(sa.StatSConns),
+		ThresholdSConns: slices.Clone(sa.ThresholdSConns),
+		Timezone:        sa.Timezone,
+	}
+	if sa.RequestProcessors != nil {
+		clone.RequestProcessors = make([]*RequestProcessor, len(sa.RequestProcessors))
+		for i, rp := range sa.RequestProcessors {
+			clone.RequestProcessors[i] = rp.Clone()
+		}
+	}
+	return clone
+}
@@ -35,6 +35,8 @@ type StorDBOpts struct {
 	InternalDBDumpInterval    time.Duration // Regurarly dump database to file
 	InternalDBRewriteInterval time.Duration // Regurarly rewrite dump files
 	InternalDBFileSizeLimit   int64         // maximum size that can be written in a singular dump file
+	InternalDBWAL             bool          // log the changes in a write-ahead log replayed on start-up
+	InternalDBWALSyncInterval time.Duration // fsync the write-ahead log: -1 on each change, 0 left to the OS
 	SQLMaxOpenConns           int
 	SQLMaxIdleConns           int
 	SQLConnMaxLifetime        time.Duration
@@ -98,6 +100,14 @@ func (dbOpts *StorDBOpts) loadFromJSONCfg(jsnCfg *DBOptsJson) (err error) {
 			return err
 		}
 	}
+	if jsnCfg.InternalDBWAL != nil {
+		dbOpts.InternalDBWAL = *jsnCfg.InternalDBWAL
+	}
+	if jsnCfg.InternalDBWALSyncInterval != nil {
+		if dbOpts.InternalDBWALSyncInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.InternalDBWALSyncInterval); err != nil {
+			return err
+		}
+	}
 	if jsnCfg.SQLMaxOpenConns != nil {
 		dbOpts.SQLMaxOpenConns = *jsnCfg.SQLMaxOpenConns
 	}
@@ -229,6 +239,8 @@ func (dbOpts *StorDBOpts) Clone() *StorDBOpts {
 		InternalDBDumpInterval:    dbOpts.InternalDBDumpInterval,
 		InternalDBRewriteInterval: dbOpts.InternalDBRewriteInterval,
 		InternalDBFileSizeLimit:   dbOpts.InternalDBFileSizeLimit,
+		InternalDBWAL:             dbOpts.InternalDBWAL,
+		InternalDBWALSyncInterval: dbOpts.InternalDBWALSyncInterval,
 		SQLMaxOpenConns:           dbOpts.SQLMaxOpenConns,
 		SQLMaxIdleConns:           dbOpts.SQLMaxIdleConns,
 		SQLConnMaxLifetime:        dbOpts.SQLConnMaxLifetime,
@@ -291,6 +303,8 @@ func (dbcfg *StorDbCfg) AsMapInterface() (mp map[string]any) {
 		utils.InternalDBDumpIntervalCfg:    dbcfg.Opts.InternalDBDumpInterval.String(),
 		utils.InternalDBRewriteIntervalCfg: dbcfg.Opts.InternalDBRewriteInterval.String(),
 		utils.InternalDBFileSizeLimitCfg:   dbcfg.Opts.InternalDBFileSizeLimit,
+		utils.InternalDBWALCfg:             dbcfg.Opts.InternalDBWAL,
+		utils.InternalDBWALSyncIntervalCfg: dbcfg.Opts.InternalDBWALSyncInterval.String(),
 		utils.SQLMaxOpenConnsCfg:           dbcfg.Opts.SQLMaxOpenConns,
 		utils.SQLMaxIdleConnsCfg:           dbcfg.Opts.SQLMaxIdleConns,
 		utils.SQLConnMaxLifetime:           dbcfg.Opts.SQLConnMaxLifetime.String(),
@@ -253,6 +253,8 @@ func TestStorDbCfgAsMapInterface(t *testing.T) {
 			utils.InternalDBBackupPathCfg:      "/var/lib/cgrates/internal_db/backup/stordb",
 			utils.InternalDBRewriteIntervalCfg: "0s",
 			utils.InternalDBFileSizeLimitCfg:   int64(1073741824),
+			utils.InternalDBWALCfg:             false,
+			utils.InternalDBWALSyncIntervalCfg: "0s",
 			utils.SQLMaxOpenConnsCfg:           100,
 			utils.SQLMaxIdleConnsCfg:           10,
 			utils.SQLLogLevel:                  3,
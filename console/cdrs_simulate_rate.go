@@ -0,0 +1,64 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdSimulateRateCDRs{
+		name:      "cdrs_simulate_rate",
+		rpcMethod: utils.CDRsV1SimulateRateCDRs,
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// Commander implementation
+type CmdSimulateRateCDRs struct {
+	name      string
+	rpcMethod string
+	rpcParams *engine.ArgSimulateRateCDRs
+	*CommandExecuter
+}
+
+func (self *CmdSimulateRateCDRs) Name() string {
+	return self.name
+}
+
+func (self *CmdSimulateRateCDRs) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdSimulateRateCDRs) RpcParams(reset bool) any {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = new(engine.ArgSimulateRateCDRs)
+	}
+	return self.rpcParams
+}
+
+func (self *CmdSimulateRateCDRs) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdSimulateRateCDRs) RpcResult() any {
+	return new(engine.RateCDRsSimulation)
+}
@@ -0,0 +1,65 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/dispatchers"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdGetDispatcherHostsStats{
+		name:      "dispatchers_hosts_stats",
+		rpcMethod: utils.DispatcherSv1GetHostsStats,
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// Commander implementation
+type CmdGetDispatcherHostsStats struct {
+	name      string
+	rpcMethod string
+	rpcParams *utils.TenantIDWithAPIOpts
+	*CommandExecuter
+}
+
+func (self *CmdGetDispatcherHostsStats) Name() string {
+	return self.name
+}
+
+func (self *CmdGetDispatcherHostsStats) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdGetDispatcherHostsStats) RpcParams(reset bool) any {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &utils.TenantIDWithAPIOpts{}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdGetDispatcherHostsStats) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdGetDispatcherHostsStats) RpcResult() any {
+	var s map[string]*dispatchers.HostStats
+	return &s
+}
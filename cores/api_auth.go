@@ -0,0 +1,440 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// NewAPIAuth returns the authenticator of the API requests. The configuration is
+// read on each use so the reloads of the api_auth section apply without a restart
+func NewAPIAuth(cfg *config.CGRConfig) *APIAuth {
+	return &APIAuth{cfg: cfg}
+}
+
+// APIAuth authenticates the API callers using the API keys or the JWTs from the
+// api_auth configuration and authorizes their requests based on the role permissions
+type APIAuth struct {
+	cfg *config.CGRConfig
+}
+
+// apiIdentity is the authenticated caller of the APIs
+type apiIdentity struct {
+	ID     string
+	Tenant string // *any for access to all the tenants
+	Roles  []string
+}
+
+// apiClaims are the claims of the JWTs identifying the callers
+type apiClaims struct {
+	Tenant string   `json:"tenant"`
+	Roles  []string `json:"roles"`
+	jwt.StandardClaims
+}
+
+// authenticate returns the identity owning the API key or the one described by the JWT
+func (a *APIAuth) authenticate(token string) (*apiIdentity, error) {
+	if token == utils.EmptyString {
+		return nil, utils.ErrUnauthenticated
+	}
+	aaCfg := a.cfg.APIAuthCfg()
+	for id, key := range aaCfg.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(key.Key), []byte(token)) == 1 {
+			return &apiIdentity{ID: id, Tenant: key.Tenant, Roles: key.Roles}, nil
+		}
+	}
+	if aaCfg.JWTSecret == utils.EmptyString || strings.Count(token, utils.NestingSep) != 2 {
+		return nil, utils.ErrUnknownApiKey
+	}
+	claims := new(apiClaims)
+	if _, err := jwt.ParseWithClaims(token, claims, func(tkn *jwt.Token) (any, error) {
+		if tkn.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected signing method: %v", tkn.Header["alg"])
+		}
+		return []byte(aaCfg.JWTSecret), nil
+	}); err != nil {
+		return nil, err
+	}
+	if claims.Tenant == utils.EmptyString {
+		return nil, utils.NewErrMandatoryIeMissing(utils.Tenant)
+	}
+	return &apiIdentity{ID: claims.Subject, Tenant: claims.Tenant, Roles: claims.Roles}, nil
+}
+
+// authorize checks if any of the identity roles allows the method
+func (a *APIAuth) authorize(idt *apiIdentity, method string) error {
+	roles := a.cfg.APIAuthCfg().Roles
+	for _, role := range idt.Roles {
+		for _, pattern := range roles[role] {
+			if methodMatches(pattern, method) {
+				return nil
+			}
+		}
+	}
+	return utils.ErrUnauthorizedApi
+}
+
+// methodMatches matches the ServiceMethod against *any, a prefix ending in * or the exact name
+func methodMatches(pattern, method string) bool {
+	if pattern == utils.MetaAny {
+		return true
+	}
+	if prfx, isPrfx := strings.CutSuffix(pattern, "*"); isPrfx {
+		return strings.HasPrefix(method, prfx)
+	}
+	return pattern == method
+}
+
+// newSession returns the state of a new connection, nil if the authentication is disabled
+func (a *APIAuth) newSession() *apiAuthSession {
+	if a == nil || !a.cfg.APIAuthCfg().Enabled {
+		return nil
+	}
+	return &apiAuthSession{auth: a}
+}
+
+// newHTTPSession authenticates the connection with the credentials from the request
+// headers. The connections without credentials need to call AuthSv1.Authenticate
+func (a *APIAuth) newHTTPSession(r *http.Request) (sess *apiAuthSession, err error) {
+	if sess = a.newSession(); sess == nil {
+		return
+	}
+	token := r.Header.Get(utils.APIKeyHeader)
+	if token == utils.EmptyString {
+		token, _ = strings.CutPrefix(r.Header.Get(utils.AuthorizationHdr), utils.BearerAuth+" ")
+	}
+	if token == utils.EmptyString {
+		return
+	}
+	if sess.idt, err = a.authenticate(token); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed authenticating the request from <%s>: %v",
+			utils.APIAuth, r.RemoteAddr, err))
+		return nil, err
+	}
+	return
+}
+
+// apiAuthSession keeps the identity authenticated on one connection
+type apiAuthSession struct {
+	sync.RWMutex
+	auth *APIAuth
+	idt  *apiIdentity
+}
+
+// identity returns the ID of the authenticated caller
+func (s *apiAuthSession) identity() string {
+	s.RLock()
+	defer s.RUnlock()
+	if s.idt == nil {
+		return utils.EmptyString
+	}
+	return s.idt.ID
+}
+
+// checkRequest authenticates the connection on AuthSv1.Authenticate and authorizes the
+// rest of the requests, enforcing the tenant of the caller on their arguments
+func (s *apiAuthSession) checkRequest(method string, args any) (err error) {
+	switch method {
+	case utils.AuthSv1Authenticate:
+		var token string
+		if authArgs, canCast := args.(*utils.ArgsAuthenticate); canCast {
+			token = authArgs.Token
+		}
+		var idt *apiIdentity
+		if idt, err = s.auth.authenticate(token); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed authenticating the connection: %v", utils.APIAuth, err))
+		}
+		s.Lock()
+		s.idt = idt
+		s.Unlock()
+		return
+	case utils.SessionSv1CapsError: // the caps codec replies to it
+		return
+	}
+	s.RLock()
+	idt := s.idt
+	s.RUnlock()
+	if idt == nil {
+		return utils.ErrUnauthenticated
+	}
+	if err = s.auth.authorize(idt, method); err != nil {
+		return
+	}
+	setActor(reflect.ValueOf(args), idt.ID)
+	if idt.Tenant == utils.MetaAny {
+		return
+	}
+	var keysChecked, found bool
+	if keysChecked, err = enforceKeysTenant(args, idt.Tenant); err != nil {
+		return
+	}
+	if found, err = enforceTenant(reflect.ValueOf(args), idt.Tenant); err != nil {
+		return
+	}
+	if !found && !keysChecked { // the data accessed cannot be checked against the tenant
+		return utils.ErrUnauthorizedTenant
+	}
+	return
+}
+
+// setActor records the caller in the APIOpts of the arguments so the audit trail
+// knows who did the changes. Any actor sent by the caller is overwritten
+func setActor(v reflect.Value, actor string) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	optsFld, has := v.Type().FieldByName(utils.APIOpts)
+	if !has || optsFld.Type != reflect.TypeOf(map[string]any(nil)) {
+		return
+	}
+	opts, err := v.FieldByIndexErr(optsFld.Index)
+	if err != nil { // behind a nil embedded pointer
+		return
+	}
+	if opts.IsNil() {
+		if !opts.CanSet() {
+			return
+		}
+		opts.Set(reflect.ValueOf(make(map[string]any)))
+	}
+	opts.SetMapIndex(reflect.ValueOf(utils.OptsActor), reflect.ValueOf(actor))
+}
+
+// enforceTenant populates the empty Tenant fields of the arguments with the tenant of the
+// caller and rejects the requests for other tenants. The nested structures are checked as
+// well so the profiles sent over the APIs cannot be set on other tenants. Returns false
+// if the arguments have no Tenant fields to check
+func enforceTenant(v reflect.Value, tnt string) (found bool, err error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			var has bool
+			if has, err = enforceTenant(v.Index(i), tnt); err != nil {
+				return
+			}
+			found = found || has
+		}
+	case reflect.Struct:
+		vType := v.Type()
+		for i := range v.NumField() {
+			if !vType.Field(i).IsExported() {
+				continue
+			}
+			fld := v.Field(i)
+			switch name := vType.Field(i).Name; {
+			case name == utils.Tenant && fld.Kind() == reflect.String:
+				if fld.String() == utils.EmptyString && fld.CanSet() {
+					fld.SetString(tnt)
+				} else if fld.String() != tnt {
+					return true, utils.ErrUnauthorizedTenant
+				}
+				found = true
+			case name == "Tenants" && fld.Type() == reflect.TypeOf([]string(nil)):
+				if fld.Len() == 0 && fld.CanSet() {
+					fld.Set(reflect.ValueOf([]string{tnt}))
+				}
+				for j := range fld.Len() {
+					if fld.Index(j).String() != tnt {
+						return true, utils.ErrUnauthorizedTenant
+					}
+				}
+				found = found || fld.Len() != 0
+			default:
+				var has bool
+				if has, err = enforceTenant(fld, tnt); err != nil {
+					return
+				}
+				found = found || has
+			}
+		}
+	}
+	return
+}
+
+// enforceKeysTenant checks the tenant of the items referenced by their keys instead of the
+// Tenant field, ie: by the ReplicatorSv1 and CacheSv1 arguments, where the Tenant field is
+// only used to route the request. Returns false for the arguments without keys
+func enforceKeysTenant(args any, tnt string) (checked bool, err error) {
+	var keys []string
+	switch a := args.(type) {
+	case *utils.StringWithAPIOpts:
+		keys = []string{a.Arg}
+	case *utils.ArgsGetCacheItemWithAPIOpts:
+		keys = []string{a.ItemID}
+	case *utils.ArgsGetCacheItemIDsWithAPIOpts:
+		keys = []string{a.ItemIDPrefix}
+	case *utils.ArgsGetGroupWithAPIOpts:
+		keys = []string{a.GroupID}
+	case *utils.AttrReloadCacheWithAPIOpts:
+		v := reflect.ValueOf(a).Elem()
+		for i := range v.NumField() {
+			if ids, canCast := v.Field(i).Interface().([]string); canCast {
+				keys = append(keys, ids...)
+			}
+		}
+	case *utils.AttrCacheIDsWithAPIOpts: // the entire caches, shared by all the tenants
+	default:
+		return
+	}
+	if len(keys) == 0 {
+		return true, utils.ErrUnauthorizedTenant
+	}
+	for _, key := range keys {
+		if keyTenant(key) != tnt {
+			return true, utils.ErrUnauthorizedTenant
+		}
+	}
+	return true, nil
+}
+
+// keyTenant returns the tenant out of the tnt:id keys, including the rating profile
+// ones (*out:tnt:category:subject), empty for the keys without tenant
+func keyTenant(key string) string {
+	key = strings.TrimPrefix(key, utils.MetaOut+utils.ConcatenatedKeySep)
+	if tnt, _, has := strings.Cut(key, utils.ConcatenatedKeySep); has {
+		return tnt
+	}
+	return utils.EmptyString
+}
+
+// AuthSv1 authenticates the connections which cannot send the credentials as HTTP headers.
+// The credentials are verified by the codecs before the request reaches the service
+type AuthSv1 struct{}
+
+// Authenticate replies OK once the connection was authenticated with the token in args
+func (*AuthSv1) Authenticate(_ *context.Context, _ *utils.ArgsAuthenticate, reply *string) error {
+	*reply = utils.OK
+	return nil
+}
+
+// newAPIAuthServerCodec adds the authorization of the requests on top of the codec
+func newAPIAuthServerCodec(sc birpc.ServerCodec, sess *apiAuthSession) birpc.ServerCodec {
+	if sess == nil {
+		return sc
+	}
+	return &apiAuthServerCodec{
+		sc:   sc,
+		sess: sess,
+	}
+}
+
+type apiAuthServerCodec struct {
+	sc     birpc.ServerCodec
+	sess   *apiAuthSession
+	method string // of the request being read
+}
+
+func (c *apiAuthServerCodec) ReadRequestHeader(r *birpc.Request) (err error) {
+	err = c.sc.ReadRequestHeader(r)
+	c.method = r.ServiceMethod
+	return
+}
+
+func (c *apiAuthServerCodec) ReadRequestBody(x any) (err error) {
+	if err = c.sc.ReadRequestBody(x); err != nil || x == nil {
+		return
+	}
+	return c.sess.checkRequest(c.method, x)
+}
+
+func (c *apiAuthServerCodec) WriteResponse(r *birpc.Response, x any) error {
+	return c.sc.WriteResponse(r, x)
+}
+
+func (c *apiAuthServerCodec) Close() error { return c.sc.Close() }
+
+// RequestIdentity returns the authenticated caller so the AnalyzerS can record it
+func (c *apiAuthServerCodec) RequestIdentity() string { return c.sess.identity() }
+
+// newAPIAuthBiRPCCodec adds the authorization of the incoming requests on top of the codec
+func newAPIAuthBiRPCCodec(sc birpc.BirpcCodec, sess *apiAuthSession) birpc.BirpcCodec {
+	if sess == nil {
+		return sc
+	}
+	return &apiAuthBiRPCCodec{
+		sc:   sc,
+		sess: sess,
+	}
+}
+
+type apiAuthBiRPCCodec struct {
+	sc     birpc.BirpcCodec
+	sess   *apiAuthSession
+	method string // of the request being read, empty for replies
+}
+
+// ReadHeader must read a message and populate either the request
+// or the response by inspecting the incoming message.
+func (c *apiAuthBiRPCCodec) ReadHeader(req *birpc.Request, resp *birpc.Response) (err error) {
+	err = c.sc.ReadHeader(req, resp)
+	c.method = req.ServiceMethod
+	return
+}
+
+// ReadRequestBody into args argument of handler function.
+func (c *apiAuthBiRPCCodec) ReadRequestBody(x any) (err error) {
+	if err = c.sc.ReadRequestBody(x); err != nil || x == nil {
+		return
+	}
+	return c.sess.checkRequest(c.method, x)
+}
+
+// ReadResponseBody into reply argument of handler function.
+func (c *apiAuthBiRPCCodec) ReadResponseBody(x any) error {
+	return c.sc.ReadResponseBody(x)
+}
+
+// WriteRequest must be safe for concurrent use by multiple goroutines.
+func (c *apiAuthBiRPCCodec) WriteRequest(req *birpc.Request, x any) error {
+	return c.sc.WriteRequest(req, x)
+}
+
+// WriteResponse must be safe for concurrent use by multiple goroutines.
+func (c *apiAuthBiRPCCodec) WriteResponse(r *birpc.Response, x any) error {
+	return c.sc.WriteResponse(r, x)
+}
+
+// Close is called when client/server finished with the connection.
+func (c *apiAuthBiRPCCodec) Close() error { return c.sc.Close() }
+
+// RequestIdentity returns the authenticated caller so the AnalyzerS can record it
+func (c *apiAuthBiRPCCodec) RequestIdentity() string { return c.sess.identity() }
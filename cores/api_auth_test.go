@@ -0,0 +1,376 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/birpc/jsonrpc"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+func newTestAPIAuth() *APIAuth {
+	cfg := config.NewDefaultCGRConfig()
+	aaCfg := cfg.APIAuthCfg()
+	aaCfg.Enabled = true
+	aaCfg.JWTSecret = "secret"
+	aaCfg.Roles = map[string][]string{
+		"reseller": {"TestSv1.Get*"},
+		"admin":    {utils.MetaAny},
+	}
+	aaCfg.APIKeys = map[string]*config.APIKeyCfg{
+		"reseller1": {Key: "key1", Tenant: "reseller1.com", Roles: []string{"reseller"}},
+		"admin":     {Key: "key2", Tenant: utils.MetaAny, Roles: []string{"admin"}},
+	}
+	return NewAPIAuth(cfg)
+}
+
+func newTestJWT(t *testing.T, secret string, claims *apiClaims) string {
+	tkn, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tkn
+}
+
+func TestAPIAuthAuthenticate(t *testing.T) {
+	auth := newTestAPIAuth()
+	exp := &apiIdentity{ID: "reseller1", Tenant: "reseller1.com", Roles: []string{"reseller"}}
+	if idt, err := auth.authenticate("key1"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, idt) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(idt))
+	}
+	if _, err := auth.authenticate(utils.EmptyString); err != utils.ErrUnauthenticated {
+		t.Errorf("expected %v, received %v", utils.ErrUnauthenticated, err)
+	}
+	if _, err := auth.authenticate("key3"); err != utils.ErrUnknownApiKey {
+		t.Errorf("expected %v, received %v", utils.ErrUnknownApiKey, err)
+	}
+
+	exp = &apiIdentity{ID: "reseller2", Tenant: "reseller2.com", Roles: []string{"reseller"}}
+	claims := &apiClaims{Tenant: "reseller2.com", Roles: []string{"reseller"},
+		StandardClaims: jwt.StandardClaims{Subject: "reseller2", ExpiresAt: time.Now().Add(time.Hour).Unix()}}
+	if idt, err := auth.authenticate(newTestJWT(t, "secret", claims)); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, idt) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(idt))
+	}
+	if _, err := auth.authenticate(newTestJWT(t, "wrong", claims)); err == nil {
+		t.Error("expected error for the wrong signature")
+	}
+	claims.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+	if _, err := auth.authenticate(newTestJWT(t, "secret", claims)); err == nil ||
+		!strings.Contains(err.Error(), "expired") {
+		t.Errorf("expected expired token error, received %v", err)
+	}
+	claims.ExpiresAt = 0
+	claims.Tenant = utils.EmptyString
+	if _, err := auth.authenticate(newTestJWT(t, "secret", claims)); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.Tenant).Error() {
+		t.Errorf("expected missing tenant error, received %v", err)
+	}
+}
+
+func TestAPIAuthAuthorize(t *testing.T) {
+	auth := newTestAPIAuth()
+	idt := &apiIdentity{ID: "reseller1", Tenant: "reseller1.com", Roles: []string{"reseller", "unknown"}}
+	for method, exp := range map[string]error{
+		"TestSv1.GetAccount": nil,
+		"TestSv1.SetAccount": utils.ErrUnauthorizedApi,
+		"APIerSv1.GetCost":   utils.ErrUnauthorizedApi,
+	} {
+		if err := auth.authorize(idt, method); err != exp {
+			t.Errorf("%s: expected %v, received %v", method, exp, err)
+		}
+	}
+	idt.Roles = []string{"admin"}
+	if err := auth.authorize(idt, "APIerSv1.RemoveAccount"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAPIAuthEnforceTenant(t *testing.T) {
+	ev := &utils.CGREvent{Event: map[string]any{utils.Tenant: "reseller2.com"}}
+	if _, err := enforceTenant(reflect.ValueOf(ev), "reseller1.com"); err != nil {
+		t.Error(err)
+	} else if ev.Tenant != "reseller1.com" {
+		t.Errorf("expected the tenant populated, received %q", ev.Tenant)
+	}
+	tntID := &utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{Tenant: "reseller2.com", ID: "1001"}}
+	if _, err := enforceTenant(reflect.ValueOf(tntID), "reseller1.com"); err != utils.ErrUnauthorizedTenant {
+		t.Errorf("expected %v, received %v", utils.ErrUnauthorizedTenant, err)
+	}
+	attrPrf := &engine.AttributeProfileWithAPIOpts{AttributeProfile: &engine.AttributeProfile{
+		Tenant: "reseller1.com",
+		ID:     "ATTR1",
+		Attributes: []*engine.Attribute{{
+			Path:  utils.MetaReq + utils.NestingSep + utils.Tenant,
+			Value: config.NewRSRParsersMustCompile("reseller2.com", utils.InfieldSep),
+		}},
+	}}
+	if _, err := enforceTenant(reflect.ValueOf(attrPrf), "reseller1.com"); err != nil {
+		t.Error(err)
+	}
+	cdrsFltr := &utils.RPCCDRsFilterWithAPIOpts{RPCCDRsFilter: &utils.RPCCDRsFilter{}}
+	if _, err := enforceTenant(reflect.ValueOf(cdrsFltr), "reseller1.com"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual([]string{"reseller1.com"}, cdrsFltr.Tenants) {
+		t.Errorf("expected the tenants populated, received %v", cdrsFltr.Tenants)
+	}
+	cdrsFltr.Tenants = append(cdrsFltr.Tenants, "reseller2.com")
+	if _, err := enforceTenant(reflect.ValueOf(cdrsFltr), "reseller1.com"); err != utils.ErrUnauthorizedTenant {
+		t.Errorf("expected %v, received %v", utils.ErrUnauthorizedTenant, err)
+	}
+	evs := []*utils.CGREvent{{Tenant: "reseller1.com"}, {Tenant: "reseller2.com"}}
+	if _, err := enforceTenant(reflect.ValueOf(&evs), "reseller1.com"); err != utils.ErrUnauthorizedTenant {
+		t.Errorf("expected %v, received %v", utils.ErrUnauthorizedTenant, err)
+	}
+}
+
+func TestAPIAuthEnforceKeysTenant(t *testing.T) {
+	for _, args := range []any{
+		&utils.StringWithAPIOpts{Tenant: "reseller1.com", Arg: "reseller1.com:1001"},
+		&utils.StringWithAPIOpts{Arg: "*out:reseller1.com:call:1001"},
+		&utils.ArgsGetCacheItemWithAPIOpts{ArgsGetCacheItem: utils.ArgsGetCacheItem{
+			CacheID: utils.CacheAttributeProfiles, ItemID: "reseller1.com:ATTR1"}},
+		&utils.ArgsGetCacheItemIDsWithAPIOpts{ArgsGetCacheItemIDs: utils.ArgsGetCacheItemIDs{
+			CacheID: utils.CacheAttributeProfiles, ItemIDPrefix: "reseller1.com:"}},
+		&utils.AttrReloadCacheWithAPIOpts{AttributeProfileIDs: []string{"reseller1.com:ATTR1"},
+			AccountActionPlanIDs: []string{"reseller1.com:1001"}},
+	} {
+		if checked, err := enforceKeysTenant(args, "reseller1.com"); err != nil || !checked {
+			t.Errorf("expected %s allowed, received %v, %v", utils.ToJSON(args), checked, err)
+		}
+	}
+	for _, args := range []any{
+		&utils.StringWithAPIOpts{Tenant: "reseller1.com", Arg: "reseller2.com:1001"},
+		&utils.StringWithAPIOpts{Tenant: "reseller1.com", Arg: "*out:reseller2.com:call:1001"},
+		&utils.StringWithAPIOpts{Tenant: "reseller1.com", Arg: "DST_DE"},
+		&utils.ArgsGetCacheItemWithAPIOpts{Tenant: "reseller1.com", ArgsGetCacheItem: utils.ArgsGetCacheItem{
+			CacheID: utils.CacheAttributeProfiles, ItemID: "reseller2.com:ATTR1"}},
+		&utils.ArgsGetCacheItemIDsWithAPIOpts{ArgsGetCacheItemIDs: utils.ArgsGetCacheItemIDs{
+			CacheID: utils.CacheAttributeProfiles}},
+		&utils.AttrReloadCacheWithAPIOpts{AttributeProfileIDs: []string{"reseller1.com:ATTR1", utils.MetaAny}},
+		&utils.AttrReloadCacheWithAPIOpts{},
+		&utils.AttrCacheIDsWithAPIOpts{Tenant: "reseller1.com"},
+	} {
+		if _, err := enforceKeysTenant(args, "reseller1.com"); err != utils.ErrUnauthorizedTenant {
+			t.Errorf("expected %v for %s, received %v", utils.ErrUnauthorizedTenant, utils.ToJSON(args), err)
+		}
+	}
+	if checked, err := enforceKeysTenant(&utils.CGREvent{}, "reseller1.com"); err != nil || checked {
+		t.Errorf("expected no keys, received %v, %v", checked, err)
+	}
+}
+
+func TestAPIAuthCheckRequestNoTenant(t *testing.T) {
+	sess := &apiAuthSession{
+		auth: NewAPIAuth(config.NewDefaultCGRConfig()),
+		idt:  &apiIdentity{ID: "reseller1", Tenant: "reseller1.com", Roles: []string{"admin"}},
+	}
+	sess.auth.cfg.APIAuthCfg().Roles = map[string][]string{"admin": {utils.MetaAny}}
+	// the arguments without tenant cannot be checked so they are denied
+	for _, args := range []any{
+		&engine.AccountWithAPIOpts{Account: &engine.Account{ID: "reseller2.com:1001"}},
+		&utils.TenantIDWithAPIOpts{},
+		&map[string]any{utils.Tenant: "reseller1.com"},
+	} {
+		if err := sess.checkRequest(utils.ReplicatorSv1SetAccount, args); err != utils.ErrUnauthorizedTenant {
+			t.Errorf("expected %v for %s, received %v", utils.ErrUnauthorizedTenant, utils.ToJSON(args), err)
+		}
+	}
+	if err := sess.checkRequest(utils.ReplicatorSv1GetAccount,
+		&utils.StringWithAPIOpts{Arg: "reseller1.com:1001"}); err != nil {
+		t.Error(err)
+	}
+	if err := sess.checkRequest(utils.ReplicatorSv1GetAccount,
+		&utils.StringWithAPIOpts{Tenant: "reseller1.com", Arg: "reseller2.com:1001"}); err != utils.ErrUnauthorizedTenant {
+		t.Errorf("expected %v, received %v", utils.ErrUnauthorizedTenant, err)
+	}
+	if err := sess.checkRequest(utils.CoreSv1Ping, &utils.CGREvent{}); err != nil {
+		t.Error(err)
+	}
+	sess.idt.Tenant = utils.MetaAny
+	if err := sess.checkRequest(utils.ReplicatorSv1SetAccount,
+		&engine.AccountWithAPIOpts{Account: &engine.Account{ID: "reseller2.com:1001"}}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAPIAuthSetActor(t *testing.T) {
+	tntID := &utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{ID: "1001"}}
+	setActor(reflect.ValueOf(tntID), "reseller1")
+	if exp := map[string]any{utils.OptsActor: "reseller1"}; !reflect.DeepEqual(exp, tntID.APIOpts) {
+		t.Errorf("expected %v, received %v", exp, tntID.APIOpts)
+	}
+	rstArgs := &utils.ArgsRestoreAuditVersion{ArgsGetAuditRecords: utils.ArgsGetAuditRecords{
+		APIOpts: map[string]any{utils.OptsActor: "admin", utils.CacheOpt: utils.MetaNone},
+	}}
+	setActor(reflect.ValueOf(rstArgs), "reseller1")
+	if exp := map[string]any{utils.OptsActor: "reseller1", utils.CacheOpt: utils.MetaNone}; !reflect.DeepEqual(exp, rstArgs.APIOpts) {
+		t.Errorf("expected %v, received %v", exp, rstArgs.APIOpts)
+	}
+	fltr := &engine.FilterWithAPIOpts{}
+	setActor(reflect.ValueOf(fltr), "reseller1")
+	if fltr.APIOpts[utils.OptsActor] != "reseller1" {
+		t.Errorf("expected the actor populated, received %v", fltr.APIOpts)
+	}
+	setActor(reflect.ValueOf(&utils.TenantID{}), "reseller1") // no APIOpts
+	setActor(reflect.ValueOf((*utils.TenantIDWithAPIOpts)(nil)), "reseller1")
+}
+
+type testAuthSv1 struct{}
+
+func (*testAuthSv1) GetTenant(_ *context.Context, args *utils.TenantIDWithAPIOpts, reply *string) error {
+	*reply = args.Tenant
+	return nil
+}
+
+func (*testAuthSv1) SetTenant(_ *context.Context, args *utils.TenantIDWithAPIOpts, reply *string) error {
+	*reply = utils.OK
+	return nil
+}
+
+func newTestAuthServer() *Server {
+	srv := NewServer(engine.NewCaps(0, utils.MetaBusy))
+	srv.SetAPIAuth(newTestAPIAuth())
+	srv.RpcRegisterName("TestSv1", new(testAuthSv1))
+	return srv
+}
+
+func TestAPIAuthJSONCodec(t *testing.T) {
+	srv := newTestAuthServer()
+	srvConn, cliConn := net.Pipe()
+	go srv.rpcSrv.ServeCodec(newCapsJSONCodec(srvConn, srv.caps, nil, srv.auth.newSession()))
+	clnt := jsonrpc.NewClient(cliConn)
+	defer clnt.Close()
+
+	args := &utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{ID: "1001"}}
+	var reply string
+	if err := clnt.Call(context.Background(), "TestSv1.GetTenant", args, &reply); err == nil ||
+		err.Error() != utils.ErrUnauthenticated.Error() {
+		t.Errorf("expected %v, received %v", utils.ErrUnauthenticated, err)
+	}
+	if err := clnt.Call(context.Background(), utils.AuthSv1Authenticate,
+		&utils.ArgsAuthenticate{Token: "key3"}, &reply); err == nil || err.Error() != utils.ErrUnknownApiKey.Error() {
+		t.Errorf("expected %v, received %v", utils.ErrUnknownApiKey, err)
+	}
+	if err := clnt.Call(context.Background(), utils.AuthSv1Authenticate,
+		&utils.ArgsAuthenticate{Token: "key1"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := clnt.Call(context.Background(), "TestSv1.GetTenant", args, &reply); err != nil {
+		t.Error(err)
+	} else if reply != "reseller1.com" {
+		t.Errorf("expected the tenant of the API key, received %q", reply)
+	}
+	args.Tenant = "reseller2.com"
+	if err := clnt.Call(context.Background(), "TestSv1.GetTenant", args, &reply); err == nil ||
+		err.Error() != utils.ErrUnauthorizedTenant.Error() {
+		t.Errorf("expected %v, received %v", utils.ErrUnauthorizedTenant, err)
+	}
+	if err := clnt.Call(context.Background(), "TestSv1.SetTenant", args, &reply); err == nil ||
+		err.Error() != utils.ErrUnauthorizedApi.Error() {
+		t.Errorf("expected %v, received %v", utils.ErrUnauthorizedApi, err)
+	}
+}
+
+func TestAPIAuthBiRPCCodec(t *testing.T) {
+	srv := newTestAuthServer()
+	srv.BiRPCRegisterName("TestSv1", new(testAuthSv1))
+	srvConn, cliConn := net.Pipe()
+	go srv.birpcSrv.ServeCodec(newCapsBiRPCJSONCodec(srvConn, srv.caps, nil, srv.auth.newSession()))
+	clnt := birpc.NewBirpcClientWithCodec(jsonrpc.NewJSONBirpcCodec(cliConn))
+	defer clnt.Close()
+
+	var reply string
+	if err := clnt.Call(context.Background(), "TestSv1.GetTenant",
+		&utils.TenantIDWithAPIOpts{TenantID: new(utils.TenantID)}, &reply); err == nil ||
+		err.Error() != utils.ErrUnauthenticated.Error() {
+		t.Errorf("expected %v, received %v", utils.ErrUnauthenticated, err)
+	}
+	if err := clnt.Call(context.Background(), utils.AuthSv1Authenticate,
+		&utils.ArgsAuthenticate{Token: "key2"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := clnt.Call(context.Background(), "TestSv1.SetTenant",
+		&utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{Tenant: "reseller2.com"}}, &reply); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAPIAuthHTTP(t *testing.T) {
+	srv := newTestAuthServer()
+	httpSrv := httptest.NewServer(http.HandlerFunc(srv.handleRequest))
+	defer httpSrv.Close()
+	post := func(hdrs map[string]string) (status int, body string) {
+		req, err := http.NewRequest(http.MethodPost, httpSrv.URL,
+			strings.NewReader(`{"method":"TestSv1.GetTenant","params":[{"ID":"1001"}],"id":1}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for hdr, val := range hdrs {
+			req.Header.Set(hdr, val)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(b)
+	}
+
+	if status, body := post(nil); status != http.StatusOK ||
+		!strings.Contains(body, utils.ErrUnauthenticated.Error()) {
+		t.Errorf("unexpected reply: %d %s", status, body)
+	}
+	if status, _ := post(map[string]string{utils.APIKeyHeader: "key3"}); status != http.StatusUnauthorized {
+		t.Errorf("expected status %d, received %d", http.StatusUnauthorized, status)
+	}
+	if status, body := post(map[string]string{utils.APIKeyHeader: "key1"}); status != http.StatusOK ||
+		!strings.Contains(body, `"result":"reseller1.com"`) {
+		t.Errorf("unexpected reply: %d %s", status, body)
+	}
+	tkn := newTestJWT(t, "secret", &apiClaims{Tenant: "reseller2.com", Roles: []string{"reseller"},
+		StandardClaims: jwt.StandardClaims{Subject: "reseller2"}})
+	if status, body := post(map[string]string{utils.AuthorizationHdr: utils.BearerAuth + " " + tkn}); status != http.StatusOK ||
+		!strings.Contains(body, `"result":"reseller2.com"`) {
+		t.Errorf("unexpected reply: %d %s", status, body)
+	}
+
+	// disabling the authentication applies to the new requests
+	srv.auth.cfg.APIAuthCfg().Enabled = false
+	if status, body := post(nil); status != http.StatusOK || !strings.Contains(body, `"result":""`) {
+		t.Errorf("unexpected reply: %d %s", status, body)
+	}
+}
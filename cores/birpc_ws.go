@@ -0,0 +1,78 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"nhooyr.io/websocket"
+)
+
+// RegisterBiRPCWebSocket serves the BiRPC requests over WebSocket connections
+// received on the HTTP listeners at the given pattern, reading messages of
+// up to readLimit bytes
+func (s *Server) RegisterBiRPCWebSocket(pattern string, pingInterval time.Duration,
+	originPatterns []string, readLimit int64) {
+	s.Lock()
+	s.birpcWSPing = pingInterval
+	s.birpcWSOrigins = originPatterns
+	s.birpcWSLimit = readLimit
+	s.Unlock()
+	utils.Logger.Info(fmt.Sprintf("<HTTP> enabling handler for BiRPC WebSocket connections at %q", pattern))
+	s.RegisterHttpFunc(pattern, s.handleBiRPCWebSocket)
+}
+
+// handleBiRPCWebSocket upgrades the request and serves the BiRPC codec over it
+// until the connection is closed
+func (s *Server) handleBiRPCWebSocket(w http.ResponseWriter, r *http.Request) {
+	s.RLock()
+	srv := s.birpcSrv
+	active := s.birpcActive
+	pingInterval := s.birpcWSPing
+	originPatterns := s.birpcWSOrigins
+	readLimit := s.birpcWSLimit
+	s.RUnlock()
+	if srv == nil || !active {
+		http.Error(w, "BiRPC server not running", http.StatusServiceUnavailable)
+		return
+	}
+	sess, err := s.auth.newHTTPSession(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	ws, err := websocket.Accept(w, r, &websocket.AcceptOptions{OriginPatterns: originPatterns})
+	if err != nil { // the response was already written by Accept
+		utils.Logger.Warning(fmt.Sprintf("<BiRPC> failed accepting the WebSocket connection from <%s>: %v",
+			r.RemoteAddr, err))
+		return
+	}
+	ws.SetReadLimit(readLimit)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if pingInterval > 0 {
+		go utils.PingWebSocket(ctx, ws, pingInterval)
+	}
+	srv.ServeCodec(newCapsBiRPCJSONCodec(websocket.NetConn(ctx, ws, websocket.MessageText),
+		s.caps, s.anz, sess))
+}
@@ -0,0 +1,165 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// sessionSMock records the calls received from the BiRPC clients
+type sessionSMock struct {
+	sync.Mutex
+	conns    map[string]birpc.ClientConnector
+	syncs    int
+	register chan string
+}
+
+func (sS *sessionSMock) RegisterInternalBiJSONConn(ctx *context.Context, connID string, reply *string) error {
+	sS.Lock()
+	sS.conns[connID] = ctx.Client
+	sS.Unlock()
+	*reply = utils.OK
+	sS.register <- connID
+	return nil
+}
+
+func (sS *sessionSMock) SyncSessions(ctx *context.Context, _ *utils.TenantWithAPIOpts, reply *string) error {
+	sS.Lock()
+	sS.syncs++
+	sS.Unlock()
+	*reply = utils.OK
+	return nil
+}
+
+// DisconnectClient calls back the client over the same connection
+func (sS *sessionSMock) DisconnectClient(ctx *context.Context, reason string, reply *string) error {
+	return ctx.Client.Call(ctx, utils.AgentV1DisconnectSession, reason, reply)
+}
+
+type agentMock struct {
+	disconnects chan string
+}
+
+func (a *agentMock) DisconnectSession(_ *context.Context, reason string, reply *string) error {
+	a.disconnects <- reason
+	*reply = utils.OK
+	return nil
+}
+
+func TestBiRPCWebSocket(t *testing.T) {
+	s := NewServer(engine.NewCaps(0, utils.MetaBusy))
+	sS := &sessionSMock{
+		conns:    make(map[string]birpc.ClientConnector),
+		register: make(chan string, 1),
+	}
+	s.BiRPCRegisterName(utils.SessionSv1, sS)
+	s.birpcWSPing = 10 * time.Millisecond
+	s.birpcWSLimit = 1 << 20
+	srv := httptest.NewServer(http.HandlerFunc(s.handleBiRPCWebSocket))
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	// refused while the BiRPC server is not running
+	if _, err := utils.NewBiJSONrpcClientWS(url, nil, 0, 1<<20, nil); err == nil ||
+		!strings.Contains(err.Error(), "503") {
+		t.Errorf("expected 503 error, received: %v", err)
+	}
+
+	go s.ServeBiRPC(utils.EmptyString, utils.EmptyString, func(birpc.ClientConnector) {}, func(birpc.ClientConnector) {})
+	defer s.StopBiRPC()
+	for i := 0; ; i++ {
+		s.RLock()
+		active := s.birpcActive
+		s.RUnlock()
+		if active {
+			break
+		}
+		if i == 100 {
+			t.Fatal("BiRPC server did not start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	agent := &agentMock{disconnects: make(chan string, 1)}
+	obj, err := birpc.NewService(agent, utils.AgentV1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clnt, err := utils.NewBiJSONrpcClientWSReconnect(url, "agent1", nil,
+		10*time.Millisecond, 10*time.Millisecond, 5, 1<<20, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clnt.Close()
+	if connID := <-sS.register; connID != "agent1" {
+		t.Errorf("expected agent1, received %q", connID)
+	}
+
+	// server to client call over the WebSocket
+	var reply string
+	if err = clnt.Call(context.Background(), "SessionSv1.DisconnectClient", "FORCED", &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("unexpected reply: %q", reply)
+	}
+	if reason := <-agent.disconnects; reason != "FORCED" {
+		t.Errorf("unexpected reason: %q", reason)
+	}
+
+	// the connection is dropped by the server, the client reconnects and syncs
+	sS.Lock()
+	oldConn := sS.conns["agent1"]
+	sS.Unlock()
+	oldConn.(*birpc.BirpcClient).Close()
+	select {
+	case connID := <-sS.register:
+		if connID != "agent1" {
+			t.Errorf("expected agent1, received %q", connID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("client did not reconnect")
+	}
+	for i := 0; ; i++ { // the new connection is swapped in after the registration
+		if err = clnt.Call(context.Background(), "SessionSv1.DisconnectClient", "AFTER_RECONNECT", &reply); err == nil {
+			break
+		} else if i == 100 {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if reason := <-agent.disconnects; reason != "AFTER_RECONNECT" {
+		t.Errorf("unexpected reason: %q", reason)
+	}
+	sS.Lock()
+	syncs := sS.syncs
+	sS.Unlock()
+	if syncs != 1 {
+		t.Errorf("expected 1 sync after reconnect, received %d", syncs)
+	}
+}
@@ -37,8 +37,8 @@ type conn interface {
 	RemoteAddr() net.Addr
 }
 
-func newCapsGOBCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) (r birpc.ServerCodec) {
-	r = newCapsServerCodec(birpc.NewServerCodec(conn), caps)
+func newCapsGOBCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService, sess *apiAuthSession) (r birpc.ServerCodec) {
+	r = newAPIAuthServerCodec(newCapsServerCodec(birpc.NewServerCodec(conn), caps), sess)
 	if anz != nil {
 		from := conn.RemoteAddr()
 		var fromstr string
@@ -55,8 +55,8 @@ func newCapsGOBCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerServic
 	return
 }
 
-func newCapsJSONCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) (r birpc.ServerCodec) {
-	r = newCapsServerCodec(jsonrpc.NewServerCodec(conn), caps)
+func newCapsJSONCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService, sess *apiAuthSession) (r birpc.ServerCodec) {
+	r = newAPIAuthServerCodec(newCapsServerCodec(jsonrpc.NewServerCodec(conn), caps), sess)
 	if anz != nil {
 		from := conn.RemoteAddr()
 		var fromstr string
@@ -108,8 +108,8 @@ func (c *capsServerCodec) WriteResponse(r *birpc.Response, x any) error {
 }
 func (c *capsServerCodec) Close() error { return c.sc.Close() }
 
-func newCapsBiRPCGOBCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) (r birpc.BirpcCodec) {
-	r = newCapsBiRPCCodec(birpc.NewGobBirpcCodec(conn), caps)
+func newCapsBiRPCGOBCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService, sess *apiAuthSession) (r birpc.BirpcCodec) {
+	r = newAPIAuthBiRPCCodec(newCapsBiRPCCodec(birpc.NewGobBirpcCodec(conn), caps), sess)
 	if anz != nil {
 		from := conn.RemoteAddr()
 		var fromstr string
@@ -126,8 +126,8 @@ func newCapsBiRPCGOBCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerS
 	return
 }
 
-func newCapsBiRPCJSONCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) (r birpc.BirpcCodec) {
-	r = newCapsBiRPCCodec(jsonrpc.NewJSONBirpcCodec(conn), caps)
+func newCapsBiRPCJSONCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService, sess *apiAuthSession) (r birpc.BirpcCodec) {
+	r = newAPIAuthBiRPCCodec(newCapsBiRPCCodec(jsonrpc.NewJSONBirpcCodec(conn), caps), sess)
 	if anz != nil {
 		from := conn.RemoteAddr()
 		var fromstr string
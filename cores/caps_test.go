@@ -113,11 +113,11 @@ func TestNewCapsJSONCodec(t *testing.T) {
 	cr := engine.NewCaps(0, utils.MetaBusy)
 	anz := &analyzers.AnalyzerService{}
 	exp := jsonrpc.NewServerCodec(conn)
-	if r := newCapsJSONCodec(conn, cr, nil); !reflect.DeepEqual(r, exp) {
+	if r := newCapsJSONCodec(conn, cr, nil, nil); !reflect.DeepEqual(r, exp) {
 		t.Errorf("Expected: %v ,received:%v", exp, r)
 	}
 	exp = analyzers.NewAnalyzerServerCodec(jsonrpc.NewServerCodec(conn), anz, utils.MetaJSON, utils.Local, utils.Local)
-	if r := newCapsJSONCodec(conn, cr, anz); !reflect.DeepEqual(r, exp) {
+	if r := newCapsJSONCodec(conn, cr, anz, nil); !reflect.DeepEqual(r, exp) {
 		t.Errorf("Expected: %v ,received:%v", exp, r)
 	}
 }
@@ -208,11 +208,11 @@ func TestNewCapsGOBBiRPCCodec(t *testing.T) {
 	cr := engine.NewCaps(0, utils.MetaBusy)
 	anz := &analyzers.AnalyzerService{}
 	exp := birpc.NewGobBirpcCodec(conn)
-	if r := newCapsBiRPCGOBCodec(conn, cr, nil); !reflect.DeepEqual(r, exp) {
+	if r := newCapsBiRPCGOBCodec(conn, cr, nil, nil); !reflect.DeepEqual(r, exp) {
 		t.Errorf("Expected: %v ,received:%v", exp, r)
 	}
 	exp = analyzers.NewAnalyzerBiRPCCodec(birpc.NewGobBirpcCodec(conn), anz, rpcclient.BiRPCGOB, utils.Local, utils.Local)
-	if r := newCapsBiRPCGOBCodec(conn, cr, anz); !reflect.DeepEqual(r, exp) {
+	if r := newCapsBiRPCGOBCodec(conn, cr, anz, nil); !reflect.DeepEqual(r, exp) {
 		t.Errorf("Expected: %v ,received:%v", exp, r)
 	}
 }
@@ -222,11 +222,11 @@ func TestNewCapsJSONBiRPCCodec(t *testing.T) {
 	cr := engine.NewCaps(0, utils.MetaBusy)
 	anz := &analyzers.AnalyzerService{}
 	exp := jsonrpc.NewJSONBirpcCodec(conn)
-	if r := newCapsBiRPCJSONCodec(conn, cr, nil); !reflect.DeepEqual(r, exp) {
+	if r := newCapsBiRPCJSONCodec(conn, cr, nil, nil); !reflect.DeepEqual(r, exp) {
 		t.Errorf("Expected: %v ,received:%v", exp, r)
 	}
 	exp = analyzers.NewAnalyzerBiRPCCodec(jsonrpc.NewJSONBirpcCodec(conn), anz, rpcclient.BiRPCJSON, utils.Local, utils.Local)
-	if r := newCapsBiRPCJSONCodec(conn, cr, anz); !reflect.DeepEqual(r, exp) {
+	if r := newCapsBiRPCJSONCodec(conn, cr, anz, nil); !reflect.DeepEqual(r, exp) {
 		t.Errorf("Expected: %v ,received:%v", exp, r)
 	}
 }
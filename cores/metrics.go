@@ -19,14 +19,17 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package cores
 
 import (
+	"cmp"
 	"fmt"
 	"os"
 	"runtime"
 	"runtime/debug"
 	"runtime/metrics"
+	"slices"
 	"strconv"
 	"time"
 
+	"github.com/cgrates/cgrates/guardian"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/prometheus/procfs"
 )
@@ -57,6 +60,7 @@ type StatusMetrics struct {
 	GCDurationStats GCDurationStats `json:"gc_duration_stats"`
 	ProcStats       ProcStats       `json:"proc_stats"`
 	CapsStats       *CapsStats      `json:"caps_stats"`
+	LockStats       LockStats       `json:"lock_stats"`
 
 	MaxProcs  float64 `json:"maxprocs"`
 	GCPercent float64 `json:"gc_percent"`
@@ -83,6 +87,7 @@ func (sm StatusMetrics) toMap(debug bool, timezone string) (map[string]any, erro
 		utils.MetricGCPercent:         sm.GCPercent,
 		utils.MetricMemLimit:          sm.MemLimit,
 		utils.FieldCapsStats:          sm.CapsStats.toMap(),
+		utils.FieldLockStats:          sm.LockStats.toMap(),
 	}
 	return m, nil
 }
@@ -237,6 +242,60 @@ func (cs *CapsStats) toMap() map[string]any {
 	}
 }
 
+// LockStats contains the time spent waiting for the guardian locks and the time
+// they were held, as histograms.
+type LockStats struct {
+	Wait LockDurationStats `json:"wait"`
+	Hold LockDurationStats `json:"hold"`
+}
+
+func (ls LockStats) toMap() map[string]any {
+	return map[string]any{
+		utils.MetricLockWait: ls.Wait.toMap(),
+		utils.MetricLockHold: ls.Hold.toMap(),
+	}
+}
+
+type LockDurationStats struct {
+	Buckets []Bucket `json:"buckets"`
+	Sum     float64  `json:"sum"`
+	Count   float64  `json:"count"`
+}
+
+func (s LockDurationStats) toMap() map[string]any {
+	return map[string]any{
+		utils.MetricLockBuckets: s.Buckets,
+		utils.MetricGCSum:       s.Sum,
+		utils.MetricGCCount:     s.Count,
+	}
+}
+
+// Bucket holds the cumulative count of the values lower or equal to UpperBound.
+type Bucket struct {
+	UpperBound float64 `json:"upper_bound"`
+	Count      float64 `json:"count"`
+}
+
+// newLockDurationStats converts the guardian histogram, sorting the buckets
+// by their upper bound.
+func newLockDurationStats(h guardian.DurationHistogram) LockDurationStats {
+	s := LockDurationStats{
+		Buckets: make([]Bucket, 0, len(h.Buckets)),
+		Sum:     h.Sum,
+		Count:   float64(h.Count),
+	}
+	for upperBound, count := range h.Buckets {
+		s.Buckets = append(s.Buckets, Bucket{
+			UpperBound: upperBound,
+			Count:      float64(count),
+		})
+	}
+	slices.SortFunc(s.Buckets, func(a, b Bucket) int {
+		return cmp.Compare(a.UpperBound, b.UpperBound)
+	})
+	return s
+}
+
 // computeAppMetrics gathers runtime metrics including memory usage, goroutines,
 // GC stats, and process information for monitoring and diagnostics.
 func computeAppMetrics() (StatusMetrics, error) {
@@ -360,6 +419,8 @@ func computeAppMetrics() (StatusMetrics, error) {
 	goGCPercent := getFloat64Metric(samples, metricPathGoGCPercent)
 	goMemLimit := getFloat64Metric(samples, metricPathGoMemLimit)
 
+	lockWait, lockHold := guardian.Guardian.LockStats()
+
 	return StatusMetrics{
 		PID:             float64(pid),
 		GoVersion:       runtime.Version(),
@@ -372,6 +433,10 @@ func computeAppMetrics() (StatusMetrics, error) {
 		MaxProcs:        float64(goMaxProcs),
 		GCPercent:       float64(goGCPercent),
 		MemLimit:        float64(goMemLimit),
+		LockStats: LockStats{
+			Wait: newLockDurationStats(lockWait),
+			Hold: newLockDurationStats(lockHold),
+		},
 	}, nil
 }
 
@@ -24,6 +24,7 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/cgrates/cgrates/guardian"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/prometheus/procfs"
 )
@@ -56,6 +57,9 @@ func TestStatusMetricsToMap(t *testing.T) {
 	gcDurationStats := GCDurationStats{}
 	procStats := ProcStats{}
 	capsStats := &CapsStats{}
+	lockStats := LockStats{
+		Wait: LockDurationStats{Buckets: []Bucket{{UpperBound: 0.001, Count: 2}}, Sum: 0.0005, Count: 2},
+	}
 
 	sm := StatusMetrics{
 		PID:             1234,
@@ -68,6 +72,7 @@ func TestStatusMetricsToMap(t *testing.T) {
 		GCDurationStats: gcDurationStats,
 		ProcStats:       procStats,
 		CapsStats:       capsStats,
+		LockStats:       lockStats,
 		MaxProcs:        3,
 		GCPercent:       100,
 		MemLimit:        5555,
@@ -88,6 +93,7 @@ func TestStatusMetricsToMap(t *testing.T) {
 		utils.FieldGCDurationStats:    gcDurationStats.toMap(),
 		utils.FieldProcStats:          procStats.toMap(),
 		utils.FieldCapsStats:          capsStats.toMap(),
+		utils.FieldLockStats:          lockStats.toMap(),
 		utils.MetricRuntimeMaxProcs:   3.,
 		utils.MetricGCPercent:         100.,
 		utils.MetricMemLimit:          5555.,
@@ -136,3 +142,23 @@ func TestComputeAppMetrics(t *testing.T) {
 	}
 
 }
+
+func TestNewLockDurationStats(t *testing.T) {
+	rcv := newLockDurationStats(guardian.DurationHistogram{
+		Count:   3,
+		Sum:     0.75,
+		Buckets: map[float64]uint64{0.5: 2, 0.1: 1, 1: 3},
+	})
+	exp := LockDurationStats{
+		Buckets: []Bucket{
+			{UpperBound: 0.1, Count: 1},
+			{UpperBound: 0.5, Count: 2},
+			{UpperBound: 1, Count: 3},
+		},
+		Sum:   0.75,
+		Count: 3,
+	}
+	if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
@@ -58,17 +58,29 @@ type Server struct {
 	httpEnabled     bool
 	rpcSrv          *birpc.Server
 	birpcSrv        *birpc.BirpcServer
+	birpcActive     bool          // the BiRPC handlers are served (SessionS running)
 	stopBiRPCServer chan struct{} // used in order to fully stop the biRPC
+	birpcWSPing     time.Duration // interval between the pings on the BiRPC WebSocket connections
+	birpcWSOrigins  []string      // host patterns accepted for cross-origin BiRPC WebSocket connections
+	birpcWSLimit    int64         // maximum size of a message read from the BiRPC WebSocket connections
 	httpsMux        *http.ServeMux
 	httpMux         *http.ServeMux
 	caps            *engine.Caps
 	anz             *analyzers.AnalyzerService
+	auth            *APIAuth
 }
 
 func (s *Server) SetAnalyzer(anz *analyzers.AnalyzerService) {
 	s.anz = anz
 }
 
+// SetAPIAuth enables the authentication of the requests received on the listeners
+func (s *Server) SetAPIAuth(auth *APIAuth) {
+	s.auth = auth
+	s.rpcSrv.RegisterName(utils.AuthSv1, new(AuthSv1))
+	s.birpcSrv.RegisterName(utils.AuthSv1, new(AuthSv1))
+}
+
 func (s *Server) RpcRegister(rcvr any) {
 	utils.RegisterRpcParams(utils.EmptyString, rcvr)
 	s.rpcSrv.Register(rcvr)
@@ -122,7 +134,7 @@ func (s *Server) BiRPCUnregisterName(name string) {
 	s.birpcSrv.UnregisterName(name)
 }
 
-func (s *Server) serveCodec(addr, codecName string, newCodec func(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) birpc.ServerCodec,
+func (s *Server) serveCodec(addr, codecName string, newCodec func(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService, sess *apiAuthSession) birpc.ServerCodec,
 	shdChan *utils.SyncedChan) {
 	s.RLock()
 	enabled := s.rpcEnabled
@@ -141,7 +153,7 @@ func (s *Server) serveCodec(addr, codecName string, newCodec func(conn conn, cap
 	s.accept(l, codecName, newCodec, shdChan)
 }
 
-func (s *Server) accept(l net.Listener, codecName string, newCodec func(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) birpc.ServerCodec,
+func (s *Server) accept(l net.Listener, codecName string, newCodec func(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService, sess *apiAuthSession) birpc.ServerCodec,
 	shdChan *utils.SyncedChan) {
 	var errCnt int
 	var lastErrorTime time.Time
@@ -161,7 +173,7 @@ func (s *Server) accept(l net.Listener, codecName string, newCodec func(conn con
 			}
 			continue
 		}
-		go s.rpcSrv.ServeCodec(newCodec(conn, s.caps, s.anz))
+		go s.rpcSrv.ServeCodec(newCodec(conn, s.caps, s.anz, s.auth.newSession()))
 	}
 }
 
@@ -181,9 +193,14 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
 		w.Header().Set("Access-Control-Allow-Headers", "Accept, Accept-Language, Content-Type")
 	}
+	sess, err := s.auth.newHTTPSession(r)
+	if err != nil {
+		http.Error(w, "Not authorized", http.StatusUnauthorized)
+		return
+	}
 	rmtIP, _ := utils.GetRemoteIP(r)
 	rmtAddr, _ := net.ResolveIPAddr(utils.EmptyString, rmtIP)
-	res := newRPCRequest(s.rpcSrv, r.Body, rmtAddr, s.caps, s.anz).Call()
+	res := newRPCRequest(s.rpcSrv, r.Body, rmtAddr, s.caps, s.anz, sess).Call()
 	io.Copy(w, res)
 }
 
@@ -258,10 +275,13 @@ func (s *Server) ServeHTTP(addr, jsonRPCURL, wsRPCURL, pprofPath string, useBasi
 func (s *Server) ServeBiRPC(addrJSON, addrGOB string, onConn, onDis func(birpc.ClientConnector)) (err error) {
 	s.birpcSrv.OnConnect(onConn)
 	s.birpcSrv.OnDisconnect(onDis)
+	s.Lock()
+	s.birpcActive = true
+	s.Unlock()
 	if addrJSON != utils.EmptyString {
 		var ljson net.Listener
 		if ljson, err = listenBiRPC(s.birpcSrv, addrJSON, utils.JSONCaps, func(conn conn) birpc.BirpcCodec {
-			return newCapsBiRPCJSONCodec(conn, s.caps, s.anz)
+			return newCapsBiRPCJSONCodec(conn, s.caps, s.anz, s.auth.newSession())
 		}, s.stopBiRPCServer); err != nil {
 			return
 		}
@@ -270,7 +290,7 @@ func (s *Server) ServeBiRPC(addrJSON, addrGOB string, onConn, onDis func(birpc.C
 	if addrGOB != utils.EmptyString {
 		var lgob net.Listener
 		if lgob, err = listenBiRPC(s.birpcSrv, addrGOB, utils.GOBCaps, func(conn conn) birpc.BirpcCodec {
-			return newCapsBiRPCGOBCodec(conn, s.caps, s.anz)
+			return newCapsBiRPCGOBCodec(conn, s.caps, s.anz, s.auth.newSession())
 		}, s.stopBiRPCServer); err != nil {
 			return
 		}
@@ -310,6 +330,7 @@ func (s *Server) StopBiRPC() {
 	s.stopBiRPCServer <- struct{}{}
 	s.Lock()
 	s.birpcSrv = nil
+	s.birpcActive = false
 	s.Unlock()
 }
 
@@ -321,17 +342,20 @@ type rpcRequest struct {
 	remoteAddr net.Addr
 	caps       *engine.Caps
 	anzWarpper *analyzers.AnalyzerService
+	authSess   *apiAuthSession
 	srv        *birpc.Server
 }
 
 // newRPCRequest returns a new rpcRequest.
-func newRPCRequest(srv *birpc.Server, r io.ReadCloser, remoteAddr net.Addr, caps *engine.Caps, anz *analyzers.AnalyzerService) *rpcRequest {
+func newRPCRequest(srv *birpc.Server, r io.ReadCloser, remoteAddr net.Addr, caps *engine.Caps,
+	anz *analyzers.AnalyzerService, authSess *apiAuthSession) *rpcRequest {
 	return &rpcRequest{
 		r:          r,
 		rw:         new(bytes.Buffer),
 		remoteAddr: remoteAddr,
 		caps:       caps,
 		anzWarpper: anz,
+		authSess:   authSess,
 		srv:        srv,
 	}
 }
@@ -357,7 +381,7 @@ func (r *rpcRequest) Close() error {
 
 // Call invokes the RPC request, waits for it to complete, and returns the results.
 func (r *rpcRequest) Call() io.Reader {
-	r.srv.ServeCodec(newCapsJSONCodec(r, r.caps, r.anzWarpper))
+	r.srv.ServeCodec(newCapsJSONCodec(r, r.caps, r.anzWarpper, r.authSess))
 	return r.rw
 }
 
@@ -401,7 +425,7 @@ func loadTLSConfig(serverCrt, serverKey, caCert string, serverPolicy int,
 }
 
 func (s *Server) serveCodecTLS(addr, codecName, serverCrt, serverKey, caCert string,
-	serverPolicy int, serverName string, newCodec func(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) birpc.ServerCodec,
+	serverPolicy int, serverName string, newCodec func(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService, sess *apiAuthSession) birpc.ServerCodec,
 	shdChan *utils.SyncedChan) {
 	s.RLock()
 	enabled := s.rpcEnabled
@@ -435,7 +459,12 @@ func (s *Server) ServeJSONTLS(addr, serverCrt, serverKey, caCert string,
 }
 
 func (s *Server) handleWebSocket(ws *websocket.Conn) {
-	s.rpcSrv.ServeCodec(newCapsJSONCodec(ws, s.caps, s.anz))
+	sess, err := s.auth.newHTTPSession(ws.Request())
+	if err != nil {
+		ws.Close()
+		return
+	}
+	s.rpcSrv.ServeCodec(newCapsJSONCodec(ws, s.caps, s.anz, sess))
 }
 
 func (s *Server) ServeHTTPTLS(addr, serverCrt, serverKey, caCert string, serverPolicy int,
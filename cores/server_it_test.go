@@ -708,7 +708,7 @@ func testAcceptBiRPC(t *testing.T) {
 		p1: p1,
 	}
 	go acceptBiRPC(server.birpcSrv, l, utils.JSONCaps, func(conn conn) birpc.BirpcCodec {
-		return newCapsBiRPCJSONCodec(conn, server.caps, server.anz)
+		return newCapsBiRPCJSONCodec(conn, server.caps, server.anz, nil)
 	}, server.stopBiRPCServer)
 	rpc := jsonrpc.NewClient(p2)
 	var reply string
@@ -738,7 +738,7 @@ func testAcceptBiRPCError(t *testing.T) {
 	//it will contain "use of closed network connection"
 	l := new(mockListenError)
 	go acceptBiRPC(server.birpcSrv, l, utils.JSONCaps, func(conn conn) birpc.BirpcCodec {
-		return newCapsBiRPCJSONCodec(conn, server.caps, server.anz)
+		return newCapsBiRPCJSONCodec(conn, server.caps, server.anz, nil)
 	}, server.stopBiRPCServer)
 	runtime.Gosched()
 }
@@ -755,7 +755,7 @@ func testRpcRegisterActions(t *testing.T) {
 	rmtIP, _ := utils.GetRemoteIP(r)
 	rmtAddr, _ := net.ResolveIPAddr(utils.EmptyString, rmtIP)
 
-	rpcReq := newRPCRequest(server.rpcSrv, r.Body, rmtAddr, server.caps, nil)
+	rpcReq := newRPCRequest(server.rpcSrv, r.Body, rmtAddr, server.caps, nil, nil)
 	rpcReq.remoteAddr = utils.NewNetAddr("network", "127.0.0.1:2012")
 
 	if n, err := rpcReq.Write([]byte(`TEST`)); err != nil {
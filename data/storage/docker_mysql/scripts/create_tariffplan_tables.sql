@@ -71,6 +71,7 @@ CREATE TABLE `tp_destination_rates` (
   `rounding_decimals` tinyint(4) NOT NULL,
   `max_cost` decimal(7,4) NOT NULL,
   `max_cost_strategy` varchar(16) NOT NULL,
+  `usage_counter` varchar(64) DEFAULT '',
   `created_at` TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `tpid` (`tpid`),
@@ -71,6 +71,7 @@ CREATE TABLE `tp_destination_rates` (
   `rounding_decimals` tinyint(4) NOT NULL,
   `max_cost` decimal(7,4) NOT NULL,
   `max_cost_strategy` varchar(16) NOT NULL,
+  `usage_counter` varchar(64) DEFAULT '',
   `created_at` TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `tpid` (`tpid`),
@@ -342,6 +343,31 @@ CREATE TABLE tp_rankings(
   UNIQUE KEY `unique_tp_rankings` (`tpid`,`tenant`,`id`,`stat_ids`)
   );
 
+--
+-- Table structure for table `tp_tax_profiles`
+--
+
+DROP TABLE IF EXISTS tp_tax_profiles;
+CREATE TABLE tp_tax_profiles(
+ `pk` int(11) NOT NULL AUTO_INCREMENT,
+ `tpid` varchar(64) NOT NULL,
+ `tenant` varchar(64) NOT NULL,
+ `id` varchar(64) NOT NULL,
+ `filter_ids` varchar(64) NOT NULL,
+ `jurisdiction` varchar(64) NOT NULL,
+ `weight` decimal(8,2) NOT NULL,
+ `rate_id` varchar(64) NOT NULL,
+ `rate_type` varchar(32) NOT NULL,
+ `rate` decimal(8,4) NOT NULL,
+ `rate_amount` decimal(16,4) NOT NULL,
+ `rate_compound` BOOLEAN NOT NULL,
+ `rate_activation_interval` varchar(64) NOT NULL,
+ `created_at` TIMESTAMP,
+  PRIMARY KEY (`pk`),
+  KEY `tpid`  (`tpid`),
+  UNIQUE KEY `unique_tp_tax_profiles` (`tpid`,`tenant`,`id`,`rate_id`)
+  );
+
 --
 -- Table structure for tabls `tp_trends`
 --
@@ -68,6 +68,7 @@ CREATE TABLE tp_destination_rates (
   rounding_decimals SMALLINT NOT NULL,
   max_cost NUMERIC(7,4) NOT NULL,
   max_cost_strategy VARCHAR(16) NOT NULL,
+  usage_counter VARCHAR(64) DEFAULT '',
   created_at TIMESTAMP WITH TIME ZONE,
   UNIQUE (tpid, tag , destinations_tag)
 );
@@ -336,6 +337,30 @@ CREATE TABLE tp_rankings(
 CREATE INDEX tp_rankings_idx ON tp_rankings (tpid);
 CREATE INDEX tp_rankings_unique ON tp_rankings  ("tpid","tenant", "id","stat_ids");
 
+--
+-- Table structure for table `tp_tax_profiles`
+--
+
+DROP TABLE IF EXISTS tp_tax_profiles;
+CREATE TABLE tp_tax_profiles(
+  "pk"  SERIAL PRIMARY KEY,
+  "tpid" varchar(64) NOT NULL,
+  "tenant" varchar(64) NOT NULL,
+  "id" varchar(64) NOT NULL,
+  "filter_ids" varchar(64) NOT NULL,
+  "jurisdiction" varchar(64) NOT NULL,
+  "weight" decimal(8,2) NOT NULL,
+  "rate_id" varchar(64) NOT NULL,
+  "rate_type" varchar(32) NOT NULL,
+  "rate" decimal(8,4) NOT NULL,
+  "rate_amount" decimal(16,4) NOT NULL,
+  "rate_compound" BOOLEAN NOT NULL,
+  "rate_activation_interval" varchar(64) NOT NULL,
+  "created_at" TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX tp_tax_profiles_idx ON tp_tax_profiles (tpid);
+CREATE INDEX tp_tax_profiles_unique ON tp_tax_profiles  ("tpid","tenant", "id","rate_id");
+
 --
 -- Table structure for tabls `tp_trends`
 --
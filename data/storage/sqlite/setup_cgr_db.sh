@@ -0,0 +1,21 @@
+#!/bin/bash
+
+
+db=$1
+if [ -z "$1" ]; then
+	db="/var/lib/cgrates/sqlite/cgrates.db"
+fi
+
+DIR="$(dirname "$(readlink -f "$0")")"
+
+mkdir -p "$(dirname "$db")"
+
+sqlite3 "$db" < "$DIR"/create_cdrs_tables.sql
+cdrt=$?
+sqlite3 "$db" < "$DIR"/create_tariffplan_tables.sql
+tpt=$?
+
+if [ $cdrt = 0 ] && [ $tpt = 0 ]; then
+	echo "\n\t+++ CGR-DB successfully set-up! +++\n"
+	exit 0
+fi
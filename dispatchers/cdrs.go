@@ -116,6 +116,23 @@ func (dS *DispatcherService) CDRsV1RateCDRs(ctx *context.Context, args *engine.A
 	}, utils.MetaCDRs, utils.CDRsV1RateCDRs, args, reply)
 }
 
+func (dS *DispatcherService) CDRsV1SimulateRateCDRs(ctx *context.Context, args *engine.ArgSimulateRateCDRs, reply *engine.RateCDRsSimulation) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.CDRsV1SimulateRateCDRs, tnt,
+			utils.IfaceAsString(args.APIOpts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant:  tnt,
+		APIOpts: args.APIOpts,
+	}, utils.MetaCDRs, utils.CDRsV1SimulateRateCDRs, args, reply)
+}
+
 func (dS *DispatcherService) CDRsV1ProcessExternalCDR(ctx *context.Context, args *engine.ExternalCDRWithAPIOpts, reply *string) (err error) {
 	tnt := dS.cfg.GeneralCfg().DefaultTenant
 	if args.Tenant != utils.EmptyString {
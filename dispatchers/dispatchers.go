@@ -247,8 +247,12 @@ func (dS *DispatcherService) Dispatch(ev *utils.CGREvent, subsys string,
 	if routeID != utils.EmptyString { // overwrite routeID with RouteID:Subsystem for subsystem correct routing
 		routeID = utils.ConcatenatedKey(routeID, subsys)
 		guardID := utils.ConcatenatedKey(utils.DispatcherSv1, utils.OptsRouteID, routeID)
-		refID := guardian.Guardian.GuardIDs("", dS.cfg.GeneralCfg().LockingTimeout,
-			guardID) // lock the routeID so we can make sure we have time to execute only once before caching
+		// lock the routeID so we can make sure we have time to execute only once before caching
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("", dS.cfg.GeneralCfg().LockingTimeout,
+			guardID); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 		// use previously discovered route
 		argsCache := &utils.ArgsGetCacheItemWithAPIOpts{
@@ -336,6 +340,30 @@ func (dS *DispatcherService) DispatcherSv1GetProfilesForEvent(ctx *context.Conte
 	return
 }
 
+// DispatcherSv1GetHostsStats returns the host metrics collected by the
+// *least_conns and *least_latency strategies of a dispatcher profile
+func (dS *DispatcherService) DispatcherSv1GetHostsStats(ctx *context.Context, args *utils.TenantIDWithAPIOpts,
+	reply *map[string]*HostStats) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = dS.cfg.GeneralCfg().DefaultTenant
+	}
+	var d Dispatcher
+	if d, err = getDispatcherWithCache(&engine.DispatcherProfile{Tenant: tnt, ID: args.ID}, dS.dm); err != nil {
+		return utils.NewErrDispatcherS(err)
+	}
+	hd, canCast := d.(*healthDispatcher)
+	if !canCast {
+		return utils.NewErrDispatcherS(
+			fmt.Errorf("dispatcher profile <%s> does not collect host stats", utils.ConcatenatedKey(tnt, args.ID)))
+	}
+	*reply = hd.hostsStats()
+	return
+}
+
 /*
 // V1Apier is a generic way to cover all APIer methods
 func (dS *DispatcherService) V1Apier(ctx *context.Context,apier any, args *utils.MethodParameters, reply *any) (err error) {
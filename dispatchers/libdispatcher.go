@@ -95,6 +95,8 @@ func newDispatcher(pfl *engine.DispatcherProfile) (d Dispatcher, err error) {
 		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), new(randomSort))
 	case utils.MetaRoundRobin:
 		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), new(roundRobinSort))
+	case utils.MetaLeastConns, utils.MetaLeastLatency:
+		return newHealthDispatcher(hosts, pfl.StrategyParams, pfl.Strategy)
 	case rpcclient.PoolBroadcast,
 		rpcclient.PoolBroadcastSync,
 		rpcclient.PoolBroadcastAsync:
@@ -426,6 +428,215 @@ func (lM *LoadMetrics) decrementLoad(hostID, tntID string) {
 	lM.mutex.Unlock()
 }
 
+// default values for the healthDispatcher parameters
+const (
+	defaultMaxFailures   = 3
+	defaultEjectCooldown = 30 * time.Second
+	defaultLatencyAlpha  = 0.3
+)
+
+// HostStats holds the runtime metrics collected for a host by the
+// *least_conns and *least_latency strategies
+type HostStats struct {
+	ActiveRequests int64         // requests currently in flight
+	AvgLatency     time.Duration // exponential moving average of the response times
+	Failures       int           // consecutive failed calls
+	EjectedUntil   time.Time     // zero if the host is not ejected
+}
+
+// newHealthDispatcher is the constructor for healthDispatcher struct
+func newHealthDispatcher(hosts engine.DispatcherHostProfiles, params map[string]any,
+	strategy string) (_ Dispatcher, err error) {
+	hd := &healthDispatcher{
+		strategy:    strategy,
+		hosts:       hosts,
+		maxFailures: defaultMaxFailures,
+		cooldown:    defaultEjectCooldown,
+		alpha:       defaultLatencyAlpha,
+		stats:       make(map[string]*HostStats),
+		probing:     make(utils.StringSet),
+	}
+	if maxFails, has := params[utils.MetaMaxFailures]; has {
+		var maxFailures int64
+		if maxFailures, err = utils.IfaceAsTInt64(maxFails); err != nil {
+			return
+		}
+		hd.maxFailures = int(maxFailures)
+	}
+	if cooldown, has := params[utils.MetaEjectCooldown]; has {
+		if hd.cooldown, err = utils.IfaceAsDuration(cooldown); err != nil {
+			return
+		}
+	}
+	if alpha, has := params[utils.MetaLatencyAlpha]; has {
+		if hd.alpha, err = utils.IfaceAsFloat64(alpha); err != nil {
+			return
+		}
+		if hd.alpha <= 0 || hd.alpha > 1 {
+			return nil, fmt.Errorf("%s must be in the (0, 1] interval, received: %v",
+				utils.MetaLatencyAlpha, hd.alpha)
+		}
+	}
+	return hd, nil
+}
+
+// healthDispatcher routes the event to the host with the least requests in flight
+// (*least_conns) or with the lowest average response time (*least_latency)
+// hosts failing for maxFailures consecutive times are ejected for the cooldown
+// period, after which a single probe request decides if they come back
+// implements Dispatcher interface
+type healthDispatcher struct {
+	strategy    string
+	hosts       engine.DispatcherHostProfiles
+	maxFailures int
+	cooldown    time.Duration
+	alpha       float64
+
+	mux     sync.Mutex
+	stats   map[string]*HostStats
+	probing utils.StringSet // ejected hosts with a probe request in flight
+}
+
+func (hd *healthDispatcher) Dispatch(dm *engine.DataManager, flts *engine.FilterS,
+	ev utils.DataProvider, tnt, routeID string, dR *DispatcherRoute,
+	serviceMethod string, args any, reply any) (err error) {
+	if dR != nil && dR.HostID != utils.EmptyString && // route to previously discovered route
+		hd.acquire(dR.HostID) {
+		if err = hd.callHost(tnt, dR.HostID, routeID, dR, dm,
+			serviceMethod, args, reply); err != utils.ErrDSPHostNotFound &&
+			!rpcclient.ShouldFailover(err) { // successful dispatch with normal errors
+			return
+		}
+		// not found or network errors will continue with standard dispatching
+		utils.Logger.Warning(fmt.Sprintf("<%s> error <%s> dispatching to host with id <%q>",
+			utils.DispatcherS, err.Error(), dR.HostID))
+	}
+	var hostIDs engine.DispatcherHostIDs
+	if hostIDs, err = getDispatcherHosts(flts, ev, tnt, hd.hosts); err != nil {
+		return
+	}
+	err = utils.ErrDSPHostNotFound // in case we do not match any available host
+	for _, hostID := range hd.sortHostIDs(hostIDs) {
+		if !hd.acquire(hostID) { // ejected host
+			continue
+		}
+		var dRh *DispatcherRoute
+		if routeID != utils.EmptyString {
+			dRh = &DispatcherRoute{
+				Tenant:    dR.Tenant,
+				ProfileID: dR.ProfileID,
+				HostID:    hostID,
+			}
+		}
+		if err = hd.callHost(tnt, hostID, routeID, dRh, dm,
+			serviceMethod, args, reply); err != utils.ErrDSPHostNotFound &&
+			!rpcclient.ShouldFailover(err) { // successful dispatch with normal errors
+			return
+		}
+		// not found or network errors will continue with standard dispatching
+		utils.Logger.Warning(fmt.Sprintf("<%s> error <%s> dispatching to host with id <%q>",
+			utils.DispatcherS, err.Error(), hostID))
+	}
+	return
+}
+
+// callHost dispatches the request to the host acquired before, recording the call results
+func (hd *healthDispatcher) callHost(tnt, hostID, routeID string, dR *DispatcherRoute,
+	dm *engine.DataManager, serviceMethod string, args, reply any) (err error) {
+	start := time.Now()
+	err = callDHwithID(tnt, hostID, routeID, dR, dm, serviceMethod, args, reply)
+	hd.release(hostID, time.Since(start), err)
+	return
+}
+
+// hostStats returns the stats of the host, creating them if missing
+// should be called under lock
+func (hd *healthDispatcher) hostStats(hostID string) (hs *HostStats) {
+	var has bool
+	if hs, has = hd.stats[hostID]; !has {
+		hs = new(HostStats)
+		hd.stats[hostID] = hs
+	}
+	return
+}
+
+// acquire marks a new request in flight for the host
+// returns false if the host is ejected and cannot be probed yet
+func (hd *healthDispatcher) acquire(hostID string) bool {
+	hd.mux.Lock()
+	defer hd.mux.Unlock()
+	hs := hd.hostStats(hostID)
+	if !hs.EjectedUntil.IsZero() {
+		if time.Now().Before(hs.EjectedUntil) ||
+			hd.probing.Has(hostID) { // only one probe at a time
+			return false
+		}
+		hd.probing.Add(hostID)
+	}
+	hs.ActiveRequests++
+	return true
+}
+
+// release marks the end of a request, updating the latency and the ejection status
+func (hd *healthDispatcher) release(hostID string, latency time.Duration, err error) {
+	hd.mux.Lock()
+	defer hd.mux.Unlock()
+	hs := hd.hostStats(hostID)
+	hs.ActiveRequests--
+	isProbe := hd.probing.Has(hostID)
+	hd.probing.Remove(hostID)
+	if err == utils.ErrDSPHostNotFound {
+		return // host not configured, nothing to measure
+	}
+	if rpcclient.ShouldFailover(err) {
+		hs.Failures++
+		if isProbe || hs.Failures >= hd.maxFailures {
+			hs.EjectedUntil = time.Now().Add(hd.cooldown)
+		}
+		return
+	}
+	hs.Failures = 0
+	hs.EjectedUntil = time.Time{}
+	if hs.AvgLatency == 0 {
+		hs.AvgLatency = latency
+		return
+	}
+	hs.AvgLatency = time.Duration(hd.alpha*float64(latency) +
+		(1-hd.alpha)*float64(hs.AvgLatency))
+}
+
+// sortHostIDs sorts the hosts based on strategy
+// hosts without measured latency come first so they can be evaluated
+func (hd *healthDispatcher) sortHostIDs(hostIDs engine.DispatcherHostIDs) engine.DispatcherHostIDs {
+	hd.mux.Lock()
+	defer hd.mux.Unlock()
+	metric := make(map[string]int64, len(hostIDs))
+	for _, hostID := range hostIDs {
+		hs := hd.hostStats(hostID)
+		if hd.strategy == utils.MetaLeastConns {
+			metric[hostID] = hs.ActiveRequests
+		} else {
+			metric[hostID] = int64(hs.AvgLatency)
+		}
+	}
+	sort.SliceStable(hostIDs, func(i, j int) bool { // stable so weight order is kept on equal metrics
+		return metric[hostIDs[i]] < metric[hostIDs[j]]
+	})
+	return hostIDs
+}
+
+// hostsStats returns a copy of the stats collected for each host
+func (hd *healthDispatcher) hostsStats() map[string]*HostStats {
+	hd.mux.Lock()
+	defer hd.mux.Unlock()
+	stats := make(map[string]*HostStats, len(hd.hosts))
+	for _, host := range hd.hosts {
+		hs := *hd.hostStats(host.ID)
+		stats[host.ID] = &hs
+	}
+	return stats
+}
+
 // lazyDH is created for the broadcast strategy so we can make sure host exists during setup phase
 type lazyDH struct {
 	dh      *engine.DispatcherHost
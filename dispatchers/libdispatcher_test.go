@@ -1046,3 +1046,135 @@ func TestLibDispatcherNewInternalHost(t *testing.T) {
 		t.Errorf("newInternalHost(%q) returned an unexpected value(-want +got): \n%s", tnt, diff)
 	}
 }
+
+func TestLibDispatcherNewDispatcherHealthStrategies(t *testing.T) {
+	for _, strategy := range []string{utils.MetaLeastConns, utils.MetaLeastLatency} {
+		pfl := &engine.DispatcherProfile{
+			Hosts:    engine.DispatcherHostProfiles{{ID: "HOST1"}},
+			Strategy: strategy,
+			StrategyParams: map[string]any{
+				utils.MetaMaxFailures:   5,
+				utils.MetaEjectCooldown: "1m",
+				utils.MetaLatencyAlpha:  0.5,
+			},
+		}
+		d, err := newDispatcher(pfl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hd, canCast := d.(*healthDispatcher)
+		if !canCast {
+			t.Fatalf("expected *healthDispatcher, received %T", d)
+		}
+		if hd.strategy != strategy || hd.maxFailures != 5 ||
+			hd.cooldown != time.Minute || hd.alpha != 0.5 {
+			t.Errorf("unexpected dispatcher: %+v", hd)
+		}
+	}
+	for _, params := range []map[string]any{
+		{utils.MetaMaxFailures: "notANumber"},
+		{utils.MetaEjectCooldown: "notADuration"},
+		{utils.MetaLatencyAlpha: 2},
+	} {
+		if _, err := newDispatcher(&engine.DispatcherProfile{
+			Strategy:       utils.MetaLeastLatency,
+			StrategyParams: params,
+		}); err == nil {
+			t.Errorf("expected error for params %v", params)
+		}
+	}
+}
+
+func TestLibDispatcherHealthDispatcherSortHostIDs(t *testing.T) {
+	d, err := newHealthDispatcher(engine.DispatcherHostProfiles{
+		{ID: "HOST1"}, {ID: "HOST2"}, {ID: "HOST3"},
+	}, nil, utils.MetaLeastConns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hd := d.(*healthDispatcher)
+	hd.acquire("HOST1")
+	hd.acquire("HOST1")
+	hd.acquire("HOST2")
+	exp := engine.DispatcherHostIDs{"HOST3", "HOST2", "HOST1"}
+	if rcv := hd.sortHostIDs(engine.DispatcherHostIDs{"HOST1", "HOST2", "HOST3"}); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %v, received %v", exp, rcv)
+	}
+
+	hd.strategy = utils.MetaLeastLatency
+	hd.release("HOST1", 30*time.Millisecond, nil)
+	hd.release("HOST2", 10*time.Millisecond, nil)
+	hd.release("HOST1", 40*time.Millisecond, nil) // moving average of 0.3*40+0.7*30
+	exp = engine.DispatcherHostIDs{"HOST3", "HOST2", "HOST1"}
+	if rcv := hd.sortHostIDs(engine.DispatcherHostIDs{"HOST1", "HOST2", "HOST3"}); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %v, received %v", exp, rcv)
+	}
+	expStats := map[string]*HostStats{
+		"HOST1": {AvgLatency: 33 * time.Millisecond},
+		"HOST2": {AvgLatency: 10 * time.Millisecond},
+		"HOST3": {},
+	}
+	if rcv := hd.hostsStats(); !reflect.DeepEqual(expStats, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expStats), utils.ToJSON(rcv))
+	}
+}
+
+func TestLibDispatcherHealthDispatcherEjection(t *testing.T) {
+	d, err := newHealthDispatcher(engine.DispatcherHostProfiles{{ID: "HOST1"}},
+		map[string]any{
+			utils.MetaMaxFailures:   2,
+			utils.MetaEjectCooldown: 20 * time.Millisecond,
+		}, utils.MetaLeastLatency)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hd := d.(*healthDispatcher)
+	for range 2 {
+		if !hd.acquire("HOST1") {
+			t.Fatal("expected host to be available")
+		}
+		hd.release("HOST1", time.Millisecond, rpcclient.ErrDisconnected)
+	}
+	if hd.acquire("HOST1") {
+		t.Fatal("expected host to be ejected")
+	}
+	time.Sleep(25 * time.Millisecond)
+	if !hd.acquire("HOST1") {
+		t.Fatal("expected host to be probed after cooldown")
+	}
+	if hd.acquire("HOST1") {
+		t.Fatal("expected a single probe in flight")
+	}
+	hd.release("HOST1", time.Millisecond, rpcclient.ErrDisconnected) // failed probe ejects the host again
+	if hd.acquire("HOST1") {
+		t.Fatal("expected host to be ejected after failed probe")
+	}
+	time.Sleep(25 * time.Millisecond)
+	if !hd.acquire("HOST1") {
+		t.Fatal("expected host to be probed after cooldown")
+	}
+	hd.release("HOST1", time.Millisecond, utils.ErrNotFound) // application errors count as success
+	if stats := hd.hostsStats()["HOST1"]; stats.Failures != 0 || !stats.EjectedUntil.IsZero() ||
+		stats.ActiveRequests != 0 {
+		t.Errorf("expected host to be restored, received %s", utils.ToJSON(stats))
+	}
+}
+
+func TestLibDispatcherHealthDispatcherNoHosts(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dataDB, err := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm := engine.NewDataManager(dataDB, cfg.CacheCfg(), nil)
+	d, err := newHealthDispatcher(engine.DispatcherHostProfiles{
+		{ID: "HOST1", FilterIDs: []string{"*string:~*req.Account:1001"}},
+	}, nil, utils.MetaLeastConns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Dispatch(dm, engine.NewFilterS(cfg, nil, dm), utils.MapStorage{utils.MetaReq: utils.MapStorage{}},
+		"cgrates.org", utils.EmptyString, nil, utils.AttributeSv1Ping, nil, nil); err != utils.ErrDSPHostNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrDSPHostNotFound, err)
+	}
+}
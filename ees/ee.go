@@ -57,9 +57,16 @@ func NewEventExporter(cfg *config.EventExporterCfg, cgrCfg *config.CGRConfig, fi
 		return NewHTTPPostEE(cfg, cgrCfg, filterS, em)
 	case utils.MetaHTTPjsonMap:
 		return NewHTTPjsonMapEE(cfg, cgrCfg, filterS, em)
+	case utils.MetaWebhook:
+		return NewWebhookEE(cfg, cgrCfg, filterS, em)
 	case utils.MetaNatsjsonMap:
 		return NewNatsEE(cfg, cgrCfg.GeneralCfg().NodeID,
 			cgrCfg.GeneralCfg().ConnectTimeout, em)
+	case utils.MetaMQTTjsonMap:
+		return NewMQTTEE(cfg, cgrCfg.GeneralCfg().NodeID,
+			cgrCfg.GeneralCfg().ConnectTimeout, em)
+	case utils.MetaRedisStreamjsonMap:
+		return NewRedisStreamEE(cfg, cgrCfg.GeneralCfg().ConnectTimeout, em)
 	case utils.MetaAMQPjsonMap:
 		return NewAMQPee(cfg, em), nil
 	case utils.MetaAMQPV1jsonMap:
@@ -123,6 +130,21 @@ func composeHeaderTrailer(prfx string, fields []*config.FCTemplate, em utils.Dat
 	return
 }
 
+// batchedRequest is implemented by the export requests which can be buffered
+// by the exporter and written later together with other requests
+type batchedRequest interface {
+	// onWrite registers the function called with the result of the write,
+	// returning false if the request is written right away
+	onWrite(func(error)) bool
+}
+
+// retryingExporter is implemented by the exporters retrying the failed exports
+// on their own, which are attempted only once by ExportWithAttempts
+type retryingExporter interface {
+	// handlesRetries returns true if the exporter retries the failed exports itself
+	handlesRetries() bool
+}
+
 func updateEEMetrics(em *utils.ExporterMetrics, cgrID string, ev engine.MapEvent, hasError bool, timezone string) {
 	em.Lock()
 	defer em.Unlock()
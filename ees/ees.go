@@ -298,9 +298,12 @@ func (eeS *EventExporterS) V1ProcessEvent(ctx *context.Context, cgrEv *engine.CG
 }
 
 func exportEventWithExporter(exp EventExporter, ev *utils.CGREvent, oneTime bool, cfg *config.CGRConfig, filterS *engine.FilterS) (err error) {
+	timezone := utils.FirstNonEmpty(exp.Cfg().Timezone, cfg.GeneralCfg().DefaultTimezone)
+	var batched bool // the metrics are updated once the batch is written
 	defer func() {
-		updateEEMetrics(exp.GetMetrics(), ev.ID, ev.Event, err != nil, utils.FirstNonEmpty(exp.Cfg().Timezone,
-			cfg.GeneralCfg().DefaultTimezone))
+		if !batched || err != nil {
+			updateEEMetrics(exp.GetMetrics(), ev.ID, ev.Event, err != nil, timezone)
+		}
 		if oneTime {
 			exp.Close()
 		}
@@ -343,6 +346,11 @@ func exportEventWithExporter(exp EventExporter, ev *utils.CGREvent, oneTime bool
 	key := utils.ConcatenatedKey(utils.FirstNonEmpty(engine.MapEvent(ev.Event).GetStringIgnoreErrors(utils.CGRID), utils.GenUUID()),
 		utils.FirstNonEmpty(engine.MapEvent(ev.Event).GetStringIgnoreErrors(utils.RunID), utils.MetaDefault))
 
+	if bReq, canCast := eEv.(batchedRequest); canCast {
+		batched = bReq.onWrite(func(wErr error) {
+			updateEEMetrics(exp.GetMetrics(), ev.ID, ev.Event, wErr != nil, timezone)
+		})
+	}
 	return ExportWithAttempts(exp, eEv, key)
 }
 
@@ -394,12 +402,16 @@ func ExportWithAttempts(exp EventExporter, eEv any, key string) (err error) {
 				utils.EEs, exp.Cfg().ID, evLog))
 	}
 
-	for i := 0; i < exp.Cfg().Attempts; i++ {
+	attempts := exp.Cfg().Attempts
+	if rExp, canCast := exp.(retryingExporter); canCast && rExp.handlesRetries() {
+		attempts = 1 // retried by the exporter using its own backoff
+	}
+	for i := 0; i < attempts; i++ {
 		if err = exp.ExportEvent(eEv, key); err == nil ||
 			err == utils.ErrDisconnected { // special error in case the exporter was closed
 			break
 		}
-		if i+1 < exp.Cfg().Attempts {
+		if i+1 < attempts {
 			time.Sleep(fib())
 		}
 	}
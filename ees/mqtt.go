@@ -0,0 +1,149 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"crypto/tls"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// NewMQTTEE creates a MQTT poster
+func NewMQTTEE(cfg *config.EventExporterCfg, nodeID string, connTimeout time.Duration, em *utils.ExporterMetrics) (mqttPstr *MQTTEE, err error) {
+	mqttPstr = &MQTTEE{
+		cfg:   cfg,
+		em:    em,
+		topic: utils.DefaultQueueID,
+		qos:   utils.MQTTDefaultQoS,
+		clientOpts: mqtt.NewClientOptions().
+			SetClientID(utils.CGRateSLwr + utils.Underline + nodeID + utils.Underline + cfg.ID).
+			SetConnectTimeout(connTimeout).
+			SetAutoReconnect(false), // Connect dials again once the connection is lost
+		reqs: newConcReq(cfg.ConcurrentRequests),
+	}
+	err = mqttPstr.parseOpts(cfg.Opts.MQTT)
+	return
+}
+
+// MQTTEE is a MQTT poster
+type MQTTEE struct {
+	topic      string // topic where we publish
+	qos        byte
+	retain     bool
+	clientOpts *mqtt.ClientOptions
+
+	poster mqtt.Client
+
+	cfg          *config.EventExporterCfg
+	em           *utils.ExporterMetrics
+	reqs         *concReq
+	sync.RWMutex // protect poster
+	bytePreparing
+}
+
+func (pstr *MQTTEE) parseOpts(opts *config.MQTTOpts) (err error) {
+	if opts == nil {
+		pstr.clientOpts.AddBroker(utils.MQTTBrokerURL(pstr.cfg.ExportPath, false))
+		return
+	}
+	if opts.Topic != nil {
+		pstr.topic = *opts.Topic
+	}
+	if opts.QoS != nil {
+		pstr.qos = byte(*opts.QoS)
+	}
+	if opts.Retain != nil {
+		pstr.retain = *opts.Retain
+	}
+	if opts.ClientID != nil {
+		pstr.clientOpts.SetClientID(*opts.ClientID)
+	}
+	if opts.Username != nil {
+		pstr.clientOpts.SetUsername(*opts.Username)
+	}
+	if opts.Password != nil {
+		pstr.clientOpts.SetPassword(*opts.Password)
+	}
+	useTLS := opts.TLS != nil && *opts.TLS
+	pstr.clientOpts.AddBroker(utils.MQTTBrokerURL(pstr.cfg.ExportPath, useTLS))
+	if useTLS {
+		var caPath, certPath, keyPath string
+		if opts.CAPath != nil {
+			caPath = *opts.CAPath
+		}
+		if opts.ClientCertificate != nil {
+			certPath = *opts.ClientCertificate
+		}
+		if opts.ClientKey != nil {
+			keyPath = *opts.ClientKey
+		}
+		var tlsCfg *tls.Config
+		if tlsCfg, err = utils.NewMQTTTLSConfig(caPath, certPath, keyPath,
+			opts.SkipTLSVerify != nil && *opts.SkipTLSVerify); err != nil {
+			return
+		}
+		pstr.clientOpts.SetTLSConfig(tlsCfg)
+	}
+	return
+}
+
+func (pstr *MQTTEE) Cfg() *config.EventExporterCfg { return pstr.cfg }
+
+func (pstr *MQTTEE) Connect() (err error) {
+	pstr.Lock()
+	defer pstr.Unlock()
+	if pstr.poster != nil && pstr.poster.IsConnectionOpen() {
+		return
+	}
+	poster := mqtt.NewClient(pstr.clientOpts)
+	if err = utils.MQTTWait(poster.Connect(), pstr.clientOpts.ConnectTimeout); err != nil {
+		return
+	}
+	pstr.poster = poster
+	return
+}
+
+func (pstr *MQTTEE) ExportEvent(content any, _ string) error {
+	pstr.reqs.get()
+	defer pstr.reqs.done()
+	pstr.RLock()
+	defer pstr.RUnlock()
+	if pstr.poster == nil {
+		return utils.ErrDisconnected
+	}
+	return utils.MQTTWait(pstr.poster.Publish(pstr.topic, pstr.qos, pstr.retain, content.([]byte)),
+		pstr.clientOpts.ConnectTimeout)
+}
+
+func (pstr *MQTTEE) Close() (err error) {
+	pstr.Lock()
+	defer pstr.Unlock()
+	if pstr.poster == nil {
+		return
+	}
+	pstr.poster.Disconnect(250) // give the in-flight messages some time to complete
+	pstr.poster = nil
+	return
+}
+
+func (pstr *MQTTEE) GetMetrics() *utils.ExporterMetrics { return pstr.em }
@@ -0,0 +1,88 @@
+//go:build integration
+// +build integration
+
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+func TestMQTTEEExportEvent(t *testing.T) {
+	cmd := exec.Command("mosquitto", "-p", "1883")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err) // most probably not installed
+	}
+	time.Sleep(50 * time.Millisecond)
+	defer cmd.Process.Kill()
+
+	cfg := &config.EventExporterCfg{
+		ID:                 "mqtt_exporter",
+		Type:               utils.MetaMQTTjsonMap,
+		ExportPath:         "127.0.0.1:1883",
+		ConcurrentRequests: 1,
+		Opts: &config.EventExporterOpts{
+			MQTT: &config.MQTTOpts{
+				Topic:  utils.StringPointer("cgrates/cdrs"),
+				Retain: utils.BoolPointer(true),
+			},
+		},
+	}
+	pstr, err := NewMQTTEE(cfg, "node1", time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = pstr.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if err = pstr.ExportEvent([]byte(`{"OriginID":"abc"}`), utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if err = pstr.Close(); err != nil {
+		t.Error(err)
+	}
+
+	// the message was retained so a late subscriber still receives it
+	sub := mqtt.NewClient(mqtt.NewClientOptions().AddBroker("tcp://127.0.0.1:1883"))
+	if tkn := sub.Connect(); tkn.Wait() && tkn.Error() != nil {
+		t.Fatal(tkn.Error())
+	}
+	defer sub.Disconnect(0)
+	msgs := make(chan string, 1)
+	if tkn := sub.Subscribe("cgrates/#", 1, func(_ mqtt.Client, msg mqtt.Message) {
+		msgs <- msg.Topic() + " " + string(msg.Payload())
+	}); tkn.Wait() && tkn.Error() != nil {
+		t.Fatal(tkn.Error())
+	}
+	select {
+	case msg := <-msgs:
+		if exp := `cgrates/cdrs {"OriginID":"abc"}`; msg != exp {
+			t.Errorf("expected %q, received %q", exp, msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("timeout waiting for message")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestNewMQTTEE(t *testing.T) {
+	cfg := &config.EventExporterCfg{
+		ID:                 "mqtt_exporter",
+		Type:               utils.MetaMQTTjsonMap,
+		ExportPath:         "mqtt://127.0.0.1",
+		ConcurrentRequests: 1,
+		Opts: &config.EventExporterOpts{
+			MQTT: &config.MQTTOpts{
+				Topic:    utils.StringPointer("cgrates/cdrs"),
+				QoS:      utils.IntPointer(2),
+				Retain:   utils.BoolPointer(true),
+				ClientID: utils.StringPointer("exporter1"),
+				Username: utils.StringPointer("cgrates"),
+				Password: utils.StringPointer("secret"),
+			},
+		},
+	}
+	pstr, err := NewMQTTEE(cfg, "node1", time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pstr.topic != "cgrates/cdrs" || pstr.qos != 2 || !pstr.retain {
+		t.Errorf("unexpected publishing options: %q %d %v", pstr.topic, pstr.qos, pstr.retain)
+	}
+	if opts := pstr.clientOpts; len(opts.Servers) != 1 ||
+		opts.Servers[0].String() != "mqtt://127.0.0.1:1883" ||
+		opts.ClientID != "exporter1" ||
+		opts.Username != "cgrates" ||
+		opts.Password != "secret" ||
+		opts.ConnectTimeout != time.Second {
+		t.Errorf("unexpected client options: %+v", opts)
+	}
+	if pstr.Cfg() != cfg {
+		t.Error("unexpected config")
+	}
+	if err = pstr.ExportEvent([]byte("{}"), utils.EmptyString); err != utils.ErrDisconnected {
+		t.Errorf("expected %v, received %v", utils.ErrDisconnected, err)
+	}
+
+	cfg.Opts.MQTT = &config.MQTTOpts{
+		TLS:               utils.BoolPointer(true),
+		ClientCertificate: utils.StringPointer("/tmp/inexistent.crt"),
+		ClientKey:         utils.StringPointer("/tmp/inexistent.key"),
+	}
+	if _, err = NewMQTTEE(cfg, "node1", time.Second, nil); err == nil {
+		t.Error("expected error for missing client certificate")
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/mediocregopher/radix/v3"
+)
+
+// NewRedisStreamEE creates a Redis Streams poster
+func NewRedisStreamEE(cfg *config.EventExporterCfg, connTimeout time.Duration, em *utils.ExporterMetrics) (pstr *RedisStreamEE, err error) {
+	pstr = &RedisStreamEE{
+		cfg:          cfg,
+		em:           em,
+		stream:       utils.DefaultQueueID,
+		field:        utils.RedisStreamDefaultField,
+		maxLenApprox: true,
+		clientOpts: utils.RedisStreamClientOpts{
+			Address:        cfg.ExportPath,
+			ConnectTimeout: connTimeout,
+			PoolSize:       max(cfg.ConcurrentRequests, 1),
+		},
+		reqs: newConcReq(cfg.ConcurrentRequests),
+	}
+	err = pstr.parseOpts(cfg.Opts.RedisStream)
+	return
+}
+
+// RedisStreamEE is a Redis Streams poster
+type RedisStreamEE struct {
+	stream       string // stream where we add the entries
+	field        string // entry field holding the event
+	maxLen       int    // 0 disables trimming
+	maxLenApprox bool
+	clientOpts   utils.RedisStreamClientOpts
+
+	poster radix.Client
+
+	cfg          *config.EventExporterCfg
+	em           *utils.ExporterMetrics
+	reqs         *concReq
+	sync.RWMutex // protect poster
+	bytePreparing
+}
+
+func (pstr *RedisStreamEE) parseOpts(opts *config.RedisStreamOpts) (err error) {
+	if opts == nil {
+		return
+	}
+	if opts.Stream != nil {
+		pstr.stream = *opts.Stream
+	}
+	if opts.Field != nil {
+		pstr.field = *opts.Field
+	}
+	if opts.MaxLen != nil {
+		pstr.maxLen = *opts.MaxLen
+	}
+	if opts.MaxLenApprox != nil {
+		pstr.maxLenApprox = *opts.MaxLenApprox
+	}
+	if opts.TLS != nil && *opts.TLS {
+		var caPath, certPath, keyPath string
+		if opts.CACertificate != nil {
+			caPath = *opts.CACertificate
+		}
+		if opts.ClientCertificate != nil {
+			certPath = *opts.ClientCertificate
+		}
+		if opts.ClientKey != nil {
+			keyPath = *opts.ClientKey
+		}
+		pstr.clientOpts.TLSConfig, err = utils.NewMQTTTLSConfig(caPath, certPath, keyPath, false)
+	}
+	return
+}
+
+// xAddArgs returns the XADD arguments, trimming the stream when maxLen is set
+func (pstr *RedisStreamEE) xAddArgs(content []byte) (args []string) {
+	args = make([]string, 1, 7)
+	args[0] = pstr.stream
+	if pstr.maxLen > 0 {
+		args = append(args, "MAXLEN")
+		if pstr.maxLenApprox {
+			args = append(args, "~")
+		}
+		args = append(args, strconv.Itoa(pstr.maxLen))
+	}
+	return append(args, "*", pstr.field, string(content))
+}
+
+func (pstr *RedisStreamEE) Cfg() *config.EventExporterCfg { return pstr.cfg }
+
+func (pstr *RedisStreamEE) Connect() (err error) {
+	pstr.Lock()
+	defer pstr.Unlock()
+	if pstr.poster == nil {
+		pstr.poster, err = utils.NewRedisStreamClient(pstr.clientOpts)
+	}
+	return
+}
+
+func (pstr *RedisStreamEE) ExportEvent(content any, _ string) error {
+	pstr.reqs.get()
+	defer pstr.reqs.done()
+	pstr.RLock()
+	defer pstr.RUnlock()
+	if pstr.poster == nil {
+		return utils.ErrDisconnected
+	}
+	return pstr.poster.Do(radix.Cmd(nil, "XADD", pstr.xAddArgs(content.([]byte))...))
+}
+
+func (pstr *RedisStreamEE) Close() (err error) {
+	pstr.Lock()
+	defer pstr.Unlock()
+	if pstr.poster == nil {
+		return
+	}
+	err = pstr.poster.Close()
+	pstr.poster = nil
+	return
+}
+
+func (pstr *RedisStreamEE) GetMetrics() *utils.ExporterMetrics { return pstr.em }
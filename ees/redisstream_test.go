@@ -0,0 +1,133 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/mediocregopher/radix/v3"
+)
+
+func TestNewRedisStreamEE(t *testing.T) {
+	cfg := &config.EventExporterCfg{
+		ID:                 "redis_exporter",
+		Type:               utils.MetaRedisStreamjsonMap,
+		ExportPath:         "redis://127.0.0.1:6379/1",
+		ConcurrentRequests: 2,
+		Opts: &config.EventExporterOpts{
+			RedisStream: &config.RedisStreamOpts{
+				Stream: utils.StringPointer("cdrs"),
+				Field:  utils.StringPointer("cdr"),
+				MaxLen: utils.IntPointer(1000),
+			},
+		},
+	}
+	pstr, err := NewRedisStreamEE(cfg, time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pstr.stream != "cdrs" || pstr.field != "cdr" ||
+		pstr.maxLen != 1000 || !pstr.maxLenApprox {
+		t.Errorf("unexpected exporter options: %+v", pstr)
+	}
+	exp := utils.RedisStreamClientOpts{
+		Address:        "redis://127.0.0.1:6379/1",
+		ConnectTimeout: time.Second,
+		PoolSize:       2,
+	}
+	if !reflect.DeepEqual(exp, pstr.clientOpts) {
+		t.Errorf("expected %+v, received %+v", exp, pstr.clientOpts)
+	}
+	if pstr.Cfg() != cfg {
+		t.Error("unexpected config")
+	}
+	if err = pstr.ExportEvent([]byte("{}"), utils.EmptyString); err != utils.ErrDisconnected {
+		t.Errorf("expected %v, received %v", utils.ErrDisconnected, err)
+	}
+
+	cfg.Opts.RedisStream = &config.RedisStreamOpts{
+		TLS:               utils.BoolPointer(true),
+		ClientCertificate: utils.StringPointer("/tmp/inexistent.crt"),
+		ClientKey:         utils.StringPointer("/tmp/inexistent.key"),
+	}
+	if _, err = NewRedisStreamEE(cfg, time.Second, nil); err == nil {
+		t.Error("expected error for missing client certificate")
+	}
+}
+
+func TestRedisStreamEEXAddArgs(t *testing.T) {
+	pstr := &RedisStreamEE{
+		stream: "cdrs",
+		field:  utils.RedisStreamDefaultField,
+	}
+	content := []byte(`{"OriginID":"abc"}`)
+	if exp, rcv := []string{"cdrs", "*", "event", `{"OriginID":"abc"}`}, pstr.xAddArgs(content); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %q, received %q", exp, rcv)
+	}
+	pstr.maxLen = 100
+	if exp, rcv := []string{"cdrs", "MAXLEN", "100", "*", "event", `{"OriginID":"abc"}`}, pstr.xAddArgs(content); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %q, received %q", exp, rcv)
+	}
+	pstr.maxLenApprox = true
+	if exp, rcv := []string{"cdrs", "MAXLEN", "~", "100", "*", "event", `{"OriginID":"abc"}`}, pstr.xAddArgs(content); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %q, received %q", exp, rcv)
+	}
+}
+
+func TestRedisStreamEEExportEvent(t *testing.T) {
+	cfg := &config.EventExporterCfg{
+		ID:                 "redis_exporter",
+		Type:               utils.MetaRedisStreamjsonMap,
+		ExportPath:         "127.0.0.1:6379",
+		ConcurrentRequests: 1,
+		Opts: &config.EventExporterOpts{
+			RedisStream: &config.RedisStreamOpts{
+				MaxLen: utils.IntPointer(10),
+			},
+		},
+	}
+	pstr, err := NewRedisStreamEE(cfg, time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cmds [][]string
+	pstr.poster = radix.Stub(utils.TCP, cfg.ExportPath, func(args []string) any {
+		cmds = append(cmds, args)
+		return "1-0"
+	})
+	if err = pstr.Connect(); err != nil { // already connected
+		t.Fatal(err)
+	}
+	if err = pstr.ExportEvent([]byte(`{"OriginID":"abc"}`), utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if exp := [][]string{{"XADD", utils.DefaultQueueID, "MAXLEN", "~", "10", "*", "event", `{"OriginID":"abc"}`}}; !reflect.DeepEqual(exp, cmds) {
+		t.Errorf("expected %q, received %q", exp, cmds)
+	}
+	if err = pstr.Close(); err != nil {
+		t.Error(err)
+	}
+	if err = pstr.ExportEvent([]byte("{}"), utils.EmptyString); err != utils.ErrDisconnected {
+		t.Errorf("expected %v, received %v", utils.ErrDisconnected, err)
+	}
+}
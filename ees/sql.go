@@ -22,9 +22,12 @@ import (
 	"database/sql"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -41,7 +44,19 @@ func NewSQLEe(cfg *config.EventExporterCfg,
 		em:   em,
 		reqs: newConcReq(cfg.ConcurrentRequests),
 	}
-	err = sqlEe.initDialector()
+	if err = sqlEe.initDialector(); err != nil {
+		return
+	}
+	sqlOpts := cfg.Opts.SQL
+	if sqlOpts.BatchSize != nil {
+		sqlEe.batchSize = *sqlOpts.BatchSize
+	}
+	if sqlOpts.BatchInterval != nil {
+		sqlEe.batchInterval = *sqlOpts.BatchInterval
+	}
+	if sqlOpts.ConflictFields != nil {
+		sqlEe.conflictFields = *sqlOpts.ConflictFields
+	}
 	return
 }
 
@@ -54,13 +69,41 @@ type SQLEe struct {
 	reqs  *concReq
 
 	dialect   gorm.Dialector
+	dbType    string
 	tableName string
 	sync.RWMutex
+
+	conflictFields []string      // the unique key columns used on upsert
+	batchSize      int           // the rows are batched if bigger than 1
+	batchInterval  time.Duration // flush interval for the incomplete batches
+	batch          []*sqlPosterRequest
+	batchMux       sync.Mutex
+	stopBatch      chan struct{}
 }
 
 type sqlPosterRequest struct {
-	Querry string
-	Values []any
+	Querry  string
+	Values  []any
+	Columns []string // populated for the rows inserted in batches, with the query built on flush
+
+	written []func(error) // called with the result of the batch insert
+}
+
+// onWrite registers the function called with the result of the batch
+// insert, returning false for the rows not inserted in batches
+func (sReq *sqlPosterRequest) onWrite(f func(error)) bool {
+	if sReq.Querry != utils.EmptyString {
+		return false
+	}
+	sReq.written = append(sReq.written, f)
+	return true
+}
+
+// reportWrite passes the result of the batch insert to the registered functions
+func (sReq *sqlPosterRequest) reportWrite(err error) {
+	for _, f := range sReq.written {
+		f(err)
+	}
 }
 
 func (sqlEe *SQLEe) initDialector() (err error) {
@@ -86,13 +129,19 @@ func (sqlEe *SQLEe) initDialector() (err error) {
 		return utils.NewErrMandatoryIeMissing(utils.SQLTableNameOpt)
 	}
 
-	// var dialect gorm.Dialector
+	sqlEe.dbType = u.Scheme
 	switch u.Scheme {
 	case utils.MySQL:
 		sqlEe.dialect = mysql.Open(fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8&loc=Local&parseTime=true&sql_mode='ALLOW_INVALID_DATES'",
 			u.User.Username(), password, u.Hostname(), u.Port(), dbname) + engine.AppendToMysqlDSNOpts(sqlEe.Cfg().Opts.SQL.MYSQLDSNParams))
 	case utils.Postgres:
 		sqlEe.dialect = postgres.Open(fmt.Sprintf("host=%s port=%s dbname=%s user=%s password=%s sslmode=%s", u.Hostname(), u.Port(), dbname, u.User.Username(), password, ssl))
+	case utils.SQLite:
+		path := u.Host + u.Path // *sqlite:///var/lib/cgrates/cdrs.db, falling back to the dbName option
+		if path == utils.EmptyString {
+			path = dbname
+		}
+		sqlEe.dialect = sqlite.Open(engine.SQLiteDSN(path))
 	default:
 		return fmt.Errorf("db type <%s> not supported", u.Scheme)
 	}
@@ -124,10 +173,24 @@ func (sqlEe *SQLEe) Cfg() *config.EventExporterCfg { return sqlEe.cfg }
 
 func (sqlEe *SQLEe) Connect() (err error) {
 	sqlEe.Lock()
-	if sqlEe.db == nil || sqlEe.sqldb == nil {
-		sqlEe.db, sqlEe.sqldb, err = openDB(sqlEe.dialect, sqlEe.Cfg().Opts.SQL)
+	defer sqlEe.Unlock()
+	if sqlEe.db != nil && sqlEe.sqldb != nil {
+		return
+	}
+	if sqlEe.db, sqlEe.sqldb, err = openDB(sqlEe.dialect, sqlEe.Cfg().Opts.SQL); err != nil {
+		return
+	}
+	if createTable := sqlEe.Cfg().Opts.SQL.CreateTable; createTable != nil && *createTable {
+		if err = sqlEe.createTable(); err != nil {
+			sqlEe.sqldb.Close()
+			sqlEe.db, sqlEe.sqldb = nil, nil
+			return
+		}
+	}
+	if sqlEe.batchSize > 1 && sqlEe.batchInterval > 0 {
+		sqlEe.stopBatch = make(chan struct{})
+		go sqlEe.flushLoop(sqlEe.stopBatch)
 	}
-	sqlEe.Unlock()
 	return
 }
 
@@ -142,11 +205,22 @@ func (sqlEe *SQLEe) ExportEvent(req any, _ string) error {
 		return utils.ErrDisconnected
 	}
 	sReq := req.(*sqlPosterRequest)
+	if sReq.Querry == utils.EmptyString { // the query is built when the batch is flushed
+		sqlEe.addToBatch(sReq)
+		return nil
+	}
 	return sqlEe.db.Table(sqlEe.tableName).Exec(sReq.Querry, sReq.Values...).Error
 }
 
 func (sqlEe *SQLEe) Close() (err error) {
 	sqlEe.Lock()
+	if sqlEe.stopBatch != nil {
+		close(sqlEe.stopBatch)
+		sqlEe.stopBatch = nil
+	}
+	if sqlEe.db != nil {
+		sqlEe.flush() // do not lose the pending rows
+	}
 	if sqlEe.sqldb != nil {
 		err = sqlEe.sqldb.Close()
 	}
@@ -166,6 +240,9 @@ func (sqlEe *SQLEe) PrepareMap(cgrEv *utils.CGREvent) (any, error) {
 		colNames = append(colNames, colName)
 		vals = append(vals, value)
 	}
+	if sqlEe.batchSize > 1 || len(sqlEe.conflictFields) != 0 {
+		return sqlEe.newRowRequest(colNames, vals), nil
+	}
 	sqlValues := make([]string, len(vals)) // values to be inserted as "?" for the query
 	for i := range vals {
 		sqlValues[i] = "?"
@@ -222,6 +299,9 @@ func (sqlEe *SQLEe) PrepareOrderMap(mp *utils.OrderedNavigableMap) (any, error)
 		for _, val := range whereVals {
 			vals = append(vals, val)
 		}
+	} else if len(colNames) == len(vals) &&
+		(sqlEe.batchSize > 1 || len(sqlEe.conflictFields) != 0) {
+		return sqlEe.newRowRequest(colNames, vals), nil
 	} else {
 		if len(colNames) != len(vals) {
 			sqlQuery = fmt.Sprintf("INSERT INTO %s VALUES (%s); ",
@@ -239,3 +319,254 @@ func (sqlEe *SQLEe) PrepareOrderMap(mp *utils.OrderedNavigableMap) (any, error)
 		Values: vals,
 	}, nil
 }
+
+// newRowRequest returns the request for one row, with the query built later
+// in case of batches or as an insert/upsert of the single row
+func (sqlEe *SQLEe) newRowRequest(colNames []string, vals []any) *sqlPosterRequest {
+	if sqlEe.batchSize > 1 {
+		return &sqlPosterRequest{
+			Values:  vals,
+			Columns: colNames,
+		}
+	}
+	return &sqlPosterRequest{
+		Querry: sqlEe.insertQuery(colNames, 1),
+		Values: vals,
+	}
+}
+
+// insertQuery builds the INSERT query for the number of rows, updating
+// the existing rows on conflict if the conflict fields are configured
+func (sqlEe *SQLEe) insertQuery(colNames []string, rows int) string {
+	row := "(" + strings.TrimSuffix(strings.Repeat("?,", len(colNames)), ",") + ")"
+	sqlRows := make([]string, rows)
+	for i := range sqlRows {
+		sqlRows[i] = row
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s",
+		sqlEe.tableName,
+		strings.Join(colNames, ", "),
+		strings.Join(sqlRows, ","))
+	if len(sqlEe.conflictFields) == 0 {
+		return query + ";"
+	}
+	setClauses := make([]string, 0, len(colNames)) // columns updated on conflict
+	for _, col := range colNames {
+		if slices.Contains(sqlEe.conflictFields, col) {
+			continue
+		}
+		if sqlEe.dbType == utils.Postgres || sqlEe.dbType == utils.SQLite {
+			setClauses = append(setClauses, fmt.Sprintf("%s = EXCLUDED.%s", col, col))
+		} else {
+			setClauses = append(setClauses, fmt.Sprintf("%s = VALUES(%s)", col, col))
+		}
+	}
+	if sqlEe.dbType == utils.Postgres || sqlEe.dbType == utils.SQLite {
+		if len(setClauses) == 0 {
+			return fmt.Sprintf("%s ON CONFLICT (%s) DO NOTHING;",
+				query, strings.Join(sqlEe.conflictFields, ", "))
+		}
+		return fmt.Sprintf("%s ON CONFLICT (%s) DO UPDATE SET %s;",
+			query, strings.Join(sqlEe.conflictFields, ", "), strings.Join(setClauses, ", "))
+	}
+	if len(setClauses) == 0 { // nothing to update, keep the existing row
+		setClauses = append(setClauses, fmt.Sprintf("%s = %s", sqlEe.conflictFields[0], sqlEe.conflictFields[0]))
+	}
+	return fmt.Sprintf("%s ON DUPLICATE KEY UPDATE %s;", query, strings.Join(setClauses, ", "))
+}
+
+// addToBatch buffers the row and inserts the batch once full
+func (sqlEe *SQLEe) addToBatch(row *sqlPosterRequest) {
+	sqlEe.batchMux.Lock()
+	sqlEe.batch = append(sqlEe.batch, row)
+	if len(sqlEe.batch) < sqlEe.batchSize {
+		sqlEe.batchMux.Unlock()
+		return
+	}
+	rows := sqlEe.batch
+	sqlEe.batch = nil
+	sqlEe.batchMux.Unlock()
+	sqlEe.insertBatch(rows)
+}
+
+// flush inserts the pending rows, the caller needs to hold the connection lock
+func (sqlEe *SQLEe) flush() {
+	sqlEe.batchMux.Lock()
+	rows := sqlEe.batch
+	sqlEe.batch = nil
+	sqlEe.batchMux.Unlock()
+	if len(rows) != 0 {
+		sqlEe.insertBatch(rows)
+	}
+}
+
+// flushLoop inserts the incomplete batches on each batch interval
+func (sqlEe *SQLEe) flushLoop(stop chan struct{}) {
+	tkr := time.NewTicker(sqlEe.batchInterval)
+	defer tkr.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-tkr.C:
+			sqlEe.RLock()
+			if sqlEe.db != nil {
+				sqlEe.flush()
+			}
+			sqlEe.RUnlock()
+		}
+	}
+}
+
+// maxBatchRows returns the number of rows fitting into one insert query
+// without going over the placeholders limit of the database
+func (sqlEe *SQLEe) maxBatchRows(cols int) int {
+	maxVars := 65535 // MySQL and PostgreSQL
+	if sqlEe.dbType == utils.SQLite {
+		maxVars = 32766
+	}
+	return max(maxVars/max(cols, 1), 1)
+}
+
+// insertBatch inserts the rows with one query for each set of columns, split
+// to stay within the placeholders limit. The queries failing after all the
+// attempts are saved as single rows to the failed posts
+func (sqlEe *SQLEe) insertBatch(rows []*sqlPosterRequest) {
+	var keys []string
+	groups := make(map[string][]*sqlPosterRequest)
+	for _, row := range rows {
+		key := strings.Join(row.Columns, utils.FieldsSep)
+		if _, has := groups[key]; !has {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+	for _, key := range keys {
+		group := sqlEe.dedupConflicts(groups[key])
+		for chunk := range slices.Chunk(group, sqlEe.maxBatchRows(len(group[0].Columns))) {
+			err := sqlEe.insertRows(chunk)
+			for _, row := range chunk {
+				row.reportWrite(err)
+			}
+		}
+	}
+}
+
+// insertRows inserts the rows having the same columns with one query
+func (sqlEe *SQLEe) insertRows(rows []*sqlPosterRequest) (err error) {
+	colNames := rows[0].Columns
+	vals := make([]any, 0, len(rows)*len(colNames))
+	for _, row := range rows {
+		vals = append(vals, row.Values...)
+	}
+	query := sqlEe.insertQuery(colNames, len(rows))
+	fib := utils.FibDuration(time.Second, 0)
+	for i := 0; i < sqlEe.Cfg().Attempts; i++ {
+		if err = sqlEe.db.Table(sqlEe.tableName).Exec(query, vals...).Error; err == nil {
+			return
+		}
+		if i+1 < sqlEe.Cfg().Attempts {
+			time.Sleep(fib())
+		}
+	}
+	utils.Logger.Warning(
+		fmt.Sprintf("<%s> Exporter <%s> could not insert a batch of %d rows because err: <%s>",
+			utils.EEs, sqlEe.Cfg().ID, len(rows), err.Error()))
+	if sqlEe.Cfg().FailedPostsDir == utils.MetaNone {
+		return
+	}
+	rowQuery := sqlEe.insertQuery(colNames, 1)
+	for _, row := range rows {
+		AddFailedPost(sqlEe.Cfg().FailedPostsDir, sqlEe.Cfg().ExportPath,
+			sqlEe.Cfg().Type, sqlEe.Cfg().Attempts, &sqlPosterRequest{
+				Querry: rowQuery,
+				Values: row.Values,
+			}, sqlEe.Cfg().Opts)
+	}
+	return
+}
+
+// dedupConflicts keeps only the last row for the same conflict key since
+// one upsert query cannot update the same row twice, the dropped rows
+// sharing the result of the row replacing them
+func (sqlEe *SQLEe) dedupConflicts(rows []*sqlPosterRequest) []*sqlPosterRequest {
+	if len(sqlEe.conflictFields) == 0 || len(rows) < 2 {
+		return rows
+	}
+	var idxs []int
+	for i, col := range rows[0].Columns {
+		if slices.Contains(sqlEe.conflictFields, col) {
+			idxs = append(idxs, i)
+		}
+	}
+	if len(idxs) == 0 {
+		return rows
+	}
+	lastIdx := make(map[string]int, len(rows))
+	rowKeys := make([]string, len(rows))
+	for i, row := range rows {
+		keyVals := make([]string, len(idxs))
+		for j, idx := range idxs {
+			keyVals[j] = utils.IfaceAsString(row.Values[idx])
+		}
+		rowKeys[i] = utils.ConcatenatedKey(keyVals...)
+		lastIdx[rowKeys[i]] = i
+	}
+	dedup := make([]*sqlPosterRequest, 0, len(lastIdx))
+	for i, row := range rows {
+		if last := lastIdx[rowKeys[i]]; last != i {
+			rows[last].written = append(rows[last].written, row.written...)
+			continue
+		}
+		dedup = append(dedup, row)
+	}
+	return dedup
+}
+
+// createTable creates the table if missing, with the columns out of the
+// *exp fields of the template and an unique key over the conflict fields
+func (sqlEe *SQLEe) createTable() error {
+	var colDefs []string
+	cols := make(utils.StringSet)
+	for _, fld := range sqlEe.Cfg().Fields {
+		col, isExp := strings.CutPrefix(fld.Path, utils.MetaExp+utils.NestingSep)
+		if !isExp || fld.Type == utils.MetaNone || cols.Has(col) {
+			continue
+		}
+		cols.Add(col)
+		colDefs = append(colDefs, col+" "+sqlEe.columnType(fld, slices.Contains(sqlEe.conflictFields, col)))
+	}
+	if len(colDefs) == 0 {
+		return fmt.Errorf("no columns in fields template to create table <%s>", sqlEe.tableName)
+	}
+	if len(sqlEe.conflictFields) != 0 {
+		colDefs = append(colDefs, fmt.Sprintf("UNIQUE (%s)", strings.Join(sqlEe.conflictFields, ", ")))
+	}
+	return sqlEe.db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s);",
+		sqlEe.tableName, strings.Join(colDefs, ", "))).Error
+}
+
+// columnType returns the SQL type of the column based on the template field type
+func (sqlEe *SQLEe) columnType(fld *config.FCTemplate, isKey bool) string {
+	switch fld.Type {
+	case utils.MetaUnixTimestamp:
+		return "BIGINT"
+	case utils.MetaSum, utils.MetaMultiply, utils.MetaDivide, utils.MetaValueExponent:
+		if fld.RoundingDecimals != nil {
+			return fmt.Sprintf("DECIMAL(30,%d)", *fld.RoundingDecimals)
+		}
+		if sqlEe.dbType == utils.Postgres {
+			return "DOUBLE PRECISION"
+		}
+		return "DOUBLE"
+	case utils.MetaDateTime:
+		if sqlEe.dbType == utils.Postgres {
+			return "TIMESTAMP"
+		}
+		return "DATETIME"
+	}
+	if isKey && sqlEe.dbType != utils.Postgres {
+		return "VARCHAR(255)" // MySQL cannot index TEXT columns without a prefix length
+	}
+	return "TEXT"
+}
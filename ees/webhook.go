@@ -0,0 +1,292 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewWebhookEE returns an exporter posting the events as JSON to a webhook,
+// signing the requests and retrying them with exponential backoff
+func NewWebhookEE(cfg *config.EventExporterCfg, cgrCfg *config.CGRConfig, filterS *engine.FilterS,
+	em *utils.ExporterMetrics) (whEE *WebhookEE, err error) {
+	whEE = &WebhookEE{
+		sigHdr:     utils.WebhookDefaultSignatureHeader,
+		tsHdr:      utils.WebhookDefaultTimestampHeader,
+		backoff:    utils.WebhookDefaultBackoff,
+		maxBackoff: utils.WebhookDefaultMaxBackoff,
+		stop:       make(chan struct{}),
+	}
+	threshold := utils.WebhookDefaultBreakerThreshold
+	cooldown := utils.WebhookDefaultBreakerCooldown
+	if whOpts := cfg.Opts.Webhook; whOpts != nil {
+		if whOpts.Secret != nil {
+			whEE.secret = []byte(*whOpts.Secret)
+		}
+		if whOpts.SignatureHeader != nil {
+			whEE.sigHdr = *whOpts.SignatureHeader
+		}
+		if whOpts.TimestampHeader != nil {
+			whEE.tsHdr = *whOpts.TimestampHeader
+		}
+		whEE.authHdrs = whOpts.AuthHeaders
+		if whOpts.Backoff != nil {
+			whEE.backoff = *whOpts.Backoff
+		}
+		if whOpts.MaxBackoff != nil {
+			whEE.maxBackoff = *whOpts.MaxBackoff
+		}
+		if whOpts.BreakerThreshold != nil {
+			threshold = *whOpts.BreakerThreshold
+		}
+		if whOpts.BreakerCooldown != nil {
+			cooldown = *whOpts.BreakerCooldown
+		}
+	}
+	// the exporters are usually created for each event so the breaker state is kept outside of them
+	whEE.breaker = getCircuitBreaker(utils.ConcatenatedKey(cfg.ID, cfg.ExportPath), threshold, cooldown)
+	whEE.HTTPjsonMapEE, err = NewHTTPjsonMapEE(cfg, cgrCfg, filterS, em)
+	return
+}
+
+// WebhookEE implements EventExporter interface for signed webhook requests
+type WebhookEE struct {
+	*HTTPjsonMapEE
+
+	secret     []byte
+	sigHdr     string
+	tsHdr      string
+	authHdrs   map[string]string
+	backoff    time.Duration
+	maxBackoff time.Duration
+	breaker    *circuitBreaker
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// ExportEvent posts the event retrying up to the configured attempts. The 4xx replies
+// are not retried, with the exception of 408 and 429, and do not count as breaker failures
+func (whEE *WebhookEE) ExportEvent(content any, _ string) (err error) {
+	whEE.reqs.get()
+	defer whEE.reqs.done()
+	pReq := content.(*HTTPPosterRequest)
+	body := pReq.Body.([]byte)
+	for attempt := 0; ; attempt++ {
+		if !whEE.breaker.allow() {
+			return utils.ErrCircuitBreakerOpen
+		}
+		var req *http.Request
+		if req, err = prepareRequest(whEE.Cfg().ExportPath, utils.ContentJSON, body,
+			whEE.requestHeader(pReq.Header, body)); err != nil {
+			whEE.breaker.success() // not the fault of the endpoint
+			return
+		}
+		var status int
+		var retryAfter time.Duration
+		status, retryAfter, err = whEE.do(req)
+		switch {
+		case err == nil:
+			whEE.breaker.success()
+			return
+		case status == 0 || status >= http.StatusInternalServerError:
+			if whEE.breaker.failure() {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> Exporter <%s> opened the circuit breaker for <%s> because err: <%s>",
+						utils.EEs, whEE.Cfg().ID, whEE.Cfg().ExportPath, err.Error()))
+			}
+		case status == http.StatusRequestTimeout || status == http.StatusTooManyRequests:
+			whEE.breaker.success() // the endpoint is up, only asking us to slow down
+		default: // the request is rejected, no point in sending it again
+			whEE.breaker.success()
+			return
+		}
+		if attempt+1 >= whEE.Cfg().Attempts {
+			return
+		}
+		delay := whEE.backoffDelay(attempt)
+		if retryAfter != 0 {
+			if retryAfter > whEE.maxBackoff {
+				return fmt.Errorf("%w, Retry-After of %s exceeds the maximum backoff", err, retryAfter)
+			}
+			delay = retryAfter
+		}
+		select {
+		case <-whEE.stop:
+			return utils.ErrDisconnected
+		case <-time.After(delay):
+		}
+	}
+}
+
+// requestHeader adds the authentication headers and the signature to a copy of the event header
+func (whEE *WebhookEE) requestHeader(evHdr http.Header, body []byte) (hdr http.Header) {
+	hdr = evHdr.Clone()
+	for name, val := range whEE.authHdrs {
+		hdr.Set(name, val)
+	}
+	if len(whEE.secret) == 0 {
+		return
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	hdr.Set(whEE.tsHdr, ts)
+	hdr.Set(whEE.sigHdr, "sha256="+webhookSignature(whEE.secret, ts, body))
+	return
+}
+
+// do sends the request, returning the status code(0 on transport errors) and the Retry-After delay
+func (whEE *WebhookEE) do(req *http.Request) (status int, retryAfter time.Duration, err error) {
+	var resp *http.Response
+	if resp, err = whEE.client.Do(req); err != nil {
+		return
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if status = resp.StatusCode; status > 299 {
+		err = fmt.Errorf("unexpected status code received: <%d>", status)
+		retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	}
+	return
+}
+
+// backoffDelay doubles the initial backoff for each attempt, capped at the maximum
+// backoff, and randomizes the upper half of it to spread the retries in time
+func (whEE *WebhookEE) backoffDelay(attempt int) time.Duration {
+	delay := whEE.maxBackoff
+	if attempt < 32 {
+		if d := whEE.backoff << attempt; d > 0 && d < delay {
+			delay = d
+		}
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// handlesRetries implements retryingExporter, the failed requests being retried with backoff by ExportEvent
+func (whEE *WebhookEE) handlesRetries() bool { return true }
+
+func (whEE *WebhookEE) Close() (_ error) {
+	whEE.closeOnce.Do(func() { close(whEE.stop) })
+	return
+}
+
+// webhookSignature computes the hex encoded HMAC-SHA256 of {timestamp}.{body}
+func webhookSignature(secret []byte, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseRetryAfter accepts both the delay in seconds and the HTTP-date formats
+func parseRetryAfter(val string, now time.Time) time.Duration {
+	if val == utils.EmptyString {
+		return 0
+	}
+	if secs, err := strconv.Atoi(val); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(val); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+var (
+	circuitBreakers   = make(map[string]*circuitBreaker)
+	circuitBreakersMu sync.Mutex
+)
+
+// getCircuitBreaker returns the breaker shared by the exporters with the same key,
+// updating its settings in case they were reloaded
+func getCircuitBreaker(key string, threshold int, cooldown time.Duration) (cb *circuitBreaker) {
+	circuitBreakersMu.Lock()
+	defer circuitBreakersMu.Unlock()
+	var has bool
+	if cb, has = circuitBreakers[key]; !has {
+		cb = new(circuitBreaker)
+		circuitBreakers[key] = cb
+	}
+	cb.Lock()
+	cb.threshold = threshold
+	cb.cooldown = cooldown
+	cb.Unlock()
+	return
+}
+
+// circuitBreaker stops the requests to an endpoint after a number of consecutive
+// failures. Once the cooldown passes a single probe request is let through which
+// closes the breaker on success or keeps it open for another cooldown on failure
+type circuitBreaker struct {
+	sync.Mutex
+	threshold int // 0 disables the breaker
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func (cb *circuitBreaker) allow() bool {
+	cb.Lock()
+	defer cb.Unlock()
+	if cb.threshold == 0 || cb.failures < cb.threshold {
+		return true
+	}
+	if cb.probing || time.Now().Before(cb.openUntil) {
+		return false
+	}
+	cb.probing = true
+	return true
+}
+
+func (cb *circuitBreaker) success() {
+	cb.Lock()
+	cb.failures = 0
+	cb.probing = false
+	cb.Unlock()
+}
+
+// failure records a failed request, returning true if this opened the breaker
+func (cb *circuitBreaker) failure() (opened bool) {
+	cb.Lock()
+	defer cb.Unlock()
+	cb.failures++
+	if cb.threshold == 0 || cb.failures < cb.threshold {
+		return
+	}
+	opened = cb.probing || cb.failures == cb.threshold
+	cb.probing = false
+	cb.openUntil = time.Now().Add(cb.cooldown)
+	return
+}
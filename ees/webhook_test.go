@@ -0,0 +1,239 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// newTestWebhookEE starts a server with the given handler and returns the exporter posting to it
+func newTestWebhookEE(t *testing.T, id string, attempts int, whOpts *config.WebhookOpts,
+	handler http.HandlerFunc) *WebhookEE {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	cfg := config.NewEventExporterCfg(id, utils.MetaWebhook, srv.URL, utils.MetaNone, attempts,
+		&config.EventExporterOpts{Webhook: whOpts})
+	whEE, err := NewWebhookEE(cfg, config.NewDefaultCGRConfig(), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { whEE.Close() })
+	return whEE
+}
+
+// statusHandler replies with the given status codes in order, repeating the last one
+func statusHandler(reqs *atomic.Int32, statuses ...int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idx := int(reqs.Add(1)) - 1
+		w.WriteHeader(statuses[min(idx, len(statuses)-1)])
+	}
+}
+
+func TestWebhookEESign(t *testing.T) {
+	body := []byte(`{"Account":"1001"}`)
+	rcv := make(chan *http.Request, 1)
+	whEE := newTestWebhookEE(t, "TestWebhookEESign", 1, &config.WebhookOpts{
+		Secret:      utils.StringPointer("secret"),
+		AuthHeaders: map[string]string{"Authorization": "Bearer token"},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if b, _ := io.ReadAll(r.Body); string(b) != string(body) {
+			t.Errorf("unexpected body: %s", b)
+		}
+		rcv <- r
+	})
+	hdr := make(http.Header)
+	hdr.Set("X-Tenant", "cgrates.org")
+	if err := whEE.ExportEvent(&HTTPPosterRequest{Header: hdr, Body: body}, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	r := <-rcv
+	ts := r.Header.Get(utils.WebhookDefaultTimestampHeader)
+	if exp := "sha256=" + webhookSignature([]byte("secret"), ts, body); r.Header.Get(utils.WebhookDefaultSignatureHeader) != exp {
+		t.Errorf("expected signature %q, received %q", exp, r.Header.Get(utils.WebhookDefaultSignatureHeader))
+	}
+	// echo -n '1700000000.{"Account":"1001"}' | openssl dgst -sha256 -hmac secret
+	if exp, rcvSig := "13973d5ae78f4885df5bb88f2cd2ff503649b60cea9de8e76715bec74756305d",
+		webhookSignature([]byte("secret"), "1700000000", body); rcvSig != exp {
+		t.Errorf("expected %s, received %s", exp, rcvSig)
+	}
+	if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("X-Tenant") != "cgrates.org" ||
+		r.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected headers: %v", r.Header)
+	}
+	if len(hdr) != 1 {
+		t.Errorf("event header should not be modified: %v", hdr)
+	}
+}
+
+func TestWebhookEERetries(t *testing.T) {
+	var reqs atomic.Int32
+	whEE := newTestWebhookEE(t, "TestWebhookEERetries", 3, &config.WebhookOpts{
+		Backoff:          utils.DurationPointer(time.Millisecond),
+		BreakerThreshold: utils.IntPointer(0),
+	}, statusHandler(&reqs, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK))
+	if err := whEE.ExportEvent(&HTTPPosterRequest{Header: make(http.Header), Body: []byte("{}")},
+		utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if reqs.Load() != 3 {
+		t.Errorf("expected 3 requests, received %d", reqs.Load())
+	}
+
+	// 4xx replies are not retried
+	reqs.Store(0)
+	whEE = newTestWebhookEE(t, "TestWebhookEERetries", 3, &config.WebhookOpts{
+		Backoff: utils.DurationPointer(time.Millisecond),
+	}, statusHandler(&reqs, http.StatusUnauthorized))
+	if err := whEE.ExportEvent(&HTTPPosterRequest{Header: make(http.Header), Body: []byte("{}")},
+		utils.EmptyString); err == nil || err.Error() != "unexpected status code received: <401>" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if reqs.Load() != 1 {
+		t.Errorf("expected 1 request, received %d", reqs.Load())
+	}
+}
+
+func TestWebhookEERetryAfter(t *testing.T) {
+	var reqs atomic.Int32
+	whEE := newTestWebhookEE(t, "TestWebhookEERetryAfter", 3, &config.WebhookOpts{
+		Backoff:    utils.DurationPointer(time.Millisecond),
+		MaxBackoff: utils.DurationPointer(10 * time.Millisecond),
+	}, func(w http.ResponseWriter, r *http.Request) {
+		reqs.Add(1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	if err := whEE.ExportEvent(&HTTPPosterRequest{Header: make(http.Header), Body: []byte("{}")},
+		utils.EmptyString); err == nil || !strings.Contains(err.Error(), "Retry-After of 2m0s") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if reqs.Load() != 1 {
+		t.Errorf("expected 1 request, received %d", reqs.Load())
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for val, exp := range map[string]time.Duration{
+		"":                              0,
+		"5":                             5 * time.Second,
+		"-1":                            0,
+		"Mon, 01 Jan 2024 12:00:30 GMT": 30 * time.Second,
+		"Mon, 01 Jan 2024 11:00:00 GMT": 0,
+		"soon":                          0,
+	} {
+		if rcv := parseRetryAfter(val, now); rcv != exp {
+			t.Errorf("Retry-After %q: expected %s, received %s", val, exp, rcv)
+		}
+	}
+}
+
+func TestWebhookEEBackoffDelay(t *testing.T) {
+	whEE := &WebhookEE{backoff: 100 * time.Millisecond, maxBackoff: time.Second}
+	for attempt, exp := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond,
+		400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		if rcv := whEE.backoffDelay(attempt); rcv < exp/2 || rcv > exp {
+			t.Errorf("attempt %d: expected delay between %s and %s, received %s", attempt, exp/2, exp, rcv)
+		}
+	}
+	if rcv := whEE.backoffDelay(100); rcv < time.Second/2 || rcv > time.Second {
+		t.Errorf("expected delay capped at %s, received %s", time.Second, rcv)
+	}
+}
+
+func TestWebhookEECircuitBreaker(t *testing.T) {
+	var reqs atomic.Int32
+	whEE := newTestWebhookEE(t, "TestWebhookEECircuitBreaker", 1, &config.WebhookOpts{
+		BreakerThreshold: utils.IntPointer(2),
+		BreakerCooldown:  utils.DurationPointer(50 * time.Millisecond),
+	}, statusHandler(&reqs, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusInternalServerError, http.StatusOK))
+	ev := &HTTPPosterRequest{Header: make(http.Header), Body: []byte("{}")}
+	for range 2 {
+		if err := whEE.ExportEvent(ev, utils.EmptyString); err == nil {
+			t.Error("expected error")
+		}
+	}
+	if err := whEE.ExportEvent(ev, utils.EmptyString); err != utils.ErrCircuitBreakerOpen {
+		t.Errorf("expected %v, received %v", utils.ErrCircuitBreakerOpen, err)
+	}
+	if reqs.Load() != 2 {
+		t.Errorf("expected 2 requests, received %d", reqs.Load())
+	}
+
+	// a failed probe keeps the breaker open for another cooldown
+	time.Sleep(60 * time.Millisecond)
+	if err := whEE.ExportEvent(ev, utils.EmptyString); err == nil || err == utils.ErrCircuitBreakerOpen {
+		t.Errorf("expected probe error, received %v", err)
+	}
+	if err := whEE.ExportEvent(ev, utils.EmptyString); err != utils.ErrCircuitBreakerOpen {
+		t.Errorf("expected %v, received %v", utils.ErrCircuitBreakerOpen, err)
+	}
+
+	// the state is shared with the exporters created later for the same webhook
+	time.Sleep(60 * time.Millisecond)
+	whEE2, err := NewWebhookEE(whEE.Cfg(), config.NewDefaultCGRConfig(), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if whEE2.breaker != whEE.breaker {
+		t.Error("expected the same circuit breaker")
+	}
+	for range 2 {
+		if err := whEE2.ExportEvent(ev, utils.EmptyString); err != nil {
+			t.Error(err)
+		}
+	}
+	if reqs.Load() != 5 {
+		t.Errorf("expected 5 requests, received %d", reqs.Load())
+	}
+}
+
+func TestWebhookEEExportWithAttempts(t *testing.T) {
+	var reqs atomic.Int32
+	whEE := newTestWebhookEE(t, "TestWebhookEEExportWithAttempts", 3, &config.WebhookOpts{
+		Backoff:          utils.DurationPointer(time.Millisecond),
+		BreakerThreshold: utils.IntPointer(0),
+	}, statusHandler(&reqs, http.StatusInternalServerError))
+	if err := ExportWithAttempts(whEE, &HTTPPosterRequest{Header: make(http.Header), Body: []byte("{}")},
+		utils.EmptyString); err == nil {
+		t.Error("expected error")
+	}
+	if reqs.Load() != 3 {
+		t.Errorf("expected 3 requests, received %d", reqs.Load())
+	}
+
+	whEE.backoff = time.Minute
+	whEE.maxBackoff = time.Minute
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		whEE.Close()
+	}()
+	if err := whEE.ExportEvent(&HTTPPosterRequest{Header: make(http.Header), Body: []byte("{}")},
+		utils.EmptyString); err != utils.ErrDisconnected {
+		t.Errorf("expected %v, received %v", utils.ErrDisconnected, err)
+	}
+}
@@ -53,6 +53,8 @@ type Account struct {
 	ActionTriggers    ActionTriggers
 	AllowNegative     bool
 	Disabled          bool
+	Reservations      map[string]*Reservation // amounts held on balances, indexed by reservation ID
+	UsageCounters     UsageCounters           // usage rated on the tiered rates, indexed by counter ID
 	UpdateTime        time.Time
 	executingTriggers bool
 }
@@ -391,6 +393,7 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 	usefulMoneyBalances := acc.getAlldBalancesForPrefix(cd.Destination, cd.Category, utils.MetaMonetary, cd.TimeStart)
 	// intiValues map[UUID]float64 and pass them to publish updating initial value
 	initUnitBal, initMoneyBal := balancesValues(usefulUnitBalances), balancesValues(usefulMoneyBalances)
+	cd.setUsageCounters(acc.UsageCounters)
 
 	var leftCC *CallCost
 	cc = cd.CreateCallCost()
@@ -574,6 +577,7 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 
 COMMIT:
 	if !dryRun {
+		acc.countUsage(cc.Timespans, time.Now())
 		// save darty shared balances
 		usefulMoneyBalances.SaveDirtyBalances(acc, initMoneyBal)
 		usefulUnitBalances.SaveDirtyBalances(acc, initUnitBal)
@@ -832,6 +836,7 @@ func (acc *Account) Clone() *Account {
 		UnitCounters:  acc.UnitCounters.Clone(),
 		AllowNegative: acc.AllowNegative,
 		Disabled:      acc.Disabled,
+		UsageCounters: acc.UsageCounters.Clone(),
 	}
 	if acc.BalanceMap != nil {
 		newAcc.BalanceMap = make(map[string]Balances, len(acc.BalanceMap))
@@ -845,6 +850,12 @@ func (acc *Account) Clone() *Account {
 			newAcc.ActionTriggers[key] = actionTrigger.Clone()
 		}
 	}
+	if acc.Reservations != nil {
+		newAcc.Reservations = make(map[string]*Reservation, len(acc.Reservations))
+		for rsvID, rsv := range acc.Reservations {
+			newAcc.Reservations[rsvID] = rsv.Clone()
+		}
+	}
 	return newAcc
 }
 
@@ -1426,6 +1437,18 @@ func (acc *Account) FieldAsInterface(fldPath []string) (val any, err error) {
 			}
 		}
 		return nil, utils.ErrNotFound
+	case utils.UsageCounters:
+		if len(fldPath) == 1 {
+			return acc.UsageCounters, nil
+		}
+		uc, has := acc.UsageCounters[fldPath[1]]
+		if !has {
+			return nil, utils.ErrNotFound
+		}
+		if len(fldPath) == 2 {
+			return uc, nil
+		}
+		return uc.FieldAsInterface(fldPath[2:])
 	case utils.AllowNegative:
 		if len(fldPath) != 1 {
 			return nil, utils.ErrNotFound
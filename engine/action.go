@@ -169,6 +169,7 @@ var accountActions = map[string]bool{
 	utils.MetaDebitReset:              true,
 	utils.MetaTransferBalance:         true,
 	utils.MetaResetCounters:           true,
+	utils.MetaResetUsageCounters:      true,
 	utils.MetaEnableAccount:           true,
 	utils.MetaDisableAccount:          true,
 	utils.MetaTransferMonetaryDefault: true,
@@ -178,6 +179,7 @@ var accountActions = map[string]bool{
 	utils.TopUpZeroNegative:           true,
 	utils.MetaSetBalance:              true,
 	utils.MetaRemoveBalance:           true,
+	utils.MetaGenerateInvoice:         true,
 }
 
 func init() {
@@ -195,6 +197,7 @@ func init() {
 	actionFuncMap[utils.MetaDebit] = debitAction
 	actionFuncMap[utils.MetaTransferBalance] = transferBalanceAction
 	actionFuncMap[utils.MetaResetCounters] = resetCountersAction
+	actionFuncMap[utils.MetaResetUsageCounters] = resetUsageCountersAction
 	actionFuncMap[utils.MetaEnableAccount] = enableAccountAction
 	actionFuncMap[utils.MetaDisableAccount] = disableAccountAction
 	actionFuncMap[utils.MetaMailAsync] = mailAsync
@@ -215,6 +218,7 @@ func init() {
 	actionFuncMap[utils.MetaExport] = export
 	actionFuncMap[utils.MetaResetThreshold] = resetThreshold
 	actionFuncMap[utils.MetaResetStatQueue] = resetStatQueue
+	actionFuncMap[utils.MetaGenerateInvoice] = generateInvoice
 	actionFuncMap[utils.MetaRemoteSetAccount] = remoteSetAccount
 	actionFuncMap[utils.MetaDynamicThreshold] = dynamicThreshold
 	actionFuncMap[utils.MetaDynamicStats] = dynamicStats
@@ -1457,6 +1461,31 @@ func resetStatQueue(_ *Account, a *Action, _ Actions, _ *FilterS, _ any, _ Share
 		utils.StatSv1ResetStatQueue, args, &rply)
 }
 
+// generateInvoice asks InvoiceS to issue the invoice of the account. The ExtraParameters
+// may hold the duration of the period ending now, otherwise the previous month is invoiced
+func generateInvoice(ub *Account, a *Action, _ Actions, _ *FilterS, _ any, _ SharedActionsData, _ ActionConnCfg) (err error) {
+	if ub == nil {
+		return errors.New("nil account")
+	}
+	tntAcnt := utils.NewTenantID(ub.ID)
+	args := &utils.ArgsGenerateInvoice{
+		Tenant:  tntAcnt.Tenant,
+		Account: tntAcnt.ID,
+	}
+	if a.ExtraParameters != utils.EmptyString {
+		var period time.Duration
+		if period, err = utils.ParseDurationWithNanosecs(a.ExtraParameters); err != nil {
+			return
+		}
+		now := time.Now()
+		args.PeriodStart = now.Add(-period).Format(time.RFC3339)
+		args.PeriodEnd = now.Format(time.RFC3339)
+	}
+	var rply Invoice
+	return connMgr.Call(context.TODO(), config.CgrConfig().SchedulerCfg().InvoiceSConns,
+		utils.InvoiceSv1GenerateInvoice, args, &rply)
+}
+
 func remoteSetAccount(ub *Account, a *Action, _ Actions, _ *FilterS, _ any, _ SharedActionsData, _ ActionConnCfg) (err error) {
 	client := &http.Client{Transport: httpPstrTransport}
 	var resp *http.Response
@@ -237,14 +237,13 @@ func (at *ActionTiming) Execute(fltrS *FilterS, originService string) (err error
 	}
 	var partialyExecuted bool
 	for accID := range at.accountIDs {
-		_ = guardian.Guardian.Guard(func() error {
+		if errGuard := guardian.Guardian.Guard(func() error {
 			acc, err := dm.GetAccount(accID)
 			if err != nil { // create account
 				if err != utils.ErrNotFound {
 					utils.Logger.Warning(fmt.Sprintf("Could not get account id: %s. Skipping!", accID))
-					return err
+					return nil
 				}
-				err = nil
 				acc = &Account{
 					ID: accID,
 				}
@@ -257,7 +256,9 @@ func (at *ActionTiming) Execute(fltrS *FilterS, originService string) (err error
 				if len(act.Filters) > 0 {
 					if pass, err := fltrS.Pass(utils.NewTenantID(accID).Tenant, act.Filters,
 						utils.MapStorage{utils.MetaReq: acc}); err != nil {
-						return err
+						utils.Logger.Warning(fmt.Sprintf("Could not check the filters of action %s on account id: %s, error: %v. Skipping!",
+							act.Id, accID, err))
+						return nil
 					} else if !pass {
 						continue
 					}
@@ -302,7 +303,12 @@ func (at *ActionTiming) Execute(fltrS *FilterS, originService string) (err error
 				dm.SetAccount(acc)
 			}
 			return nil
-		}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+accID)
+		}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+accID); errGuard != nil {
+			utils.Logger.Err(
+				fmt.Sprintf("Error executing actions %s on account %s: %v!",
+					at.ActionsID, accID, errGuard))
+			partialyExecuted = true
+		}
 	}
 	//reset the error in case that the account is not found
 	err = nil
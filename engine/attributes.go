@@ -208,6 +208,10 @@ func (alS *AttributeService) processEvent(tnt string, args *utils.CGREvent, evNm
 		}
 		var out any
 		if out, err = ParseAttribute(dynDP, utils.FirstNonEmpty(attribute.Type, utils.MetaVariable), utils.DynamicDataPrefix+attribute.Path, attribute.Value, alS.cgrcfg.GeneralCfg().RoundingDecimals, alS.cgrcfg.GeneralCfg().DefaultTimezone, time.RFC3339, alS.cgrcfg.GeneralCfg().RSRSep); err != nil {
+			if err == utils.ErrNotFound && attribute.Type == utils.MetaDataSet {
+				err = nil // value not in the data set, leave the field untouched
+				continue
+			}
 			rply = nil
 			return
 		}
@@ -561,6 +565,41 @@ func ParseAttribute(dp utils.DataProvider, attrType, path string, value config.R
 
 		sort.Strings(values[1:])
 		out = strings.Join(values, utils.InfieldSep)
+	case utils.MetaDataSet: // DataSetID;~*req.Field[;MetadataKey]
+		if len(value) != 2 && len(value) != 3 {
+			return nil, fmt.Errorf("invalid number of arguments <%s> to %s",
+				utils.ToJSON(value), utils.MetaDataSet)
+		}
+		var dsID, fldVal string
+		if dsID, err = value[0].ParseDataProvider(dp); err != nil {
+			return
+		}
+		if fldVal, err = value[1].ParseDataProvider(dp); err != nil {
+			return
+		}
+		var ds *DataSet
+		if ds, err = dm.GetDataSet(dataSetTenant(dp), dsID, true, true, utils.NonTransactional); err != nil {
+			if err == utils.ErrNotFound {
+				err = fmt.Errorf("data set <%s> not found for %s", dsID, utils.MetaDataSet)
+			}
+			return
+		}
+		ent, has := ds.Match(fldVal)
+		if !has {
+			return nil, utils.ErrNotFound
+		}
+		if len(value) == 2 { // no metadata key, return the matched entry
+			return ent.Value, nil
+		}
+		var mdKey string
+		if mdKey, err = value[2].ParseDataProvider(dp); err != nil {
+			return
+		}
+		var mdVal string
+		if mdVal, has = ent.Metadata[mdKey]; !has {
+			return nil, utils.ErrNotFound
+		}
+		out = mdVal
 	default:
 		if strings.HasPrefix(attrType, utils.MetaHTTP) {
 			out, err = externalAttributeAPI(attrType, dp)
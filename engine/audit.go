@@ -0,0 +1,230 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// AuditRecord is one change of a dataDB profile kept on the audit trail
+type AuditRecord struct {
+	ObjectType string // the dataDB item, ie. *attribute_profiles
+	Tenant     string
+	ID         string // the full key for the rating profiles
+	Action     string // <*set|*remove>
+	Time       time.Time
+	Actor      string          // the caller which did the change, empty if not known
+	Method     string          // the API or the subsystem which did the change
+	Before     json.RawMessage // the profile before the change, empty if it did not exist
+	After      json.RawMessage // the profile after the change, empty if it was removed
+	Changes    []*AuditChange
+}
+
+// AuditChange is a field of the profile which changed
+type AuditChange struct {
+	Path   string
+	Before json.RawMessage
+	After  json.RawMessage
+}
+
+// AuditRecordWithAPIOpts is used in replicatorV1 for dispatcher
+type AuditRecordWithAPIOpts struct {
+	*AuditRecord
+	APIOpts map[string]any
+}
+
+// ObjectKey returns the key of the profile the record belongs to
+func (rec *AuditRecord) ObjectKey() string {
+	return utils.ConcatenatedKey(rec.ObjectType, rec.Tenant, rec.ID)
+}
+
+// CacheClone returns a clone of AuditRecord used by ltcache CacheCloner
+func (rec *AuditRecord) CacheClone() any {
+	return rec.Clone()
+}
+
+// Clone returns a deep copy of the record
+func (rec *AuditRecord) Clone() (cln *AuditRecord) {
+	if rec == nil {
+		return
+	}
+	cln = &AuditRecord{
+		ObjectType: rec.ObjectType,
+		Tenant:     rec.Tenant,
+		ID:         rec.ID,
+		Action:     rec.Action,
+		Time:       rec.Time,
+		Actor:      rec.Actor,
+		Method:     rec.Method,
+		Before:     slices.Clone(rec.Before),
+		After:      slices.Clone(rec.After),
+	}
+	if rec.Changes != nil {
+		cln.Changes = make([]*AuditChange, len(rec.Changes))
+		for i, chng := range rec.Changes {
+			cln.Changes[i] = &AuditChange{
+				Path:   chng.Path,
+				Before: slices.Clone(chng.Before),
+				After:  slices.Clone(chng.After),
+			}
+		}
+	}
+	return
+}
+
+// AuditState returns the profile as it was at the given time, nil if it did not exist.
+// The records need to be sorted by time
+func AuditState(recs []*AuditRecord, at time.Time) json.RawMessage {
+	idx := sort.Search(len(recs), func(i int) bool { return recs[i].Time.After(at) })
+	if idx == 0 {
+		return recs[0].Before // changed for the first time after
+	}
+	return recs[idx-1].After
+}
+
+// SortAuditRecords sorts the records by the time of the change
+func SortAuditRecords(recs []*AuditRecord) {
+	slices.SortStableFunc(recs, func(a, b *AuditRecord) int {
+		return a.Time.Compare(b.Time)
+	})
+}
+
+// newAuditRecord returns the record of the change, nil values marking the missing profiles
+func newAuditRecord(objType, tnt, id string, before, after any) (rec *AuditRecord, err error) {
+	rec = &AuditRecord{
+		ObjectType: objType,
+		Tenant:     tnt,
+		ID:         id,
+		Action:     utils.MetaSet,
+		Time:       time.Now(),
+	}
+	if rec.Before, err = marshalAuditState(before); err != nil {
+		return
+	}
+	if rec.After, err = marshalAuditState(after); err != nil {
+		return
+	}
+	if rec.After == nil {
+		rec.Action = utils.MetaRemove
+	}
+	var beforeVal, afterVal any
+	if rec.Before != nil {
+		if err = json.Unmarshal(rec.Before, &beforeVal); err != nil {
+			return
+		}
+	}
+	if rec.After != nil {
+		if err = json.Unmarshal(rec.After, &afterVal); err != nil {
+			return
+		}
+	}
+	for _, diff := range utils.DiffJSONValues(beforeVal, afterVal) {
+		change := &AuditChange{Path: diff.Path}
+		if change.Before, err = marshalAuditState(diff.Before); err != nil {
+			return
+		}
+		if change.After, err = marshalAuditState(diff.After); err != nil {
+			return
+		}
+		rec.Changes = append(rec.Changes, change)
+	}
+	return
+}
+
+// ratingProfileTenant extracts the tenant out of the rating profile key( ie. *out:cgrates.org:call:1001)
+func ratingProfileTenant(key string) string {
+	if splt := strings.SplitN(key, utils.ConcatenatedKeySep, 3); len(splt) > 1 {
+		return splt[1]
+	}
+	return utils.EmptyString
+}
+
+// marshalAuditState returns the JSON of the profile, nil for the missing ones
+func marshalAuditState(prf any) (json.RawMessage, error) {
+	b, err := json.Marshal(prf)
+	if err != nil || bytes.Equal(b, []byte("null")) {
+		return nil, err
+	}
+	return b, nil
+}
+
+// WithAudit returns a DataManager recording the actor and the method on the changes
+// written to the audit trail
+func (dm *DataManager) WithAudit(actor, method string) *DataManager {
+	if dm == nil {
+		return nil
+	}
+	adm := *dm
+	adm.auditActor, adm.auditMethod = actor, method
+	return &adm
+}
+
+// auditChange records the change of the profile if the audit is enabled for its type
+func (dm *DataManager) auditChange(objType, tnt, id string, before, after any) (err error) {
+	if !config.CgrConfig().AuditCfg().Audited(objType) {
+		return
+	}
+	var rec *AuditRecord
+	if rec, err = newAuditRecord(objType, tnt, id, before, after); err != nil {
+		return
+	}
+	rec.Actor, rec.Method = dm.auditActor, dm.auditMethod
+	return dm.SetAuditRecord(rec)
+}
+
+// GetAuditRecords returns the changes of the profile, sorted by their time
+func (dm *DataManager) GetAuditRecords(objType, tenant, id string) (recs []*AuditRecord, err error) {
+	if dm == nil {
+		return nil, utils.ErrNoDatabaseConn
+	}
+	if recs, err = dm.dataDB.GetAuditRecordsDrv(objType, tenant, id); err != nil {
+		return
+	}
+	if len(recs) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	SortAuditRecords(recs)
+	return
+}
+
+// SetAuditRecord appends the record to the audit trail of its profile
+func (dm *DataManager) SetAuditRecord(rec *AuditRecord) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	if err = dm.dataDB.SetAuditRecordDrv(rec); err != nil {
+		return
+	}
+	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaAuditRecords]
+	return dm.replicator.replicate(
+		utils.AuditRecordPrefix, rec.ObjectKey(),
+		utils.ReplicatorSv1SetAuditRecord,
+		&AuditRecordWithAPIOpts{
+			AuditRecord: rec,
+			APIOpts: utils.GenerateDBItemOpts(itm.APIKey, itm.RouteID,
+				config.CgrConfig().DataDbCfg().RplCache, utils.EmptyString)}, itm)
+}
@@ -0,0 +1,184 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAuditNewRecord(t *testing.T) {
+	before := map[string]any{"ID": "ATTR1", "FilterIDs": []string{"*string:~*req.Account:1001"}, "Weight": 10}
+	after := map[string]any{"ID": "ATTR1", "FilterIDs": []string{"*string:~*req.Account:1002", "FLTR1"}, "Weight": 10}
+	rec, err := newAuditRecord(utils.MetaAttributeProfiles, "cgrates.org", "ATTR1", before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Action != utils.MetaSet {
+		t.Errorf("expected %s, received %s", utils.MetaSet, rec.Action)
+	}
+	exp := []*AuditChange{
+		{Path: "FilterIDs[0]", Before: json.RawMessage(`"*string:~*req.Account:1001"`), After: json.RawMessage(`"*string:~*req.Account:1002"`)},
+		{Path: "FilterIDs[1]", After: json.RawMessage(`"FLTR1"`)},
+	}
+	if !reflect.DeepEqual(exp, rec.Changes) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rec.Changes))
+	}
+
+	if rec, err = newAuditRecord(utils.MetaAttributeProfiles, "cgrates.org", "ATTR1", before, nil); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Action != utils.MetaRemove || rec.After != nil {
+		t.Errorf("expected a removal, received %s", utils.ToJSON(rec))
+	}
+	if len(rec.Changes) != 1 || rec.Changes[0].Path != utils.EmptyString ||
+		rec.Changes[0].After != nil {
+		t.Errorf("expected the whole profile removed, received %s", utils.ToJSON(rec.Changes))
+	}
+}
+
+func TestAuditState(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	recs := []*AuditRecord{
+		{Time: t0, After: json.RawMessage(`{"Weight":10}`)},
+		{Time: t0.Add(time.Hour), Before: json.RawMessage(`{"Weight":10}`), After: json.RawMessage(`{"Weight":20}`)},
+		{Time: t0.Add(2 * time.Hour), Before: json.RawMessage(`{"Weight":20}`)},
+	}
+	for at, exp := range map[time.Time]json.RawMessage{
+		t0.Add(-time.Minute):                nil,
+		t0:                                  json.RawMessage(`{"Weight":10}`),
+		t0.Add(90 * time.Minute):            json.RawMessage(`{"Weight":20}`),
+		t0.Add(3 * time.Hour):               nil,
+		t0.Add(time.Hour - time.Nanosecond): json.RawMessage(`{"Weight":10}`),
+	} {
+		if rcv := AuditState(recs, at); !reflect.DeepEqual(exp, rcv) {
+			t.Errorf("at %v expected %s, received %s", at, exp, rcv)
+		}
+	}
+}
+
+func TestAuditDataManager(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AuditCfg().Enabled = true
+	config.SetCgrConfig(cfg)
+	defer config.SetCgrConfig(config.NewDefaultCGRConfig())
+	dataDB, err := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm := NewDataManager(dataDB, cfg.CacheCfg(), nil)
+	if _, err = dm.GetAuditRecords(utils.MetaFilters, "cgrates.org", "FLTR1"); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	fltr := &Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR1",
+		Rules:  []*FilterRule{{Type: utils.MetaString, Element: "~*req.Account", Values: []string{"1001"}}},
+	}
+	adm := dm.WithAudit("admin", utils.APIerSv1SetFilter)
+	if err = adm.SetFilter(fltr, true); err != nil {
+		t.Fatal(err)
+	}
+	fltr2 := fltr.Clone()
+	fltr2.Rules[0].Values = []string{"1002"}
+	if err = adm.SetFilter(fltr2, true); err != nil {
+		t.Fatal(err)
+	}
+	if err = dm.WithAudit("loader1", utils.LoaderS).RemoveFilter("cgrates.org", "FLTR1", true); err != nil {
+		t.Fatal(err)
+	}
+	recs, err := dm.GetAuditRecords(utils.MetaFilters, "cgrates.org", "FLTR1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 3 {
+		t.Fatalf("expected 3 records, received %s", utils.ToJSON(recs))
+	}
+	for i, exp := range []struct{ action, actor, method string }{
+		{utils.MetaSet, "admin", utils.APIerSv1SetFilter},
+		{utils.MetaSet, "admin", utils.APIerSv1SetFilter},
+		{utils.MetaRemove, "loader1", utils.LoaderS},
+	} {
+		if recs[i].Action != exp.action || recs[i].Actor != exp.actor || recs[i].Method != exp.method {
+			t.Errorf("record %d: expected %+v, received %s", i, exp, utils.ToJSON(recs[i]))
+		}
+	}
+	expChng := []*AuditChange{{Path: "Rules[0].Values[0]",
+		Before: json.RawMessage(`"1001"`), After: json.RawMessage(`"1002"`)}}
+	if !reflect.DeepEqual(expChng, recs[1].Changes) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expChng), utils.ToJSON(recs[1].Changes))
+	}
+	var rcv Filter
+	if err = json.Unmarshal(AuditState(recs, recs[0].Time), &rcv); err != nil {
+		t.Fatal(err)
+	}
+	if rcv.Rules[0].Values[0] != "1001" {
+		t.Errorf("expected the first version, received %s", utils.ToJSON(rcv))
+	}
+
+	cfg.AuditCfg().ObjectTypes = utils.NewStringSet([]string{utils.MetaAttributeProfiles})
+	if err = dm.SetFilter(fltr, true); err != nil {
+		t.Fatal(err)
+	}
+	if recs, err = dm.GetAuditRecords(utils.MetaFilters, "cgrates.org", "FLTR1"); err != nil {
+		t.Fatal(err)
+	} else if len(recs) != 3 {
+		t.Errorf("expected the filters not audited, received %s", utils.ToJSON(recs))
+	}
+}
+
+func TestAuditDataManagerSkipOldItem(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	config.SetCgrConfig(cfg)
+	defer config.SetCgrConfig(config.NewDefaultCGRConfig())
+	errGet := errors.New("old item read")
+	dm := NewDataManager(&DataDBMock{
+		GetFilterDrvF: func(string, string) (*Filter, error) { return nil, errGet },
+		GetRouteProfileDrvF: func(string, string) (*RouteProfile, error) {
+			return nil, errGet
+		},
+	}, cfg.CacheCfg(), nil)
+	fltr := &Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_AUDIT_SKIP",
+		Rules:  []*FilterRule{{Type: utils.MetaString, Element: "~*req.Account", Values: []string{"1001"}}},
+	}
+	rpp := &RouteProfile{Tenant: "cgrates.org", ID: "ROUTE_AUDIT_SKIP"}
+	// with the audit disabled and no indexes the old items are not read, only the writes are done
+	if err := dm.SetFilter(fltr, false); err != utils.ErrNotImplemented {
+		t.Errorf("expected %v, received %v", utils.ErrNotImplemented, err)
+	}
+	if err := dm.SetRouteProfile(rpp, false); err != utils.ErrNotImplemented {
+		t.Errorf("expected %v, received %v", utils.ErrNotImplemented, err)
+	}
+
+	cfg.AuditCfg().Enabled = true
+	if err := dm.SetFilter(fltr, false); err != errGet {
+		t.Errorf("expected %v, received %v", errGet, err)
+	}
+	if err := dm.SetRouteProfile(rpp, false); err != errGet {
+		t.Errorf("expected %v, received %v", errGet, err)
+	}
+}
@@ -73,6 +73,14 @@ func init() {
 	gob.Register(new(RankingProfile))
 	gob.Register(new(RankingProfileWithAPIOpts))
 	gob.Register(new(utils.TPRankingProfile))
+	// DataSets
+	gob.Register(new(DataSet))
+	gob.Register(new(DataSetEntry))
+	gob.Register(new(DataSetWithAPIOpts))
+	// Taxes
+	gob.Register(new(TaxProfile))
+	gob.Register(new(TaxProfileWithAPIOpts))
+	gob.Register(new(utils.TPTaxProfile))
 	// RouteS
 	gob.Register(new(RouteProfile))
 	gob.Register(new(RouteProfileWithAPIOpts))
@@ -171,6 +179,12 @@ func init() {
 	gob.Register(new(TrendProfileWithAPIOpts))
 	gob.Register(new(utils.TPTrendsProfile))
 
+	gob.Register(new(Invoice))
+	gob.Register(new(InvoiceWithAPIOpts))
+
+	gob.Register(new(AuditRecord))
+	gob.Register(new(AuditRecordWithAPIOpts))
+
 	gob.Register(new(SharedGroup))
 	gob.Register(new(SharedGroupWithAPIOpts))
 	gob.Register(new(utils.TPSharedGroups))
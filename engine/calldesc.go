@@ -135,8 +135,11 @@ type CallDescriptor struct {
 	PerformRounding     bool // flag for rating info rounding
 	DenyNegativeAccount bool // prevent account going on negative during debit
 	account             *Account
-	testCallcost        *CallCost // testing purpose only!
+	usageCounters       UsageCounters // account usage counters selecting the rate tiers
+	usageStart          time.Duration // call duration before this request, not yet on the usage counters
+	testCallcost        *CallCost     // testing purpose only!
 	DryRun              bool
+	ratingDB            DataDB // when set, rating data is read from it instead of DataManager, ie. for rating simulations
 }
 
 // AsCGREvent converts the CallDescriptor into CGREvent
@@ -231,7 +234,9 @@ func (cd *CallDescriptor) AddRatingInfo(ris ...*RatingInfo) {
 // Gets and caches the user balance information.
 func (cd *CallDescriptor) getAccount() (ub *Account, err error) {
 	if cd.account == nil {
-		cd.account, err = dm.GetAccount(cd.GetAccountKey())
+		if cd.account, err = dm.GetAccount(cd.GetAccountKey()); err == nil && cd.account != nil {
+			cd.account.releaseExpiredReservations(time.Now()) // saved together with the debit
+		}
 	}
 	if cd.account != nil && cd.account.Disabled {
 		return nil, utils.ErrAccountDisabled
@@ -273,7 +278,7 @@ func (cd *CallDescriptor) getRatingPlansForPrefix(key string, recursionDepth int
 	if recursionDepth > config.CgrConfig().RalsCfg().FallbackDepth {
 		return recursionDepth, utils.ErrMaxRecursionDepth
 	}
-	rpf, err := RatingProfileSubjectPrefixMatching(key)
+	rpf, err := ratingProfileSubjectPrefixMatching(key, cd.getRatingProfile)
 	if err != nil || rpf == nil {
 		return recursionDepth, utils.ErrNotFound
 	}
@@ -291,6 +296,7 @@ func (cd *CallDescriptor) getRatingPlansForPrefix(key string, recursionDepth int
 					Category:    cd.Category,
 					Tenant:      cd.Tenant,
 					Destination: cd.Destination,
+					ratingDB:    cd.ratingDB,
 				}
 				if index == 0 {
 					tempCD.TimeStart = cd.TimeStart
@@ -334,6 +340,30 @@ func (cd *CallDescriptor) getRatingPlansForPrefix(key string, recursionDepth int
 	return recursionDepth, nil
 }
 
+// getRatingProfile returns the RatingProfile with the given key
+func (cd *CallDescriptor) getRatingProfile(key string) (*RatingProfile, error) {
+	if cd.ratingDB != nil {
+		return cd.ratingDB.GetRatingProfileDrv(key)
+	}
+	return dm.GetRatingProfile(key, false, utils.NonTransactional)
+}
+
+// getRatingPlan returns the RatingPlan with the given ID
+func (cd *CallDescriptor) getRatingPlan(id string) (*RatingPlan, error) {
+	if cd.ratingDB != nil {
+		return cd.ratingDB.GetRatingPlanDrv(id)
+	}
+	return dm.GetRatingPlan(id, false, utils.NonTransactional)
+}
+
+// getReverseDestination returns the IDs of the destinations containing the prefix
+func (cd *CallDescriptor) getReverseDestination(prefix string) ([]string, error) {
+	if cd.ratingDB != nil {
+		return cd.ratingDB.GetReverseDestinationDrv(prefix, utils.NonTransactional)
+	}
+	return dm.GetReverseDestination(prefix, true, true, utils.NonTransactional)
+}
+
 // checks if there is rating info for the entire call duration
 func (cd *CallDescriptor) continousRatingInfos() bool {
 	if len(cd.RatingInfos) == 0 || cd.RatingInfos[0].ActivationTime.After(cd.TimeStart) {
@@ -509,6 +539,37 @@ func (cd *CallDescriptor) GetDuration() time.Duration {
 	return cd.TimeEnd.Sub(cd.TimeStart)
 }
 
+// setUsageCounters fixes the usage counters and the start of the request on them,
+// the clones created while debiting the request being positioned relative to it
+func (cd *CallDescriptor) setUsageCounters(ucs UsageCounters) {
+	if ucs == nil {
+		ucs = make(UsageCounters)
+	}
+	cd.usageCounters = ucs
+	cd.usageStart = max(cd.DurationIndex-cd.GetDuration(), 0)
+}
+
+// setUsageOffsets positions the tiered rate intervals on the account usage counters
+func (cd *CallDescriptor) setUsageOffsets() {
+	for _, ri := range cd.RatingInfos {
+		for _, rIl := range ri.RateIntervals {
+			if rIl.Rating == nil || rIl.Rating.UsageCounter == utils.EmptyString {
+				continue
+			}
+			if cd.usageCounters == nil {
+				var ucs UsageCounters
+				if cd.ratingDB == nil { // no accounts on rating simulations
+					if acc, err := dm.GetAccount(cd.GetAccountKey()); err == nil {
+						ucs = acc.UsageCounters
+					}
+				}
+				cd.setUsageCounters(ucs)
+			}
+			rIl.UsageOffset = cd.usageCounters.Usage(rIl.Rating.UsageCounter) - cd.usageStart
+		}
+	}
+}
+
 /*
 Creates a CallCost structure with the cost information calculated for the received CallDescriptor.
 */
@@ -585,6 +646,7 @@ func (cd *CallDescriptor) getCost() (*CallCost, error) {
 	if err != nil {
 		return &CallCost{Cost: -1}, err
 	}
+	cd.setUsageOffsets()
 	timespans := cd.splitInTimeSpans()
 	cost := 0.0
 
@@ -909,7 +971,7 @@ func (cd *CallDescriptor) RefundIncrements(fltrS *FilterS) (acnt *Account, err e
 			accMap[utils.AccountPrefix+increment.BalanceInfo.AccountID] = true
 		}
 	}
-	guardian.Guardian.Guard(func() (_ error) {
+	err = guardian.Guardian.Guard(func() (err error) {
 		acnt, err = cd.refundIncrements(fltrS)
 		return
 	}, config.CgrConfig().GeneralCfg().LockingTimeout, accMap.Slice()...)
@@ -956,7 +1018,7 @@ func (cd *CallDescriptor) RefundRounding(fltrS *FilterS) (acc *Account, err erro
 	for _, inc := range cd.Increments {
 		accMap[utils.AccountPrefix+inc.BalanceInfo.AccountID] = true
 	}
-	guardian.Guardian.Guard(func() (_ error) {
+	err = guardian.Guardian.Guard(func() (err error) {
 		var accCache map[string]*Account
 		if accCache, err = cd.refundRounding(nil, fltrS); err != nil {
 			return
@@ -1000,6 +1062,9 @@ func (cd *CallDescriptor) Clone() *CallDescriptor {
 		PerformRounding: cd.PerformRounding,
 		CgrID:           cd.CgrID,
 		RunID:           cd.RunID,
+		usageCounters:   cd.usageCounters,
+		usageStart:      cd.usageStart,
+		ratingDB:        cd.ratingDB,
 	}
 	if cd.ExtraFields != nil {
 		cln.ExtraFields = make(map[string]string, len(cd.ExtraFields))
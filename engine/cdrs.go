@@ -450,6 +450,7 @@ type cdrProcessingArgs struct {
 	thdS      bool
 	stS       bool
 	reprocess bool
+	taxS      bool
 }
 
 // newCDRProcessingArgs initializes processing arguments from config and overrides them with provided flags.
@@ -462,6 +463,7 @@ func newCDRProcessingArgs(cfg *config.CdrsCfg, flags utils.FlagsWithParams, opts
 		thdS:   len(cfg.ThresholdSConns) != 0,
 		stS:    len(cfg.StatSConns) != 0,
 		ralS:   len(cfg.RaterConns) != 0,
+		taxS:   cfg.ApplyTaxes,
 	}
 	var err error
 	if v, has := opts[utils.OptsAttributeS]; has {
@@ -539,6 +541,14 @@ func newCDRProcessingArgs(cfg *config.CdrsCfg, flags utils.FlagsWithParams, opts
 	if flags.Has(utils.MetaRALs) {
 		args.ralS = flags.GetBool(utils.MetaRALs)
 	}
+	if v, has := opts[utils.OptsTaxS]; has {
+		if args.taxS, err = utils.IfaceAsBool(v); err != nil {
+			return nil, err
+		}
+	}
+	if flags.Has(utils.MetaTaxes) {
+		args.taxS = flags.GetBool(utils.MetaTaxes)
+	}
 	return args, nil
 }
 
@@ -621,6 +631,14 @@ func newCDRProcessingArgsNoCfg(flags utils.FlagsWithParams, opts map[string]any)
 	if flags.Has(utils.MetaRALs) {
 		args.ralS = flags.GetBool(utils.MetaRALs)
 	}
+	if v, has := opts[utils.OptsTaxS]; has {
+		if args.taxS, err = utils.IfaceAsBool(v); err != nil {
+			return nil, err
+		}
+	}
+	if flags.Has(utils.MetaTaxes) {
+		args.taxS = flags.GetBool(utils.MetaTaxes)
+	}
 	return args, nil
 }
 
@@ -683,7 +701,7 @@ func (cdrS *CDRServer) processEvents(evs []*utils.CGREvent, args *cdrProcessingA
 	}
 	// Populate CDR list out of events
 	cdrs := make([]*CDR, len(cgrEvs))
-	if args.refund || args.ralS || args.store || args.reRate || args.export {
+	if args.refund || args.ralS || args.store || args.reRate || args.export || args.taxS {
 		for i, cgrEv := range cgrEvs {
 			if args.refund {
 				if _, has := cgrEv.Event[utils.CostDetails]; !has {
@@ -761,6 +779,20 @@ func (cdrS *CDRServer) processEvents(evs []*utils.CGREvent, args *cdrProcessingA
 			}
 		}
 	}
+	if args.taxS {
+		for i, cdr := range cdrs {
+			if applied, errTax := cdrS.applyTaxes(cdr, cgrEvs[i]); errTax != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: <%s> applying taxes on CDR %+v",
+						utils.CDRs, errTax.Error(), utils.ToJSON(cdr)))
+			} else if applied {
+				cgrEv := cdr.AsCGREvent()
+				cgrEv.APIOpts = cgrEvs[i].APIOpts
+				cgrEvs[i] = cgrEv
+				procFlgs[i].Add(utils.MetaTaxes)
+			}
+		}
+	}
 	if args.store {
 		refundCDRCosts := func() { // will be used to refund all CDRs on errors
 			for _, cdr := range cdrs { // refund what we have charged since duplicates are not allowed
@@ -846,8 +878,12 @@ func (cdrS *CDRServer) V1ProcessCDR(ctx *context.Context, cdr *CDRWithAPIOpts, r
 	// RPC caching
 	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.CDRsV1ProcessCDR, cdr.CGRID, cdr.RunID)
-		refID := guardian.Guardian.GuardIDs("",
-			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
 			cachedResp := itm.(*utils.CachedRPCResponse)
@@ -939,8 +975,12 @@ func (cdrS *CDRServer) V1ProcessEvent(ctx *context.Context, arg *ArgV1ProcessEve
 	// RPC caching
 	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.CDRsV1ProcessEvent, arg.CGREvent.ID)
-		refID := guardian.Guardian.GuardIDs("",
-			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 
 		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
@@ -978,8 +1018,12 @@ func (cdrS *CDRServer) V2ProcessEvent(ctx *context.Context, arg *ArgV1ProcessEve
 	// RPC caching
 	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.CDRsV2ProcessEvent, arg.CGREvent.ID)
-		refID := guardian.Guardian.GuardIDs("",
-			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 
 		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
@@ -1087,8 +1131,12 @@ func (cdrS *CDRServer) V1StoreSessionCost(ctx *context.Context, attr *AttrCDRSSt
 	// RPC caching
 	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.CDRsV1StoreSessionCost, attr.Cost.CGRID, attr.Cost.RunID)
-		refID := guardian.Guardian.GuardIDs("",
-			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
 			cachedResp := itm.(*utils.CachedRPCResponse)
@@ -1120,8 +1168,12 @@ func (cdrS *CDRServer) V2StoreSessionCost(ctx *context.Context, args *ArgsV2CDRS
 	// RPC caching
 	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.CDRsV1StoreSessionCost, args.Cost.CGRID, args.Cost.RunID)
-		refID := guardian.Guardian.GuardIDs("",
-			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 
 		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
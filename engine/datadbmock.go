@@ -45,6 +45,14 @@ type DataDBMock struct {
 	SetRankingProfileDrvF     func(sq *RankingProfile) (err error)
 	GetRankingProfileDrvF     func(tenant string, id string) (sq *RankingProfile, err error)
 	RemRankingProfileDrvF     func(tenant string, id string) (err error)
+	GetDataSetDrvF            func(tenant, id string) (ds *DataSet, err error)
+	SetDataSetDrvF            func(ds *DataSet) (err error)
+	AddDataSetEntriesDrvF     func(tenant, id string, ents []*DataSetEntry) (err error)
+	RemoveDataSetEntriesDrvF  func(tenant, id string, ents []*DataSetEntry) (err error)
+	RemoveDataSetDrvF         func(tenant, id string) (err error)
+	GetTaxProfileDrvF         func(tenant, id string) (tp *TaxProfile, err error)
+	SetTaxProfileDrvF         func(tp *TaxProfile) (err error)
+	RemoveTaxProfileDrvF      func(tenant, id string) (err error)
 	SetTrendProfileDrvF       func(sq *TrendProfile) (err error)
 	GetTrendProfileDrvF       func(tenant string, id string) (sq *TrendProfile, err error)
 	RemTrendProfileDrvF       func(tenant string, id string) (err error)
@@ -396,6 +404,62 @@ func (dbM *DataDBMock) RemRankingProfileDrv(tenant string, id string) (err error
 	}
 	return utils.ErrNotImplemented
 }
+
+func (dbM *DataDBMock) GetDataSetDrv(tenant, id string) (ds *DataSet, err error) {
+	if dbM.GetDataSetDrvF != nil {
+		return dbM.GetDataSetDrvF(tenant, id)
+	}
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetDataSetDrv(ds *DataSet) (err error) {
+	if dbM.SetDataSetDrvF != nil {
+		return dbM.SetDataSetDrvF(ds)
+	}
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) AddDataSetEntriesDrv(tenant, id string, ents []*DataSetEntry) (err error) {
+	if dbM.AddDataSetEntriesDrvF != nil {
+		return dbM.AddDataSetEntriesDrvF(tenant, id, ents)
+	}
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveDataSetEntriesDrv(tenant, id string, ents []*DataSetEntry) (err error) {
+	if dbM.RemoveDataSetEntriesDrvF != nil {
+		return dbM.RemoveDataSetEntriesDrvF(tenant, id, ents)
+	}
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveDataSetDrv(tenant, id string) (err error) {
+	if dbM.RemoveDataSetDrvF != nil {
+		return dbM.RemoveDataSetDrvF(tenant, id)
+	}
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetTaxProfileDrv(tenant, id string) (tp *TaxProfile, err error) {
+	if dbM.GetTaxProfileDrvF != nil {
+		return dbM.GetTaxProfileDrvF(tenant, id)
+	}
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetTaxProfileDrv(tp *TaxProfile) (err error) {
+	if dbM.SetTaxProfileDrvF != nil {
+		return dbM.SetTaxProfileDrvF(tp)
+	}
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveTaxProfileDrv(tenant, id string) (err error) {
+	if dbM.RemoveTaxProfileDrvF != nil {
+		return dbM.RemoveTaxProfileDrvF(tenant, id)
+	}
+	return utils.ErrNotImplemented
+}
 func (dbM *DataDBMock) GetTrendProfileDrv(tenant, id string) (sg *TrendProfile, err error) {
 	if dbM.GetStatQueueProfileDrvF != nil {
 		return dbM.GetTrendProfileDrvF(tenant, id)
@@ -606,6 +670,30 @@ func (dbM *DataDBMock) RemoveSessionsBackupDrv(nodeID, tnt, cgrid string) error
 	return utils.ErrNotImplemented
 }
 
+func (dbM *DataDBMock) GetInvoiceDrv(tnt, id string) (*Invoice, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetInvoicesDrv(tnt, acnt string) ([]*Invoice, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) CountInvoicesDrv(tnt string) (int, error) {
+	return 0, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetInvoiceDrv(inv *Invoice) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetAuditRecordsDrv(objType, tnt, id string) ([]*AuditRecord, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetAuditRecordDrv(rec *AuditRecord) error {
+	return utils.ErrNotImplemented
+}
+
 func (dbM *DataDBMock) DumpDataDB() error {
 	return utils.ErrNotImplemented
 }
@@ -40,6 +40,7 @@ var (
 		utils.RouteFilterIndexes:      {},
 		utils.ChargerFilterIndexes:    {},
 		utils.DispatcherFilterIndexes: {},
+		utils.TaxProfileFilterIndexes: {},
 		utils.ActionPlanIndexes:       {},
 		utils.FilterIndexPrfx:         {},
 	}
@@ -64,6 +65,8 @@ var (
 		utils.ThresholdProfilePrefix:   {},
 		utils.RankingPrefix:            {},
 		utils.RankingsProfilePrefix:    {},
+		utils.DataSetPrefix:            {},
+		utils.TaxProfilePrefix:         {},
 		utils.FilterPrefix:             {},
 		utils.RouteProfilePrefix:       {},
 		utils.AttributeProfilePrefix:   {},
@@ -79,6 +82,7 @@ var (
 		utils.RouteFilterIndexes:       {},
 		utils.ChargerFilterIndexes:     {},
 		utils.DispatcherFilterIndexes:  {},
+		utils.TaxProfileFilterIndexes:  {},
 		utils.FilterIndexPrfx:          {},
 		utils.MetaAPIBan:               {}, // not realy a prefix as this is not stored in DB
 		utils.MetaNotSentryPeer:        {},
@@ -108,6 +112,9 @@ type DataManager struct {
 	connMgr    *ConnManager
 	ms         Marshaler
 	replicator *replicator
+
+	auditActor  string // recorded on the audit trail, see WithAudit
+	auditMethod string
 }
 
 func (dm *DataManager) Close() {
@@ -184,32 +191,50 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 			_, err = dm.GetSharedGroup(dataID, true, utils.NonTransactional)
 		case utils.ResourceProfilesPrefix:
 			tntID := utils.NewTenantID(dataID)
-			lkID := guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, resourceProfileLockKey(tntID.Tenant, tntID.ID))
+			var lkID string
+			if lkID, err = guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, resourceProfileLockKey(tntID.Tenant, tntID.ID)); err != nil {
+				return
+			}
 			_, err = dm.GetResourceProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
 			guardian.Guardian.UnguardIDs(lkID)
 		case utils.ResourcesPrefix:
 			tntID := utils.NewTenantID(dataID)
-			lkID := guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, resourceLockKey(tntID.Tenant, tntID.ID))
+			var lkID string
+			if lkID, err = guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, resourceLockKey(tntID.Tenant, tntID.ID)); err != nil {
+				return
+			}
 			_, err = dm.GetResource(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
 			guardian.Guardian.UnguardIDs(lkID)
 		case utils.IPProfilesPrefix:
 			tntID := utils.NewTenantID(dataID)
-			lkID := guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, ipProfileLockKey(tntID.Tenant, tntID.ID))
+			var lkID string
+			if lkID, err = guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, ipProfileLockKey(tntID.Tenant, tntID.ID)); err != nil {
+				return
+			}
 			_, err = dm.GetIPProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
 			guardian.Guardian.UnguardIDs(lkID)
 		case utils.IPAllocationsPrefix:
 			tntID := utils.NewTenantID(dataID)
-			lkID := guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, ipAllocationsLockKey(tntID.Tenant, tntID.ID))
+			var lkID string
+			if lkID, err = guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, ipAllocationsLockKey(tntID.Tenant, tntID.ID)); err != nil {
+				return
+			}
 			_, err = dm.GetIPAllocations(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional, nil)
 			guardian.Guardian.UnguardIDs(lkID)
 		case utils.StatQueueProfilePrefix:
 			tntID := utils.NewTenantID(dataID)
-			lkID := guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, statQueueProfileLockKey(tntID.Tenant, tntID.ID))
+			var lkID string
+			if lkID, err = guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, statQueueProfileLockKey(tntID.Tenant, tntID.ID)); err != nil {
+				return
+			}
 			_, err = dm.GetStatQueueProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
 			guardian.Guardian.UnguardIDs(lkID)
 		case utils.StatQueuePrefix:
 			tntID := utils.NewTenantID(dataID)
-			lkID := guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, statQueueLockKey(tntID.Tenant, tntID.ID))
+			var lkID string
+			if lkID, err = guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, statQueueLockKey(tntID.Tenant, tntID.ID)); err != nil {
+				return
+			}
 			_, err = dm.GetStatQueue(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
 			guardian.Guardian.UnguardIDs(lkID)
 		case utils.RankingsProfilePrefix:
@@ -218,16 +243,28 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 		case utils.RankingPrefix:
 			tntID := utils.NewTenantID(dataID)
 			_, err = dm.GetRanking(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
+		case utils.DataSetPrefix:
+			tntID := utils.NewTenantID(dataID)
+			_, err = dm.GetDataSet(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
+		case utils.TaxProfilePrefix:
+			tntID := utils.NewTenantID(dataID)
+			_, err = dm.GetTaxProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
 		case utils.TimingsPrefix:
 			_, err = dm.GetTiming(dataID, true, utils.NonTransactional)
 		case utils.ThresholdProfilePrefix:
 			tntID := utils.NewTenantID(dataID)
-			lkID := guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, thresholdProfileLockKey(tntID.Tenant, tntID.ID))
+			var lkID string
+			if lkID, err = guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, thresholdProfileLockKey(tntID.Tenant, tntID.ID)); err != nil {
+				return
+			}
 			_, err = dm.GetThresholdProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
 			guardian.Guardian.UnguardIDs(lkID)
 		case utils.ThresholdPrefix:
 			tntID := utils.NewTenantID(dataID)
-			lkID := guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, thresholdLockKey(tntID.Tenant, tntID.ID))
+			var lkID string
+			if lkID, err = guardian.Guardian.GuardIDs("", config.CgrConfig().GeneralCfg().LockingTimeout, thresholdLockKey(tntID.Tenant, tntID.ID)); err != nil {
+				return
+			}
 			_, err = dm.GetThreshold(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
 			guardian.Guardian.UnguardIDs(lkID)
 		case utils.TrendsProfilePrefix:
@@ -296,6 +333,12 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 				return
 			}
 			_, err = dm.GetIndexes(utils.CacheDispatcherFilterIndexes, tntCtx, false, true, idxKey)
+		case utils.TaxProfileFilterIndexes:
+			var tntCtx, idxKey string
+			if tntCtx, idxKey, err = splitFilterIndex(dataID); err != nil {
+				return
+			}
+			_, err = dm.GetIndexes(utils.CacheTaxProfileFilterIndexes, tntCtx, false, true, idxKey)
 		case utils.FilterIndexPrfx:
 			idx := strings.LastIndexByte(dataID, utils.InInFieldSep[0])
 			if idx < 0 {
@@ -622,6 +665,7 @@ func (dm *DataManager) GetAccount(id string) (acc *Account, err error) {
 			return nil, err
 		}
 	}
+	armReservations(dm, acc)
 	return
 }
 
@@ -725,9 +769,11 @@ func (dm *DataManager) SetFilter(fltr *Filter, withIndex bool) (err error) {
 		return
 	}
 	var oldFlt *Filter
-	if oldFlt, err = dm.GetFilter(fltr.Tenant, fltr.ID, true, false,
-		utils.NonTransactional); err != nil && err != utils.ErrNotFound {
-		return err
+	if withIndex || config.CgrConfig().AuditCfg().Audited(utils.MetaFilters) { // the old filter is only needed by the indexes and the audit trail
+		if oldFlt, err = dm.GetFilter(fltr.Tenant, fltr.ID, true, false,
+			utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+			return err
+		}
 	}
 	if err = dm.DataDB().SetFilterDrv(fltr); err != nil {
 		return
@@ -737,6 +783,9 @@ func (dm *DataManager) SetFilter(fltr *Filter, withIndex bool) (err error) {
 			return
 		}
 	}
+	if err = dm.auditChange(utils.MetaFilters, fltr.Tenant, fltr.ID, oldFlt, fltr); err != nil {
+		return
+	}
 	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaFilters]
 	return dm.replicator.replicate(
 		utils.FilterPrefix, fltr.TenantID(), // these are used to get the host IDs from cache
@@ -778,6 +827,9 @@ func (dm *DataManager) RemoveFilter(tenant, id string, withIndex bool) (err erro
 	if oldFlt == nil {
 		return utils.ErrNotFound
 	}
+	if err = dm.auditChange(utils.MetaFilters, tenant, id, oldFlt, nil); err != nil {
+		return
+	}
 	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaFilters]
 	_ = dm.replicator.replicate(
 		utils.FilterPrefix, utils.ConcatenatedKey(tenant, id), // these are used to get the host IDs from cache
@@ -1216,7 +1268,7 @@ func (dm *DataManager) SetStatQueueProfile(sqp *StatQueueProfile, withIndex bool
 		oldSts.TTL != sqp.TTL ||
 		oldSts.MinItems != sqp.MinItems ||
 		(oldSts.Stored != sqp.Stored && oldSts.Stored) { // reset the stats queue if the profile changed these fields
-		guardian.Guardian.Guard(func() (_ error) { // we change the queue so lock it
+		err = guardian.Guardian.Guard(func() (err error) { // we change the queue so lock it
 			var sq *StatQueue
 			if sq, err = NewStatQueue(sqp.Tenant, sqp.ID, sqp.Metrics,
 				sqp.MinItems); err != nil {
@@ -1226,7 +1278,7 @@ func (dm *DataManager) SetStatQueueProfile(sqp *StatQueueProfile, withIndex bool
 			return
 		}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.StatQueuePrefix+sqp.TenantID())
 	} else {
-		guardian.Guardian.Guard(func() (_ error) { // we change the queue so lock it
+		err = guardian.Guardian.Guard(func() (err error) { // we change the queue so lock it
 			oSq, errRs := dm.GetStatQueue(sqp.Tenant, sqp.ID, // do not try to get the stats queue if the configuration changed
 				true, false, utils.NonTransactional)
 			if errRs == utils.ErrNotFound { // the stats queue does not exist
@@ -1682,6 +1734,260 @@ func (dm *DataManager) RemoveRankingProfile(tenant, id string) (err error) {
 		}, itm)
 	return
 }
+func (dm *DataManager) GetDataSet(tenant, id string, cacheRead, cacheWrite bool, transactionID string) (ds *DataSet, err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	if cacheRead {
+		if x, ok := Cache.Get(utils.CacheDataSets, tntID); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*DataSet), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	ds, err = dm.dataDB.GetDataSetDrv(tenant, id)
+	if err != nil {
+		if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDataSets]; err == utils.ErrNotFound && itm.Remote {
+			if err = dm.connMgr.Call(context.TODO(), config.CgrConfig().DataDbCfg().RmtConns,
+				utils.ReplicatorSv1GetDataSet,
+				&utils.TenantIDWithAPIOpts{
+					TenantID: &utils.TenantID{Tenant: tenant, ID: id},
+					APIOpts: utils.GenerateDBItemOpts(itm.APIKey, itm.RouteID, utils.EmptyString,
+						utils.FirstNonEmpty(config.CgrConfig().DataDbCfg().RmtConnID,
+							config.CgrConfig().GeneralCfg().NodeID)),
+				}, &ds); err == nil {
+				err = dm.dataDB.SetDataSetDrv(ds)
+			}
+		}
+		if err != nil {
+			err = utils.CastRPCErr(err)
+			if err == utils.ErrNotFound && cacheWrite {
+				if errCh := Cache.Set(utils.CacheDataSets, tntID, nil, nil,
+					cacheCommit(transactionID), transactionID); errCh != nil {
+					return nil, errCh
+				}
+			}
+			return nil, err
+		}
+	}
+	if cacheWrite {
+		if errCh := Cache.Set(utils.CacheDataSets, tntID, ds, nil,
+			cacheCommit(transactionID), transactionID); errCh != nil {
+			return nil, errCh
+		}
+	}
+	return
+}
+
+func (dm *DataManager) SetDataSet(ds *DataSet) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	if err = dm.DataDB().SetDataSetDrv(ds); err != nil {
+		return
+	}
+	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDataSets]
+	return dm.replicator.replicate(
+		utils.DataSetPrefix, ds.TenantID(),
+		utils.ReplicatorSv1SetDataSet,
+		&DataSetWithAPIOpts{
+			DataSet: ds,
+			APIOpts: utils.GenerateDBItemOpts(itm.APIKey, itm.RouteID,
+				config.CgrConfig().DataDbCfg().RplCache, utils.EmptyString),
+		}, itm)
+}
+
+// AddDataSetEntries adds the entries to the DataSet stored in DataDB, creating it if missing
+func (dm *DataManager) AddDataSetEntries(tenant, id string, ents []*DataSetEntry) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	if err = dm.DataDB().AddDataSetEntriesDrv(tenant, id, ents); err != nil {
+		return
+	}
+	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDataSets]
+	return dm.replicator.replicate(
+		utils.DataSetPrefix, utils.ConcatenatedKey(tenant, id),
+		utils.ReplicatorSv1AddDataSetEntries,
+		&DataSetEntriesWithAPIOpts{
+			Tenant:  tenant,
+			ID:      id,
+			Entries: ents,
+			APIOpts: utils.GenerateDBItemOpts(itm.APIKey, itm.RouteID,
+				config.CgrConfig().DataDbCfg().RplCache, utils.EmptyString),
+		}, itm)
+}
+
+// RemoveDataSetEntries removes the entries from the DataSet stored in DataDB
+func (dm *DataManager) RemoveDataSetEntries(tenant, id string, ents []*DataSetEntry) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	if err = dm.DataDB().RemoveDataSetEntriesDrv(tenant, id, ents); err != nil {
+		return
+	}
+	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDataSets]
+	return dm.replicator.replicate(
+		utils.DataSetPrefix, utils.ConcatenatedKey(tenant, id),
+		utils.ReplicatorSv1RemoveDataSetEntries,
+		&DataSetEntriesWithAPIOpts{
+			Tenant:  tenant,
+			ID:      id,
+			Entries: ents,
+			APIOpts: utils.GenerateDBItemOpts(itm.APIKey, itm.RouteID,
+				config.CgrConfig().DataDbCfg().RplCache, utils.EmptyString),
+		}, itm)
+}
+
+func (dm *DataManager) RemoveDataSet(tenant, id string) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	oldDs, err := dm.GetDataSet(tenant, id, false, false, utils.NonTransactional)
+	if err != nil && err != utils.ErrNotFound {
+		return err
+	}
+	if err = dm.DataDB().RemoveDataSetDrv(tenant, id); err != nil {
+		return
+	}
+	if oldDs == nil {
+		return utils.ErrNotFound
+	}
+	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDataSets]
+	return dm.replicator.replicate(
+		utils.DataSetPrefix, utils.ConcatenatedKey(tenant, id), // these are used to get the host IDs from cache
+		utils.ReplicatorSv1RemoveDataSet,
+		&utils.TenantIDWithAPIOpts{
+			TenantID: &utils.TenantID{Tenant: tenant, ID: id},
+			APIOpts: utils.GenerateDBItemOpts(itm.APIKey, itm.RouteID,
+				config.CgrConfig().DataDbCfg().RplCache, utils.EmptyString),
+		}, itm)
+}
+
+func (dm *DataManager) GetTaxProfile(tenant, id string, cacheRead, cacheWrite bool, transactionID string) (tp *TaxProfile, err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	if cacheRead {
+		if x, ok := Cache.Get(utils.CacheTaxProfiles, tntID); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*TaxProfile), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	tp, err = dm.dataDB.GetTaxProfileDrv(tenant, id)
+	if err != nil {
+		if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaTaxProfiles]; err == utils.ErrNotFound && itm.Remote {
+			if err = dm.connMgr.Call(context.TODO(), config.CgrConfig().DataDbCfg().RmtConns,
+				utils.ReplicatorSv1GetTaxProfile,
+				&utils.TenantIDWithAPIOpts{
+					TenantID: &utils.TenantID{Tenant: tenant, ID: id},
+					APIOpts: utils.GenerateDBItemOpts(itm.APIKey, itm.RouteID, utils.EmptyString,
+						utils.FirstNonEmpty(config.CgrConfig().DataDbCfg().RmtConnID,
+							config.CgrConfig().GeneralCfg().NodeID)),
+				}, &tp); err == nil {
+				err = dm.dataDB.SetTaxProfileDrv(tp)
+			}
+		}
+		if err != nil {
+			err = utils.CastRPCErr(err)
+			if err == utils.ErrNotFound && cacheWrite {
+				if errCh := Cache.Set(utils.CacheTaxProfiles, tntID, nil, nil,
+					cacheCommit(transactionID), transactionID); errCh != nil {
+					return nil, errCh
+				}
+			}
+			return nil, err
+		}
+	}
+	if cacheWrite {
+		if errCh := Cache.Set(utils.CacheTaxProfiles, tntID, tp, nil,
+			cacheCommit(transactionID), transactionID); errCh != nil {
+			return nil, errCh
+		}
+	}
+	return
+}
+
+func (dm *DataManager) SetTaxProfile(tp *TaxProfile, withIndex bool) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	if withIndex {
+		if err = dm.checkFilters(tp.Tenant, tp.FilterIDs); err != nil {
+			// if we get a broken filter do not set the profile
+			return fmt.Errorf("%+s for item with ID: %+v",
+				err, tp.TenantID())
+		}
+	}
+	oldTp, err := dm.GetTaxProfile(tp.Tenant, tp.ID, true, false, utils.NonTransactional)
+	if err != nil && err != utils.ErrNotFound {
+		return err
+	}
+	if err = dm.DataDB().SetTaxProfileDrv(tp); err != nil {
+		return
+	}
+	if withIndex {
+		var oldFiltersIDs *[]string
+		if oldTp != nil {
+			oldFiltersIDs = &oldTp.FilterIDs
+		}
+		if err = updatedIndexes(dm, utils.CacheTaxProfileFilterIndexes, tp.Tenant,
+			utils.EmptyString, tp.ID, oldFiltersIDs, tp.FilterIDs, false); err != nil {
+			return
+		}
+	}
+	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaTaxProfiles]
+	return dm.replicator.replicate(
+		utils.TaxProfilePrefix, tp.TenantID(),
+		utils.ReplicatorSv1SetTaxProfile,
+		&TaxProfileWithAPIOpts{
+			TaxProfile: tp,
+			APIOpts: utils.GenerateDBItemOpts(itm.APIKey, itm.RouteID,
+				config.CgrConfig().DataDbCfg().RplCache, utils.EmptyString),
+		}, itm)
+}
+
+func (dm *DataManager) RemoveTaxProfile(tenant, id string, withIndex bool) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	oldTp, err := dm.GetTaxProfile(tenant, id, true, false, utils.NonTransactional)
+	if err != nil && err != utils.ErrNotFound {
+		return err
+	}
+	if err = dm.DataDB().RemoveTaxProfileDrv(tenant, id); err != nil {
+		return
+	}
+	if oldTp == nil {
+		return utils.ErrNotFound
+	}
+	if withIndex {
+		if err = removeIndexFiltersItem(dm, utils.CacheTaxProfileFilterIndexes, tenant, id, oldTp.FilterIDs); err != nil {
+			return
+		}
+		if err = removeItemFromFilterIndex(dm, utils.CacheTaxProfileFilterIndexes,
+			tenant, utils.EmptyString, id, oldTp.FilterIDs); err != nil {
+			return
+		}
+	}
+	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaTaxProfiles]
+	return dm.replicator.replicate(
+		utils.TaxProfilePrefix, utils.ConcatenatedKey(tenant, id), // these are used to get the host IDs from cache
+		utils.ReplicatorSv1RemoveTaxProfile,
+		&utils.TenantIDWithAPIOpts{
+			TenantID: &utils.TenantID{Tenant: tenant, ID: id},
+			APIOpts: utils.GenerateDBItemOpts(itm.APIKey, itm.RouteID,
+				config.CgrConfig().DataDbCfg().RplCache, utils.EmptyString),
+		}, itm)
+}
+
 func (dm *DataManager) GetRanking(tenant, id string, cacheRead, cacheWrite bool, transactionID string) (rn *Ranking, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
 	if cacheRead {
@@ -3024,9 +3330,18 @@ func (dm *DataManager) SetRatingProfile(rpf *RatingProfile) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	var oldRpf *RatingProfile
+	if config.CgrConfig().AuditCfg().Audited(utils.MetaRatingProfiles) {
+		if oldRpf, err = dm.DataDB().GetRatingProfileDrv(rpf.Id); err != nil && err != utils.ErrNotFound {
+			return
+		}
+	}
 	if err = dm.DataDB().SetRatingProfileDrv(rpf); err != nil {
 		return
 	}
+	if err = dm.auditChange(utils.MetaRatingProfiles, ratingProfileTenant(rpf.Id), rpf.Id, oldRpf, rpf); err != nil {
+		return
+	}
 	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRatingProfiles]
 	return dm.replicator.replicate(
 		utils.RatingProfilePrefix, rpf.Id, // these are used to get the host IDs from cache
@@ -3043,9 +3358,20 @@ func (dm *DataManager) RemoveRatingProfile(key string) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	var oldRpf *RatingProfile
+	if config.CgrConfig().AuditCfg().Audited(utils.MetaRatingProfiles) {
+		if oldRpf, err = dm.DataDB().GetRatingProfileDrv(key); err != nil && err != utils.ErrNotFound {
+			return
+		}
+	}
 	if err = dm.DataDB().RemoveRatingProfileDrv(key); err != nil {
 		return
 	}
+	if oldRpf != nil { // not recorded for the removals by prefix
+		if err = dm.auditChange(utils.MetaRatingProfiles, ratingProfileTenant(key), key, oldRpf, nil); err != nil {
+			return
+		}
+	}
 	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRatingProfiles]
 	_ = dm.replicator.replicate(
 		utils.RatingProfilePrefix, key, // these are used to get the host IDs from cache
@@ -3131,9 +3457,12 @@ func (dm *DataManager) SetRouteProfile(rpp *RouteProfile, withIndex bool) (err e
 				err, rpp.TenantID())
 		}
 	}
-	oldRpp, err := dm.GetRouteProfile(rpp.Tenant, rpp.ID, true, false, utils.NonTransactional)
-	if err != nil && err != utils.ErrNotFound {
-		return err
+	var oldRpp *RouteProfile
+	if withIndex || config.CgrConfig().AuditCfg().Audited(utils.MetaRouteProfiles) {
+		if oldRpp, err = dm.GetRouteProfile(rpp.Tenant, rpp.ID, true, false,
+			utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+			return err
+		}
 	}
 	if err = dm.DataDB().SetRouteProfileDrv(rpp); err != nil {
 		return err
@@ -3148,6 +3477,9 @@ func (dm *DataManager) SetRouteProfile(rpp *RouteProfile, withIndex bool) (err e
 			return err
 		}
 	}
+	if err = dm.auditChange(utils.MetaRouteProfiles, rpp.Tenant, rpp.ID, oldRpp, rpp); err != nil {
+		return
+	}
 	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRouteProfiles]
 	return dm.replicator.replicate(
 		utils.RouteProfilePrefix, rpp.TenantID(), // these are used to get the host IDs from cache
@@ -3182,6 +3514,9 @@ func (dm *DataManager) RemoveRouteProfile(tenant, id string, withIndex bool) (er
 			return
 		}
 	}
+	if err = dm.auditChange(utils.MetaRouteProfiles, tenant, id, oldRpp, nil); err != nil {
+		return
+	}
 	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRouteProfiles]
 	_ = dm.replicator.replicate(
 		utils.RouteProfilePrefix, utils.ConcatenatedKey(tenant, id), // these are used to get the host IDs from cache
@@ -3262,9 +3597,12 @@ func (dm *DataManager) SetAttributeProfile(ap *AttributeProfile, withIndex bool)
 				err, ap.TenantID())
 		}
 	}
-	oldAP, err := dm.GetAttributeProfile(ap.Tenant, ap.ID, true, false, utils.NonTransactional)
-	if err != nil && err != utils.ErrNotFound {
-		return err
+	var oldAP *AttributeProfile
+	if withIndex || config.CgrConfig().AuditCfg().Audited(utils.MetaAttributeProfiles) {
+		if oldAP, err = dm.GetAttributeProfile(ap.Tenant, ap.ID, true, false,
+			utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+			return err
+		}
 	}
 	if len(ap.Contexts) == 0 {
 		ap.Contexts = append(ap.Contexts, utils.MetaAny)
@@ -3284,6 +3622,9 @@ func (dm *DataManager) SetAttributeProfile(ap *AttributeProfile, withIndex bool)
 			return
 		}
 	}
+	if err = dm.auditChange(utils.MetaAttributeProfiles, ap.Tenant, ap.ID, oldAP, ap); err != nil {
+		return
+	}
 	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaAttributeProfiles]
 	return dm.replicator.replicate(
 		utils.AttributeProfilePrefix, ap.TenantID(), // these are used to get the host IDs from cache
@@ -3320,6 +3661,9 @@ func (dm *DataManager) RemoveAttributeProfile(tenant, id string, withIndex bool)
 			}
 		}
 	}
+	if err = dm.auditChange(utils.MetaAttributeProfiles, tenant, id, oldAttr, nil); err != nil {
+		return
+	}
 	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaAttributeProfiles]
 	_ = dm.replicator.replicate(
 		utils.AttributeProfilePrefix, utils.ConcatenatedKey(tenant, id), // these are used to get the host IDs from cache
@@ -4024,3 +4368,45 @@ func (dm *DataManager) RemoveSessionsBackup(nodeID, tenant, cgrid string) (err e
 			Tenant: tenant,
 		}, itm)
 }
+
+// GetInvoice returns an issued invoice
+func (dm *DataManager) GetInvoice(tenant, id string) (*Invoice, error) {
+	if dm == nil {
+		return nil, utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.GetInvoiceDrv(tenant, id)
+}
+
+// GetInvoices returns the invoices of the tenant, all of them if the account is empty
+func (dm *DataManager) GetInvoices(tenant, account string) ([]*Invoice, error) {
+	if dm == nil {
+		return nil, utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.GetInvoicesDrv(tenant, account)
+}
+
+// CountInvoices returns the number of invoices issued for the tenant
+func (dm *DataManager) CountInvoices(tenant string) (int, error) {
+	if dm == nil {
+		return 0, utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.CountInvoicesDrv(tenant)
+}
+
+// SetInvoice stores a new invoice, the issued ones cannot be overwritten
+func (dm *DataManager) SetInvoice(inv *Invoice) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	if err = dm.dataDB.SetInvoiceDrv(inv); err != nil {
+		return
+	}
+	itm := config.CgrConfig().DataDbCfg().Items[utils.MetaInvoices]
+	return dm.replicator.replicate(
+		utils.InvoicePrefix, inv.TenantID(),
+		utils.ReplicatorSv1SetInvoice,
+		&InvoiceWithAPIOpts{
+			Invoice: inv,
+			APIOpts: utils.GenerateDBItemOpts(itm.APIKey, itm.RouteID,
+				config.CgrConfig().DataDbCfg().RplCache, utils.EmptyString)}, itm)
+}
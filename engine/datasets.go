@@ -0,0 +1,208 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// DataSetEntry is one value of a DataSet as received over the API or loaded from csv
+type DataSetEntry struct {
+	Value    string            // the value to match
+	Prefix   bool              // match the Value as prefix instead of exact
+	Metadata map[string]string // optional data returned on match
+}
+
+// Clone returns a deep copy of the DataSetEntry
+func (ent *DataSetEntry) Clone() *DataSetEntry {
+	if ent == nil {
+		return nil
+	}
+	return &DataSetEntry{
+		Value:    ent.Value,
+		Prefix:   ent.Prefix,
+		Metadata: maps.Clone(ent.Metadata),
+	}
+}
+
+// CacheClone returns a clone of DataSetEntry used by ltcache CacheCloner
+func (ent *DataSetEntry) CacheClone() any {
+	return ent.Clone()
+}
+
+// DataSetWithAPIOpts is used in replicatorV1 for dispatcher
+type DataSetWithAPIOpts struct {
+	*DataSet
+	APIOpts map[string]any
+}
+
+// DataSetEntriesWithAPIOpts is used to incrementally add or remove entries of a DataSet
+type DataSetEntriesWithAPIOpts struct {
+	Tenant  string
+	ID      string
+	Entries []*DataSetEntry
+	APIOpts map[string]any
+}
+
+// DataSet is a named list of values (block/allow lists) queried by filters and attributes.
+// Exact and prefix values are kept in separate maps so a lookup costs at most len(value) map accesses.
+type DataSet struct {
+	Tenant   string
+	ID       string
+	Exact    map[string]map[string]string // exact values with their metadata
+	Prefixes map[string]map[string]string // prefix values with their metadata
+}
+
+// NewDataSet returns an empty DataSet
+func NewDataSet(tnt, id string) *DataSet {
+	return &DataSet{
+		Tenant:   tnt,
+		ID:       id,
+		Exact:    make(map[string]map[string]string),
+		Prefixes: make(map[string]map[string]string),
+	}
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (ds *DataSet) TenantID() string {
+	return utils.ConcatenatedKey(ds.Tenant, ds.ID)
+}
+
+// Match checks the value against the exact entries first and afterwards against
+// the prefixes, longest one winning. Returns the matched entry.
+func (ds *DataSet) Match(val string) (ent *DataSetEntry, has bool) {
+	var md map[string]string
+	if md, has = ds.Exact[val]; has {
+		return &DataSetEntry{Value: val, Metadata: md}, true
+	}
+	if len(ds.Prefixes) == 0 {
+		return
+	}
+	for i := len(val); i > 0; i-- {
+		if md, has = ds.Prefixes[val[:i]]; has {
+			return &DataSetEntry{Value: val[:i], Prefix: true, Metadata: md}, true
+		}
+	}
+	return
+}
+
+// SetEntries adds the entries to the DataSet, overwriting the metadata of the existing ones
+func (ds *DataSet) SetEntries(ents []*DataSetEntry) {
+	if ds.Exact == nil {
+		ds.Exact = make(map[string]map[string]string)
+	}
+	if ds.Prefixes == nil {
+		ds.Prefixes = make(map[string]map[string]string)
+	}
+	for _, ent := range ents {
+		if ent.Prefix {
+			ds.Prefixes[ent.Value] = ent.Metadata
+			continue
+		}
+		ds.Exact[ent.Value] = ent.Metadata
+	}
+}
+
+// RemoveEntries removes the entries from the DataSet, ignoring the ones not present
+func (ds *DataSet) RemoveEntries(ents []*DataSetEntry) {
+	for _, ent := range ents {
+		if ent.Prefix {
+			delete(ds.Prefixes, ent.Value)
+			continue
+		}
+		delete(ds.Exact, ent.Value)
+	}
+}
+
+// Entries returns the entries of the DataSet, as stored per entry in DataDB
+func (ds *DataSet) Entries() (ents []*DataSetEntry) {
+	ents = make([]*DataSetEntry, 0, len(ds.Exact)+len(ds.Prefixes))
+	for val, md := range ds.Exact {
+		ents = append(ents, &DataSetEntry{Value: val, Metadata: md})
+	}
+	for val, md := range ds.Prefixes {
+		ents = append(ents, &DataSetEntry{Value: val, Prefix: true, Metadata: md})
+	}
+	return
+}
+
+// dataSetEntryKey returns the key identifying the entry within the DataSet,
+// ie. *string:1001 or *prefix:+49
+func dataSetEntryKey(ent *DataSetEntry) string {
+	if ent.Prefix {
+		return utils.ConcatenatedKey(utils.MetaPrefix, ent.Value)
+	}
+	return utils.ConcatenatedKey(utils.MetaString, ent.Value)
+}
+
+// newDataSetEntry builds the entry out of its key, the Value being left with the
+// separators it contains
+func newDataSetEntry(key string, md map[string]string) (ent *DataSetEntry, err error) {
+	typ, val, has := strings.Cut(key, utils.ConcatenatedKeySep)
+	if !has || (typ != utils.MetaString && typ != utils.MetaPrefix) {
+		return nil, fmt.Errorf("invalid data set entry key: <%s>", key)
+	}
+	return &DataSetEntry{Value: val, Prefix: typ == utils.MetaPrefix, Metadata: md}, nil
+}
+
+// Clone returns a deep copy of the DataSet
+func (ds *DataSet) Clone() (cln *DataSet) {
+	if ds == nil {
+		return nil
+	}
+	cln = &DataSet{
+		Tenant: ds.Tenant,
+		ID:     ds.ID,
+	}
+	if ds.Exact != nil {
+		cln.Exact = make(map[string]map[string]string, len(ds.Exact))
+		for val, md := range ds.Exact {
+			cln.Exact[val] = maps.Clone(md)
+		}
+	}
+	if ds.Prefixes != nil {
+		cln.Prefixes = make(map[string]map[string]string, len(ds.Prefixes))
+		for val, md := range ds.Prefixes {
+			cln.Prefixes[val] = maps.Clone(md)
+		}
+	}
+	return
+}
+
+// CacheClone returns a clone of DataSet used by ltcache CacheCloner
+func (ds *DataSet) CacheClone() any {
+	return ds.Clone()
+}
+
+// dataSetTenant returns the tenant used to query the data sets from within a DataProvider
+func dataSetTenant(dDP utils.DataProvider) string {
+	if dynDP, canCast := dDP.(*dynamicDP); canCast && dynDP.tenant != utils.EmptyString {
+		return dynDP.tenant
+	}
+	if tnt, err := dDP.FieldAsString([]string{utils.MetaTenant}); err == nil &&
+		tnt != utils.EmptyString {
+		return tnt
+	}
+	return config.CgrConfig().GeneralCfg().DefaultTenant
+}
@@ -0,0 +1,386 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func testDataSetDM(t *testing.T) *DataManager {
+	cfg := config.NewDefaultCGRConfig()
+	data, err := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dmDS := NewDataManager(data, cfg.CacheCfg(), nil)
+	oldDM := dm
+	SetDataStorage(dmDS)
+	Cache.Clear(nil)
+	t.Cleanup(func() {
+		SetDataStorage(oldDM)
+		Cache.Clear(nil)
+	})
+	return dmDS
+}
+
+func TestDataSetMatch(t *testing.T) {
+	ds := NewDataSet("cgrates.org", "BLOCKLIST")
+	ds.SetEntries([]*DataSetEntry{
+		{Value: "1001", Metadata: map[string]string{"Reason": "fraud"}},
+		{Value: "+4986", Prefix: true, Metadata: map[string]string{"Country": "DE"}},
+		{Value: "+498651", Prefix: true, Metadata: map[string]string{"Region": "Berchtesgaden"}},
+	})
+	if ent, has := ds.Match("1001"); !has {
+		t.Error("expected exact match")
+	} else if exp := (&DataSetEntry{Value: "1001",
+		Metadata: map[string]string{"Reason": "fraud"}}); !reflect.DeepEqual(exp, ent) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(ent))
+	}
+	if _, has := ds.Match("10011"); has {
+		t.Error("exact entries should not match as prefix")
+	}
+	if ent, has := ds.Match("+4986517174963"); !has {
+		t.Error("expected prefix match")
+	} else if exp := (&DataSetEntry{Value: "+498651", Prefix: true,
+		Metadata: map[string]string{"Region": "Berchtesgaden"}}); !reflect.DeepEqual(exp, ent) {
+		t.Errorf("expected the longest prefix %s, received %s", utils.ToJSON(exp), utils.ToJSON(ent))
+	}
+	if ent, has := ds.Match("+4986100"); !has || ent.Value != "+4986" {
+		t.Errorf("expected prefix <+4986>, received %s", utils.ToJSON(ent))
+	}
+	if _, has := ds.Match("+40"); has {
+		t.Error("unexpected match")
+	}
+	ds.RemoveEntries([]*DataSetEntry{{Value: "+498651", Prefix: true}, {Value: "1001"}, {Value: "missing"}})
+	if _, has := ds.Match("1001"); has {
+		t.Error("removed entry still matching")
+	}
+	if ent, has := ds.Match("+4986517174963"); !has || ent.Value != "+4986" {
+		t.Errorf("expected prefix <+4986>, received %s", utils.ToJSON(ent))
+	}
+}
+
+func TestDataSetClone(t *testing.T) {
+	ds := NewDataSet("cgrates.org", "ALLOW")
+	ds.SetEntries([]*DataSetEntry{
+		{Value: "1001", Metadata: map[string]string{"Plan": "gold"}},
+		{Value: "+40", Prefix: true},
+	})
+	cln := ds.Clone()
+	if !reflect.DeepEqual(ds, cln) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(ds), utils.ToJSON(cln))
+	}
+	cln.Exact["1001"]["Plan"] = "silver"
+	cln.SetEntries([]*DataSetEntry{{Value: "1002"}})
+	if ds.Exact["1001"]["Plan"] != "gold" {
+		t.Error("clone shares the metadata with the original")
+	}
+	if _, has := ds.Exact["1002"]; has {
+		t.Error("clone shares the entries with the original")
+	}
+	if (*DataSet)(nil).Clone() != nil {
+		t.Error("expected nil clone")
+	}
+}
+
+func TestDataSetEntryKey(t *testing.T) {
+	for _, ent := range []*DataSetEntry{
+		{Value: "sip:1001@cgrates.org", Metadata: map[string]string{"Plan": "gold"}},
+		{Value: "+40", Prefix: true},
+	} {
+		if rcv, err := newDataSetEntry(dataSetEntryKey(ent), ent.Metadata); err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(ent, rcv) {
+			t.Errorf("expected %s, received %s", utils.ToJSON(ent), utils.ToJSON(rcv))
+		}
+	}
+	if _, err := newDataSetEntry("*suffix:1001", nil); err == nil {
+		t.Error("expected error for the unknown entry type")
+	}
+}
+
+func TestDataSetMdlsAsDataSets(t *testing.T) {
+	mdls := DataSetMdls{
+		{Tenant: "cgrates.org", ID: "BLOCKLIST", Value: "1001", Metadata: "Reason:fraud;Since:2024-01-01T10:00:00Z"},
+		{Tenant: "cgrates.org", ID: "BLOCKLIST", Value: "+4986", Prefix: true},
+		{Tenant: "cgrates.org", ID: "EMPTY"},
+	}
+	exp := []*DataSet{
+		{
+			Tenant: "cgrates.org",
+			ID:     "BLOCKLIST",
+			Exact: map[string]map[string]string{
+				"1001": {"Reason": "fraud", "Since": "2024-01-01T10:00:00Z"},
+			},
+			Prefixes: map[string]map[string]string{"+4986": nil},
+		},
+		NewDataSet("cgrates.org", "EMPTY"),
+	}
+	if rcv, err := mdls.AsDataSets(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	mdls = DataSetMdls{{Tenant: "cgrates.org", ID: "BLOCKLIST", Value: "1001", Metadata: "Reason"}}
+	if _, err := mdls.AsDataSets(); err == nil {
+		t.Error("expected error for invalid metadata")
+	}
+}
+
+func TestDataManagerDataSet(t *testing.T) {
+	dmDS := testDataSetDM(t)
+	if _, err := dmDS.GetDataSet("cgrates.org", "BLOCKLIST", true, true, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	Cache.Clear(nil)
+	ds := NewDataSet("cgrates.org", "BLOCKLIST")
+	ds.SetEntries([]*DataSetEntry{{Value: "1001"}})
+	if err := dmDS.SetDataSet(ds); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dmDS.GetDataSet("cgrates.org", "BLOCKLIST", true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(ds, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(ds), utils.ToJSON(rcv))
+	}
+	if err := dmDS.RemoveDataSet("cgrates.org", "BLOCKLIST"); err != nil {
+		t.Error(err)
+	}
+	Cache.Clear(nil) // the cache is updated over CacheS
+	if err := dmDS.RemoveDataSet("cgrates.org", "BLOCKLIST"); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestFilterPassInSet(t *testing.T) {
+	dmDS := testDataSetDM(t)
+	ds := NewDataSet("cgrates.org", "BLOCKLIST")
+	ds.SetEntries([]*DataSetEntry{
+		{Value: "1001"},
+		{Value: "+4986", Prefix: true},
+	})
+	if err := dmDS.SetDataSet(ds); err != nil {
+		t.Fatal(err)
+	}
+	ev := utils.MapStorage{
+		utils.MetaReq: map[string]any{
+			utils.AccountField: "1001",
+			utils.Destination:  "+4986517174963",
+			utils.Subject:      "1002",
+		},
+	}
+	dDP := newDynamicDP(nil, nil, nil, nil, nil, "cgrates.org", ev)
+	for _, tc := range []struct {
+		rule string
+		pass bool
+	}{
+		{"*in_set:~*req.Account:BLOCKLIST", true},
+		{"*in_set:~*req.Destination:BLOCKLIST", true},
+		{"*in_set:~*req.Subject:BLOCKLIST", false},
+		{"*in_set:~*req.Subject:MISSING|BLOCKLIST", false},
+		{"*in_set:~*req.Account:MISSING|BLOCKLIST", true},
+		{"*in_set:~*req.Unknown:BLOCKLIST", false},
+		{"*notin_set:~*req.Account:BLOCKLIST", false},
+		{"*notin_set:~*req.Subject:BLOCKLIST", true},
+	} {
+		fltr, err := NewFilterFromInline("cgrates.org", tc.rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pass, err := fltr.Rules[0].Pass(dDP); err != nil {
+			t.Error(err)
+		} else if pass != tc.pass {
+			t.Errorf("%s: expected %v, received %v", tc.rule, tc.pass, pass)
+		}
+	}
+	// the tenant is taken from the event so other tenants do not see the set
+	dDP = newDynamicDP(nil, nil, nil, nil, nil, "itsyscom.com", ev)
+	fltr, err := NewFilterFromInline("itsyscom.com", "*in_set:~*req.Account:BLOCKLIST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pass, err := fltr.Rules[0].Pass(dDP); err != nil {
+		t.Error(err)
+	} else if pass {
+		t.Error("expected the filter not to pass for other tenant")
+	}
+	if _, err := NewFilterRule(utils.MetaInSet, "~*req.Account", nil); err == nil {
+		t.Error("expected error for missing values")
+	}
+}
+
+func TestParseAttributeDataSet(t *testing.T) {
+	dmDS := testDataSetDM(t)
+	ds := NewDataSet("cgrates.org", "ROUTING")
+	ds.SetEntries([]*DataSetEntry{
+		{Value: "+4986", Prefix: true, Metadata: map[string]string{"Carrier": "carrier1"}},
+		{Value: "+40", Prefix: true},
+	})
+	if err := dmDS.SetDataSet(ds); err != nil {
+		t.Fatal(err)
+	}
+	dp := utils.MapStorage{
+		utils.MetaReq: map[string]any{
+			utils.Destination: "+4986517174963",
+		},
+		utils.MetaTenant: "cgrates.org",
+	}
+	parse := func(val string) (any, error) {
+		rsrVal, err := config.NewRSRParsers(val, utils.InfieldSep)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ParseAttribute(dp, utils.MetaDataSet, utils.EmptyString, rsrVal,
+			0, utils.EmptyString, utils.EmptyString, utils.InfieldSep)
+	}
+	if out, err := parse("ROUTING;~*req.Destination;Carrier"); err != nil {
+		t.Error(err)
+	} else if out != "carrier1" {
+		t.Errorf("expected <carrier1>, received <%v>", out)
+	}
+	if out, err := parse("ROUTING;~*req.Destination"); err != nil {
+		t.Error(err)
+	} else if out != "+4986" {
+		t.Errorf("expected <+4986>, received <%v>", out)
+	}
+	if _, err := parse("ROUTING;~*req.Destination;Price"); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	dp[utils.MetaReq].(map[string]any)[utils.Destination] = "+33"
+	if _, err := parse("ROUTING;~*req.Destination;Carrier"); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if _, err := parse("MISSING;~*req.Destination"); err == nil || err == utils.ErrNotFound {
+		t.Errorf("expected data set not found error, received %v", err)
+	}
+	if _, err := parse("ROUTING"); err == nil {
+		t.Error("expected invalid number of arguments error")
+	}
+}
+
+func TestAttributesProcessEventDataSet(t *testing.T) {
+	dmDS := testDataSetDM(t)
+	cfg := config.NewDefaultCGRConfig()
+	ds := NewDataSet("cgrates.org", "VIP")
+	ds.SetEntries([]*DataSetEntry{{Value: "1001", Metadata: map[string]string{"Plan": "gold"}}})
+	if err := dmDS.SetDataSet(ds); err != nil {
+		t.Fatal(err)
+	}
+	if err := dmDS.SetAttributeProfile(&AttributeProfile{
+		Tenant:   "cgrates.org",
+		ID:       "ATTR_VIP",
+		Contexts: []string{utils.MetaAny},
+		Attributes: []*Attribute{
+			{
+				Path:  "*req.Plan",
+				Type:  utils.MetaDataSet,
+				Value: config.NewRSRParsersMustCompile("VIP;~*req.Account;Plan", utils.InfieldSep),
+			},
+			{
+				Path:  "*req.Processed",
+				Type:  utils.MetaConstant,
+				Value: config.NewRSRParsersMustCompile("true", utils.InfieldSep),
+			},
+		},
+		Weight: 10,
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	alS := NewAttributeService(dmDS, NewFilterS(cfg, nil, dmDS), cfg)
+	var rply AttrSProcessEventReply
+	if err := alS.V1ProcessEvent(context.Background(), &utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event:  map[string]any{utils.AccountField: "1001"},
+	}, &rply); err != nil {
+		t.Fatal(err)
+	} else if rply.CGREvent.Event["Plan"] != "gold" {
+		t.Errorf("expected <gold>, received %s", utils.ToJSON(rply.CGREvent.Event))
+	}
+	// accounts outside the set keep the event untouched but the following attributes are still processed
+	if err := alS.V1ProcessEvent(context.Background(), &utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event:  map[string]any{utils.AccountField: "1002"},
+	}, &rply); err != nil {
+		t.Fatal(err)
+	} else if exp := map[string]any{utils.AccountField: "1002", "Processed": "true"}; !reflect.DeepEqual(exp, rply.CGREvent.Event) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rply.CGREvent.Event))
+	}
+}
+
+func TestInternalDBDataSetEntries(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	iDB, err := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.RemoveDataSetEntriesDrv("cgrates.org", "DS:1", []*DataSetEntry{{Value: "1001"}}); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err = iDB.AddDataSetEntriesDrv("cgrates.org", "DS:1", []*DataSetEntry{
+		{Value: "1001"}, {Value: "a:*string:b"}, {Value: "+49", Prefix: true}}); err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.AddDataSetEntriesDrv("cgrates.org", "DS2", []*DataSetEntry{{Value: "1002"}}); err != nil {
+		t.Fatal(err)
+	}
+	if has, err := iDB.HasDataDrv(utils.DataSetPrefix, "DS:1", "cgrates.org"); err != nil {
+		t.Error(err)
+	} else if !has {
+		t.Error("expected the DataSet stored")
+	}
+	if keys, err := iDB.GetKeysForPrefix(utils.DataSetPrefix, utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if exp := []string{utils.DataSetPrefix + "cgrates.org:DS2", utils.DataSetPrefix + "cgrates.org:DS:1"}; !reflect.DeepEqual(
+		utils.NewStringSet(exp), utils.NewStringSet(keys)) || len(keys) != len(exp) {
+		t.Errorf("expected %q, received %q", exp, keys)
+	}
+
+	// SetDataSetDrv replaces the entries
+	ds := NewDataSet("cgrates.org", "DS:1")
+	ds.SetEntries([]*DataSetEntry{{Value: "1001", Metadata: map[string]string{"Reason": "fraud"}}})
+	if err = iDB.SetDataSetDrv(ds); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := iDB.GetDataSetDrv("cgrates.org", "DS:1"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(ds, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(ds), utils.ToJSON(rcv))
+	}
+
+	// removing the last entry removes the DataSet
+	if err = iDB.RemoveDataSetEntriesDrv("cgrates.org", "DS:1", []*DataSetEntry{{Value: "1001"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = iDB.GetDataSetDrv("cgrates.org", "DS:1"); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err = iDB.RemoveDataSetDrv("cgrates.org", "DS2"); err != nil {
+		t.Fatal(err)
+	}
+	if keys, err := iDB.GetKeysForPrefix(utils.DataSetPrefix, utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if len(keys) != 0 {
+		t.Errorf("expected no DataSets, received %q", keys)
+	}
+}
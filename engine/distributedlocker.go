@@ -0,0 +1,76 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewDistributedLocker returns the guardian.DistributedLocker sharing the locks through the dataDB.
+// The *internal dataDB is not shared, so the locks are held by the engine it replicates to.
+func NewDistributedLocker(dm *DataManager, rplConns []string) (guardian.DistributedLocker, error) {
+	switch dataDB := dm.DataDB().(type) {
+	case *InternalDB:
+		if len(rplConns) == 0 {
+			return nil, fmt.Errorf("replication_conns required for distributed locking on %s DataDB", utils.MetaInternal)
+		}
+		return &replicatorLocker{
+			connMgr:  dm.connMgr,
+			rplConns: rplConns,
+		}, nil
+	case guardian.DistributedLocker:
+		return dataDB, nil
+	default:
+		return nil, fmt.Errorf("distributed locking not supported by DataDB of type %T", dataDB)
+	}
+}
+
+// replicatorLocker holds the locks remotely, through ReplicatorSv1
+type replicatorLocker struct {
+	connMgr  *ConnManager
+	rplConns []string
+}
+
+// TryLock attempts to acquire the lease on lkID for ttl, returning its token if acquired
+func (rl *replicatorLocker) TryLock(lkID string, ttl time.Duration) (token int64, acquired bool, err error) {
+	if err = rl.connMgr.Call(context.TODO(), rl.rplConns, utils.ReplicatorSv1TryLock,
+		&utils.ArgsDistributedLock{LockID: lkID, TTL: ttl}, &token); err != nil {
+		return
+	}
+	return token, token != 0, nil
+}
+
+// RefreshLock extends the lease on lkID with ttl, if it is still held with token
+func (rl *replicatorLocker) RefreshLock(lkID string, token int64, ttl time.Duration) (refreshed bool, err error) {
+	err = rl.connMgr.Call(context.TODO(), rl.rplConns, utils.ReplicatorSv1RefreshLock,
+		&utils.ArgsDistributedLock{LockID: lkID, Token: token, TTL: ttl}, &refreshed)
+	return
+}
+
+// Unlock releases the lease on lkID, if it is still held with token
+func (rl *replicatorLocker) Unlock(lkID string, token int64) error {
+	var reply string
+	return rl.connMgr.Call(context.TODO(), rl.rplConns, utils.ReplicatorSv1Unlock,
+		&utils.ArgsDistributedLock{LockID: lkID, Token: token}, &reply)
+}
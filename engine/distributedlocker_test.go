@@ -0,0 +1,136 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestInternalDBDistributedLock(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	iDB, err := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, acquired, err := iDB.TryLock("cgrates.org:1001", 20*time.Millisecond)
+	if err != nil || !acquired || token != 1 {
+		t.Fatalf("expected token 1 acquired, received %d %v %v", token, acquired, err)
+	}
+	if _, acquired, _ = iDB.TryLock("cgrates.org:1001", 20*time.Millisecond); acquired {
+		t.Error("expected the lock to be held")
+	}
+	if token2, acquired, _ := iDB.TryLock("cgrates.org:1002", time.Second); !acquired || token2 != 2 {
+		t.Errorf("expected token 2 acquired, received %d %v", token2, acquired)
+	}
+	if refreshed, _ := iDB.RefreshLock("cgrates.org:1001", token, 20*time.Millisecond); !refreshed {
+		t.Error("expected the lease to be refreshed")
+	}
+
+	time.Sleep(25 * time.Millisecond) // lease expired
+	token3, acquired, _ := iDB.TryLock("cgrates.org:1001", time.Second)
+	if !acquired || token3 != 3 {
+		t.Fatalf("expected token 3 acquired, received %d %v", token3, acquired)
+	}
+	// the previous holder can no longer refresh or release the lock
+	if refreshed, _ := iDB.RefreshLock("cgrates.org:1001", token, time.Second); refreshed {
+		t.Error("expected the lease not to be refreshed with a stale token")
+	}
+	iDB.Unlock("cgrates.org:1001", token)
+	if _, acquired, _ = iDB.TryLock("cgrates.org:1001", time.Second); acquired {
+		t.Error("expected the lock to be held")
+	}
+	iDB.Unlock("cgrates.org:1001", token3)
+	if _, acquired, _ = iDB.TryLock("cgrates.org:1001", time.Second); !acquired {
+		t.Error("expected the lock to be released")
+	}
+}
+
+func TestNewDistributedLocker(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	remoteDB, err := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ccM := &ccMock{
+		calls: map[string]func(ctx *context.Context, args any, reply any) error{
+			utils.ReplicatorSv1TryLock: func(ctx *context.Context, args, reply any) error {
+				arg := args.(*utils.ArgsDistributedLock)
+				token, _, err := remoteDB.TryLock(arg.LockID, arg.TTL)
+				*reply.(*int64) = token
+				return err
+			},
+			utils.ReplicatorSv1RefreshLock: func(ctx *context.Context, args, reply any) (err error) {
+				arg := args.(*utils.ArgsDistributedLock)
+				*reply.(*bool), err = remoteDB.RefreshLock(arg.LockID, arg.Token, arg.TTL)
+				return
+			},
+			utils.ReplicatorSv1Unlock: func(ctx *context.Context, args, reply any) error {
+				arg := args.(*utils.ArgsDistributedLock)
+				*reply.(*string) = utils.OK
+				return remoteDB.Unlock(arg.LockID, arg.Token)
+			},
+		},
+	}
+	rplConn := make(chan birpc.ClientConnector, 1)
+	rplConn <- ccM
+	connMgr := NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.ReplicatorSv1): rplConn,
+	})
+	iDB, err := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm := NewDataManager(iDB, cfg.CacheCfg(), connMgr)
+	if _, err = NewDistributedLocker(dm, nil); err == nil {
+		t.Error("expected error for *internal DataDB without replication_conns")
+	}
+	var dl guardian.DistributedLocker
+	if dl, err = NewDistributedLocker(dm, []string{utils.ConcatenatedKey(utils.MetaInternal, utils.ReplicatorSv1)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, isRemote := dl.(*replicatorLocker); !isRemote {
+		t.Fatalf("expected the locks held remotely, received %T", dl)
+	}
+	token, acquired, err := dl.TryLock("cgrates.org:1001", time.Second)
+	if err != nil || !acquired || token != 1 {
+		t.Fatalf("expected token 1 acquired, received %d %v %v", token, acquired, err)
+	}
+	if _, acquired, _ = remoteDB.TryLock("cgrates.org:1001", time.Second); acquired {
+		t.Error("expected the lock to be held remotely")
+	}
+	if _, acquired, _ = dl.TryLock("cgrates.org:1001", time.Second); acquired {
+		t.Error("expected the lock to be held")
+	}
+	if refreshed, err := dl.RefreshLock("cgrates.org:1001", token, time.Second); err != nil || !refreshed {
+		t.Errorf("expected the lease to be refreshed, received %v %v", refreshed, err)
+	}
+	if err = dl.Unlock("cgrates.org:1001", token); err != nil {
+		t.Error(err)
+	}
+	if _, acquired, _ = remoteDB.TryLock("cgrates.org:1001", time.Second); !acquired {
+		t.Error("expected the lock to be released")
+	}
+}
@@ -60,15 +60,19 @@ func NewEventCostFromCallCost(cc *CallCost, cgrID, runID string) (ec *EventCost)
 			utils.Subject:               ts.MatchedSubject,
 		}
 		isPause := ts.RatingPlanId == utils.MetaPause
-		cIl.RatingID = ec.ratingIDForRateInterval(ts.RateInterval, rf, isPause)
+		var usageTier time.Duration
+		if ts.RateInterval != nil {
+			usageTier = ts.RateInterval.UsageTier(ts.GetGroupStart())
+		}
+		cIl.RatingID = ec.ratingIDForRateInterval(ts.RateInterval, rf, isPause, usageTier)
 		if len(ts.Increments) != 0 {
 			cIl.Increments = make([]*ChargingIncrement, 0, len(ts.Increments)+1)
 		}
 		for _, incr := range ts.Increments {
-			cIl.Increments = append(cIl.Increments, ec.newChargingIncrement(incr, rf, false, isPause))
+			cIl.Increments = append(cIl.Increments, ec.newChargingIncrement(incr, rf, false, isPause, usageTier))
 		}
 		if ts.RoundIncrement != nil {
-			cIl.Increments = append(cIl.Increments, ec.newChargingIncrement(ts.RoundIncrement, rf, true, false))
+			cIl.Increments = append(cIl.Increments, ec.newChargingIncrement(ts.RoundIncrement, rf, true, false, usageTier))
 		}
 		ec.Charges[i] = cIl
 	}
@@ -77,7 +81,8 @@ func NewEventCostFromCallCost(cc *CallCost, cgrID, runID string) (ec *EventCost)
 
 // newChargingIncrement creates ChargingIncrement from a Increment
 // special case if is the roundIncrement the rateID is *rounding
-func (ec *EventCost) newChargingIncrement(incr *Increment, rf RatingMatchedFilters, roundedIncrement, isPause bool) (cIt *ChargingIncrement) {
+func (ec *EventCost) newChargingIncrement(incr *Increment, rf RatingMatchedFilters, roundedIncrement, isPause bool,
+	usageTier time.Duration) (cIt *ChargingIncrement) {
 	cIt = &ChargingIncrement{
 		Usage:          incr.Duration,
 		Cost:           incr.Cost,
@@ -96,7 +101,7 @@ func (ec *EventCost) newChargingIncrement(incr *Increment, rf RatingMatchedFilte
 		ecUUID := utils.MetaNone // populate no matter what due to Unit not nil
 		if incr.BalanceInfo.Monetary != nil {
 			if !roundedIncrement {
-				rateID = ec.ratingIDForRateInterval(incr.BalanceInfo.Monetary.RateInterval, rf, isPause)
+				rateID = ec.ratingIDForRateInterval(incr.BalanceInfo.Monetary.RateInterval, rf, isPause, usageTier)
 			}
 			bc := &BalanceCharge{
 				AccountID:   incr.BalanceInfo.AccountID,
@@ -112,7 +117,7 @@ func (ec *EventCost) newChargingIncrement(incr *Increment, rf RatingMatchedFilte
 			}
 		}
 		if !roundedIncrement {
-			rateID = ec.ratingIDForRateInterval(incr.BalanceInfo.Unit.RateInterval, rf, isPause)
+			rateID = ec.ratingIDForRateInterval(incr.BalanceInfo.Unit.RateInterval, rf, isPause, usageTier)
 		}
 		bc := &BalanceCharge{
 			AccountID:     incr.BalanceInfo.AccountID,
@@ -135,7 +140,7 @@ func (ec *EventCost) newChargingIncrement(incr *Increment, rf RatingMatchedFilte
 		}
 	} else if incr.BalanceInfo.Monetary != nil { // Only monetary
 		if !roundedIncrement {
-			rateID = ec.ratingIDForRateInterval(incr.BalanceInfo.Monetary.RateInterval, rf, isPause)
+			rateID = ec.ratingIDForRateInterval(incr.BalanceInfo.Monetary.RateInterval, rf, isPause, usageTier)
 		}
 		bc := &BalanceCharge{
 			AccountID:   incr.BalanceInfo.AccountID,
@@ -177,7 +182,10 @@ func (ec *EventCost) initCache() {
 	}
 }
 
-func (ec *EventCost) ratingIDForRateInterval(ri *RateInterval, rf RatingMatchedFilters, isPause bool) string {
+// ratingIDForRateInterval returns the ID of the RatingUnit matching the rate interval, the usageTier
+// being the GroupIntervalStart of the tier applied on the usage counter of the tiered rates
+func (ec *EventCost) ratingIDForRateInterval(ri *RateInterval, rf RatingMatchedFilters, isPause bool,
+	usageTier time.Duration) string {
 	if ri == nil || ri.Rating == nil {
 		return utils.EmptyString
 	}
@@ -220,6 +228,10 @@ func (ec *EventCost) ratingIDForRateInterval(ri *RateInterval, rf RatingMatchedF
 		RatesID:          rtUUID,
 		RatingFiltersID:  rfUUID,
 	}
+	if ri.Rating.UsageCounter != utils.EmptyString {
+		ru.UsageCounter = ri.Rating.UsageCounter
+		ru.UsageTier = usageTier
+	}
 	if isPause {
 		ec.Rating[utils.MetaPause] = ru
 		return utils.MetaPause
@@ -456,8 +468,8 @@ func (ec *EventCost) AsCallCost(tor string) *CallCost {
 
 		incrs := cIl.Increments
 		if incrLen := len(cIl.Increments); incrLen != 0 {
-			if cIl.Increments[incrLen-1].Cost != 0 &&
-				ec.Accounting[cIl.Increments[incrLen-1].AccountingID].RatingID == utils.MetaRounding {
+			if bc, has := ec.Accounting[cIl.Increments[incrLen-1].AccountingID]; has && // no accounting for the taxes
+				cIl.Increments[incrLen-1].Cost != 0 && bc.RatingID == utils.MetaRounding {
 				// special case: if the last increment has the ratingID equal to *rounding
 				// we consider it as the roundIncrement
 				incrLen--
@@ -4199,7 +4199,7 @@ func TestECnewChargingIncrementMissingBalanceInfo(t *testing.T) {
 		Cost:           incr.Cost,
 		CompressFactor: incr.CompressFactor,
 	}
-	rcv := ec.newChargingIncrement(incr, rf, false, false)
+	rcv := ec.newChargingIncrement(incr, rf, false, false, 0)
 
 	if !reflect.DeepEqual(rcv, exp) {
 		t.Errorf("\nexpected: <%+v>, \nreceived: <%+v>", exp, rcv)
@@ -4224,7 +4224,7 @@ func TestECnewChargingIncrementWithUnitInfo(t *testing.T) {
 		CompressFactor: incr.CompressFactor,
 		AccountingID:   utils.MetaPause,
 	}
-	rcv := ec.newChargingIncrement(incr, rf, false, true)
+	rcv := ec.newChargingIncrement(incr, rf, false, true, 0)
 
 	if !reflect.DeepEqual(rcv, exp) {
 		t.Errorf("\nexpected: <%+v>, \nreceived: <%+v>", exp, rcv)
@@ -4253,7 +4253,7 @@ func TestECnewChargingIncrementNoUnitInfo(t *testing.T) {
 			utils.MetaPause: &BalanceCharge{},
 		},
 	}
-	rcv := ec.newChargingIncrement(incr, rf, false, true)
+	rcv := ec.newChargingIncrement(incr, rf, false, true, 0)
 
 	if !reflect.DeepEqual(rcv, exp) {
 		t.Errorf("\nexpected: <%+v>, \nreceived: <%+v>", exp, rcv)
@@ -4552,7 +4552,7 @@ func TestECratingIDForRateIntervalPause(t *testing.T) {
 			},
 		},
 	}
-	rcv := ec.ratingIDForRateInterval(ri, rf, true)
+	rcv := ec.ratingIDForRateInterval(ri, rf, true, 0)
 
 	if rcv != exp {
 		t.Fatalf("\nexpected: <%+v>, \nreceived: <%+v>", exp, rcv)
@@ -256,19 +256,19 @@ var supportedFiltersType utils.StringSet = utils.NewStringSet([]string{
 	utils.MetaEmpty, utils.MetaExists, utils.MetaLessThan, utils.MetaLessOrEqual,
 	utils.MetaGreaterThan, utils.MetaGreaterOrEqual, utils.MetaEqual,
 	utils.MetaIPNet, utils.MetaAPIBan, utils.MetaSentryPeer, utils.MetaActivationInterval,
-	utils.MetaRegex})
+	utils.MetaRegex, utils.MetaInSet})
 var needsFieldName utils.StringSet = utils.NewStringSet([]string{
 	utils.MetaString, utils.MetaContains, utils.MetaPrefix, utils.MetaSuffix,
 	utils.MetaTimings, utils.MetaRSR, utils.MetaDestinations, utils.MetaLessThan,
 	utils.MetaEmpty, utils.MetaExists, utils.MetaLessOrEqual, utils.MetaGreaterThan,
 	utils.MetaGreaterOrEqual, utils.MetaEqual, utils.MetaIPNet, utils.MetaAPIBan, utils.MetaSentryPeer,
 	utils.MetaActivationInterval,
-	utils.MetaRegex})
+	utils.MetaRegex, utils.MetaInSet})
 var needsValues utils.StringSet = utils.NewStringSet([]string{utils.MetaString, utils.MetaContains, utils.MetaPrefix,
 	utils.MetaSuffix, utils.MetaTimings, utils.MetaRSR, utils.MetaDestinations,
 	utils.MetaLessThan, utils.MetaLessOrEqual, utils.MetaGreaterThan, utils.MetaGreaterOrEqual,
 	utils.MetaEqual, utils.MetaIPNet, utils.MetaAPIBan, utils.MetaSentryPeer, utils.MetaActivationInterval,
-	utils.MetaRegex})
+	utils.MetaRegex, utils.MetaInSet})
 
 // NewFilterRule returns a new filter
 func NewFilterRule(rfType, fieldName string, vals []string) (*FilterRule, error) {
@@ -425,6 +425,8 @@ func (fltr *FilterRule) Pass(dDP utils.DataProvider) (result bool, err error) {
 		result, err = fltr.passActivationInterval(dDP)
 	case utils.MetaRegex, utils.MetaNotRegex:
 		result, err = fltr.passRegex(dDP)
+	case utils.MetaInSet, utils.MetaNotInSet:
+		result, err = fltr.passInSet(dDP)
 	default:
 		if strings.HasPrefix(fltr.Type, utils.MetaHTTP) && strings.Index(fltr.Type, "#") == len(utils.MetaHTTP) {
 			result, err = fltr.passHttp(dDP)
@@ -643,6 +645,35 @@ func (fltr *FilterRule) passDestinations(dDP utils.DataProvider) (bool, error) {
 	return false, err
 }
 
+func (fltr *FilterRule) passInSet(dDP utils.DataProvider) (bool, error) {
+	strVal, err := fltr.rsrElement.ParseDataProvider(dDP)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	tnt := dataSetTenant(dDP)
+	// iterate the DataSet IDs gotten from filter values and check the value against each of them
+	for _, val := range fltr.rsrValues {
+		dsID, err := val.ParseDataProvider(dDP)
+		if err != nil {
+			continue
+		}
+		ds, err := dm.GetDataSet(tnt, dsID, true, true, utils.NonTransactional)
+		if err != nil {
+			if err == utils.ErrNotFound {
+				continue // if the DataSet is not found, continue on next filter value
+			}
+			return false, err
+		}
+		if _, has := ds.Match(strVal); has {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (fltr *FilterRule) passRSR(dDP utils.DataProvider) (bool, error) {
 	fld, err := fltr.rsrElement.ParseDataProvider(dDP)
 	if err != nil {
@@ -0,0 +1,568 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewFraudS returns a new FraudS
+func NewFraudS(cgrcfg *config.CGRConfig, filterS *FilterS, connMgr *ConnManager) *FraudS {
+	return &FraudS{
+		cgrcfg:      cgrcfg,
+		filterS:     filterS,
+		connMgr:     connMgr,
+		accounts:    make(map[string]*fraudAccount),
+		stopCleanup: make(chan struct{}),
+		loopStopped: make(chan struct{}),
+		now:         time.Now,
+	}
+}
+
+// FraudS scores the session events of the accounts against their own recent history,
+// flagging or blocking the ones deviating from it. The history is kept only in memory,
+// being rebuilt from scratch after a restart
+type FraudS struct {
+	cgrcfg      *config.CGRConfig
+	filterS     *FilterS
+	connMgr     *ConnManager
+	mu          sync.Mutex               // protects the accounts
+	accounts    map[string]*fraudAccount // keyed by tenant:account:profileID
+	stopCleanup chan struct{}
+	loopStopped chan struct{}
+	now         func() time.Time
+}
+
+// FraudIndicator is the value of one indicator compared with its baseline
+type FraudIndicator struct {
+	Type      string
+	Value     float64
+	Baseline  float64
+	Triggered bool
+	Score     float64
+}
+
+// FraudScore is the result of scoring an event
+type FraudScore struct {
+	Tenant     string
+	Account    string
+	ProfileID  string
+	Score      float64
+	Action     string            // <""|*flag|*block>
+	Indicators []*FraudIndicator // the triggered indicators
+}
+
+// AsNavigableMap returns the score as a map of DataNodes, used in the agent replies
+func (fScore *FraudScore) AsNavigableMap() map[string]*utils.DataNode {
+	indicators := &utils.DataNode{Type: utils.NMSliceType, Slice: make([]*utils.DataNode, len(fScore.Indicators))}
+	for i, ind := range fScore.Indicators {
+		indicators.Slice[i] = utils.NewLeafNode(ind.Type)
+	}
+	return map[string]*utils.DataNode{
+		utils.FraudProfileID:  utils.NewLeafNode(fScore.ProfileID),
+		utils.FraudScore:      utils.NewLeafNode(fScore.Score),
+		utils.FraudAction:     utils.NewLeafNode(fScore.Action),
+		utils.FraudIndicators: indicators,
+	}
+}
+
+// FraudBaselines is the state of an account within one fraud profile
+type FraudBaselines struct {
+	Tenant         string
+	Account        string
+	ProfileID      string
+	ActiveSessions int
+	Indicators     []*FraudIndicator
+}
+
+// fraudBucket holds the values of the indicators within one window
+type fraudBucket struct {
+	idx    int64     // number of the window since the epoch
+	values []float64 // one value per indicator, peaks for *concurrent_calls
+}
+
+// fraudSession is an active session of an account
+type fraudSession struct {
+	setupTime time.Time
+	counted   []bool // the indicators the session passed the filters of
+}
+
+// fraudAccount is the history of one account, as defined by the profile scoring it
+type fraudAccount struct {
+	prf          *config.FraudProfileCfg
+	firstBucket  int64          // the bucket the account was first seen in
+	buckets      []*fraudBucket // ring holding the baseline buckets and the current one
+	active       map[string]*fraudSession
+	destinations map[string]time.Time // last call time per destination
+	lastActivity time.Time
+}
+
+func newFraudAccount(prf *config.FraudProfileCfg, now time.Time) *fraudAccount {
+	return &fraudAccount{
+		prf:          prf,
+		firstBucket:  now.UnixNano() / int64(prf.Window),
+		buckets:      make([]*fraudBucket, prf.Baseline/prf.Window+1),
+		active:       make(map[string]*fraudSession),
+		destinations: make(map[string]time.Time),
+		lastActivity: now,
+	}
+}
+
+// compatible checks if the history can still be used with the profile, after a config reload
+func (acnt *fraudAccount) compatible(prf *config.FraudProfileCfg) bool {
+	if acnt.prf.Window != prf.Window || acnt.prf.Baseline != prf.Baseline ||
+		len(acnt.prf.Indicators) != len(prf.Indicators) {
+		return false
+	}
+	for i, ind := range acnt.prf.Indicators {
+		if ind.Type != prf.Indicators[i].Type {
+			return false
+		}
+	}
+	return true
+}
+
+// bucket returns the bucket with the idx number, resetting the one it overwrites in the ring
+func (acnt *fraudAccount) bucket(idx int64) *fraudBucket {
+	pos := idx % int64(len(acnt.buckets))
+	if acnt.buckets[pos] == nil || acnt.buckets[pos].idx != idx {
+		acnt.buckets[pos] = &fraudBucket{
+			idx:    idx,
+			values: make([]float64, len(acnt.prf.Indicators)),
+		}
+	}
+	return acnt.buckets[pos]
+}
+
+// value returns the bucket value of the indicator or 0 if the bucket was not recorded
+func (acnt *fraudAccount) value(idx int64, ind int) float64 {
+	b := acnt.buckets[idx%int64(len(acnt.buckets))]
+	if b == nil || b.idx != idx {
+		return 0
+	}
+	return b.values[ind]
+}
+
+// prune drops the sessions over the TTL and the destinations older than the baseline
+func (acnt *fraudAccount) prune(now time.Time, sessionTTL time.Duration) {
+	if sessionTTL > 0 {
+		for originID, ses := range acnt.active {
+			if now.Sub(ses.setupTime) > sessionTTL {
+				delete(acnt.active, originID)
+			}
+		}
+	}
+	for dst, lastSeen := range acnt.destinations {
+		if now.Sub(lastSeen) > acnt.prf.Baseline {
+			delete(acnt.destinations, dst)
+		}
+	}
+}
+
+// concurrent returns the number of active sessions counted by the indicator
+func (acnt *fraudAccount) concurrent(ind int) (cnt float64) {
+	for _, ses := range acnt.active {
+		if ses.counted[ind] {
+			cnt++
+		}
+	}
+	return
+}
+
+// indicators computes the values of the indicators at time now, including the increments of
+// the event being scored. The window value is the current bucket plus the part of the previous
+// one still within the sliding window, the baseline is the average of the complete buckets
+func (acnt *fraudAccount) indicators(now time.Time, incr []float64) (inds []*FraudIndicator) {
+	window := int64(acnt.prf.Window)
+	cur := now.UnixNano() / window
+	prevWeight := 1 - float64(now.UnixNano()%window)/float64(window)
+	complete := min(cur-acnt.firstBucket, int64(len(acnt.buckets)-1))
+	inds = make([]*FraudIndicator, len(acnt.prf.Indicators))
+	for i, indCfg := range acnt.prf.Indicators {
+		ind := &FraudIndicator{Type: indCfg.Type}
+		var sum float64
+		for idx := cur - complete; idx < cur; idx++ {
+			sum += acnt.value(idx, i)
+		}
+		if complete > 0 {
+			ind.Baseline = sum / float64(complete)
+		}
+		switch indCfg.Type {
+		case utils.MetaConcurrentCalls:
+			ind.Value = acnt.concurrent(i) + incr[i]
+		default:
+			ind.Value = acnt.value(cur, i) + acnt.value(cur-1, i)*prevWeight + incr[i]
+		}
+		if indCfg.Type == utils.MetaCostPerHour { // normalize the window to one hour
+			ind.Value *= float64(time.Hour) / float64(window)
+			ind.Baseline *= float64(time.Hour) / float64(window)
+		}
+		ind.Triggered = ind.Value >= indCfg.MinValue &&
+			ind.Value > indCfg.Factor*ind.Baseline
+		if ind.Triggered {
+			ind.Score = indCfg.Score
+		}
+		inds[i] = ind
+	}
+	return
+}
+
+// matchingProfile returns the fraud profile with the highest weight matching the event
+func (fS *FraudS) matchingProfile(tnt string, evNm utils.MapStorage) (fPrf *config.FraudProfileCfg, err error) {
+	for _, prf := range fS.cgrcfg.FraudSCfg().Profiles {
+		if fPrf != nil && fPrf.Weight >= prf.Weight {
+			continue
+		}
+		var pass bool
+		if pass, err = fS.filterS.Pass(tnt, prf.Filters, evNm); err != nil {
+			return nil, err
+		} else if pass {
+			fPrf = prf
+		}
+	}
+	if fPrf == nil {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+// fraudEvent is an event prepared for scoring
+type fraudEvent struct {
+	tnt      string
+	account  string
+	originID string
+	dst      string
+	prf      *config.FraudProfileCfg
+	passed   []bool // the indicators the event passes the filters of
+}
+
+func (fS *FraudS) newFraudEvent(args *utils.CGREvent) (fEv *fraudEvent, err error) {
+	if args == nil {
+		return nil, utils.NewErrMandatoryIeMissing(utils.CGREventString)
+	}
+	if args.Event == nil {
+		return nil, utils.NewErrMandatoryIeMissing(utils.Event)
+	}
+	fEv = &fraudEvent{
+		tnt:      utils.FirstNonEmpty(args.Tenant, fS.cgrcfg.GeneralCfg().DefaultTenant),
+		originID: utils.IfaceAsString(args.Event[utils.OriginID]),
+		dst:      utils.IfaceAsString(args.Event[utils.Destination]),
+	}
+	if fEv.account, err = args.FieldAsString(utils.AccountField); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.NewErrMandatoryIeMissing(utils.AccountField)
+		}
+		return nil, err
+	}
+	if fEv.originID == utils.EmptyString {
+		fEv.originID = args.ID
+	}
+	evNm := utils.MapStorage{
+		utils.MetaReq:  args.Event,
+		utils.MetaOpts: args.APIOpts,
+	}
+	if fEv.prf, err = fS.matchingProfile(fEv.tnt, evNm); err != nil {
+		return nil, err
+	}
+	fEv.passed = make([]bool, len(fEv.prf.Indicators))
+	for i, ind := range fEv.prf.Indicators {
+		if fEv.passed[i], err = fS.filterS.Pass(fEv.tnt, ind.Filters, evNm); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// account returns the history of the account scored by the event profile, creating it if needed.
+// Called with the lock held
+func (fS *FraudS) account(fEv *fraudEvent, now time.Time) (acnt *fraudAccount) {
+	acntID := utils.ConcatenatedKey(fEv.tnt, fEv.account, fEv.prf.ID)
+	if acnt = fS.accounts[acntID]; acnt == nil || !acnt.compatible(fEv.prf) {
+		acnt = newFraudAccount(fEv.prf, now)
+		fS.accounts[acntID] = acnt
+	}
+	acnt.prf = fEv.prf
+	acnt.prune(now, fS.cgrcfg.FraudSCfg().SessionTTL)
+	return
+}
+
+// scoreEvent scores the event as a new session of the account, recording it if requested
+func (fS *FraudS) scoreEvent(args *utils.CGREvent, record bool) (fScore *FraudScore, err error) {
+	var fEv *fraudEvent
+	if fEv, err = fS.newFraudEvent(args); err != nil {
+		return
+	}
+	now := fS.now()
+	fS.mu.Lock()
+	acnt := fS.account(fEv, now)
+	_, isActive := acnt.active[fEv.originID]
+	lastSeen, knownDst := acnt.destinations[fEv.dst]
+	knownDst = knownDst && now.Sub(lastSeen) <= fEv.prf.Baseline
+	incr := make([]float64, len(fEv.prf.Indicators))
+	for i, ind := range fEv.prf.Indicators {
+		if !fEv.passed[i] {
+			continue
+		}
+		switch ind.Type {
+		case utils.MetaHighRiskCalls:
+			incr[i] = 1
+		case utils.MetaConcurrentCalls:
+			if !isActive {
+				incr[i] = 1
+			}
+		case utils.MetaNewDestinations:
+			if fEv.dst != utils.EmptyString && !knownDst {
+				incr[i] = 1
+			}
+		}
+	}
+	inds := acnt.indicators(now, incr)
+	fScore = &FraudScore{
+		Tenant:    fEv.tnt,
+		Account:   fEv.account,
+		ProfileID: fEv.prf.ID,
+	}
+	for _, ind := range inds {
+		if ind.Triggered {
+			fScore.Score += ind.Score
+			fScore.Indicators = append(fScore.Indicators, ind)
+		}
+	}
+	switch {
+	case fEv.prf.BlockScore > 0 && fScore.Score >= fEv.prf.BlockScore:
+		fScore.Action = utils.MetaBlock
+	case fEv.prf.FlagScore > 0 && fScore.Score >= fEv.prf.FlagScore:
+		fScore.Action = utils.MetaFlag
+	}
+	// the blocked events do not start a session so they are not recorded
+	if record && !isActive && fScore.Action != utils.MetaBlock {
+		bkt := acnt.bucket(now.UnixNano() / int64(fEv.prf.Window))
+		for i, ind := range fEv.prf.Indicators {
+			if ind.Type == utils.MetaConcurrentCalls {
+				bkt.values[i] = max(bkt.values[i], inds[i].Value)
+				continue
+			}
+			bkt.values[i] += incr[i]
+		}
+		acnt.active[fEv.originID] = &fraudSession{
+			setupTime: now,
+			counted:   fEv.passed,
+		}
+		if fEv.dst != utils.EmptyString {
+			acnt.destinations[fEv.dst] = now
+		}
+	}
+	acnt.lastActivity = now
+	fS.mu.Unlock()
+	if fScore.Action != utils.EmptyString {
+		go fS.processThresholds(fScore, fEv.prf)
+	}
+	return
+}
+
+// releaseEvent ends the session of the account, adding its cost to the current window
+func (fS *FraudS) releaseEvent(args *utils.CGREvent) (err error) {
+	var fEv *fraudEvent
+	if fEv, err = fS.newFraudEvent(args); err != nil {
+		return
+	}
+	var cost float64
+	if costIface, has := args.Event[utils.Cost]; has {
+		if cost, err = utils.IfaceAsFloat64(costIface); err != nil {
+			return
+		}
+	}
+	now := fS.now()
+	fS.mu.Lock()
+	defer fS.mu.Unlock()
+	acnt := fS.account(fEv, now)
+	delete(acnt.active, fEv.originID)
+	if cost > 0 {
+		bkt := acnt.bucket(now.UnixNano() / int64(fEv.prf.Window))
+		for i, ind := range fEv.prf.Indicators {
+			if ind.Type == utils.MetaCostPerHour && fEv.passed[i] {
+				bkt.values[i] += cost
+			}
+		}
+	}
+	acnt.lastActivity = now
+	return
+}
+
+// processThresholds raises the fraud alert towards ThresholdS
+func (fS *FraudS) processThresholds(fScore *FraudScore, prf *config.FraudProfileCfg) {
+	if len(fS.cgrcfg.FraudSCfg().ThresholdSConns) == 0 {
+		return
+	}
+	opts := map[string]any{
+		utils.MetaEventType: utils.FraudAlert,
+	}
+	var thIDs []string
+	if len(prf.ThresholdIDs) != 0 {
+		if len(prf.ThresholdIDs) == 1 &&
+			prf.ThresholdIDs[0] == utils.MetaNone {
+			return
+		}
+		thIDs = make([]string, len(prf.ThresholdIDs))
+		copy(thIDs, prf.ThresholdIDs)
+	}
+	opts[utils.OptsThresholdsProfileIDs] = thIDs
+	indicators := make([]string, len(fScore.Indicators))
+	for i, ind := range fScore.Indicators {
+		indicators[i] = ind.Type
+	}
+	fraudEv := &utils.CGREvent{
+		Tenant:  fScore.Tenant,
+		ID:      utils.GenUUID(),
+		APIOpts: opts,
+		Event: map[string]any{
+			utils.AccountField:    fScore.Account,
+			utils.FraudProfileID:  fScore.ProfileID,
+			utils.FraudScore:      fScore.Score,
+			utils.FraudAction:     fScore.Action,
+			utils.FraudIndicators: indicators,
+		},
+	}
+	var tIDs []string
+	if err := fS.connMgr.Call(context.TODO(), fS.cgrcfg.FraudSCfg().ThresholdSConns,
+		utils.ThresholdSv1ProcessEvent, fraudEv, &tIDs); err != nil &&
+		(len(thIDs) != 0 || err.Error() != utils.ErrNotFound.Error()) {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s processing event %+v with ThresholdS.", utils.FraudS, err.Error(), fraudEv))
+	}
+}
+
+// cleanup removes the accounts without activity within their baseline
+func (fS *FraudS) cleanup() {
+	now := fS.now()
+	fS.mu.Lock()
+	defer fS.mu.Unlock()
+	for acntID, acnt := range fS.accounts {
+		acnt.prune(now, fS.cgrcfg.FraudSCfg().SessionTTL)
+		if len(acnt.active) == 0 &&
+			now.Sub(acnt.lastActivity) > acnt.prf.Baseline+acnt.prf.Window {
+			delete(fS.accounts, acntID)
+		}
+	}
+}
+
+// runCleanup will regularly remove the inactive accounts
+func (fS *FraudS) runCleanup() {
+	cleanupInterval := fS.cgrcfg.FraudSCfg().CleanupInterval
+	if cleanupInterval <= 0 {
+		fS.loopStopped <- struct{}{}
+		return
+	}
+	for {
+		select {
+		case <-fS.stopCleanup:
+			fS.loopStopped <- struct{}{}
+			return
+		case <-time.After(cleanupInterval):
+			fS.cleanup()
+		}
+	}
+}
+
+// StartLoop starts the gorutine with the cleanup loop
+func (fS *FraudS) StartLoop() {
+	go fS.runCleanup()
+}
+
+// Reload restarts the cleanup loop with the new config
+func (fS *FraudS) Reload() {
+	close(fS.stopCleanup)
+	<-fS.loopStopped // wait until the loop is done
+	fS.stopCleanup = make(chan struct{})
+	go fS.runCleanup()
+}
+
+// Shutdown is called to shutdown the service
+func (fS *FraudS) Shutdown() {
+	utils.Logger.Info(fmt.Sprintf("<%s> shutdown initialized", utils.FraudS))
+	close(fS.stopCleanup)
+	<-fS.loopStopped
+	utils.Logger.Info(fmt.Sprintf("<%s> shutdown complete", utils.FraudS))
+}
+
+// V1ScoreEvent scores the event as a new session of the account, without recording it
+func (fS *FraudS) V1ScoreEvent(ctx *context.Context, args *utils.CGREvent, reply *FraudScore) (err error) {
+	var fScore *FraudScore
+	if fScore, err = fS.scoreEvent(args, false); err != nil {
+		return
+	}
+	*reply = *fScore
+	return
+}
+
+// V1ProcessEvent scores the event and records it as an active session of the account, unless blocked
+func (fS *FraudS) V1ProcessEvent(ctx *context.Context, args *utils.CGREvent, reply *FraudScore) (err error) {
+	var fScore *FraudScore
+	if fScore, err = fS.scoreEvent(args, true); err != nil {
+		return
+	}
+	*reply = *fScore
+	return
+}
+
+// V1ReleaseEvent ends the session of the account, recording its Cost
+func (fS *FraudS) V1ReleaseEvent(ctx *context.Context, args *utils.CGREvent, reply *string) (err error) {
+	if err = fS.releaseEvent(args); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1GetAccountBaselines returns the indicators of the account compared with their baselines
+func (fS *FraudS) V1GetAccountBaselines(ctx *context.Context, args *utils.TenantIDWithAPIOpts, reply *[]*FraudBaselines) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, fS.cgrcfg.GeneralCfg().DefaultTenant)
+	now := fS.now()
+	fS.mu.Lock()
+	defer fS.mu.Unlock()
+	var fBls []*FraudBaselines
+	for _, prf := range fS.cgrcfg.FraudSCfg().Profiles {
+		acnt, has := fS.accounts[utils.ConcatenatedKey(tnt, args.ID, prf.ID)]
+		if !has {
+			continue
+		}
+		acnt.prune(now, fS.cgrcfg.FraudSCfg().SessionTTL)
+		fBls = append(fBls, &FraudBaselines{
+			Tenant:         tnt,
+			Account:        args.ID,
+			ProfileID:      prf.ID,
+			ActiveSessions: len(acnt.active),
+			Indicators:     acnt.indicators(now, make([]float64, len(acnt.prf.Indicators))),
+		})
+	}
+	if len(fBls) == 0 {
+		return utils.ErrNotFound
+	}
+	*reply = fBls
+	return
+}
@@ -0,0 +1,366 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestFraudAccountIndicators(t *testing.T) {
+	prf := &config.FraudProfileCfg{
+		ID:       "FRD",
+		Window:   time.Hour,
+		Baseline: 3 * time.Hour,
+		Indicators: []*config.FraudIndicatorCfg{
+			{Type: utils.MetaHighRiskCalls, Factor: 2, MinValue: 1, Score: 10},
+			{Type: utils.MetaCostPerHour, Factor: 2, MinValue: 10, Score: 20},
+		},
+	}
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	acnt := newFraudAccount(prf, t0)
+	idx := t0.UnixNano() / int64(time.Hour)
+	acnt.bucket(idx).values[0] = 4
+	acnt.bucket(idx + 1).values[0] = 2
+	acnt.bucket(idx + 1).values[1] = 3
+
+	// the previous window is weighted by the part still within the sliding window
+	inds := acnt.indicators(t0.Add(90*time.Minute), []float64{0, 0})
+	exp := []*FraudIndicator{
+		{Type: utils.MetaHighRiskCalls, Value: 4, Baseline: 4},
+		{Type: utils.MetaCostPerHour, Value: 3, Baseline: 0},
+	}
+	if !reflect.DeepEqual(exp, inds) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(inds))
+	}
+
+	// only the complete buckets within the baseline are averaged
+	inds = acnt.indicators(t0.Add(210*time.Minute), []float64{1, 0})
+	exp = []*FraudIndicator{
+		{Type: utils.MetaHighRiskCalls, Value: 1, Baseline: 2},
+		{Type: utils.MetaCostPerHour, Value: 0, Baseline: 1},
+	}
+	if !reflect.DeepEqual(exp, inds) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(inds))
+	}
+	inds = acnt.indicators(t0.Add(5*time.Hour), []float64{5, 0})
+	exp = []*FraudIndicator{
+		{Type: utils.MetaHighRiskCalls, Value: 5, Baseline: 0, Triggered: true, Score: 10},
+		{Type: utils.MetaCostPerHour, Value: 0, Baseline: 0},
+	}
+	if !reflect.DeepEqual(exp, inds) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(inds))
+	}
+
+	// the cost is normalized to one hour
+	prf = &config.FraudProfileCfg{
+		ID:       "FRD",
+		Window:   30 * time.Minute,
+		Baseline: time.Hour,
+		Indicators: []*config.FraudIndicatorCfg{
+			{Type: utils.MetaCostPerHour, Factor: 2, MinValue: 10, Score: 20},
+		},
+	}
+	acnt = newFraudAccount(prf, t0)
+	acnt.bucket(t0.UnixNano() / int64(prf.Window)).values[0] = 6
+	if inds = acnt.indicators(t0, []float64{0}); inds[0].Value != 12 || !inds[0].Triggered {
+		t.Errorf("unexpected indicators: %s", utils.ToJSON(inds))
+	}
+}
+
+func TestFraudAccountCompatible(t *testing.T) {
+	prf := &config.FraudProfileCfg{
+		Window:     time.Hour,
+		Baseline:   2 * time.Hour,
+		Indicators: []*config.FraudIndicatorCfg{{Type: utils.MetaHighRiskCalls}},
+	}
+	acnt := newFraudAccount(prf, time.Now())
+	if !acnt.compatible(&config.FraudProfileCfg{
+		Window:     time.Hour,
+		Baseline:   2 * time.Hour,
+		FlagScore:  10,
+		Indicators: []*config.FraudIndicatorCfg{{Type: utils.MetaHighRiskCalls, Score: 10}},
+	}) {
+		t.Error("expected the history to be compatible")
+	}
+	if acnt.compatible(&config.FraudProfileCfg{
+		Window:     time.Hour,
+		Baseline:   3 * time.Hour,
+		Indicators: []*config.FraudIndicatorCfg{{Type: utils.MetaHighRiskCalls}},
+	}) {
+		t.Error("expected the history to not be compatible")
+	}
+	if acnt.compatible(&config.FraudProfileCfg{
+		Window:     time.Hour,
+		Baseline:   2 * time.Hour,
+		Indicators: []*config.FraudIndicatorCfg{{Type: utils.MetaCostPerHour}},
+	}) {
+		t.Error("expected the history to not be compatible")
+	}
+}
+
+func newTestFraudS(t *testing.T, thdCh chan *utils.CGREvent) (fS *FraudS, now *time.Time) {
+	Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	cfg.FraudSCfg().SessionTTL = time.Hour
+	cfg.FraudSCfg().ThresholdSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)}
+	cfg.FraudSCfg().Profiles = []*config.FraudProfileCfg{
+		{
+			ID:         "FRD_DEFAULT",
+			Filters:    []string{"*notstring:~*req.Account:2000"},
+			Window:     time.Hour,
+			Baseline:   2 * time.Hour,
+			FlagScore:  50,
+			BlockScore: 100,
+			Indicators: []*config.FraudIndicatorCfg{
+				{Type: utils.MetaConcurrentCalls, Factor: 2, MinValue: 2, Score: 50},
+				{Type: utils.MetaHighRiskCalls, Filters: []string{"*prefix:~*req.Destination:882"},
+					Factor: 1, MinValue: 1, Score: 100},
+				{Type: utils.MetaCostPerHour, Factor: 2, MinValue: 10, Score: 50},
+			},
+		},
+	}
+	dataDB, err := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm := NewDataManager(dataDB, cfg.CacheCfg(), nil)
+	ccM := &ccMock{
+		calls: map[string]func(ctx *context.Context, args any, reply any) error{
+			utils.ThresholdSv1ProcessEvent: func(ctx *context.Context, args any, reply any) error {
+				thdCh <- args.(*utils.CGREvent)
+				return nil
+			},
+		},
+	}
+	rpcInternal := make(chan birpc.ClientConnector, 1)
+	rpcInternal <- ccM
+	connMgr := NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds): rpcInternal,
+	})
+	fS = NewFraudS(cfg, NewFilterS(cfg, nil, dm), connMgr)
+	now = new(time.Time)
+	*now = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	fS.now = func() time.Time { return *now }
+	return
+}
+
+func fraudTestEvent(originID, dst string) *utils.CGREvent {
+	return &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     originID,
+		Event: map[string]any{
+			utils.AccountField: "1001",
+			utils.OriginID:     originID,
+			utils.Destination:  dst,
+		},
+	}
+}
+
+func TestFraudSProcessEvents(t *testing.T) {
+	thdCh := make(chan *utils.CGREvent, 10)
+	fS, now := newTestFraudS(t, thdCh)
+
+	var fScore FraudScore
+	if err := fS.V1ProcessEvent(context.Background(), fraudTestEvent("o1", "1002"), &fScore); err != nil {
+		t.Fatal(err)
+	}
+	exp := FraudScore{Tenant: "cgrates.org", Account: "1001", ProfileID: "FRD_DEFAULT"}
+	if !reflect.DeepEqual(exp, fScore) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(fScore))
+	}
+
+	// the second concurrent call is over the baseline
+	fScore = FraudScore{}
+	if err := fS.V1ScoreEvent(context.Background(), fraudTestEvent("o2", "1003"), &fScore); err != nil {
+		t.Fatal(err)
+	}
+	exp = FraudScore{
+		Tenant:    "cgrates.org",
+		Account:   "1001",
+		ProfileID: "FRD_DEFAULT",
+		Score:     50,
+		Action:    utils.MetaFlag,
+		Indicators: []*FraudIndicator{
+			{Type: utils.MetaConcurrentCalls, Value: 2, Triggered: true, Score: 50},
+		},
+	}
+	if !reflect.DeepEqual(exp, fScore) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(fScore))
+	}
+	select {
+	case ev := <-thdCh:
+		expEv := map[string]any{
+			utils.AccountField:    "1001",
+			utils.FraudProfileID:  "FRD_DEFAULT",
+			utils.FraudScore:      50.,
+			utils.FraudAction:     utils.MetaFlag,
+			utils.FraudIndicators: []string{utils.MetaConcurrentCalls},
+		}
+		if !reflect.DeepEqual(expEv, ev.Event) {
+			t.Errorf("expected %s, received %s", utils.ToJSON(expEv), utils.ToJSON(ev.Event))
+		}
+		if ev.APIOpts[utils.MetaEventType] != utils.FraudAlert {
+			t.Errorf("unexpected opts: %s", utils.ToJSON(ev.APIOpts))
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the alert to reach ThresholdS")
+	}
+
+	// scoring does not record the session
+	var fBls []*FraudBaselines
+	if err := fS.V1GetAccountBaselines(context.Background(),
+		&utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{Tenant: "cgrates.org", ID: "1001"}}, &fBls); err != nil {
+		t.Fatal(err)
+	} else if len(fBls) != 1 || fBls[0].ActiveSessions != 1 {
+		t.Errorf("unexpected baselines: %s", utils.ToJSON(fBls))
+	}
+
+	fScore = FraudScore{}
+	if err := fS.V1ScoreEvent(context.Background(), fraudTestEvent("o3", "88212345"), &fScore); err != nil {
+		t.Fatal(err)
+	} else if fScore.Score != 150 || fScore.Action != utils.MetaBlock {
+		t.Errorf("unexpected score: %s", utils.ToJSON(fScore))
+	}
+	// the blocked events are not recorded as active sessions
+	fScore = FraudScore{}
+	if err := fS.V1ProcessEvent(context.Background(), fraudTestEvent("o3", "88212345"), &fScore); err != nil {
+		t.Fatal(err)
+	} else if fScore.Action != utils.MetaBlock {
+		t.Errorf("unexpected score: %s", utils.ToJSON(fScore))
+	}
+	fBls = nil
+	if err := fS.V1GetAccountBaselines(context.Background(),
+		&utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{Tenant: "cgrates.org", ID: "1001"}}, &fBls); err != nil {
+		t.Fatal(err)
+	} else if len(fBls) != 1 || fBls[0].ActiveSessions != 1 {
+		t.Errorf("unexpected baselines: %s", utils.ToJSON(fBls))
+	}
+
+	// the released cost counts for the cost per hour
+	relEv := fraudTestEvent("o1", "1002")
+	relEv.Event[utils.Cost] = 12.
+	var reply string
+	if err := fS.V1ReleaseEvent(context.Background(), relEv, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+	fScore = FraudScore{}
+	if err := fS.V1ScoreEvent(context.Background(), fraudTestEvent("o2", "1003"), &fScore); err != nil {
+		t.Fatal(err)
+	}
+	exp = FraudScore{
+		Tenant:    "cgrates.org",
+		Account:   "1001",
+		ProfileID: "FRD_DEFAULT",
+		Score:     50,
+		Action:    utils.MetaFlag,
+		Indicators: []*FraudIndicator{
+			{Type: utils.MetaCostPerHour, Value: 12, Triggered: true, Score: 50},
+		},
+	}
+	if !reflect.DeepEqual(exp, fScore) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(fScore))
+	}
+
+	// once the cost moves into the baseline it is no longer a deviation
+	*now = now.Add(150 * time.Minute)
+	fScore = FraudScore{}
+	if err := fS.V1ScoreEvent(context.Background(), fraudTestEvent("o2", "1003"), &fScore); err != nil {
+		t.Fatal(err)
+	} else if fScore.Score != 0 || fScore.Action != utils.EmptyString {
+		t.Errorf("unexpected score: %s", utils.ToJSON(fScore))
+	}
+	fBls = nil
+	if err := fS.V1GetAccountBaselines(context.Background(),
+		&utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{Tenant: "cgrates.org", ID: "1001"}}, &fBls); err != nil {
+		t.Fatal(err)
+	} else if fBls[0].Indicators[2].Baseline != 6 {
+		t.Errorf("unexpected baselines: %s", utils.ToJSON(fBls))
+	}
+}
+
+func TestFraudSProcessEventErrors(t *testing.T) {
+	fS, _ := newTestFraudS(t, make(chan *utils.CGREvent, 10))
+	var fScore FraudScore
+	if err := fS.V1ProcessEvent(context.Background(), nil, &fScore); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.CGREventString).Error() {
+		t.Errorf("unexpected error: %v", err)
+	}
+	ev := fraudTestEvent("o1", "1002")
+	delete(ev.Event, utils.AccountField)
+	if err := fS.V1ProcessEvent(context.Background(), ev, &fScore); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.AccountField).Error() {
+		t.Errorf("unexpected error: %v", err)
+	}
+	ev.Event[utils.AccountField] = "2000"
+	if err := fS.V1ProcessEvent(context.Background(), ev, &fScore); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	var fBls []*FraudBaselines
+	if err := fS.V1GetAccountBaselines(context.Background(),
+		&utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{ID: "1001"}}, &fBls); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	var reply string
+	ev.Event[utils.AccountField] = "1001"
+	ev.Event[utils.Cost] = "cost"
+	if err := fS.V1ReleaseEvent(context.Background(), ev, &reply); err == nil {
+		t.Error("expected error for invalid cost")
+	}
+}
+
+func TestFraudSCleanup(t *testing.T) {
+	fS, now := newTestFraudS(t, make(chan *utils.CGREvent, 10))
+	var fScore FraudScore
+	if err := fS.V1ProcessEvent(context.Background(), fraudTestEvent("o1", "1002"), &fScore); err != nil {
+		t.Fatal(err)
+	}
+	// the active session keeps the account until its TTL passes
+	*now = now.Add(30 * time.Minute)
+	fS.cleanup()
+	if len(fS.accounts) != 1 {
+		t.Fatalf("expected the account to be kept, received %d accounts", len(fS.accounts))
+	}
+	*now = now.Add(2 * time.Hour)
+	fS.cleanup()
+	if len(fS.accounts) != 1 {
+		t.Fatalf("expected the account to be kept, received %d accounts", len(fS.accounts))
+	} else if acnt := fS.accounts["cgrates.org:1001:FRD_DEFAULT"]; len(acnt.active) != 0 ||
+		len(acnt.destinations) != 0 {
+		t.Errorf("expected the expired session and destination to be pruned")
+	}
+	*now = now.Add(31 * time.Minute)
+	fS.cleanup()
+	if len(fS.accounts) != 0 {
+		t.Errorf("expected the account to be removed, received %d accounts", len(fS.accounts))
+	}
+
+	fS.cgrcfg.FraudSCfg().CleanupInterval = time.Millisecond
+	fS.StartLoop()
+	fS.Reload()
+	fS.Shutdown()
+}
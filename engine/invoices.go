@@ -0,0 +1,630 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Invoice is the billing document issued for an account and period. Once issued it
+// is never modified, corrections are done with new invoices
+type Invoice struct {
+	Tenant      string
+	ID          string // the invoice number
+	Sequence    int    // position of the invoice within the tenant numbering
+	Account     string
+	PeriodStart time.Time
+	PeriodEnd   time.Time // not included in the period
+	IssueTime   time.Time
+	Lines       []*InvoiceLine
+	Taxes       []*InvoiceTax
+	Balances    map[string]float64 // total value per balance type at issue time
+	Subtotal    float64
+	TaxTotal    float64
+	Total       float64
+}
+
+// InvoiceLine is either the usage aggregated out of the CDRs or a recurring fee
+type InvoiceLine struct {
+	Type          string // <*usage|*recurring>
+	Description   string // the actions ID of the recurring fees
+	ToR           string
+	DestinationID string // the destination the tax rate was selected on
+	Quantity      int    // number of CDRs or of fee occurrences
+	Usage         time.Duration
+	Amount        float64
+	TaxRate       float64 // percentage
+	Tax           float64
+}
+
+// InvoiceTax sums up the taxes applied with the rate of one destination
+type InvoiceTax struct {
+	DestinationID string
+	Rate          float64
+	Base          float64
+	Amount        float64
+}
+
+// InvoiceWithAPIOpts is used in replicatorV1 for dispatcher
+type InvoiceWithAPIOpts struct {
+	*Invoice
+	APIOpts map[string]any
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (inv *Invoice) TenantID() string {
+	return utils.ConcatenatedKey(inv.Tenant, inv.ID)
+}
+
+// CacheClone returns a clone of Invoice used by ltcache CacheCloner
+func (inv *Invoice) CacheClone() any {
+	return inv.Clone()
+}
+
+// Clone returns a deep copy of the invoice
+func (inv *Invoice) Clone() (cln *Invoice) {
+	if inv == nil {
+		return
+	}
+	cln = &Invoice{
+		Tenant:      inv.Tenant,
+		ID:          inv.ID,
+		Sequence:    inv.Sequence,
+		Account:     inv.Account,
+		PeriodStart: inv.PeriodStart,
+		PeriodEnd:   inv.PeriodEnd,
+		IssueTime:   inv.IssueTime,
+		Balances:    maps.Clone(inv.Balances),
+		Subtotal:    inv.Subtotal,
+		TaxTotal:    inv.TaxTotal,
+		Total:       inv.Total,
+	}
+	if inv.Lines != nil {
+		cln.Lines = make([]*InvoiceLine, len(inv.Lines))
+		for i, ln := range inv.Lines {
+			lnCln := *ln
+			cln.Lines[i] = &lnCln
+		}
+	}
+	if inv.Taxes != nil {
+		cln.Taxes = make([]*InvoiceTax, len(inv.Taxes))
+		for i, tx := range inv.Taxes {
+			txCln := *tx
+			cln.Taxes[i] = &txCln
+		}
+	}
+	return
+}
+
+// computeTotals applies the tax rates on the lines and sums up the invoice
+func (inv *Invoice) computeTotals(taxRates map[string]float64) {
+	taxes := make(map[string]*InvoiceTax)
+	inv.Subtotal, inv.TaxTotal = 0, 0
+	for _, ln := range inv.Lines {
+		ln.Amount = utils.Round(ln.Amount, globalRoundingDecimals, utils.MetaRoundingMiddle)
+		taxDstID := utils.FirstNonEmpty(ln.DestinationID, utils.MetaAny)
+		ln.TaxRate = taxRates[taxDstID]
+		ln.Tax = utils.Round(ln.Amount*ln.TaxRate/100, globalRoundingDecimals, utils.MetaRoundingMiddle)
+		inv.Subtotal += ln.Amount
+		inv.TaxTotal += ln.Tax
+		if ln.TaxRate == 0 {
+			continue
+		}
+		tx, has := taxes[taxDstID]
+		if !has {
+			tx = &InvoiceTax{DestinationID: taxDstID, Rate: ln.TaxRate}
+			taxes[taxDstID] = tx
+		}
+		tx.Base += ln.Amount
+		tx.Amount += ln.Tax
+	}
+	inv.Taxes = nil
+	for _, taxDstID := range slices.Sorted(maps.Keys(taxes)) {
+		tx := taxes[taxDstID]
+		tx.Base = utils.Round(tx.Base, globalRoundingDecimals, utils.MetaRoundingMiddle)
+		tx.Amount = utils.Round(tx.Amount, globalRoundingDecimals, utils.MetaRoundingMiddle)
+		inv.Taxes = append(inv.Taxes, tx)
+	}
+	inv.Subtotal = utils.Round(inv.Subtotal, globalRoundingDecimals, utils.MetaRoundingMiddle)
+	inv.TaxTotal = utils.Round(inv.TaxTotal, globalRoundingDecimals, utils.MetaRoundingMiddle)
+	inv.Total = utils.Round(inv.Subtotal+inv.TaxTotal, globalRoundingDecimals, utils.MetaRoundingMiddle)
+}
+
+// RenderInvoice returns the invoice document in one of the *json, *csv or *html formats.
+// The *html one uses the template at htmlTmplPath or the built-in one if empty
+func RenderInvoice(inv *Invoice, format, htmlTmplPath string) ([]byte, error) {
+	switch format {
+	case utils.MetaJSON:
+		return json.MarshalIndent(inv, utils.EmptyString, "  ")
+	case utils.MetaCSV:
+		return inv.asCSV()
+	case utils.MetaHTML:
+		return inv.asHTML(htmlTmplPath)
+	}
+	return nil, utils.ErrUnsupportedFormat
+}
+
+// asCSV writes the invoice header, the lines and the totals as separate CSV blocks
+func (inv *Invoice) asCSV() ([]byte, error) {
+	fmtFloat := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	records := [][]string{
+		{utils.InvoiceID, utils.Tenant, utils.AccountField, utils.InvoicePeriodStart,
+			utils.InvoicePeriodEnd, utils.InvoiceIssueTime},
+		{inv.ID, inv.Tenant, inv.Account, inv.PeriodStart.Format(time.RFC3339),
+			inv.PeriodEnd.Format(time.RFC3339), inv.IssueTime.Format(time.RFC3339)},
+		{},
+		{"Type", "Description", utils.ToR, "DestinationID", "Quantity", utils.Usage,
+			"Amount", "TaxRate", "Tax"},
+	}
+	for _, ln := range inv.Lines {
+		records = append(records, []string{ln.Type, ln.Description, ln.ToR, ln.DestinationID,
+			strconv.Itoa(ln.Quantity), ln.Usage.String(), fmtFloat(ln.Amount),
+			fmtFloat(ln.TaxRate), fmtFloat(ln.Tax)})
+	}
+	records = append(records, []string{},
+		[]string{utils.InvoiceSubtotal, utils.InvoiceTaxTotal, utils.InvoiceTotal},
+		[]string{fmtFloat(inv.Subtotal), fmtFloat(inv.TaxTotal), fmtFloat(inv.Total)})
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (inv *Invoice) asHTML(tmplPath string) ([]byte, error) {
+	tmplStr := defaultInvoiceHTMLTemplate
+	if tmplPath != utils.EmptyString {
+		content, err := os.ReadFile(tmplPath)
+		if err != nil {
+			return nil, err
+		}
+		tmplStr = string(content)
+	}
+	tmpl, err := template.New(utils.MetaHTML).Parse(tmplStr)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, inv); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+const defaultInvoiceHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Invoice {{.ID}}</title></head>
+<body>
+<h1>Invoice {{.ID}}</h1>
+<p>Account: {{.Account}}<br>
+Period: {{.PeriodStart.Format "2006-01-02 15:04:05"}} - {{.PeriodEnd.Format "2006-01-02 15:04:05"}}<br>
+Issued: {{.IssueTime.Format "2006-01-02 15:04:05"}}</p>
+<table>
+<tr><th>Type</th><th>Description</th><th>ToR</th><th>Destination</th><th>Quantity</th><th>Usage</th><th>Amount</th><th>Tax rate</th><th>Tax</th></tr>
+{{range .Lines}}<tr><td>{{.Type}}</td><td>{{.Description}}</td><td>{{.ToR}}</td><td>{{.DestinationID}}</td><td>{{.Quantity}}</td><td>{{.Usage}}</td><td>{{.Amount}}</td><td>{{.TaxRate}}%</td><td>{{.Tax}}</td></tr>
+{{end}}</table>
+<p>Subtotal: {{.Subtotal}}<br>
+Taxes: {{.TaxTotal}}<br>
+<b>Total: {{.Total}}</b></p>
+</body>
+</html>
+`
+
+// NewInvoiceS is the constructor for InvoiceS
+func NewInvoiceS(dm *DataManager, connMgr *ConnManager, cfg *config.CGRConfig) *InvoiceS {
+	return &InvoiceS{
+		dm:      dm,
+		connMgr: connMgr,
+		cfg:     cfg,
+	}
+}
+
+// InvoiceS issues the invoices out of the CDRs and the recurring fees of the accounts
+type InvoiceS struct {
+	dm      *DataManager
+	connMgr *ConnManager
+	cfg     *config.CGRConfig
+}
+
+// V1GenerateInvoice issues a new invoice for the account and billing period
+func (invS *InvoiceS) V1GenerateInvoice(ctx *context.Context, args *utils.ArgsGenerateInvoice, reply *Invoice) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.AccountField}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, invS.cfg.GeneralCfg().DefaultTenant)
+	var inv *Invoice
+	if inv, err = invS.generateInvoice(ctx, tnt, args); err != nil {
+		return
+	}
+	*reply = *inv
+	return
+}
+
+// V1GetInvoice returns an issued invoice
+func (invS *InvoiceS) V1GetInvoice(ctx *context.Context, args *utils.TenantIDWithAPIOpts, reply *Invoice) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, invS.cfg.GeneralCfg().DefaultTenant)
+	var inv *Invoice
+	if inv, err = invS.dm.GetInvoice(tnt, args.ID); err != nil {
+		return
+	}
+	*reply = *inv
+	return
+}
+
+// V1GetInvoices returns the invoices of the tenant, ordered by their number
+func (invS *InvoiceS) V1GetInvoices(ctx *context.Context, args *utils.ArgsGetInvoices, reply *[]*Invoice) (err error) {
+	tnt := utils.FirstNonEmpty(args.Tenant, invS.cfg.GeneralCfg().DefaultTenant)
+	var invs []*Invoice
+	if invs, err = invS.dm.GetInvoices(tnt, args.Account); err != nil {
+		return
+	}
+	if len(invs) == 0 {
+		return utils.ErrNotFound
+	}
+	slices.SortFunc(invs, func(a, b *Invoice) int { return a.Sequence - b.Sequence })
+	*reply = invs
+	return
+}
+
+// V1RenderInvoice returns the document of an issued invoice in the requested format
+func (invS *InvoiceS) V1RenderInvoice(ctx *context.Context, args *utils.ArgsRenderInvoice, reply *string) (err error) {
+	if args.TenantID == nil {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	if missing := utils.MissingStructFields(args.TenantID, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, invS.cfg.GeneralCfg().DefaultTenant)
+	var inv *Invoice
+	if inv, err = invS.dm.GetInvoice(tnt, args.ID); err != nil {
+		return
+	}
+	iCfg := invS.cfg.InvoiceSCfg()
+	var doc []byte
+	if doc, err = RenderInvoice(inv, utils.FirstNonEmpty(args.Format, iCfg.DocumentFormat),
+		iCfg.HTMLTemplate); err != nil {
+		return
+	}
+	*reply = string(doc)
+	return
+}
+
+// generateInvoice builds, issues and delivers the invoice of the account,
+// returning the already issued one if the period was invoiced before
+func (invS *InvoiceS) generateInvoice(ctx *context.Context, tnt string, args *utils.ArgsGenerateInvoice) (inv *Invoice, err error) {
+	var start, end time.Time
+	if start, end, err = invoicePeriod(args.PeriodStart, args.PeriodEnd,
+		invS.cfg.GeneralCfg().DefaultTimezone, time.Now()); err != nil {
+		return
+	}
+	if inv, err = invS.issuedInvoice(tnt, args.Account, start, end); err != nil ||
+		inv != nil {
+		return
+	}
+	acntID := utils.ConcatenatedKey(tnt, args.Account)
+	var acc *Account
+	if acc, err = invS.dm.GetAccount(acntID); err != nil {
+		return
+	}
+	inv = &Invoice{
+		Tenant:      tnt,
+		Account:     args.Account,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Balances:    make(map[string]float64),
+	}
+	for blncType, blncs := range acc.BalanceMap {
+		inv.Balances[blncType] = blncs.GetTotalValue()
+	}
+	iCfg := invS.cfg.InvoiceSCfg()
+	var cdrs []*CDR
+	if cdrs, err = invS.getCDRs(ctx, tnt, args.Account, start, end, args.CDRsFilter); err != nil {
+		return
+	}
+	inv.Lines = invS.usageLines(cdrs, iCfg.TaxRates)
+	var fees []*InvoiceLine
+	if fees, err = invS.recurringFees(acntID, start, end); err != nil {
+		return
+	}
+	inv.Lines = append(inv.Lines, fees...)
+	inv.computeTotals(iCfg.TaxRates)
+	var issued bool
+	if issued, err = invS.issue(inv); err != nil || !issued {
+		return // an invoice for the same period was issued meanwhile
+	}
+	if errDlv := invS.deliver(ctx, inv); errDlv != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed delivering invoice <%s> through EEs: %v",
+				utils.InvoiceS, inv.TenantID(), errDlv))
+	}
+	return
+}
+
+// invoicePeriod parses the period limits, defaulting to the calendar month before now
+func invoicePeriod(startStr, endStr, timezone string, now time.Time) (start, end time.Time, err error) {
+	if startStr == utils.EmptyString && endStr == utils.EmptyString {
+		var loc *time.Location
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return
+		}
+		now = now.In(loc)
+		end = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+		return end.AddDate(0, -1, 0), end, nil
+	}
+	if start, err = utils.ParseTimeDetectLayout(startStr, timezone); err != nil {
+		return
+	}
+	if end, err = utils.ParseTimeDetectLayout(endStr, timezone); err != nil {
+		return
+	}
+	if start.IsZero() || end.IsZero() {
+		err = utils.NewErrMandatoryIeMissing(utils.InvoicePeriodStart, utils.InvoicePeriodEnd)
+		return
+	}
+	if !start.Before(end) {
+		err = fmt.Errorf("invoice period ending at <%s> before starting at <%s>", end, start)
+	}
+	return
+}
+
+// getCDRs queries CDRs for the ones answered within the period
+func (invS *InvoiceS) getCDRs(ctx *context.Context, tnt, acnt string, start, end time.Time,
+	extraFltr *utils.RPCCDRsFilter) (cdrs []*CDR, err error) {
+	fltr := new(utils.RPCCDRsFilter)
+	if extraFltr != nil {
+		*fltr = *extraFltr
+	}
+	fltr.Tenants = []string{tnt}
+	fltr.Accounts = []string{acnt}
+	fltr.AnswerTimeStart = start.Format(time.RFC3339)
+	fltr.AnswerTimeEnd = end.Format(time.RFC3339)
+	if len(fltr.RunIDs) == 0 {
+		fltr.RunIDs = invS.cfg.InvoiceSCfg().RunIDs
+	}
+	if err = invS.connMgr.Call(ctx, invS.cfg.InvoiceSCfg().CDRsConns, utils.CDRsV1GetCDRs,
+		&utils.RPCCDRsFilterWithAPIOpts{RPCCDRsFilter: fltr, Tenant: tnt}, &cdrs); err != nil &&
+		err.Error() == utils.ErrNotFound.Error() {
+		err = nil
+	}
+	return
+}
+
+// usageLines aggregates the cost and usage of the CDRs per type of record and
+// the destination they are taxed on
+func (invS *InvoiceS) usageLines(cdrs []*CDR, taxRates map[string]float64) (lines []*InvoiceLine) {
+	lnIdx := make(map[string]*InvoiceLine)
+	var unrated int
+	for _, cdr := range cdrs {
+		if cdr.Cost < 0 {
+			unrated++
+			continue
+		}
+		dstID := invS.taxDestinationID(cdr.Destination, taxRates)
+		lnKey := utils.ConcatenatedKey(cdr.ToR, dstID)
+		ln, has := lnIdx[lnKey]
+		if !has {
+			ln = &InvoiceLine{
+				Type:          utils.MetaUsage,
+				ToR:           cdr.ToR,
+				DestinationID: dstID,
+			}
+			lnIdx[lnKey] = ln
+			lines = append(lines, ln)
+		}
+		ln.Quantity++
+		ln.Usage += cdr.Usage
+		ln.Amount += cdr.Cost
+	}
+	if unrated != 0 {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> skipped %d unrated CDRs", utils.InvoiceS, unrated))
+	}
+	slices.SortFunc(lines, func(a, b *InvoiceLine) int {
+		if c := strings.Compare(a.ToR, b.ToR); c != 0 {
+			return c
+		}
+		return strings.Compare(a.DestinationID, b.DestinationID)
+	})
+	return
+}
+
+// taxDestinationID returns the destination with a tax rate which matches the
+// longest prefix of the number, empty if there is none
+func (invS *InvoiceS) taxDestinationID(dst string, taxRates map[string]float64) string {
+	if len(taxRates) == 0 {
+		return utils.EmptyString
+	}
+	for _, pfx := range utils.SplitPrefix(dst, MIN_PREFIX_MATCH) {
+		dstIDs, err := invS.dm.GetReverseDestination(pfx, true, true, utils.NonTransactional)
+		if err != nil {
+			continue
+		}
+		for _, dstID := range dstIDs {
+			if _, has := taxRates[dstID]; has {
+				return dstID
+			}
+		}
+	}
+	return utils.EmptyString
+}
+
+// recurringFees returns the monetary debits scheduled by the action plans of the
+// account, multiplied with the number of times they were due within the period
+func (invS *InvoiceS) recurringFees(acntID string, start, end time.Time) (lines []*InvoiceLine, err error) {
+	var apIDs []string
+	if apIDs, err = invS.dm.GetAccountActionPlans(acntID, true, true, utils.NonTransactional); err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	for _, apID := range apIDs {
+		var ap *ActionPlan
+		if ap, err = invS.dm.GetActionPlan(apID, true, true, utils.NonTransactional); err != nil {
+			if err == utils.ErrNotFound {
+				err = nil
+				continue
+			}
+			return
+		}
+		for _, at := range ap.ActionTimings {
+			occurrences := actionTimingOccurrences(at, start, end)
+			if occurrences == 0 {
+				continue
+			}
+			var acts Actions
+			if acts, err = invS.dm.GetActions(at.ActionsID, false, utils.NonTransactional); err != nil {
+				return
+			}
+			for _, a := range acts {
+				if (a.ActionType != utils.MetaDebit && a.ActionType != utils.MetaDebitReset) ||
+					a.Balance.GetType() != utils.MetaMonetary {
+					continue
+				}
+				if fee := a.Balance.GetValue(); fee != 0 {
+					lines = append(lines, &InvoiceLine{
+						Type:        utils.MetaRecurring,
+						Description: at.ActionsID,
+						Quantity:    occurrences,
+						Amount:      fee * float64(occurrences),
+					})
+				}
+			}
+		}
+	}
+	return
+}
+
+// actionTimingOccurrences counts the times the action timing was due within [start, end)
+func actionTimingOccurrences(at *ActionTiming, start, end time.Time) (n int) {
+	if at.Timing == nil || at.Timing.Timing == nil || at.IsASAP() ||
+		strings.HasPrefix(at.Timing.Timing.StartTime, utils.PlusChar) {
+		return // one time actions are not recurring
+	}
+	at = at.Clone() // the next start time is cached on the action timing
+	for t := start.Add(-time.Nanosecond); ; n++ {
+		at.ResetStartTimeCache()
+		if t = at.GetNextStartTime(t); t.IsZero() || !t.Before(end) {
+			return
+		}
+	}
+}
+
+// issuedInvoice returns the invoice already issued for the account and period, nil if none
+func (invS *InvoiceS) issuedInvoice(tnt, acnt string, start, end time.Time) (*Invoice, error) {
+	invs, err := invS.dm.GetInvoices(tnt, acnt)
+	if err != nil && err != utils.ErrNotFound {
+		return nil, err
+	}
+	for _, inv := range invs {
+		if inv.PeriodStart.Equal(start) && inv.PeriodEnd.Equal(end) {
+			return inv, nil
+		}
+	}
+	return nil, nil
+}
+
+// issue numbers the invoice with the next sequence of the tenant and stores it.
+// If the period of the account was invoiced meanwhile, inv is replaced with the
+// existing invoice and issued is false.
+func (invS *InvoiceS) issue(inv *Invoice) (issued bool, err error) {
+	err = guardian.Guardian.Guard(func() (err error) {
+		var prev *Invoice
+		if prev, err = invS.issuedInvoice(inv.Tenant, inv.Account,
+			inv.PeriodStart, inv.PeriodEnd); err != nil {
+			return
+		}
+		if prev != nil {
+			*inv = *prev
+			return
+		}
+		var count int
+		if count, err = invS.dm.CountInvoices(inv.Tenant); err != nil {
+			return
+		}
+		inv.Sequence = count + 1
+		inv.ID = fmt.Sprintf("%s%06d", invS.cfg.InvoiceSCfg().NumberPrefix, inv.Sequence)
+		inv.IssueTime = time.Now()
+		if err = invS.dm.SetInvoice(inv); err != nil {
+			return
+		}
+		issued = true
+		return
+	}, invS.cfg.GeneralCfg().LockingTimeout, utils.InvoicePrefix+inv.Tenant)
+	return
+}
+
+// deliver exports the issued invoice, together with its document, through EEs
+func (invS *InvoiceS) deliver(ctx *context.Context, inv *Invoice) (err error) {
+	iCfg := invS.cfg.InvoiceSCfg()
+	if len(iCfg.EEsConns) == 0 {
+		return
+	}
+	var doc []byte
+	if doc, err = RenderInvoice(inv, iCfg.DocumentFormat, iCfg.HTMLTemplate); err != nil {
+		return
+	}
+	invEv := &CGREventWithEeIDs{
+		EeIDs: iCfg.EEsExporterIDs,
+		CGREvent: &utils.CGREvent{
+			Tenant: inv.Tenant,
+			ID:     utils.GenUUID(),
+			Event: map[string]any{
+				utils.InvoiceID:          inv.ID,
+				utils.AccountField:       inv.Account,
+				utils.InvoicePeriodStart: inv.PeriodStart,
+				utils.InvoicePeriodEnd:   inv.PeriodEnd,
+				utils.InvoiceIssueTime:   inv.IssueTime,
+				utils.InvoiceSubtotal:    inv.Subtotal,
+				utils.InvoiceTaxTotal:    inv.TaxTotal,
+				utils.InvoiceTotal:       inv.Total,
+				utils.InvoiceFormat:      iCfg.DocumentFormat,
+				utils.InvoiceDocument:    string(doc),
+			},
+			APIOpts: map[string]any{
+				utils.MetaEventType: utils.InvoiceIssued,
+			},
+		},
+	}
+	var reply map[string]map[string]any
+	if err = invS.connMgr.Call(ctx, iCfg.EEsConns, utils.EeSv1ProcessEvent,
+		invEv, &reply); err != nil && err.Error() == utils.ErrNotFound.Error() {
+		err = nil // no exporter matched
+	}
+	return
+}
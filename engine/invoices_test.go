@@ -0,0 +1,347 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newInvoiceTestS(t *testing.T, cdrs []*CDR, exported chan *CGREventWithEeIDs) *InvoiceS {
+	t.Helper()
+	Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	cfg.InvoiceSCfg().CDRsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs)}
+	cfg.InvoiceSCfg().EEsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)}
+	cfg.InvoiceSCfg().TaxRates = map[string]float64{utils.MetaAny: 10, "DST_DE": 19}
+	dataDB, err := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm := NewDataManager(dataDB, cfg.CacheCfg(), nil)
+	if err = dm.SetAccount(&Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {{Uuid: "uuid1", ID: "main", Value: 10}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = dm.SetDestination(&Destination{Id: "DST_DE", Prefixes: []string{"49"}}, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err = dm.SetReverseDestination("DST_DE", []string{"49"}, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err = dm.SetActions("ACT_FEE", Actions{{
+		Id:         "ACT_FEE",
+		ActionType: utils.MetaDebit,
+		Balance: &BalanceFilter{
+			Type:  utils.StringPointer(utils.MetaMonetary),
+			Value: &utils.ValueFormula{Static: 5},
+		},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if err = dm.SetActionPlan("AP_MONTHLY", &ActionPlan{
+		Id:         "AP_MONTHLY",
+		AccountIDs: utils.StringMap{"cgrates.org:1001": true},
+		ActionTimings: []*ActionTiming{{
+			Uuid: "at1",
+			Timing: &RateInterval{Timing: &RITiming{
+				MonthDays: utils.MonthDays{1},
+				StartTime: "00:00:00",
+			}},
+			ActionsID: "ACT_FEE",
+		}},
+	}, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err = dm.SetAccountActionPlans("cgrates.org:1001", []string{"AP_MONTHLY"}, true); err != nil {
+		t.Fatal(err)
+	}
+	clientConn := make(chan birpc.ClientConnector, 1)
+	clientConn <- &ccMock{
+		calls: map[string]func(ctx *context.Context, args any, reply any) error{
+			utils.CDRsV1GetCDRs: func(ctx *context.Context, args, reply any) error {
+				fltr := args.(*utils.RPCCDRsFilterWithAPIOpts)
+				if !reflect.DeepEqual(fltr.Accounts, []string{"1001"}) ||
+					!reflect.DeepEqual(fltr.RunIDs, []string{utils.MetaDefault}) {
+					t.Errorf("unexpected CDRs filter: %s", utils.ToJSON(fltr))
+				}
+				if len(cdrs) == 0 {
+					return utils.ErrNotFound
+				}
+				*reply.(*[]*CDR) = cdrs
+				return nil
+			},
+			utils.EeSv1ProcessEvent: func(ctx *context.Context, args, reply any) error {
+				exported <- args.(*CGREventWithEeIDs)
+				return nil
+			},
+		},
+	}
+	connMgr := NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs): clientConn,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs):  clientConn,
+	})
+	return NewInvoiceS(dm, connMgr, cfg)
+}
+
+func TestInvoiceSGenerateInvoice(t *testing.T) {
+	exported := make(chan *CGREventWithEeIDs, 1)
+	invS := newInvoiceTestS(t, []*CDR{
+		{ToR: utils.MetaVoice, Destination: "4930123", Usage: time.Minute, Cost: 1.2},
+		{ToR: utils.MetaVoice, Destination: "4930456", Usage: 2 * time.Minute, Cost: 2.3},
+		{ToR: utils.MetaVoice, Destination: "3312345", Usage: time.Minute, Cost: 0.5},
+		{ToR: utils.MetaSMS, Destination: "4930123", Usage: 1, Cost: 0.1},
+		{ToR: utils.MetaVoice, Destination: "4930123", Usage: time.Minute, Cost: -1}, // unrated
+	}, exported)
+	var inv Invoice
+	if err := invS.V1GenerateInvoice(context.Background(), &utils.ArgsGenerateInvoice{
+		Account:     "1001",
+		PeriodStart: "2024-01-01T00:00:00Z",
+		PeriodEnd:   "2024-03-01T00:00:00Z",
+	}, &inv); err != nil {
+		t.Fatal(err)
+	}
+	expLines := []*InvoiceLine{
+		{Type: utils.MetaUsage, ToR: utils.MetaSMS, DestinationID: "DST_DE", Quantity: 1,
+			Usage: 1, Amount: 0.1, TaxRate: 19, Tax: 0.019},
+		{Type: utils.MetaUsage, ToR: utils.MetaVoice, Quantity: 1,
+			Usage: time.Minute, Amount: 0.5, TaxRate: 10, Tax: 0.05},
+		{Type: utils.MetaUsage, ToR: utils.MetaVoice, DestinationID: "DST_DE", Quantity: 2,
+			Usage: 3 * time.Minute, Amount: 3.5, TaxRate: 19, Tax: 0.665},
+		{Type: utils.MetaRecurring, Description: "ACT_FEE", Quantity: 2, Amount: 10, TaxRate: 10, Tax: 1},
+	}
+	if !reflect.DeepEqual(expLines, inv.Lines) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expLines), utils.ToJSON(inv.Lines))
+	}
+	expTaxes := []*InvoiceTax{
+		{DestinationID: utils.MetaAny, Rate: 10, Base: 10.5, Amount: 1.05},
+		{DestinationID: "DST_DE", Rate: 19, Base: 3.6, Amount: 0.684},
+	}
+	if !reflect.DeepEqual(expTaxes, inv.Taxes) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expTaxes), utils.ToJSON(inv.Taxes))
+	}
+	if inv.Subtotal != 14.1 || inv.TaxTotal != 1.734 || inv.Total != 15.834 {
+		t.Errorf("unexpected totals: %v %v %v", inv.Subtotal, inv.TaxTotal, inv.Total)
+	}
+	if inv.ID != "INV000001" || inv.Sequence != 1 || inv.IssueTime.IsZero() ||
+		!reflect.DeepEqual(inv.Balances, map[string]float64{utils.MetaMonetary: 10}) {
+		t.Errorf("unexpected invoice: %s", utils.ToJSON(inv))
+	}
+	select {
+	case ev := <-exported:
+		if ev.Event[utils.InvoiceID] != "INV000001" || ev.APIOpts[utils.MetaEventType] != utils.InvoiceIssued {
+			t.Errorf("unexpected event: %s", utils.ToJSON(ev))
+		}
+		var doc Invoice
+		if err := json.Unmarshal([]byte(ev.Event[utils.InvoiceDocument].(string)), &doc); err != nil {
+			t.Error(err)
+		} else if doc.Total != inv.Total {
+			t.Errorf("unexpected document: %s", ev.Event[utils.InvoiceDocument])
+		}
+	default:
+		t.Error("invoice not exported")
+	}
+
+	var rcv Invoice
+	if err := invS.V1GetInvoice(context.Background(),
+		&utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{ID: "INV000001"}}, &rcv); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(utils.ToJSON(inv), utils.ToJSON(rcv)) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(inv), utils.ToJSON(rcv))
+	}
+}
+
+func TestInvoiceSNumbering(t *testing.T) {
+	invS := newInvoiceTestS(t, nil, make(chan *CGREventWithEeIDs, 3))
+	for i := range 3 {
+		var inv Invoice
+		if err := invS.V1GenerateInvoice(context.Background(), &utils.ArgsGenerateInvoice{
+			Account:     "1001",
+			PeriodStart: fmt.Sprintf("2024-0%d-01T00:00:00Z", i+1),
+			PeriodEnd:   fmt.Sprintf("2024-0%d-01T00:00:00Z", i+2),
+		}, &inv); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var invs []*Invoice
+	if err := invS.V1GetInvoices(context.Background(), &utils.ArgsGetInvoices{Account: "1001"}, &invs); err != nil {
+		t.Fatal(err)
+	}
+	if len(invs) != 3 {
+		t.Fatalf("expected 3 invoices, received %s", utils.ToJSON(invs))
+	}
+	for i, inv := range invs {
+		if inv.Sequence != i+1 || inv.ID != invS.cfg.InvoiceSCfg().NumberPrefix+"00000"+string(rune('1'+i)) {
+			t.Errorf("unexpected numbering: %s", utils.ToJSON(inv))
+		}
+	}
+	if err := invS.V1GetInvoices(context.Background(), &utils.ArgsGetInvoices{Account: "1002"},
+		&invs); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+
+	// issued invoices cannot be overwritten
+	invs[0].Total = 0
+	if err := invS.dm.SetInvoice(invs[0]); err != utils.ErrExists {
+		t.Errorf("expected %v, received %v", utils.ErrExists, err)
+	}
+}
+
+func TestInvoiceSGenerateInvoiceTwice(t *testing.T) {
+	exported := make(chan *CGREventWithEeIDs, 2)
+	invS := newInvoiceTestS(t, []*CDR{
+		{ToR: utils.MetaVoice, Destination: "4930123", Usage: time.Minute, Cost: 1.2},
+	}, exported)
+	args := &utils.ArgsGenerateInvoice{
+		Account:     "1001",
+		PeriodStart: "2024-01-01T00:00:00Z",
+		PeriodEnd:   "2024-02-01T00:00:00Z",
+	}
+	var inv, again Invoice
+	if err := invS.V1GenerateInvoice(context.Background(), args, &inv); err != nil {
+		t.Fatal(err)
+	}
+	if err := invS.V1GenerateInvoice(context.Background(), args, &again); err != nil {
+		t.Fatal(err)
+	}
+	if utils.ToJSON(inv) != utils.ToJSON(again) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(inv), utils.ToJSON(again))
+	}
+	if cnt, err := invS.dm.CountInvoices("cgrates.org"); err != nil {
+		t.Error(err)
+	} else if cnt != 1 {
+		t.Errorf("expected 1 invoice, received %d", cnt)
+	}
+	if len(exported) != 1 {
+		t.Errorf("expected a single delivery, received %d", len(exported))
+	}
+
+	// the check under the lock covers the concurrent generations
+	dup := &Invoice{Tenant: "cgrates.org", Account: "1001",
+		PeriodStart: inv.PeriodStart, PeriodEnd: inv.PeriodEnd}
+	if issued, err := invS.issue(dup); err != nil {
+		t.Error(err)
+	} else if issued || dup.ID != inv.ID {
+		t.Errorf("expected the existing invoice, received %s", utils.ToJSON(dup))
+	}
+}
+
+func TestInvoicePeriod(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	start, end, err := invoicePeriod(utils.EmptyString, utils.EmptyString, "UTC", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !start.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)) ||
+		!end.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected period: %v - %v", start, end)
+	}
+	if _, _, err = invoicePeriod("2024-01-01T00:00:00Z", utils.EmptyString, "UTC", now); err == nil ||
+		err.Error() != "MANDATORY_IE_MISSING: [PeriodStart PeriodEnd]" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, _, err = invoicePeriod("2024-02-01T00:00:00Z", "2024-01-01T00:00:00Z", "UTC", now); err == nil {
+		t.Error("expected error for period ending before it starts")
+	}
+}
+
+func TestActionTimingOccurrences(t *testing.T) {
+	at := &ActionTiming{Timing: &RateInterval{Timing: &RITiming{
+		WeekDays:  utils.WeekDays{time.Monday},
+		StartTime: "00:00:00",
+	}}}
+	// 4 Mondays in February 2024, the 5th of February being the first one
+	if n := actionTimingOccurrences(at, time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)); n != 4 {
+		t.Errorf("expected 4 occurrences, received %d", n)
+	}
+	if n := actionTimingOccurrences(at, time.Date(2024, 2, 5, 0, 0, 0, 0, time.Local),
+		time.Date(2024, 2, 12, 0, 0, 0, 0, time.Local)); n != 1 {
+		t.Errorf("expected the period start to be included, received %d occurrences", n)
+	}
+	at.Timing.Timing.StartTime = utils.MetaASAP
+	if n := actionTimingOccurrences(at, time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)); n != 0 {
+		t.Errorf("expected no occurrences for *asap timings, received %d", n)
+	}
+}
+
+func TestRenderInvoice(t *testing.T) {
+	inv := &Invoice{
+		Tenant:      "cgrates.org",
+		ID:          "INV000001",
+		Account:     "1001",
+		PeriodStart: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		IssueTime:   time.Date(2024, 2, 1, 1, 0, 0, 0, time.UTC),
+		Lines: []*InvoiceLine{{Type: utils.MetaUsage, ToR: utils.MetaVoice, Quantity: 2,
+			Usage: 2 * time.Minute, Amount: 1.5, TaxRate: 10, Tax: 0.15}},
+		Subtotal: 1.5,
+		TaxTotal: 0.15,
+		Total:    1.65,
+	}
+	doc, err := RenderInvoice(inv, utils.MetaCSV, utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expCSV := `InvoiceID,Tenant,Account,PeriodStart,PeriodEnd,IssueTime
+INV000001,cgrates.org,1001,2024-01-01T00:00:00Z,2024-02-01T00:00:00Z,2024-02-01T01:00:00Z
+
+Type,Description,ToR,DestinationID,Quantity,Usage,Amount,TaxRate,Tax
+*usage,,*voice,,2,2m0s,1.5,10,0.15
+
+Subtotal,TaxTotal,Total
+1.5,0.15,1.65
+`
+	if string(doc) != expCSV {
+		t.Errorf("expected %q, received %q", expCSV, doc)
+	}
+	if doc, err = RenderInvoice(inv, utils.MetaHTML, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	} else if !strings.Contains(string(doc), "<b>Total: 1.65</b>") {
+		t.Errorf("unexpected document: %s", doc)
+	}
+	tmplPath := filepath.Join(t.TempDir(), "invoice.html")
+	if err = os.WriteFile(tmplPath, []byte(`{{.ID}}: {{.Total}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if doc, err = RenderInvoice(inv, utils.MetaHTML, tmplPath); err != nil {
+		t.Fatal(err)
+	} else if string(doc) != "INV000001: 1.65" {
+		t.Errorf("unexpected document: %s", doc)
+	}
+	if _, err = RenderInvoice(inv, utils.MetaXml, utils.EmptyString); err != utils.ErrUnsupportedFormat {
+		t.Errorf("expected %v, received %v", utils.ErrUnsupportedFormat, err)
+	}
+}
@@ -89,13 +89,16 @@ func (p *IPProfile) CacheClone() any {
 
 // Lock acquires a guardian lock on the IPProfile and stores the lock ID.
 // Uses given lockID or creates a new lock.
-func (p *IPProfile) lock(lockID string) {
+func (p *IPProfile) lock(lockID string) (err error) {
 	if lockID == "" {
-		lockID = guardian.Guardian.GuardIDs("",
+		if lockID, err = guardian.Guardian.GuardIDs("",
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			ipProfileLockKey(p.Tenant, p.ID))
+			ipProfileLockKey(p.Tenant, p.ID)); err != nil {
+			return
+		}
 	}
 	p.lockID = lockID
+	return
 }
 
 // Unlock releases the lock on the IPProfile and clears the stored lock ID.
@@ -201,8 +204,9 @@ type IPAllocations struct {
 	TTLIndex    []string                   // allocIDs ordered by allocation time for TTL expiry
 
 	prfl       *IPProfile
-	poolRanges map[string]netip.Prefix          // parsed CIDR ranges by pool ID
+	poolRanges map[string]*ipPoolRange          // parsed ranges by pool ID
 	poolAllocs map[string]map[netip.Addr]string // IP to allocation ID mapping by pool (map[poolID]map[Addr]allocID)
+	poolUsed   map[string]*ipSpanSet            // free-address index with allocated and excluded IPs by pool
 	lockID     string
 }
 
@@ -231,33 +235,54 @@ func (a *IPAllocations) computeUnexported(prfl *IPProfile) error {
 		return nil // already computed for this profile
 	}
 	a.prfl = prfl
-	a.poolAllocs = make(map[string]map[netip.Addr]string)
-	for allocID, alloc := range a.Allocations {
-		if _, hasPool := a.poolAllocs[alloc.PoolID]; !hasPool {
-			a.poolAllocs[alloc.PoolID] = make(map[netip.Addr]string)
-		}
-		a.poolAllocs[alloc.PoolID][alloc.Address] = allocID
-	}
-	a.poolRanges = make(map[string]netip.Prefix)
+	a.poolRanges = make(map[string]*ipPoolRange)
+	a.poolUsed = make(map[string]*ipSpanSet)
 	for _, poolCfg := range a.prfl.Pools {
-		prefix, err := netip.ParsePrefix(poolCfg.Range)
+		poolRange, err := parseIPPoolRange(poolCfg.Range)
 		if err != nil {
-			return err
+			return fmt.Errorf("invalid range for pool %q: %w", poolCfg.ID, err)
 		}
-		a.poolRanges[poolCfg.ID] = prefix
+		a.poolRanges[poolCfg.ID] = poolRange
+		a.poolUsed[poolCfg.ID] = poolRange.newUsedSet()
+	}
+	a.poolAllocs = make(map[string]map[netip.Addr]string)
+	for allocID, alloc := range a.Allocations {
+		a.indexAllocation(allocID, alloc)
 	}
 	return nil
 }
 
+// indexAllocation marks the address of alloc as used within its pool.
+func (a *IPAllocations) indexAllocation(allocID string, alloc *PoolAllocation) {
+	if _, hasPool := a.poolAllocs[alloc.PoolID]; !hasPool {
+		a.poolAllocs[alloc.PoolID] = make(map[netip.Addr]string)
+	}
+	a.poolAllocs[alloc.PoolID][alloc.Address] = allocID
+	if used, hasPool := a.poolUsed[alloc.PoolID]; hasPool {
+		used.add(alloc.Address)
+	}
+}
+
+// unindexAllocation marks the address of alloc as free within its pool.
+// Excluded addresses stay in the free-address index.
+func (a *IPAllocations) unindexAllocation(alloc *PoolAllocation) {
+	if poolMap, hasPool := a.poolAllocs[alloc.PoolID]; hasPool {
+		delete(poolMap, alloc.Address)
+	}
+	used, hasPool := a.poolUsed[alloc.PoolID]
+	if !hasPool || a.poolRanges[alloc.PoolID].excluded.has(alloc.Address) {
+		return
+	}
+	used.remove(alloc.Address)
+}
+
 // releaseAllocation releases the allocation for an ID.
 func (a *IPAllocations) releaseAllocation(allocID string) error {
 	alloc, has := a.Allocations[allocID] // Get the allocation first
 	if !has {
 		return fmt.Errorf("cannot find allocation record with id: %s", allocID)
 	}
-	if poolMap, hasPool := a.poolAllocs[alloc.PoolID]; hasPool {
-		delete(poolMap, alloc.Address)
-	}
+	a.unindexAllocation(alloc)
 	if a.prfl.TTL > 0 {
 		for i, refID := range a.TTLIndex {
 			if refID == allocID {
@@ -276,6 +301,9 @@ func (a *IPAllocations) clearAllocations(allocIDs []string) error {
 	if len(allocIDs) == 0 {
 		clear(a.Allocations)
 		clear(a.poolAllocs)
+		for poolID, poolRange := range a.poolRanges {
+			a.poolUsed[poolID] = poolRange.newUsedSet()
+		}
 		a.TTLIndex = a.TTLIndex[:0] // maintain capacity
 		return nil
 	}
@@ -292,10 +320,7 @@ func (a *IPAllocations) clearAllocations(allocIDs []string) error {
 	}
 
 	for _, allocID := range allocIDs {
-		alloc := a.Allocations[allocID]
-		if poolMap, hasPool := a.poolAllocs[alloc.PoolID]; hasPool {
-			delete(poolMap, alloc.Address)
-		}
+		a.unindexAllocation(a.Allocations[allocID])
 		if a.prfl.TTL > 0 {
 			for i, refID := range a.TTLIndex {
 				if refID == allocID {
@@ -329,16 +354,22 @@ func (a *IPAllocations) allocateIPOnPool(allocID string, pool *IPPool,
 			Address:   poolAlloc.Address,
 		}, nil
 	}
-	poolRange := a.poolRanges[pool.ID]
-	if !poolRange.IsSingleIP() {
-		return nil, errors.New("only single IP Pools are supported for now")
+	poolRange, hasRange := a.poolRanges[pool.ID]
+	if !hasRange {
+		return nil, fmt.Errorf("pool %q: %w", pool.ID, utils.ErrNotFound)
 	}
-	addr := poolRange.Addr()
-	if _, hasPool := a.poolAllocs[pool.ID]; hasPool {
-		if alcID, inUse := a.poolAllocs[pool.ID][addr]; inUse {
+	addr, found, err := poolRange.freeAddr(*a.poolUsed[pool.ID], pool.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if alcID, inUse := a.poolAllocs[pool.ID][poolRange.first]; inUse &&
+			poolRange.first == poolRange.last {
 			return nil, fmt.Errorf("allocation failed for pool %q, IP %q: %w (allocated to %q)",
-				pool.ID, addr, utils.ErrIPAlreadyAllocated, alcID)
+				pool.ID, poolRange.first, utils.ErrIPAlreadyAllocated, alcID)
 		}
+		return nil, fmt.Errorf("allocation failed for pool %q: %w (no free addresses left)",
+			pool.ID, utils.ErrIPAlreadyAllocated)
 	}
 	allocIP := &AllocatedIP{
 		ProfileID: a.ID,
@@ -349,15 +380,13 @@ func (a *IPAllocations) allocateIPOnPool(allocID string, pool *IPPool,
 	if dryRun {
 		return allocIP, nil
 	}
-	a.Allocations[allocID] = &PoolAllocation{
+	alloc := &PoolAllocation{
 		PoolID:  pool.ID,
 		Address: addr,
 		Time:    time.Now(),
 	}
-	if _, hasPool := a.poolAllocs[pool.ID]; !hasPool {
-		a.poolAllocs[pool.ID] = make(map[netip.Addr]string)
-	}
-	a.poolAllocs[pool.ID][addr] = allocID
+	a.Allocations[allocID] = alloc
+	a.indexAllocation(allocID, alloc)
 	return allocIP, nil
 }
 
@@ -371,9 +400,7 @@ func (a *IPAllocations) removeExpiredUnits() {
 			break
 		}
 		if alloc != nil {
-			if poolMap, hasPool := a.poolAllocs[alloc.PoolID]; hasPool {
-				delete(poolMap, alloc.Address)
-			}
+			a.unindexAllocation(alloc)
 		}
 		delete(a.Allocations, allocID)
 		expiredCount++
@@ -395,13 +422,16 @@ func (a *IPAllocations) removeAllocFromTTLIndex(allocID string) {
 
 // lock acquires a guardian lock on the IPAllocations and stores the lock ID.
 // Uses given lockID (assumes already acquired) or creates a new lock.
-func (a *IPAllocations) lock(lockID string) {
+func (a *IPAllocations) lock(lockID string) (err error) {
 	if lockID == "" {
-		lockID = guardian.Guardian.GuardIDs("",
+		if lockID, err = guardian.Guardian.GuardIDs("",
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			ipAllocationsLockKey(a.Tenant, a.ID))
+			ipAllocationsLockKey(a.Tenant, a.ID)); err != nil {
+			return
+		}
 	}
 	a.lockID = lockID
+	return
 }
 
 // unlock releases the lock on the IPAllocations and clears the stored lock ID.
@@ -453,6 +483,13 @@ func (a *IPAllocations) Clone() *IPAllocations {
 			clone.poolAllocs[poolID] = maps.Clone(allocs)
 		}
 	}
+	if a.poolUsed != nil {
+		clone.poolUsed = make(map[string]*ipSpanSet, len(a.poolUsed))
+		for poolID, used := range a.poolUsed {
+			usedClone := used.clone()
+			clone.poolUsed[poolID] = &usedClone
+		}
+	}
 	if a.Allocations != nil {
 		clone.Allocations = make(map[string]*PoolAllocation, len(a.Allocations))
 		for id, alloc := range a.Allocations {
@@ -549,7 +586,11 @@ func (s *IPService) storeIPAllocationsList() {
 			continue
 		}
 		allocs := allocIf.(*IPAllocations)
-		allocs.lock(utils.EmptyString)
+		if err := allocs.lock(utils.EmptyString); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> %v", utils.IPs, err))
+			failedAllocIDs = append(failedAllocIDs, allocsID)
+			continue
+		}
 		if err := s.storeIPAllocations(allocs); err != nil {
 			utils.Logger.Warning(fmt.Sprintf("<%s> %v", utils.IPs, err))
 			failedAllocIDs = append(failedAllocIDs, allocsID) // record failure so we can schedule it for next backup
@@ -651,9 +692,15 @@ func (s *IPService) matchingIPAllocationsForEvent(tnt string,
 	var matchedPrfl *IPProfile
 	var maxWeight float64
 	for _, id := range itemIDs {
-		lkPrflID := guardian.Guardian.GuardIDs("",
+		var lkPrflID string
+		if lkPrflID, err = guardian.Guardian.GuardIDs("",
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			ipProfileLockKey(tnt, id))
+			ipProfileLockKey(tnt, id)); err != nil {
+			if matchedPrfl != nil {
+				matchedPrfl.unlock()
+			}
+			return nil, err
+		}
 		var prfl *IPProfile
 		if prfl, err = s.dm.GetIPProfile(tnt, id, true, true, utils.NonTransactional); err != nil {
 			guardian.Guardian.UnguardIDs(lkPrflID)
@@ -689,9 +736,13 @@ func (s *IPService) matchingIPAllocationsForEvent(tnt string,
 	if matchedPrfl == nil {
 		return nil, utils.ErrNotFound
 	}
-	lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+	var lkID string
+	if lkID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
 		config.CgrConfig().GeneralCfg().LockingTimeout,
-		ipAllocationsLockKey(matchedPrfl.Tenant, matchedPrfl.ID))
+		ipAllocationsLockKey(matchedPrfl.Tenant, matchedPrfl.ID)); err != nil {
+		matchedPrfl.unlock()
+		return nil, err
+	}
 	allocs, err = s.dm.GetIPAllocations(matchedPrfl.Tenant, matchedPrfl.ID, true, true, "", matchedPrfl)
 	if err != nil {
 		guardian.Guardian.UnguardIDs(lkID)
@@ -792,8 +843,12 @@ func (s *IPService) V1GetIPAllocationForEvent(ctx *context.Context, args *utils.
 	// RPC caching
 	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.IPsV1GetIPAllocationForEvent, utils.ConcatenatedKey(tnt, args.ID))
-		refID := guardian.Guardian.GuardIDs("",
-			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
 			cachedResp := itm.(*utils.CachedRPCResponse)
@@ -837,8 +892,12 @@ func (s *IPService) V1AuthorizeIP(ctx *context.Context, args *utils.CGREvent, re
 	// RPC caching
 	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.IPsV1AuthorizeIP, utils.ConcatenatedKey(tnt, args.ID))
-		refID := guardian.Guardian.GuardIDs("",
-			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
 			cachedResp := itm.(*utils.CachedRPCResponse)
@@ -897,8 +956,12 @@ func (s *IPService) V1AllocateIP(ctx *context.Context, args *utils.CGREvent, rep
 	// RPC caching
 	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.IPsV1AllocateIP, utils.ConcatenatedKey(tnt, args.ID))
-		refID := guardian.Guardian.GuardIDs("",
-			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
 			cachedResp := itm.(*utils.CachedRPCResponse)
@@ -958,8 +1021,12 @@ func (s *IPService) V1ReleaseIP(ctx *context.Context, args *utils.CGREvent, repl
 	// RPC caching
 	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.IPsV1ReleaseIP, utils.ConcatenatedKey(tnt, args.ID))
-		refID := guardian.Guardian.GuardIDs("",
-			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
 			cachedResp := itm.(*utils.CachedRPCResponse)
@@ -1005,9 +1072,12 @@ func (s *IPService) V1GetIPAllocations(ctx *context.Context, arg *utils.TenantID
 	}
 
 	// make sure resource is locked at process level
-	lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+	lkID, err := guardian.Guardian.GuardIDs(utils.EmptyString,
 		config.CgrConfig().GeneralCfg().LockingTimeout,
 		ipAllocationsLockKey(tnt, arg.ID))
+	if err != nil {
+		return err
+	}
 	defer guardian.Guardian.UnguardIDs(lkID)
 
 	ip, err := s.dm.GetIPAllocations(tnt, arg.ID, true, true, utils.NonTransactional, nil)
@@ -1030,9 +1100,12 @@ func (s *IPService) V1ClearIPAllocations(ctx *context.Context, args *ClearIPAllo
 		tnt = s.cfg.GeneralCfg().DefaultTenant
 	}
 
-	lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+	lkID, err := guardian.Guardian.GuardIDs(utils.EmptyString,
 		config.CgrConfig().GeneralCfg().LockingTimeout,
 		ipAllocationsLockKey(tnt, args.ID))
+	if err != nil {
+		return err
+	}
 	defer guardian.Guardian.UnguardIDs(lkID)
 
 	allocs, err := s.dm.GetIPAllocations(tnt, args.ID, true, true, utils.NonTransactional, nil)
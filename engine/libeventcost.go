@@ -345,6 +345,8 @@ type RatingUnit struct {
 	TimingID         string // This RatingUnit is bounded to specific timing profile
 	RatesID          string
 	RatingFiltersID  string
+	UsageCounter     string        // the account usage counter of the tiered rates
+	UsageTier        time.Duration // GroupIntervalStart of the tier applied on the UsageCounter
 }
 
 // Equals returns if RatingUnit is equal to the other
@@ -356,7 +358,9 @@ func (ru *RatingUnit) Equals(oRU *RatingUnit) bool {
 		ru.MaxCostStrategy == oRU.MaxCostStrategy &&
 		ru.TimingID == oRU.TimingID &&
 		ru.RatesID == oRU.RatesID &&
-		ru.RatingFiltersID == oRU.RatingFiltersID
+		ru.RatingFiltersID == oRU.RatingFiltersID &&
+		ru.UsageCounter == oRU.UsageCounter &&
+		ru.UsageTier == oRU.UsageTier
 }
 
 // Clone creates a copy of RatingUnit
@@ -390,6 +394,10 @@ func (ru RatingUnit) FieldAsInterface(fldPath []string) (val any, err error) {
 		return ru.RatesID, nil
 	case utils.RatingFiltersID:
 		return ru.RatingFiltersID, nil
+	case utils.UsageCounter:
+		return ru.UsageCounter, nil
+	case utils.UsageTier:
+		return ru.UsageTier, nil
 	}
 }
 
@@ -150,8 +150,11 @@ func addItemToFilterIndex(dm *DataManager, idxItmType, tnt, ctx, itemID string,
 	}
 	// early lock to be sure that until we do not write back the indexes
 	// another goroutine can't create new indexes
-	refID := guardian.Guardian.GuardIDs(utils.EmptyString,
-		config.CgrConfig().GeneralCfg().LockingTimeout, idxItmType+tntCtx)
+	var refID string
+	if refID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
+		config.CgrConfig().GeneralCfg().LockingTimeout, idxItmType+tntCtx); err != nil {
+		return
+	}
 	defer guardian.Guardian.UnguardIDs(refID)
 
 	var indexes map[string]utils.StringSet
@@ -181,8 +184,11 @@ func removeItemFromFilterIndex(dm *DataManager, idxItmType, tnt, ctx, itemID str
 	}
 	// early lock to be sure that until we do not write back the indexes
 	// another goroutine can't create new indexes
-	refID := guardian.Guardian.GuardIDs(utils.EmptyString,
-		config.CgrConfig().GeneralCfg().LockingTimeout, idxItmType+tntCtx)
+	var refID string
+	if refID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
+		config.CgrConfig().GeneralCfg().LockingTimeout, idxItmType+tntCtx); err != nil {
+		return
+	}
 	defer guardian.Guardian.UnguardIDs(refID)
 
 	var indexes map[string]utils.StringSet
@@ -441,8 +447,11 @@ func ComputeIndexes(dm *DataManager, tnt, ctx, idxItmType string, IDs *[]string,
 
 	// early lock to be sure that until we do not write back the indexes
 	// another goroutine can't create new indexes
-	refID := guardian.Guardian.GuardIDs(utils.EmptyString,
-		config.CgrConfig().GeneralCfg().LockingTimeout, idxItmType+tntCtx)
+	var refID string
+	if refID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
+		config.CgrConfig().GeneralCfg().LockingTimeout, idxItmType+tntCtx); err != nil {
+		return
+	}
 	defer guardian.Guardian.UnguardIDs(refID)
 	for _, id := range profilesIDs {
 		var filterIDs *[]string
@@ -476,8 +485,11 @@ func addIndexFiltersItem(dm *DataManager, idxItmType, tnt, itemID string, filter
 			continue
 		}
 		tntCtx := utils.ConcatenatedKey(tnt, ID)
-		refID := guardian.Guardian.GuardIDs(utils.EmptyString,
-			config.CgrConfig().GeneralCfg().LockingTimeout, utils.CacheReverseFilterIndexes+tntCtx)
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
+			config.CgrConfig().GeneralCfg().LockingTimeout, utils.CacheReverseFilterIndexes+tntCtx); err != nil {
+			return
+		}
 		var indexes map[string]utils.StringSet
 		if indexes, err = dm.GetIndexes(utils.CacheReverseFilterIndexes, tntCtx,
 			true, false, idxItmType); err != nil {
@@ -513,8 +525,11 @@ func removeIndexFiltersItem(dm *DataManager, idxItmType, tnt, itemID string, fil
 			continue
 		}
 		tntCtx := utils.ConcatenatedKey(tnt, ID)
-		refID := guardian.Guardian.GuardIDs(utils.EmptyString,
-			config.CgrConfig().GeneralCfg().LockingTimeout, utils.CacheReverseFilterIndexes+tntCtx)
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
+			config.CgrConfig().GeneralCfg().LockingTimeout, utils.CacheReverseFilterIndexes+tntCtx); err != nil {
+			return
+		}
 		var indexes map[string]utils.StringSet
 		if indexes, err = dm.GetIndexes(utils.CacheReverseFilterIndexes, tntCtx,
 			true, false, idxItmType); err != nil {
@@ -621,8 +636,11 @@ func UpdateFilterIndex(dm *DataManager, oldFlt, newFlt *Filter) (err error) {
 	}
 
 	tntID := newFlt.TenantID()
-	refID := guardian.Guardian.GuardIDs(utils.EmptyString,
-		config.CgrConfig().GeneralCfg().LockingTimeout, utils.CacheReverseFilterIndexes+tntID)
+	var refID string
+	if refID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
+		config.CgrConfig().GeneralCfg().LockingTimeout, utils.CacheReverseFilterIndexes+tntID); err != nil {
+		return
+	}
 	defer guardian.Guardian.UnguardIDs(refID)
 	var rcvIndx map[string]utils.StringSet
 	// get all reverse indexes from DB
@@ -732,6 +750,24 @@ func UpdateFilterIndex(dm *DataManager, oldFlt, newFlt *Filter) (err error) {
 				}, newFlt); err != nil && err != utils.ErrNotFound {
 				return utils.APIErrorHandler(err)
 			}
+		case utils.CacheTaxProfileFilterIndexes:
+			if err = removeFilterIndexesForFilter(dm, idxItmType, newFlt.Tenant, // remove the indexes for the filter
+				removeIndexKeys, indx); err != nil {
+				return
+			}
+			idxSlice := indx.AsSlice()
+			if _, err = ComputeIndexes(dm, newFlt.Tenant, utils.EmptyString, idxItmType, // compute all the indexes for afected items
+				&idxSlice, utils.NonTransactional, func(tnt, id, ctx string) (*[]string, error) {
+					tp, e := dm.GetTaxProfile(tnt, id, true, false, utils.NonTransactional)
+					if e != nil {
+						return nil, e
+					}
+					fltrIDs := make([]string, len(tp.FilterIDs))
+					copy(fltrIDs, tp.FilterIDs)
+					return &fltrIDs, nil
+				}, newFlt); err != nil && err != utils.ErrNotFound {
+				return utils.APIErrorHandler(err)
+			}
 		case utils.CacheAttributeFilterIndexes:
 			for itemID := range indx {
 				var ap *AttributeProfile
@@ -746,8 +782,11 @@ func UpdateFilterIndex(dm *DataManager, oldFlt, newFlt *Filter) (err error) {
 						removeIndexKeys, indx); err != nil {
 						return
 					}
-					refID := guardian.Guardian.GuardIDs(utils.EmptyString,
-						config.CgrConfig().GeneralCfg().LockingTimeout, idxItmType+tntCtx)
+					var refID string
+					if refID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
+						config.CgrConfig().GeneralCfg().LockingTimeout, idxItmType+tntCtx); err != nil {
+						return
+					}
 					var updIdx map[string]utils.StringSet
 					if updIdx, err = newFilterIndex(dm, idxItmType,
 						newFlt.Tenant, ctx, itemID, ap.FilterIDs, newFlt); err != nil {
@@ -779,8 +818,11 @@ func UpdateFilterIndex(dm *DataManager, oldFlt, newFlt *Filter) (err error) {
 						removeIndexKeys, indx); err != nil {
 						return
 					}
-					refID := guardian.Guardian.GuardIDs(utils.EmptyString,
-						config.CgrConfig().GeneralCfg().LockingTimeout, idxItmType+tntCtx)
+					var refID string
+					if refID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
+						config.CgrConfig().GeneralCfg().LockingTimeout, idxItmType+tntCtx); err != nil {
+						return
+					}
 					var updIdx map[string]utils.StringSet
 					if updIdx, err = newFilterIndex(dm, idxItmType,
 						newFlt.Tenant, ctx, itemID, dp.FilterIDs, newFlt); err != nil {
@@ -810,8 +852,11 @@ func removeFilterIndexesForFilter(dm *DataManager, itemType, tnt string,
 	if len(removeIndexKeys) == 0 {
 		return nil // no indexes to remove
 	}
-	refID := guardian.Guardian.GuardIDs(utils.EmptyString,
+	refID, err := guardian.Guardian.GuardIDs(utils.EmptyString,
 		config.CgrConfig().GeneralCfg().LockingTimeout, itemType+tnt)
+	if err != nil {
+		return err
+	}
 	defer guardian.Guardian.UnguardIDs(refID)
 
 	indexes, err := dm.GetIndexes(itemType, tnt, true, false, removeIndexKeys...)
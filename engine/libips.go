@@ -0,0 +1,294 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ipSpan is an inclusive interval of IP addresses of the same family.
+type ipSpan struct {
+	first netip.Addr
+	last  netip.Addr
+}
+
+// adjacentOrAfter reports whether last is equal, greater or immediately
+// before addr, meaning that a span ending in last can absorb addr.
+func adjacentOrAfter(last, addr netip.Addr) bool {
+	return last.Compare(addr) >= 0 || last.Next() == addr
+}
+
+// ipSpanSet is a sorted list of disjoint and non-adjacent IP spans. It is
+// used as free-address index for IP pools: every used (allocated or
+// excluded) address is part of one span, so the first free address after
+// a span is always the one following its last address.
+type ipSpanSet []ipSpan
+
+// index returns the position of the first span ending at or after addr.
+func (s ipSpanSet) index(addr netip.Addr) int {
+	return sort.Search(len(s), func(i int) bool {
+		return s[i].last.Compare(addr) >= 0
+	})
+}
+
+// spanOf returns the span containing addr.
+func (s ipSpanSet) spanOf(addr netip.Addr) (ipSpan, bool) {
+	if i := s.index(addr); i < len(s) && s[i].first.Compare(addr) <= 0 {
+		return s[i], true
+	}
+	return ipSpan{}, false
+}
+
+// has checks if addr is part of the set.
+func (s ipSpanSet) has(addr netip.Addr) bool {
+	_, has := s.spanOf(addr)
+	return has
+}
+
+// add inserts one address into the set.
+func (s *ipSpanSet) add(addr netip.Addr) {
+	s.addSpan(addr, addr)
+}
+
+// addSpan inserts the [first, last] interval into the set, merging it with
+// the overlapping or adjacent spans.
+func (s *ipSpanSet) addSpan(first, last netip.Addr) {
+	lo := sort.Search(len(*s), func(i int) bool {
+		return adjacentOrAfter((*s)[i].last, first)
+	})
+	hi := lo
+	for hi < len(*s) && adjacentOrAfter(last, (*s)[hi].first) {
+		hi++
+	}
+	if lo == hi {
+		*s = slices.Insert(*s, lo, ipSpan{first: first, last: last})
+		return
+	}
+	merged := ipSpan{first: first, last: last}
+	if (*s)[lo].first.Less(first) {
+		merged.first = (*s)[lo].first
+	}
+	if last.Less((*s)[hi-1].last) {
+		merged.last = (*s)[hi-1].last
+	}
+	*s = slices.Replace(*s, lo, hi, merged)
+}
+
+// remove takes out one address from the set, splitting its span if needed.
+func (s *ipSpanSet) remove(addr netip.Addr) {
+	i := s.index(addr)
+	if i == len(*s) || addr.Less((*s)[i].first) {
+		return
+	}
+	span := (*s)[i]
+	switch {
+	case span.first == addr && span.last == addr:
+		*s = slices.Delete(*s, i, i+1)
+	case span.first == addr:
+		(*s)[i].first = addr.Next()
+	case span.last == addr:
+		(*s)[i].last = addr.Prev()
+	default:
+		(*s)[i].last = addr.Prev()
+		*s = slices.Insert(*s, i+1, ipSpan{first: addr.Next(), last: span.last})
+	}
+}
+
+// clone returns a copy of the set.
+func (s ipSpanSet) clone() ipSpanSet {
+	return slices.Clone(s)
+}
+
+// ipPoolRange is the parsed form of IPPool.Range.
+type ipPoolRange struct {
+	first    netip.Addr
+	last     netip.Addr
+	excluded ipSpanSet // addresses which are never allocated
+}
+
+// parseIPPoolRange parses the IPPool.Range definition. It contains one
+// address interval, optionally followed by exclusions prefixed with "!",
+// all separated by utils.InfieldSep. Each interval can be one address, a
+// CIDR prefix or a <first>-<last> range, e.g.:
+//
+//	10.0.0.0/16;!10.0.0.1;!10.0.10.0-10.0.10.255
+//
+// For IPv4 prefixes bigger than /31 the network and broadcast addresses
+// are excluded automatically.
+func parseIPPoolRange(rng string) (*ipPoolRange, error) {
+	var r *ipPoolRange
+	var exclusions []ipSpan
+	for entry := range strings.SplitSeq(rng, utils.InfieldSep) {
+		entry = strings.TrimSpace(entry)
+		if entry == utils.EmptyString {
+			continue
+		}
+		if excl, isExcl := strings.CutPrefix(entry, "!"); isExcl {
+			span, err := parseIPSpan(excl)
+			if err != nil {
+				return nil, err
+			}
+			exclusions = append(exclusions, span)
+			continue
+		}
+		if r != nil {
+			return nil, fmt.Errorf("multiple address ranges in IP pool range %q", rng)
+		}
+		span, err := parseIPSpan(entry)
+		if err != nil {
+			return nil, err
+		}
+		r = &ipPoolRange{first: span.first, last: span.last}
+		if strings.Contains(entry, "/") && span.first.Is4() &&
+			span.first.Next() != span.last && span.first != span.last {
+			r.excluded.add(span.first)
+			r.excluded.add(span.last)
+		}
+	}
+	if r == nil {
+		return nil, fmt.Errorf("missing address range in IP pool range %q", rng)
+	}
+	for _, excl := range exclusions {
+		if excl.first.BitLen() != r.first.BitLen() {
+			return nil, fmt.Errorf("excluded addresses <%s-%s> not matching the IP pool family",
+				excl.first, excl.last)
+		}
+		r.excluded.addSpan(excl.first, excl.last)
+	}
+	return r, nil
+}
+
+// parseIPSpan parses a single address, a CIDR prefix or a <first>-<last>
+// address range into an ipSpan.
+func parseIPSpan(s string) (ipSpan, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return ipSpan{}, err
+		}
+		prefix = prefix.Masked()
+		return ipSpan{first: prefix.Addr(), last: lastIPOfPrefix(prefix)}, nil
+	}
+	if firstStr, lastStr, isRange := strings.Cut(s, "-"); isRange {
+		first, err := netip.ParseAddr(strings.TrimSpace(firstStr))
+		if err != nil {
+			return ipSpan{}, err
+		}
+		last, err := netip.ParseAddr(strings.TrimSpace(lastStr))
+		if err != nil {
+			return ipSpan{}, err
+		}
+		if first.BitLen() != last.BitLen() {
+			return ipSpan{}, fmt.Errorf("mixed address families in IP range %q", s)
+		}
+		if last.Less(first) {
+			return ipSpan{}, fmt.Errorf("invalid IP range %q: first address is bigger than last", s)
+		}
+		return ipSpan{first: first, last: last}, nil
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return ipSpan{}, err
+	}
+	return ipSpan{first: addr, last: addr}, nil
+}
+
+// lastIPOfPrefix returns the highest address within a masked prefix.
+func lastIPOfPrefix(prefix netip.Prefix) netip.Addr {
+	b := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+// contains checks if addr is within the pool boundaries.
+func (r *ipPoolRange) contains(addr netip.Addr) bool {
+	return r.first.Compare(addr) <= 0 && addr.Compare(r.last) <= 0
+}
+
+// newUsedSet returns the free-address index for an empty pool.
+func (r *ipPoolRange) newUsedSet() *ipSpanSet {
+	used := r.excluded.clone()
+	return &used
+}
+
+// freeAddr picks an address within the pool which is not part of used,
+// based on strategy. Returns false if the pool is exhausted.
+func (r *ipPoolRange) freeAddr(used ipSpanSet, strategy string) (netip.Addr, bool, error) {
+	switch strategy {
+	case utils.EmptyString, utils.MetaAscending:
+		free, found := r.freeAddrAbove(used, r.first)
+		return free, found, nil
+	case utils.MetaDescending:
+		free, found := r.freeAddrBelow(used, r.last)
+		return free, found, nil
+	case utils.MetaRandom:
+		addr, err := r.randomAddr()
+		if err != nil {
+			return netip.Addr{}, false, err
+		}
+		if free, found := r.freeAddrAbove(used, addr); found {
+			return free, true, nil
+		}
+		free, found := r.freeAddrBelow(used, addr)
+		return free, found, nil
+	default:
+		return netip.Addr{}, false, fmt.Errorf("unsupported IP pool strategy: %q", strategy)
+	}
+}
+
+// freeAddrAbove returns the lowest free address starting with addr.
+func (r *ipPoolRange) freeAddrAbove(used ipSpanSet, addr netip.Addr) (netip.Addr, bool) {
+	if span, isUsed := used.spanOf(addr); isUsed {
+		addr = span.last.Next()
+	}
+	return addr, addr.IsValid() && r.contains(addr)
+}
+
+// freeAddrBelow returns the highest free address starting with addr.
+func (r *ipPoolRange) freeAddrBelow(used ipSpanSet, addr netip.Addr) (netip.Addr, bool) {
+	if span, isUsed := used.spanOf(addr); isUsed {
+		addr = span.first.Prev()
+	}
+	return addr, addr.IsValid() && r.contains(addr)
+}
+
+// randomAddr returns a random address within the pool boundaries.
+func (r *ipPoolRange) randomAddr() (netip.Addr, error) {
+	first := new(big.Int).SetBytes(r.first.AsSlice())
+	size := new(big.Int).SetBytes(r.last.AsSlice())
+	size.Sub(size, first).Add(size, big.NewInt(1))
+	offset, err := rand.Int(rand.Reader, size)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	b := offset.Add(offset, first).FillBytes(make([]byte, r.first.BitLen()/8))
+	addr, _ := netip.AddrFromSlice(b)
+	return addr, nil
+}
@@ -0,0 +1,251 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestIPSpanSetAddRemove(t *testing.T) {
+	var s ipSpanSet
+	s.add(netip.MustParseAddr("10.0.0.5"))
+	s.add(netip.MustParseAddr("10.0.0.7"))
+	s.add(netip.MustParseAddr("10.0.0.6")) // joins the two spans
+	s.addSpan(netip.MustParseAddr("10.0.0.10"), netip.MustParseAddr("10.0.0.20"))
+	exp := ipSpanSet{
+		{first: netip.MustParseAddr("10.0.0.5"), last: netip.MustParseAddr("10.0.0.7")},
+		{first: netip.MustParseAddr("10.0.0.10"), last: netip.MustParseAddr("10.0.0.20")},
+	}
+	if !reflect.DeepEqual(exp, s) {
+		t.Fatalf("expected %v, received %v", exp, s)
+	}
+	s.addSpan(netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.12"))
+	exp = ipSpanSet{
+		{first: netip.MustParseAddr("10.0.0.1"), last: netip.MustParseAddr("10.0.0.20")},
+	}
+	if !reflect.DeepEqual(exp, s) {
+		t.Fatalf("expected %v, received %v", exp, s)
+	}
+	s.remove(netip.MustParseAddr("10.0.0.15"))
+	s.remove(netip.MustParseAddr("10.0.0.1"))
+	s.remove(netip.MustParseAddr("10.0.0.20"))
+	s.remove(netip.MustParseAddr("10.0.0.30")) // not in set
+	exp = ipSpanSet{
+		{first: netip.MustParseAddr("10.0.0.2"), last: netip.MustParseAddr("10.0.0.14")},
+		{first: netip.MustParseAddr("10.0.0.16"), last: netip.MustParseAddr("10.0.0.19")},
+	}
+	if !reflect.DeepEqual(exp, s) {
+		t.Fatalf("expected %v, received %v", exp, s)
+	}
+	if s.has(netip.MustParseAddr("10.0.0.15")) {
+		t.Error("expected 10.0.0.15 to be removed")
+	}
+	if !s.has(netip.MustParseAddr("10.0.0.16")) {
+		t.Error("expected 10.0.0.16 to be part of the set")
+	}
+}
+
+func TestParseIPPoolRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		rng     string
+		exp     *ipPoolRange
+		wantErr bool
+	}{
+		{
+			name: "single IP prefix",
+			rng:  "10.100.0.1/32",
+			exp: &ipPoolRange{
+				first: netip.MustParseAddr("10.100.0.1"),
+				last:  netip.MustParseAddr("10.100.0.1"),
+			},
+		},
+		{
+			name: "IPv4 CIDR with exclusions",
+			rng:  "10.0.0.0/24;!10.0.0.1;!10.0.0.100-10.0.0.110",
+			exp: &ipPoolRange{
+				first: netip.MustParseAddr("10.0.0.0"),
+				last:  netip.MustParseAddr("10.0.0.255"),
+				excluded: ipSpanSet{
+					{first: netip.MustParseAddr("10.0.0.0"), last: netip.MustParseAddr("10.0.0.1")},
+					{first: netip.MustParseAddr("10.0.0.100"), last: netip.MustParseAddr("10.0.0.110")},
+					{first: netip.MustParseAddr("10.0.0.255"), last: netip.MustParseAddr("10.0.0.255")},
+				},
+			},
+		},
+		{
+			name: "address range",
+			rng:  "192.168.1.10-192.168.1.20",
+			exp: &ipPoolRange{
+				first: netip.MustParseAddr("192.168.1.10"),
+				last:  netip.MustParseAddr("192.168.1.20"),
+			},
+		},
+		{
+			name: "IPv6 prefix",
+			rng:  "2001:db8::/64",
+			exp: &ipPoolRange{
+				first: netip.MustParseAddr("2001:db8::"),
+				last:  netip.MustParseAddr("2001:db8::ffff:ffff:ffff:ffff"),
+			},
+		},
+		{name: "missing range", rng: "!10.0.0.1", wantErr: true},
+		{name: "multiple ranges", rng: "10.0.0.0/24;10.0.1.0/24", wantErr: true},
+		{name: "reversed range", rng: "10.0.0.20-10.0.0.10", wantErr: true},
+		{name: "mixed families", rng: "10.0.0.0/24;!2001:db8::1", wantErr: true},
+		{name: "invalid address", rng: "10.0.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rcv, err := parseIPPoolRange(tt.rng)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, received nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tt.exp, rcv) {
+				t.Errorf("expected %+v, received %+v", tt.exp, rcv)
+			}
+		})
+	}
+}
+
+func TestIPPoolRangeFreeAddr(t *testing.T) {
+	r, err := parseIPPoolRange("10.0.0.0/29;!10.0.0.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	used := r.newUsedSet()
+	used.add(netip.MustParseAddr("10.0.0.1"))
+	if addr, found, err := r.freeAddr(*used, utils.MetaAscending); err != nil {
+		t.Fatal(err)
+	} else if !found || addr != netip.MustParseAddr("10.0.0.3") {
+		t.Errorf("expected 10.0.0.3, received %v (found: %v)", addr, found)
+	}
+	if addr, found, err := r.freeAddr(*used, utils.MetaDescending); err != nil {
+		t.Fatal(err)
+	} else if !found || addr != netip.MustParseAddr("10.0.0.6") {
+		t.Errorf("expected 10.0.0.6, received %v (found: %v)", addr, found)
+	}
+	for range 10 {
+		addr, found, err := r.freeAddr(*used, utils.MetaRandom)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !found || used.has(addr) || !r.contains(addr) {
+			t.Errorf("unexpected random address %v (found: %v)", addr, found)
+		}
+	}
+	used.addSpan(netip.MustParseAddr("10.0.0.3"), netip.MustParseAddr("10.0.0.6"))
+	for _, strategy := range []string{utils.MetaAscending, utils.MetaDescending, utils.MetaRandom} {
+		if _, found, err := r.freeAddr(*used, strategy); err != nil {
+			t.Fatal(err)
+		} else if found {
+			t.Errorf("expected exhausted pool for strategy %s", strategy)
+		}
+	}
+	if _, _, err := r.freeAddr(*used, "*unknown"); err == nil {
+		t.Error("expected error for unsupported strategy")
+	}
+}
+
+func TestIPAllocationsAllocateIPOnPoolRange(t *testing.T) {
+	prfl := &IPProfile{
+		Tenant: "cgrates.org",
+		ID:     "IPs1",
+		Pools: []*IPPool{
+			{
+				ID:       "POOL_V4",
+				Range:    "10.0.0.0/30",
+				Strategy: utils.MetaAscending,
+			},
+			{
+				ID:       "POOL_V6",
+				Range:    "2001:db8::/120",
+				Strategy: utils.MetaDescending,
+			},
+		},
+	}
+	allocs := &IPAllocations{
+		Tenant:      "cgrates.org",
+		ID:          "IPs1",
+		Allocations: make(map[string]*PoolAllocation),
+	}
+	if err := allocs.computeUnexported(prfl); err != nil {
+		t.Fatal(err)
+	}
+	for _, alloc := range []struct{ id, expAddr string }{
+		{"alloc1", "10.0.0.1"},
+		{"alloc2", "10.0.0.2"},
+	} {
+		allocIP, err := allocs.allocateIPOnPool(alloc.id, prfl.Pools[0], false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if allocIP.Address != netip.MustParseAddr(alloc.expAddr) {
+			t.Errorf("expected %s for %s, received %s", alloc.expAddr, alloc.id, allocIP.Address)
+		}
+	}
+	if _, err := allocs.allocateIPOnPool("alloc3", prfl.Pools[0], false); !errors.Is(err, utils.ErrIPAlreadyAllocated) {
+		t.Errorf("expected %v, received %v", utils.ErrIPAlreadyAllocated, err)
+	}
+	if err := allocs.releaseAllocation("alloc1"); err != nil {
+		t.Fatal(err)
+	}
+	if allocIP, err := allocs.allocateIPOnPool("alloc3", prfl.Pools[0], false); err != nil {
+		t.Fatal(err)
+	} else if allocIP.Address != netip.MustParseAddr("10.0.0.1") {
+		t.Errorf("expected released address to be reused, received %s", allocIP.Address)
+	}
+	if allocIP, err := allocs.allocateIPOnPool("alloc4", prfl.Pools[1], false); err != nil {
+		t.Fatal(err)
+	} else if allocIP.Address != netip.MustParseAddr("2001:db8::ff") {
+		t.Errorf("expected 2001:db8::ff, received %s", allocIP.Address)
+	}
+
+	// Rebuilding the index from stored allocations must keep used addresses.
+	rebuilt := &IPAllocations{
+		Tenant:      allocs.Tenant,
+		ID:          allocs.ID,
+		Allocations: allocs.Clone().Allocations,
+	}
+	if err := rebuilt.computeUnexported(prfl); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(allocs.poolUsed, rebuilt.poolUsed) {
+		t.Errorf("expected %v, received %v", allocs.poolUsed, rebuilt.poolUsed)
+	}
+	if err := rebuilt.clearAllocations(nil); err != nil {
+		t.Fatal(err)
+	}
+	if allocIP, err := rebuilt.allocateIPOnPool("alloc5", prfl.Pools[0], true); err != nil {
+		t.Fatal(err)
+	} else if allocIP.Address != netip.MustParseAddr("10.0.0.1") {
+		t.Errorf("expected 10.0.0.1, received %s", allocIP.Address)
+	}
+}
@@ -20,6 +20,7 @@ package engine
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 
@@ -180,6 +181,55 @@ func (sRoutes *SortedRoutes) SortLoadDistribution() {
 	})
 }
 
+// SortScore is part of sort interface, sort ascendent based on the score with fallback on Weight.
+// Each term is normalized between the routes to [0,1], 0 being the best value and 1 the worst one or
+// a missing value, the score being the sum of the normalized terms multiplied by their coefficients.
+// The percentage terms (*asr) are scored as 1-ASR instead of relative to the other routes
+func (sRoutes *SortedRoutes) SortScore(terms []*scoreTerm) {
+	for _, sr := range sRoutes.Routes {
+		sr.sortingDataF64[utils.Score] = 0
+		sr.SortingData[utils.ScoreTerms] = make(map[string]any)
+	}
+	for _, term := range terms {
+		minVal, maxVal := math.Inf(1), math.Inf(-1)
+		for _, sr := range sRoutes.Routes {
+			if val, has := term.value(sr); has {
+				minVal = math.Min(minVal, val)
+				maxVal = math.Max(maxVal, val)
+			}
+		}
+		for _, sr := range sRoutes.Routes {
+			norm := 1.0
+			if val, has := term.value(sr); has {
+				norm = 0
+				if term.percentage {
+					norm = 1 - math.Min(math.Max(val, 0), 100)/100
+				} else if maxVal > minVal {
+					norm = (val - minVal) / (maxVal - minVal)
+					if !term.lowerBetter {
+						norm = 1 - norm
+					}
+				}
+			}
+			termScore := term.coefficient * norm
+			sr.sortingDataF64[utils.Score] += termScore
+			sr.SortingData[utils.ScoreTerms].(map[string]any)[term.name] = termScore
+		}
+	}
+	for _, sr := range sRoutes.Routes {
+		sr.SortingData[utils.Score] = sr.sortingDataF64[utils.Score]
+	}
+	sort.Slice(sRoutes.Routes, func(i, j int) bool {
+		if sRoutes.Routes[i].sortingDataF64[utils.Score] == sRoutes.Routes[j].sortingDataF64[utils.Score] {
+			if sRoutes.Routes[i].sortingDataF64[utils.Weight] == sRoutes.Routes[j].sortingDataF64[utils.Weight] {
+				return utils.BoolGenerator().RandomBool()
+			}
+			return sRoutes.Routes[i].sortingDataF64[utils.Weight] > sRoutes.Routes[j].sortingDataF64[utils.Weight]
+		}
+		return sRoutes.Routes[i].sortingDataF64[utils.Score] < sRoutes.Routes[j].sortingDataF64[utils.Score]
+	})
+}
+
 // Digest returns list of routeIDs + parameters for easier outside access
 // format route1:route1params,route2:route2params
 func (sRoutes *SortedRoutes) Digest() string {
@@ -233,6 +283,7 @@ func NewRouteSortDispatcher(lcrS *RouteService) (rsd RouteSortDispatcher) {
 	rsd[utils.MetaReas] = NewResourceAscendetSorter(lcrS)
 	rsd[utils.MetaReds] = NewResourceDescendentSorter(lcrS)
 	rsd[utils.MetaLoad] = NewLoadDistributionSorter(lcrS)
+	rsd[utils.MetaScore] = NewScoreSorter(lcrS)
 	return
 }
 
@@ -105,13 +105,16 @@ func statQueueProfileLockKey(tnt, id string) string {
 
 // lock will lock the StatQueueProfile using guardian and store the lock within r.lkID
 // if lkID is passed as argument, the lock is considered as executed
-func (sqp *StatQueueProfile) lock(lkID string) {
+func (sqp *StatQueueProfile) lock(lkID string) (err error) {
 	if lkID == utils.EmptyString {
-		lkID = guardian.Guardian.GuardIDs("",
+		if lkID, err = guardian.Guardian.GuardIDs("",
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			statQueueProfileLockKey(sqp.Tenant, sqp.ID))
+			statQueueProfileLockKey(sqp.Tenant, sqp.ID)); err != nil {
+			return
+		}
 	}
 	sqp.lkID = lkID
+	return
 }
 
 // unlock will unlock the StatQueueProfile and clear rp.lkID
@@ -308,13 +311,16 @@ func statQueueLockKey(tnt, id string) string {
 
 // lock will lock the StatQueue using guardian and store the lock within r.lkID
 // if lkID is passed as argument, the lock is considered as executed
-func (sq *StatQueue) lock(lkID string) {
+func (sq *StatQueue) lock(lkID string) (err error) {
 	if lkID == utils.EmptyString {
-		lkID = guardian.Guardian.GuardIDs("",
+		if lkID, err = guardian.Guardian.GuardIDs("",
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			statQueueLockKey(sq.Tenant, sq.ID))
+			statQueueLockKey(sq.Tenant, sq.ID)); err != nil {
+			return
+		}
 	}
 	sq.lkID = lkID
+	return
 }
 
 // unlock will unlock the StatQueue and clear r.lkID
@@ -574,6 +580,10 @@ func (sq *StatQueue) UnmarshalJSON(data []byte) (err error) {
 			metric = new(StatREPSC)
 		case utils.MetaREPFC:
 			metric = new(StatREPFC)
+		case utils.MetaPercentile, utils.MetaMedian:
+			metric = new(StatPercentile)
+		case utils.MetaStdDev:
+			metric = new(StatStdDev)
 		default:
 			return fmt.Errorf("unsupported metric type <%s>", metricSplit[0])
 		}
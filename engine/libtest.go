@@ -81,7 +81,7 @@ func InitStorDb(cfg *config.CGRConfig) error {
 		dbPath)); err != nil {
 		return err
 	}
-	if slices.Contains([]string{utils.MetaMongo, utils.MetaMySQL, utils.MetaPostgres},
+	if slices.Contains([]string{utils.MetaMongo, utils.MetaMySQL, utils.MetaPostgres, utils.MetaSQLite},
 		cfg.StorDbCfg().Type) {
 		if err := SetDBVersions(storDB); err != nil {
 			return err
@@ -289,6 +289,9 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 		utils.CacheStatQueues:              {},
 		utils.CacheRankings:                {},
 		utils.CacheRankingProfiles:         {},
+		utils.CacheDataSets:                {},
+		utils.CacheTaxProfiles:             {},
+		utils.CacheTaxProfileFilterIndexes: {},
 		utils.CacheSTIR:                    {},
 		utils.CacheRouteFilterIndexes:      {},
 		utils.CacheRouteProfiles:           {},
@@ -247,6 +247,12 @@ cgrates.org,Ranking1,@every 5m,Stats2;Stats3;Stats4,Metric1;Metric3,*asc,,true,T
 	TrendsCSVContent = `
 #Tenant[0],Id[1],Schedule[2],StatID[3],Metrics[4],TTL[5],QueueLength[6],MinItems[7],CorrelationType[8],Tolerance[9],Stored[10],ThresholdIDs[11]
 cgrates.org,TREND1,0 12 * * *,Stats2,*acc;*tcc,-1,-1,1,*average,2.1,true,TD1;TD2
+`
+	TaxProfilesCSVContent = `
+#Tenant[0],ID[1],FilterIDs[2],Jurisdiction[3],Weight[4],RateID[5],RateType[6],Rate[7],RateAmount[8],RateCompound[9],RateActivationInterval[10]
+cgrates.org,TAX_DE,*prefix:~*req.Destination:49,DE,20,VAT,*vat,19,0,false,2014-07-29T15:00:00Z
+cgrates.org,TAX_DE,,,,EXCISE,*excise,10,0,true,
+cgrates.org,TAX_DE,,,,REG,*regulatory,0,0.01,false,
 `
 	ThresholdsCSVContent = `
 #Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],MaxHits[4],MinHits[5],MinSleep[6],Blocker[7],Weight[8],ActionIDs[9],Async[10],EeIDs[11]
@@ -300,7 +306,7 @@ func init() {
 		ActionsCSVContent, ActionPlansCSVContent, ActionTriggersCSVContent, AccountActionsCSVContent,
 		ResourcesCSVContent, IPsCSVContent, StatsCSVContent, TrendsCSVContent, RankingsCSVContent,
 		ThresholdsCSVContent, FiltersCSVContent, RoutesCSVContent, AttributesCSVContent,
-		ChargersCSVContent, DispatcherCSVContent, DispatcherHostCSVContent), testTPID, "", nil, nil)
+		ChargersCSVContent, DispatcherCSVContent, DispatcherHostCSVContent, TaxProfilesCSVContent), testTPID, "", nil, nil)
 	if err != nil {
 		log.Print("error when creating TpReader:", err)
 	}
@@ -373,6 +379,9 @@ func init() {
 	if err := csvr.LoadDispatcherHosts(); err != nil {
 		log.Print("error in LoadDispatcherHosts:", err)
 	}
+	if err := csvr.LoadTaxProfiles(); err != nil {
+		log.Print("error in LoadTaxProfiles:", err)
+	}
 	if err := csvr.WriteToDatabase(false, false); err != nil {
 		log.Print("error when writing into database ", err)
 	}
@@ -1364,6 +1373,29 @@ func TestLoadRankingProfiles(t *testing.T) {
 	}
 }
 
+func TestLoadTaxProfiles(t *testing.T) {
+	eTxp := &utils.TPTaxProfile{
+		TPid:         testTPID,
+		Tenant:       "cgrates.org",
+		ID:           "TAX_DE",
+		FilterIDs:    []string{"*prefix:~*req.Destination:49"},
+		Jurisdiction: "DE",
+		Weight:       20,
+		Rates: []*utils.TPTaxRate{
+			{ID: "VAT", Type: "*vat", Rate: 19,
+				ActivationInterval: &utils.TPActivationInterval{ActivationTime: "2014-07-29T15:00:00Z"}},
+			{ID: "EXCISE", Type: "*excise", Rate: 10, Compound: true},
+			{ID: "REG", Type: "*regulatory", Amount: 0.01},
+		},
+	}
+	txpKey := utils.TenantID{Tenant: "cgrates.org", ID: "TAX_DE"}
+	if len(csvr.taxProfiles) != 1 {
+		t.Errorf("Failed to load TaxProfiles: %s", utils.ToJSON(csvr.taxProfiles))
+	} else if !reflect.DeepEqual(eTxp, csvr.taxProfiles[txpKey]) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eTxp), utils.ToJSON(csvr.taxProfiles[txpKey]))
+	}
+}
+
 func TestTrendProfiles(t *testing.T) {
 	eTrends := map[utils.TenantID]*utils.TPTrendsProfile{
 		{Tenant: "cgrates.org", ID: "TREND1"}: {
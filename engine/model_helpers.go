@@ -35,12 +35,18 @@ func csvLoad(s any, values []string) (any, error) {
 	fieldValueMap := make(map[string]string)
 	st := reflect.TypeOf(s)
 	numFields := st.NumField()
+	if colCount := getColumnCount(s); len(values) > colCount {
+		return nil, fmt.Errorf("invalid %v number of fields %d, expecting at most %d", st.Name(), len(values), colCount)
+	}
 	for i := 0; i < numFields; i++ {
 		field := st.Field(i)
 		re := field.Tag.Get("re")
 		index := field.Tag.Get("index")
 		if index != utils.EmptyString {
 			idx, err := strconv.Atoi(index)
+			if err == nil && len(values) <= idx && field.Tag.Get("optional") == utils.TrueStr {
+				continue // optional column missing, left empty
+			}
 			if err != nil || len(values) <= idx {
 				return nil, fmt.Errorf("invalid %v.%v index %v", st.Name(), field.Name, index)
 			}
@@ -146,6 +152,18 @@ func getColumnCount(s any) int {
 	return count
 }
 
+// hasOptionalColumns checks if some of the trailing columns of the model can be left out,
+// in which case the number of fields differs between the records
+func hasOptionalColumns(s any) bool {
+	st := reflect.TypeOf(s)
+	for i := 0; i < st.NumField(); i++ {
+		if st.Field(i).Tag.Get("optional") == utils.TrueStr {
+			return true
+		}
+	}
+	return false
+}
+
 type DestinationMdls []DestinationMdl
 
 func (tps DestinationMdls) AsMapDestinations() (map[string]*Destination, error) {
@@ -359,6 +377,7 @@ func (tps DestinationRateMdls) AsMapDestinationRates() (result map[string]*utils
 					RoundingDecimals: tp.RoundingDecimals,
 					MaxCost:          tp.MaxCost,
 					MaxCostStrategy:  tp.MaxCostStrategy,
+					UsageCounter:     tp.UsageCounter,
 				},
 			},
 		}
@@ -404,6 +423,7 @@ func APItoModelDestinationRate(d *utils.TPDestinationRate) (result DestinationRa
 				RoundingDecimals: dr.RoundingDecimals,
 				MaxCost:          dr.MaxCost,
 				MaxCostStrategy:  dr.MaxCostStrategy,
+				UsageCounter:     dr.UsageCounter,
 			})
 		}
 		if len(d.DestinationRates) == 0 {
@@ -472,6 +492,7 @@ func GetRateInterval(rpl *utils.TPRatingPlanBinding, dr *utils.DestinationRate)
 			RoundingDecimals: dr.RoundingDecimals,
 			MaxCost:          dr.MaxCost,
 			MaxCostStrategy:  dr.MaxCostStrategy,
+			UsageCounter:     dr.UsageCounter,
 			tag:              dr.Rate.ID,
 		},
 	}
@@ -1916,6 +1937,217 @@ func RankingProfileToAPI(rg *RankingProfile) (tpRG *utils.TPRankingProfile) {
 	return
 }
 
+type TaxProfileMdls []*TaxProfileMdl
+
+func (tps TaxProfileMdls) CSVHeader() (result []string) {
+	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.TaxJurisdiction,
+		utils.Weight, utils.RateID, utils.RateType, utils.RateValue, utils.RateAmount,
+		utils.RateCompound, utils.RateActivationInterval}
+}
+
+// AsTPTaxProfile groups the rows into profiles, keeping the rates in the order they were defined
+func (tps TaxProfileMdls) AsTPTaxProfile() (result []*utils.TPTaxProfile) {
+	filterMap := make(map[string]utils.StringSet)
+	rateMap := make(map[string]map[string]*utils.TPTaxRate)
+	mtp := make(map[string]*utils.TPTaxProfile)
+	for _, tp := range tps {
+		tntID := utils.ConcatenatedKey(tp.Tenant, tp.ID)
+		txp, found := mtp[tntID]
+		if !found {
+			txp = &utils.TPTaxProfile{
+				TPid:   tp.Tpid,
+				Tenant: tp.Tenant,
+				ID:     tp.ID,
+			}
+			mtp[tntID] = txp
+			filterMap[tntID] = make(utils.StringSet)
+			rateMap[tntID] = make(map[string]*utils.TPTaxRate)
+			result = append(result, txp)
+		}
+		if tp.FilterIDs != utils.EmptyString {
+			filterMap[tntID].AddSlice(strings.Split(tp.FilterIDs, utils.InfieldSep))
+		}
+		if tp.Jurisdiction != utils.EmptyString {
+			txp.Jurisdiction = tp.Jurisdiction
+		}
+		if tp.Weight != 0 {
+			txp.Weight = tp.Weight
+		}
+		if tp.RateID == utils.EmptyString {
+			continue
+		}
+		rate, has := rateMap[tntID][tp.RateID]
+		if !has {
+			rate = &utils.TPTaxRate{ID: tp.RateID}
+			rateMap[tntID][tp.RateID] = rate
+			txp.Rates = append(txp.Rates, rate)
+		}
+		if tp.RateType != utils.EmptyString {
+			rate.Type = tp.RateType
+		}
+		if tp.Rate != 0 {
+			rate.Rate = tp.Rate
+		}
+		if tp.RateAmount != 0 {
+			rate.Amount = tp.RateAmount
+		}
+		if tp.RateCompound {
+			rate.Compound = tp.RateCompound
+		}
+		if tp.RateActivationInterval != utils.EmptyString {
+			rate.ActivationInterval = new(utils.TPActivationInterval)
+			aiSplt := strings.Split(tp.RateActivationInterval, utils.InfieldSep)
+			rate.ActivationInterval.ActivationTime = aiSplt[0]
+			if len(aiSplt) == 2 {
+				rate.ActivationInterval.ExpiryTime = aiSplt[1]
+			}
+		}
+	}
+	for _, txp := range result {
+		txp.FilterIDs = filterMap[utils.ConcatenatedKey(txp.Tenant, txp.ID)].AsSlice()
+	}
+	return
+}
+
+// APItoModelTPTaxProfile converts the profile into one row for each of its rates
+func APItoModelTPTaxProfile(tp *utils.TPTaxProfile) (mdls TaxProfileMdls) {
+	if tp == nil {
+		return
+	}
+	mdl := &TaxProfileMdl{
+		Tpid:         tp.TPid,
+		Tenant:       tp.Tenant,
+		ID:           tp.ID,
+		FilterIDs:    strings.Join(tp.FilterIDs, utils.InfieldSep),
+		Jurisdiction: tp.Jurisdiction,
+		Weight:       tp.Weight,
+	}
+	if len(tp.Rates) == 0 {
+		return TaxProfileMdls{mdl}
+	}
+	for i, rate := range tp.Rates {
+		if i != 0 {
+			mdl = &TaxProfileMdl{
+				Tpid:   tp.TPid,
+				Tenant: tp.Tenant,
+				ID:     tp.ID,
+			}
+		}
+		mdl.RateID = rate.ID
+		mdl.RateType = rate.Type
+		mdl.Rate = rate.Rate
+		mdl.RateAmount = rate.Amount
+		mdl.RateCompound = rate.Compound
+		if rate.ActivationInterval != nil {
+			if rate.ActivationInterval.ActivationTime != utils.EmptyString {
+				mdl.RateActivationInterval = rate.ActivationInterval.ActivationTime
+			}
+			if rate.ActivationInterval.ExpiryTime != utils.EmptyString {
+				mdl.RateActivationInterval += utils.InfieldSep + rate.ActivationInterval.ExpiryTime
+			}
+		}
+		mdls = append(mdls, mdl)
+	}
+	return
+}
+
+func APItoTaxProfile(tp *utils.TPTaxProfile, timezone string) (txp *TaxProfile, err error) {
+	txp = &TaxProfile{
+		Tenant:       tp.Tenant,
+		ID:           tp.ID,
+		FilterIDs:    make([]string, len(tp.FilterIDs)),
+		Jurisdiction: tp.Jurisdiction,
+		Weight:       tp.Weight,
+		Rates:        make([]*TaxRate, len(tp.Rates)),
+	}
+	copy(txp.FilterIDs, tp.FilterIDs)
+	for i, rate := range tp.Rates {
+		txp.Rates[i] = &TaxRate{
+			ID:       rate.ID,
+			Type:     rate.Type,
+			Rate:     rate.Rate,
+			Amount:   rate.Amount,
+			Compound: rate.Compound,
+		}
+		if rate.ActivationInterval != nil {
+			if txp.Rates[i].ActivationInterval, err = rate.ActivationInterval.AsActivationInterval(timezone); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return
+}
+
+func TaxProfileToAPI(txp *TaxProfile) (tp *utils.TPTaxProfile) {
+	tp = &utils.TPTaxProfile{
+		Tenant:       txp.Tenant,
+		ID:           txp.ID,
+		FilterIDs:    make([]string, len(txp.FilterIDs)),
+		Jurisdiction: txp.Jurisdiction,
+		Weight:       txp.Weight,
+		Rates:        make([]*utils.TPTaxRate, len(txp.Rates)),
+	}
+	copy(tp.FilterIDs, txp.FilterIDs)
+	for i, rate := range txp.Rates {
+		tp.Rates[i] = &utils.TPTaxRate{
+			ID:       rate.ID,
+			Type:     rate.Type,
+			Rate:     rate.Rate,
+			Amount:   rate.Amount,
+			Compound: rate.Compound,
+		}
+		if rate.ActivationInterval != nil {
+			tp.Rates[i].ActivationInterval = new(utils.TPActivationInterval)
+			if !rate.ActivationInterval.ActivationTime.IsZero() {
+				tp.Rates[i].ActivationInterval.ActivationTime = rate.ActivationInterval.ActivationTime.Format(time.RFC3339)
+			}
+			if !rate.ActivationInterval.ExpiryTime.IsZero() {
+				tp.Rates[i].ActivationInterval.ExpiryTime = rate.ActivationInterval.ExpiryTime.Format(time.RFC3339)
+			}
+		}
+	}
+	return
+}
+
+type DataSetMdls []*DataSetMdl
+
+func (tps DataSetMdls) CSVHeader() (result []string) {
+	return []string{"#" + utils.Tenant, utils.ID, utils.Value, utils.Prefix, utils.Metadata}
+}
+
+// AsDataSets groups the entries into DataSets, the metadata being in the format Key1:Value1;Key2:Value2
+func (models DataSetMdls) AsDataSets() (result []*DataSet, err error) {
+	mds := make(map[string]*DataSet)
+	for _, model := range models {
+		tntID := utils.ConcatenatedKey(model.Tenant, model.ID)
+		ds, found := mds[tntID]
+		if !found {
+			ds = NewDataSet(model.Tenant, model.ID)
+			mds[tntID] = ds
+			result = append(result, ds)
+		}
+		if model.Value == utils.EmptyString { // allows defining empty sets
+			continue
+		}
+		ent := &DataSetEntry{
+			Value:  model.Value,
+			Prefix: model.Prefix,
+		}
+		if model.Metadata != utils.EmptyString {
+			ent.Metadata = make(map[string]string)
+			for _, kv := range strings.Split(model.Metadata, utils.InfieldSep) {
+				mdSplt := strings.SplitN(kv, utils.InInFieldSep, 2)
+				if len(mdSplt) != 2 {
+					return nil, fmt.Errorf("invalid metadata <%s> for DataSet <%s>", kv, tntID)
+				}
+				ent.Metadata[mdSplt[0]] = mdSplt[1]
+			}
+		}
+		ds.SetEntries([]*DataSetEntry{ent})
+	}
+	return
+}
+
 type TrendsMdls []*TrendsMdl
 
 func (tps TrendsMdls) CSVHeader() (result []string) {
@@ -37,6 +37,30 @@ func TestModelHelperCsvLoad(t *testing.T) {
 	}
 }
 
+func TestModelHelperCsvLoadOptionalColumn(t *testing.T) {
+	l, err := csvLoad(DestinationRateMdl{}, []string{"DR_1", "DST_1", "RT_1", "*up", "4", "0", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dr := l.(DestinationRateMdl); dr.Tag != "DR_1" || dr.UsageCounter != "" {
+		t.Errorf("model load failed: %+v", dr)
+	}
+	if l, err = csvLoad(DestinationRateMdl{}, []string{"DR_1", "DST_1", "RT_1", "*up", "4", "0", "", "MONTHLY"}); err != nil {
+		t.Fatal(err)
+	}
+	if dr := l.(DestinationRateMdl); dr.UsageCounter != "MONTHLY" {
+		t.Errorf("model load failed: %+v", dr)
+	}
+	if _, err = csvLoad(DestinationRateMdl{}, []string{"DR_1", "DST_1", "RT_1", "*up", "4", "0"}); err == nil ||
+		err.Error() != "invalid DestinationRateMdl.MaxCostStrategy index 6" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err = csvLoad(DestinationRateMdl{}, []string{"DR_1", "DST_1", "RT_1", "*up", "4", "0", "", "MONTHLY", ""}); err == nil ||
+		err.Error() != "invalid DestinationRateMdl number of fields 9, expecting at most 8" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 func TestModelHelperCsvDump(t *testing.T) {
 	tpd := DestinationMdl{
 		Tag:    "TEST_DEST",
@@ -529,8 +553,8 @@ func TestTPDestinationRateAsExportSlice(t *testing.T) {
 		},
 	}
 	expectedSlc := [][]string{
-		{"TEST_DSTRATE", "TEST_DEST1", "TEST_RATE1", "*up", "4", "0", ""},
-		{"TEST_DSTRATE", "TEST_DEST2", "TEST_RATE2", "*up", "4", "0", ""},
+		{"TEST_DSTRATE", "TEST_DEST1", "TEST_RATE1", "*up", "4", "0", "", ""},
+		{"TEST_DSTRATE", "TEST_DEST2", "TEST_RATE2", "*up", "4", "0", "", ""},
 	}
 	ms := APItoModelDestinationRate(tpDstRate)
 	var slc [][]string
@@ -6345,3 +6369,55 @@ func TestAsTPAttributesFilterIDsDuplicate(t *testing.T) {
 		})
 	}
 }
+
+func TestTaxProfileMdlsRoundTrip(t *testing.T) {
+	tp := &utils.TPTaxProfile{
+		TPid:         "TP1",
+		Tenant:       "cgrates.org",
+		ID:           "TAX_DE",
+		FilterIDs:    []string{"*prefix:~*req.Destination:49"},
+		Jurisdiction: "DE",
+		Weight:       20,
+		Rates: []*utils.TPTaxRate{
+			{ID: "VAT", Type: "*vat", Rate: 19,
+				ActivationInterval: &utils.TPActivationInterval{
+					ActivationTime: "2024-01-01T00:00:00Z",
+					ExpiryTime:     "2025-01-01T00:00:00Z",
+				}},
+			{ID: "EXCISE", Type: "*excise", Rate: 10, Compound: true},
+			{ID: "REG", Type: "*regulatory", Amount: 0.01},
+		},
+	}
+	mdls := APItoModelTPTaxProfile(tp)
+	if len(mdls) != 3 {
+		t.Fatalf("expected one row for each rate, received %s", utils.ToJSON(mdls))
+	}
+	if mdls[0].RateActivationInterval != "2024-01-01T00:00:00Z;2025-01-01T00:00:00Z" ||
+		mdls[1].Jurisdiction != utils.EmptyString {
+		t.Errorf("unexpected rows: %s", utils.ToJSON(mdls))
+	}
+	if rcv := mdls.AsTPTaxProfile(); len(rcv) != 1 || !reflect.DeepEqual(tp, rcv[0]) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(tp), utils.ToJSON(rcv))
+	}
+
+	txp, err := APItoTaxProfile(tp, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !txp.Rates[0].IsActiveAt(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)) ||
+		txp.Rates[0].IsActiveAt(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected activation interval: %s", utils.ToJSON(txp.Rates[0]))
+	}
+	tp.TPid = utils.EmptyString
+	if rcv := TaxProfileToAPI(txp); !reflect.DeepEqual(tp, rcv) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(tp), utils.ToJSON(rcv))
+	}
+
+	// profiles without rates still get one row
+	noRates := &utils.TPTaxProfile{TPid: "TP1", Tenant: "cgrates.org", ID: "TAX_NONE", Weight: 10}
+	if mdls = APItoModelTPTaxProfile(noRates); len(mdls) != 1 {
+		t.Errorf("expected one row, received %s", utils.ToJSON(mdls))
+	} else if rcv := mdls.AsTPTaxProfile(); len(rcv) != 1 || rcv[0].ID != "TAX_NONE" || len(rcv[0].Rates) != 0 {
+		t.Errorf("unexpected profile: %s", utils.ToJSON(rcv))
+	}
+}
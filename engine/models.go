@@ -83,6 +83,7 @@ type DestinationRateMdl struct {
 	RoundingDecimals int     `index:"4" re:".*"`
 	MaxCost          float64 `index:"5" re:".*"`
 	MaxCostStrategy  string  `index:"6" re:".*"`
+	UsageCounter     string  `index:"7" re:".*" optional:"true"` // trailing column, older files can leave it out
 	CreatedAt        time.Time
 }
 
@@ -327,6 +328,37 @@ func (RankingsMdl) TableName() string {
 	return utils.TBLTPRankings
 }
 
+// TaxProfileMdl is one rate of a TaxProfile, the rates are applied in the order of their rows
+type TaxProfileMdl struct {
+	PK                     uint `gorm:"primary_key"`
+	Tpid                   string
+	Tenant                 string  `index:"0" re:".*"`
+	ID                     string  `index:"1" re:".*"`
+	FilterIDs              string  `index:"2" re:".*"`
+	Jurisdiction           string  `index:"3" re:".*"`
+	Weight                 float64 `index:"4" re:".*"`
+	RateID                 string  `index:"5" re:".*"`
+	RateType               string  `index:"6" re:".*"`
+	Rate                   float64 `index:"7" re:".*"`
+	RateAmount             float64 `index:"8" re:".*"`
+	RateCompound           bool    `index:"9" re:".*"`
+	RateActivationInterval string  `index:"10" re:".*"`
+	CreatedAt              time.Time
+}
+
+func (TaxProfileMdl) TableName() string {
+	return utils.TBLTPTaxProfiles
+}
+
+// DataSetMdl is one entry of a DataSet, used by the loaders (there is no StorDB table behind it)
+type DataSetMdl struct {
+	Tenant   string `index:"0" re:".*"`
+	ID       string `index:"1" re:".*"`
+	Value    string `index:"2" re:".*"`
+	Prefix   bool   `index:"3" re:".*"`
+	Metadata string `index:"4" re:".*"`
+}
+
 type TrendsMdl struct {
 	PK              uint `gorm:"primary_key"`
 	Tpid            string
@@ -33,9 +33,10 @@ import (
 Defines a time interval for which a certain set of prices will apply
 */
 type RateInterval struct {
-	Timing *RITiming
-	Rating *RIRate
-	Weight float64
+	Timing      *RITiming
+	Rating      *RIRate
+	Weight      float64
+	UsageOffset time.Duration // usage on the Rating.UsageCounter before the start of the call
 }
 
 // Separate structure used for rating plan size optimization
@@ -219,12 +220,16 @@ type RIRate struct {
 	RoundingDecimals int
 	MaxCost          float64
 	MaxCostStrategy  string
+	UsageCounter     string     // when set, the GroupIntervalStart of the Rates is measured on the account usage counter
 	Rates            RateGroups // GroupRateInterval (start time): RGRate
 	tag              string     // loading validation only
 }
 
 func (rir *RIRate) Stringify() string {
 	str := fmt.Sprintf("%v %v %v %v %v", rir.ConnectFee, rir.RoundingMethod, rir.RoundingDecimals, rir.MaxCost, rir.MaxCostStrategy)
+	if rir.UsageCounter != utils.EmptyString {
+		str += " " + rir.UsageCounter
+	}
 	for _, r := range rir.Rates {
 		str += r.Stringify()
 	}
@@ -380,23 +385,49 @@ func (i *RateInterval) GetCost(duration, startSecond time.Duration) float64 {
 
 // Gets the price for a the provided start second
 func (i *RateInterval) GetRateParameters(startSecond time.Duration) (rate float64, rateIncrement, rateUnit time.Duration) {
-	if i.Rating == nil {
+	price := i.getRate(startSecond)
+	if price == nil {
 		return -1, -1, -1
 	}
+	if price.RateIncrement == 0 {
+		price.RateIncrement = 1 * time.Second
+	}
+	if price.RateUnit == 0 {
+		price.RateUnit = 1 * time.Second
+	}
+	return price.Value, price.RateIncrement, price.RateUnit
+}
+
+// getRate returns the rate group active at the provided start second
+func (i *RateInterval) getRate(startSecond time.Duration) *RGRate {
+	if i.Rating == nil {
+		return nil
+	}
+	startSecond = max(i.groupStart(startSecond), 0)
 	i.Rating.Rates.Sort()
 	for index, price := range i.Rating.Rates {
 		if price.GroupIntervalStart <= startSecond && (index == len(i.Rating.Rates)-1 ||
 			i.Rating.Rates[index+1].GroupIntervalStart > startSecond) {
-			if price.RateIncrement == 0 {
-				price.RateIncrement = 1 * time.Second
-			}
-			if price.RateUnit == 0 {
-				price.RateUnit = 1 * time.Second
-			}
-			return price.Value, price.RateIncrement, price.RateUnit
+			return price
 		}
 	}
-	return -1, -1, -1
+	return nil
+}
+
+// groupStart moves the call start second on the usage counter for the tiered rates
+func (i *RateInterval) groupStart(startSecond time.Duration) time.Duration {
+	if i.Rating == nil || i.Rating.UsageCounter == utils.EmptyString {
+		return startSecond
+	}
+	return startSecond + i.UsageOffset
+}
+
+// UsageTier returns the GroupIntervalStart of the tier applied at the provided start second
+func (i *RateInterval) UsageTier(startSecond time.Duration) (tier time.Duration) {
+	if rate := i.getRate(startSecond); rate != nil {
+		tier = rate.GroupIntervalStart
+	}
+	return
 }
 
 func (ri *RateInterval) GetMaxCost() (float64, string) {
@@ -456,9 +487,10 @@ func (i *RateInterval) Clone() (cln *RateInterval) {
 		return
 	}
 	cln = &RateInterval{
-		Timing: i.Timing.Clone(),
-		Rating: i.Rating.Clone(),
-		Weight: i.Weight,
+		Timing:      i.Timing.Clone(),
+		Rating:      i.Rating.Clone(),
+		Weight:      i.Weight,
+		UsageOffset: i.UsageOffset,
 	}
 	return
 }
@@ -506,6 +538,7 @@ func (rit *RIRate) Clone() (cln *RIRate) {
 		RoundingDecimals: rit.RoundingDecimals,
 		MaxCost:          rit.MaxCost,
 		MaxCostStrategy:  rit.MaxCostStrategy,
+		UsageCounter:     rit.UsageCounter,
 	}
 	if rit.Rates != nil {
 		cln.Rates = make([]*RGRate, len(rit.Rates))
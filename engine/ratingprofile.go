@@ -213,7 +213,7 @@ func (ris RatingInfos) String() string {
 func (rpf *RatingProfile) GetRatingPlansForPrefix(cd *CallDescriptor) (err error) {
 	var ris RatingInfos
 	for index, rpa := range rpf.RatingPlanActivations.GetActiveForCall(cd) {
-		rpl, err := dm.GetRatingPlan(rpa.RatingPlanId, false, utils.NonTransactional)
+		rpl, err := cd.getRatingPlan(rpa.RatingPlanId)
 		if err != nil || rpl == nil {
 			utils.Logger.Err(fmt.Sprintf("Error checking destination: %v", err))
 			continue
@@ -230,7 +230,7 @@ func (rpf *RatingProfile) GetRatingPlansForPrefix(cd *CallDescriptor) (err error
 			}
 		} else {
 			for _, p := range utils.SplitPrefix(cd.Destination, MIN_PREFIX_MATCH) {
-				if destIDs, err := dm.GetReverseDestination(p, true, true, utils.NonTransactional); err == nil {
+				if destIDs, err := cd.getReverseDestination(p); err == nil {
 					var bestWeight *float64
 					for _, dID := range destIDs {
 						var timeChecker bool
@@ -312,10 +312,19 @@ type TenantRatingSubject struct {
 }
 
 func RatingProfileSubjectPrefixMatching(key string) (rp *RatingProfile, err error) {
-	if !getRpSubjectPrefixMatching() || strings.HasSuffix(key, utils.MetaAny) {
+	return ratingProfileSubjectPrefixMatching(key, func(key string) (*RatingProfile, error) {
 		return dm.GetRatingProfile(key, false, utils.NonTransactional)
+	})
+}
+
+// ratingProfileSubjectPrefixMatching retrieves the RatingProfile using getRP, falling back
+// on the longest subject prefix in case of subject prefix matching
+func ratingProfileSubjectPrefixMatching(key string,
+	getRP func(string) (*RatingProfile, error)) (rp *RatingProfile, err error) {
+	if !getRpSubjectPrefixMatching() || strings.HasSuffix(key, utils.MetaAny) {
+		return getRP(key)
 	}
-	if rp, err = dm.GetRatingProfile(key, false, utils.NonTransactional); err == nil && rp != nil { // rp nil represents cached no-result
+	if rp, err = getRP(key); err == nil && rp != nil { // rp nil represents cached no-result
 		return
 	}
 	lastIndex := strings.LastIndex(key, utils.ConcatenatedKeySep)
@@ -323,8 +332,7 @@ func RatingProfileSubjectPrefixMatching(key string) (rp *RatingProfile, err erro
 	subject := key[lastIndex:]
 	lenSubject := len(subject)
 	for i := 1; i < lenSubject-1; i++ {
-		if rp, err = dm.GetRatingProfile(baseKey+subject[:lenSubject-i],
-			false, utils.NonTransactional); err == nil && rp != nil {
+		if rp, err = getRP(baseKey + subject[:lenSubject-i]); err == nil && rp != nil {
 			return
 		}
 	}
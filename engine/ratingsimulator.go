@@ -0,0 +1,250 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ArgSimulateRateCDRs are the arguments for CDRsV1.SimulateRateCDRs
+type ArgSimulateRateCDRs struct {
+	TPid       string // the tariff plan within StorDB used to rate the CDRs
+	FolderPath string // the tariff plan folder used to rate the CDRs, considered when TPid is empty
+	utils.RPCCDRsFilter
+	Export  bool     // export the per CDR cost differences through EEs
+	EeIDs   []string // exporters to use when Export is enabled
+	Tenant  string
+	APIOpts map[string]any
+}
+
+// CostDelta aggregates the old and the simulated cost for a group of CDRs
+type CostDelta struct {
+	CDRs    int
+	OldCost float64
+	NewCost float64
+	Delta   float64
+}
+
+func (cDlt *CostDelta) add(oldCost, newCost float64) {
+	cDlt.CDRs++
+	cDlt.OldCost += oldCost
+	cDlt.NewCost += newCost
+	cDlt.Delta = cDlt.NewCost - cDlt.OldCost
+}
+
+func (cDlt *CostDelta) round(decimals int) {
+	cDlt.OldCost = utils.Round(cDlt.OldCost, decimals, utils.MetaRoundingMiddle)
+	cDlt.NewCost = utils.Round(cDlt.NewCost, decimals, utils.MetaRoundingMiddle)
+	cDlt.Delta = utils.Round(cDlt.Delta, decimals, utils.MetaRoundingMiddle)
+}
+
+// RateCDRsSimulation is the reply of CDRsV1.SimulateRateCDRs
+type RateCDRsSimulation struct {
+	CDRs         int                   // number of CDRs matching the filter
+	Unrated      int                   // number of *raw or not previously rated CDRs, left out of the comparison
+	Errors       int                   // number of CDRs which could not be rated with the new tariff plan
+	Total        *CostDelta            // cost differences over all the rated CDRs
+	Accounts     map[string]*CostDelta // cost differences grouped by tenant:account
+	Destinations map[string]*CostDelta // cost differences grouped by the matched destination ID
+	Subjects     map[string]*CostDelta // cost differences grouped by tenant:subject
+}
+
+func newRateCDRsSimulation() *RateCDRsSimulation {
+	return &RateCDRsSimulation{
+		Total:        new(CostDelta),
+		Accounts:     make(map[string]*CostDelta),
+		Destinations: make(map[string]*CostDelta),
+		Subjects:     make(map[string]*CostDelta),
+	}
+}
+
+func (rSim *RateCDRsSimulation) add(cdr *CDR, destID string, oldCost, newCost float64) {
+	rSim.Total.add(oldCost, newCost)
+	addCostDelta(rSim.Accounts, utils.ConcatenatedKey(cdr.Tenant, cdr.Account), oldCost, newCost)
+	addCostDelta(rSim.Destinations, destID, oldCost, newCost)
+	addCostDelta(rSim.Subjects, utils.ConcatenatedKey(cdr.Tenant, cdr.Subject), oldCost, newCost)
+}
+
+func addCostDelta(grp map[string]*CostDelta, key string, oldCost, newCost float64) {
+	if _, has := grp[key]; !has {
+		grp[key] = new(CostDelta)
+	}
+	grp[key].add(oldCost, newCost)
+}
+
+func (rSim *RateCDRsSimulation) round(decimals int) {
+	rSim.Total.round(decimals)
+	for _, grp := range []map[string]*CostDelta{rSim.Accounts, rSim.Destinations, rSim.Subjects} {
+		for _, cDlt := range grp {
+			cDlt.round(decimals)
+		}
+	}
+}
+
+// newRatingSimulationDB loads the rating data of a tariff plan into a new
+// internal DataDB, isolated from the live data(no caching or replication)
+func newRatingSimulationDB(lr LoadReader, tpid, timezone string,
+	itmsCfg map[string]*config.ItemOpt) (db DataDB, err error) {
+	var iDB *InternalDB
+	if iDB, err = NewInternalDB(nil, nil, true, nil, itmsCfg); err != nil {
+		return
+	}
+	var tpr *TpReader
+	if tpr, err = NewTpReader(iDB, lr, tpid, timezone, nil, nil); err != nil {
+		return
+	}
+	for _, load := range []func() error{
+		tpr.LoadDestinations,
+		tpr.LoadTimings,
+		tpr.LoadRates,
+		tpr.LoadDestinationRates,
+		tpr.LoadRatingPlans,
+		tpr.LoadRatingProfiles,
+	} {
+		if err = load(); err != nil && err.Error() != utils.NotFoundCaps {
+			return
+		}
+	}
+	for _, dst := range tpr.destinations {
+		if err = iDB.SetDestinationDrv(dst, utils.NonTransactional); err != nil {
+			return
+		}
+		if err = iDB.SetReverseDestinationDrv(dst.Id, dst.Prefixes, utils.NonTransactional); err != nil {
+			return
+		}
+	}
+	for _, rp := range tpr.ratingPlans {
+		if err = iDB.SetRatingPlanDrv(rp); err != nil {
+			return
+		}
+	}
+	for _, rp := range tpr.ratingProfiles {
+		if err = iDB.SetRatingProfileDrv(rp); err != nil {
+			return
+		}
+	}
+	return iDB, nil
+}
+
+// simulateCDRCost returns the cost of the CDR rated against the data in ratingDB
+func simulateCDRCost(cdr *CDR, ratingDB DataDB) (cc *CallCost, err error) {
+	timeStart := cdr.AnswerTime
+	if timeStart.IsZero() { // unanswered calls
+		timeStart = cdr.SetupTime
+	}
+	cd := &CallDescriptor{
+		ToR:             cdr.ToR,
+		Tenant:          cdr.Tenant,
+		Category:        cdr.Category,
+		Subject:         cdr.Subject,
+		Account:         cdr.Account,
+		Destination:     cdr.Destination,
+		ExtraFields:     cdr.ExtraFields,
+		TimeStart:       timeStart,
+		TimeEnd:         timeStart.Add(cdr.Usage),
+		DurationIndex:   cdr.Usage,
+		PerformRounding: true,
+		ratingDB:        ratingDB,
+	}
+	return cd.GetCost()
+}
+
+// V1SimulateRateCDRs rates the CDRs matching the filter against a tariff plan loaded
+// into an isolated DataDB, returning the cost differences without touching the live data
+func (cdrS *CDRServer) V1SimulateRateCDRs(ctx *context.Context, arg *ArgSimulateRateCDRs, reply *RateCDRsSimulation) (err error) {
+	var lr LoadReader
+	switch {
+	case arg.TPid != utils.EmptyString:
+		var canLoad bool
+		if lr, canLoad = cdrS.cdrDb.(LoadReader); !canLoad {
+			return utils.NewErrServerError(fmt.Errorf("StorDB does not support loading tariff plans"))
+		}
+	case arg.FolderPath != utils.EmptyString:
+		if fi, err := os.Stat(arg.FolderPath); err != nil || !fi.IsDir() {
+			return utils.ErrInvalidPath
+		}
+		if lr, err = NewFileCSVStorage(utils.CSVSep, arg.FolderPath); err != nil {
+			return utils.NewErrServerError(err)
+		}
+	default:
+		return utils.NewErrMandatoryIeMissing("TPid", "FolderPath")
+	}
+	var cdrFltr *utils.CDRsFilter
+	if cdrFltr, err = arg.RPCCDRsFilter.AsCDRsFilter(cdrS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	var ratingDB DataDB
+	if ratingDB, err = newRatingSimulationDB(lr, arg.TPid,
+		cdrS.cgrCfg.GeneralCfg().DefaultTimezone,
+		cdrS.cgrCfg.DataDbCfg().Items); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	var cdrs []*CDR
+	if cdrs, _, err = cdrS.cdrDb.GetCDRs(cdrFltr, false); err != nil {
+		return
+	}
+	rSim := newRateCDRsSimulation()
+	rSim.CDRs = len(cdrs)
+	for _, cdr := range cdrs {
+		if cdr.RunID == utils.MetaRaw || cdr.Cost < 0 { // no previous cost to compare with
+			rSim.Unrated++
+			continue
+		}
+		cc, err := simulateCDRCost(cdr, ratingDB)
+		if err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed simulating cost for CDR with CGRID: %s, RunID: %s, error: %s",
+					utils.CDRs, cdr.CGRID, cdr.RunID, err.Error()))
+			rSim.Errors++
+			continue
+		}
+		destID := cdr.Destination
+		if len(cc.Timespans) != 0 && cc.Timespans[0].MatchedDestId != utils.EmptyString {
+			destID = cc.Timespans[0].MatchedDestId
+		}
+		oldCost := cdr.Cost
+		rSim.add(cdr, destID, oldCost, cc.Cost)
+		if !arg.Export {
+			continue
+		}
+		cgrEv := cdr.AsCGREvent()
+		cgrEv.Event[utils.SimulatedCost] = cc.Cost
+		cgrEv.Event[utils.CostDelta] = utils.Round(cc.Cost-oldCost,
+			cdrS.cgrCfg.GeneralCfg().RoundingDecimals, utils.MetaRoundingMiddle)
+		for k, v := range arg.APIOpts {
+			cgrEv.APIOpts[k] = v
+		}
+		if err = cdrS.eeSProcessEvent(&CGREventWithEeIDs{
+			EeIDs:    arg.EeIDs,
+			CGREvent: cgrEv,
+		}); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: <%s> exporting simulated cost for CDR with CGRID: %s, RunID: %s",
+					utils.CDRs, err.Error(), cdr.CGRID, cdr.RunID))
+		}
+	}
+	rSim.round(cdrS.cgrCfg.GeneralCfg().RoundingDecimals)
+	*reply = *rSim
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestV1SimulateRateCDRs(t *testing.T) {
+	Cache.Clear(nil)
+	tpDir := t.TempDir()
+	for fName, content := range map[string]string{
+		utils.DestinationsCsv: `#Id,Prefix
+DST_1002,1002
+DST_1003,1003
+`,
+		utils.RatesCsv: `#Id,ConnectFee,Rate,RateUnit,RateIncrement,GroupIntervalStart
+RT_1CNT,0,0.01,1s,1s,0s
+RT_2CNT,0,0.02,1s,1s,0s
+`,
+		utils.DestinationRatesCsv: `#Id,DestinationId,RatesTag,RoundingMethod,RoundingDecimals,MaxCost,MaxCostStrategy
+DR_1002,DST_1002,RT_1CNT,*up,4,0,
+DR_1003,DST_1003,RT_2CNT,*up,4,0,
+`,
+		utils.RatingPlansCsv: `#Id,DestinationRatesId,TimingTag,Weight
+RP_1001,DR_1002,*any,10
+RP_1001,DR_1003,*any,10
+`,
+		utils.RatingProfilesCsv: `#Tenant,Category,Subject,ActivationTime,RatingPlanId,RatesFallbackSubject
+cgrates.org,call,1001,2014-01-14T00:00:00Z,RP_1001,
+`,
+	} {
+		if err := os.WriteFile(path.Join(tpDir, fName), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var exported []*utils.CGREvent
+	clientconn := make(chan birpc.ClientConnector, 1)
+	clientconn <- &ccMock{
+		calls: map[string]func(ctx *context.Context, args any, reply any) error{
+			utils.EeSv1ProcessEvent: func(ctx *context.Context, args, reply any) error {
+				exported = append(exported, args.(*CGREventWithEeIDs).CGREvent)
+				return nil
+			},
+		},
+	}
+	cfg := config.NewDefaultCGRConfig()
+	cfg.CdrsCfg().EEsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.EEsConnsCfg)}
+	connMgr := NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.EEsConnsCfg): clientconn,
+	})
+	storDB, err := NewInternalDB(nil, nil, false, nil, cfg.StorDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cdrS := &CDRServer{
+		cgrCfg:  cfg,
+		cdrDb:   storDB,
+		connMgr: connMgr,
+	}
+	answerTime := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	for _, cdr := range []*CDR{
+		{CGRID: "cdr1", RunID: utils.MetaDefault, OrderID: 1, ToR: utils.MetaVoice, Tenant: "cgrates.org",
+			Category: "call", Account: "1001", Subject: "1001", Destination: "1002",
+			SetupTime: answerTime, AnswerTime: answerTime, Usage: time.Minute, Cost: 0.5},
+		{CGRID: "cdr2", RunID: utils.MetaDefault, OrderID: 2, ToR: utils.MetaVoice, Tenant: "cgrates.org",
+			Category: "call", Account: "1001", Subject: "1001", Destination: "1003",
+			SetupTime: answerTime, AnswerTime: answerTime, Usage: 30 * time.Second, Cost: 0.7},
+		{CGRID: "cdr3", RunID: utils.MetaDefault, OrderID: 3, ToR: utils.MetaVoice, Tenant: "cgrates.org",
+			Category: "call", Account: "1001", Subject: "1001", Destination: "1004",
+			SetupTime: answerTime, AnswerTime: answerTime, Usage: time.Minute, Cost: 0.3},
+		{CGRID: "cdr4", RunID: utils.MetaRaw, OrderID: 4, ToR: utils.MetaVoice, Tenant: "cgrates.org",
+			Category: "call", Account: "1001", Subject: "1001", Destination: "1002",
+			SetupTime: answerTime, AnswerTime: answerTime, Usage: time.Minute, Cost: -1},
+		{CGRID: "cdr5", RunID: utils.MetaDefault, OrderID: 5, ToR: utils.MetaVoice, Tenant: "cgrates.org",
+			Category: "call", Account: "1001", Subject: "1001", Destination: "1003",
+			SetupTime: answerTime, AnswerTime: answerTime, Usage: time.Minute, Cost: -1},
+	} {
+		if err = storDB.SetCDR(cdr, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var reply RateCDRsSimulation
+	if err = cdrS.V1SimulateRateCDRs(context.Background(), &ArgSimulateRateCDRs{},
+		&reply); err == nil || err.Error() != "MANDATORY_IE_MISSING: [TPid FolderPath]" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err = cdrS.V1SimulateRateCDRs(context.Background(), &ArgSimulateRateCDRs{
+		FolderPath: path.Join(tpDir, "inexistent"),
+	}, &reply); err != utils.ErrInvalidPath {
+		t.Errorf("expected %v, received %v", utils.ErrInvalidPath, err)
+	}
+	if err = cdrS.V1SimulateRateCDRs(context.Background(), &ArgSimulateRateCDRs{
+		FolderPath: tpDir,
+		Export:     true,
+		EeIDs:      []string{"sim_diffs"},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	exp := RateCDRsSimulation{
+		CDRs:    5,
+		Unrated: 2, // the *raw CDR and the one not rated before
+		Errors:  1, // no rates for destination 1004
+		Total:   &CostDelta{CDRs: 2, OldCost: 1.2, NewCost: 1.2, Delta: 0},
+		Accounts: map[string]*CostDelta{
+			"cgrates.org:1001": {CDRs: 2, OldCost: 1.2, NewCost: 1.2, Delta: 0},
+		},
+		Destinations: map[string]*CostDelta{
+			"DST_1002": {CDRs: 1, OldCost: 0.5, NewCost: 0.6, Delta: 0.1},
+			"DST_1003": {CDRs: 1, OldCost: 0.7, NewCost: 0.6, Delta: -0.1},
+		},
+		Subjects: map[string]*CostDelta{
+			"cgrates.org:1001": {CDRs: 2, OldCost: 1.2, NewCost: 1.2, Delta: 0},
+		},
+	}
+	if !reflect.DeepEqual(exp, reply) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(reply))
+	}
+	if len(exported) != 2 {
+		t.Fatalf("expected 2 exported events, received %d", len(exported))
+	}
+	if exported[0].Event[utils.SimulatedCost] != 0.6 {
+		t.Errorf("unexpected exported event: %s", utils.ToJSON(exported[0]))
+	}
+
+	// live data remains untouched
+	if _, has := Cache.Get(utils.CacheRatingProfiles, "*out:cgrates.org:call:1001"); has {
+		t.Error("simulation data was cached")
+	}
+	if cdrs, _, err := storDB.GetCDRs(&utils.CDRsFilter{CGRIDs: []string{"cdr1"}}, false); err != nil {
+		t.Error(err)
+	} else if cdrs[0].Cost != 0.5 {
+		t.Errorf("expected the stored cost to remain 0.5, received %v", cdrs[0].Cost)
+	}
+}
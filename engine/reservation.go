@@ -0,0 +1,272 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Reservation is an amount held on the account balances until it is committed or released.
+// The amount is subtracted from the balances when reserved so it is no longer available
+// for the debits and the max usage calculations
+type Reservation struct {
+	ID          string
+	BalanceType string
+	Amount      float64
+	Balances    []*ReservedBalance // in the order they were debited
+	ExpiryTime  time.Time
+}
+
+// ReservedBalance is the part of the reservation held on one balance
+type ReservedBalance struct {
+	UUID  string
+	Value float64
+}
+
+// Clone returns a copy of the reservation
+func (rsv *Reservation) Clone() (cln *Reservation) {
+	cln = &Reservation{
+		ID:          rsv.ID,
+		BalanceType: rsv.BalanceType,
+		Amount:      rsv.Amount,
+		ExpiryTime:  rsv.ExpiryTime,
+	}
+	if rsv.Balances != nil {
+		cln.Balances = make([]*ReservedBalance, len(rsv.Balances))
+		for i, rb := range rsv.Balances {
+			cln.Balances[i] = &ReservedBalance{UUID: rb.UUID, Value: rb.Value}
+		}
+	}
+	return
+}
+
+// IsExpiredAt checks if the reservation expired at time t
+func (rsv *Reservation) IsExpiredAt(t time.Time) bool {
+	return !rsv.ExpiryTime.IsZero() && !rsv.ExpiryTime.After(t)
+}
+
+// reserve holds the reservation amount on the active balances matching the filter,
+// starting with the ones that would be debited first
+func (acc *Account) reserve(rsv *Reservation, fltr *BalanceFilter, now time.Time) (err error) {
+	if _, has := acc.Reservations[rsv.ID]; has {
+		return utils.ErrExists
+	}
+	var blncs Balances
+	for _, b := range acc.BalanceMap[rsv.BalanceType] {
+		if !b.Disabled && b.GetValue() > 0 && !b.IsExpiredAt(now) && b.IsActiveAt(now) &&
+			b.MatchFilter(fltr, rsv.BalanceType, false, false) {
+			blncs = append(blncs, b)
+		}
+	}
+	if blncs.GetTotalValue() < rsv.Amount {
+		return utils.ErrInsufficientCredit
+	}
+	blncs.Sort()
+	toHold := rsv.Amount
+	for _, b := range blncs {
+		if toHold <= 0 {
+			break
+		}
+		held := min(b.GetValue(), toHold)
+		b.SubtractValue(held)
+		toHold = utils.Round(toHold-held, globalRoundingDecimals, utils.MetaRoundingMiddle)
+		rsv.Balances = append(rsv.Balances, &ReservedBalance{UUID: b.Uuid, Value: held})
+	}
+	if acc.Reservations == nil {
+		acc.Reservations = make(map[string]*Reservation)
+	}
+	acc.Reservations[rsv.ID] = rsv
+	return
+}
+
+// refundReservation returns the amount to the balances it was held on, in the
+// reverse order of debiting, and removes the reservation from the account
+func (acc *Account) refundReservation(rsv *Reservation, amount float64) {
+	for i := len(rsv.Balances) - 1; i >= 0 && amount > 0; i-- {
+		rb := rsv.Balances[i]
+		refund := min(rb.Value, amount)
+		amount = utils.Round(amount-refund, globalRoundingDecimals, utils.MetaRoundingMiddle)
+		b := acc.BalanceMap[rsv.BalanceType].GetBalance(rb.UUID)
+		if b == nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> could not refund %v of reservation <%s> on account <%s>, balance <%s> was removed",
+					utils.RALService, refund, rsv.ID, acc.ID, rb.UUID))
+			continue
+		}
+		b.AddValue(refund)
+	}
+	delete(acc.Reservations, rsv.ID)
+}
+
+// releaseExpiredReservations returns the funds of the expired reservations to the balances
+func (acc *Account) releaseExpiredReservations(now time.Time) (released bool) {
+	for _, rsv := range acc.Reservations {
+		if rsv.IsExpiredAt(now) {
+			acc.refundReservation(rsv, rsv.Amount)
+			released = true
+		}
+	}
+	return
+}
+
+// ReserveBalance holds the amount on the balances of the account until the reservation is
+// committed, released or it expires
+func ReserveBalance(dm *DataManager, args *utils.ArgsReserveBalance, timezone string) (rsv *Reservation, err error) {
+	var fltr *BalanceFilter
+	if fltr, err = NewBalanceFilter(args.Balance, timezone); err != nil {
+		return
+	}
+	rsv = &Reservation{
+		ID:          utils.FirstNonEmpty(args.ReservationID, utils.UUIDSha1Prefix()),
+		BalanceType: args.BalanceType,
+		Amount:      args.Amount,
+	}
+	if rsv.ExpiryTime, err = utils.ParseTimeDetectLayout(args.ExpiryTime, timezone); err != nil {
+		return
+	}
+	acntID := utils.ConcatenatedKey(args.Tenant, args.Account)
+	if err = guardian.Guardian.Guard(func() (err error) {
+		var acc *Account
+		if acc, err = dm.GetAccount(acntID); err != nil {
+			return
+		}
+		if acc.Disabled {
+			return utils.ErrAccountDisabled
+		}
+		now := time.Now()
+		acc.releaseExpiredReservations(now)
+		if err = acc.reserve(rsv, fltr, now); err != nil {
+			return
+		}
+		return dm.SetAccount(acc)
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+acntID); err != nil {
+		return nil, err
+	}
+	armReservationExpiry(dm, acntID, rsv)
+	return
+}
+
+// rsvTimers holds the expiry timers armed by this engine, indexed by account, reservation ID and expiry time
+var rsvTimers = struct {
+	sync.Mutex
+	timers map[string]*time.Timer
+}{timers: make(map[string]*time.Timer)}
+
+// armReservationExpiry schedules the release of the reservation at its expiry time,
+// unless already scheduled by this engine
+func armReservationExpiry(dm *DataManager, acntID string, rsv *Reservation) {
+	if rsv.ExpiryTime.IsZero() {
+		return
+	}
+	tmrID := utils.ConcatenatedKey(acntID, rsv.ID, strconv.FormatInt(rsv.ExpiryTime.UnixNano(), 10))
+	rsvTimers.Lock()
+	defer rsvTimers.Unlock()
+	if _, has := rsvTimers.timers[tmrID]; has {
+		return
+	}
+	rsvTimers.timers[tmrID] = time.AfterFunc(time.Until(rsv.ExpiryTime), func() {
+		rsvTimers.Lock()
+		delete(rsvTimers.timers, tmrID)
+		rsvTimers.Unlock()
+		if err := releaseExpiredReservations(dm, acntID); err != nil &&
+			err != utils.ErrNotFound { // account removed in the meantime
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed releasing expired reservations on account <%s>: %v",
+					utils.RALService, acntID, err))
+		}
+	})
+}
+
+// releaseExpiredReservations returns the funds of the expired reservations to the account balances.
+// Only the reservations expired by now are released, so a reservation committed and created
+// again with the same ID is not affected by the timer of the old one
+func releaseExpiredReservations(dm *DataManager, acntID string) error {
+	return guardian.Guardian.Guard(func() (err error) {
+		var acc *Account
+		if acc, err = dm.GetAccount(acntID); err != nil {
+			return
+		}
+		if acc.releaseExpiredReservations(time.Now()) {
+			err = dm.SetAccount(acc)
+		}
+		return
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+acntID)
+}
+
+// armReservations schedules the release of the account reservations, rearming the
+// expiry timers lost with an engine restart once the account is loaded
+func armReservations(dm *DataManager, acc *Account) {
+	for _, rsv := range acc.Reservations {
+		armReservationExpiry(dm, acc.ID, rsv)
+	}
+}
+
+// CommitReservation debits the amount out of the reservation, returning the rest to the balances.
+// A nil amount commits the entire reservation
+func CommitReservation(dm *DataManager, tnt, acnt, rsvID string, amount *float64) error {
+	return updateReservation(dm, utils.ConcatenatedKey(tnt, acnt), rsvID, func(rsv *Reservation) (float64, error) {
+		if amount == nil {
+			return 0, nil
+		}
+		if *amount < 0 || *amount > rsv.Amount {
+			return 0, fmt.Errorf("commit amount %v outside of reserved %v", *amount, rsv.Amount)
+		}
+		return utils.Round(rsv.Amount-*amount, globalRoundingDecimals, utils.MetaRoundingMiddle), nil
+	})
+}
+
+// ReleaseReservation returns the entire reservation to the balances
+func ReleaseReservation(dm *DataManager, tnt, acnt, rsvID string) error {
+	return updateReservation(dm, utils.ConcatenatedKey(tnt, acnt), rsvID, func(rsv *Reservation) (float64, error) {
+		return rsv.Amount, nil
+	})
+}
+
+// updateReservation removes the reservation refunding the amount returned by refundFunc
+func updateReservation(dm *DataManager, acntID, rsvID string,
+	refundFunc func(*Reservation) (float64, error)) error {
+	return guardian.Guardian.Guard(func() (err error) {
+		var acc *Account
+		if acc, err = dm.GetAccount(acntID); err != nil {
+			return
+		}
+		rsv, has := acc.Reservations[rsvID]
+		if !has || rsv.IsExpiredAt(time.Now()) {
+			if acc.releaseExpiredReservations(time.Now()) {
+				if err = dm.SetAccount(acc); err != nil {
+					return
+				}
+			}
+			return utils.ErrNotFound
+		}
+		var refund float64
+		if refund, err = refundFunc(rsv); err != nil {
+			return
+		}
+		acc.refundReservation(rsv, refund)
+		return dm.SetAccount(acc)
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+acntID)
+}
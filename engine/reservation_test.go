@@ -0,0 +1,294 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newReservationTestDM(t *testing.T) *DataManager {
+	cfg := config.NewDefaultCGRConfig()
+	dataDB, err := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm := NewDataManager(dataDB, cfg.CacheCfg(), nil)
+	if err = dm.SetAccount(&Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {
+				{Uuid: "uuid1", ID: "main", Value: 10, Weight: 10, Categories: utils.StringMap{"call": true}},
+				{Uuid: "uuid2", ID: "bonus", Value: 5, Weight: 20, Categories: utils.StringMap{"call": true}},
+				{Uuid: "uuid3", ID: "promo", Value: 50, Categories: utils.StringMap{"sms": true}},
+			},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return dm
+}
+
+func reservationTestValues(t *testing.T, dm *DataManager) (vals map[string]float64, rsvs map[string]*Reservation) {
+	t.Helper()
+	acc, err := dm.GetAccount("cgrates.org:1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vals = make(map[string]float64)
+	for _, b := range acc.BalanceMap[utils.MetaMonetary] {
+		vals[b.ID] = b.Value
+	}
+	return vals, acc.Reservations
+}
+
+func TestReserveBalance(t *testing.T) {
+	dm := newReservationTestDM(t)
+	args := &utils.ArgsReserveBalance{
+		Tenant:        "cgrates.org",
+		Account:       "1001",
+		ReservationID: "RSV1",
+		BalanceType:   utils.MetaMonetary,
+		Amount:        12,
+		Balance:       map[string]any{utils.Categories: "call"},
+		ExpiryTime:    "+1h",
+	}
+	rsv, err := ReserveBalance(dm, args, utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expBlncs := []*ReservedBalance{{UUID: "uuid2", Value: 5}, {UUID: "uuid1", Value: 7}}
+	if !reflect.DeepEqual(expBlncs, rsv.Balances) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expBlncs), utils.ToJSON(rsv.Balances))
+	}
+	if time.Until(rsv.ExpiryTime) <= 59*time.Minute {
+		t.Errorf("unexpected expiry time: %v", rsv.ExpiryTime)
+	}
+	vals, rsvs := reservationTestValues(t, dm)
+	if exp := map[string]float64{"main": 3, "bonus": 0, "promo": 50}; !reflect.DeepEqual(exp, vals) {
+		t.Errorf("expected %v, received %v", exp, vals)
+	}
+	if !reflect.DeepEqual(map[string]*Reservation{"RSV1": rsv}, rsvs) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(rsv), utils.ToJSON(rsvs))
+	}
+
+	if _, err = ReserveBalance(dm, args, utils.EmptyString); err != utils.ErrExists {
+		t.Errorf("expected %v, received %v", utils.ErrExists, err)
+	}
+	args.ReservationID = "RSV2"
+	args.Amount = 4
+	if _, err = ReserveBalance(dm, args, utils.EmptyString); err != utils.ErrInsufficientCredit {
+		t.Errorf("expected %v, received %v", utils.ErrInsufficientCredit, err)
+	}
+	args.Tenant = "cgrates.net"
+	if _, err = ReserveBalance(dm, args, utils.EmptyString); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestCommitReleaseReservation(t *testing.T) {
+	dm := newReservationTestDM(t)
+	for _, rsvID := range []string{"RSV1", "RSV2"} {
+		if _, err := ReserveBalance(dm, &utils.ArgsReserveBalance{
+			Tenant:        "cgrates.org",
+			Account:       "1001",
+			ReservationID: rsvID,
+			BalanceType:   utils.MetaMonetary,
+			Amount:        6,
+			Balance:       map[string]any{utils.Categories: "call"},
+			ExpiryTime:    "+1h",
+		}, utils.EmptyString); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if vals, _ := reservationTestValues(t, dm); vals["bonus"] != 0 || vals["main"] != 3 {
+		t.Errorf("unexpected balances: %v", vals)
+	}
+
+	if err := CommitReservation(dm, "cgrates.org", "1001", "RSV1", utils.Float64Pointer(7)); err == nil {
+		t.Error("expected error for amount higher than reserved")
+	}
+	if err := CommitReservation(dm, "cgrates.org", "1001", "RSV2", utils.Float64Pointer(2.5)); err != nil {
+		t.Fatal(err)
+	}
+	vals, rsvs := reservationTestValues(t, dm)
+	if exp := map[string]float64{"main": 6.5, "bonus": 0, "promo": 50}; !reflect.DeepEqual(exp, vals) {
+		t.Errorf("expected %v, received %v", exp, vals)
+	}
+	if _, has := rsvs["RSV2"]; has {
+		t.Error("committed reservation not removed")
+	}
+	if err := CommitReservation(dm, "cgrates.org", "1001", "RSV2", nil); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+
+	// RSV1 held 5 on bonus and 1 on main
+	if err := ReleaseReservation(dm, "cgrates.org", "1001", "RSV1"); err != nil {
+		t.Fatal(err)
+	}
+	vals, rsvs = reservationTestValues(t, dm)
+	if exp := map[string]float64{"main": 7.5, "bonus": 5, "promo": 50}; !reflect.DeepEqual(exp, vals) {
+		t.Errorf("expected %v, received %v", exp, vals)
+	}
+	if len(rsvs) != 0 {
+		t.Errorf("expected no reservations, received %s", utils.ToJSON(rsvs))
+	}
+	if err := ReleaseReservation(dm, "cgrates.org", "1001", "RSV1"); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestReservationExpiry(t *testing.T) {
+	rdm := newReservationTestDM(t)
+	if _, err := ReserveBalance(rdm, &utils.ArgsReserveBalance{
+		Tenant:        "cgrates.org",
+		Account:       "1001",
+		ReservationID: "RSV1",
+		BalanceType:   utils.MetaMonetary,
+		Amount:        10,
+		ExpiryTime:    "+20ms",
+	}, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	vals, rsvs := reservationTestValues(t, rdm)
+	if exp := map[string]float64{"main": 10, "bonus": 5, "promo": 50}; !reflect.DeepEqual(exp, vals) {
+		t.Errorf("expected %v, received %v", exp, vals)
+	}
+	if len(rsvs) != 0 {
+		t.Errorf("expected no reservations, received %s", utils.ToJSON(rsvs))
+	}
+
+	// reservations expired while the engine was down are released when the account is loaded
+	acc, err := rdm.GetAccount("cgrates.org:1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc.BalanceMap[utils.MetaMonetary][1].Value = 0
+	acc.Reservations = map[string]*Reservation{
+		"RSV2": {
+			ID:          "RSV2",
+			BalanceType: utils.MetaMonetary,
+			Amount:      5,
+			Balances:    []*ReservedBalance{{UUID: "uuid2", Value: 5}},
+			ExpiryTime:  time.Now().Add(-time.Minute),
+		},
+	}
+	if err = rdm.SetAccount(acc); err != nil {
+		t.Fatal(err)
+	}
+	tmpDm := dm
+	defer SetDataStorage(tmpDm)
+	SetDataStorage(rdm)
+	cd := &CallDescriptor{Tenant: "cgrates.org", Account: "1001"}
+	if acc, err = cd.getAccount(); err != nil {
+		t.Fatal(err)
+	}
+	if acc.BalanceMap[utils.MetaMonetary][1].Value != 5 || len(acc.Reservations) != 0 {
+		t.Errorf("expected released reservation, received %s", utils.ToJSON(acc))
+	}
+}
+
+func TestReservationExpiryRearmed(t *testing.T) {
+	rdm := newReservationTestDM(t)
+	acc, err := rdm.GetAccount("cgrates.org:1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// reservation stored before a restart, without the expiry timer armed by this engine
+	acc.BalanceMap[utils.MetaMonetary][1].Value = 0
+	acc.Reservations = map[string]*Reservation{
+		"RSV3": {
+			ID:          "RSV3",
+			BalanceType: utils.MetaMonetary,
+			Amount:      5,
+			Balances:    []*ReservedBalance{{UUID: "uuid2", Value: 5}},
+			ExpiryTime:  time.Now().Add(20 * time.Millisecond),
+		},
+	}
+	if err = rdm.dataDB.SetAccountDrv(acc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = rdm.GetAccount("cgrates.org:1001"); err != nil { // loading the account rearms the timer
+		t.Fatal(err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	vals, rsvs := reservationTestValues(t, rdm)
+	if exp := map[string]float64{"main": 10, "bonus": 5, "promo": 50}; !reflect.DeepEqual(exp, vals) {
+		t.Errorf("expected %v, received %v", exp, vals)
+	}
+	if len(rsvs) != 0 {
+		t.Errorf("expected no reservations, received %s", utils.ToJSON(rsvs))
+	}
+}
+
+func TestReserveBalanceDisabled(t *testing.T) {
+	rdm := newReservationTestDM(t)
+	acc, err := rdm.GetAccount("cgrates.org:1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc.BalanceMap[utils.MetaMonetary][1].Disabled = true
+	if err = rdm.SetAccount(acc); err != nil {
+		t.Fatal(err)
+	}
+	args := &utils.ArgsReserveBalance{
+		Tenant:        "cgrates.org",
+		Account:       "1001",
+		ReservationID: "RSV1",
+		BalanceType:   utils.MetaMonetary,
+		Amount:        12,
+		Balance:       map[string]any{utils.Categories: "call"},
+		ExpiryTime:    "+1h",
+	}
+	if _, err = ReserveBalance(rdm, args, utils.EmptyString); err != utils.ErrInsufficientCredit {
+		t.Errorf("expected %v, received %v", utils.ErrInsufficientCredit, err)
+	}
+	args.Amount = 8
+	rsv, err := ReserveBalance(rdm, args, utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := []*ReservedBalance{{UUID: "uuid1", Value: 8}}; !reflect.DeepEqual(exp, rsv.Balances) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rsv.Balances))
+	}
+}
+
+func TestReservationClone(t *testing.T) {
+	rsv := &Reservation{
+		ID:          "RSV1",
+		BalanceType: utils.MetaMonetary,
+		Amount:      5,
+		Balances:    []*ReservedBalance{{UUID: "uuid1", Value: 5}},
+		ExpiryTime:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	acc := &Account{ID: "cgrates.org:1001", Reservations: map[string]*Reservation{"RSV1": rsv}}
+	cln := acc.Clone()
+	if !reflect.DeepEqual(acc, cln) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(acc), utils.ToJSON(cln))
+	}
+	cln.Reservations["RSV1"].Balances[0].Value = 1
+	if rsv.Balances[0].Value != 5 {
+		t.Error("expected a deep copy of the reservations")
+	}
+}
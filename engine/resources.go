@@ -102,13 +102,16 @@ func resourceProfileLockKey(tnt, id string) string {
 
 // lock will lock the resourceProfile using guardian and store the lock within r.lkID
 // if lkID is passed as argument, the lock is considered as executed
-func (rp *ResourceProfile) lock(lkID string) {
+func (rp *ResourceProfile) lock(lkID string) (err error) {
 	if lkID == utils.EmptyString {
-		lkID = guardian.Guardian.GuardIDs("",
+		if lkID, err = guardian.Guardian.GuardIDs("",
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			resourceProfileLockKey(rp.Tenant, rp.ID))
+			resourceProfileLockKey(rp.Tenant, rp.ID)); err != nil {
+			return
+		}
 	}
 	rp.lkID = lkID
+	return
 }
 
 // unlock will unlock the resourceProfile and clear rp.lkID
@@ -218,13 +221,16 @@ func resourceLockKey(tnt, id string) string {
 
 // lock will lock the resource using guardian and store the lock within r.lkID
 // if lkID is passed as argument, the lock is considered as executed
-func (r *Resource) lock(lkID string) {
+func (r *Resource) lock(lkID string) (err error) {
 	if lkID == utils.EmptyString {
-		lkID = guardian.Guardian.GuardIDs("",
+		if lkID, err = guardian.Guardian.GuardIDs("",
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			resourceLockKey(r.Tenant, r.ID))
+			resourceLockKey(r.Tenant, r.ID)); err != nil {
+			return
+		}
 	}
 	r.lkID = lkID
+	return
 }
 
 // unlock will unlock the resource and clear r.lkID
@@ -516,7 +522,11 @@ func (rS *ResourceService) storeResources() {
 			continue
 		}
 		r := rIf.(*Resource)
-		r.lock(utils.EmptyString)
+		if err := r.lock(utils.EmptyString); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed locking resource with ID: %s, error: %s", utils.ResourceS, rID, err.Error()))
+			failedRIDs = append(failedRIDs, rID)
+			continue
+		}
 		if err := rS.storeResource(r); err != nil {
 			failedRIDs = append(failedRIDs, rID) // record failure so we can schedule it for next backup
 		}
@@ -673,9 +683,13 @@ func (rS *ResourceService) matchingResourcesForEvent(tnt string, ev *utils.CGREv
 	}
 	rs = make(Resources, 0, len(itemIDs))
 	for _, id := range itemIDs {
-		lkPrflID := guardian.Guardian.GuardIDs("",
+		var lkPrflID string
+		if lkPrflID, err = guardian.Guardian.GuardIDs("",
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			resourceProfileLockKey(tnt, id))
+			resourceProfileLockKey(tnt, id)); err != nil {
+			rs.unlock()
+			return nil, err
+		}
 		var rPrf *ResourceProfile
 		if rPrf, err = rS.dm.GetResourceProfile(tnt, id,
 			true, true, utils.NonTransactional); err != nil {
@@ -702,9 +716,14 @@ func (rS *ResourceService) matchingResourcesForEvent(tnt string, ev *utils.CGREv
 			rPrf.unlock()
 			continue
 		}
-		lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+		var lkID string
+		if lkID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			resourceLockKey(rPrf.Tenant, rPrf.ID))
+			resourceLockKey(rPrf.Tenant, rPrf.ID)); err != nil {
+			rPrf.unlock()
+			rs.unlock()
+			return nil, err
+		}
 		var r *Resource
 		if r, err = rS.dm.GetResource(rPrf.Tenant, rPrf.ID, true, true, ""); err != nil {
 			guardian.Guardian.UnguardIDs(lkID)
@@ -764,8 +783,12 @@ func (rS *ResourceService) V1GetResourcesForEvent(ctx *context.Context, args *ut
 	// RPC caching
 	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.ResourceSv1GetResourcesForEvent, utils.ConcatenatedKey(tnt, args.ID))
-		refID := guardian.Guardian.GuardIDs("",
-			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
 			cachedResp := itm.(*utils.CachedRPCResponse)
@@ -814,8 +837,12 @@ func (rS *ResourceService) V1AuthorizeResources(ctx *context.Context, args *util
 	// RPC caching
 	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.ResourceSv1AuthorizeResources, utils.ConcatenatedKey(tnt, args.ID))
-		refID := guardian.Guardian.GuardIDs("",
-			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
 			cachedResp := itm.(*utils.CachedRPCResponse)
@@ -881,8 +908,12 @@ func (rS *ResourceService) V1AllocateResources(ctx *context.Context, args *utils
 	// RPC caching
 	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.ResourceSv1AllocateResources, utils.ConcatenatedKey(tnt, args.ID))
-		refID := guardian.Guardian.GuardIDs("",
-			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
 			cachedResp := itm.(*utils.CachedRPCResponse)
@@ -952,8 +983,12 @@ func (rS *ResourceService) V1ReleaseResources(ctx *context.Context, args *utils.
 	// RPC caching
 	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.ResourceSv1ReleaseResources, utils.ConcatenatedKey(tnt, args.ID))
-		refID := guardian.Guardian.GuardIDs("",
-			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
 			cachedResp := itm.(*utils.CachedRPCResponse)
@@ -1007,9 +1042,12 @@ func (rS *ResourceService) V1GetResource(ctx *context.Context, arg *utils.Tenant
 	}
 
 	// make sure resource is locked at process level
-	lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+	lkID, err := guardian.Guardian.GuardIDs(utils.EmptyString,
 		config.CgrConfig().GeneralCfg().LockingTimeout,
 		resourceLockKey(tnt, arg.ID))
+	if err != nil {
+		return err
+	}
 	defer guardian.Guardian.UnguardIDs(lkID)
 
 	res, err := rS.dm.GetResource(tnt, arg.ID, true, true, utils.NonTransactional)
@@ -1035,9 +1073,12 @@ func (rS *ResourceService) V1GetResourceWithConfig(ctx *context.Context, arg *ut
 	}
 
 	// make sure resource is locked at process level
-	lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+	var lkID string
+	if lkID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
 		config.CgrConfig().GeneralCfg().LockingTimeout,
-		resourceLockKey(tnt, arg.ID))
+		resourceLockKey(tnt, arg.ID)); err != nil {
+		return
+	}
 	defer guardian.Guardian.UnguardIDs(lkID)
 
 	var res *Resource
@@ -1047,9 +1088,12 @@ func (rS *ResourceService) V1GetResourceWithConfig(ctx *context.Context, arg *ut
 	}
 
 	// make sure resourceProfile is locked at process level
-	lkPrflID := guardian.Guardian.GuardIDs(utils.EmptyString,
+	var lkPrflID string
+	if lkPrflID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
 		config.CgrConfig().GeneralCfg().LockingTimeout,
-		resourceProfileLockKey(tnt, arg.ID))
+		resourceProfileLockKey(tnt, arg.ID)); err != nil {
+		return
+	}
 	defer guardian.Guardian.UnguardIDs(lkPrflID)
 
 	if res.rPrf == nil {
@@ -0,0 +1,120 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewScoreSorter .
+func NewScoreSorter(rS *RouteService) *ScoreSorter {
+	return &ScoreSorter{rS: rS,
+		sorting: utils.MetaScore}
+}
+
+// ScoreSorter orders routes based on a weighted sum of their cost, stat metrics, resource usage and weight
+type ScoreSorter struct {
+	sorting string
+	rS      *RouteService
+}
+
+// SortRoutes .
+func (ss *ScoreSorter) SortRoutes(prflID string, routes map[string]*Route,
+	ev *utils.CGREvent, extraOpts *optsGetRoutes) (sortedRoutes *SortedRoutes, err error) {
+	var terms []*scoreTerm
+	if terms, err = parseScoreTerms(extraOpts.sortingParameters); err != nil {
+		return
+	}
+	// the routes missing a metric get the worst score for it instead of the *qos defaults
+	popOpts := *extraOpts
+	popOpts.sortingParameters = nil
+	sortedRoutes = &SortedRoutes{ProfileID: prflID,
+		Sorting: ss.sorting,
+		Routes:  make([]*SortedRoute, 0)}
+	for _, route := range routes {
+		if srtSpl, pass, err := ss.rS.populateSortingData(ev, route, &popOpts); err != nil {
+			return nil, err
+		} else if pass && srtSpl != nil {
+			sortedRoutes.Routes = append(sortedRoutes.Routes, srtSpl)
+		}
+	}
+	sortedRoutes.SortScore(terms)
+	return
+}
+
+// scoreTerm is one member of the *score formula
+type scoreTerm struct {
+	name        string  // as defined in SortingParameters
+	dataKey     string  // key of the value in the sorting data
+	coefficient float64 // weight of the term in the score
+	lowerBetter bool    // false for the values which are better when higher, ie: *weight
+	isMetric    bool
+	percentage  bool // scored as is instead of normalized across the routes, ie: 1-ASR
+}
+
+// value returns the value of the term for the route and false if the route is missing it
+func (st *scoreTerm) value(sr *SortedRoute) (val float64, has bool) {
+	if val, has = sr.sortingDataF64[st.dataKey]; has && st.isMetric && val == utils.StatsNA {
+		has = false // metric without data
+	}
+	return
+}
+
+// parseScoreTerms parses the SortingParameters of the *score strategy, defined as term:coefficient.
+// The terms are *cost, *resources, *weight or a StatS metric ID
+func parseScoreTerms(params []string) (terms []*scoreTerm, err error) {
+	if len(params) == 0 {
+		return nil, utils.NewErrMandatoryIeMissing(utils.SortingParameters)
+	}
+	terms = make([]*scoreTerm, len(params))
+	for i, param := range params {
+		idx := strings.LastIndex(param, utils.InInFieldSep)
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid %s sorting parameter: <%s>", utils.MetaScore, param)
+		}
+		st := &scoreTerm{name: param[:idx]}
+		if st.coefficient, err = strconv.ParseFloat(param[idx+1:], 64); err != nil {
+			return nil, fmt.Errorf("invalid %s sorting parameter: <%s>", utils.MetaScore, param)
+		}
+		switch st.name {
+		case utils.MetaCost:
+			st.dataKey = utils.Cost
+			st.lowerBetter = true
+		case utils.MetaResources:
+			st.dataKey = utils.ResourceUsage
+			st.lowerBetter = true
+		case utils.MetaWeight:
+			st.dataKey = utils.Weight
+		case utils.MetaASR:
+			st.dataKey = st.name
+			st.isMetric = true
+			st.percentage = true
+		default:
+			st.dataKey = st.name
+			st.isMetric = true
+			st.lowerBetter = st.name == utils.MetaPDD
+		}
+		terms[i] = st
+	}
+	return
+}
@@ -150,6 +150,10 @@ type RouteProfileWithAPIOpts struct {
 }
 
 func (rp *RouteProfile) compileCacheParameters() error {
+	if rp.Sorting == utils.MetaScore { // only validate, the terms are parsed on sorting
+		_, err := parseScoreTerms(rp.SortingParameters)
+		return err
+	}
 	if rp.Sorting == utils.MetaLoad {
 		// construct the map for ratio
 		ratioMap := make(map[string]int)
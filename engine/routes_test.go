@@ -2753,3 +2753,148 @@ func TestSortedRoutesSortHighestCost(t *testing.T) {
 		}
 	}
 }
+
+func TestRoutesSortScore(t *testing.T) {
+	sRoutes := &SortedRoutes{
+		Routes: []*SortedRoute{
+			{
+				RouteID:        "route1",
+				sortingDataF64: map[string]float64{utils.Weight: 10, utils.Cost: 1, utils.MetaASR: 40, utils.ResourceUsage: 2},
+				SortingData:    map[string]any{utils.Weight: 10.0, utils.Cost: 1.0, utils.MetaASR: 40.0, utils.ResourceUsage: 2.0},
+			},
+			{
+				RouteID:        "route2",
+				sortingDataF64: map[string]float64{utils.Weight: 10, utils.Cost: 2, utils.MetaASR: 100, utils.ResourceUsage: 0},
+				SortingData:    map[string]any{utils.Weight: 10.0, utils.Cost: 2.0, utils.MetaASR: 100.0, utils.ResourceUsage: 0.0},
+			},
+			{ // no data for ASR, scored as the worst
+				RouteID:        "route3",
+				sortingDataF64: map[string]float64{utils.Weight: 20, utils.Cost: 3, utils.MetaASR: -1, utils.ResourceUsage: 4},
+				SortingData:    map[string]any{utils.Weight: 20.0, utils.Cost: 3.0, utils.MetaASR: -1.0, utils.ResourceUsage: 4.0},
+			},
+		},
+	}
+	terms, err := parseScoreTerms([]string{"*cost:0.5", "*asr:0.3", "*resources:0.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sRoutes.SortScore(terms)
+	if rcv, exp := sRoutes.RouteIDs(), []string{"route2", "route1", "route3"}; !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	if exp := map[string]any{"*cost": 0.25, "*asr": 0.0, "*resources": 0.0}; !reflect.DeepEqual(exp,
+		sRoutes.Routes[0].SortingData[utils.ScoreTerms]) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, sRoutes.Routes[0].SortingData[utils.ScoreTerms])
+	}
+	if sRoutes.Routes[0].SortingData[utils.Score] != 0.25 {
+		t.Errorf("Expecting: 0.25, received: %+v", sRoutes.Routes[0].SortingData[utils.Score])
+	}
+	// the ASR is scored as 1-ASR, not relative to the other routes
+	if rcv := sRoutes.Routes[1].SortingData[utils.ScoreTerms].(map[string]any)[utils.MetaASR]; rcv != 0.18 {
+		t.Errorf("Expecting: 0.18, received: %+v", rcv)
+	}
+	if exp := map[string]any{"*cost": 0.5, "*asr": 0.3, "*resources": 0.2}; !reflect.DeepEqual(exp,
+		sRoutes.Routes[2].SortingData[utils.ScoreTerms]) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, sRoutes.Routes[2].SortingData[utils.ScoreTerms])
+	}
+
+	// equal scores fall back on weight
+	terms, _ = parseScoreTerms([]string{"*pdd:1"})
+	sRoutes.SortScore(terms)
+	if rcv := sRoutes.RouteIDs(); rcv[0] != "route3" {
+		t.Errorf("Expecting route3 first, received: %+v", rcv)
+	}
+}
+
+func TestRoutesParseScoreTerms(t *testing.T) {
+	exp := []*scoreTerm{
+		{name: utils.MetaCost, dataKey: utils.Cost, coefficient: 0.5, lowerBetter: true},
+		{name: utils.MetaResources, dataKey: utils.ResourceUsage, coefficient: 0.1, lowerBetter: true},
+		{name: utils.MetaWeight, dataKey: utils.Weight, coefficient: 0.1},
+		{name: utils.MetaPDD, dataKey: utils.MetaPDD, coefficient: 0.1, lowerBetter: true, isMetric: true},
+		{name: utils.MetaASR, dataKey: utils.MetaASR, coefficient: 0.3, isMetric: true, percentage: true},
+		{name: "*sum#~*req.Usage", dataKey: "*sum#~*req.Usage", coefficient: -0.2, isMetric: true},
+	}
+	if rcv, err := parseScoreTerms([]string{"*cost:0.5", "*resources:0.1", "*weight:0.1",
+		"*pdd:0.1", "*asr:0.3", "*sum#~*req.Usage:-0.2"}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if _, err := parseScoreTerms(nil); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.SortingParameters).Error() {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, param := range []string{"*cost", ":0.5", "*cost:half"} {
+		if _, err := parseScoreTerms([]string{param}); err == nil ||
+			err.Error() != "invalid *score sorting parameter: <"+param+">" {
+			t.Errorf("unexpected error for %q: %v", param, err)
+		}
+	}
+	rp := &RouteProfile{Sorting: utils.MetaScore, SortingParameters: []string{"*asr"}}
+	if err := rp.Compile(); err == nil {
+		t.Error("expected error for invalid sorting parameters")
+	}
+}
+
+func TestRouteServiceScoreSorter(t *testing.T) {
+	defer func() {
+		config.SetCgrConfig(config.NewDefaultCGRConfig())
+	}()
+	testMock := &ccMock{
+		calls: map[string]func(ctx *context.Context, args, reply any) error{
+			utils.StatSv1GetQueueFloatMetrics: func(ctx *context.Context, args, reply any) error {
+				asr := map[string]float64{"STATS_GW1": 80, "STATS_GW2": 40, "STATS_GW3": -1}
+				*reply.(*map[string]float64) = map[string]float64{
+					utils.MetaASR: asr[args.(*utils.TenantIDWithAPIOpts).ID],
+				}
+				return nil
+			},
+			utils.ResourceSv1GetResource: func(ctx *context.Context, args, reply any) error {
+				usage := map[string]float64{"RES_GW1": 8, "RES_GW2": 2, "RES_GW3": 0}
+				*reply.(*Resource) = Resource{Usages: map[string]*ResourceUsage{
+					"RU1": {Units: usage[args.(*utils.TenantIDWithAPIOpts).ID]},
+				}}
+				return nil
+			},
+		},
+	}
+	cfg := config.NewDefaultCGRConfig()
+	data, err := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	cfg.RouteSCfg().StatSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)}
+	cfg.RouteSCfg().ResourceSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources)}
+	clientconn := make(chan birpc.ClientConnector, 1)
+	clientconn <- testMock
+	connMgr := NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats):     clientconn,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources): clientconn,
+	})
+	rpS := NewRouteService(dm, &FilterS{dm: dm, cfg: cfg, connMgr: nil}, cfg, connMgr)
+	routes := make(map[string]*Route)
+	for _, gw := range []string{"GW1", "GW2", "GW3"} {
+		routes[gw] = &Route{ID: gw, Weight: 10,
+			StatIDs: []string{"STATS_" + gw}, ResourceIDs: []string{"RES_" + gw}}
+	}
+	sortedRoutes, err := rpS.sorter.SortRoutes("ROUTE_SCORE", utils.MetaScore, routes,
+		&utils.CGREvent{Tenant: "cgrates.org", Event: map[string]any{}},
+		&optsGetRoutes{sortingStrategy: utils.MetaScore,
+			sortingParameters: []string{"*asr:0.6", "*resources:0.4"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// GW1: 0.6*(1-0.8) + 0.4*1, GW2: 0.6*(1-0.4) + 0.4*0.25, GW3: 0.6*1 + 0.4*0
+	if rcv, exp := sortedRoutes.RouteIDs(), []string{"GW2", "GW1", "GW3"}; !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	if exp := map[string]any{utils.MetaASR: 0.36, utils.MetaResources: 0.1}; !reflect.DeepEqual(exp,
+		sortedRoutes.Routes[0].SortingData[utils.ScoreTerms]) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, sortedRoutes.Routes[0].SortingData[utils.ScoreTerms])
+	}
+	if _, has := sortedRoutes.Routes[0].SortingData["*asr:0.6"]; has {
+		t.Errorf("unexpected sorting data: %+v", sortedRoutes.Routes[0].SortingData)
+	}
+}
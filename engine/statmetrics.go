@@ -71,24 +71,28 @@ func (swc *StatWithCompress) Clone() *StatWithCompress {
 // cfg serves as general purpose container to pass config options to metric
 func NewStatMetric(metricID string, minItems int, filterIDs []string) (sm StatMetric, err error) {
 	metrics := map[string]func(int, string, []string) (StatMetric, error){
-		utils.MetaASR:      NewASR,
-		utils.MetaACD:      NewACD,
-		utils.MetaTCD:      NewTCD,
-		utils.MetaACC:      NewACC,
-		utils.MetaTCC:      NewTCC,
-		utils.MetaPDD:      NewPDD,
-		utils.MetaDDC:      NewDDC,
-		utils.MetaSum:      NewStatSum,
-		utils.MetaAverage:  NewStatAverage,
-		utils.MetaDistinct: NewStatDistinct,
-		utils.MetaHighest:  NewStatHighest,
-		utils.MetaLowest:   NewStatLowest,
-		utils.MetaREPSC:    NewStatREPSC,
-		utils.MetaREPFC:    NewStatREPFC,
+		utils.MetaASR:        NewASR,
+		utils.MetaACD:        NewACD,
+		utils.MetaTCD:        NewTCD,
+		utils.MetaACC:        NewACC,
+		utils.MetaTCC:        NewTCC,
+		utils.MetaPDD:        NewPDD,
+		utils.MetaDDC:        NewDDC,
+		utils.MetaSum:        NewStatSum,
+		utils.MetaAverage:    NewStatAverage,
+		utils.MetaDistinct:   NewStatDistinct,
+		utils.MetaHighest:    NewStatHighest,
+		utils.MetaLowest:     NewStatLowest,
+		utils.MetaREPSC:      NewStatREPSC,
+		utils.MetaREPFC:      NewStatREPFC,
+		utils.MetaPercentile: NewStatPercentile,
+		utils.MetaMedian:     NewStatMedian,
+		utils.MetaStdDev:     NewStatStdDev,
 	}
 	// split the metricID
 	// in case of *sum we have *sum#~*req.FieldName
-	metricSplit := strings.Split(metricID, utils.HashtagSep)
+	// in case of *percentile we have *percentile#95#~*req.FieldName
+	metricSplit := strings.SplitN(metricID, utils.HashtagSep, 2)
 	if _, has := metrics[metricSplit[0]]; !has {
 		return nil, fmt.Errorf("unsupported metric type <%s>", metricSplit[0])
 	}
@@ -2692,3 +2696,385 @@ func (s *StatREPFC) GetCompressFactor(events map[string]int) map[string]int {
 	}
 	return events
 }
+
+// NewStatPercentile creates a StatPercentile metric, with extraParams in the
+// format <percentile>#<fieldName> (e.g. 95#~*req.Usage).
+func NewStatPercentile(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	pctStr, fieldName, found := strings.Cut(extraParams, utils.HashtagSep)
+	if !found || fieldName == utils.EmptyString {
+		return nil, fmt.Errorf("invalid format for %s metric params: <%s>, expected <percentile#fieldName>",
+			utils.MetaPercentile, extraParams)
+	}
+	pct, err := strconv.ParseFloat(pctStr, 64)
+	if err != nil {
+		return nil, err
+	}
+	if pct < 0 || pct > 100 {
+		return nil, fmt.Errorf("percentile <%v> out of [0, 100] range", pct)
+	}
+	return &StatPercentile{
+		FilterIDs:  filterIDs,
+		MinItems:   minItems,
+		FieldName:  fieldName,
+		Percentile: pct,
+		Events:     make(map[string]float64),
+	}, nil
+}
+
+// NewStatMedian creates a StatPercentile metric for the 50th percentile.
+func NewStatMedian(minItems int, fieldName string, filterIDs []string) (StatMetric, error) {
+	return &StatPercentile{
+		FilterIDs:  filterIDs,
+		MinItems:   minItems,
+		FieldName:  fieldName,
+		Percentile: 50,
+		Events:     make(map[string]float64),
+	}, nil
+}
+
+// StatPercentile computes a percentile of a specific field across events.
+// Values of the events within the queue are kept to compute the exact value.
+// Values of the events which never expire are summarized in a TDigest so the
+// memory stays bounded for long queues.
+type StatPercentile struct {
+	FilterIDs  []string // event filters to apply before processing
+	FieldName  string   // field path to extract from events
+	MinItems   int      // minimum events required for valid results
+	Percentile float64  // percentile to compute, within [0, 100]
+
+	Count  int64              // number of events currently tracked
+	Events map[string]float64 // event values indexed by ID for deletion
+	Digest *TDigest           // values of events which are never removed
+
+	// cachedVal caches the result to avoid recalculation.
+	cachedVal *float64
+}
+
+// Clone creates a deep copy of StatPercentile.
+func (s *StatPercentile) Clone() StatMetric {
+	if s == nil {
+		return nil
+	}
+	clone := &StatPercentile{
+		FilterIDs:  slices.Clone(s.FilterIDs),
+		FieldName:  s.FieldName,
+		MinItems:   s.MinItems,
+		Percentile: s.Percentile,
+		Count:      s.Count,
+		Events:     maps.Clone(s.Events),
+		Digest:     s.Digest.Clone(),
+	}
+	if s.cachedVal != nil {
+		val := *s.cachedVal
+		clone.cachedVal = &val
+	}
+	return clone
+}
+
+func (s *StatPercentile) GetStringValue(decimals int) string {
+	v := s.getValue(decimals)
+	if v == utils.StatsNA {
+		return utils.NotAvailable
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func (s *StatPercentile) GetValue(decimals int) any {
+	return s.getValue(decimals)
+}
+
+func (s *StatPercentile) GetFloat64Value(decimals int) float64 {
+	return s.getValue(decimals)
+}
+
+// getValue returns the percentile value, calculating if cache is invalid.
+func (s *StatPercentile) getValue(decimals int) float64 {
+	if s.cachedVal != nil {
+		return *s.cachedVal
+	}
+	if s.Count == 0 || s.Count < int64(s.MinItems) {
+		s.cachedVal = utils.Float64Pointer(utils.StatsNA)
+		return *s.cachedVal
+	}
+	var v float64
+	if s.Digest == nil {
+		v = percentileOf(slices.Collect(maps.Values(s.Events)), s.Percentile)
+	} else {
+		td := s.Digest.Clone()
+		for _, val := range s.Events {
+			td.Add(val)
+		}
+		v = td.Quantile(s.Percentile / 100)
+	}
+	v = utils.Round(v, decimals, utils.MetaRoundingMiddle)
+	s.cachedVal = &v
+	return v
+}
+
+// percentileOf returns the percentile of the values, interpolating linearly
+// between the closest ranks.
+func percentileOf(vals []float64, pct float64) float64 {
+	slices.Sort(vals)
+	rank := pct / 100 * float64(len(vals)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return vals[lower] + (vals[upper]-vals[lower])*(rank-float64(lower))
+}
+
+// getFieldValue gets the numeric value from the DataProvider.
+func (s *StatPercentile) getFieldValue(ev utils.DataProvider) (float64, error) {
+	ival, err := utils.DPDynamicInterface(s.FieldName, ev)
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return 0, utils.ErrPrefix(err, s.FieldName)
+		}
+		return 0, err
+	}
+	return utils.IfaceAsFloat64(ival)
+}
+
+// AddEvent processes a new event, storing its value for removal.
+func (s *StatPercentile) AddEvent(evID string, ev utils.DataProvider) error {
+	val, err := s.getFieldValue(ev)
+	if err != nil {
+		return err
+	}
+	if _, exists := s.Events[evID]; !exists {
+		s.Count++
+	}
+	s.Events[evID] = val
+	s.cachedVal = nil
+	return nil
+}
+
+// AddOneEvent processes event without storing for removal (used when events
+// never expire).
+func (s *StatPercentile) AddOneEvent(ev utils.DataProvider) error {
+	val, err := s.getFieldValue(ev)
+	if err != nil {
+		return err
+	}
+	if s.Digest == nil {
+		s.Digest = NewTDigest(defaultTDigestCompression)
+	}
+	s.Digest.Add(val)
+	s.Count++
+	s.cachedVal = nil
+	return nil
+}
+
+func (s *StatPercentile) RemEvent(evID string) {
+	if _, exists := s.Events[evID]; !exists {
+		return
+	}
+	delete(s.Events, evID)
+	s.Count--
+	s.cachedVal = nil
+}
+
+func (s *StatPercentile) Marshal(ms Marshaler) ([]byte, error) {
+	return ms.Marshal(s)
+}
+
+func (s *StatPercentile) LoadMarshaled(ms Marshaler, marshaled []byte) error {
+	return ms.Unmarshal(marshaled, &s)
+}
+
+// GetFilterIDs is part of StatMetric interface.
+func (s *StatPercentile) GetFilterIDs() []string {
+	return s.FilterIDs
+}
+
+// GetMinItems returns the minimum items for the metric.
+func (s *StatPercentile) GetMinItems() int { return s.MinItems }
+
+// Compress is part of StatMetric interface.
+func (s *StatPercentile) Compress(queueLen int64, defaultID string, decimals int) []string {
+	eventIDs := make([]string, 0, len(s.Events))
+	for id := range s.Events {
+		eventIDs = append(eventIDs, id)
+	}
+	return eventIDs
+}
+
+func (s *StatPercentile) GetCompressFactor(events map[string]int) map[string]int {
+	for id := range s.Events {
+		if _, exists := events[id]; !exists {
+			events[id] = 1
+		}
+	}
+	return events
+}
+
+// NewStatStdDev creates a StatStdDev metric for the given field.
+func NewStatStdDev(minItems int, fieldName string, filterIDs []string) (StatMetric, error) {
+	return &StatStdDev{
+		FilterIDs: filterIDs,
+		MinItems:  minItems,
+		FieldName: fieldName,
+		Events:    make(map[string]float64),
+	}, nil
+}
+
+// StatStdDev computes the population standard deviation of a specific field
+// across events, out of the running sum and sum of squares.
+type StatStdDev struct {
+	FilterIDs []string // event filters to apply before processing
+	FieldName string   // field path to extract from events
+	MinItems  int      // minimum events required for valid results
+
+	Count      int64              // number of events currently tracked
+	Sum        float64            // sum of the values
+	SumSquares float64            // sum of the squared values
+	Events     map[string]float64 // event values indexed by ID for deletion
+
+	// cachedVal caches the result to avoid recalculation.
+	cachedVal *float64
+}
+
+// Clone creates a deep copy of StatStdDev.
+func (s *StatStdDev) Clone() StatMetric {
+	if s == nil {
+		return nil
+	}
+	clone := &StatStdDev{
+		FilterIDs:  slices.Clone(s.FilterIDs),
+		FieldName:  s.FieldName,
+		MinItems:   s.MinItems,
+		Count:      s.Count,
+		Sum:        s.Sum,
+		SumSquares: s.SumSquares,
+		Events:     maps.Clone(s.Events),
+	}
+	if s.cachedVal != nil {
+		val := *s.cachedVal
+		clone.cachedVal = &val
+	}
+	return clone
+}
+
+func (s *StatStdDev) GetStringValue(decimals int) string {
+	v := s.getValue(decimals)
+	if v == utils.StatsNA {
+		return utils.NotAvailable
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func (s *StatStdDev) GetValue(decimals int) any {
+	return s.getValue(decimals)
+}
+
+func (s *StatStdDev) GetFloat64Value(decimals int) float64 {
+	return s.getValue(decimals)
+}
+
+// getValue returns the standard deviation, calculating if cache is invalid.
+func (s *StatStdDev) getValue(decimals int) float64 {
+	if s.cachedVal != nil {
+		return *s.cachedVal
+	}
+	if s.Count == 0 || s.Count < int64(s.MinItems) {
+		s.cachedVal = utils.Float64Pointer(utils.StatsNA)
+		return *s.cachedVal
+	}
+	mean := s.Sum / float64(s.Count)
+	variance := s.SumSquares/float64(s.Count) - mean*mean
+	if variance < 0 { // floating point errors for constant values
+		variance = 0
+	}
+	v := utils.Round(math.Sqrt(variance), decimals, utils.MetaRoundingMiddle)
+	s.cachedVal = &v
+	return v
+}
+
+// getFieldValue gets the numeric value from the DataProvider.
+func (s *StatStdDev) getFieldValue(ev utils.DataProvider) (float64, error) {
+	ival, err := utils.DPDynamicInterface(s.FieldName, ev)
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return 0, utils.ErrPrefix(err, s.FieldName)
+		}
+		return 0, err
+	}
+	return utils.IfaceAsFloat64(ival)
+}
+
+// AddEvent processes a new event, replacing the value of an existing one.
+func (s *StatStdDev) AddEvent(evID string, ev utils.DataProvider) error {
+	val, err := s.getFieldValue(ev)
+	if err != nil {
+		return err
+	}
+	if oldVal, exists := s.Events[evID]; exists {
+		s.Sum -= oldVal
+		s.SumSquares -= oldVal * oldVal
+	} else {
+		s.Count++
+	}
+	s.Events[evID] = val
+	s.Sum += val
+	s.SumSquares += val * val
+	s.cachedVal = nil
+	return nil
+}
+
+// AddOneEvent processes event without storing for removal (used when events
+// never expire).
+func (s *StatStdDev) AddOneEvent(ev utils.DataProvider) error {
+	val, err := s.getFieldValue(ev)
+	if err != nil {
+		return err
+	}
+	s.Count++
+	s.Sum += val
+	s.SumSquares += val * val
+	s.cachedVal = nil
+	return nil
+}
+
+func (s *StatStdDev) RemEvent(evID string) {
+	val, exists := s.Events[evID]
+	if !exists {
+		return
+	}
+	delete(s.Events, evID)
+	s.Count--
+	s.Sum -= val
+	s.SumSquares -= val * val
+	s.cachedVal = nil
+}
+
+func (s *StatStdDev) Marshal(ms Marshaler) ([]byte, error) {
+	return ms.Marshal(s)
+}
+
+func (s *StatStdDev) LoadMarshaled(ms Marshaler, marshaled []byte) error {
+	return ms.Unmarshal(marshaled, &s)
+}
+
+// GetFilterIDs is part of StatMetric interface.
+func (s *StatStdDev) GetFilterIDs() []string {
+	return s.FilterIDs
+}
+
+// GetMinItems returns the minimum items for the metric.
+func (s *StatStdDev) GetMinItems() int { return s.MinItems }
+
+// Compress is part of StatMetric interface.
+func (s *StatStdDev) Compress(queueLen int64, defaultID string, decimals int) []string {
+	eventIDs := make([]string, 0, len(s.Events))
+	for id := range s.Events {
+		eventIDs = append(eventIDs, id)
+	}
+	return eventIDs
+}
+
+func (s *StatStdDev) GetCompressFactor(events map[string]int) map[string]int {
+	for id := range s.Events {
+		if _, exists := events[id]; !exists {
+			events[id] = 1
+		}
+	}
+	return events
+}
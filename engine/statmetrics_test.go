@@ -19,6 +19,7 @@ package engine
 
 import (
 	"encoding/json"
+	"fmt"
 	"math"
 	"net"
 	"reflect"
@@ -6687,3 +6688,161 @@ func TestStatLowestGetMinItems(t *testing.T) {
 		t.Errorf("expected MinItems 10, got %d", got)
 	}
 }
+
+func TestStatPercentileGetValue(t *testing.T) {
+	pct, err := NewStatMetric("*percentile#90#~*req.Usage", 2, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]any{"Usage": 10}}
+	if err := pct.AddEvent(ev.ID, utils.MapStorage{utils.MetaReq: ev.Event}); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := pct.GetStringValue(5); rcv != utils.NotAvailable {
+		t.Errorf("expected %s, received %s", utils.NotAvailable, rcv)
+	}
+	for i, usage := range []int{20, 30, 40, 50, 60, 70, 80, 90, 100} {
+		ev := &utils.CGREvent{Tenant: "cgrates.org", ID: fmt.Sprintf("EVENT_%d", i+2),
+			Event: map[string]any{"Usage": usage}}
+		if err := pct.AddEvent(ev.ID, utils.MapStorage{utils.MetaReq: ev.Event}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rcv := pct.GetFloat64Value(5); rcv != 91 {
+		t.Errorf("expected 91, received %v", rcv)
+	}
+	pct.RemEvent("EVENT_10") // removes the usage of 100
+	if rcv := pct.GetStringValue(5); rcv != "82" {
+		t.Errorf("expected 82, received %s", rcv)
+	}
+	if err := pct.AddEvent("EVENT_11", utils.MapStorage{utils.MetaReq: map[string]any{}}); err == nil ||
+		err.Error() != "NOT_FOUND:~*req.Usage" {
+		t.Errorf("expected NOT_FOUND:~*req.Usage, received %v", err)
+	}
+}
+
+func TestStatMedianGetValue(t *testing.T) {
+	median, err := NewStatMetric("*median#~*req.Cost", 0, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, cost := range []float64{7, 1, 3, 5} {
+		if err := median.AddEvent(fmt.Sprintf("EVENT_%d", i),
+			utils.MapStorage{utils.MetaReq: map[string]any{"Cost": cost}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rcv := median.GetValue(2); rcv != 4.0 {
+		t.Errorf("expected 4, received %v", rcv)
+	}
+	// overwriting an event replaces its value
+	if err := median.AddEvent("EVENT_0",
+		utils.MapStorage{utils.MetaReq: map[string]any{"Cost": 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := median.GetValue(2); rcv != 2.5 {
+		t.Errorf("expected 2.5, received %v", rcv)
+	}
+}
+
+func TestStatPercentileAddOneEvent(t *testing.T) {
+	pct, err := NewStatMetric("*percentile#99#~*req.Usage", 0, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 10000; i++ {
+		if err := pct.AddOneEvent(utils.MapStorage{utils.MetaReq: map[string]any{"Usage": i}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rcv := pct.GetFloat64Value(0); math.Abs(rcv-9900) > 50 {
+		t.Errorf("expected around 9900, received %v", rcv)
+	}
+	if n := len(pct.(*StatPercentile).Digest.Centroids); n > 10*defaultTDigestCompression {
+		t.Errorf("expected the digest to be compressed, received %d centroids", n)
+	}
+}
+
+func TestNewStatPercentileErrors(t *testing.T) {
+	for _, metricID := range []string{
+		"*percentile#~*req.Usage",
+		"*percentile#95",
+		"*percentile#101#~*req.Usage",
+		"*percentile#-1#~*req.Usage",
+	} {
+		if _, err := NewStatMetric(metricID, 0, nil); err == nil {
+			t.Errorf("expected error for metric %s", metricID)
+		}
+	}
+}
+
+func TestStatStdDevGetValue(t *testing.T) {
+	stdDev, err := NewStatMetric("*stddev#~*req.Usage", 3, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, usage := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		if rcv := stdDev.GetStringValue(2); i < 3 && rcv != utils.NotAvailable {
+			t.Errorf("expected %s, received %s", utils.NotAvailable, rcv)
+		}
+		if err := stdDev.AddEvent(fmt.Sprintf("EVENT_%d", i),
+			utils.MapStorage{utils.MetaReq: map[string]any{"Usage": usage}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rcv := stdDev.GetFloat64Value(2); rcv != 2 {
+		t.Errorf("expected 2, received %v", rcv)
+	}
+	stdDev.RemEvent("EVENT_0")
+	stdDev.RemEvent("EVENT_7")
+	if rcv := stdDev.GetStringValue(4); rcv != "1.0672" {
+		t.Errorf("expected 1.0672, received %s", rcv)
+	}
+	if err := stdDev.AddOneEvent(utils.MapStorage{utils.MetaReq: map[string]any{"Usage": 4.5}}); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := stdDev.GetStringValue(4); rcv != "0.9949" {
+		t.Errorf("expected 0.9949, received %s", rcv)
+	}
+}
+
+func TestStatPercentileStdDevMarshal(t *testing.T) {
+	sq := &StatQueue{
+		Tenant:    "cgrates.org",
+		ID:        "SQ_1",
+		SQMetrics: make(map[string]StatMetric),
+	}
+	for _, metricID := range []string{"*percentile#95#~*req.Usage", "*median#~*req.Usage", "*stddev#~*req.Usage"} {
+		metric, err := NewStatMetric(metricID, 0, []string{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sq.SQMetrics[metricID] = metric
+	}
+	for i, usage := range []int{10, 20, 30} {
+		evID := fmt.Sprintf("EVENT_%d", i)
+		sq.SQItems = append(sq.SQItems, SQItem{EventID: evID})
+		for _, metric := range sq.SQMetrics {
+			if err := metric.AddEvent(evID,
+				utils.MapStorage{utils.MetaReq: map[string]any{"Usage": usage}}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	ms := new(JSONMarshaler)
+	sSQ, err := NewStoredStatQueue(sq, ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rcv, err := sSQ.AsStatQueue(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for metricID, metric := range sq.SQMetrics {
+		if exp, rcvVal := metric.GetStringValue(4),
+			rcv.SQMetrics[metricID].GetStringValue(4); exp != rcvVal {
+			t.Errorf("expected %s for %s, received %s", exp, metricID, rcvVal)
+		}
+	}
+}
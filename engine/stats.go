@@ -118,7 +118,13 @@ func (sS *StatService) storeStats() {
 			continue
 		}
 		s := sqIf.(*StatQueue)
-		s.lock(utils.EmptyString)
+		if err := s.lock(utils.EmptyString); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed locking stat queue with ID: %s, error: %s",
+					utils.StatService, sID, err.Error()))
+			failedSqIDs = append(failedSqIDs, sID)
+			continue
+		}
 		if err := sS.StoreStatQueue(s); err != nil {
 			failedSqIDs = append(failedSqIDs, sID) // record failure so we can schedule it for next backup
 		}
@@ -182,9 +188,13 @@ func (sS *StatService) matchingStatQueuesForEvent(tnt string, statsIDs []string,
 
 	sqs = make(StatQueues, 0, len(itemIDs))
 	for _, id := range itemIDs {
-		lkPrflID := guardian.Guardian.GuardIDs("",
+		var lkPrflID string
+		if lkPrflID, err = guardian.Guardian.GuardIDs("",
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			statQueueProfileLockKey(tnt, id))
+			statQueueProfileLockKey(tnt, id)); err != nil {
+			sqs.unlock()
+			return nil, err
+		}
 		var sqPrfl *StatQueueProfile
 		if sqPrfl, err = sS.dm.GetStatQueueProfile(tnt, id, true, true, utils.NonTransactional); err != nil {
 			guardian.Guardian.UnguardIDs(lkPrflID)
@@ -213,9 +223,14 @@ func (sS *StatService) matchingStatQueuesForEvent(tnt string, statsIDs []string,
 				continue
 			}
 		}
-		lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+		var lkID string
+		if lkID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			statQueueLockKey(sqPrfl.Tenant, sqPrfl.ID))
+			statQueueLockKey(sqPrfl.Tenant, sqPrfl.ID)); err != nil {
+			sqPrfl.unlock()
+			sqs.unlock()
+			return nil, err
+		}
 		var sq *StatQueue
 		if sq, err = sS.dm.GetStatQueue(sqPrfl.Tenant, sqPrfl.ID, true, true, ""); err != nil {
 			guardian.Guardian.UnguardIDs(lkID)
@@ -473,9 +488,12 @@ func (sS *StatService) V1GetStatQueue(ctx *context.Context, args *utils.TenantID
 		tnt = sS.cgrcfg.GeneralCfg().DefaultTenant
 	}
 	// make sure statQueue is locked at process level
-	lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+	var lkID string
+	if lkID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
 		config.CgrConfig().GeneralCfg().LockingTimeout,
-		statQueueLockKey(tnt, args.ID))
+		statQueueLockKey(tnt, args.ID)); err != nil {
+		return
+	}
 	defer guardian.Guardian.UnguardIDs(lkID)
 	sq, err := sS.getStatQueue(tnt, args.ID)
 	if err != nil {
@@ -495,9 +513,12 @@ func (sS *StatService) V1GetQueueStringMetrics(ctx *context.Context, args *utils
 		tnt = sS.cgrcfg.GeneralCfg().DefaultTenant
 	}
 	// make sure statQueue is locked at process level
-	lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+	var lkID string
+	if lkID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
 		config.CgrConfig().GeneralCfg().LockingTimeout,
-		statQueueLockKey(tnt, args.ID))
+		statQueueLockKey(tnt, args.ID)); err != nil {
+		return
+	}
 	defer guardian.Guardian.UnguardIDs(lkID)
 	sq, err := sS.getStatQueue(tnt, args.ID)
 	if err != nil {
@@ -524,9 +545,12 @@ func (sS *StatService) V1GetQueueFloatMetrics(ctx *context.Context, args *utils.
 		tnt = sS.cgrcfg.GeneralCfg().DefaultTenant
 	}
 	// make sure statQueue is locked at process level
-	lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+	var lkID string
+	if lkID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
 		config.CgrConfig().GeneralCfg().LockingTimeout,
-		statQueueLockKey(tnt, args.ID))
+		statQueueLockKey(tnt, args.ID)); err != nil {
+		return
+	}
 	defer guardian.Guardian.UnguardIDs(lkID)
 	sq, err := sS.getStatQueue(tnt, args.ID)
 	if err != nil {
@@ -571,9 +595,12 @@ func (sS *StatService) V1ResetStatQueue(ctx *context.Context, tntID *utils.Tenan
 		tnt = sS.cgrcfg.GeneralCfg().DefaultTenant
 	}
 	// make sure statQueue is locked at process level
-	lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+	var lkID string
+	if lkID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
 		config.CgrConfig().GeneralCfg().LockingTimeout,
-		statQueueLockKey(tnt, tntID.ID))
+		statQueueLockKey(tnt, tntID.ID)); err != nil {
+		return
+	}
 	defer guardian.Guardian.UnguardIDs(lkID)
 	var sq *StatQueue
 	if sq, err = sS.dm.GetStatQueue(tnt, tntID.ID,
@@ -68,6 +68,7 @@ type CSVStorage struct {
 	chargerProfilesFn        []string
 	dispatcherProfilesFn     []string
 	dispatcherHostsFn        []string
+	taxProfilesFn            []string
 }
 
 // NewCSVStorage creates a CSV storage that takes the data from the paths specified
@@ -76,7 +77,8 @@ func NewCSVStorage(sep rune,
 	destinationratetimingsFn, ratingprofilesFn, sharedgroupsFn,
 	actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn,
 	resProfilesFn, ipProfilesFn, statsFn, trendsFn, rankingsFn, thresholdsFn, filterFn, routeProfilesFn,
-	attributeProfilesFn, chargerProfilesFn, dispatcherProfilesFn, dispatcherHostsFn []string) *CSVStorage {
+	attributeProfilesFn, chargerProfilesFn, dispatcherProfilesFn, dispatcherHostsFn,
+	taxProfilesFn []string) *CSVStorage {
 	return &CSVStorage{
 		sep:                      sep,
 		generator:                NewCsvFile,
@@ -103,6 +105,7 @@ func NewCSVStorage(sep rune,
 		chargerProfilesFn:        chargerProfilesFn,
 		dispatcherProfilesFn:     dispatcherProfilesFn,
 		dispatcherHostsFn:        dispatcherHostsFn,
+		taxProfilesFn:            taxProfilesFn,
 	}
 }
 
@@ -135,6 +138,7 @@ func NewFileCSVStorage(sep rune, dataPath string) (*CSVStorage, error) {
 	chargersPaths := appendName(allFoldersPath, utils.ChargersCsv)
 	dispatcherprofilesPaths := appendName(allFoldersPath, utils.DispatcherProfilesCsv)
 	dispatcherhostsPaths := appendName(allFoldersPath, utils.DispatcherHostsCsv)
+	taxProfilesPaths := appendName(allFoldersPath, utils.TaxProfilesCsv)
 	return NewCSVStorage(sep,
 		destinationsPaths,
 		timingsPaths,
@@ -159,6 +163,7 @@ func NewFileCSVStorage(sep rune, dataPath string) (*CSVStorage, error) {
 		chargersPaths,
 		dispatcherprofilesPaths,
 		dispatcherhostsPaths,
+		taxProfilesPaths,
 	), nil
 }
 
@@ -168,14 +173,15 @@ func NewStringCSVStorage(sep rune,
 	destinationratetimingsFn, ratingprofilesFn, sharedgroupsFn,
 	actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn,
 	resProfilesFn, ipProfilesFn, statsFn, trendsFn, rankingsFn, thresholdsFn, filterFn, routeProfilesFn,
-	attributeProfilesFn, chargerProfilesFn, dispatcherProfilesFn, dispatcherHostsFn string) *CSVStorage {
+	attributeProfilesFn, chargerProfilesFn, dispatcherProfilesFn, dispatcherHostsFn,
+	taxProfilesFn string) *CSVStorage {
 	c := NewCSVStorage(sep, []string{destinationsFn}, []string{timingsFn},
 		[]string{ratesFn}, []string{destinationratesFn}, []string{destinationratetimingsFn},
 		[]string{ratingprofilesFn}, []string{sharedgroupsFn}, []string{actionsFn},
 		[]string{actiontimingsFn}, []string{actiontriggersFn}, []string{accountactionsFn},
 		[]string{resProfilesFn}, []string{ipProfilesFn}, []string{statsFn}, []string{trendsFn}, []string{rankingsFn}, []string{thresholdsFn}, []string{filterFn},
 		[]string{routeProfilesFn}, []string{attributeProfilesFn}, []string{chargerProfilesFn},
-		[]string{dispatcherProfilesFn}, []string{dispatcherHostsFn})
+		[]string{dispatcherProfilesFn}, []string{dispatcherHostsFn}, []string{taxProfilesFn})
 	c.generator = NewCsvString
 	return c
 }
@@ -220,6 +226,7 @@ func NewGoogleCSVStorage(sep rune, spreadsheetID string) (*CSVStorage, error) {
 		getIfExist(utils.Chargers),
 		getIfExist(utils.DispatcherProfiles),
 		getIfExist(utils.DispatcherHosts),
+		getIfExist(utils.TaxProfiles),
 	)
 	c.generator = func() csvReaderCloser {
 		return &csvGoogle{
@@ -255,6 +262,7 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 	var chargersPaths []string
 	var dispatcherprofilesPaths []string
 	var dispatcherhostsPaths []string
+	var taxProfilesPaths []string
 
 	for _, baseURL := range strings.Split(dataPath, utils.InfieldSep) {
 		if !strings.HasSuffix(baseURL, utils.CSVSuffix) {
@@ -281,6 +289,7 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 			chargersPaths = append(chargersPaths, joinURL(baseURL, utils.ChargersCsv))
 			dispatcherprofilesPaths = append(dispatcherprofilesPaths, joinURL(baseURL, utils.DispatcherProfilesCsv))
 			dispatcherhostsPaths = append(dispatcherhostsPaths, joinURL(baseURL, utils.DispatcherHostsCsv))
+			taxProfilesPaths = append(taxProfilesPaths, joinURL(baseURL, utils.TaxProfilesCsv))
 			continue
 		}
 		switch {
@@ -330,6 +339,8 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 			dispatcherprofilesPaths = append(dispatcherprofilesPaths, baseURL)
 		case strings.HasSuffix(baseURL, utils.DispatcherHostsCsv):
 			dispatcherhostsPaths = append(dispatcherhostsPaths, baseURL)
+		case strings.HasSuffix(baseURL, utils.TaxProfilesCsv):
+			taxProfilesPaths = append(taxProfilesPaths, baseURL)
 		}
 	}
 
@@ -357,6 +368,7 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 		chargersPaths,
 		dispatcherprofilesPaths,
 		dispatcherhostsPaths,
+		taxProfilesPaths,
 	)
 	c.generator = func() csvReaderCloser {
 		return &csvURL{}
@@ -396,6 +408,9 @@ func appendName(paths []string, fileName string) (out []string) {
 
 func (csvs *CSVStorage) proccesData(listType any, fns []string, process func(any)) error {
 	collumnCount := getColumnCount(listType)
+	if hasOptionalColumns(listType) {
+		collumnCount = -1 // checked by csvLoad
+	}
 	for _, fileName := range fns {
 		csvReader := csvs.generator()
 		err := csvReader.Open(fileName, csvs.sep, collumnCount)
@@ -623,6 +638,18 @@ func (csvs *CSVStorage) GetTPRankings(tpid, tenant, id string) ([]*utils.TPRanki
 	return tpRankings.AsTPRanking(), nil
 }
 
+func (csvs *CSVStorage) GetTPTaxProfiles(tpid, tenant, id string) ([]*utils.TPTaxProfile, error) {
+	var tpTaxProfiles TaxProfileMdls
+	if err := csvs.proccesData(TaxProfileMdl{}, csvs.taxProfilesFn, func(tp any) {
+		tpTaxProfile := tp.(TaxProfileMdl)
+		tpTaxProfile.Tpid = tpid
+		tpTaxProfiles = append(tpTaxProfiles, &tpTaxProfile)
+	}); err != nil {
+		return nil, err
+	}
+	return tpTaxProfiles.AsTPTaxProfile(), nil
+}
+
 func (csvs *CSVStorage) GetTPThresholds(tpid, tenant, id string) ([]*utils.TPThresholdProfile, error) {
 	var tpThreshold ThresholdMdls
 	if err := csvs.proccesData(ThresholdMdl{}, csvs.thresholdsFn, func(tp any) {
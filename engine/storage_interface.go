@@ -110,6 +110,14 @@ type DataDB interface {
 	SetRankingProfileDrv(sq *RankingProfile) (err error)
 	GetRankingProfileDrv(tenant string, id string) (sq *RankingProfile, err error)
 	RemRankingProfileDrv(tenant string, id string) (err error)
+	GetDataSetDrv(tenant, id string) (ds *DataSet, err error)
+	SetDataSetDrv(ds *DataSet) (err error)
+	AddDataSetEntriesDrv(tenant, id string, ents []*DataSetEntry) (err error)
+	RemoveDataSetEntriesDrv(tenant, id string, ents []*DataSetEntry) (err error)
+	RemoveDataSetDrv(tenant, id string) (err error)
+	GetTaxProfileDrv(tenant, id string) (tp *TaxProfile, err error)
+	SetTaxProfileDrv(tp *TaxProfile) (err error)
+	RemoveTaxProfileDrv(tenant, id string) (err error)
 	GetRankingDrv(string, string) (*Ranking, error)
 	SetRankingDrv(*Ranking) error
 	RemoveRankingDrv(string, string) error
@@ -149,6 +157,12 @@ type DataDB interface {
 	SetBackupSessionsDrv(nodeID string, tenant string, sessions []*StoredSession) error
 	GetSessionsBackupDrv(nodeID string, tenant string) ([]*StoredSession, error)
 	RemoveSessionsBackupDrv(nodeID, tenant, cgrid string) error
+	GetInvoiceDrv(tenant, id string) (*Invoice, error)
+	GetInvoicesDrv(tenant, account string) ([]*Invoice, error)
+	CountInvoicesDrv(tenant string) (int, error)
+	SetInvoiceDrv(inv *Invoice) error
+	GetAuditRecordsDrv(objType, tenant, id string) ([]*AuditRecord, error)
+	SetAuditRecordDrv(rec *AuditRecord) error
 	DumpDataDB() error
 	RewriteDataDB() error
 	BackupDataDB(string, bool) error
@@ -197,6 +211,7 @@ type LoadReader interface {
 	GetTPStats(string, string, string) ([]*utils.TPStatProfile, error)
 	GetTPTrends(string, string, string) ([]*utils.TPTrendsProfile, error)
 	GetTPRankings(string, string, string) ([]*utils.TPRankingProfile, error)
+	GetTPTaxProfiles(string, string, string) ([]*utils.TPTaxProfile, error)
 	GetTPThresholds(string, string, string) ([]*utils.TPThresholdProfile, error)
 	GetTPFilters(string, string, string) ([]*utils.TPFilterProfile, error)
 	GetTPRoutes(string, string, string) ([]*utils.TPRouteProfile, error)
@@ -224,6 +239,7 @@ type LoadWriter interface {
 	SetTPStats([]*utils.TPStatProfile) error
 	SetTPTrends([]*utils.TPTrendsProfile) error
 	SetTPRankings([]*utils.TPRankingProfile) error
+	SetTPTaxProfiles([]*utils.TPTaxProfile) error
 	SetTPThresholds([]*utils.TPThresholdProfile) error
 	SetTPFilters([]*utils.TPFilterProfile) error
 	SetTPRoutes([]*utils.TPRouteProfile) error
@@ -21,6 +21,7 @@ package engine
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -40,8 +41,18 @@ type InternalDB struct {
 	indexedFieldsMutex  sync.RWMutex   // used for reload
 	cnter               *utils.Counter // used for OrderID for cdr
 	ms                  Marshaler
-	db                  *ltcache.TransCache
+	db                  *walTransCache
 	isDataDB            bool
+
+	leases    map[string]*internalLease // distributed locks held by the engines replicating to us
+	lastToken int64                     // token of the last lease acquired
+	leasesMux sync.Mutex
+}
+
+// internalLease is a lock held in the InternalDB on behalf of a remote engine
+type internalLease struct {
+	token     int64
+	expiresAt time.Time
 }
 
 // NewInternalDB constructs an InternalDB
@@ -71,7 +82,7 @@ func NewInternalDB(stringIndexedFields, prefixIndexedFields []string, isDataDB b
 		prefixIndexedFields: prefixIndexedFields,
 		cnter:               utils.NewCounter(time.Now().UnixNano(), 0),
 		ms:                  ms,
-		db:                  tc,
+		db:                  &walTransCache{TransCache: tc},
 		isDataDB:            isDataDB,
 	}, nil
 }
@@ -97,8 +108,7 @@ func (iDB *InternalDB) Close() {
 
 // Flush clears the cache
 func (iDB *InternalDB) Flush(string) error {
-	iDB.db.Clear(nil)
-	return nil
+	return iDB.db.Clear(nil)
 }
 
 // SelectDatabase only to implement Storage interface
@@ -117,6 +127,13 @@ func (iDB *InternalDB) GetKeysForPrefix(prefix, search string) (ids []string, er
 	category := prefix[:keyLen] // prefix length
 	queryPrefix := prefix[keyLen:]
 	ids = iDB.db.GetItemIDs(utils.CachePrefixToInstance[category], queryPrefix)
+	if category == utils.DataSetPrefix { // the DataSets are stored per entry
+		tntIDs := make(utils.StringSet)
+		for _, id := range ids {
+			tntIDs.Add(dataSetTenantID(id))
+		}
+		ids = tntIDs.AsSlice()
+	}
 	for i := range ids {
 		ids[i] = category + ids[i]
 	}
@@ -139,8 +156,10 @@ func (iDB *InternalDB) RemoveKeysForPrefix(prefix string) (err error) {
 	}
 	cacheID := utils.CachePrefixToInstance[prefix[:keyLen]]
 	for _, key := range iDB.db.GetItemIDs(cacheID, prefix[keyLen:]) {
-		iDB.db.Remove(cacheID, key,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Remove(cacheID, key,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -168,17 +187,18 @@ func (iDB *InternalDB) SetVersions(vrs Versions, overwrite bool) (err error) {
 	}
 	x, ok := iDB.db.Get(utils.CacheVersions, utils.VersionName)
 	if !ok || x == nil {
-		iDB.db.Set(utils.CacheVersions, utils.VersionName, vrs, nil,
-			true, utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheVersions, utils.VersionName, vrs, nil,
+			true, utils.NonTransactional); err != nil {
+			return
+		}
 		return
 	}
 	provVrs := x.(Versions)
 	for key, val := range vrs {
 		provVrs[key] = val
 	}
-	iDB.db.Set(utils.CacheVersions, utils.VersionName, provVrs, nil,
+	return iDB.db.Set(utils.CacheVersions, utils.VersionName, provVrs, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveVersions(vrs Versions) (err error) {
@@ -192,13 +212,14 @@ func (iDB *InternalDB) RemoveVersions(vrs Versions) (err error) {
 		for key := range vrs {
 			delete(internalVersions, key)
 		}
-		iDB.db.Set(utils.CacheVersions, utils.VersionName, internalVersions, nil,
-			true, utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheVersions, utils.VersionName, internalVersions, nil,
+			true, utils.NonTransactional); err != nil {
+			return
+		}
 		return
 	}
-	iDB.db.Remove(utils.CacheVersions, utils.VersionName,
+	return iDB.db.Remove(utils.CacheVersions, utils.VersionName,
 		true, utils.NonTransactional)
-	return
 }
 
 // GetStorageType returns the storage type
@@ -230,8 +251,10 @@ func (iDB *InternalDB) HasDataDrv(category, subject, tenant string) (bool, error
 		utils.IPProfilesPrefix, utils.StatQueuePrefix, utils.StatQueueProfilePrefix,
 		utils.ThresholdPrefix, utils.ThresholdProfilePrefix, utils.FilterPrefix,
 		utils.RouteProfilePrefix, utils.AttributeProfilePrefix, utils.ChargerProfilePrefix,
-		utils.DispatcherProfilePrefix, utils.DispatcherHostPrefix:
+		utils.DispatcherProfilePrefix, utils.DispatcherHostPrefix, utils.TaxProfilePrefix:
 		return iDB.db.HasItem(utils.CachePrefixToInstance[category], utils.ConcatenatedKey(tenant, subject)), nil
+	case utils.DataSetPrefix: // stored per entry
+		return iDB.db.HasGroup(utils.CacheDataSets, utils.ConcatenatedKey(tenant, subject)), nil
 	}
 	return false, errors.New("Unsupported HasData category")
 }
@@ -245,15 +268,13 @@ func (iDB *InternalDB) GetRatingPlanDrv(id string) (rp *RatingPlan, err error) {
 }
 
 func (iDB *InternalDB) SetRatingPlanDrv(rp *RatingPlan) (err error) {
-	iDB.db.Set(utils.CacheRatingPlans, rp.Id, rp, nil,
+	return iDB.db.Set(utils.CacheRatingPlans, rp.Id, rp, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveRatingPlanDrv(id string) (err error) {
-	iDB.db.Remove(utils.CacheRatingPlans, id,
+	return iDB.db.Remove(utils.CacheRatingPlans, id,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetRatingProfileDrv(id string) (rp *RatingProfile, err error) {
@@ -265,15 +286,13 @@ func (iDB *InternalDB) GetRatingProfileDrv(id string) (rp *RatingProfile, err er
 }
 
 func (iDB *InternalDB) SetRatingProfileDrv(rp *RatingProfile) (err error) {
-	iDB.db.Set(utils.CacheRatingProfiles, rp.Id, rp, nil,
+	return iDB.db.Set(utils.CacheRatingProfiles, rp.Id, rp, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveRatingProfileDrv(id string) (err error) {
-	iDB.db.Remove(utils.CacheRatingProfiles, id,
+	return iDB.db.Remove(utils.CacheRatingProfiles, id,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetDestinationDrv(key, _ string) (dest *Destination, err error) {
@@ -284,15 +303,13 @@ func (iDB *InternalDB) GetDestinationDrv(key, _ string) (dest *Destination, err
 }
 
 func (iDB *InternalDB) SetDestinationDrv(dest *Destination, transactionID string) (err error) {
-	iDB.db.Set(utils.CacheDestinations, dest.Id, dest, nil,
+	return iDB.db.Set(utils.CacheDestinations, dest.Id, dest, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveDestinationDrv(destID string, transactionID string) (err error) {
-	iDB.db.Remove(utils.CacheDestinations, destID,
+	return iDB.db.Remove(utils.CacheDestinations, destID,
 		cacheCommit(transactionID), transactionID)
-	return
 }
 
 func (iDB *InternalDB) RemoveReverseDestinationDrv(dstID, prfx, transactionID string) (err error) {
@@ -307,11 +324,15 @@ func (iDB *InternalDB) RemoveReverseDestinationDrv(dstID, prfx, transactionID st
 	mpRevDst := utils.NewStringSet(revDst)
 	mpRevDst.Remove(dstID)
 	if mpRevDst.Size() != 0 {
-		iDB.db.Set(utils.CacheReverseDestinations, prfx, mpRevDst.AsSlice(), nil,
-			cacheCommit(transactionID), transactionID)
+		if err = iDB.db.Set(utils.CacheReverseDestinations, prfx, mpRevDst.AsSlice(), nil,
+			cacheCommit(transactionID), transactionID); err != nil {
+			return
+		}
 	} else {
-		iDB.db.Remove(utils.CacheReverseDestinations, prfx,
-			cacheCommit(transactionID), transactionID)
+		if err = iDB.db.Remove(utils.CacheReverseDestinations, prfx,
+			cacheCommit(transactionID), transactionID); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -327,8 +348,10 @@ func (iDB *InternalDB) SetReverseDestinationDrv(destID string, prefixes []string
 		mpRevDst := utils.NewStringSet(revDst)
 		mpRevDst.Add(destID)
 		// for ReverseDestination we will use Groups
-		iDB.db.Set(utils.CacheReverseDestinations, p, mpRevDst.AsSlice(), nil,
-			true, utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheReverseDestinations, p, mpRevDst.AsSlice(), nil,
+			true, utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -350,15 +373,13 @@ func (iDB *InternalDB) GetActionsDrv(id string) (acts Actions, err error) {
 }
 
 func (iDB *InternalDB) SetActionsDrv(id string, acts Actions) (err error) {
-	iDB.db.Set(utils.CacheActions, id, acts, nil,
+	return iDB.db.Set(utils.CacheActions, id, acts, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveActionsDrv(id string) (err error) {
-	iDB.db.Remove(utils.CacheActions, id,
+	return iDB.db.Remove(utils.CacheActions, id,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetSharedGroupDrv(id string) (sh *SharedGroup, err error) {
@@ -369,15 +390,13 @@ func (iDB *InternalDB) GetSharedGroupDrv(id string) (sh *SharedGroup, err error)
 }
 
 func (iDB *InternalDB) SetSharedGroupDrv(sh *SharedGroup) (err error) {
-	iDB.db.Set(utils.CacheSharedGroups, sh.Id, sh, nil,
+	return iDB.db.Set(utils.CacheSharedGroups, sh.Id, sh, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveSharedGroupDrv(id string) (err error) {
-	iDB.db.Remove(utils.CacheSharedGroups, id,
+	return iDB.db.Remove(utils.CacheSharedGroups, id,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetActionTriggersDrv(id string) (at ActionTriggers, err error) {
@@ -388,15 +407,13 @@ func (iDB *InternalDB) GetActionTriggersDrv(id string) (at ActionTriggers, err e
 }
 
 func (iDB *InternalDB) SetActionTriggersDrv(id string, at ActionTriggers) (err error) {
-	iDB.db.Set(utils.CacheActionTriggers, id, at, nil,
+	return iDB.db.Set(utils.CacheActionTriggers, id, at, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveActionTriggersDrv(id string) (err error) {
-	iDB.db.Remove(utils.CacheActionTriggers, id,
+	return iDB.db.Remove(utils.CacheActionTriggers, id,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetActionPlanDrv(key string) (ats *ActionPlan, err error) {
@@ -407,14 +424,12 @@ func (iDB *InternalDB) GetActionPlanDrv(key string) (ats *ActionPlan, err error)
 }
 
 func (iDB *InternalDB) SetActionPlanDrv(key string, ats *ActionPlan) (err error) {
-	iDB.db.Set(utils.CacheActionPlans, key, ats, nil,
+	return iDB.db.Set(utils.CacheActionPlans, key, ats, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveActionPlanDrv(key string) (err error) {
-	iDB.db.Remove(utils.CacheActionPlans, key, true, utils.NonTransactional)
-	return
+	return iDB.db.Remove(utils.CacheActionPlans, key, true, utils.NonTransactional)
 }
 
 func (iDB *InternalDB) GetAllActionPlansDrv() (ats map[string]*ActionPlan, err error) {
@@ -443,15 +458,13 @@ func (iDB *InternalDB) GetAccountActionPlansDrv(acntID string) (apIDs []string,
 }
 
 func (iDB *InternalDB) SetAccountActionPlansDrv(acntID string, apIDs []string) (err error) {
-	iDB.db.Set(utils.CacheAccountActionPlans, acntID, apIDs, nil,
+	return iDB.db.Set(utils.CacheAccountActionPlans, acntID, apIDs, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemAccountActionPlansDrv(acntID string) (err error) {
-	iDB.db.Remove(utils.CacheAccountActionPlans, acntID,
+	return iDB.db.Remove(utils.CacheAccountActionPlans, acntID,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) PushTask(t *Task) (err error) {
@@ -489,21 +502,20 @@ func (iDB *InternalDB) SetAccountDrv(acc *Account) (err error) {
 		if ac, err := iDB.GetAccountDrv(acc.ID); err == nil && !ac.allBalancesExpired() {
 			ac.ActionTriggers = acc.ActionTriggers
 			ac.UnitCounters = acc.UnitCounters
+			ac.UsageCounters = acc.UsageCounters
 			ac.AllowNegative = acc.AllowNegative
 			ac.Disabled = acc.Disabled
 			acc = ac
 		}
 	}
 	acc.UpdateTime = time.Now()
-	iDB.db.Set(utils.CacheAccounts, acc.ID, acc, nil,
+	return iDB.db.Set(utils.CacheAccounts, acc.ID, acc, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveAccountDrv(id string) (err error) {
-	iDB.db.Remove(utils.CacheAccounts, id,
+	return iDB.db.Remove(utils.CacheAccounts, id,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetResourceProfileDrv(tenant, id string) (rp *ResourceProfile, err error) {
@@ -514,15 +526,13 @@ func (iDB *InternalDB) GetResourceProfileDrv(tenant, id string) (rp *ResourcePro
 }
 
 func (iDB *InternalDB) SetResourceProfileDrv(rp *ResourceProfile) (err error) {
-	iDB.db.Set(utils.CacheResourceProfiles, rp.TenantID(), rp, nil,
+	return iDB.db.Set(utils.CacheResourceProfiles, rp.TenantID(), rp, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveResourceProfileDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheResourceProfiles, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheResourceProfiles, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetResourceDrv(tenant, id string) (r *Resource, err error) {
@@ -533,15 +543,13 @@ func (iDB *InternalDB) GetResourceDrv(tenant, id string) (r *Resource, err error
 }
 
 func (iDB *InternalDB) SetResourceDrv(r *Resource) (err error) {
-	iDB.db.Set(utils.CacheResources, r.TenantID(), r, nil,
+	return iDB.db.Set(utils.CacheResources, r.TenantID(), r, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveResourceDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheResources, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheResources, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetIPProfileDrv(tenant, id string) (*IPProfile, error) {
@@ -552,15 +560,13 @@ func (iDB *InternalDB) GetIPProfileDrv(tenant, id string) (*IPProfile, error) {
 }
 
 func (iDB *InternalDB) SetIPProfileDrv(ipp *IPProfile) error {
-	iDB.db.Set(utils.CacheIPProfiles, ipp.TenantID(), ipp, nil,
+	return iDB.db.Set(utils.CacheIPProfiles, ipp.TenantID(), ipp, nil,
 		true, utils.NonTransactional)
-	return nil
 }
 
 func (iDB *InternalDB) RemoveIPProfileDrv(tenant, id string) error {
-	iDB.db.Remove(utils.CacheIPProfiles, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheIPProfiles, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return nil
 }
 
 func (iDB *InternalDB) GetIPAllocationsDrv(tenant, id string) (*IPAllocations, error) {
@@ -571,15 +577,13 @@ func (iDB *InternalDB) GetIPAllocationsDrv(tenant, id string) (*IPAllocations, e
 }
 
 func (iDB *InternalDB) SetIPAllocationsDrv(ip *IPAllocations) error {
-	iDB.db.Set(utils.CacheIPAllocations, ip.TenantID(), ip, nil,
+	return iDB.db.Set(utils.CacheIPAllocations, ip.TenantID(), ip, nil,
 		true, utils.NonTransactional)
-	return nil
 }
 
 func (iDB *InternalDB) RemoveIPAllocationsDrv(tenant, id string) error {
-	iDB.db.Remove(utils.CacheIPAllocations, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheIPAllocations, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return nil
 }
 
 func (iDB *InternalDB) GetTimingDrv(id string) (tmg *utils.TPTiming, err error) {
@@ -591,15 +595,13 @@ func (iDB *InternalDB) GetTimingDrv(id string) (tmg *utils.TPTiming, err error)
 }
 
 func (iDB *InternalDB) SetTimingDrv(timing *utils.TPTiming) (err error) {
-	iDB.db.Set(utils.CacheTimings, timing.ID, timing, nil,
+	return iDB.db.Set(utils.CacheTimings, timing.ID, timing, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveTimingDrv(id string) (err error) {
-	iDB.db.Remove(utils.CacheTimings, id,
+	return iDB.db.Remove(utils.CacheTimings, id,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetLoadHistory(int, bool, string) ([]*utils.LoadInstance, error) {
@@ -619,15 +621,13 @@ func (iDB *InternalDB) GetStatQueueProfileDrv(tenant string, id string) (sq *Sta
 
 }
 func (iDB *InternalDB) SetStatQueueProfileDrv(sq *StatQueueProfile) (err error) {
-	iDB.db.Set(utils.CacheStatQueueProfiles, sq.TenantID(), sq, nil,
+	return iDB.db.Set(utils.CacheStatQueueProfiles, sq.TenantID(), sq, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemStatQueueProfileDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheStatQueueProfiles, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheStatQueueProfiles, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetStatQueueDrv(tenant, id string) (sq *StatQueue, err error) {
@@ -644,23 +644,19 @@ func (iDB *InternalDB) SetStatQueueDrv(ssq *StoredStatQueue, sq *StatQueue) (err
 			return
 		}
 	}
-	iDB.db.Set(utils.CacheStatQueues, utils.ConcatenatedKey(sq.Tenant, sq.ID), sq, nil,
+	return iDB.db.Set(utils.CacheStatQueues, utils.ConcatenatedKey(sq.Tenant, sq.ID), sq, nil,
 		true, utils.NonTransactional)
-	return
 }
 func (iDB *InternalDB) RemStatQueueDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheStatQueues, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheStatQueues, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 func (iDB *InternalDB) SetTrendProfileDrv(srp *TrendProfile) (err error) {
-	iDB.db.Set(utils.CacheTrendProfiles, srp.TenantID(), srp, nil, true, utils.NonTransactional)
-	return nil
+	return iDB.db.Set(utils.CacheTrendProfiles, srp.TenantID(), srp, nil, true, utils.NonTransactional)
 }
 
 func (iDB *InternalDB) RemTrendProfileDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheTrendProfiles, utils.ConcatenatedKey(tenant, id), true, utils.NonTransactional)
-	return nil
+	return iDB.db.Remove(utils.CacheTrendProfiles, utils.ConcatenatedKey(tenant, id), true, utils.NonTransactional)
 }
 
 func (iDB *InternalDB) GetTrendProfileDrv(tenant, id string) (sg *TrendProfile, err error) {
@@ -680,25 +676,21 @@ func (iDB *InternalDB) GetTrendDrv(tenant, id string) (th *Trend, err error) {
 }
 
 func (iDB *InternalDB) SetTrendDrv(tr *Trend) (err error) {
-	iDB.db.Set(utils.CacheTrends, tr.TenantID(), tr, nil,
+	return iDB.db.Set(utils.CacheTrends, tr.TenantID(), tr, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveTrendDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheTrends, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheTrends, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) SetRankingProfileDrv(sgp *RankingProfile) (err error) {
-	iDB.db.Set(utils.CacheRankingProfiles, sgp.TenantID(), sgp, nil, true, utils.NonTransactional)
-	return nil
+	return iDB.db.Set(utils.CacheRankingProfiles, sgp.TenantID(), sgp, nil, true, utils.NonTransactional)
 }
 
 func (iDB *InternalDB) RemRankingProfileDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheRankingProfiles, utils.ConcatenatedKey(tenant, id), true, utils.NonTransactional)
-	return nil
+	return iDB.db.Remove(utils.CacheRankingProfiles, utils.ConcatenatedKey(tenant, id), true, utils.NonTransactional)
 }
 
 func (iDB *InternalDB) GetRankingProfileDrv(tenant, id string) (sg *RankingProfile, err error) {
@@ -709,6 +701,118 @@ func (iDB *InternalDB) GetRankingProfileDrv(tenant, id string) (sg *RankingProfi
 	return x.(*RankingProfile), nil
 }
 
+// dataSetEntryItemID returns the ID of the item holding the entry of the DataSet, the
+// entries being stored one by one, grouped under the DataSet tenant and ID
+func dataSetEntryItemID(tntID string, ent *DataSetEntry) string {
+	return utils.ConcatenatedKey(tntID, dataSetEntryKey(ent))
+}
+
+// dataSetTenantID returns the tenant and ID of the DataSet out of the ID of one of its entries
+func dataSetTenantID(itmID string) string {
+	idx := -1
+	for _, typ := range []string{utils.MetaString, utils.MetaPrefix} {
+		if i := strings.Index(itmID, utils.ConcatenatedKeySep+typ+utils.ConcatenatedKeySep); i != -1 &&
+			(idx == -1 || i < idx) {
+			idx = i
+		}
+	}
+	if idx == -1 {
+		return itmID
+	}
+	return itmID[:idx]
+}
+
+// GetDataSetDrv builds the DataSet out of its entries
+func (iDB *InternalDB) GetDataSetDrv(tenant, id string) (ds *DataSet, err error) {
+	itms := iDB.db.GetGroupItems(utils.CacheDataSets, utils.ConcatenatedKey(tenant, id))
+	if len(itms) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	ents := make([]*DataSetEntry, 0, len(itms))
+	for _, itm := range itms {
+		if itm != nil {
+			ents = append(ents, itm.(*DataSetEntry))
+		}
+	}
+	ds = NewDataSet(tenant, id)
+	ds.SetEntries(ents)
+	return
+}
+
+// SetDataSetDrv replaces the entries of the DataSet, removing it if left without entries as the other DataDBs do
+func (iDB *InternalDB) SetDataSetDrv(ds *DataSet) (err error) {
+	tntID := ds.TenantID()
+	ents := ds.Entries()
+	itmIDs := make(utils.StringSet, len(ents))
+	for _, ent := range ents {
+		itmIDs.Add(dataSetEntryItemID(tntID, ent))
+	}
+	for _, itmID := range iDB.db.GetGroupItemIDs(utils.CacheDataSets, tntID) {
+		if itmIDs.Has(itmID) {
+			continue
+		}
+		if err = iDB.db.Remove(utils.CacheDataSets, itmID, true, utils.NonTransactional); err != nil {
+			return
+		}
+	}
+	return iDB.setDataSetEntries(tntID, ents)
+}
+
+// AddDataSetEntriesDrv sets the entries of the DataSet, creating it if missing
+func (iDB *InternalDB) AddDataSetEntriesDrv(tenant, id string, ents []*DataSetEntry) (err error) {
+	return iDB.setDataSetEntries(utils.ConcatenatedKey(tenant, id), ents)
+}
+
+// setDataSetEntries stores the entries one by one so only the changed ones reach the write-ahead log
+func (iDB *InternalDB) setDataSetEntries(tntID string, ents []*DataSetEntry) (err error) {
+	grpIDs := []string{tntID}
+	for _, ent := range ents {
+		if err = iDB.db.Set(utils.CacheDataSets, dataSetEntryItemID(tntID, ent), ent, grpIDs,
+			true, utils.NonTransactional); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// RemoveDataSetEntriesDrv removes the entries of the DataSet, ignoring the ones not present
+func (iDB *InternalDB) RemoveDataSetEntriesDrv(tenant, id string, ents []*DataSetEntry) (err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	if !iDB.db.HasGroup(utils.CacheDataSets, tntID) {
+		return utils.ErrNotFound
+	}
+	for _, ent := range ents {
+		itmID := dataSetEntryItemID(tntID, ent)
+		if !iDB.db.HasItem(utils.CacheDataSets, itmID) {
+			continue
+		}
+		if err = iDB.db.Remove(utils.CacheDataSets, itmID, true, utils.NonTransactional); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (iDB *InternalDB) RemoveDataSetDrv(tenant, id string) (err error) {
+	return iDB.db.RemoveGroup(utils.CacheDataSets, utils.ConcatenatedKey(tenant, id), true, utils.NonTransactional)
+}
+
+func (iDB *InternalDB) GetTaxProfileDrv(tenant, id string) (tp *TaxProfile, err error) {
+	x, ok := iDB.db.Get(utils.CacheTaxProfiles, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*TaxProfile), nil
+}
+
+func (iDB *InternalDB) SetTaxProfileDrv(tp *TaxProfile) (err error) {
+	return iDB.db.Set(utils.CacheTaxProfiles, tp.TenantID(), tp, nil, true, utils.NonTransactional)
+}
+
+func (iDB *InternalDB) RemoveTaxProfileDrv(tenant, id string) (err error) {
+	return iDB.db.Remove(utils.CacheTaxProfiles, utils.ConcatenatedKey(tenant, id), true, utils.NonTransactional)
+}
+
 func (iDB *InternalDB) GetRankingDrv(tenant, id string) (rn *Ranking, err error) {
 	x, ok := iDB.db.Get(utils.CacheRankings, utils.ConcatenatedKey(tenant, id))
 	if !ok || x == nil {
@@ -718,15 +822,13 @@ func (iDB *InternalDB) GetRankingDrv(tenant, id string) (rn *Ranking, err error)
 }
 
 func (iDB *InternalDB) SetRankingDrv(rn *Ranking) (err error) {
-	iDB.db.Set(utils.CacheRankings, rn.TenantID(), rn, nil,
+	return iDB.db.Set(utils.CacheRankings, rn.TenantID(), rn, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveRankingDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheRankings, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheRankings, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetThresholdProfileDrv(tenant, id string) (tp *ThresholdProfile, err error) {
@@ -738,15 +840,13 @@ func (iDB *InternalDB) GetThresholdProfileDrv(tenant, id string) (tp *ThresholdP
 }
 
 func (iDB *InternalDB) SetThresholdProfileDrv(tp *ThresholdProfile) (err error) {
-	iDB.db.Set(utils.CacheThresholdProfiles, tp.TenantID(), tp, nil,
+	return iDB.db.Set(utils.CacheThresholdProfiles, tp.TenantID(), tp, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemThresholdProfileDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheThresholdProfiles, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheThresholdProfiles, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetThresholdDrv(tenant, id string) (th *Threshold, err error) {
@@ -758,15 +858,13 @@ func (iDB *InternalDB) GetThresholdDrv(tenant, id string) (th *Threshold, err er
 }
 
 func (iDB *InternalDB) SetThresholdDrv(th *Threshold) (err error) {
-	iDB.db.Set(utils.CacheThresholds, th.TenantID(), th, nil,
+	return iDB.db.Set(utils.CacheThresholds, th.TenantID(), th, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveThresholdDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheThresholds, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheThresholds, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetFilterDrv(tenant, id string) (fltr *Filter, err error) {
@@ -782,15 +880,13 @@ func (iDB *InternalDB) SetFilterDrv(fltr *Filter) (err error) {
 	if err = fltr.Compile(); err != nil {
 		return
 	}
-	iDB.db.Set(utils.CacheFilters, fltr.TenantID(), fltr, nil,
+	return iDB.db.Set(utils.CacheFilters, fltr.TenantID(), fltr, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveFilterDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheFilters, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheFilters, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetRouteProfileDrv(tenant, id string) (spp *RouteProfile, err error) {
@@ -805,15 +901,13 @@ func (iDB *InternalDB) SetRouteProfileDrv(spp *RouteProfile) (err error) {
 	if err = spp.Compile(); err != nil {
 		return
 	}
-	iDB.db.Set(utils.CacheRouteProfiles, spp.TenantID(), spp, nil,
+	return iDB.db.Set(utils.CacheRouteProfiles, spp.TenantID(), spp, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveRouteProfileDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheRouteProfiles, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheRouteProfiles, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetAttributeProfileDrv(tenant, id string) (attr *AttributeProfile, err error) {
@@ -828,15 +922,13 @@ func (iDB *InternalDB) SetAttributeProfileDrv(attr *AttributeProfile) (err error
 	if err = attr.Compile(); err != nil {
 		return
 	}
-	iDB.db.Set(utils.CacheAttributeProfiles, attr.TenantID(), attr, nil,
+	return iDB.db.Set(utils.CacheAttributeProfiles, attr.TenantID(), attr, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveAttributeProfileDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheAttributeProfiles, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheAttributeProfiles, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetChargerProfileDrv(tenant, id string) (ch *ChargerProfile, err error) {
@@ -848,15 +940,13 @@ func (iDB *InternalDB) GetChargerProfileDrv(tenant, id string) (ch *ChargerProfi
 }
 
 func (iDB *InternalDB) SetChargerProfileDrv(chr *ChargerProfile) (err error) {
-	iDB.db.Set(utils.CacheChargerProfiles, chr.TenantID(), chr, nil,
+	return iDB.db.Set(utils.CacheChargerProfiles, chr.TenantID(), chr, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveChargerProfileDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheChargerProfiles, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheChargerProfiles, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetDispatcherProfileDrv(tenant, id string) (dpp *DispatcherProfile, err error) {
@@ -868,15 +958,13 @@ func (iDB *InternalDB) GetDispatcherProfileDrv(tenant, id string) (dpp *Dispatch
 }
 
 func (iDB *InternalDB) SetDispatcherProfileDrv(dpp *DispatcherProfile) (err error) {
-	iDB.db.Set(utils.CacheDispatcherProfiles, dpp.TenantID(), dpp, nil,
+	return iDB.db.Set(utils.CacheDispatcherProfiles, dpp.TenantID(), dpp, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveDispatcherProfileDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheDispatcherProfiles, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheDispatcherProfiles, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetItemLoadIDsDrv(itemIDPrefix string) (loadIDs map[string]int64, err error) {
@@ -892,9 +980,8 @@ func (iDB *InternalDB) GetItemLoadIDsDrv(itemIDPrefix string) (loadIDs map[strin
 }
 
 func (iDB *InternalDB) SetLoadIDsDrv(loadIDs map[string]int64) (err error) {
-	iDB.db.Set(utils.CacheLoadIDs, utils.LoadIDs, loadIDs, nil,
+	return iDB.db.Set(utils.CacheLoadIDs, utils.LoadIDs, loadIDs, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) GetDispatcherHostDrv(tenant, id string) (dpp *DispatcherHost, err error) {
@@ -906,15 +993,13 @@ func (iDB *InternalDB) GetDispatcherHostDrv(tenant, id string) (dpp *DispatcherH
 }
 
 func (iDB *InternalDB) SetDispatcherHostDrv(dpp *DispatcherHost) (err error) {
-	iDB.db.Set(utils.CacheDispatcherHosts, dpp.TenantID(), dpp, nil,
+	return iDB.db.Set(utils.CacheDispatcherHosts, dpp.TenantID(), dpp, nil,
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveDispatcherHostDrv(tenant, id string) (err error) {
-	iDB.db.Remove(utils.CacheDispatcherHosts, utils.ConcatenatedKey(tenant, id),
+	return iDB.db.Remove(utils.CacheDispatcherHosts, utils.ConcatenatedKey(tenant, id),
 		true, utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveLoadIDsDrv() (err error) {
@@ -962,11 +1047,15 @@ func (iDB *InternalDB) SetIndexesDrv(idxItmType, tntCtx string,
 			if !ok || x == nil {
 				continue
 			}
-			iDB.db.Remove(idxItmType, dbKey,
-				true, utils.NonTransactional)
+			if err = iDB.db.Remove(idxItmType, dbKey,
+				true, utils.NonTransactional); err != nil {
+				return
+			}
 			key := strings.TrimSuffix(strings.TrimPrefix(dbKey, "tmp_"), utils.ConcatenatedKeySep+transactionID)
-			iDB.db.Set(idxItmType, key, x, []string{tntCtx},
-				true, utils.NonTransactional)
+			if err = iDB.db.Set(idxItmType, key, x, []string{tntCtx},
+				true, utils.NonTransactional); err != nil {
+				return
+			}
 		}
 		return
 	}
@@ -976,23 +1065,31 @@ func (iDB *InternalDB) SetIndexesDrv(idxItmType, tntCtx string,
 			dbKey = "tmp_" + utils.ConcatenatedKey(dbKey, transactionID)
 		}
 		if len(indx) == 0 {
-			iDB.db.Remove(idxItmType, dbKey,
-				true, utils.NonTransactional)
+			if err = iDB.db.Remove(idxItmType, dbKey,
+				true, utils.NonTransactional); err != nil {
+				return
+			}
 			continue
 		}
-		iDB.db.Set(idxItmType, dbKey, indx, []string{tntCtx},
-			true, utils.NonTransactional)
+		if err = iDB.db.Set(idxItmType, dbKey, indx, []string{tntCtx},
+			true, utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
 
 func (iDB *InternalDB) RemoveIndexesDrv(idxItmType, tntCtx string, idxKeys ...string) (err error) {
 	if len(idxKeys) == 0 { // remove all
-		iDB.db.RemoveGroup(idxItmType, tntCtx, true, utils.EmptyString)
+		if err = iDB.db.RemoveGroup(idxItmType, tntCtx, true, utils.EmptyString); err != nil {
+			return
+		}
 		return
 	}
 	for _, idxKey := range idxKeys {
-		iDB.db.Remove(idxItmType, utils.ConcatenatedKey(tntCtx, idxKey), true, utils.NonTransactional)
+		if err = iDB.db.Remove(idxItmType, utils.ConcatenatedKey(tntCtx, idxKey), true, utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -1001,8 +1098,10 @@ func (iDB *InternalDB) RemoveIndexesDrv(idxItmType, tntCtx string, idxKeys ...st
 func (iDB *InternalDB) SetBackupSessionsDrv(nodeID string,
 	tnt string, storedSessions []*StoredSession) error {
 	for _, sess := range storedSessions {
-		iDB.db.Set(utils.CacheSessionsBackup, sess.CGRID, sess,
-			[]string{utils.ConcatenatedKey(tnt, nodeID)}, true, utils.NonTransactional)
+		if err := iDB.db.Set(utils.CacheSessionsBackup, sess.CGRID, sess,
+			[]string{utils.ConcatenatedKey(tnt, nodeID)}, true, utils.NonTransactional); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1021,15 +1120,65 @@ func (iDB *InternalDB) GetSessionsBackupDrv(nodeID, tnt string) ([]*StoredSessio
 	return storedSessions, nil
 }
 
+// GetInvoiceDrv returns an issued invoice
+func (iDB *InternalDB) GetInvoiceDrv(tnt, id string) (*Invoice, error) {
+	x, ok := iDB.db.Get(utils.CacheInvoices, utils.ConcatenatedKey(tnt, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*Invoice), nil
+}
+
+// GetInvoicesDrv returns the invoices of the tenant, all of them if the account is empty
+func (iDB *InternalDB) GetInvoicesDrv(tnt, acnt string) (invs []*Invoice, err error) {
+	for _, x := range iDB.db.GetGroupItems(utils.CacheInvoices, tnt) {
+		if inv := x.(*Invoice); acnt == utils.EmptyString || inv.Account == acnt {
+			invs = append(invs, inv)
+		}
+	}
+	return
+}
+
+// CountInvoicesDrv returns the number of invoices issued for the tenant
+func (iDB *InternalDB) CountInvoicesDrv(tnt string) (int, error) {
+	return len(iDB.db.GetGroupItemIDs(utils.CacheInvoices, tnt)), nil
+}
+
+// SetInvoiceDrv stores the invoice, refusing to overwrite an existing one
+func (iDB *InternalDB) SetInvoiceDrv(inv *Invoice) error {
+	if _, has := iDB.db.Get(utils.CacheInvoices, inv.TenantID()); has {
+		return utils.ErrExists
+	}
+	return iDB.db.Set(utils.CacheInvoices, inv.TenantID(), inv, []string{inv.Tenant},
+		true, utils.NonTransactional)
+}
+
+// GetAuditRecordsDrv returns the audit trail of the profile
+func (iDB *InternalDB) GetAuditRecordsDrv(objType, tnt, id string) (recs []*AuditRecord, err error) {
+	for _, x := range iDB.db.GetGroupItems(utils.CacheAuditRecords,
+		utils.ConcatenatedKey(objType, tnt, id)) {
+		recs = append(recs, x.(*AuditRecord))
+	}
+	return
+}
+
+// SetAuditRecordDrv appends the record to the audit trail of the profile
+func (iDB *InternalDB) SetAuditRecordDrv(rec *AuditRecord) error {
+	objKey := rec.ObjectKey()
+	return iDB.db.Set(utils.CacheAuditRecords, utils.ConcatenatedKey(objKey, strconv.FormatInt(iDB.cnter.Next(), 10)),
+		rec, []string{objKey}, true, utils.NonTransactional)
+}
+
 // Will remove one or all sessions from dataDB backup
 func (iDB *InternalDB) RemoveSessionsBackupDrv(nodeID, tnt, cgrid string) error {
 	if cgrid == utils.EmptyString {
-		iDB.db.RemoveGroup(utils.CacheSessionsBackup, utils.ConcatenatedKey(tnt,
-			nodeID), true, utils.NonTransactional)
+		if err := iDB.db.RemoveGroup(utils.CacheSessionsBackup, utils.ConcatenatedKey(tnt,
+			nodeID), true, utils.NonTransactional); err != nil {
+			return err
+		}
 		return nil
 	}
-	iDB.db.Remove(utils.CacheSessionsBackup, cgrid, true, utils.NonTransactional)
-	return nil
+	return iDB.db.Remove(utils.CacheSessionsBackup, cgrid, true, utils.NonTransactional)
 }
 
 // Will dump everything inside datadb to files
@@ -1046,3 +1195,44 @@ func (iDB *InternalDB) RewriteDataDB() (err error) {
 func (iDB *InternalDB) BackupDataDB(backupFolderPath string, zip bool) (err error) {
 	return iDB.db.BackupDumpFolder(backupFolderPath, zip)
 }
+
+// TryLock attempts to acquire the lease on lkID for ttl, returning its token if acquired
+func (iDB *InternalDB) TryLock(lkID string, ttl time.Duration) (token int64, acquired bool, err error) {
+	iDB.leasesMux.Lock()
+	defer iDB.leasesMux.Unlock()
+	now := time.Now()
+	if ls, has := iDB.leases[lkID]; has && ls.expiresAt.After(now) {
+		return
+	}
+	if iDB.leases == nil {
+		iDB.leases = make(map[string]*internalLease)
+	}
+	iDB.lastToken++
+	iDB.leases[lkID] = &internalLease{
+		token:     iDB.lastToken,
+		expiresAt: now.Add(ttl),
+	}
+	return iDB.lastToken, true, nil
+}
+
+// RefreshLock extends the lease on lkID with ttl, if it is still held with token
+func (iDB *InternalDB) RefreshLock(lkID string, token int64, ttl time.Duration) (bool, error) {
+	iDB.leasesMux.Lock()
+	defer iDB.leasesMux.Unlock()
+	ls, has := iDB.leases[lkID]
+	if !has || ls.token != token {
+		return false, nil
+	}
+	ls.expiresAt = time.Now().Add(ttl)
+	return true, nil
+}
+
+// Unlock releases the lease on lkID, if it is still held with token
+func (iDB *InternalDB) Unlock(lkID string, token int64) error {
+	iDB.leasesMux.Lock()
+	if ls, has := iDB.leases[lkID]; has && ls.token == token {
+		delete(iDB.leases, lkID)
+	}
+	iDB.leasesMux.Unlock()
+	return nil
+}
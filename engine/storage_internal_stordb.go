@@ -472,6 +472,28 @@ func (iDB *InternalDB) GetTPRankings(tpid string, tenant string, id string) (ran
 	return
 }
 
+func (iDB *InternalDB) GetTPTaxProfiles(tpid string, tenant string, id string) (taxProfiles []*utils.TPTaxProfile, err error) {
+	key := tpid
+	if tenant != utils.EmptyString {
+		key += utils.ConcatenatedKeySep + tenant
+	}
+	if id != utils.EmptyString {
+		key += utils.ConcatenatedKeySep + id
+	}
+	ids := iDB.db.GetItemIDs(utils.CacheTBLTPTaxProfiles, key)
+	for _, id := range ids {
+		x, ok := iDB.db.Get(utils.CacheTBLTPTaxProfiles, id)
+		if !ok || x == nil {
+			return nil, utils.ErrNotFound
+		}
+		taxProfiles = append(taxProfiles, x.(*utils.TPTaxProfile))
+	}
+	if len(taxProfiles) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
 func (iDB *InternalDB) GetTPThresholds(tpid, tenant, id string) (ths []*utils.TPThresholdProfile, err error) {
 	key := tpid
 	if tenant != utils.EmptyString {
@@ -658,8 +680,10 @@ func (iDB *InternalDB) RemTpData(table, tpid string, args map[string]string) (er
 	}
 	ids := iDB.db.GetItemIDs(utils.CacheStorDBPartitions[table], key)
 	for _, id := range ids {
-		iDB.db.Remove(utils.CacheStorDBPartitions[table], id,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Remove(utils.CacheStorDBPartitions[table], id,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -669,8 +693,10 @@ func (iDB *InternalDB) SetTPTimings(timings []*utils.ApierTPTiming) (err error)
 		return nil
 	}
 	for _, timing := range timings {
-		iDB.db.Set(utils.CacheTBLTPTimings, utils.ConcatenatedKey(timing.TPid, timing.ID), timing, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPTimings, utils.ConcatenatedKey(timing.TPid, timing.ID), timing, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -679,8 +705,10 @@ func (iDB *InternalDB) SetTPDestinations(dests []*utils.TPDestination) (err erro
 		return nil
 	}
 	for _, destination := range dests {
-		iDB.db.Set(utils.CacheTBLTPDestinations, utils.ConcatenatedKey(destination.TPid, destination.ID), destination, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPDestinations, utils.ConcatenatedKey(destination.TPid, destination.ID), destination, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -690,8 +718,10 @@ func (iDB *InternalDB) SetTPRates(rates []*utils.TPRateRALs) (err error) {
 		return nil
 	}
 	for _, rate := range rates {
-		iDB.db.Set(utils.CacheTBLTPRates, utils.ConcatenatedKey(rate.TPid, rate.ID), rate, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPRates, utils.ConcatenatedKey(rate.TPid, rate.ID), rate, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -701,8 +731,10 @@ func (iDB *InternalDB) SetTPDestinationRates(dRates []*utils.TPDestinationRate)
 		return nil
 	}
 	for _, dRate := range dRates {
-		iDB.db.Set(utils.CacheTBLTPDestinationRates, utils.ConcatenatedKey(dRate.TPid, dRate.ID), dRate, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPDestinationRates, utils.ConcatenatedKey(dRate.TPid, dRate.ID), dRate, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -712,8 +744,10 @@ func (iDB *InternalDB) SetTPRatingPlans(ratingPlans []*utils.TPRatingPlan) (err
 		return nil
 	}
 	for _, rPlan := range ratingPlans {
-		iDB.db.Set(utils.CacheTBLTPRatingPlans, utils.ConcatenatedKey(rPlan.TPid, rPlan.ID), rPlan, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPRatingPlans, utils.ConcatenatedKey(rPlan.TPid, rPlan.ID), rPlan, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -723,9 +757,11 @@ func (iDB *InternalDB) SetTPRatingProfiles(ratingProfiles []*utils.TPRatingProfi
 		return nil
 	}
 	for _, rProfile := range ratingProfiles {
-		iDB.db.Set(utils.CacheTBLTPRatingProfiles, utils.ConcatenatedKey(rProfile.TPid,
+		if err = iDB.db.Set(utils.CacheTBLTPRatingProfiles, utils.ConcatenatedKey(rProfile.TPid,
 			rProfile.LoadId, rProfile.Tenant, rProfile.Category, rProfile.Subject), rProfile, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -735,8 +771,10 @@ func (iDB *InternalDB) SetTPSharedGroups(groups []*utils.TPSharedGroups) (err er
 		return nil
 	}
 	for _, group := range groups {
-		iDB.db.Set(utils.CacheTBLTPSharedGroups, utils.ConcatenatedKey(group.TPid, group.ID), group, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPSharedGroups, utils.ConcatenatedKey(group.TPid, group.ID), group, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -746,8 +784,10 @@ func (iDB *InternalDB) SetTPActions(acts []*utils.TPActions) (err error) {
 		return nil
 	}
 	for _, action := range acts {
-		iDB.db.Set(utils.CacheTBLTPActions, utils.ConcatenatedKey(action.TPid, action.ID), action, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPActions, utils.ConcatenatedKey(action.TPid, action.ID), action, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -757,8 +797,10 @@ func (iDB *InternalDB) SetTPActionPlans(aPlans []*utils.TPActionPlan) (err error
 		return nil
 	}
 	for _, aPlan := range aPlans {
-		iDB.db.Set(utils.CacheTBLTPActionPlans, utils.ConcatenatedKey(aPlan.TPid, aPlan.ID), aPlan, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPActionPlans, utils.ConcatenatedKey(aPlan.TPid, aPlan.ID), aPlan, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -768,8 +810,10 @@ func (iDB *InternalDB) SetTPActionTriggers(aTriggers []*utils.TPActionTriggers)
 		return nil
 	}
 	for _, aTrigger := range aTriggers {
-		iDB.db.Set(utils.CacheTBLTPActionTriggers, utils.ConcatenatedKey(aTrigger.TPid, aTrigger.ID), aTrigger, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPActionTriggers, utils.ConcatenatedKey(aTrigger.TPid, aTrigger.ID), aTrigger, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -779,9 +823,11 @@ func (iDB *InternalDB) SetTPAccountActions(accActions []*utils.TPAccountActions)
 		return nil
 	}
 	for _, accAction := range accActions {
-		iDB.db.Set(utils.CacheTBLTPAccountActions, utils.ConcatenatedKey(accAction.TPid,
+		if err = iDB.db.Set(utils.CacheTBLTPAccountActions, utils.ConcatenatedKey(accAction.TPid,
 			accAction.LoadId, accAction.Tenant, accAction.Account), accAction, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -791,8 +837,10 @@ func (iDB *InternalDB) SetTPResources(resources []*utils.TPResourceProfile) (err
 		return nil
 	}
 	for _, resource := range resources {
-		iDB.db.Set(utils.CacheTBLTPResources, utils.ConcatenatedKey(resource.TPid, resource.Tenant, resource.ID), resource, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPResources, utils.ConcatenatedKey(resource.TPid, resource.Tenant, resource.ID), resource, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -802,8 +850,10 @@ func (iDB *InternalDB) SetTPIPs(ips []*utils.TPIPProfile) (err error) {
 		return nil
 	}
 	for _, ip := range ips {
-		iDB.db.Set(utils.CacheTBLTPIPs, utils.ConcatenatedKey(ip.TPid, ip.Tenant, ip.ID), ip, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPIPs, utils.ConcatenatedKey(ip.TPid, ip.Tenant, ip.ID), ip, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -813,8 +863,10 @@ func (iDB *InternalDB) SetTPStats(stats []*utils.TPStatProfile) (err error) {
 		return nil
 	}
 	for _, stat := range stats {
-		iDB.db.Set(utils.CacheTBLTPStats, utils.ConcatenatedKey(stat.TPid, stat.Tenant, stat.ID), stat, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPStats, utils.ConcatenatedKey(stat.TPid, stat.Tenant, stat.ID), stat, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -823,7 +875,20 @@ func (iDB *InternalDB) SetTPRankings(rankings []*utils.TPRankingProfile) (err er
 		return nil
 	}
 	for _, ranking := range rankings {
-		iDB.db.Set(utils.CacheTBLTPRankings, utils.ConcatenatedKey(ranking.TPid, ranking.Tenant, ranking.ID), ranking, nil, cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPRankings, utils.ConcatenatedKey(ranking.TPid, ranking.Tenant, ranking.ID), ranking, nil, cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
+	}
+	return
+}
+func (iDB *InternalDB) SetTPTaxProfiles(taxProfiles []*utils.TPTaxProfile) (err error) {
+	if len(taxProfiles) == 0 {
+		return nil
+	}
+	for _, taxProfile := range taxProfiles {
+		if err = iDB.db.Set(utils.CacheTBLTPTaxProfiles, utils.ConcatenatedKey(taxProfile.TPid, taxProfile.Tenant, taxProfile.ID), taxProfile, nil, cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -832,7 +897,9 @@ func (iDB *InternalDB) SetTPTrends(trends []*utils.TPTrendsProfile) (err error)
 		return nil
 	}
 	for _, trend := range trends {
-		iDB.db.Set(utils.CacheTBLTPTrends, utils.ConcatenatedKey(trend.TPid, trend.Tenant, trend.ID), trend, nil, cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPTrends, utils.ConcatenatedKey(trend.TPid, trend.Tenant, trend.ID), trend, nil, cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -842,8 +909,10 @@ func (iDB *InternalDB) SetTPThresholds(thresholds []*utils.TPThresholdProfile) (
 	}
 
 	for _, threshold := range thresholds {
-		iDB.db.Set(utils.CacheTBLTPThresholds, utils.ConcatenatedKey(threshold.TPid, threshold.Tenant, threshold.ID), threshold, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPThresholds, utils.ConcatenatedKey(threshold.TPid, threshold.Tenant, threshold.ID), threshold, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -853,8 +922,10 @@ func (iDB *InternalDB) SetTPFilters(filters []*utils.TPFilterProfile) (err error
 	}
 
 	for _, filter := range filters {
-		iDB.db.Set(utils.CacheTBLTPFilters, utils.ConcatenatedKey(filter.TPid, filter.Tenant, filter.ID), filter, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPFilters, utils.ConcatenatedKey(filter.TPid, filter.Tenant, filter.ID), filter, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -864,8 +935,10 @@ func (iDB *InternalDB) SetTPRoutes(routes []*utils.TPRouteProfile) (err error) {
 		return nil
 	}
 	for _, route := range routes {
-		iDB.db.Set(utils.CacheTBLTPRoutes, utils.ConcatenatedKey(route.TPid, route.Tenant, route.ID), route, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPRoutes, utils.ConcatenatedKey(route.TPid, route.Tenant, route.ID), route, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -876,8 +949,10 @@ func (iDB *InternalDB) SetTPAttributes(attributes []*utils.TPAttributeProfile) (
 	}
 
 	for _, attribute := range attributes {
-		iDB.db.Set(utils.CacheTBLTPAttributes, utils.ConcatenatedKey(attribute.TPid, attribute.Tenant, attribute.ID), attribute, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPAttributes, utils.ConcatenatedKey(attribute.TPid, attribute.Tenant, attribute.ID), attribute, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -887,8 +962,10 @@ func (iDB *InternalDB) SetTPChargers(cpps []*utils.TPChargerProfile) (err error)
 	}
 
 	for _, cpp := range cpps {
-		iDB.db.Set(utils.CacheTBLTPChargers, utils.ConcatenatedKey(cpp.TPid, cpp.Tenant, cpp.ID), cpp, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPChargers, utils.ConcatenatedKey(cpp.TPid, cpp.Tenant, cpp.ID), cpp, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -898,8 +975,10 @@ func (iDB *InternalDB) SetTPDispatcherProfiles(dpps []*utils.TPDispatcherProfile
 	}
 
 	for _, dpp := range dpps {
-		iDB.db.Set(utils.CacheTBLTPDispatchers, utils.ConcatenatedKey(dpp.TPid, dpp.Tenant, dpp.ID), dpp, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPDispatchers, utils.ConcatenatedKey(dpp.TPid, dpp.Tenant, dpp.ID), dpp, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -908,8 +987,10 @@ func (iDB *InternalDB) SetTPDispatcherHosts(dpps []*utils.TPDispatcherHost) (err
 		return nil
 	}
 	for _, dpp := range dpps {
-		iDB.db.Set(utils.CacheTBLTPDispatcherHosts, utils.ConcatenatedKey(dpp.TPid, dpp.Tenant, dpp.ID), dpp, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err = iDB.db.Set(utils.CacheTBLTPDispatcherHosts, utils.ConcatenatedKey(dpp.TPid, dpp.Tenant, dpp.ID), dpp, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -965,16 +1046,13 @@ func (iDB *InternalDB) SetCDR(cdr *CDR, allowUpdate bool) (err error) {
 		}
 	}
 
-	iDB.db.Set(utils.CacheCDRsTBL, cdrKey, saveCDR, idxs.AsSlice(),
+	return iDB.db.Set(utils.CacheCDRsTBL, cdrKey, saveCDR, idxs.AsSlice(),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
-
-	return
 }
 
 func (iDB *InternalDB) RemoveSMCost(smc *SMCost) (err error) {
-	iDB.db.Remove(utils.CacheSessionCostsTBL, utils.ConcatenatedKey(smc.CGRID, smc.RunID, smc.OriginHost, smc.OriginID),
+	return iDB.db.Remove(utils.CacheSessionCostsTBL, utils.ConcatenatedKey(smc.CGRID, smc.RunID, smc.OriginHost, smc.OriginID),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
-	return
 }
 
 func (iDB *InternalDB) RemoveSMCosts(qryFltr *utils.SMCostFilter) error {
@@ -1053,8 +1131,10 @@ func (iDB *InternalDB) RemoveSMCosts(qryFltr *utils.SMCostFilter) error {
 	}
 
 	for key := range smMpIDs {
-		iDB.db.Remove(utils.CacheSessionCostsTBL, key,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		if err := iDB.db.Remove(utils.CacheSessionCostsTBL, key,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1508,8 +1588,10 @@ func (iDB *InternalDB) GetCDRs(filter *utils.CDRsFilter, remove bool) (cdrs []*C
 	}
 	if remove {
 		for _, cdr := range cdrs {
-			iDB.db.Remove(utils.CacheCDRsTBL, utils.ConcatenatedKey(cdr.CGRID, cdr.RunID, cdr.OriginID),
-				cacheCommit(utils.NonTransactional), utils.NonTransactional)
+			if err = iDB.db.Remove(utils.CacheCDRsTBL, utils.ConcatenatedKey(cdr.CGRID, cdr.RunID, cdr.OriginID),
+				cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+				return nil, 0, err
+			}
 		}
 		return nil, 0, nil
 	}
@@ -1582,9 +1664,8 @@ func (iDB *InternalDB) SetSMCost(smCost *SMCost) (err error) {
 	idxs.Add(utils.ConcatenatedKey(utils.OriginHost, smCost.OriginHost))
 	idxs.Add(utils.ConcatenatedKey(utils.OriginID, smCost.OriginID))
 	idxs.Add(utils.ConcatenatedKey(utils.CostSource, smCost.CostSource))
-	iDB.db.Set(utils.CacheSessionCostsTBL, utils.ConcatenatedKey(smCost.CGRID, smCost.RunID, smCost.OriginHost, smCost.OriginID), smCost, idxs.AsSlice(),
+	return iDB.db.Set(utils.CacheSessionCostsTBL, utils.ConcatenatedKey(smCost.CGRID, smCost.RunID, smCost.OriginHost, smCost.OriginID), smCost, idxs.AsSlice(),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
-	return err
 }
 
 // Will dump everything inside stordb to files
@@ -0,0 +1,448 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
+)
+
+const (
+	walFileName        = "wal.log"   // the write-ahead log file inside the dump folder
+	walRotatedFileName = "wal.log.1" // the log moved aside while being compacted in the background
+)
+
+var (
+	errWALClosed     = errors.New("write-ahead log closed")
+	errWALCompacting = errors.New("write-ahead log compaction in progress")
+)
+
+// walEntry is one change of the InternalDB recorded in the write-ahead log
+type walEntry struct {
+	Action   string   // <*set|*remove|*remove_group|*clear>
+	CacheID  string   // the partition of the item
+	CacheIDs []string // the partitions cleared by *clear, all of them if empty
+	ItemID   string   // the item or the group removed by *remove_group
+	GroupIDs []string
+	Value    any
+}
+
+// walTransCache is the TransCache of the InternalDB. When the write-ahead log is
+// enabled the changes are logged before being applied so they can be replayed after a crash
+type walTransCache struct {
+	*ltcache.TransCache
+	wal *internalWAL
+}
+
+// Set logs the item in the write-ahead log and sets it in the TransCache. The item
+// is not set if it could not be logged
+func (tc *walTransCache) Set(chID, itmID string, value any, groupIDs []string, commit bool, transID string) error {
+	if tc.wal == nil || !commit { // the uncommitted changes are not applied either
+		tc.TransCache.Set(chID, itmID, value, groupIDs, commit, transID)
+		return nil
+	}
+	tc.wal.Lock()
+	defer tc.wal.Unlock()
+	if err := tc.wal.log(&walEntry{Action: utils.MetaSet, CacheID: chID, ItemID: itmID,
+		GroupIDs: groupIDs, Value: value}); err != nil {
+		return err
+	}
+	tc.TransCache.Set(chID, itmID, value, groupIDs, commit, transID)
+	return nil
+}
+
+// Remove logs the removal in the write-ahead log and removes the item from the TransCache
+func (tc *walTransCache) Remove(chID, itmID string, commit bool, transID string) error {
+	if tc.wal == nil || !commit {
+		tc.TransCache.Remove(chID, itmID, commit, transID)
+		return nil
+	}
+	tc.wal.Lock()
+	defer tc.wal.Unlock()
+	if err := tc.wal.log(&walEntry{Action: utils.MetaRemove, CacheID: chID, ItemID: itmID}); err != nil {
+		return err
+	}
+	tc.TransCache.Remove(chID, itmID, commit, transID)
+	return nil
+}
+
+// RemoveGroup logs the removal in the write-ahead log and removes the group from the TransCache
+func (tc *walTransCache) RemoveGroup(chID, grpID string, commit bool, transID string) error {
+	if tc.wal == nil || !commit {
+		tc.TransCache.RemoveGroup(chID, grpID, commit, transID)
+		return nil
+	}
+	tc.wal.Lock()
+	defer tc.wal.Unlock()
+	if err := tc.wal.log(&walEntry{Action: utils.MetaRemoveGroup, CacheID: chID, ItemID: grpID}); err != nil {
+		return err
+	}
+	tc.TransCache.RemoveGroup(chID, grpID, commit, transID)
+	return nil
+}
+
+// Clear logs the clear in the write-ahead log and clears the TransCache partitions
+func (tc *walTransCache) Clear(chIDs []string) error {
+	if tc.wal == nil {
+		tc.TransCache.Clear(chIDs)
+		return nil
+	}
+	tc.wal.Lock()
+	defer tc.wal.Unlock()
+	if err := tc.wal.log(&walEntry{Action: utils.MetaClear, CacheIDs: chIDs}); err != nil {
+		return err
+	}
+	tc.TransCache.Clear(chIDs)
+	return nil
+}
+
+// DumpAll dumps the TransCache, compacting the write-ahead log into the dump files
+func (tc *walTransCache) DumpAll() error {
+	if tc.wal == nil {
+		return tc.TransCache.DumpAll()
+	}
+	tc.wal.Lock()
+	defer tc.wal.Unlock()
+	return tc.wal.compact()
+}
+
+// BackupDumpFolder compacts the write-ahead log before backing up the dump folder
+// so the backup holds all the changes
+func (tc *walTransCache) BackupDumpFolder(backupFolderPath string, zip bool) error {
+	if tc.wal == nil {
+		return tc.TransCache.BackupDumpFolder(backupFolderPath, zip)
+	}
+	tc.wal.Lock()
+	defer tc.wal.Unlock()
+	if err := tc.wal.compact(); err != nil {
+		return err
+	}
+	return tc.TransCache.BackupDumpFolder(backupFolderPath, zip)
+}
+
+// Shutdown shuts down the TransCache and closes the write-ahead log. The logs are
+// kept as they are, being compacted on the next start
+func (tc *walTransCache) Shutdown() {
+	if tc.wal == nil {
+		tc.TransCache.Shutdown()
+		return
+	}
+	tc.wal.Lock()
+	defer tc.wal.Unlock()
+	tc.wal.compactions.Wait() // no dump after the TransCache is shut down
+	tc.TransCache.Shutdown()
+	tc.wal.close()
+}
+
+// internalWAL is the append-only log of the changes done on the InternalDB
+type internalWAL struct {
+	sync.Mutex
+	tc           *ltcache.TransCache
+	dumpPath     string
+	file         *os.File
+	enc          *gob.Encoder
+	size         int64         // bytes written since the last compaction
+	sizeLimit    int64         // the log is rotated and compacted once it grows over it
+	syncInterval time.Duration // <-1: fsync on each change; 0: left to the OS; >0: fsync interval>
+	unsynced     bool          // changes written since the last fsync
+	err          error         // the last write failure, the log being rotated before the next change
+	closed       bool
+	stopSync     chan struct{}
+	compactMux   sync.Mutex     // one dump of the TransCache at a time
+	compactions  sync.WaitGroup // the compactions running in the background
+}
+
+// OpenWAL replays the write-ahead log from dumpPath over the data recovered from the
+// dump files, compacts it into the dump and starts logging the changes done on the DB.
+// Needs the InternalDB to be created with the dump enabled
+func (iDB *InternalDB) OpenWAL(dumpPath string, syncInterval time.Duration, sizeLimit int64) (err error) {
+	w := &internalWAL{
+		tc:           iDB.db.TransCache,
+		dumpPath:     dumpPath,
+		sizeLimit:    sizeLimit,
+		syncInterval: syncInterval,
+	}
+	if w.file, err = os.OpenFile(filepath.Join(dumpPath, walFileName), os.O_CREATE|os.O_RDWR, 0644); err != nil {
+		return
+	}
+	var replayed int
+	if replayed, err = w.replayRotated(); err != nil { // older than the changes from the current log
+		w.file.Close()
+		return
+	}
+	var n int
+	if n, err = w.replay(w.file); err != nil {
+		w.file.Close()
+		return
+	}
+	replayed += n
+	if err = w.compact(); err != nil {
+		w.file.Close()
+		return
+	}
+	if replayed != 0 {
+		utils.Logger.Info(fmt.Sprintf("<%s> replayed %d changes from the write-ahead log in <%s>",
+			utils.MetaInternal, replayed, dumpPath))
+	}
+	if syncInterval > 0 {
+		w.stopSync = make(chan struct{})
+		go w.syncLoop()
+	}
+	iDB.db.wal = w
+	return
+}
+
+// replayRotated replays the log left by a compaction not finished before stopping
+func (w *internalWAL) replayRotated() (replayed int, err error) {
+	f, err := os.Open(filepath.Join(w.dumpPath, walRotatedFileName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+		return
+	}
+	defer f.Close()
+	return w.replay(f)
+}
+
+// replay applies the changes from the log on the TransCache. An incomplete change at
+// the end of the log, written while crashing, is discarded
+func (w *internalWAL) replay(f *os.File) (replayed int, err error) {
+	dec := gob.NewDecoder(f)
+	for {
+		var e walEntry
+		if err = dec.Decode(&e); err != nil {
+			if err != io.EOF {
+				utils.Logger.Warning(fmt.Sprintf("<%s> discarding the write-ahead log after %d changes: %v",
+					utils.MetaInternal, replayed, err))
+			}
+			return replayed, nil
+		}
+		switch e.Action {
+		case utils.MetaSet:
+			w.tc.Set(e.CacheID, e.ItemID, e.Value, e.GroupIDs, true, utils.NonTransactional)
+		case utils.MetaRemove:
+			w.tc.Remove(e.CacheID, e.ItemID, true, utils.NonTransactional)
+		case utils.MetaRemoveGroup:
+			w.tc.RemoveGroup(e.CacheID, e.ItemID, true, utils.NonTransactional)
+		case utils.MetaClear:
+			w.tc.Clear(e.CacheIDs)
+		default:
+			return replayed, fmt.Errorf("unsupported write-ahead log action: <%s>", e.Action)
+		}
+		replayed++
+	}
+}
+
+// Write counts the bytes written by the encoder in the log file
+func (w *internalWAL) Write(b []byte) (n int, err error) {
+	n, err = w.file.Write(b)
+	w.size += int64(n)
+	return
+}
+
+// log writes the change in the log, rotating it first if grown over the size limit
+// or after a failed write, which could leave an incomplete change at its end (not thread safe)
+func (w *internalWAL) log(e *walEntry) (err error) {
+	if w.closed {
+		return fmt.Errorf("failed logging <%s> of <%s:%s>: %w", e.Action, e.CacheID, e.ItemID, errWALClosed)
+	}
+	if w.err != nil || w.size > w.sizeLimit {
+		if err = w.rotate(); err != nil {
+			if w.err != nil { // not writing after the incomplete change
+				return fmt.Errorf("failed logging <%s> of <%s:%s>, the write-ahead log not being rotated after <%v>: %w",
+					e.Action, e.CacheID, e.ItemID, w.err, err)
+			}
+			if err != errWALCompacting {
+				utils.Logger.Warning(fmt.Sprintf("<%s> failed rotating the write-ahead log: %v",
+					utils.MetaInternal, err))
+			}
+		}
+	}
+	if err = w.enc.Encode(e); err == nil && w.syncInterval < 0 {
+		err = w.file.Sync()
+	}
+	if err != nil {
+		w.err = err
+		return fmt.Errorf("failed logging <%s> of <%s:%s> in the write-ahead log: %w",
+			e.Action, e.CacheID, e.ItemID, err)
+	}
+	w.unsynced = w.syncInterval > 0
+	return
+}
+
+// rotate moves the log aside, compacting it in the background while the changes are
+// logged in a new file. Only one log is rotated at a time (not thread safe)
+func (w *internalWAL) rotate() (err error) {
+	rotatedPath := filepath.Join(w.dumpPath, walRotatedFileName)
+	if _, err = os.Stat(rotatedPath); err == nil {
+		return errWALCompacting
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	logPath := w.file.Name()
+	if err = os.Rename(logPath, rotatedPath); err != nil {
+		return
+	}
+	var f *os.File
+	if f, err = os.OpenFile(logPath, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0644); err != nil {
+		if rErr := os.Rename(rotatedPath, logPath); rErr != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed restoring the rotated write-ahead log: %v",
+				utils.MetaInternal, rErr))
+		}
+		return
+	}
+	if w.err == nil && w.syncInterval != 0 {
+		err = w.file.Sync()
+	}
+	if cErr := w.file.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil { // the rotated log is still replayed on start
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed closing the rotated write-ahead log: %v",
+			utils.MetaInternal, err))
+	}
+	w.file = f
+	w.size, w.unsynced, w.err = 0, false, nil
+	w.enc = gob.NewEncoder(w)
+	w.compactions.Add(1)
+	go func() {
+		defer w.compactions.Done()
+		w.compactMux.Lock()
+		defer w.compactMux.Unlock()
+		if err := w.dump(); err != nil { // retried on the next rotation
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed compacting the write-ahead log: %v",
+				utils.MetaInternal, err))
+			return
+		}
+		if err := os.Remove(rotatedPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed removing the compacted write-ahead log: %v",
+				utils.MetaInternal, err))
+		}
+	}()
+	return nil
+}
+
+// dump dumps the TransCache, making sure it reached the disk before the logs are dropped
+func (w *internalWAL) dump() (err error) {
+	if err = w.tc.DumpAll(); err != nil {
+		return
+	}
+	if w.syncInterval != 0 {
+		err = syncFolder(w.dumpPath)
+	}
+	return
+}
+
+// compact dumps the TransCache and empties the logs (not thread safe)
+func (w *internalWAL) compact() (err error) {
+	w.compactMux.Lock()
+	defer w.compactMux.Unlock()
+	if err = w.dump(); err != nil {
+		return
+	}
+	if err = os.Remove(filepath.Join(w.dumpPath, walRotatedFileName)); err != nil &&
+		!errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	if err = w.file.Truncate(0); err != nil {
+		return
+	}
+	if _, err = w.file.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+	w.size, w.unsynced, w.err = 0, false, nil
+	w.enc = gob.NewEncoder(w) // the type definitions are sent again with the new stream
+	return
+}
+
+// syncLoop fsyncs the log on each syncInterval
+func (w *internalWAL) syncLoop() {
+	tckr := time.NewTicker(w.syncInterval)
+	defer tckr.Stop()
+	for {
+		select {
+		case <-w.stopSync:
+			return
+		case <-tckr.C:
+			w.Lock()
+			if w.unsynced && !w.closed {
+				if err := w.file.Sync(); err != nil {
+					utils.Logger.Warning(fmt.Sprintf("<%s> failed syncing the write-ahead log: %v",
+						utils.MetaInternal, err))
+				} else {
+					w.unsynced = false
+				}
+			}
+			w.Unlock()
+		}
+	}
+}
+
+// close stops the periodic fsync and closes the log file (not thread safe)
+func (w *internalWAL) close() {
+	if w.closed {
+		return
+	}
+	w.closed = true
+	if w.stopSync != nil {
+		close(w.stopSync)
+		w.stopSync = nil
+	}
+	if err := w.file.Sync(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed syncing the write-ahead log: %v",
+			utils.MetaInternal, err))
+	}
+	if err := w.file.Close(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed closing the write-ahead log: %v",
+			utils.MetaInternal, err))
+	}
+}
+
+// syncFolder fsyncs the files and the folders from the dump path. The files
+// renamed or removed meanwhile by the dump rewriting are skipped
+func syncFolder(fldrPath string) error {
+	return filepath.WalkDir(fldrPath, func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		defer f.Close()
+		return f.Sync()
+	})
+}
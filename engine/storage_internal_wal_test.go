@@ -0,0 +1,265 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
+)
+
+// newWALTestDB opens the InternalDB from dumpPath without closing it, the same as
+// an engine crashing would leave it
+func newWALTestDB(t *testing.T, dumpPath string, wal bool, sizeLimit int64) *InternalDB {
+	t.Helper()
+	cfg := config.NewDefaultCGRConfig()
+	iDB, err := NewInternalDB(nil, nil, true, &ltcache.TransCacheOpts{
+		DumpPath:      dumpPath,
+		StartTimeout:  time.Minute,
+		DumpInterval:  time.Hour, // no dump while the test runs
+		FileSizeLimit: 1 << 20,
+	}, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wal {
+		if err = iDB.OpenWAL(dumpPath, -1, sizeLimit); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return iDB
+}
+
+func TestInternalWALReplay(t *testing.T) {
+	dumpPath := t.TempDir()
+	iDB := newWALTestDB(t, dumpPath, true, 1<<20)
+	acc := &Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {{Uuid: "uuid1", ID: "main", Value: 10}},
+		},
+	}
+	if err := iDB.SetAccountDrv(acc); err != nil {
+		t.Fatal(err)
+	}
+	if err := iDB.SetAccountDrv(&Account{ID: "cgrates.org:1002"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := iDB.RemoveAccountDrv("cgrates.org:1002"); err != nil {
+		t.Fatal(err)
+	}
+	acc.BalanceMap[utils.MetaMonetary][0].Value = 7.5
+	if err := iDB.SetAccountDrv(acc); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(filepath.Join(dumpPath, walFileName)); err != nil {
+		t.Fatal(err)
+	} else if fi.Size() == 0 {
+		t.Fatal("expected the changes in the write-ahead log")
+	}
+
+	// crash before the dump
+	iDB = newWALTestDB(t, dumpPath, true, 1<<20)
+	if rcv, err := iDB.GetAccountDrv("cgrates.org:1001"); err != nil {
+		t.Fatal(err)
+	} else if val := rcv.BalanceMap[utils.MetaMonetary][0].Value; val != 7.5 {
+		t.Errorf("expected the last balance, received %v", val)
+	}
+	if _, err := iDB.GetAccountDrv("cgrates.org:1002"); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if fi, err := os.Stat(filepath.Join(dumpPath, walFileName)); err != nil {
+		t.Fatal(err)
+	} else if fi.Size() != 0 {
+		t.Errorf("expected the write-ahead log compacted, received %d bytes", fi.Size())
+	}
+	iDB.Close()
+
+	// the changes were compacted into the dump files
+	iDB = newWALTestDB(t, dumpPath, false, 0)
+	defer iDB.Close()
+	if rcv, err := iDB.GetAccountDrv("cgrates.org:1001"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(acc.BalanceMap, rcv.BalanceMap) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(acc.BalanceMap), utils.ToJSON(rcv.BalanceMap))
+	}
+}
+
+func TestInternalWALIncompleteEntry(t *testing.T) {
+	dumpPath := t.TempDir()
+	iDB := newWALTestDB(t, dumpPath, true, 1<<20)
+	if err := iDB.SetAccountDrv(&Account{ID: "cgrates.org:1001"}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(filepath.Join(dumpPath, walFileName), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.Write([]byte{0x20, 0xff, 0x81}); err != nil { // crashed while writing
+		t.Fatal(err)
+	}
+	f.Close()
+
+	iDB = newWALTestDB(t, dumpPath, true, 1<<20)
+	defer iDB.Close()
+	if _, err := iDB.GetAccountDrv("cgrates.org:1001"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInternalWALSizeLimit(t *testing.T) {
+	dumpPath := t.TempDir()
+	iDB := newWALTestDB(t, dumpPath, true, 1)
+	for _, id := range []string{"cgrates.org:1001", "cgrates.org:1002", "cgrates.org:1003"} {
+		if err := iDB.SetAccountDrv(&Account{ID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := iDB.RemoveAccountDrv("cgrates.org:1002"); err != nil {
+		t.Fatal(err)
+	}
+	if err := iDB.Flush(utils.EmptyString); err != nil { // logged as *clear
+		t.Fatal(err)
+	}
+	if err := iDB.SetAccountDrv(&Account{ID: "cgrates.org:1004"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the logs are rotated and compacted in the background
+	iDB.db.wal.compactions.Wait()
+	if _, err := os.Stat(filepath.Join(dumpPath, walRotatedFileName)); !os.IsNotExist(err) {
+		t.Errorf("expected the rotated log removed after compaction, received %v", err)
+	}
+	if fi, err := os.Stat(filepath.Join(dumpPath, walFileName)); err != nil {
+		t.Fatal(err)
+	} else if fi.Size() > iDB.db.wal.size {
+		t.Errorf("expected the changes since the last rotation only, received %d bytes", fi.Size())
+	}
+	iDB = newWALTestDB(t, dumpPath, true, 1)
+	defer iDB.Close()
+	if ids, err := iDB.GetKeysForPrefix(utils.AccountPrefix, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	} else if exp := []string{utils.AccountPrefix + "cgrates.org:1004"}; !reflect.DeepEqual(exp, ids) {
+		t.Errorf("expected %v, received %v", exp, ids)
+	}
+}
+
+func TestInternalWALRotatedReplay(t *testing.T) {
+	dumpPath := t.TempDir()
+	iDB := newWALTestDB(t, dumpPath, true, 1<<20)
+	if err := iDB.SetAccountDrv(&Account{ID: "cgrates.org:1001"}); err != nil {
+		t.Fatal(err)
+	}
+	// crash before the rotated log is compacted
+	if err := os.Rename(filepath.Join(dumpPath, walFileName),
+		filepath.Join(dumpPath, walRotatedFileName)); err != nil {
+		t.Fatal(err)
+	}
+	iDB = newWALTestDB(t, dumpPath, true, 1<<20)
+	defer iDB.Close()
+	if _, err := iDB.GetAccountDrv("cgrates.org:1001"); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(filepath.Join(dumpPath, walRotatedFileName)); !os.IsNotExist(err) {
+		t.Errorf("expected the rotated log removed after compaction, received %v", err)
+	}
+}
+
+func TestInternalWALWriteError(t *testing.T) {
+	dumpPath := t.TempDir()
+	iDB := newWALTestDB(t, dumpPath, true, 1<<20)
+	defer iDB.Close()
+	iDB.db.wal.file.Close() // the writes fail
+	if err := iDB.SetAccountDrv(&Account{ID: "cgrates.org:1001"}); err == nil {
+		t.Fatal("expected the write error reported")
+	}
+	if _, err := iDB.GetAccountDrv("cgrates.org:1001"); err != utils.ErrNotFound {
+		t.Errorf("expected the change not applied, received %v", err)
+	}
+	// the log is rotated before the next change
+	if err := iDB.SetAccountDrv(&Account{ID: "cgrates.org:1002"}); err != nil {
+		t.Fatal(err)
+	}
+	iDB.db.wal.compactions.Wait()
+	iDB = newWALTestDB(t, dumpPath, true, 1<<20)
+	if ids, err := iDB.GetKeysForPrefix(utils.AccountPrefix, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	} else if exp := []string{utils.AccountPrefix + "cgrates.org:1002"}; !reflect.DeepEqual(exp, ids) {
+		t.Errorf("expected %v, received %v", exp, ids)
+	}
+}
+
+func TestInternalWALDataSetEntries(t *testing.T) {
+	dumpPath := t.TempDir()
+	iDB := newWALTestDB(t, dumpPath, true, 1<<20)
+	ds := NewDataSet("cgrates.org", "BLOCKLIST")
+	ds.SetEntries([]*DataSetEntry{{Value: "1001"}, {Value: "1002"}, {Value: "+49", Prefix: true}})
+	if err := iDB.SetDataSetDrv(ds); err != nil {
+		t.Fatal(err)
+	}
+	if err := iDB.AddDataSetEntriesDrv("cgrates.org", "BLOCKLIST", []*DataSetEntry{
+		{Value: "1003", Metadata: map[string]string{"Reason": "fraud"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := iDB.RemoveDataSetEntriesDrv("cgrates.org", "BLOCKLIST", []*DataSetEntry{
+		{Value: "1001"}, {Value: "1009"}}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(filepath.Join(dumpPath, walFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logged []string
+	dec := gob.NewDecoder(f)
+	for {
+		var e walEntry
+		if err := dec.Decode(&e); err != nil {
+			break
+		}
+		logged = append(logged, e.Action+" "+e.ItemID)
+	}
+	f.Close()
+	// only the changed entries are logged, not the whole DataSet
+	exp := []string{
+		"*set cgrates.org:BLOCKLIST:*string:1003",
+		"*remove cgrates.org:BLOCKLIST:*string:1001",
+	}
+	if len(logged) != 5 || !reflect.DeepEqual(exp, logged[3:]) {
+		t.Errorf("expected the 3 entries set followed by %q, received %q", exp, logged)
+	}
+
+	// crash before the dump
+	iDB = newWALTestDB(t, dumpPath, true, 1<<20)
+	defer iDB.Close()
+	expDS := NewDataSet("cgrates.org", "BLOCKLIST")
+	expDS.SetEntries([]*DataSetEntry{{Value: "1002"}, {Value: "+49", Prefix: true},
+		{Value: "1003", Metadata: map[string]string{"Reason": "fraud"}}})
+	if rcv, err := iDB.GetDataSetDrv("cgrates.org", "BLOCKLIST"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(expDS, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expDS), utils.ToJSON(rcv))
+	}
+}
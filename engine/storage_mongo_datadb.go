@@ -28,6 +28,7 @@ import (
 	"maps"
 	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -73,6 +74,8 @@ const (
 	ColSqp  = "statqueue_profiles"
 	ColRgp  = "ranking_profiles"
 	ColRnk  = "rankings"
+	ColDts  = "data_sets"
+	ColTxp  = "tax_profiles"
 	ColTps  = "threshold_profiles"
 	ColThs  = "thresholds"
 	ColFlt  = "filters"
@@ -84,6 +87,9 @@ const (
 	ColDph  = "dispatcher_hosts"
 	ColLID  = "load_ids"
 	ColBkup = "sessions_backup"
+	ColInv  = "invoices"
+	ColAdt  = "audit_records"
+	ColGlk  = "guardian_locks"
 )
 
 var (
@@ -308,14 +314,18 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) error { // exported for
 	switch col {
 	case ColAct, ColApl, ColAAp, ColAtr, ColRpl, ColDst, ColRds, ColLht, ColIndx:
 		err = ms.enusureIndex(col, true, "key")
-	case ColRsP, ColRes, ColIPp, ColIPs, ColSqs, ColRgp, ColTrp, ColRnk, ColSqp, ColTps, ColThs, ColTrd, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColDph:
+	case ColRsP, ColRes, ColIPp, ColIPs, ColSqs, ColRgp, ColTrp, ColRnk, ColSqp, ColTps, ColThs, ColTrd, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColDph, ColInv, ColTxp:
 		err = ms.enusureIndex(col, true, "tenant", "id")
+	case ColDts: // one document per entry
+		err = ms.enusureIndex(col, true, "tenant", "id", "value", "prefix")
 	case ColRpf, ColShg, ColAcc:
 		err = ms.enusureIndex(col, true, "id")
+	case ColAdt:
+		err = ms.enusureIndex(col, false, "objecttype", "tenant", "id")
 		// StorDB
 	case utils.TBLTPTimings, utils.TBLTPDestinations,
 		utils.TBLTPDestinationRates, utils.TBLTPRatingPlans,
-		utils.TBLTPSharedGroups, utils.TBLTPActions, utils.TBLTPRankings,
+		utils.TBLTPSharedGroups, utils.TBLTPActions, utils.TBLTPRankings, utils.TBLTPTaxProfiles,
 		utils.TBLTPActionPlans, utils.TBLTPActionTriggers,
 		utils.TBLTPStats, utils.TBLTPResources, utils.TBLTPIPs,
 		utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts,
@@ -355,12 +365,13 @@ func (ms *MongoStorage) EnsureIndexes(cols ...string) error {
 				ColAct, ColApl, ColAAp, ColAtr, ColRpl, ColDst, ColRds, ColLht, ColIndx,
 				ColRsP, ColRes, ColIPs, ColSqs, ColSqp, ColTps, ColThs, ColRts, ColAttr,
 				ColFlt, ColCpp, ColDpp, ColRpf, ColShg, ColAcc, ColRgp, ColTrp, ColTrd, ColRnk,
+				ColInv, ColAdt, ColDts, ColTxp,
 			}
 		} else {
 			cols = []string{
 				utils.TBLTPTimings, utils.TBLTPDestinations, utils.TBLTPDestinationRates,
 				utils.TBLTPRatingPlans, utils.TBLTPSharedGroups, utils.TBLTPActions, utils.TBLTPActionPlans,
-				utils.TBLTPActionTriggers, utils.TBLTPRankings, utils.TBLTPStats, utils.TBLTPResources,
+				utils.TBLTPActionTriggers, utils.TBLTPRankings, utils.TBLTPTaxProfiles, utils.TBLTPStats, utils.TBLTPResources,
 				utils.TBLTPIPs, utils.TBLTPRatingProfiles, utils.CDRsTBL, utils.SessionCostsTBL,
 			}
 		}
@@ -453,6 +464,10 @@ func (ms *MongoStorage) RemoveKeysForPrefix(prefix string) error {
 		colName = ColTrd
 	case utils.RankingPrefix:
 		colName = ColRnk
+	case utils.DataSetPrefix:
+		colName = ColDts
+	case utils.TaxProfilePrefix:
+		colName = ColTxp
 	case utils.ThresholdPrefix:
 		colName = ColThs
 	case utils.FilterPrefix:
@@ -670,6 +685,13 @@ func (ms *MongoStorage) GetKeysForPrefix(prefix, search string) (keys []string,
 			keys, qryErr = ms.getAllKeysMatchingTenantID(sctx, ColTrd, utils.TrendPrefix, subject, search, tntID)
 		case utils.RankingPrefix:
 			keys, qryErr = ms.getAllKeysMatchingTenantID(sctx, ColRnk, utils.RankingPrefix, subject, search, tntID)
+		case utils.DataSetPrefix:
+			if keys, qryErr = ms.getAllKeysMatchingTenantID(sctx, ColDts, utils.DataSetPrefix, subject, search, tntID); qryErr == nil {
+				slices.Sort(keys) // one key per entry
+				keys = slices.Compact(keys)
+			}
+		case utils.TaxProfilePrefix:
+			keys, qryErr = ms.getAllKeysMatchingTenantID(sctx, ColTxp, utils.TaxProfilePrefix, subject, search, tntID)
 		case utils.FilterPrefix:
 			keys, qryErr = ms.getAllKeysMatchingTenantID(sctx, ColFlt, utils.FilterPrefix, subject, search, tntID)
 		case utils.ThresholdPrefix:
@@ -702,6 +724,8 @@ func (ms *MongoStorage) GetKeysForPrefix(prefix, search string) (keys []string,
 			keys, qryErr = ms.getAllIndexKeys(sctx, utils.ChargerFilterIndexes)
 		case utils.DispatcherFilterIndexes:
 			keys, qryErr = ms.getAllIndexKeys(sctx, utils.DispatcherFilterIndexes)
+		case utils.TaxProfileFilterIndexes:
+			keys, qryErr = ms.getAllIndexKeys(sctx, utils.TaxProfileFilterIndexes)
 		case utils.ActionPlanIndexes:
 			keys, qryErr = ms.getAllIndexKeys(sctx, utils.ActionPlanIndexes)
 		case utils.FilterIndexPrfx:
@@ -746,6 +770,10 @@ func (ms *MongoStorage) HasDataDrv(category, subject, tenant string) (has bool,
 			count, err = ms.getCol(ColRnk).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
 		case utils.RankingsProfilePrefix:
 			count, err = ms.getCol(ColSqp).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
+		case utils.DataSetPrefix:
+			count, err = ms.getCol(ColDts).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
+		case utils.TaxProfilePrefix:
+			count, err = ms.getCol(ColTxp).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
 		case utils.TrendPrefix:
 			count, err = ms.getCol(ColTrd).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
 		case utils.TrendsProfilePrefix:
@@ -1092,6 +1120,7 @@ func (ms *MongoStorage) SetAccountDrv(acc *Account) error {
 		if err == nil && !ac.allBalancesExpired() {
 			ac.ActionTriggers = acc.ActionTriggers
 			ac.UnitCounters = acc.UnitCounters
+			ac.UsageCounters = acc.UsageCounters
 			ac.AllowNegative = acc.AllowNegative
 			ac.Disabled = acc.Disabled
 			acc = ac
@@ -1694,6 +1723,147 @@ func (ms *MongoStorage) RemRankingProfileDrv(tenant, id string) (err error) {
 	})
 }
 
+func (ms *MongoStorage) GetTaxProfileDrv(tenant, id string) (*TaxProfile, error) {
+	tp := new(TaxProfile)
+	err := ms.query(func(sctx mongo.SessionContext) error {
+		sr := ms.getCol(ColTxp).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		decodeErr := sr.Decode(tp)
+		if errors.Is(decodeErr, mongo.ErrNoDocuments) {
+			return utils.ErrNotFound
+		}
+		return decodeErr
+	})
+	return tp, err
+}
+
+func (ms *MongoStorage) SetTaxProfileDrv(tp *TaxProfile) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) error {
+		_, err := ms.getCol(ColTxp).UpdateOne(sctx, bson.M{"tenant": tp.Tenant, "id": tp.ID},
+			bson.M{"$set": tp},
+			options.Update().SetUpsert(true))
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveTaxProfileDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) error {
+		dr, err := ms.getCol(ColTxp).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+// mongoDataSetEntry stores one entry of a DataSet per document
+type mongoDataSetEntry struct {
+	Tenant   string
+	ID       string
+	Value    string
+	Prefix   bool
+	Metadata map[string]string
+}
+
+func (ms *MongoStorage) GetDataSetDrv(tenant, id string) (ds *DataSet, err error) {
+	var ents []*DataSetEntry
+	if err = ms.query(func(sctx mongo.SessionContext) (qryErr error) {
+		cur, qryErr := ms.getCol(ColDts).Find(sctx, bson.M{"tenant": tenant, "id": id})
+		if qryErr != nil {
+			return
+		}
+		defer cur.Close(sctx)
+		for cur.Next(sctx) {
+			var ment mongoDataSetEntry
+			if qryErr = cur.Decode(&ment); qryErr != nil {
+				return
+			}
+			ents = append(ents, &DataSetEntry{Value: ment.Value, Prefix: ment.Prefix, Metadata: ment.Metadata})
+		}
+		return cur.Err()
+	}); err != nil {
+		return
+	}
+	if len(ents) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	ds = NewDataSet(tenant, id)
+	ds.SetEntries(ents)
+	return
+}
+
+// SetDataSetDrv replaces the entries of the DataSet
+func (ms *MongoStorage) SetDataSetDrv(ds *DataSet) (err error) {
+	models := []mongo.WriteModel{
+		mongo.NewDeleteManyModel().SetFilter(bson.M{"tenant": ds.Tenant, "id": ds.ID}),
+	}
+	models = append(models, dataSetEntryModels(ds.Tenant, ds.ID, ds.Entries())...)
+	return ms.query(func(sctx mongo.SessionContext) error {
+		_, err := ms.getCol(ColDts).BulkWrite(sctx, models)
+		return err
+	})
+}
+
+// AddDataSetEntriesDrv upserts one document for each of the entries
+func (ms *MongoStorage) AddDataSetEntriesDrv(tenant, id string, ents []*DataSetEntry) (err error) {
+	if len(ents) == 0 {
+		return
+	}
+	return ms.query(func(sctx mongo.SessionContext) error {
+		_, err := ms.getCol(ColDts).BulkWrite(sctx, dataSetEntryModels(tenant, id, ents))
+		return err
+	})
+}
+
+// RemoveDataSetEntriesDrv deletes the documents of the entries
+func (ms *MongoStorage) RemoveDataSetEntriesDrv(tenant, id string, ents []*DataSetEntry) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) error {
+		count, err := ms.getCol(ColDts).CountDocuments(sctx, bson.M{"tenant": tenant, "id": id},
+			options.Count().SetLimit(1))
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return utils.ErrNotFound
+		}
+		models := make([]mongo.WriteModel, len(ents))
+		for i, ent := range ents {
+			models[i] = mongo.NewDeleteOneModel().SetFilter(bson.M{"tenant": tenant, "id": id,
+				"value": ent.Value, "prefix": ent.Prefix})
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		_, err = ms.getCol(ColDts).BulkWrite(sctx, models)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveDataSetDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) error {
+		dr, err := ms.getCol(ColDts).DeleteMany(sctx, bson.M{"tenant": tenant, "id": id})
+		if err != nil {
+			return err
+		}
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return nil
+	})
+}
+
+// dataSetEntryModels returns the upserts of the DataSet entries
+func dataSetEntryModels(tenant, id string, ents []*DataSetEntry) []mongo.WriteModel {
+	models := make([]mongo.WriteModel, len(ents))
+	for i, ent := range ents {
+		models[i] = mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"tenant": tenant, "id": id, "value": ent.Value, "prefix": ent.Prefix}).
+			SetReplacement(&mongoDataSetEntry{Tenant: tenant, ID: id, Value: ent.Value,
+				Prefix: ent.Prefix, Metadata: ent.Metadata}).
+			SetUpsert(true)
+	}
+	return models
+}
+
 func (ms *MongoStorage) GetRankingDrv(tenant, id string) (*Ranking, error) {
 	rn := new(Ranking)
 	err := ms.query(func(sctx mongo.SessionContext) error {
@@ -2246,6 +2416,7 @@ func (ms *MongoStorage) RemoveIndexesDrv(idxItmType, tntCtx string, idxKeys ...s
 // used to "mold" the structure so that it appears in the first level of the mongo document and to make the conversion from mongo back to cgrates simpler
 type mongoStoredSession struct {
 	NodeID        string
+	Version       int
 	CGRID         string
 	Tenant        string
 	IPAllocID     string
@@ -2273,6 +2444,7 @@ func (ms *MongoStorage) SetBackupSessionsDrv(nodeID, tnt string, storedSessions
 			for _, sess := range batch {
 				doc := bson.M{"$set": mongoStoredSession{
 					NodeID:        nodeID,
+					Version:       sess.Version,
 					CGRID:         sess.CGRID,
 					Tenant:        sess.Tenant,
 					IPAllocID:     sess.IPAllocID,
@@ -2322,6 +2494,7 @@ func (ms *MongoStorage) GetSessionsBackupDrv(nodeID, tnt string) ([]*StoredSessi
 				return qryErr
 			}
 			oneStSession := &StoredSession{
+				Version:       result.Version,
 				CGRID:         result.CGRID,
 				Tenant:        result.Tenant,
 				IPAllocID:     result.IPAllocID,
@@ -2360,6 +2533,79 @@ func (ms *MongoStorage) RemoveSessionsBackupDrv(nodeID, tnt, cgrid string) error
 	})
 }
 
+// GetInvoiceDrv returns an issued invoice
+func (ms *MongoStorage) GetInvoiceDrv(tnt, id string) (*Invoice, error) {
+	inv := new(Invoice)
+	err := ms.query(func(sctx mongo.SessionContext) error {
+		sr := ms.getCol(ColInv).FindOne(sctx, bson.M{"tenant": tnt, "id": id})
+		decodeErr := sr.Decode(inv)
+		if errors.Is(decodeErr, mongo.ErrNoDocuments) {
+			return utils.ErrNotFound
+		}
+		return decodeErr
+	})
+	return inv, err
+}
+
+// GetInvoicesDrv returns the invoices of the tenant, all of them if the account is empty
+func (ms *MongoStorage) GetInvoicesDrv(tnt, acnt string) (invs []*Invoice, err error) {
+	fltr := bson.M{"tenant": tnt}
+	if acnt != utils.EmptyString {
+		fltr["account"] = acnt
+	}
+	err = ms.query(func(sctx mongo.SessionContext) error {
+		cur, qryErr := ms.getCol(ColInv).Find(sctx, fltr)
+		if qryErr != nil {
+			return qryErr
+		}
+		return cur.All(sctx, &invs)
+	})
+	return
+}
+
+// CountInvoicesDrv returns the number of invoices issued for the tenant
+func (ms *MongoStorage) CountInvoicesDrv(tnt string) (n int, err error) {
+	err = ms.query(func(sctx mongo.SessionContext) error {
+		cnt, qryErr := ms.getCol(ColInv).CountDocuments(sctx, bson.M{"tenant": tnt})
+		n = int(cnt)
+		return qryErr
+	})
+	return
+}
+
+// SetInvoiceDrv stores the invoice, refusing to overwrite an existing one
+func (ms *MongoStorage) SetInvoiceDrv(inv *Invoice) error {
+	return ms.query(func(sctx mongo.SessionContext) error {
+		_, err := ms.getCol(ColInv).InsertOne(sctx, inv)
+		if mongo.IsDuplicateKeyError(err) {
+			return utils.ErrExists
+		}
+		return err
+	})
+}
+
+// GetAuditRecordsDrv returns the audit trail of the profile
+func (ms *MongoStorage) GetAuditRecordsDrv(objType, tnt, id string) (recs []*AuditRecord, err error) {
+	err = ms.query(func(sctx mongo.SessionContext) error {
+		cur, qryErr := ms.getCol(ColAdt).Find(sctx,
+			bson.M{"objecttype": objType, "tenant": tnt, "id": id},
+			options.Find().SetSort(bson.D{{Key: "time", Value: 1}}))
+		if qryErr != nil {
+			return qryErr
+		}
+		return cur.All(sctx, &recs)
+	})
+	return
+}
+
+// SetAuditRecordDrv appends the record to the audit trail of the profile
+func (ms *MongoStorage) SetAuditRecordDrv(rec *AuditRecord) error {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColAdt).InsertOne(sctx, rec)
+		return
+	})
+}
+
 // DumpDataDB will dump all of datadb from memory to a file, only for InternalDB
 func (ms *MongoStorage) DumpDataDB() error {
 	return utils.ErrNotImplemented
@@ -2374,3 +2620,56 @@ func (ms *MongoStorage) RewriteDataDB() (err error) {
 func (ms *MongoStorage) BackupDataDB(backupFolderPath string, zip bool) (err error) {
 	return utils.ErrNotImplemented
 }
+
+// TryLock attempts to acquire the lease on lkID, relying on the unique _id to fail the upsert
+// while the lease is held by someone else. The token is incremented with each acquisition
+// and kept after unlock, so an expired holder cannot release the lease acquired after it.
+func (ms *MongoStorage) TryLock(lkID string, ttl time.Duration) (token int64, acquired bool, err error) {
+	now := time.Now()
+	err = ms.query(func(sctx mongo.SessionContext) error {
+		var lk struct {
+			Token int64
+		}
+		err := ms.getCol(ColGlk).FindOneAndUpdate(sctx,
+			bson.M{"_id": lkID, "expiresat": bson.M{"$lte": now}},
+			bson.M{"$inc": bson.M{"token": 1}, "$set": bson.M{"expiresat": now.Add(ttl)}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&lk)
+		if mongo.IsDuplicateKeyError(err) { // lease held by someone else
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		token, acquired = lk.Token, true
+		return nil
+	})
+	return
+}
+
+// RefreshLock extends the lease on lkID with ttl, if it is still held with token
+func (ms *MongoStorage) RefreshLock(lkID string, token int64, ttl time.Duration) (refreshed bool, err error) {
+	err = ms.query(func(sctx mongo.SessionContext) error {
+		rslt, err := ms.getCol(ColGlk).UpdateOne(sctx,
+			bson.M{"_id": lkID, "token": token},
+			bson.M{"$set": bson.M{"expiresat": time.Now().Add(ttl)}},
+		)
+		if err != nil {
+			return err
+		}
+		refreshed = rslt.MatchedCount == 1
+		return nil
+	})
+	return
+}
+
+// Unlock releases the lease on lkID, if it is still held with token
+func (ms *MongoStorage) Unlock(lkID string, token int64) error {
+	return ms.query(func(sctx mongo.SessionContext) error {
+		_, err := ms.getCol(ColGlk).UpdateOne(sctx,
+			bson.M{"_id": lkID, "token": token},
+			bson.M{"$set": bson.M{"expiresat": time.Time{}}},
+		)
+		return err
+	})
+}
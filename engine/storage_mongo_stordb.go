@@ -535,6 +535,38 @@ func (ms *MongoStorage) GetTPRankings(tpid string, tenant string, id string) ([]
 	return results, err
 }
 
+func (ms *MongoStorage) GetTPTaxProfiles(tpid string, tenant string, id string) ([]*utils.TPTaxProfile, error) {
+	filter := bson.M{
+		"tpid": tpid,
+	}
+	if id != "" {
+		filter["id"] = id
+	}
+	if tenant != "" {
+		filter["tenant"] = tenant
+	}
+	var results []*utils.TPTaxProfile
+	err := ms.query(func(sctx mongo.SessionContext) error {
+		cur, err := ms.getCol(utils.TBLTPTaxProfiles).Find(sctx, filter)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var el utils.TPTaxProfile
+			err := cur.Decode(&el)
+			if err != nil {
+				return err
+			}
+			results = append(results, &el)
+		}
+		if len(results) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	return results, err
+}
+
 func (ms *MongoStorage) GetTPActions(tpid, id string) ([]*utils.TPActions, error) {
 	filter := bson.M{"tpid": tpid}
 	if id != "" {
@@ -1382,6 +1414,22 @@ func (ms *MongoStorage) SetTPRankings(tpRankings []*utils.TPRankingProfile) (err
 	})
 }
 
+func (ms *MongoStorage) SetTPTaxProfiles(tpTaxProfiles []*utils.TPTaxProfile) (err error) {
+	if len(tpTaxProfiles) == 0 {
+		return
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		for _, tp := range tpTaxProfiles {
+			_, err := ms.getCol(utils.TBLTPTaxProfiles).UpdateOne(sctx, bson.M{"tpid": tp.TPid, "id": tp.ID},
+				bson.M{"$set": tp}, options.Update().SetUpsert(true))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (ms *MongoStorage) GetTPThresholds(tpid, tenant, id string) ([]*utils.TPThresholdProfile, error) {
 	filter := bson.M{"tpid": tpid}
 	if id != "" {
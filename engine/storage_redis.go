@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -68,12 +69,28 @@ const (
 	redis_RENAME   = "RENAME"
 	redis_HMSET    = "HMSET"
 	redis_HSET     = "HSET"
+	redis_HSETNX   = "HSETNX"
+	redis_HLEN     = "HLEN"
 	redis_SCAN     = "SCAN"
+	redis_INCR     = "INCR"
 
 	redisLoadError = "Redis is loading the dataset in memory"
 	RedisLimit     = 524287 // https://github.com/StackExchange/StackExchange.Redis/issues/201#issuecomment-98639005
 )
 
+var (
+	// redisRefreshLock extends the lease only if it is still held with the token received as argument
+	redisRefreshLock = radix.NewEvalScript(1, `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+	// redisUnlock removes the lease only if it is still held with the token received as argument
+	redisUnlock = radix.NewEvalScript(1, `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
 func NewRedisStorage(address string, db int, user, pass, mrshlerStr string,
 	maxConns, attempts int, sentinelName string, isCluster bool, clusterSync,
 	clusterOnDownDelay, connTimeout, readTimeout, writeTimeout,
@@ -355,7 +372,8 @@ func (rs *RedisStorage) HasDataDrv(category, subject, tenant string) (exists boo
 		utils.IPProfilesPrefix, utils.StatQueuePrefix, utils.StatQueueProfilePrefix,
 		utils.ThresholdPrefix, utils.ThresholdProfilePrefix, utils.FilterPrefix,
 		utils.RouteProfilePrefix, utils.AttributeProfilePrefix, utils.ChargerProfilePrefix,
-		utils.DispatcherProfilePrefix, utils.DispatcherHostPrefix:
+		utils.DispatcherProfilePrefix, utils.DispatcherHostPrefix, utils.DataSetPrefix,
+		utils.TaxProfilePrefix:
 		err := rs.Cmd(&i, redis_EXISTS, category+utils.ConcatenatedKey(tenant, subject))
 		return i == 1, err
 	}
@@ -582,6 +600,7 @@ func (rs *RedisStorage) SetAccountDrv(acc *Account) (err error) {
 		if ac, err = rs.GetAccountDrv(acc.ID); err == nil && !ac.allBalancesExpired() {
 			ac.ActionTriggers = acc.ActionTriggers
 			ac.UnitCounters = acc.UnitCounters
+			ac.UsageCounters = acc.UsageCounters
 			ac.AllowNegative = acc.AllowNegative
 			ac.Disabled = acc.Disabled
 			acc = ac
@@ -1120,6 +1139,119 @@ func (rs *RedisStorage) RemRankingProfileDrv(tenant string, id string) (err erro
 	return rs.Cmd(nil, redis_DEL, utils.RankingsProfilePrefix+utils.ConcatenatedKey(tenant, id))
 }
 
+func (rs *RedisStorage) SetTaxProfileDrv(tp *TaxProfile) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(tp); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.TaxProfilePrefix+tp.TenantID(), string(result))
+}
+
+func (rs *RedisStorage) GetTaxProfileDrv(tenant, id string) (tp *TaxProfile, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.TaxProfilePrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &tp)
+	return
+}
+
+func (rs *RedisStorage) RemoveTaxProfileDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.TaxProfilePrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+// GetDataSetDrv reads the DataSet kept as hash, one field per entry holding its metadata
+func (rs *RedisStorage) GetDataSetDrv(tenant, id string) (ds *DataSet, err error) {
+	var mp map[string]string
+	if err = rs.Cmd(&mp, redis_HGETALL, utils.DataSetPrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(mp) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	ents := make([]*DataSetEntry, 0, len(mp))
+	for key, val := range mp {
+		var md map[string]string
+		if err = rs.ms.Unmarshal([]byte(val), &md); err != nil {
+			return
+		}
+		var ent *DataSetEntry
+		if ent, err = newDataSetEntry(key, md); err != nil {
+			return
+		}
+		ents = append(ents, ent)
+	}
+	ds = NewDataSet(tenant, id)
+	ds.SetEntries(ents)
+	return
+}
+
+// SetDataSetDrv replaces the entries of the DataSet, the new hash being renamed over the old one
+func (rs *RedisStorage) SetDataSetDrv(ds *DataSet) (err error) {
+	dbKey := utils.DataSetPrefix + ds.TenantID()
+	ents := ds.Entries()
+	if len(ents) == 0 {
+		return rs.Cmd(nil, redis_DEL, dbKey)
+	}
+	tmpKey := "tmp_" + utils.ConcatenatedKey(dbKey, utils.GenUUID())
+	if err = rs.setDataSetEntries(tmpKey, ents); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_RENAME, tmpKey, dbKey)
+}
+
+// AddDataSetEntriesDrv sets the entries in the DataSet hash, creating it if missing
+func (rs *RedisStorage) AddDataSetEntriesDrv(tenant, id string, ents []*DataSetEntry) (err error) {
+	return rs.setDataSetEntries(utils.DataSetPrefix+utils.ConcatenatedKey(tenant, id), ents)
+}
+
+// setDataSetEntries sets the entries as fields of the hash, within RedisLimit arguments per command
+func (rs *RedisStorage) setDataSetEntries(dbKey string, ents []*DataSetEntry) (err error) {
+	for chunk := range slices.Chunk(ents, RedisLimit/2) {
+		args := make([]string, 1, 2*len(chunk)+1)
+		args[0] = dbKey
+		for _, ent := range chunk {
+			var md []byte
+			if md, err = rs.ms.Marshal(ent.Metadata); err != nil {
+				return
+			}
+			args = append(args, dataSetEntryKey(ent), string(md))
+		}
+		if err = rs.Cmd(nil, redis_HSET, args...); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// RemoveDataSetEntriesDrv removes the entries from the DataSet hash
+func (rs *RedisStorage) RemoveDataSetEntriesDrv(tenant, id string, ents []*DataSetEntry) (err error) {
+	dbKey := utils.DataSetPrefix + utils.ConcatenatedKey(tenant, id)
+	var i int
+	if err = rs.Cmd(&i, redis_EXISTS, dbKey); err != nil {
+		return
+	} else if i == 0 {
+		return utils.ErrNotFound
+	}
+	for chunk := range slices.Chunk(ents, RedisLimit) {
+		args := make([]string, 1, len(chunk)+1)
+		args[0] = dbKey
+		for _, ent := range chunk {
+			args = append(args, dataSetEntryKey(ent))
+		}
+		if err = rs.Cmd(nil, redis_HDEL, args...); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (rs *RedisStorage) RemoveDataSetDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.DataSetPrefix+utils.ConcatenatedKey(tenant, id))
+}
+
 func (rs *RedisStorage) GetRankingDrv(tenant, id string) (rn *Ranking, err error) {
 	var values []byte
 	if err = rs.Cmd(&values, redis_GET, utils.RankingPrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
@@ -1531,6 +1663,84 @@ func (rs *RedisStorage) RemoveSessionsBackupDrv(nodeID, tnt, cgrid string) error
 	return rs.Cmd(nil, redis_HDEL, utils.SessionsBackupPrefix+utils.ConcatenatedKey(tnt, nodeID), cgrid)
 }
 
+// GetInvoiceDrv returns the invoice out of the tenant invoices hash
+func (rs *RedisStorage) GetInvoiceDrv(tnt, id string) (inv *Invoice, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_HGET, utils.InvoicePrefix+tnt, id); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &inv)
+	return
+}
+
+// GetInvoicesDrv returns the invoices of the tenant, all of them if the account is empty
+func (rs *RedisStorage) GetInvoicesDrv(tnt, acnt string) (invs []*Invoice, err error) {
+	mp := make(map[string]string)
+	if err = rs.Cmd(&mp, redis_HGETALL, utils.InvoicePrefix+tnt); err != nil {
+		return
+	}
+	for _, v := range mp {
+		var inv *Invoice
+		if err = rs.ms.Unmarshal([]byte(v), &inv); err != nil {
+			return
+		}
+		if acnt == utils.EmptyString || inv.Account == acnt {
+			invs = append(invs, inv)
+		}
+	}
+	return
+}
+
+// CountInvoicesDrv returns the number of invoices issued for the tenant
+func (rs *RedisStorage) CountInvoicesDrv(tnt string) (n int, err error) {
+	err = rs.Cmd(&n, redis_HLEN, utils.InvoicePrefix+tnt)
+	return
+}
+
+// SetInvoiceDrv stores the invoice, refusing to overwrite an existing one
+func (rs *RedisStorage) SetInvoiceDrv(inv *Invoice) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(inv); err != nil {
+		return
+	}
+	var set int
+	if err = rs.Cmd(&set, redis_HSETNX, utils.InvoicePrefix+inv.Tenant, inv.ID, string(result)); err != nil {
+		return
+	}
+	if set == 0 {
+		err = utils.ErrExists
+	}
+	return
+}
+
+// GetAuditRecordsDrv returns the audit trail of the profile out of its list
+func (rs *RedisStorage) GetAuditRecordsDrv(objType, tnt, id string) (recs []*AuditRecord, err error) {
+	var values [][]byte
+	if err = rs.Cmd(&values, redis_LRANGE,
+		utils.AuditRecordPrefix+utils.ConcatenatedKey(objType, tnt, id), "0", "-1"); err != nil {
+		return
+	}
+	recs = make([]*AuditRecord, len(values))
+	for i, val := range values {
+		if err = rs.ms.Unmarshal(val, &recs[i]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// SetAuditRecordDrv appends the record to the audit trail of the profile
+func (rs *RedisStorage) SetAuditRecordDrv(rec *AuditRecord) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(rec); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_RPUSH, utils.AuditRecordPrefix+rec.ObjectKey(), string(result))
+}
+
 // DumpDataDB will dump all of datadb from memory to a file, only for InternalDB
 func (rs *RedisStorage) DumpDataDB() error {
 	return utils.ErrNotImplemented
@@ -1545,3 +1755,31 @@ func (rs *RedisStorage) RewriteDataDB() (err error) {
 func (rs *RedisStorage) BackupDataDB(backupFolderPath string, zip bool) (err error) {
 	return utils.ErrNotImplemented
 }
+
+// TryLock attempts to acquire the lease on lkID with SET NX PX, using a counter
+// incremented on each attempt as lease token
+func (rs *RedisStorage) TryLock(lkID string, ttl time.Duration) (token int64, acquired bool, err error) {
+	if err = rs.Cmd(&token, redis_INCR, utils.GuardianFenceKey); err != nil {
+		return
+	}
+	var reply string
+	if err = rs.Cmd(&reply, redis_SET, utils.GuardianLockPrefix+lkID, strconv.FormatInt(token, 10),
+		"NX", "PX", strconv.FormatInt(ttl.Milliseconds(), 10)); err != nil {
+		return
+	}
+	return token, reply == "OK", nil
+}
+
+// RefreshLock extends the lease on lkID with ttl, if it is still held with token
+func (rs *RedisStorage) RefreshLock(lkID string, token int64, ttl time.Duration) (refreshed bool, err error) {
+	var reply int
+	err = rs.client.Do(redisRefreshLock.Cmd(&reply, utils.GuardianLockPrefix+lkID,
+		strconv.FormatInt(token, 10), strconv.FormatInt(ttl.Milliseconds(), 10)))
+	return reply == 1, err
+}
+
+// Unlock releases the lease on lkID, if it is still held with token
+func (rs *RedisStorage) Unlock(lkID string, token int64) error {
+	return rs.client.Do(redisUnlock.Cmd(nil, utils.GuardianLockPrefix+lkID,
+		strconv.FormatInt(token, 10)))
+}
@@ -122,10 +122,10 @@ func (sqls *SQLStorage) IsDBEmpty() (resp bool, err error) {
 func (sqls *SQLStorage) GetTpIds(colName string) ([]string, error) {
 	var rows *sql.Rows
 	var err error
-	qryStr := fmt.Sprintf(" (SELECT tpid FROM %s)", colName)
+	qryStr := fmt.Sprintf("SELECT tpid FROM %s", colName)
 	if colName == "" {
 		qryStr = fmt.Sprintf(
-			"(SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s)",
+			"SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s",
 			utils.TBLTPTimings,
 			utils.TBLTPDestinations,
 			utils.TBLTPRates,
@@ -139,6 +139,7 @@ func (sqls *SQLStorage) GetTpIds(colName string) ([]string, error) {
 			utils.TBLTPResources,
 			utils.TBLTPStats,
 			utils.TBLTPRankings,
+			utils.TBLTPTaxProfiles,
 			utils.TBLTPThresholds,
 			utils.TBLTPFilters,
 			utils.TBLTPActionPlans,
@@ -241,7 +242,7 @@ func (sqls *SQLStorage) RemTpData(table, tpid string, args map[string]string) er
 		for _, tblName := range []string{utils.TBLTPTimings, utils.TBLTPDestinations, utils.TBLTPRates,
 			utils.TBLTPDestinationRates, utils.TBLTPRatingPlans, utils.TBLTPRatingProfiles,
 			utils.TBLTPSharedGroups, utils.TBLTPActions, utils.TBLTPActionTriggers, utils.TBLTPRankings,
-			utils.TBLTPAccountActions, utils.TBLTPResources, utils.TBLTPStats, utils.TBLTPThresholds,
+			utils.TBLTPTaxProfiles, utils.TBLTPAccountActions, utils.TBLTPResources, utils.TBLTPStats, utils.TBLTPThresholds,
 			utils.TBLTPFilters, utils.TBLTPActionPlans, utils.TBLTPRoutes, utils.TBLTPAttributes,
 			utils.TBLTPChargers, utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts} {
 			if err := tx.Table(tblName).Where("tpid = ?", tpid).Delete(nil).Error; err != nil {
@@ -621,6 +622,27 @@ func (sqls *SQLStorage) SetTPRankings(rgs []*utils.TPRankingProfile) error {
 	return nil
 }
 
+func (sqls *SQLStorage) SetTPTaxProfiles(tps []*utils.TPTaxProfile) error {
+	if len(tps) == 0 {
+		return nil
+	}
+	tx := sqls.db.Begin()
+	for _, tp := range tps {
+		if err := tx.Where(&TaxProfileMdl{Tpid: tp.TPid, Tenant: tp.Tenant, ID: tp.ID}).Delete(TaxProfileMdl{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		for _, mdl := range APItoModelTPTaxProfile(tp) {
+			if err := tx.Create(&mdl).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
 func (sqls *SQLStorage) SetTPTrends(trs []*utils.TPTrendsProfile) error {
 	if len(trs) == 0 {
 		return nil
@@ -949,7 +971,8 @@ func (sqls *SQLStorage) SetCDR(cdr *CDR, allowUpdate bool) error {
 	if saved.Error != nil {
 		tx.Rollback()
 		if !allowUpdate {
-			if strings.Contains(saved.Error.Error(), "1062") || strings.Contains(saved.Error.Error(), "duplicate key") { // returns 1062/pq when key is duplicated
+			if strings.Contains(saved.Error.Error(), "1062") || strings.Contains(saved.Error.Error(), "duplicate key") ||
+				strings.Contains(saved.Error.Error(), "UNIQUE constraint failed") { // returns 1062/pq/sqlite when key is duplicated
 				return utils.ErrExists
 			}
 			return saved.Error
@@ -1544,6 +1567,25 @@ func (sqls *SQLStorage) GetTPRankings(tpid string, tenant string, id string) ([]
 	return asgs, nil
 }
 
+func (sqls *SQLStorage) GetTPTaxProfiles(tpid, tenant, id string) ([]*utils.TPTaxProfile, error) {
+	var tps TaxProfileMdls
+	q := sqls.db.Where("tpid = ?", tpid)
+	if len(id) != 0 {
+		q = q.Where("id = ?", id)
+	}
+	if len(tenant) != 0 {
+		q = q.Where("tenant = ?", tenant)
+	}
+	if err := q.Order("pk").Find(&tps).Error; err != nil { // the order of the rows gives the order of the rates
+		return nil, err
+	}
+	atps := tps.AsTPTaxProfile()
+	if len(atps) == 0 {
+		return atps, utils.ErrNotFound
+	}
+	return atps, nil
+}
+
 func (sqls *SQLStorage) GetTPThresholds(tpid, tenant, id string) ([]*utils.TPThresholdProfile, error) {
 	var ths ThresholdMdls
 	q := sqls.db.Where("tpid = ?", tpid)
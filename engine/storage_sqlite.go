@@ -0,0 +1,124 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// sqliteDSNPragmas are appended to the database path so the concurrent
+// writers wait for the lock instead of failing with SQLITE_BUSY
+const sqliteDSNPragmas = "_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+
+// SQLiteDSN returns the connection string for the sqlite database found at path
+func SQLiteDSN(path string) string {
+	sep := "?"
+	if strings.Contains(path, sep) {
+		sep = "&"
+	}
+	return path + sep + sqliteDSNPragmas
+}
+
+// NewSQLiteStorage returns the sqlite storDB kept in the file found at path
+func NewSQLiteStorage(path, mrshlerStr string,
+	maxConn, maxIdleConn, logLevel int, connMaxLifetime time.Duration) (*SQLStorage, error) {
+	if path == utils.EmptyString {
+		return nil, fmt.Errorf("missing the path of the %s database", utils.MetaSQLite)
+	}
+	ms, err := NewMarshaler(mrshlerStr)
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(sqlite.Open(SQLiteDSN(path)), &gorm.Config{AllowGlobalUpdate: true, Logger: logger.Default.LogMode(logger.LogLevel(logLevel))})
+	if err != nil {
+		return nil, err
+	}
+	sqliteStor := new(SQLiteStorage)
+	if sqliteStor.Db, err = db.DB(); err != nil {
+		return nil, err
+	}
+	if err = sqliteStor.Db.Ping(); err != nil {
+		return nil, err
+	}
+	sqliteStor.Db.SetMaxIdleConns(maxIdleConn)
+	sqliteStor.Db.SetMaxOpenConns(maxConn)
+	sqliteStor.Db.SetConnMaxLifetime(connMaxLifetime)
+	sqliteStor.db = db
+	return &SQLStorage{
+		Db:      sqliteStor.Db,
+		db:      sqliteStor.db,
+		StorDB:  sqliteStor,
+		SQLImpl: sqliteStor,
+		ms:      ms,
+	}, nil
+}
+
+// SQLiteStorage is the embedded storDB, sharing the queries with the other SQL storages
+type SQLiteStorage struct {
+	SQLStorage
+}
+
+func (sqliteS *SQLiteStorage) SetVersions(vrs Versions, overwrite bool) (err error) {
+	tx := sqliteS.db.Begin()
+	if overwrite {
+		tx.Table(utils.TBLVersions).Delete(nil)
+	}
+	for key, val := range vrs {
+		vrModel := &TBLVersion{Item: key, Version: val}
+		if !overwrite {
+			if err = tx.Model(&TBLVersion{}).Where(
+				TBLVersion{Item: vrModel.Item}).Delete(TBLVersion{Version: val}).Error; err != nil {
+				tx.Rollback()
+				return
+			}
+		}
+		if err = tx.Save(vrModel).Error; err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+	tx.Commit()
+	return
+}
+
+func (sqliteS *SQLiteStorage) extraFieldsExistsQry(field string) string {
+	return fmt.Sprintf(" json_type(extra_fields, '$.\"%s\"') IS NOT NULL", field)
+}
+
+func (sqliteS *SQLiteStorage) extraFieldsValueQry(field, value string) string {
+	return fmt.Sprintf(" json_extract(extra_fields, '$.\"%s\"') = '%s'", field, value)
+}
+
+func (sqliteS *SQLiteStorage) notExtraFieldsExistsQry(field string) string {
+	return fmt.Sprintf(" json_type(extra_fields, '$.\"%s\"') IS NULL", field)
+}
+
+func (sqliteS *SQLiteStorage) notExtraFieldsValueQry(field, value string) string {
+	return fmt.Sprintf(" NOT (json_type(extra_fields, '$.\"%s\"') IS NOT NULL AND json_extract(extra_fields, '$.\"%s\"') = '%s')", field, field, value)
+}
+
+func (sqliteS *SQLiteStorage) GetStorageType() string {
+	return utils.MetaSQLite
+}
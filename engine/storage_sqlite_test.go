@@ -0,0 +1,263 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newTestSQLiteStorage(t *testing.T) *SQLStorage {
+	t.Helper()
+	sqliteDB, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "cgrates.db"),
+		utils.MsgPack, 10, 2, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(sqliteDB.Close)
+	if err = sqliteDB.Flush(filepath.Join("..", "data", "storage", "sqlite")); err != nil {
+		t.Fatal(err)
+	}
+	return sqliteDB
+}
+
+func TestSQLiteStorageMissingPath(t *testing.T) {
+	if _, err := NewSQLiteStorage(utils.EmptyString, utils.MsgPack,
+		10, 2, 1, 0); err == nil {
+		t.Error("expected error for missing path")
+	}
+}
+
+func TestSQLiteStorageType(t *testing.T) {
+	sqliteDB := newTestSQLiteStorage(t)
+	if rcv := sqliteDB.GetStorageType(); rcv != utils.MetaSQLite {
+		t.Errorf("expected %q, received %q", utils.MetaSQLite, rcv)
+	}
+	if empty, err := sqliteDB.IsDBEmpty(); err != nil {
+		t.Fatal(err)
+	} else if empty {
+		t.Error("expected the tables to be created")
+	}
+}
+
+func TestSQLiteStorageCDRs(t *testing.T) {
+	sqliteDB := newTestSQLiteStorage(t)
+	setupTime := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	answerTime := setupTime.Add(5 * time.Second)
+	cdr1 := &CDR{
+		CGRID:       "cgrid1",
+		RunID:       utils.MetaDefault,
+		OriginHost:  "127.0.0.1",
+		Source:      "test",
+		OriginID:    "orig1",
+		ToR:         utils.MetaVoice,
+		RequestType: utils.MetaPrepaid,
+		Tenant:      "cgrates.org",
+		Category:    "call",
+		Account:     "1001",
+		Subject:     "1001",
+		Destination: "1002",
+		SetupTime:   setupTime,
+		AnswerTime:  answerTime,
+		Usage:       time.Minute,
+		ExtraFields: map[string]string{"Service": "premium"},
+		Cost:        1.5,
+	}
+	cdr2 := cdr1.Clone()
+	cdr2.CGRID = "cgrid2"
+	cdr2.OriginID = "orig2"
+	cdr2.Account = "1002"
+	cdr2.ExtraFields = map[string]string{"Service": "basic", "Route": "r1"}
+	for _, cdr := range []*CDR{cdr1, cdr2} {
+		if err := sqliteDB.SetCDR(cdr, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sqliteDB.SetCDR(cdr1, false); err != utils.ErrExists {
+		t.Errorf("expected %v, received %v", utils.ErrExists, err)
+	}
+	cdr1.Cost = 2
+	if err := sqliteDB.SetCDR(cdr1, true); err != nil {
+		t.Fatal(err)
+	}
+
+	cdrs, _, err := sqliteDB.GetCDRs(&utils.CDRsFilter{CGRIDs: []string{"cgrid1"}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cdrs) != 1 {
+		t.Fatalf("expected 1 CDR, received %s", utils.ToJSON(cdrs))
+	}
+	if cdrs[0].Cost != 2 ||
+		!cdrs[0].SetupTime.Equal(setupTime) ||
+		!cdrs[0].AnswerTime.Equal(answerTime) ||
+		cdrs[0].Usage != time.Minute ||
+		!reflect.DeepEqual(cdrs[0].ExtraFields, cdr1.ExtraFields) {
+		t.Errorf("unexpected CDR: %s", utils.ToJSON(cdrs[0]))
+	}
+
+	for _, tc := range []struct {
+		name   string
+		fltr   *utils.CDRsFilter
+		cgrIDs []string
+	}{
+		{
+			name:   "ExtraFieldValue",
+			fltr:   &utils.CDRsFilter{ExtraFields: map[string]string{"Service": "basic"}},
+			cgrIDs: []string{"cgrid2"},
+		},
+		{
+			name:   "ExtraFieldExists",
+			fltr:   &utils.CDRsFilter{ExtraFields: map[string]string{"Route": utils.MetaExists}},
+			cgrIDs: []string{"cgrid2"},
+		},
+		{
+			name:   "NotExtraFieldValue",
+			fltr:   &utils.CDRsFilter{NotExtraFields: map[string]string{"Service": "basic"}},
+			cgrIDs: []string{"cgrid1"},
+		},
+		{
+			name:   "NotExtraFieldExists",
+			fltr:   &utils.CDRsFilter{NotExtraFields: map[string]string{"Route": utils.MetaExists}},
+			cgrIDs: []string{"cgrid1"},
+		},
+		{
+			name:   "Usage",
+			fltr:   &utils.CDRsFilter{MinUsage: "30s", Accounts: []string{"1002"}},
+			cgrIDs: []string{"cgrid2"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cdrs, _, err := sqliteDB.GetCDRs(tc.fltr, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var cgrIDs []string
+			for _, cdr := range cdrs {
+				cgrIDs = append(cgrIDs, cdr.CGRID)
+			}
+			if !reflect.DeepEqual(cgrIDs, tc.cgrIDs) {
+				t.Errorf("expected %v, received %v", tc.cgrIDs, cgrIDs)
+			}
+		})
+	}
+
+	if _, cnt, err := sqliteDB.GetCDRs(&utils.CDRsFilter{Count: true}, false); err != nil {
+		t.Fatal(err)
+	} else if cnt != 2 {
+		t.Errorf("expected 2 CDRs, received %d", cnt)
+	}
+	if _, _, err := sqliteDB.GetCDRs(&utils.CDRsFilter{CGRIDs: []string{"cgrid1"}}, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := sqliteDB.GetCDRs(&utils.CDRsFilter{CGRIDs: []string{"cgrid1"}}, false); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestSQLiteStorageSMCosts(t *testing.T) {
+	sqliteDB := newTestSQLiteStorage(t)
+	smc := &SMCost{
+		CGRID:      "cgrid1",
+		RunID:      utils.MetaDefault,
+		OriginHost: "127.0.0.1",
+		OriginID:   "orig1",
+		CostSource: utils.MetaSessionS,
+		Usage:      time.Minute,
+		CostDetails: &EventCost{
+			CGRID: "cgrid1",
+			RunID: utils.MetaDefault,
+		},
+	}
+	if err := sqliteDB.SetSMCost(smc); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := sqliteDB.GetSMCosts("cgrid1", utils.MetaDefault, "127.0.0.1", "orig"); err != nil {
+		t.Fatal(err)
+	} else if len(rcv) != 1 || rcv[0].Usage != smc.Usage ||
+		rcv[0].CostDetails.CGRID != smc.CGRID {
+		t.Errorf("unexpected SMCosts: %s", utils.ToJSON(rcv))
+	}
+	if err := sqliteDB.RemoveSMCost(smc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sqliteDB.GetSMCosts("cgrid1", utils.MetaDefault, "", ""); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestSQLiteStorageTPTimings(t *testing.T) {
+	sqliteDB := newTestSQLiteStorage(t)
+	tms := []*utils.ApierTPTiming{
+		{
+			TPid:      "TP1",
+			ID:        "WORKDAYS",
+			Years:     utils.MetaAny,
+			Months:    utils.MetaAny,
+			MonthDays: utils.MetaAny,
+			WeekDays:  "1;2;3;4;5",
+			Time:      "08:00:00",
+		},
+	}
+	if err := sqliteDB.SetTPTimings(tms); err != nil {
+		t.Fatal(err)
+	}
+	if err := sqliteDB.SetTPTimings(tms); err != nil { // overwrites the existing ones
+		t.Fatal(err)
+	}
+	if rcv, err := sqliteDB.GetTPTimings("TP1", utils.EmptyString); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(rcv, tms) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(tms), utils.ToJSON(rcv))
+	}
+	if ids, err := sqliteDB.GetTpIds(utils.EmptyString); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(ids, []string{"TP1"}) {
+		t.Errorf("unexpected TPids: %v", ids)
+	}
+	if err := sqliteDB.RemTpData(utils.TBLTPTimings, "TP1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sqliteDB.GetTPTimings("TP1", utils.EmptyString); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestSQLiteStorageVersions(t *testing.T) {
+	sqliteDB := newTestSQLiteStorage(t)
+	if err := SetDBVersions(sqliteDB); err != nil {
+		t.Fatal(err)
+	}
+	if vrs, err := sqliteDB.GetVersions(utils.EmptyString); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(vrs, CurrentStorDBVersions()) {
+		t.Errorf("expected %v, received %v", CurrentStorDBVersions(), vrs)
+	}
+	if err := sqliteDB.SetVersions(Versions{utils.CDRs: 1}, false); err != nil {
+		t.Fatal(err)
+	}
+	if vrs, err := sqliteDB.GetVersions(utils.CDRs); err != nil {
+		t.Fatal(err)
+	} else if vrs[utils.CDRs] != 1 {
+		t.Errorf("expected version 1, received %v", vrs)
+	}
+}
@@ -55,7 +55,18 @@ func NewDataDBConn(dbType, host, port, name, user,
 	case utils.MetaMongo:
 		d, err = NewMongoStorage(opts.MongoConnScheme, host, port, name, user, pass, marshaler, utils.DataDB, nil, opts.MongoQueryTimeout)
 	case utils.MetaInternal:
-		d, err = NewInternalDB(nil, nil, true, opts.ToTransCacheOpts(), itmsCfg)
+		var iDB *InternalDB
+		if iDB, err = NewInternalDB(nil, nil, true, opts.ToTransCacheOpts(), itmsCfg); err != nil {
+			return
+		}
+		if opts.InternalDBWAL {
+			if err = iDB.OpenWAL(opts.InternalDBDumpPath, opts.InternalDBWALSyncInterval,
+				opts.InternalDBFileSizeLimit); err != nil {
+				iDB.Close()
+				return
+			}
+		}
+		d = iDB
 	default:
 		err = fmt.Errorf("unsupported db_type <%s>", dbType)
 	}
@@ -76,11 +87,25 @@ func NewStorDBConn(dbType, host, port, name, user, pass, marshaler string,
 	case utils.MetaMySQL:
 		db, err = NewMySQLStorage(host, port, name, user, pass, marshaler, opts.SQLMaxOpenConns, opts.SQLMaxIdleConns, opts.SQLLogLevel,
 			opts.SQLConnMaxLifetime, opts.MySQLLocation, opts.MySQLDSNParams)
+	case utils.MetaSQLite:
+		db, err = NewSQLiteStorage(name, marshaler, opts.SQLMaxOpenConns, opts.SQLMaxIdleConns, opts.SQLLogLevel,
+			opts.SQLConnMaxLifetime)
 	case utils.MetaInternal:
-		db, err = NewInternalDB(stringIndexedFields, prefixIndexedFields, false, opts.ToTransCacheOpts(), itmsCfg)
+		var iDB *InternalDB
+		if iDB, err = NewInternalDB(stringIndexedFields, prefixIndexedFields, false, opts.ToTransCacheOpts(), itmsCfg); err != nil {
+			return
+		}
+		if opts.InternalDBWAL {
+			if err = iDB.OpenWAL(opts.InternalDBDumpPath, opts.InternalDBWALSyncInterval,
+				opts.InternalDBFileSizeLimit); err != nil {
+				iDB.Close()
+				return
+			}
+		}
+		db = iDB
 	default:
-		err = fmt.Errorf("unknown db '%s' valid options are [%s, %s, %s, %s]",
-			dbType, utils.MetaMySQL, utils.MetaMongo, utils.MetaPostgres, utils.MetaInternal)
+		err = fmt.Errorf("unknown db '%s' valid options are [%s, %s, %s, %s, %s]",
+			dbType, utils.MetaMySQL, utils.MetaMongo, utils.MetaPostgres, utils.MetaSQLite, utils.MetaInternal)
 	}
 	return
 }
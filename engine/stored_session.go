@@ -22,6 +22,10 @@ import (
 	"time"
 )
 
+// SessionSnapshotVersion is the version of the StoredSession format, increased
+// on changes which prevent older engines from resuming the sessions correctly
+const SessionSnapshotVersion = 1
+
 // used to evade import cycle of the real sessions.SRun struct
 type StoredSRun struct {
 	Event     MapEvent        // Event received from ChargerS
@@ -31,11 +35,12 @@ type StoredSRun struct {
 	ExtraDuration time.Duration // keeps the current duration debited on top of what has been asked
 	LastUsage     time.Duration // last requested Duration
 	TotalUsage    time.Duration // sum of lastUsage
-	NextAutoDebit *time.Time
+	NextAutoDebit *time.Time    // the debit loop resumes at this time
 }
 
 // Holds a Session for storing in DataDB
 type StoredSession struct {
+	Version       int // format version, 0 for the backups made before versioning
 	CGRID         string
 	Tenant        string
 	IPAllocID     string
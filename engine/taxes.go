@@ -0,0 +1,226 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// TaxProfile groups the tax rates of one jurisdiction. The profile with the highest
+// weight out of the ones matching the event is applied
+type TaxProfile struct {
+	Tenant       string
+	ID           string
+	FilterIDs    []string
+	Jurisdiction string // recorded on the tax charges
+	Weight       float64
+	Rates        []*TaxRate // applied in order, compounding rates include the taxes before them
+}
+
+// TaxRate is one tax applied on the rated cost
+type TaxRate struct {
+	ID                 string
+	Type               string  // informative, eg: VAT, excise, regulatory
+	Rate               float64 // percentage out of the taxed amount
+	Amount             float64 // fixed amount added for each taxed event
+	Compound           bool    // tax the taxes computed before this rate as well
+	ActivationInterval *utils.ActivationInterval
+}
+
+// TaxProfileWithAPIOpts is used in replicatorV1 for dispatcher
+type TaxProfileWithAPIOpts struct {
+	*TaxProfile
+	APIOpts map[string]any
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (tp *TaxProfile) TenantID() string {
+	return utils.ConcatenatedKey(tp.Tenant, tp.ID)
+}
+
+// Clone method for TaxProfile
+func (tp *TaxProfile) Clone() *TaxProfile {
+	if tp == nil {
+		return nil
+	}
+	clone := &TaxProfile{
+		Tenant:       tp.Tenant,
+		ID:           tp.ID,
+		Jurisdiction: tp.Jurisdiction,
+		Weight:       tp.Weight,
+	}
+	if tp.FilterIDs != nil {
+		clone.FilterIDs = make([]string, len(tp.FilterIDs))
+		copy(clone.FilterIDs, tp.FilterIDs)
+	}
+	if tp.Rates != nil {
+		clone.Rates = make([]*TaxRate, len(tp.Rates))
+		for i, rate := range tp.Rates {
+			clone.Rates[i] = &TaxRate{
+				ID:       rate.ID,
+				Type:     rate.Type,
+				Rate:     rate.Rate,
+				Amount:   rate.Amount,
+				Compound: rate.Compound,
+			}
+			if rate.ActivationInterval != nil {
+				clone.Rates[i].ActivationInterval = rate.ActivationInterval.Clone()
+			}
+		}
+	}
+	return clone
+}
+
+// CacheClone returns a clone of TaxProfile used by ltcache CacheCloner
+func (tp *TaxProfile) CacheClone() any {
+	return tp.Clone()
+}
+
+// IsActiveAt checks if the rate is in effect at time t
+func (rate *TaxRate) IsActiveAt(t time.Time) bool {
+	return rate.ActivationInterval == nil || rate.ActivationInterval.IsActiveAtTime(t)
+}
+
+// TaxCharge is the tax computed for one of the rates of a tax profile
+type TaxCharge struct {
+	Rate   *TaxRate
+	Amount float64
+}
+
+// computeTaxes returns the taxes of the rates active at time at, in the order of the rates.
+// The compounding rates are applied on the base plus the taxes computed before them
+func computeTaxes(rates []*TaxRate, base float64, at time.Time) (taxes []*TaxCharge) {
+	var taxed float64 // sum of the taxes computed so far
+	for _, rate := range rates {
+		if !rate.IsActiveAt(at) {
+			continue
+		}
+		amount := base
+		if rate.Compound {
+			amount += taxed
+		}
+		amount = utils.Round(amount*rate.Rate/100+rate.Amount,
+			globalRoundingDecimals, utils.MetaRoundingMiddle)
+		if amount == 0 {
+			continue
+		}
+		taxed += amount
+		taxes = append(taxes, &TaxCharge{Rate: rate, Amount: amount})
+	}
+	return
+}
+
+// appendTaxCharge records the tax as a separate ChargingInterval without usage.
+// The tax is not part of Accounting since it is not debited out of the balances
+func (ec *EventCost) appendTaxCharge(jurisdiction string, tax *TaxCharge) {
+	rfID := ec.RatingFilters.GetIDWithSet(RatingMatchedFilters{
+		utils.Subject:               utils.EmptyString,
+		utils.DestinationPrefixName: utils.EmptyString,
+		utils.DestinationID:         utils.EmptyString,
+		utils.RatingPlanID:          utils.MetaTaxes,
+		utils.TaxJurisdiction:       jurisdiction,
+		utils.TaxRateID:             tax.Rate.ID,
+		utils.TaxType:               tax.Rate.Type,
+	})
+	ec.Charges = append(ec.Charges, &ChargingInterval{
+		RatingID: ec.Rating.GetIDWithSet(&RatingUnit{
+			RoundingMethod:   utils.MetaRoundingMiddle,
+			RoundingDecimals: globalRoundingDecimals,
+			RatingFiltersID:  rfID,
+		}),
+		Increments:     []*ChargingIncrement{{Cost: tax.Amount, CompressFactor: 1}},
+		CompressFactor: 1,
+	})
+	ec.ResetCounters()
+}
+
+// HasTaxes returns true if the taxes were already applied on the EventCost
+func (ec *EventCost) HasTaxes() bool {
+	for _, rf := range ec.RatingFilters {
+		if rf[utils.RatingPlanID] == utils.MetaTaxes {
+			return true
+		}
+	}
+	return false
+}
+
+// matchingTaxProfile returns the profile with the highest weight out of the ones of the tenant matching the event,
+// the candidates being selected with the *tax_profile_filter_indexes
+func (cdrS *CDRServer) matchingTaxProfile(cgrEv *utils.CGREvent) (tPrf *TaxProfile, err error) {
+	evNm := utils.MapStorage{
+		utils.MetaReq:  cgrEv.Event,
+		utils.MetaOpts: cgrEv.APIOpts,
+	}
+	var prfIDs utils.StringSet
+	if prfIDs, err = MatchingItemIDsForEvent(evNm, nil, nil, nil, nil,
+		cdrS.dm, utils.CacheTaxProfileFilterIndexes, cgrEv.Tenant, true, false); err != nil {
+		return
+	}
+	for prfID := range prfIDs {
+		var prf *TaxProfile
+		if prf, err = cdrS.dm.GetTaxProfile(cgrEv.Tenant, prfID,
+			true, true, utils.NonTransactional); err != nil {
+			if err == utils.ErrNotFound { // removed meanwhile
+				err = nil
+				continue
+			}
+			return nil, err
+		}
+		if tPrf != nil && tPrf.Weight >= prf.Weight {
+			continue
+		}
+		var pass bool
+		if pass, err = cdrS.filterS.Pass(cgrEv.Tenant, prf.FilterIDs, evNm); err != nil {
+			return nil, err
+		} else if pass {
+			tPrf = prf
+		}
+	}
+	if tPrf == nil {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+// applyTaxes adds the taxes of the matching profile to the CostDetails of a rated CDR,
+// updating its Cost. Returns false if no taxes were applied
+func (cdrS *CDRServer) applyTaxes(cdr *CDR, cgrEv *utils.CGREvent) (applied bool, err error) {
+	if cdr.CostDetails == nil || cdr.Cost < 0 || cdr.CostDetails.HasTaxes() {
+		return
+	}
+	var tPrf *TaxProfile
+	if tPrf, err = cdrS.matchingTaxProfile(cgrEv); err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	at := cdr.AnswerTime
+	if at.IsZero() {
+		at = cdr.SetupTime
+	}
+	taxes := computeTaxes(tPrf.Rates, cdr.CostDetails.GetCost(), at)
+	for _, tax := range taxes {
+		cdr.CostDetails.appendTaxCharge(tPrf.Jurisdiction, tax)
+	}
+	cdr.Cost = cdr.CostDetails.GetCost()
+	return len(taxes) != 0, nil
+}
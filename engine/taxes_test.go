@@ -0,0 +1,300 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestComputeTaxes(t *testing.T) {
+	vat := &TaxRate{ID: "VAT", Rate: 20}
+	excise := &TaxRate{ID: "EXCISE", Rate: 10, Compound: true}
+	fee := &TaxRate{ID: "FEE", Amount: 0.05}
+	old := &TaxRate{ID: "OLD_VAT", Rate: 19,
+		ActivationInterval: &utils.ActivationInterval{
+			ExpiryTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	taxes := computeTaxes([]*TaxRate{old, vat, excise, fee}, 10,
+		time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	// the excise is applied on the base plus the VAT: (10 + 2) * 10%
+	exp := []*TaxCharge{
+		{Rate: vat, Amount: 2},
+		{Rate: excise, Amount: 1.2},
+		{Rate: fee, Amount: 0.05},
+	}
+	if !reflect.DeepEqual(exp, taxes) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(taxes))
+	}
+
+	taxes = computeTaxes([]*TaxRate{old, vat}, 10,
+		time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	exp = []*TaxCharge{{Rate: old, Amount: 1.9}, {Rate: vat, Amount: 2}}
+	if !reflect.DeepEqual(exp, taxes) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(taxes))
+	}
+
+	if taxes = computeTaxes([]*TaxRate{vat}, 0, time.Now()); len(taxes) != 0 {
+		t.Errorf("expected no taxes for zero cost, received %s", utils.ToJSON(taxes))
+	}
+}
+
+func TestEventCostAppendTaxCharge(t *testing.T) {
+	ec := testEC.Clone()
+	cost := ec.GetCost()
+	if ec.HasTaxes() {
+		t.Fatal("expected no taxes")
+	}
+	ec.appendTaxCharge("DE", &TaxCharge{
+		Rate:   &TaxRate{ID: "VAT", Type: "*vat", Rate: 19},
+		Amount: 1.5,
+	})
+	if !ec.HasTaxes() {
+		t.Error("expected taxes")
+	}
+	if rcv, exp := ec.GetCost(), utils.Round(cost+1.5, globalRoundingDecimals, utils.MetaRoundingMiddle); rcv != exp {
+		t.Errorf("expected cost %v, received %v", exp, rcv)
+	}
+	cIl := ec.Charges[len(ec.Charges)-1]
+	if *cIl.Usage() != 0 || cIl.Cost() != 1.5 {
+		t.Errorf("unexpected tax charge: %s", utils.ToJSON(cIl))
+	}
+	rfs := ec.RatingFilters[ec.Rating[cIl.RatingID].RatingFiltersID]
+	if rfs[utils.TaxJurisdiction] != "DE" || rfs[utils.TaxRateID] != "VAT" ||
+		rfs[utils.TaxType] != "*vat" || rfs[utils.RatingPlanID] != utils.MetaTaxes {
+		t.Errorf("unexpected rating filters: %s", utils.ToJSON(rfs))
+	}
+
+	// the taxes are not debited so they are skipped on refunds
+	cd := ec.AsRefundIncrements(utils.MetaVoice)
+	if lastIncr := cd.Increments[len(cd.Increments)-1]; lastIncr.Cost != 1.5 || lastIncr.BalanceInfo != nil {
+		t.Errorf("unexpected refund increment: %s", utils.ToJSON(lastIncr))
+	}
+	cc := ec.AsCallCost(utils.MetaVoice)
+	if ts := cc.Timespans[len(cc.Timespans)-1]; ts.RatingPlanId != utils.MetaTaxes || ts.Cost != 1.5 {
+		t.Errorf("unexpected tax timespan: %s", utils.ToJSON(ts))
+	}
+}
+
+func newTaxesTestCDRs(t *testing.T) *CDRServer {
+	t.Helper()
+	cfg := config.NewDefaultCGRConfig()
+	dataDB, err := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm := NewDataManager(dataDB, cfg.CacheCfg(), nil)
+	Cache.Clear(nil) // drop the profiles and indexes cached by the previous tests
+	for _, txp := range []*TaxProfile{
+		{
+			Tenant:       "cgrates.org",
+			ID:           "TAX_EU",
+			FilterIDs:    []string{"*prefix:~*req.Destination:4"},
+			Jurisdiction: "EU",
+			Weight:       10,
+			Rates:        []*TaxRate{{ID: "VAT", Type: "*vat", Rate: 21}},
+		},
+		{
+			Tenant:       "cgrates.org",
+			ID:           "TAX_DE",
+			FilterIDs:    []string{"*prefix:~*req.Destination:49"},
+			Jurisdiction: "DE",
+			Weight:       20,
+			Rates: []*TaxRate{
+				{ID: "VAT", Type: "*vat", Rate: 19},
+				{ID: "REG", Type: "*regulatory", Amount: 0.01},
+			},
+		},
+	} {
+		if err = dm.SetTaxProfile(txp, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &CDRServer{
+		cgrCfg:  cfg,
+		dm:      dm,
+		filterS: NewFilterS(cfg, nil, dm),
+	}
+}
+
+func TestCDRsMatchingTaxProfile(t *testing.T) {
+	cdrS := newTaxesTestCDRs(t)
+	for dst, exp := range map[string]string{
+		"4986517174963": "DE",
+		"4312345":       "EU",
+	} {
+		if prf, err := cdrS.matchingTaxProfile(&utils.CGREvent{
+			Tenant: "cgrates.org",
+			Event:  map[string]any{utils.Destination: dst},
+		}); err != nil {
+			t.Error(err)
+		} else if prf.Jurisdiction != exp {
+			t.Errorf("destination %s: expected %s, received %s", dst, exp, prf.Jurisdiction)
+		}
+	}
+	if _, err := cdrS.matchingTaxProfile(&utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event:  map[string]any{utils.Destination: "1002"},
+	}); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if _, err := cdrS.matchingTaxProfile(&utils.CGREvent{
+		Tenant: "itsyscom.com",
+		Event:  map[string]any{utils.Destination: "4986517174963"},
+	}); err != utils.ErrNotFound {
+		t.Errorf("expected %v for other tenants, received %v", utils.ErrNotFound, err)
+	}
+	if err := cdrS.dm.RemoveTaxProfile("cgrates.org", "TAX_DE", true); err != nil {
+		t.Fatal(err)
+	}
+	if prf, err := cdrS.matchingTaxProfile(&utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event:  map[string]any{utils.Destination: "4986517174963"},
+	}); err != nil {
+		t.Error(err)
+	} else if prf.ID != "TAX_EU" {
+		t.Errorf("expected TAX_EU after removing TAX_DE, received %s", prf.ID)
+	}
+}
+
+func TestTaxProfileFilterIndexes(t *testing.T) {
+	cdrS := newTaxesTestCDRs(t)
+	exp := map[string]utils.StringSet{
+		"*prefix:*req.Destination:4":  {"TAX_EU": {}},
+		"*prefix:*req.Destination:49": {"TAX_DE": {}},
+	}
+	if rcv, err := cdrS.dm.GetIndexes(utils.CacheTaxProfileFilterIndexes, "cgrates.org",
+		false, false); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if err := cdrS.dm.SetTaxProfile(&TaxProfile{
+		Tenant: "cgrates.org",
+		ID:     "TAX_DE",
+		Weight: 20,
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	exp = map[string]utils.StringSet{
+		"*prefix:*req.Destination:4": {"TAX_EU": {}},
+		"*none:*any:*any":            {"TAX_DE": {}},
+	}
+	if rcv, err := cdrS.dm.GetIndexes(utils.CacheTaxProfileFilterIndexes, "cgrates.org",
+		false, false); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if err := cdrS.dm.RemoveTaxProfile("cgrates.org", "TAX_DE", true); err != nil {
+		t.Fatal(err)
+	}
+	delete(exp, "*none:*any:*any")
+	if rcv, err := cdrS.dm.GetIndexes(utils.CacheTaxProfileFilterIndexes, "cgrates.org",
+		false, false); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+func TestCDRsProcessEventsTaxes(t *testing.T) {
+	cdrS := newTaxesTestCDRs(t)
+	ec := testEC.Clone()
+	cost := ec.GetCost()
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "TestCDRsProcessEventsTaxes",
+		Event: map[string]any{
+			utils.CGRID:       "TaxedCDR",
+			utils.RunID:       utils.MetaDefault,
+			utils.Destination: "4986517174963",
+			utils.AnswerTime:  time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			utils.Cost:        cost,
+			utils.CostDetails: ec,
+		},
+	}
+	args, err := newCDRProcessingArgs(cdrS.cgrCfg.CdrsCfg(),
+		utils.FlagsWithParamsFromSlice([]string{utils.MetaTaxes}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args.store = false
+	args.reprocess = true
+	outEvs, err := cdrS.processEvents([]*utils.CGREvent{ev}, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string{utils.MetaTaxes}, outEvs[0].Flags) {
+		t.Errorf("unexpected flags: %v", outEvs[0].Flags)
+	}
+	vat := utils.Round(cost*0.19, globalRoundingDecimals, utils.MetaRoundingMiddle)
+	expCost := utils.Round(cost+vat+0.01, globalRoundingDecimals, utils.MetaRoundingMiddle)
+	if rcv := outEvs[0].Event[utils.Cost]; rcv != expCost {
+		t.Errorf("expected cost %v, received %v", expCost, rcv)
+	}
+	taxedEC := outEvs[0].Event[utils.CostDetails].(*EventCost)
+	if len(taxedEC.Charges) != len(testEC.Charges)+2 {
+		t.Errorf("expected two tax charges, received %s", utils.ToJSON(taxedEC.Charges))
+	}
+
+	// already taxed costs are not taxed again
+	ev.Event[utils.Cost] = expCost
+	ev.Event[utils.CostDetails] = taxedEC
+	if outEvs, err = cdrS.processEvents([]*utils.CGREvent{ev}, args); err != nil {
+		t.Fatal(err)
+	} else if len(outEvs[0].Flags) != 0 || outEvs[0].Event[utils.Cost] != expCost {
+		t.Errorf("unexpected event: %s", utils.ToJSON(outEvs[0]))
+	}
+}
+
+func TestNewCDRProcessingArgsTaxes(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	if args, err := newCDRProcessingArgs(cfg.CdrsCfg(), nil, nil); err != nil {
+		t.Error(err)
+	} else if args.taxS {
+		t.Error("expected taxes disabled by default")
+	}
+	cfg.CdrsCfg().ApplyTaxes = true
+	if args, err := newCDRProcessingArgs(cfg.CdrsCfg(), nil, nil); err != nil {
+		t.Error(err)
+	} else if !args.taxS {
+		t.Error("expected taxes enabled from config")
+	}
+	if args, err := newCDRProcessingArgs(cfg.CdrsCfg(), nil,
+		map[string]any{utils.OptsTaxS: false}); err != nil {
+		t.Error(err)
+	} else if args.taxS {
+		t.Error("expected taxes disabled from opts")
+	}
+	if args, err := newCDRProcessingArgsNoCfg(
+		utils.FlagsWithParamsFromSlice([]string{utils.MetaTaxes}), nil); err != nil {
+		t.Error(err)
+	} else if !args.taxS {
+		t.Error("expected taxes enabled from flags")
+	}
+	if _, err := newCDRProcessingArgsNoCfg(nil, map[string]any{utils.OptsTaxS: "maybe"}); err == nil {
+		t.Error("expected error for invalid option")
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"cmp"
+	"slices"
+)
+
+// defaultTDigestCompression controls the accuracy of the TDigest, the
+// number of centroids kept after compression is in the order of its value
+const defaultTDigestCompression = 100
+
+// Centroid is a cluster of values summarized by their mean and count.
+type Centroid struct {
+	Mean   float64
+	Weight float64
+}
+
+// TDigest is a merging t-digest sketch, estimating quantiles over a stream
+// of values with bounded memory. It is more accurate towards the extremes of
+// the distribution, where small clusters are kept.
+type TDigest struct {
+	Compression float64
+	Centroids   []*Centroid
+	Count       float64
+	Min         float64
+	Max         float64
+}
+
+// NewTDigest returns a TDigest with the given compression.
+func NewTDigest(compression float64) *TDigest {
+	if compression <= 0 {
+		compression = defaultTDigestCompression
+	}
+	return &TDigest{Compression: compression}
+}
+
+// Clone creates a deep copy of TDigest.
+func (td *TDigest) Clone() *TDigest {
+	if td == nil {
+		return nil
+	}
+	clone := &TDigest{
+		Compression: td.Compression,
+		Centroids:   make([]*Centroid, len(td.Centroids)),
+		Count:       td.Count,
+		Min:         td.Min,
+		Max:         td.Max,
+	}
+	for i, c := range td.Centroids {
+		cc := *c
+		clone.Centroids[i] = &cc
+	}
+	return clone
+}
+
+// Add inserts a value into the digest, compressing the centroids once
+// there are too many of them.
+func (td *TDigest) Add(val float64) {
+	if td.Count == 0 || val < td.Min {
+		td.Min = val
+	}
+	if td.Count == 0 || val > td.Max {
+		td.Max = val
+	}
+	td.Centroids = append(td.Centroids, &Centroid{Mean: val, Weight: 1})
+	td.Count++
+	if float64(len(td.Centroids)) > 10*td.Compression {
+		td.compress()
+	}
+}
+
+// compress sorts the centroids and merges the neighbouring ones as long as
+// the merged weight stays within the size limit for their quantile.
+func (td *TDigest) compress() {
+	if len(td.Centroids) < 2 {
+		return
+	}
+	slices.SortStableFunc(td.Centroids, func(a, b *Centroid) int {
+		return cmp.Compare(a.Mean, b.Mean)
+	})
+	merged := td.Centroids[:1]
+	var cumWeight float64 // weight of the centroids before the last merged one
+	for _, c := range td.Centroids[1:] {
+		last := merged[len(merged)-1]
+		proposed := last.Weight + c.Weight
+		q := (cumWeight + proposed/2) / td.Count
+		if proposed <= 4*td.Count*q*(1-q)/td.Compression {
+			last.Mean += (c.Mean - last.Mean) * c.Weight / proposed
+			last.Weight = proposed
+			continue
+		}
+		cumWeight += last.Weight
+		merged = append(merged, c)
+	}
+	clear(td.Centroids[len(merged):]) // release the merged centroids
+	td.Centroids = merged
+}
+
+// Quantile estimates the value at quantile q, with q within [0, 1].
+func (td *TDigest) Quantile(q float64) float64 {
+	switch {
+	case len(td.Centroids) == 0:
+		return 0
+	case q <= 0:
+		return td.Min
+	case q >= 1:
+		return td.Max
+	}
+	td.compress()
+	target := q * td.Count
+	first := td.Centroids[0]
+	if target < first.Weight/2 { // between minimum and the first centroid
+		return td.Min + (first.Mean-td.Min)*target/(first.Weight/2)
+	}
+	var cumWeight float64
+	for i := 0; i < len(td.Centroids)-1; i++ {
+		left, right := td.Centroids[i], td.Centroids[i+1]
+		leftCenter := cumWeight + left.Weight/2
+		rightCenter := cumWeight + left.Weight + right.Weight/2
+		if target <= rightCenter {
+			return left.Mean + (right.Mean-left.Mean)*
+				(target-leftCenter)/(rightCenter-leftCenter)
+		}
+		cumWeight += left.Weight
+	}
+	last := td.Centroids[len(td.Centroids)-1]
+	lastCenter := td.Count - last.Weight/2
+	return last.Mean + (td.Max-last.Mean)*(target-lastCenter)/(last.Weight/2)
+}
@@ -0,0 +1,69 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTDigestQuantile(t *testing.T) {
+	td := NewTDigest(0)
+	if rcv := td.Quantile(0.5); rcv != 0 {
+		t.Errorf("expected 0 for empty digest, received %v", rcv)
+	}
+	for i := 100000; i > 0; i-- {
+		td.Add(float64(i))
+	}
+	if len(td.Centroids) > 10*defaultTDigestCompression {
+		t.Errorf("expected compressed centroids, received %d", len(td.Centroids))
+	}
+	for _, q := range []float64{0.01, 0.25, 0.5, 0.75, 0.99} {
+		exp := q * 100000
+		if rcv := td.Quantile(q); math.Abs(rcv-exp)/exp > 0.01 {
+			t.Errorf("quantile %v: expected around %v, received %v", q, exp, rcv)
+		}
+	}
+	if rcv := td.Quantile(0); rcv != 1 {
+		t.Errorf("expected minimum 1, received %v", rcv)
+	}
+	if rcv := td.Quantile(1); rcv != 100000 {
+		t.Errorf("expected maximum 100000, received %v", rcv)
+	}
+}
+
+func TestTDigestClone(t *testing.T) {
+	var nilTD *TDigest
+	if rcv := nilTD.Clone(); rcv != nil {
+		t.Errorf("expected nil, received %+v", rcv)
+	}
+	td := NewTDigest(50)
+	td.Add(3)
+	td.Add(1)
+	clone := td.Clone()
+	if !reflect.DeepEqual(td, clone) {
+		t.Fatalf("expected %+v, received %+v", td, clone)
+	}
+	clone.Centroids[0].Mean = 10
+	clone.Add(5)
+	if td.Centroids[0].Mean != 3 || td.Count != 2 {
+		t.Errorf("original digest was modified by the clone: %+v", td)
+	}
+}
@@ -107,13 +107,16 @@ func thresholdProfileLockKey(tnt, id string) string {
 
 // lock will lock the ThresholdProfile using guardian and store the lock within r.lkID
 // if lkID is passed as argument, the lock is considered as executed
-func (tp *ThresholdProfile) lock(lkID string) {
+func (tp *ThresholdProfile) lock(lkID string) (err error) {
 	if lkID == utils.EmptyString {
-		lkID = guardian.Guardian.GuardIDs("",
+		if lkID, err = guardian.Guardian.GuardIDs("",
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			thresholdProfileLockKey(tp.Tenant, tp.ID))
+			thresholdProfileLockKey(tp.Tenant, tp.ID)); err != nil {
+			return
+		}
 	}
 	tp.lkID = lkID
+	return
 }
 
 // unlock will unlock the ThresholdProfile and clear rp.lkID
@@ -200,13 +203,16 @@ func thresholdLockKey(tnt, id string) string {
 
 // lock will lock the threshold using guardian and store the lock within r.lkID
 // if lkID is passed as argument, the lock is considered as executed
-func (t *Threshold) lock(lkID string) {
+func (t *Threshold) lock(lkID string) (err error) {
 	if lkID == utils.EmptyString {
-		lkID = guardian.Guardian.GuardIDs("",
+		if lkID, err = guardian.Guardian.GuardIDs("",
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			thresholdLockKey(t.Tenant, t.ID))
+			thresholdLockKey(t.Tenant, t.ID)); err != nil {
+			return
+		}
 	}
 	t.lkID = lkID
+	return
 }
 
 // unlock will unlock the threshold and clear r.lkID
@@ -400,7 +406,11 @@ func (tS *ThresholdService) storeThresholds() {
 			continue
 		}
 		t := tIf.(*Threshold)
-		t.lock(utils.EmptyString)
+		if err := t.lock(utils.EmptyString); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<ThresholdS> failed locking threshold with ID: %s, error: %s", tID, err.Error()))
+			failedTdIDs = append(failedTdIDs, tID)
+			continue
+		}
 		if err := tS.StoreThreshold(t); err != nil {
 			failedTdIDs = append(failedTdIDs, tID) // record failure so we can schedule it for next backup
 		}
@@ -478,9 +488,13 @@ func (tS *ThresholdService) matchingThresholdsForEvent(tnt string, args *utils.C
 
 	ts = make(Thresholds, 0, len(itemIDs))
 	for _, id := range itemIDs {
-		lkPrflID := guardian.Guardian.GuardIDs("",
+		var lkPrflID string
+		if lkPrflID, err = guardian.Guardian.GuardIDs("",
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			thresholdProfileLockKey(tnt, id))
+			thresholdProfileLockKey(tnt, id)); err != nil {
+			ts.unlock()
+			return nil, err
+		}
 		var tPrfl *ThresholdProfile
 		if tPrfl, err = tS.dm.GetThresholdProfile(tnt, id, true, true, utils.NonTransactional); err != nil {
 			guardian.Guardian.UnguardIDs(lkPrflID)
@@ -509,9 +523,14 @@ func (tS *ThresholdService) matchingThresholdsForEvent(tnt string, args *utils.C
 				continue
 			}
 		}
-		lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+		var lkID string
+		if lkID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
 			config.CgrConfig().GeneralCfg().LockingTimeout,
-			thresholdLockKey(tPrfl.Tenant, tPrfl.ID))
+			thresholdLockKey(tPrfl.Tenant, tPrfl.ID)); err != nil {
+			tPrfl.unlock()
+			ts.unlock()
+			return nil, err
+		}
 		var t *Threshold
 		if t, err = tS.dm.GetThreshold(tPrfl.Tenant, tPrfl.ID, true, true, ""); err != nil {
 			guardian.Guardian.UnguardIDs(lkID)
@@ -689,9 +708,12 @@ func (tS *ThresholdService) V1GetThreshold(ctx *context.Context, tntID *utils.Te
 		tnt = tS.cgrcfg.GeneralCfg().DefaultTenant
 	}
 	// make sure threshold is locked at process level
-	lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+	var lkID string
+	if lkID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
 		config.CgrConfig().GeneralCfg().LockingTimeout,
-		thresholdLockKey(tnt, tntID.ID))
+		thresholdLockKey(tnt, tntID.ID)); err != nil {
+		return
+	}
 	defer guardian.Guardian.UnguardIDs(lkID)
 	if thd, err = tS.dm.GetThreshold(tnt, tntID.ID, true, true, ""); err != nil {
 		return
@@ -710,9 +732,12 @@ func (tS *ThresholdService) V1ResetThreshold(ctx *context.Context, tntID *utils.
 		tnt = tS.cgrcfg.GeneralCfg().DefaultTenant
 	}
 	// make sure threshold is locked at process level
-	lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+	var lkID string
+	if lkID, err = guardian.Guardian.GuardIDs(utils.EmptyString,
 		config.CgrConfig().GeneralCfg().LockingTimeout,
-		thresholdLockKey(tnt, tntID.ID))
+		thresholdLockKey(tnt, tntID.ID)); err != nil {
+		return
+	}
 	defer guardian.Guardian.UnguardIDs(lkID)
 	if thd, err = tS.dm.GetThreshold(tnt, tntID.ID, true, true, ""); err != nil {
 		utils.Logger.Warning(fmt.Sprintf("threshold with ID %s not found", tntID.ID))
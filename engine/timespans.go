@@ -514,11 +514,12 @@ func (ts *TimeSpan) SplitByRateInterval(i *RateInterval, data bool) (nts *TimeSp
 	// split by GroupStart
 	if i.Rating != nil {
 		i.Rating.Rates.Sort()
+		groupStart, groupEnd := i.groupStart(ts.GetGroupStart()), i.groupStart(ts.GetGroupEnd())
 		for _, rate := range i.Rating.Rates {
-			if ts.GetGroupStart() < rate.GroupIntervalStart && ts.GetGroupEnd() > rate.GroupIntervalStart {
+			if groupStart < rate.GroupIntervalStart && groupEnd > rate.GroupIntervalStart {
 				//log.Print("Splitting")
 				ts.SetRateInterval(i)
-				splitTime := ts.TimeStart.Add(rate.GroupIntervalStart - ts.GetGroupStart())
+				splitTime := ts.TimeStart.Add(rate.GroupIntervalStart - groupStart)
 				nts = &TimeSpan{
 					TimeStart: splitTime,
 					TimeEnd:   ts.TimeEnd,
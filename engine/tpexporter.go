@@ -430,6 +430,21 @@ func (tpExp *TPExporter) Run() error {
 		}
 	}
 
+	storDataTaxProfiles, err := tpExp.storDb.GetTPTaxProfiles(tpExp.tpID, "", "")
+	if err != nil && err.Error() != utils.ErrNotFound.Error() {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error: %s, when getting %s from stordb for export", utils.ApierS, err, utils.TpTaxProfiles))
+		withError = true
+	}
+
+	if len(storDataTaxProfiles) != 0 {
+		toExportMap[utils.TaxProfilesCsv] = make([]any, 0, len(storDataTaxProfiles))
+		for _, sd := range storDataTaxProfiles {
+			for _, sdModel := range APItoModelTPTaxProfile(sd) {
+				toExportMap[utils.TaxProfilesCsv] = append(toExportMap[utils.TaxProfilesCsv], sdModel)
+			}
+		}
+	}
+
 	if len(toExportMap) == 0 { // if we don't have anything to export we return not found error
 		return utils.ErrNotFound
 	}
@@ -62,6 +62,7 @@ var fileHandlers = map[string]func(*TPCSVImporter, string) error{
 	utils.ChargersCsv:           (*TPCSVImporter).importChargerProfiles,
 	utils.DispatcherProfilesCsv: (*TPCSVImporter).importDispatcherProfiles,
 	utils.DispatcherHostsCsv:    (*TPCSVImporter).importDispatcherHosts,
+	utils.TaxProfilesCsv:        (*TPCSVImporter).importTaxProfiles,
 }
 
 func (tpImp *TPCSVImporter) Run() error {
@@ -320,6 +321,17 @@ func (tpImp *TPCSVImporter) importRankings(fn string) error {
 	return tpImp.StorDb.SetTPRankings(sgs)
 }
 
+func (tpImp *TPCSVImporter) importTaxProfiles(fn string) error {
+	if tpImp.Verbose {
+		log.Printf("Processing file: <%s>", fn)
+	}
+	tps, err := tpImp.csvr.GetTPTaxProfiles(tpImp.TPid, "", "")
+	if err != nil {
+		return err
+	}
+	return tpImp.StorDb.SetTPTaxProfiles(tps)
+}
+
 func (tpImp *TPCSVImporter) importThresholds(fn string) error {
 	if tpImp.Verbose {
 		log.Printf("Processing file: <%s> ", fn)
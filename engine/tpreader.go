@@ -59,6 +59,7 @@ type TpReader struct {
 	chargerProfiles    map[utils.TenantID]*utils.TPChargerProfile
 	dispatcherProfiles map[utils.TenantID]*utils.TPDispatcherProfile
 	dispatcherHosts    map[utils.TenantID]*utils.TPDispatcherHost
+	taxProfiles        map[utils.TenantID]*utils.TPTaxProfile
 	acntActionPlans    map[string][]string
 	cacheConns         []string
 	schedulerConns     []string
@@ -104,6 +105,7 @@ func (tpr *TpReader) Init() {
 	tpr.chargerProfiles = make(map[utils.TenantID]*utils.TPChargerProfile)
 	tpr.dispatcherProfiles = make(map[utils.TenantID]*utils.TPDispatcherProfile)
 	tpr.dispatcherHosts = make(map[utils.TenantID]*utils.TPDispatcherHost)
+	tpr.taxProfiles = make(map[utils.TenantID]*utils.TPTaxProfile)
 	tpr.filters = make(map[utils.TenantID]*utils.TPFilterProfile)
 	tpr.acntActionPlans = make(map[string][]string)
 }
@@ -1191,6 +1193,26 @@ func (tpr *TpReader) LoadRankings() error {
 	return tpr.LoadRankingsFiltered("")
 }
 
+func (tpr *TpReader) LoadTaxProfilesFiltered(tag string) error {
+	tps, err := tpr.lr.GetTPTaxProfiles(tpr.tpid, "", tag)
+	if err != nil {
+		return err
+	}
+	mapTxps := make(map[utils.TenantID]*utils.TPTaxProfile)
+	for _, txp := range tps {
+		if err = verifyInlineFilterS(txp.FilterIDs); err != nil {
+			return err
+		}
+		mapTxps[utils.TenantID{Tenant: txp.Tenant, ID: txp.ID}] = txp
+	}
+	tpr.taxProfiles = mapTxps
+	return nil
+}
+
+func (tpr *TpReader) LoadTaxProfiles() error {
+	return tpr.LoadTaxProfilesFiltered("")
+}
+
 func (tpr *TpReader) LoadThresholdsFiltered(tag string) (err error) {
 	tps, err := tpr.lr.GetTPThresholds(tpr.tpid, "", tag)
 	if err != nil {
@@ -1401,6 +1423,9 @@ func (tpr *TpReader) LoadAll() (err error) {
 	if err = tpr.LoadDispatcherHosts(); err != nil && err.Error() != utils.NotFoundCaps {
 		return
 	}
+	if err = tpr.LoadTaxProfiles(); err != nil && err.Error() != utils.NotFoundCaps {
+		return
+	}
 	return nil
 }
 
@@ -1796,6 +1821,24 @@ func (tpr *TpReader) WriteToDatabase(verbose, disableReverse bool) (err error) {
 	if len(tpr.dispatcherHosts) != 0 {
 		loadIDs[utils.CacheDispatcherHosts] = loadID
 	}
+	if verbose {
+		log.Print("TaxProfiles:")
+	}
+	for _, tpTxp := range tpr.taxProfiles {
+		var txp *TaxProfile
+		if txp, err = APItoTaxProfile(tpTxp, tpr.timezone); err != nil {
+			return
+		}
+		if err = tpr.dm.SetTaxProfile(txp, true); err != nil {
+			return
+		}
+		if verbose {
+			log.Print("\t", txp.TenantID())
+		}
+	}
+	if len(tpr.taxProfiles) != 0 {
+		loadIDs[utils.CacheTaxProfiles] = loadID
+	}
 
 	if verbose {
 		log.Print("Timings:")
@@ -1895,6 +1938,8 @@ func (tpr *TpReader) ShowStatistics() {
 	log.Print("DispatcherProfiles: ", len(tpr.dispatcherProfiles))
 	// Dispatcher Hosts
 	log.Print("DispatcherHosts: ", len(tpr.dispatcherHosts))
+	// Tax profiles
+	log.Print("TaxProfiles: ", len(tpr.taxProfiles))
 }
 
 // GetLoadedIds returns the identities loaded for a specific category, useful for cache reloads
@@ -2012,6 +2057,14 @@ func (tpr *TpReader) GetLoadedIds(categ string) ([]string, error) {
 			i++
 		}
 		return keys, nil
+	case utils.TaxProfilePrefix:
+		keys := make([]string, len(tpr.taxProfiles))
+		i := 0
+		for k := range tpr.taxProfiles {
+			keys[i] = k.TenantID()
+			i++
+		}
+		return keys, nil
 	case utils.ThresholdProfilePrefix:
 		keys := make([]string, len(tpr.thProfiles))
 		i := 0
@@ -2275,6 +2328,17 @@ func (tpr *TpReader) RemoveFromDatabase(verbose, disableReverse bool) (err error
 			log.Print("\t", utils.ConcatenatedKey(tpDsh.Tenant, tpDsh.ID))
 		}
 	}
+	if verbose {
+		log.Print("TaxProfiles:")
+	}
+	for _, tpTxp := range tpr.taxProfiles {
+		if err = tpr.dm.RemoveTaxProfile(tpTxp.Tenant, tpTxp.ID, true); err != nil {
+			return
+		}
+		if verbose {
+			log.Print("\t", utils.ConcatenatedKey(tpTxp.Tenant, tpTxp.ID))
+		}
+	}
 
 	if verbose {
 		log.Print("Timings:")
@@ -2383,6 +2447,9 @@ func (tpr *TpReader) RemoveFromDatabase(verbose, disableReverse bool) (err error
 	if len(tpr.timings) != 0 {
 		loadIDs[utils.CacheTimings] = loadID
 	}
+	if len(tpr.taxProfiles) != 0 {
+		loadIDs[utils.CacheTaxProfiles] = loadID
+	}
 	return tpr.dm.SetLoadIDs(loadIDs)
 }
 
@@ -2413,6 +2480,7 @@ func (tpr *TpReader) ReloadCache(caching string, verbose bool, opts map[string]a
 	dppIDs, _ := tpr.GetLoadedIds(utils.DispatcherProfilePrefix)
 	dphIDs, _ := tpr.GetLoadedIds(utils.DispatcherHostPrefix)
 	aps, _ := tpr.GetLoadedIds(utils.ActionPlanPrefix)
+	txpIDs, _ := tpr.GetLoadedIds(utils.TaxProfilePrefix)
 
 	//compose Reload Cache argument
 	cacheArgs := map[string][]string{
@@ -2441,6 +2509,7 @@ func (tpr *TpReader) ReloadCache(caching string, verbose bool, opts map[string]a
 		utils.CacheChargerProfiles:     chargerIDs,
 		utils.CacheDispatcherProfiles:  dppIDs,
 		utils.CacheDispatcherHosts:     dphIDs,
+		utils.CacheTaxProfiles:         txpIDs,
 	}
 
 	// verify if we need to clear indexes
@@ -2469,6 +2538,9 @@ func (tpr *TpReader) ReloadCache(caching string, verbose bool, opts map[string]a
 	if len(dppIDs) != 0 {
 		cacheIDs = append(cacheIDs, utils.CacheDispatcherFilterIndexes)
 	}
+	if len(txpIDs) != 0 {
+		cacheIDs = append(cacheIDs, utils.CacheTaxProfileFilterIndexes)
+	}
 	if len(flrIDs) != 0 {
 		cacheIDs = append(cacheIDs, utils.CacheReverseFilterIndexes)
 	}
@@ -953,6 +953,7 @@ func TestTPReaderReloadCache(t *testing.T) {
 		AccountActionPlanIDs:  []string{"AccountActionPlansID"},
 		RankingProfileIDs:     []string{"cgrates.org:rankingProfilesID"},
 		ReverseDestinationIDs: []string{},
+		TaxProfileIDs:         []string{},
 	}
 	rpcInternal := make(chan birpc.ClientConnector, 1)
 	rpcInternal <- &ccMock{
@@ -1069,7 +1070,7 @@ func TestTPReaderLoadDestinationsFiltered(t *testing.T) {
 		TPid: "tpID",
 		ID:   "prefixes",
 	}, []string{"groupId"}, true, "tId")
-	db.db = tscache
+	db.db = &walTransCache{TransCache: tscache}
 
 	tpr, err := NewTpReader(db, db, "itemId", "local", nil, nil)
 	if err != nil {
@@ -1240,7 +1241,7 @@ func TestTpReaderLoadAccountActions(t *testing.T) {
 	if dErr != nil {
 		t.Error(dErr)
 	}
-	db.db = tscache
+	db.db = &walTransCache{TransCache: tscache}
 	tpr, err := NewTpReader(db, db, "*prf", "local", nil, nil)
 	if err != nil {
 		t.Error(err)
@@ -1459,7 +1460,7 @@ func TestTpReaderLoadTimingsErr(t *testing.T) {
 	if dErr != nil {
 		t.Error(dErr)
 	}
-	db.db = tscache
+	db.db = &walTransCache{TransCache: tscache}
 	tpr, err := NewTpReader(db, db, "*prf", "local", nil, nil)
 	if err != nil {
 		t.Error(err)
@@ -1497,7 +1498,7 @@ func TestLoadDestinationRatesErr(t *testing.T) {
 	if dErr != nil {
 		t.Error(dErr)
 	}
-	db.db = tscache
+	db.db = &walTransCache{TransCache: tscache}
 	tpr, err := NewTpReader(db, db, "*prf", "local", nil, nil)
 	if err != nil {
 		t.Error(err)
@@ -1588,7 +1589,7 @@ func TestLoadRatingProfilesFiltered(t *testing.T) {
 	if dErr != nil {
 		t.Error(dErr)
 	}
-	db.db = tscache
+	db.db = &walTransCache{TransCache: tscache}
 	tpr, err := NewTpReader(db, db, "*prf", "local", nil, nil)
 	if err != nil {
 		t.Error(err)
@@ -1648,7 +1649,7 @@ func TestTpReaderLoadActionTriggers(t *testing.T) {
 	if dErr != nil {
 		t.Error(dErr)
 	}
-	db.db = tscache
+	db.db = &walTransCache{TransCache: tscache}
 	tpr, err := NewTpReader(db, db, "*prf", "UTC", nil, nil)
 	if err != nil {
 		t.Error(err)
@@ -1736,7 +1737,7 @@ func TestTpReaderSetDestination(t *testing.T) {
 	if dErr != nil {
 		t.Error(dErr)
 	}
-	db.db = tscache
+	db.db = &walTransCache{TransCache: tscache}
 	tpr, err := NewTpReader(db, db, "*prf", "UTC", nil, nil)
 	if err != nil {
 		t.Error(err)
@@ -0,0 +1,131 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// UsageCounter accumulates the usage rated on the tiered rates referencing it
+type UsageCounter struct {
+	Usage     time.Duration
+	StartTime time.Time // start of the counting period, set on reset
+}
+
+// Clone returns a copy of the UsageCounter
+func (uc *UsageCounter) Clone() *UsageCounter {
+	if uc == nil {
+		return nil
+	}
+	return &UsageCounter{
+		Usage:     uc.Usage,
+		StartTime: uc.StartTime,
+	}
+}
+
+// FieldAsInterface func to help filtering on the account usage counters
+func (uc *UsageCounter) FieldAsInterface(fldPath []string) (val any, err error) {
+	if uc == nil || len(fldPath) != 1 {
+		return nil, utils.ErrNotFound
+	}
+	switch fldPath[0] {
+	default:
+		return nil, fmt.Errorf("unsupported field prefix: <%s>", fldPath[0])
+	case utils.Usage:
+		return uc.Usage, nil
+	case utils.StartTime:
+		return uc.StartTime, nil
+	}
+}
+
+// UsageCounters are the usage counters of an account, indexed by their ID
+type UsageCounters map[string]*UsageCounter
+
+// Clone returns a copy of the UsageCounters
+func (ucs UsageCounters) Clone() UsageCounters {
+	if ucs == nil {
+		return nil
+	}
+	cln := make(UsageCounters, len(ucs))
+	for id, uc := range ucs {
+		cln[id] = uc.Clone()
+	}
+	return cln
+}
+
+// Usage returns the usage on the counter with the given ID
+func (ucs UsageCounters) Usage(id string) time.Duration {
+	if uc, has := ucs[id]; has && uc != nil {
+		return uc.Usage
+	}
+	return 0
+}
+
+// countUsage adds the usage of the timespans rated on tiered rates to the account counters
+func (acc *Account) countUsage(tss TimeSpans, now time.Time) {
+	for _, ts := range tss {
+		if ts.RateInterval == nil || ts.RateInterval.Rating == nil ||
+			ts.RateInterval.Rating.UsageCounter == utils.EmptyString {
+			continue
+		}
+		if acc.UsageCounters == nil {
+			acc.UsageCounters = make(UsageCounters)
+		}
+		uc, has := acc.UsageCounters[ts.RateInterval.Rating.UsageCounter]
+		if !has || uc == nil {
+			uc = &UsageCounter{StartTime: now}
+			acc.UsageCounters[ts.RateInterval.Rating.UsageCounter] = uc
+		}
+		uc.Usage += ts.GetDuration()
+	}
+}
+
+// resetUsageCounters starts a new period on the counters with the given IDs or on all of them if none is given
+func (acc *Account) resetUsageCounters(ids []string, now time.Time) {
+	if len(ids) == 0 {
+		for _, uc := range acc.UsageCounters {
+			*uc = UsageCounter{StartTime: now}
+		}
+		return
+	}
+	if acc.UsageCounters == nil {
+		acc.UsageCounters = make(UsageCounters)
+	}
+	for _, id := range ids {
+		acc.UsageCounters[id] = &UsageCounter{StartTime: now}
+	}
+}
+
+// resetUsageCountersAction resets the counters listed in the ExtraParameters, separated by ';', or all of them
+func resetUsageCountersAction(ub *Account, a *Action, _ Actions, _ *FilterS, _ any, _ SharedActionsData, _ ActionConnCfg) (err error) {
+	if ub == nil {
+		return errors.New("nil account")
+	}
+	var ids []string
+	if a.ExtraParameters != utils.EmptyString {
+		ids = strings.Split(a.ExtraParameters, utils.InfieldSep)
+	}
+	ub.resetUsageCounters(ids, time.Now())
+	return
+}
@@ -0,0 +1,211 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// newUsageCountersTestDM sets up a rating plan charging 0.02 per minute for the first
+// 10 minutes on the MONTHLY counter and 0.01 per minute afterwards
+func newUsageCountersTestDM(t *testing.T, ucs UsageCounters) *DataManager {
+	cfg := config.NewDefaultCGRConfig()
+	dataDB, err := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tdm := NewDataManager(dataDB, cfg.CacheCfg(), nil)
+	if err = tdm.SetRatingPlan(&RatingPlan{
+		Id: "RP_TIERED",
+		Timings: map[string]*RITiming{
+			"ALWAYS": {StartTime: "00:00:00"},
+		},
+		Ratings: map[string]*RIRate{
+			"TIERED": {
+				RoundingMethod:   utils.MetaRoundingMiddle,
+				RoundingDecimals: 4,
+				UsageCounter:     "MONTHLY",
+				Rates: RateGroups{
+					{GroupIntervalStart: 0, Value: 0.02, RateIncrement: time.Minute, RateUnit: time.Minute},
+					{GroupIntervalStart: 10 * time.Minute, Value: 0.01, RateIncrement: time.Minute, RateUnit: time.Minute},
+				},
+			},
+		},
+		DestinationRates: map[string]RPRateList{
+			"DST_TIERED": {{Timing: "ALWAYS", Rating: "TIERED", Weight: 10}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = tdm.SetRatingProfile(&RatingProfile{
+		Id: "*out:cgrates.org:call:1001",
+		RatingPlanActivations: RatingPlanActivations{{
+			ActivationTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			RatingPlanId:   "RP_TIERED",
+		}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = tdm.SetReverseDestination("DST_TIERED", []string{"49"}, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err = tdm.SetAccount(&Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {{Uuid: "uuid1", ID: utils.MetaDefault, Value: 10}},
+		},
+		UsageCounters: ucs,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	tmpDm := dm
+	t.Cleanup(func() { SetDataStorage(tmpDm) })
+	SetDataStorage(tdm)
+	return tdm
+}
+
+func newUsageCountersTestCD(start, usage, durationIndex time.Duration) *CallDescriptor {
+	tStart := time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC).Add(start)
+	return &CallDescriptor{
+		Category:      "call",
+		Tenant:        "cgrates.org",
+		Subject:       "1001",
+		Account:       "1001",
+		Destination:   "4986517174963",
+		TimeStart:     tStart,
+		TimeEnd:       tStart.Add(usage),
+		DurationIndex: durationIndex,
+		ToR:           utils.MetaVoice,
+	}
+}
+
+func TestUsageCountersGetCost(t *testing.T) {
+	newUsageCountersTestDM(t, UsageCounters{"MONTHLY": {Usage: 8 * time.Minute}})
+	cc, err := newUsageCountersTestCD(0, 5*time.Minute, 0).GetCost()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2 minutes on the first tier and 3 on the second one
+	if cc.Cost != 0.07 {
+		t.Errorf("expected cost 0.07, received %v", cc.Cost)
+	}
+	if len(cc.Timespans) != 2 || cc.Timespans[0].GetDuration() != 2*time.Minute {
+		t.Fatalf("unexpected timespans: %s", utils.ToJSON(cc.Timespans))
+	}
+
+	ec := NewEventCostFromCallCost(cc, "cgrID", utils.MetaDefault)
+	var tiers []time.Duration
+	for _, cIl := range ec.Charges {
+		ru := ec.Rating[cIl.RatingID]
+		if ru.UsageCounter != "MONTHLY" {
+			t.Errorf("unexpected rating unit: %s", utils.ToJSON(ru))
+		}
+		tiers = append(tiers, ru.UsageTier)
+	}
+	if exp := []time.Duration{0, 10 * time.Minute}; !reflect.DeepEqual(exp, tiers) {
+		t.Errorf("expected tiers %v, received %v", exp, tiers)
+	}
+
+	// the previous part of the call is already on the counter
+	cc, err = newUsageCountersTestCD(5*time.Minute, 5*time.Minute, 10*time.Minute).GetCost()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cc.Cost != 0.07 {
+		t.Errorf("expected cost 0.07, received %v", cc.Cost)
+	}
+}
+
+func TestUsageCountersDebit(t *testing.T) {
+	tdm := newUsageCountersTestDM(t, nil)
+	cc, err := newUsageCountersTestCD(0, 5*time.Minute, 0).Debit(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cc.Cost != 0.1 {
+		t.Errorf("expected cost 0.1, received %v", cc.Cost)
+	}
+	acc, err := tdm.GetAccount("cgrates.org:1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uc := acc.UsageCounters["MONTHLY"]; uc == nil || uc.Usage != 5*time.Minute || uc.StartTime.IsZero() {
+		t.Fatalf("unexpected counters: %s", utils.ToJSON(acc.UsageCounters))
+	}
+
+	if cc, err = newUsageCountersTestCD(time.Hour, 10*time.Minute, 0).Debit(nil); err != nil {
+		t.Fatal(err)
+	}
+	if cc.Cost != 0.15 {
+		t.Errorf("expected cost 0.15, received %v", cc.Cost)
+	}
+	if acc, err = tdm.GetAccount("cgrates.org:1001"); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := acc.UsageCounters.Usage("MONTHLY"); rcv != 15*time.Minute {
+		t.Errorf("expected usage %v, received %v", 15*time.Minute, rcv)
+	}
+	if rcv := acc.BalanceMap[utils.MetaMonetary][0].GetValue(); rcv != 9.75 {
+		t.Errorf("expected balance 9.75, received %v", rcv)
+	}
+	if rcv, err := acc.FieldAsInterface([]string{utils.UsageCounters, "MONTHLY", utils.Usage}); err != nil {
+		t.Error(err)
+	} else if rcv != 15*time.Minute {
+		t.Errorf("expected usage %v, received %v", 15*time.Minute, rcv)
+	}
+}
+
+func TestUsageCountersResetAction(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	acc := &Account{
+		ID: "cgrates.org:1001",
+		UsageCounters: UsageCounters{
+			"MONTHLY": {Usage: time.Hour, StartTime: start},
+			"DAILY":   {Usage: time.Minute, StartTime: start},
+		},
+	}
+	if err := resetUsageCountersAction(acc, &Action{ExtraParameters: "DAILY;WEEKLY"},
+		nil, nil, nil, SharedActionsData{}, ActionConnCfg{}); err != nil {
+		t.Fatal(err)
+	}
+	if acc.UsageCounters["MONTHLY"].Usage != time.Hour || acc.UsageCounters["DAILY"].Usage != 0 ||
+		!acc.UsageCounters["DAILY"].StartTime.After(start) || acc.UsageCounters["WEEKLY"] == nil {
+		t.Errorf("unexpected counters: %s", utils.ToJSON(acc.UsageCounters))
+	}
+	if err := resetUsageCountersAction(acc, &Action{}, nil, nil, nil, SharedActionsData{}, ActionConnCfg{}); err != nil {
+		t.Fatal(err)
+	}
+	if acc.UsageCounters.Usage("MONTHLY") != 0 || !acc.UsageCounters["MONTHLY"].StartTime.After(start) {
+		t.Errorf("unexpected counters: %s", utils.ToJSON(acc.UsageCounters))
+	}
+	if err := resetUsageCountersAction(nil, &Action{}, nil, nil, nil, SharedActionsData{}, ActionConnCfg{}); err == nil {
+		t.Error("expected error for nil account")
+	}
+
+	cln := acc.Clone()
+	cln.UsageCounters["MONTHLY"].Usage = time.Minute
+	if acc.UsageCounters.Usage("MONTHLY") != 0 {
+		t.Error("expected a deep copy of the usage counters")
+	}
+}
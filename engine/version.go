@@ -132,7 +132,7 @@ func (vers Versions) Compare(curent Versions, storType string, isDataDB bool) st
 		}
 	case utils.MetaInternal:
 		message = allVers
-	case utils.MetaPostgres, utils.MetaMySQL:
+	case utils.MetaPostgres, utils.MetaMySQL, utils.MetaSQLite:
 		message = storDBVers
 	case utils.MetaRedis:
 		message = dataDBVers
@@ -180,7 +180,7 @@ func CurrentStorDBVersions() Versions {
 		utils.CDRs:               2,
 		utils.TpRatingPlans:      1,
 		utils.TpFilters:          1,
-		utils.TpDestinationRates: 1,
+		utils.TpDestinationRates: 2,
 		utils.TpActionTriggers:   1,
 		utils.TpAccountActionsV:  1,
 		utils.TpActionPlans:      1,
@@ -228,7 +228,7 @@ func CurrentDBVersions(storType string, isDataDB bool) Versions {
 		return CurrentStorDBVersions()
 	case utils.MetaInternal:
 		return CurrentAllDBVersions()
-	case utils.MetaPostgres, utils.MetaMySQL:
+	case utils.MetaPostgres, utils.MetaMySQL, utils.MetaSQLite:
 		return CurrentStorDBVersions()
 	case utils.MetaRedis:
 		return CurrentDataDBVersions()
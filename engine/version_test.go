@@ -98,7 +98,7 @@ func TestCurrentDBVersions(t *testing.T) {
 	}
 	expVersStorDB := Versions{
 		utils.CostDetails: 2, utils.SessionSCosts: 3, utils.CDRs: 2,
-		utils.TpRatingPlans: 1, utils.TpFilters: 1, utils.TpDestinationRates: 1,
+		utils.TpRatingPlans: 1, utils.TpFilters: 1, utils.TpDestinationRates: 2,
 		utils.TpActionTriggers: 1, utils.TpAccountActionsV: 1, utils.TpActionPlans: 1,
 		utils.TpActions: 1, utils.TpThresholds: 1, utils.TpRoutes: 1,
 		utils.TpStats: 1, utils.TpSharedGroups: 1, utils.TpRatingProfiles: 1,
@@ -79,6 +79,7 @@ var (
 		testOnStorITTestAttributeSubstituteIface,
 		testOnStorITChargerProfile,
 		testOnStorITDispatcherProfile,
+		testOnStorITDataSet,
 		//testOnStorITCacheActionTriggers,
 		//testOnStorITCRUDActionTriggers,
 	}
@@ -2246,3 +2247,76 @@ func testOnStorITDispatcherProfile(t *testing.T) {
 		t.Error(rcvErr)
 	}
 }
+
+func testOnStorITDataSet(t *testing.T) {
+	ds := NewDataSet("cgrates.org", "ROUTING")
+	ds.SetEntries([]*DataSetEntry{
+		{Value: "1001"},
+		{Value: "sip:1002@cgrates.org", Metadata: map[string]string{"Carrier": "CARRIER1"}},
+		{Value: "+49", Prefix: true, Metadata: map[string]string{"Price": "0.1"}},
+	})
+	if err := onStor.SetDataSet(ds); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := onStor.GetDataSet("cgrates.org", "ROUTING",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(ds, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(ds), utils.ToJSON(rcv))
+	}
+	expKeys := []string{"dts_cgrates.org:ROUTING"}
+	if keys, err := onStor.DataDB().GetKeysForPrefix(utils.DataSetPrefix, utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expKeys, keys) {
+		t.Errorf("Expected : %+v, but received %+v", expKeys, keys)
+	}
+
+	if err := onStor.AddDataSetEntries("cgrates.org", "ROUTING", []*DataSetEntry{
+		{Value: "1003"},
+		{Value: "+49", Prefix: true, Metadata: map[string]string{"Price": "0.2"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := onStor.RemoveDataSetEntries("cgrates.org", "ROUTING", []*DataSetEntry{
+		{Value: "1001"},
+		{Value: "1004"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ds.SetEntries([]*DataSetEntry{
+		{Value: "1003"},
+		{Value: "+49", Prefix: true, Metadata: map[string]string{"Price": "0.2"}},
+	})
+	ds.RemoveEntries([]*DataSetEntry{{Value: "1001"}})
+	if rcv, err := onStor.GetDataSet("cgrates.org", "ROUTING",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(ds, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(ds), utils.ToJSON(rcv))
+	}
+
+	// the set replaces all the entries
+	ds = NewDataSet("cgrates.org", "ROUTING")
+	ds.SetEntries([]*DataSetEntry{{Value: "1005"}})
+	if err := onStor.SetDataSet(ds); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := onStor.GetDataSet("cgrates.org", "ROUTING",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(ds, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(ds), utils.ToJSON(rcv))
+	}
+
+	if err := onStor.RemoveDataSet("cgrates.org", "ROUTING"); err != nil {
+		t.Error(err)
+	}
+	if _, err := onStor.GetDataSet("cgrates.org", "ROUTING",
+		false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err := onStor.RemoveDataSetEntries("cgrates.org", "ROUTING",
+		[]*DataSetEntry{{Value: "1005"}}); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
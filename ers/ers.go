@@ -44,6 +44,7 @@ type erEvent struct {
 	rawEvent map[string]any
 	cgrEvent *utils.CGREvent
 	rdrCfg   *config.EventReaderCfg
+	ack      func(error) bool // optional, called with the processing result (ie. to acknowledge the message at source), false if the message is kept at source to be retried
 }
 
 // NewERService instantiates the ERService
@@ -153,6 +154,10 @@ func (erS *ERService) ListenAndServe(stopChan, cfgRldChan chan struct{}) error {
 						fmt.Sprintf("<%s> reading event: <%s> from reader: <%s> got error: <%v>",
 							utils.ERs, utils.ToJSON(erEv.cgrEvent), erEv.rdrCfg.ID, err))
 				}
+				if erEv.ack != nil && !erEv.ack(err) {
+					<-erS.concurrentEvents
+					return // exported once the retries are over
+				}
 				if err = erS.exportRawEvent(erEv, err != nil); err != nil {
 					utils.Logger.Warning(
 						fmt.Sprintf("<%s> exporting event: <%s> from reader: <%s> got error: <%v>",
@@ -167,6 +172,9 @@ func (erS *ERService) ListenAndServe(stopChan, cfgRldChan chan struct{}) error {
 					fmt.Sprintf("<%s> reading partial event: <%s> from reader: <%s> got error: <%v>",
 						utils.ERs, utils.ToJSON(pEv.cgrEvent), pEv.rdrCfg.ID, err))
 			}
+			if pEv.ack != nil && !pEv.ack(err) {
+				continue // exported once the retries are over
+			}
 			if err = erS.exportRawEvent(pEv, err != nil); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> exporting partial event: <%s> from reader: <%s> got error: <%v>",
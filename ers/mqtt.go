@@ -0,0 +1,284 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// NewMQTTER return a new MQTT event reader
+func NewMQTTER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents, partialEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (EventReader, error) {
+	rdr := &MQTTER{
+		cgrCfg:        cfg,
+		cfgIdx:        cfgIdx,
+		fltrS:         fltrS,
+		rdrEvents:     rdrEvents,
+		partialEvents: partialEvents,
+		rdrExit:       rdrExit,
+		rdrErr:        rdrErr,
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+	}
+	if err := rdr.processOpts(); err != nil {
+		return nil, err
+	}
+	return rdr, nil
+}
+
+// MQTTER implements EventReader interface for MQTT messages
+type MQTTER struct {
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	rdrEvents     chan *erEvent // channel to dispatch the events created to
+	partialEvents chan *erEvent // channel to dispatch the partial events created to
+	rdrExit       chan struct{}
+	rdrErr        chan error
+	cap           chan struct{}
+
+	subscription string // topic filter, prefixed with $share/{group}/ for shared subscriptions
+	qos          byte
+	clientOpts   *mqtt.ClientOptions
+	connLost     chan error // receives the reason once the connection to the broker is lost
+}
+
+// Config returns the curent configuration
+func (rdr *MQTTER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve connects to the MQTT broker and processes the messages received on the
+// subscribed topics until the rdrExit channel is closed
+func (rdr *MQTTER) Serve() (err error) {
+	if rdr.Config().RunDelay == time.Duration(0) { // 0 disables the automatic read, maybe done per API
+		return
+	}
+	var c mqtt.Client
+	if c, err = rdr.connect(); err != nil {
+		return
+	}
+	go rdr.serve(c)
+	return
+}
+
+// connect opens a new connection to the broker
+func (rdr *MQTTER) connect() (c mqtt.Client, err error) {
+	c = mqtt.NewClient(rdr.clientOpts)
+	if err = utils.MQTTWait(c.Connect(), rdr.clientOpts.ConnectTimeout); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// serve keeps the subscription active, reconnecting when the connection to the broker is lost
+func (rdr *MQTTER) serve(c mqtt.Client) {
+	if rdr.Config().StartDelay > 0 {
+		select {
+		case <-time.After(rdr.Config().StartDelay):
+		case <-rdr.rdrExit:
+			c.Disconnect(0)
+			return
+		}
+	}
+	for {
+		if err := utils.MQTTWait(c.Subscribe(rdr.subscription, rdr.qos, rdr.handleMessage),
+			rdr.clientOpts.ConnectTimeout); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> reader <%s> failed subscribing to <%s>: %v",
+					utils.ERs, rdr.Config().ID, rdr.subscription, err))
+			c.Disconnect(0)
+			select { // treat it as a lost connection
+			case rdr.connLost <- err:
+			default:
+			}
+		}
+		var err error
+		select {
+		case <-rdr.rdrExit:
+			utils.Logger.Info(
+				fmt.Sprintf("<%s> stop monitoring mqtt path <%s>",
+					utils.ERs, rdr.Config().SourcePath))
+			c.Disconnect(0)
+			return
+		case err = <-rdr.connLost:
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> reader <%s> lost connection to MQTT broker <%s>: %v",
+					utils.ERs, rdr.Config().ID, rdr.Config().SourcePath, err))
+		}
+		if c, err = rdr.reconnect(err); err != nil {
+			if err != utils.ErrDisconnected { // not stopped by us
+				select {
+				case rdr.rdrErr <- err:
+				case <-rdr.rdrExit:
+				}
+			}
+			return
+		}
+	}
+}
+
+// reconnect retries connecting to the broker based on the reconnects configuration,
+// returning the last error when giving up
+func (rdr *MQTTER) reconnect(err error) (c mqtt.Client, _ error) {
+	fib := utils.FibDuration(time.Second, rdr.Config().MaxReconnectInterval)
+	for retries := 0; retries < rdr.Config().Reconnects ||
+		rdr.Config().Reconnects == -1; retries++ { // -1 retries indefinitely
+		select {
+		case <-rdr.rdrExit:
+			return nil, utils.ErrDisconnected
+		case <-time.After(fib()):
+		}
+		if c, err = rdr.connect(); err == nil {
+			return c, nil
+		}
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> reader <%s> failed to connect to MQTT broker <%s>, will retry. Error: %v",
+				utils.ERs, rdr.Config().ID, rdr.Config().SourcePath, err))
+	}
+	return nil, err
+}
+
+func (rdr *MQTTER) handleMessage(_ mqtt.Client, msg mqtt.Message) {
+	topic, payload := msg.Topic(), msg.Payload()
+	// If the rdr.cap channel buffer is full, block until a resource is available.
+	if rdr.Config().ConcurrentReqs != -1 {
+		rdr.cap <- struct{}{}
+	}
+	go func() {
+		if err := rdr.processMessage(topic, payload); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> processing message %s error: %s",
+					utils.ERs, string(payload), err.Error()))
+		}
+		if rdr.Config().ConcurrentReqs != -1 {
+			<-rdr.cap
+		}
+	}()
+}
+
+func (rdr *MQTTER) processMessage(topic string, msg []byte) (err error) {
+	var decodedMessage map[string]any
+	if err = json.Unmarshal(msg, &decodedMessage); err != nil {
+		return
+	}
+
+	reqVars := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+		utils.MetaReaderID:  utils.NewLeafNode(rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx].ID),
+		utils.MetaMQTTTopic: utils.NewLeafNode(topic),
+	}}
+
+	agReq := agents.NewAgentRequest(
+		utils.MapStorage(decodedMessage), reqVars,
+		nil, nil, nil, rdr.Config().Tenant,
+		rdr.cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(rdr.Config().Timezone,
+			rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+		rdr.fltrS, nil) // create an AgentRequest
+	var pass bool
+	if pass, err = rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+		agReq); err != nil || !pass {
+		return
+	}
+	if err = agReq.SetFields(rdr.Config().Fields); err != nil {
+		return
+	}
+	cgrEv := utils.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+	rdrEv := rdr.rdrEvents
+	if _, isPartial := cgrEv.APIOpts[utils.PartialOpt]; isPartial {
+		rdrEv = rdr.partialEvents
+	}
+	rdrEv <- &erEvent{
+		cgrEvent: cgrEv,
+		rdrCfg:   rdr.Config(),
+	}
+	return
+}
+
+func (rdr *MQTTER) processOpts() (err error) {
+	rdr.subscription = utils.DefaultQueueID
+	rdr.qos = utils.MQTTDefaultQoS
+	rdr.connLost = make(chan error, 1)
+	rdr.clientOpts = mqtt.NewClientOptions().
+		SetClientID(utils.CGRateSLwr + utils.Underline + rdr.cgrCfg.GeneralCfg().NodeID + utils.Underline + rdr.Config().ID).
+		SetConnectTimeout(rdr.cgrCfg.GeneralCfg().ConnectTimeout).
+		SetAutoReconnect(false). // reconnects are handled by the reader based on its configuration
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			select {
+			case rdr.connLost <- err:
+			default:
+			}
+		})
+	mqttOpts := rdr.Config().Opts.MQTT
+	if mqttOpts == nil {
+		rdr.clientOpts.AddBroker(utils.MQTTBrokerURL(rdr.Config().SourcePath, false))
+		return
+	}
+	if mqttOpts.Topic != nil {
+		rdr.subscription = *mqttOpts.Topic
+	}
+	if mqttOpts.SharedGroup != nil && *mqttOpts.SharedGroup != utils.EmptyString {
+		rdr.subscription = "$share/" + *mqttOpts.SharedGroup + "/" + rdr.subscription
+	}
+	if mqttOpts.QoS != nil {
+		rdr.qos = byte(*mqttOpts.QoS)
+	}
+	if mqttOpts.ClientID != nil {
+		rdr.clientOpts.SetClientID(*mqttOpts.ClientID)
+	}
+	if mqttOpts.Username != nil {
+		rdr.clientOpts.SetUsername(*mqttOpts.Username)
+	}
+	if mqttOpts.Password != nil {
+		rdr.clientOpts.SetPassword(*mqttOpts.Password)
+	}
+	useTLS := mqttOpts.TLS != nil && *mqttOpts.TLS
+	rdr.clientOpts.AddBroker(utils.MQTTBrokerURL(rdr.Config().SourcePath, useTLS))
+	if useTLS {
+		var caPath, certPath, keyPath string
+		if mqttOpts.CAPath != nil {
+			caPath = *mqttOpts.CAPath
+		}
+		if mqttOpts.ClientCertificate != nil {
+			certPath = *mqttOpts.ClientCertificate
+		}
+		if mqttOpts.ClientKey != nil {
+			keyPath = *mqttOpts.ClientKey
+		}
+		var tlsCfg *tls.Config
+		if tlsCfg, err = utils.NewMQTTTLSConfig(caPath, certPath, keyPath,
+			mqttOpts.SkipTLSVerify != nil && *mqttOpts.SkipTLSVerify); err != nil {
+			return
+		}
+		rdr.clientOpts.SetTLSConfig(tlsCfg)
+	}
+	return
+}
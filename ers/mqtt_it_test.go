@@ -0,0 +1,107 @@
+//go:build integration
+
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+func TestMQTTERServe(t *testing.T) {
+	cmd := exec.Command("mosquitto", "-p", "1883")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err) // most probably not installed
+	}
+	time.Sleep(50 * time.Millisecond)
+	defer cmd.Process.Kill()
+
+	cfg := config.NewDefaultCGRConfig()
+	rdrCfg := cfg.ERsCfg().Readers[0]
+	rdrCfg.Type = utils.MetaMQTTjsonMap
+	rdrCfg.SourcePath = "127.0.0.1:1883"
+	rdrCfg.RunDelay = -1
+	rdrCfg.ConcurrentReqs = 1
+	rdrCfg.Opts.MQTT = &config.MQTTROpts{
+		Topic: utils.StringPointer("cgrates/+/cdrs"),
+	}
+	rdrCfg.Fields = []*config.FCTemplate{
+		{
+			Tag:   "OriginID",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.OriginID", utils.InfieldSep),
+			Path:  "*cgreq.OriginID",
+		},
+		{
+			Tag:   "OriginHost",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*vars.*mqttTopic", utils.InfieldSep),
+			Path:  "*cgreq.OriginHost",
+		},
+	}
+	for _, fld := range rdrCfg.Fields {
+		fld.ComputePath()
+	}
+	rdrEvents := make(chan *erEvent, 1)
+	rdrExit := make(chan struct{})
+	defer close(rdrExit)
+	rdr, err := NewMQTTER(cfg, 0, rdrEvents, make(chan *erEvent, 1),
+		make(chan error, 1), new(engine.FilterS), rdrExit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = rdr.Serve(); err != nil {
+		t.Fatal(err)
+	}
+
+	pub := mqtt.NewClient(mqtt.NewClientOptions().AddBroker("tcp://127.0.0.1:1883"))
+	if tkn := pub.Connect(); tkn.Wait() && tkn.Error() != nil {
+		t.Fatal(tkn.Error())
+	}
+	defer pub.Disconnect(0)
+	// the subscription is made asynchronously so keep publishing until the reader gets the message
+	for i := 0; ; i++ {
+		if tkn := pub.Publish("cgrates/dev1/cdrs", 1, false, []byte(`{"OriginID":"abc"}`)); tkn.Wait() && tkn.Error() != nil {
+			t.Fatal(tkn.Error())
+		}
+		select {
+		case ev := <-rdrEvents:
+			exp := map[string]any{
+				utils.OriginID:   "abc",
+				utils.OriginHost: "cgrates/dev1/cdrs",
+			}
+			if !reflect.DeepEqual(exp, ev.cgrEvent.Event) {
+				t.Errorf("expected %v, received %v", exp, ev.cgrEvent.Event)
+			}
+			return
+		case <-time.After(20 * time.Millisecond):
+		}
+		if i == 50 {
+			t.Fatal("timeout waiting for event")
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestMQTTERProcessOpts(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	rdrCfg := cfg.ERsCfg().Readers[0]
+	rdrCfg.ID = "mqtt"
+	rdrCfg.SourcePath = "tcp://127.0.0.1:1883"
+	rdrCfg.Opts.MQTT = &config.MQTTROpts{
+		Topic:       utils.StringPointer("cgrates/+/cdrs"),
+		QoS:         utils.IntPointer(2),
+		SharedGroup: utils.StringPointer("ers"),
+		Username:    utils.StringPointer("cgrates"),
+		Password:    utils.StringPointer("secret"),
+	}
+	rdr, err := NewMQTTER(cfg, 0, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mqttRdr := rdr.(*MQTTER)
+	if exp := "$share/ers/cgrates/+/cdrs"; mqttRdr.subscription != exp {
+		t.Errorf("expected %q, received %q", exp, mqttRdr.subscription)
+	}
+	if mqttRdr.qos != 2 {
+		t.Errorf("expected QoS 2, received %d", mqttRdr.qos)
+	}
+	if opts := mqttRdr.clientOpts; len(opts.Servers) != 1 ||
+		opts.Servers[0].String() != "tcp://127.0.0.1:1883" ||
+		opts.ClientID != "cgrates_"+cfg.GeneralCfg().NodeID+"_mqtt" ||
+		opts.Username != "cgrates" ||
+		opts.Password != "secret" ||
+		opts.ConnectTimeout != cfg.GeneralCfg().ConnectTimeout ||
+		opts.AutoReconnect {
+		t.Errorf("unexpected client options: %+v", opts)
+	}
+
+	rdrCfg.Opts.MQTT = &config.MQTTROpts{
+		TLS:    utils.BoolPointer(true),
+		CAPath: utils.StringPointer("/tmp/inexistent_ca.pem"),
+	}
+	if _, err = NewMQTTER(cfg, 0, nil, nil, nil, nil, nil); err == nil {
+		t.Error("expected error for missing CA file")
+	}
+}
+
+func TestMQTTERServeDisabled(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ERsCfg().Readers[0].RunDelay = 0
+	cfg.ERsCfg().Readers[0].SourcePath = "127.0.0.1:1"
+	rdr, err := NewMQTTER(cfg, 0, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = rdr.Serve(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,443 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/mediocregopher/radix/v3/resp/resp2"
+)
+
+const (
+	redisStreamDefaultCount         = 10
+	redisStreamDefaultBlock         = time.Second
+	redisStreamDefaultClaimMinIdle  = time.Minute
+	redisStreamDefaultClaimInterval = 30 * time.Second
+	redisStreamDefaultMaxDeliveries = 5
+	redisStreamPoolSize             = 4
+	redisStreamReadTimeout          = 10 * time.Second // on top of the BLOCK duration
+)
+
+// NewRedisStreamER return a new Redis Streams event reader
+func NewRedisStreamER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents, partialEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (EventReader, error) {
+	rdr := &RedisStreamER{
+		cgrCfg:        cfg,
+		cfgIdx:        cfgIdx,
+		fltrS:         fltrS,
+		rdrEvents:     rdrEvents,
+		partialEvents: partialEvents,
+		rdrExit:       rdrExit,
+		rdrErr:        rdrErr,
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+	}
+	if err := rdr.processOpts(); err != nil {
+		return nil, err
+	}
+	return rdr, nil
+}
+
+// RedisStreamER implements EventReader interface for Redis Streams, reading
+// the entries as part of a consumer group
+type RedisStreamER struct {
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	rdrEvents     chan *erEvent // channel to dispatch the events created to
+	partialEvents chan *erEvent // channel to dispatch the partial events created to
+	rdrExit       chan struct{}
+	rdrErr        chan error
+	cap           chan struct{}
+
+	stream        string
+	group         string
+	consumer      string
+	field         string // entry field holding the JSON event
+	count         int
+	block         time.Duration
+	claimMinIdle  time.Duration // 0 disables the reclaim of the pending entries
+	claimInterval time.Duration
+	maxDeliveries int // the failed entries are retried forever if 0
+	clientOpts    utils.RedisStreamClientOpts
+
+	client radix.Client
+}
+
+// Config returns the curent configuration
+func (rdr *RedisStreamER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve connects to the Redis server, creating the consumer group if missing,
+// and processes the stream entries until the rdrExit channel is closed
+func (rdr *RedisStreamER) Serve() (err error) {
+	if rdr.Config().RunDelay == time.Duration(0) { // 0 disables the automatic read, maybe done per API
+		return
+	}
+	if rdr.client, err = utils.NewRedisStreamClient(rdr.clientOpts); err != nil {
+		return
+	}
+	if err = rdr.createGroup(); err != nil {
+		rdr.client.Close()
+		return
+	}
+	go rdr.serve()
+	return
+}
+
+// createGroup creates the consumer group at the beginning of the stream,
+// creating the stream as well if it does not exist
+func (rdr *RedisStreamER) createGroup() (err error) {
+	if err = rdr.client.Do(radix.Cmd(nil, "XGROUP", "CREATE",
+		rdr.stream, rdr.group, "0", "MKSTREAM")); err != nil &&
+		strings.HasPrefix(err.Error(), "BUSYGROUP") { // group already created
+		err = nil
+	}
+	return
+}
+
+func (rdr *RedisStreamER) serve() {
+	if rdr.Config().StartDelay > 0 {
+		select {
+		case <-time.After(rdr.Config().StartDelay):
+		case <-rdr.rdrExit:
+			rdr.client.Close()
+			return
+		}
+	}
+	lastID := "0" // start with the entries left pending for this consumer by a previous run
+	var lastClaim time.Time
+	var fib func() time.Duration
+	var failures int
+	for {
+		select {
+		case <-rdr.rdrExit:
+			utils.Logger.Info(
+				fmt.Sprintf("<%s> stop monitoring redis stream <%s>",
+					utils.ERs, rdr.stream))
+			rdr.client.Close()
+			return
+		default:
+		}
+		err := rdr.claimPending(&lastClaim)
+		if err == nil {
+			err = rdr.readGroup(&lastID)
+		}
+		if err == nil {
+			failures, fib = 0, nil
+			continue
+		}
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> reader <%s> failed reading redis stream <%s>: %v",
+				utils.ERs, rdr.Config().ID, rdr.stream, err))
+		if strings.HasPrefix(err.Error(), "NOGROUP") { // stream or group removed meanwhile
+			if err = rdr.createGroup(); err == nil {
+				continue
+			}
+		}
+		if failures++; rdr.Config().Reconnects != -1 &&
+			failures > rdr.Config().Reconnects {
+			rdr.client.Close()
+			select {
+			case rdr.rdrErr <- err:
+			case <-rdr.rdrExit:
+			}
+			return
+		}
+		if fib == nil {
+			fib = utils.FibDuration(time.Second, rdr.Config().MaxReconnectInterval)
+		}
+		select {
+		case <-rdr.rdrExit:
+		case <-time.After(fib()):
+		}
+	}
+}
+
+// readGroup reads the next entries for this consumer, switching to the new
+// entries once the ones pending from a previous run are consumed
+func (rdr *RedisStreamER) readGroup(lastID *string) (err error) {
+	args := []string{"GROUP", rdr.group, rdr.consumer,
+		"COUNT", strconv.Itoa(rdr.count)}
+	if *lastID == ">" {
+		args = append(args, "BLOCK", strconv.FormatInt(rdr.block.Milliseconds(), 10))
+	}
+	args = append(args, "STREAMS", rdr.stream, *lastID)
+	var strms []radix.StreamEntries
+	if err = rdr.client.Do(radix.Cmd(&strms, "XREADGROUP", args...)); err != nil {
+		return
+	}
+	var entries []radix.StreamEntry
+	for _, strm := range strms {
+		entries = append(entries, strm.Entries...)
+	}
+	if *lastID != ">" {
+		if len(entries) == 0 {
+			*lastID = ">"
+			return
+		}
+		*lastID = entries[len(entries)-1].ID.String()
+	}
+	for _, entry := range entries {
+		rdr.handleEntry(entry)
+	}
+	return
+}
+
+// xAutoClaimReply is the reply of XAUTOCLAIM, the list of deleted IDs
+// returned by newer servers is ignored
+type xAutoClaimReply struct {
+	next    string
+	entries []radix.StreamEntry
+}
+
+func (r *xAutoClaimReply) UnmarshalRESP(br *bufio.Reader) (err error) {
+	var ah resp2.ArrayHeader
+	if err = ah.UnmarshalRESP(br); err != nil {
+		return
+	}
+	if ah.N < 2 {
+		return fmt.Errorf("unexpected XAUTOCLAIM reply with %d elements", ah.N)
+	}
+	if err = (resp2.Any{I: &r.next}).UnmarshalRESP(br); err != nil {
+		return
+	}
+	if err = (resp2.Any{I: &r.entries}).UnmarshalRESP(br); err != nil {
+		return
+	}
+	for i := 2; i < ah.N; i++ {
+		if err = (resp2.Any{}).UnmarshalRESP(br); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// claimPending takes over the entries which were delivered but not acknowledged
+// for longer than claimMinIdle, left behind by crashed consumers or failed processing
+func (rdr *RedisStreamER) claimPending(lastClaim *time.Time) (err error) {
+	if rdr.claimMinIdle == 0 ||
+		time.Since(*lastClaim) < rdr.claimInterval {
+		return
+	}
+	*lastClaim = time.Now()
+	minIdle := strconv.FormatInt(rdr.claimMinIdle.Milliseconds(), 10)
+	for start := "0-0"; ; {
+		var rply xAutoClaimReply
+		if err = rdr.client.Do(radix.Cmd(&rply, "XAUTOCLAIM", rdr.stream, rdr.group,
+			rdr.consumer, minIdle, start, "COUNT", strconv.Itoa(rdr.count))); err != nil {
+			return
+		}
+		for _, entry := range rply.entries {
+			rdr.handleEntry(entry)
+		}
+		if rply.next == "0-0" || rply.next == utils.EmptyString {
+			return
+		}
+		start = rply.next
+	}
+}
+
+// ack acknowledges the entry so it is removed from the pending entries list of the group
+func (rdr *RedisStreamER) ack(id string) {
+	if err := rdr.client.Do(radix.Cmd(nil, "XACK", rdr.stream, rdr.group, id)); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> reader <%s> failed to acknowledge entry <%s> from redis stream <%s>: %v",
+				utils.ERs, rdr.Config().ID, id, rdr.stream, err))
+	}
+}
+
+// retriesExhausted checks if the entry was already delivered maxDeliveries times
+func (rdr *RedisStreamER) retriesExhausted(id string) bool {
+	if rdr.maxDeliveries == 0 {
+		return false
+	}
+	var pending [][]string // ID, consumer, idle time and deliveries for each entry
+	if err := rdr.client.Do(radix.Cmd(&pending, "XPENDING", rdr.stream, rdr.group,
+		id, id, "1")); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> reader <%s> failed to check the deliveries of entry <%s> from redis stream <%s>: %v",
+				utils.ERs, rdr.Config().ID, id, rdr.stream, err))
+		return false
+	}
+	if len(pending) == 0 || len(pending[0]) < 4 {
+		return false
+	}
+	deliveries, err := strconv.Atoi(pending[0][3])
+	return err == nil && deliveries >= rdr.maxDeliveries
+}
+
+func (rdr *RedisStreamER) handleEntry(entry radix.StreamEntry) {
+	// If the rdr.cap channel buffer is full, block until a resource is available.
+	if rdr.Config().ConcurrentReqs != -1 {
+		rdr.cap <- struct{}{}
+	}
+	go func() {
+		if err := rdr.processEntry(entry); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> processing redis stream entry <%s> error: %s",
+					utils.ERs, entry.ID.String(), err.Error()))
+			rdr.ack(entry.ID.String()) // not able to build an event out of it, retrying will not help
+		}
+		if rdr.Config().ConcurrentReqs != -1 {
+			<-rdr.cap
+		}
+	}()
+}
+
+// processEntry builds the event out of the stream entry and dispatches it to ERs,
+// the entry being acknowledged only after it was processed successfully
+func (rdr *RedisStreamER) processEntry(entry radix.StreamEntry) (err error) {
+	id := entry.ID.String()
+	msg, has := entry.Fields[rdr.field]
+	if !has {
+		return errors.New("missing field: " + rdr.field)
+	}
+	var decodedMessage map[string]any
+	if err = json.Unmarshal([]byte(msg), &decodedMessage); err != nil {
+		return
+	}
+
+	reqVars := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+		utils.MetaReaderID:      utils.NewLeafNode(rdr.Config().ID),
+		utils.MetaRedisStreamID: utils.NewLeafNode(id),
+	}}
+
+	agReq := agents.NewAgentRequest(
+		utils.MapStorage(decodedMessage), reqVars,
+		nil, nil, nil, rdr.Config().Tenant,
+		rdr.cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(rdr.Config().Timezone,
+			rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+		rdr.fltrS, nil) // create an AgentRequest
+	var pass bool
+	if pass, err = rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+		agReq); err != nil {
+		return
+	}
+	if !pass { // nothing to process
+		rdr.ack(id)
+		return
+	}
+	if err = agReq.SetFields(rdr.Config().Fields); err != nil {
+		return
+	}
+	cgrEv := utils.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+	rdrEv := rdr.rdrEvents
+	if _, isPartial := cgrEv.APIOpts[utils.PartialOpt]; isPartial {
+		rdrEv = rdr.partialEvents
+	}
+	rdrEv <- &erEvent{
+		rawEvent: decodedMessage,
+		cgrEvent: cgrEv,
+		rdrCfg:   rdr.Config(),
+		ack: func(err error) bool {
+			if err != nil && !rdr.retriesExhausted(id) {
+				return false // left pending, to be retried once claimed
+			}
+			if err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> reader <%s> dropping entry <%s> from redis stream <%s> after %d deliveries",
+						utils.ERs, rdr.Config().ID, id, rdr.stream, rdr.maxDeliveries))
+			}
+			rdr.ack(id)
+			return true
+		},
+	}
+	return
+}
+
+func (rdr *RedisStreamER) processOpts() (err error) {
+	rdr.stream = utils.DefaultQueueID
+	rdr.group = utils.RedisStreamDefaultGroup
+	rdr.consumer = utils.CGRateSLwr + utils.Underline + rdr.cgrCfg.GeneralCfg().NodeID + utils.Underline + rdr.Config().ID
+	rdr.field = utils.RedisStreamDefaultField
+	rdr.count = redisStreamDefaultCount
+	rdr.block = redisStreamDefaultBlock
+	rdr.claimMinIdle = redisStreamDefaultClaimMinIdle
+	rdr.claimInterval = redisStreamDefaultClaimInterval
+	rdr.maxDeliveries = redisStreamDefaultMaxDeliveries
+	rdr.clientOpts = utils.RedisStreamClientOpts{
+		Address:        rdr.Config().SourcePath,
+		ConnectTimeout: rdr.cgrCfg.GeneralCfg().ConnectTimeout,
+		ReadTimeout:    redisStreamDefaultBlock + redisStreamReadTimeout,
+		PoolSize:       redisStreamPoolSize,
+	}
+	redisOpts := rdr.Config().Opts.RedisStream
+	if redisOpts == nil {
+		return
+	}
+	if redisOpts.Stream != nil {
+		rdr.stream = *redisOpts.Stream
+	}
+	if redisOpts.Group != nil {
+		rdr.group = *redisOpts.Group
+	}
+	if redisOpts.Consumer != nil && *redisOpts.Consumer != utils.EmptyString {
+		rdr.consumer = *redisOpts.Consumer
+	}
+	if redisOpts.Field != nil {
+		rdr.field = *redisOpts.Field
+	}
+	if redisOpts.Count != nil {
+		rdr.count = *redisOpts.Count
+	}
+	if redisOpts.Block != nil {
+		rdr.block = *redisOpts.Block
+		rdr.clientOpts.ReadTimeout = rdr.block + redisStreamReadTimeout
+	}
+	if redisOpts.ClaimMinIdle != nil {
+		rdr.claimMinIdle = *redisOpts.ClaimMinIdle
+	}
+	if redisOpts.ClaimInterval != nil {
+		rdr.claimInterval = *redisOpts.ClaimInterval
+	}
+	if redisOpts.MaxDeliveries != nil {
+		rdr.maxDeliveries = *redisOpts.MaxDeliveries
+	}
+	if redisOpts.TLS != nil && *redisOpts.TLS {
+		var caPath, certPath, keyPath string
+		if redisOpts.CACertificate != nil {
+			caPath = *redisOpts.CACertificate
+		}
+		if redisOpts.ClientCertificate != nil {
+			certPath = *redisOpts.ClientCertificate
+		}
+		if redisOpts.ClientKey != nil {
+			keyPath = *redisOpts.ClientKey
+		}
+		rdr.clientOpts.TLSConfig, err = utils.NewMQTTTLSConfig(caPath, certPath, keyPath, false)
+	}
+	return
+}
@@ -0,0 +1,387 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/mediocregopher/radix/v3/resp/resp2"
+)
+
+// redisStreamStub records the commands received and answers them using the reply function
+type redisStreamStub struct {
+	sync.Mutex
+	radix.Conn
+	cmds [][]string
+}
+
+func newRedisStreamStub(reply func(args []string) any) *redisStreamStub {
+	s := new(redisStreamStub)
+	s.Conn = radix.Stub(utils.TCP, "127.0.0.1:6379", func(args []string) any {
+		s.cmds = append(s.cmds, args)
+		return reply(args)
+	})
+	return s
+}
+
+func redisStreamStubErr(msg string) resp2.Error {
+	return resp2.Error{E: errors.New(msg)}
+}
+
+func (s *redisStreamStub) Do(a radix.Action) error {
+	s.Lock()
+	defer s.Unlock()
+	return s.Conn.Do(a)
+}
+
+func (s *redisStreamStub) commands(name string) (cmds [][]string) {
+	s.Lock()
+	defer s.Unlock()
+	for _, cmd := range s.cmds {
+		if cmd[0] == name {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return
+}
+
+func newTestRedisStreamER(t *testing.T, rdrEvents chan *erEvent) *RedisStreamER {
+	cfg := config.NewDefaultCGRConfig()
+	rdrCfg := cfg.ERsCfg().Readers[0]
+	rdrCfg.ID = "redis"
+	rdrCfg.Type = utils.MetaRedisStreamjsonMap
+	rdrCfg.ConcurrentReqs = 1
+	rdrCfg.Opts.RedisStream = &config.RedisStreamROpts{
+		Stream: utils.StringPointer("cdrs"),
+	}
+	rdrCfg.Fields = []*config.FCTemplate{
+		{
+			Tag:   "OriginID",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.OriginID", utils.InfieldSep),
+			Path:  "*cgreq.OriginID",
+		},
+		{
+			Tag:   "OriginHost",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*vars.*redisStreamID", utils.InfieldSep),
+			Path:  "*cgreq.OriginHost",
+		},
+	}
+	for _, fld := range rdrCfg.Fields {
+		fld.ComputePath()
+	}
+	data, err := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fltrS := engine.NewFilterS(cfg, nil, engine.NewDataManager(data, cfg.CacheCfg(), nil))
+	rdr, err := NewRedisStreamER(cfg, 0, rdrEvents, make(chan *erEvent, 1),
+		make(chan error, 1), fltrS, make(chan struct{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rdr.(*RedisStreamER)
+}
+
+func TestRedisStreamERProcessOpts(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	rdrCfg := cfg.ERsCfg().Readers[0]
+	rdrCfg.ID = "redis"
+	rdrCfg.SourcePath = "redis://127.0.0.1:6379/1"
+	rdrCfg.Opts.RedisStream = &config.RedisStreamROpts{
+		Stream:       utils.StringPointer("cdrs"),
+		Group:        utils.StringPointer("billing"),
+		Field:        utils.StringPointer("cdr"),
+		Count:        utils.IntPointer(100),
+		Block:        utils.DurationPointer(5 * time.Second),
+		ClaimMinIdle: utils.DurationPointer(0),
+	}
+	rdr, err := NewRedisStreamER(cfg, 0, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	redisRdr := rdr.(*RedisStreamER)
+	if redisRdr.stream != "cdrs" || redisRdr.group != "billing" ||
+		redisRdr.field != "cdr" || redisRdr.count != 100 ||
+		redisRdr.block != 5*time.Second || redisRdr.claimMinIdle != 0 ||
+		redisRdr.claimInterval != redisStreamDefaultClaimInterval {
+		t.Errorf("unexpected reader options: %+v", redisRdr)
+	}
+	if exp := "cgrates_" + cfg.GeneralCfg().NodeID + "_redis"; redisRdr.consumer != exp {
+		t.Errorf("expected %q, received %q", exp, redisRdr.consumer)
+	}
+	exp := utils.RedisStreamClientOpts{
+		Address:        "redis://127.0.0.1:6379/1",
+		ConnectTimeout: cfg.GeneralCfg().ConnectTimeout,
+		ReadTimeout:    5*time.Second + redisStreamReadTimeout,
+		PoolSize:       redisStreamPoolSize,
+	}
+	if !reflect.DeepEqual(exp, redisRdr.clientOpts) {
+		t.Errorf("expected %+v, received %+v", exp, redisRdr.clientOpts)
+	}
+
+	rdrCfg.Opts.RedisStream = &config.RedisStreamROpts{
+		TLS:           utils.BoolPointer(true),
+		CACertificate: utils.StringPointer("/tmp/inexistent_ca.pem"),
+	}
+	if _, err = NewRedisStreamER(cfg, 0, nil, nil, nil, nil, nil); err == nil {
+		t.Error("expected error for missing CA file")
+	}
+}
+
+func TestRedisStreamERCreateGroup(t *testing.T) {
+	rdr := newTestRedisStreamER(t, nil)
+	var rply any = "OK"
+	stub := newRedisStreamStub(func(args []string) any { return rply })
+	rdr.client = stub
+	if err := rdr.createGroup(); err != nil {
+		t.Fatal(err)
+	}
+	if exp := [][]string{{"XGROUP", "CREATE", "cdrs", "cgrates", "0", "MKSTREAM"}}; !reflect.DeepEqual(exp, stub.commands("XGROUP")) {
+		t.Errorf("expected %q, received %q", exp, stub.commands("XGROUP"))
+	}
+	rply = redisStreamStubErr("BUSYGROUP Consumer Group name already exists")
+	if err := rdr.createGroup(); err != nil {
+		t.Error(err)
+	}
+	rply = redisStreamStubErr("WRONGTYPE Operation against a key holding the wrong kind of value")
+	if err := rdr.createGroup(); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestRedisStreamERReadGroup(t *testing.T) {
+	rdrEvents := make(chan *erEvent, 1)
+	rdr := newTestRedisStreamER(t, rdrEvents)
+	stub := newRedisStreamStub(func(args []string) any {
+		switch args[0] {
+		case "XREADGROUP":
+			switch args[len(args)-1] {
+			case "0": // pending entries from a previous run
+				return []any{[]any{"cdrs", []any{
+					[]any{"1-0", []any{utils.RedisStreamDefaultField, `{"OriginID":"pending"}`}},
+				}}}
+			case "1-0":
+				return []any{[]any{"cdrs", []any{}}}
+			default:
+				return []any{[]any{"cdrs", []any{
+					[]any{"2-0", []any{utils.RedisStreamDefaultField, `{"OriginID":"new"}`}},
+				}}}
+			}
+		case "XACK":
+			return 1
+		}
+		return nil
+	})
+	rdr.client = stub
+
+	lastID := "0"
+	for _, exp := range []struct {
+		id       string
+		originID string
+		lastID   string
+	}{
+		{"1-0", "pending", "1-0"},
+		{"", "", ">"},
+		{"2-0", "new", ">"},
+	} {
+		if err := rdr.readGroup(&lastID); err != nil {
+			t.Fatal(err)
+		}
+		if lastID != exp.lastID {
+			t.Errorf("expected last ID %q, received %q", exp.lastID, lastID)
+		}
+		if exp.id == utils.EmptyString {
+			continue
+		}
+		select {
+		case ev := <-rdrEvents:
+			expEv := map[string]any{
+				utils.OriginID:   exp.originID,
+				utils.OriginHost: exp.id,
+			}
+			if !reflect.DeepEqual(expEv, ev.cgrEvent.Event) {
+				t.Errorf("expected %v, received %v", expEv, ev.cgrEvent.Event)
+			}
+			ev.ack(utils.ErrPartiallyExecuted) // failed processing is not acknowledged
+			ev.ack(nil)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+	}
+	if exp := [][]string{
+		{"XACK", "cdrs", "cgrates", "1-0"},
+		{"XACK", "cdrs", "cgrates", "2-0"},
+	}; !reflect.DeepEqual(exp, stub.commands("XACK")) {
+		t.Errorf("expected %q, received %q", exp, stub.commands("XACK"))
+	}
+	xRead := stub.commands("XREADGROUP")
+	if exp := []string{"XREADGROUP", "GROUP", "cgrates", rdr.consumer, "COUNT", "10",
+		"BLOCK", "1000", "STREAMS", "cdrs", ">"}; !reflect.DeepEqual(exp, xRead[len(xRead)-1]) {
+		t.Errorf("expected %q, received %q", exp, xRead[len(xRead)-1])
+	}
+}
+
+func TestRedisStreamERClaimPending(t *testing.T) {
+	rdrEvents := make(chan *erEvent, 2)
+	rdr := newTestRedisStreamER(t, rdrEvents)
+	stub := newRedisStreamStub(func(args []string) any {
+		switch args[len(args)-3] { // the start ID
+		case "0-0":
+			return []any{"5-0", []any{
+				[]any{"3-0", []any{utils.RedisStreamDefaultField, `{"OriginID":"crashed1"}`}},
+			}, []any{}}
+		default:
+			return []any{"0-0", []any{
+				[]any{"5-0", []any{utils.RedisStreamDefaultField, `{"OriginID":"crashed2"}`}},
+			}}
+		}
+	})
+	rdr.client = stub
+
+	var lastClaim time.Time
+	if err := rdr.claimPending(&lastClaim); err != nil {
+		t.Fatal(err)
+	}
+	for _, originID := range []string{"crashed1", "crashed2"} {
+		select {
+		case ev := <-rdrEvents:
+			if rcv := ev.cgrEvent.Event[utils.OriginID]; rcv != originID {
+				t.Errorf("expected %q, received %q", originID, rcv)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+	}
+	minIdle := "60000"
+	if exp := [][]string{
+		{"XAUTOCLAIM", "cdrs", "cgrates", rdr.consumer, minIdle, "0-0", "COUNT", "10"},
+		{"XAUTOCLAIM", "cdrs", "cgrates", rdr.consumer, minIdle, "5-0", "COUNT", "10"},
+	}; !reflect.DeepEqual(exp, stub.commands("XAUTOCLAIM")) {
+		t.Errorf("expected %q, received %q", exp, stub.commands("XAUTOCLAIM"))
+	}
+
+	// not claimed again until the claim interval passes
+	if err := rdr.claimPending(&lastClaim); err != nil {
+		t.Fatal(err)
+	}
+	if len(stub.commands("XAUTOCLAIM")) != 2 {
+		t.Errorf("unexpected commands: %q", stub.commands("XAUTOCLAIM"))
+	}
+}
+
+func TestRedisStreamERProcessEntryAck(t *testing.T) {
+	rdrEvents := make(chan *erEvent, 1)
+	rdr := newTestRedisStreamER(t, rdrEvents)
+	stub := newRedisStreamStub(func(args []string) any { return 1 })
+	rdr.client = stub
+
+	// entries that can not be processed are acknowledged since retrying will not help
+	if err := rdr.processEntry(radix.StreamEntry{
+		ID:     radix.StreamEntryID{Time: 1},
+		Fields: map[string]string{"other": "{}"},
+	}); err == nil {
+		t.Error("expected error for missing field")
+	}
+	// entries not passing the filters are acknowledged as they need no processing
+	rdr.Config().Filters = []string{"*string:~*req.OriginID:abc"}
+	if err := rdr.processEntry(radix.StreamEntry{
+		ID:     radix.StreamEntryID{Time: 2},
+		Fields: map[string]string{utils.RedisStreamDefaultField: `{"OriginID":"def"}`},
+	}); err != nil {
+		t.Error(err)
+	}
+	if exp := [][]string{{"XACK", "cdrs", "cgrates", "2-0"}}; !reflect.DeepEqual(exp, stub.commands("XACK")) {
+		t.Errorf("expected %q, received %q", exp, stub.commands("XACK"))
+	}
+	select {
+	case ev := <-rdrEvents:
+		t.Errorf("unexpected event: %s", utils.ToJSON(ev.cgrEvent))
+	default:
+	}
+}
+
+func TestRedisStreamERMaxDeliveries(t *testing.T) {
+	rdrEvents := make(chan *erEvent, 1)
+	rdr := newTestRedisStreamER(t, rdrEvents)
+	rdr.maxDeliveries = 3
+	deliveries := 2
+	stub := newRedisStreamStub(func(args []string) any {
+		if args[0] == "XPENDING" {
+			return []any{[]any{args[3], rdr.consumer, 60000, deliveries}}
+		}
+		return 1
+	})
+	rdr.client = stub
+	if err := rdr.processEntry(radix.StreamEntry{
+		ID:     radix.StreamEntryID{Time: 1},
+		Fields: map[string]string{utils.RedisStreamDefaultField: `{"OriginID":"abc"}`},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ev := <-rdrEvents
+	if exp := map[string]any{"OriginID": "abc"}; !reflect.DeepEqual(exp, ev.rawEvent) {
+		t.Errorf("expected %v, received %v", exp, ev.rawEvent)
+	}
+	// kept pending while it can still be retried
+	if ev.ack(utils.ErrPartiallyExecuted) {
+		t.Error("expected the entry to be retried")
+	}
+	if cmds := stub.commands("XACK"); len(cmds) != 0 {
+		t.Errorf("unexpected XACK: %q", cmds)
+	}
+	deliveries = 3
+	if !ev.ack(utils.ErrPartiallyExecuted) {
+		t.Error("expected the entry to be dropped")
+	}
+	if exp := [][]string{{"XACK", "cdrs", "cgrates", "1-0"}}; !reflect.DeepEqual(exp, stub.commands("XACK")) {
+		t.Errorf("expected %q, received %q", exp, stub.commands("XACK"))
+	}
+	if exp := []string{"XPENDING", "cdrs", "cgrates", "1-0", "1-0", "1"}; !reflect.DeepEqual(exp, stub.commands("XPENDING")[0]) {
+		t.Errorf("expected %q, received %q", exp, stub.commands("XPENDING")[0])
+	}
+
+	// retried forever without a limit
+	rdr.maxDeliveries = 0
+	if ev.ack(utils.ErrPartiallyExecuted) {
+		t.Error("expected the entry to be retried")
+	}
+}
+
+func TestRedisStreamERServeDisabled(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ERsCfg().Readers[0].RunDelay = 0
+	cfg.ERsCfg().Readers[0].SourcePath = "127.0.0.1:1"
+	rdr, err := NewRedisStreamER(cfg, 0, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = rdr.Serve(); err != nil {
+		t.Error(err)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/cgrates/cgrates/utils"
 
 	// libs for sql DBs
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -117,6 +118,8 @@ func (rdr *SQLEventReader) Serve() (err error) {
 		dialect = mysql.Open(rdr.connString)
 	case utils.Postgres:
 		dialect = postgres.Open(rdr.connString)
+	case utils.SQLite:
+		dialect = sqlite.Open(rdr.connString)
 	default:
 		return fmt.Errorf("db type <%s> not supported", rdr.connType)
 	}
@@ -360,6 +363,12 @@ func (rdr *SQLEventReader) setURL(inURL string, opts *config.EventReaderOpts) er
 			u.User.Username(), password, u.Hostname(), u.Port(), dbname)
 	case utils.Postgres:
 		rdr.connString = fmt.Sprintf("host=%s port=%s dbname=%s user=%s password=%s sslmode=%s", u.Hostname(), u.Port(), dbname, u.User.Username(), password, ssl)
+	case utils.SQLite:
+		path := u.Host + u.Path // *sqlite:///var/lib/cgrates/cdrs.db, falling back to the dbName option
+		if path == utils.EmptyString {
+			path = dbname
+		}
+		rdr.connString = engine.SQLiteDSN(path)
 	default:
 		return fmt.Errorf("unknown db_type %s", rdr.connType)
 	}
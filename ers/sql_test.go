@@ -132,3 +132,38 @@ func TestSQLReaderServeBadType(t *testing.T) {
 	}
 	logger.Default = tmp
 }
+
+func TestSQLSetURLSQLite(t *testing.T) {
+	for _, tc := range []struct {
+		inURL  string
+		dbName *string
+		exp    string
+	}{
+		{
+			inURL: "*sqlite:///var/lib/cgrates/ers.db",
+			exp:   "/var/lib/cgrates/ers.db?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)",
+		},
+		{
+			inURL: "*sqlite://ers.db",
+			exp:   "ers.db?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)",
+		},
+		{
+			inURL:  "*sqlite://",
+			dbName: utils.StringPointer("/tmp/ers.db"),
+			exp:    "/tmp/ers.db?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)",
+		},
+	} {
+		rdr := new(SQLEventReader)
+		if err := rdr.setURL(tc.inURL, &config.EventReaderOpts{
+			SQL: &config.SQLROpts{DBName: tc.dbName},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if rdr.connType != utils.SQLite {
+			t.Errorf("expected %q, received %q", utils.SQLite, rdr.connType)
+		}
+		if rdr.connString != tc.exp {
+			t.Errorf("expected %q, received %q", tc.exp, rdr.connString)
+		}
+	}
+}
@@ -52,6 +52,7 @@ RP_DATA1,DR_DATA_2,TM2,10`
 		utils.EmptyString, utils.EmptyString, utils.EmptyString, utils.EmptyString,
 		utils.EmptyString, utils.EmptyString, utils.EmptyString, utils.EmptyString,
 		utils.EmptyString, utils.EmptyString, utils.EmptyString, utils.EmptyString,
+		utils.EmptyString,
 	),
 		utils.EmptyString, utils.EmptyString, nil, nil)
 	if err != nil {
@@ -72,7 +72,7 @@ TOPUP10_AT,TOPUP10_AC1,ASAP,10`
 	csvr, err := engine.NewTpReader(dataDB2.DataDB(), engine.NewStringCSVStorage(utils.CSVSep, destinations, timings,
 		rates, destinationRates, ratingPlans, ratingProfiles, sharedGroups, actions, actionPlans,
 		actionTriggers, accountActions, resLimits, ips,
-		stats, trends, rankings, thresholds, filters, suppliers, attrProfiles, chargerProfiles, ``, ""), "", "", nil, nil)
+		stats, trends, rankings, thresholds, filters, suppliers, attrProfiles, chargerProfiles, ``, "", ""), "", "", nil, nil)
 	if err != nil {
 		t.Error(err)
 	}
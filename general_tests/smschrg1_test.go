@@ -51,7 +51,7 @@ func TestSMSLoadCsvTpSmsChrg1(t *testing.T) {
 		utils.EmptyString, utils.EmptyString, utils.EmptyString, utils.EmptyString,
 		utils.EmptyString, utils.EmptyString, utils.EmptyString, utils.EmptyString,
 		utils.EmptyString, utils.EmptyString, utils.EmptyString, utils.EmptyString,
-		utils.EmptyString), utils.EmptyString, utils.EmptyString, nil, nil)
+		utils.EmptyString, utils.EmptyString), utils.EmptyString, utils.EmptyString, nil, nil)
 	if err != nil {
 		t.Error(err)
 	}
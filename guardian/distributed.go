@@ -0,0 +1,138 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package guardian
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// leaseRetryInterval is the time to wait before retrying a lease held by another engine
+const leaseRetryInterval = 5 * time.Millisecond
+
+// DistributedLocker is implemented by the storages able to share the locks between engines.
+// The locks are advisory only: they exclude the engines using them but do not protect
+// the data itself. Each lease acquired is identified by a token, increasing with every
+// acquisition of the same lock, so a holder whose lease expired cannot refresh or release
+// the new one. The token is only used to identify the lease, not to fence the data writes,
+// so a holder paused past its lease can still overwrite the data of the new holder until
+// it finds out the lease was lost, when refreshing or releasing it.
+type DistributedLocker interface {
+	// TryLock attempts to acquire the lease on lkID for ttl, returning its token if acquired
+	TryLock(lkID string, ttl time.Duration) (token int64, acquired bool, err error)
+	// RefreshLock extends the lease on lkID with ttl, if it is still held with token
+	RefreshLock(lkID string, token int64, ttl time.Duration) (refreshed bool, err error)
+	// Unlock releases the lease on lkID, if it is still held with token
+	Unlock(lkID string, token int64) error
+}
+
+// SetDistributedLocker extends the locking across the engines sharing dl, with leases of ttl
+// duration, refreshed while the locks are held. A nil dl restores the in-process locking.
+func (gl *GuardianLocker) SetDistributedLocker(dl DistributedLocker, ttl time.Duration) {
+	if dl == nil {
+		gl.dLocker.Store(nil)
+		return
+	}
+	gl.dLocker.Store(&distributedLocker{DistributedLocker: dl, ttl: ttl})
+}
+
+type distributedLocker struct {
+	DistributedLocker
+	ttl time.Duration
+}
+
+// acquire waits for the lease on lkID until the deadline, a zero one waiting indefinitely.
+// The lock is never considered acquired without the lease, so the storage errors are returned.
+func (dl *distributedLocker) acquire(lkID string, deadline time.Time) (*lease, error) {
+	for {
+		token, acquired, err := dl.TryLock(lkID, dl.ttl)
+		if err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<Guardian> failed to acquire distributed lock <%s>: %v", lkID, err))
+			return nil, utils.ErrLockUnavailable
+		}
+		if acquired {
+			ls := &lease{
+				dl:    dl,
+				lkID:  lkID,
+				token: token,
+				done:  make(chan struct{}),
+			}
+			go ls.keepAlive()
+			return ls, nil
+		}
+		if !deadline.IsZero() && time.Now().Add(leaseRetryInterval).After(deadline) {
+			utils.Logger.Warning(fmt.Sprintf("<Guardian> timed out waiting for distributed lock <%s>", lkID))
+			return nil, utils.ErrTimedOut
+		}
+		time.Sleep(leaseRetryInterval)
+	}
+}
+
+// lease is a distributed lock held by this engine
+type lease struct {
+	dl    *distributedLocker
+	lkID  string
+	token int64
+	done  chan struct{}
+	lost  atomic.Bool // the lease expired or was taken by another engine while held
+}
+
+// keepAlive refreshes the lease until released or lost. Failing to
+// refresh it for a whole ttl is considered as losing the lease.
+func (ls *lease) keepAlive() {
+	tk := time.NewTicker(ls.dl.ttl / 3)
+	defer tk.Stop()
+	lastRefresh := time.Now()
+	for {
+		select {
+		case <-ls.done:
+			return
+		case <-tk.C:
+		}
+		refreshed, err := ls.dl.RefreshLock(ls.lkID, ls.token, ls.dl.ttl)
+		if err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<Guardian> failed to refresh distributed lock <%s>: %v", ls.lkID, err))
+			if time.Since(lastRefresh) < ls.dl.ttl {
+				continue
+			}
+		} else if refreshed {
+			lastRefresh = time.Now()
+			continue
+		}
+		utils.Logger.Warning(fmt.Sprintf("<Guardian> lost distributed lock <%s> with token %d", ls.lkID, ls.token))
+		ls.lost.Store(true)
+		return
+	}
+}
+
+// release stops refreshing and gives up the lease, returning
+// utils.ErrLockLost if the lease was not held until now
+func (ls *lease) release() error {
+	close(ls.done)
+	if err := ls.dl.Unlock(ls.lkID, ls.token); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<Guardian> failed to release distributed lock <%s>: %v", ls.lkID, err))
+	}
+	if ls.lost.Load() {
+		return utils.ErrLockLost
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ package guardian
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cgrates/cgrates/utils"
@@ -34,6 +35,9 @@ var Guardian = &GuardianLocker{
 type itemLock struct {
 	lk  chan struct{} //better with  mutex
 	cnt int64
+
+	lease    *lease    // distributed lease held together with the local lock
+	lockedAt time.Time // used to compute the hold duration
 }
 
 type refObj struct {
@@ -47,6 +51,10 @@ type GuardianLocker struct {
 	lkMux   sync.Mutex         // protects the locks
 	refs    map[string]*refObj // used in case of remote locks
 	refsMux sync.RWMutex       // protects the map
+
+	dLocker atomic.Pointer[distributedLocker] // nil for in-process locking only
+	wait    durationHistogram                 // time spent acquiring the locks
+	hold    durationHistogram                 // time the locks were held
 }
 
 func (gl *GuardianLocker) lockItem(itmID string) {
@@ -80,8 +88,74 @@ func (gl *GuardianLocker) unlockItem(itmID string) {
 	itmLock.lk <- struct{}{}
 }
 
+// lock acquires the local lock on itmID, followed by the distributed lease when enabled.
+// Failing to get the lease within timeout releases the local lock and returns the error.
+func (gl *GuardianLocker) lock(itmID string, timeout time.Duration) error {
+	if itmID == "" {
+		return nil
+	}
+	start := time.Now()
+	gl.lockItem(itmID)
+	var ls *lease
+	if dl := gl.dLocker.Load(); dl != nil {
+		var deadline time.Time
+		if timeout > 0 {
+			deadline = start.Add(timeout)
+		}
+		var err error
+		if ls, err = dl.acquire(itmID, deadline); err != nil {
+			gl.unlockItem(itmID)
+			return err
+		}
+	}
+	lockedAt := time.Now()
+	gl.lkMux.Lock()
+	if itmLock, has := gl.locks[itmID]; has {
+		itmLock.lease = ls
+		itmLock.lockedAt = lockedAt
+	}
+	gl.lkMux.Unlock()
+	gl.wait.observe(lockedAt.Sub(start))
+	return nil
+}
+
+// unlock releases the distributed lease on itmID, if any, followed by the local lock,
+// returning utils.ErrLockLost if the lease was lost while the lock was held
+func (gl *GuardianLocker) unlock(itmID string) (err error) {
+	gl.lkMux.Lock()
+	itmLock, has := gl.locks[itmID]
+	if !has {
+		gl.lkMux.Unlock()
+		return
+	}
+	ls, lockedAt := itmLock.lease, itmLock.lockedAt
+	itmLock.lease, itmLock.lockedAt = nil, time.Time{}
+	gl.lkMux.Unlock()
+	if ls != nil {
+		err = ls.release()
+	}
+	if !lockedAt.IsZero() {
+		gl.hold.observe(time.Since(lockedAt))
+	}
+	gl.unlockItem(itmID)
+	return
+}
+
+// lockAll locks the items in order, releasing the ones already locked on failure
+func (gl *GuardianLocker) lockAll(timeout time.Duration, lkIDs []string) (err error) {
+	for i, lkID := range lkIDs {
+		if err = gl.lock(lkID, timeout); err != nil {
+			for _, lkdID := range lkIDs[:i] {
+				gl.unlock(lkdID)
+			}
+			return
+		}
+	}
+	return
+}
+
 // lockWithReference will perform locks and also generate a lock reference for it (so it can be used when remotely locking)
-func (gl *GuardianLocker) lockWithReference(refID string, timeout time.Duration, lkIDs ...string) string {
+func (gl *GuardianLocker) lockWithReference(refID string, timeout time.Duration, lkIDs ...string) (string, error) {
 	var refEmpty bool
 	if refID == "" {
 		refEmpty = true
@@ -93,7 +167,7 @@ func (gl *GuardianLocker) lockWithReference(refID string, timeout time.Duration,
 		if _, has := gl.refs[refID]; has {
 			gl.refsMux.Unlock()
 			gl.unlockItem(refID)
-			return "" // no locking was done
+			return "", nil // no locking was done
 		}
 	}
 	var tm *time.Timer
@@ -110,11 +184,18 @@ func (gl *GuardianLocker) lockWithReference(refID string, timeout time.Duration,
 	}
 	gl.refsMux.Unlock()
 	// execute the real locks
-	for _, lk := range lkIDs {
-		gl.lockItem(lk)
+	if err := gl.lockAll(timeout, lkIDs); err != nil {
+		gl.refsMux.Lock()
+		if tm != nil {
+			tm.Stop()
+		}
+		delete(gl.refs, refID)
+		gl.refsMux.Unlock()
+		gl.unlockItem(refID)
+		return "", err
 	}
 	gl.unlockItem(refID)
-	return refID
+	return refID, nil
 }
 
 // unlockWithReference will unlock based on the reference ID
@@ -134,16 +215,18 @@ func (gl *GuardianLocker) unlockWithReference(refID string) (lkIDs []string) {
 	gl.refsMux.Unlock()
 	lkIDs = ref.refs
 	for _, lk := range lkIDs {
-		gl.unlockItem(lk)
+		gl.unlock(lk)
 	}
 	gl.unlockItem(refID)
 	return
 }
 
-// Guard executes the handler between locks
+// Guard executes the handler between locks. The handler is not executed if the locks
+// cannot be acquired within timeout and utils.ErrLockLost is returned if a distributed
+// lock was lost while the handler was executing.
 func (gl *GuardianLocker) Guard(handler func() error, timeout time.Duration, lockIDs ...string) (err error) { // do we need the interface here as a reply?
-	for _, lockID := range lockIDs {
-		gl.lockItem(lockID)
+	if err = gl.lockAll(timeout, lockIDs); err != nil {
+		return
 	}
 	errChan := make(chan error, 1)
 	go func() {
@@ -163,14 +246,17 @@ func (gl *GuardianLocker) Guard(handler func() error, timeout time.Duration, loc
 		close(errChan)
 	}
 	for _, lockID := range lockIDs {
-		gl.unlockItem(lockID)
+		if unlkErr := gl.unlock(lockID); unlkErr != nil && err == nil {
+			err = unlkErr
+		}
 	}
 	return
 }
 
 // GuardIDs aquires a lock for duration
-// returns the reference ID for the lock group aquired
-func (gl *GuardianLocker) GuardIDs(refID string, timeout time.Duration, lkIDs ...string) string {
+// returns the reference ID for the lock group aquired or the error
+// if the locks could not be acquired within timeout
+func (gl *GuardianLocker) GuardIDs(refID string, timeout time.Duration, lkIDs ...string) (string, error) {
 	return gl.lockWithReference(refID, timeout, lkIDs...)
 }
 
@@ -160,7 +160,10 @@ func TestGuardianGuardIDs(t *testing.T) {
 		}
 	}
 	// test lock  without timer
-	refID := Guardian.GuardIDs("", 0, lockIDs...)
+	refID, err := Guardian.GuardIDs("", 0, lockIDs...)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if totalLockDur := time.Since(tStart); totalLockDur < lockDur {
 		t.Errorf("Lock duration too small")
@@ -197,7 +200,7 @@ func TestGuardianGuardIDsConcurrent(t *testing.T) {
 	for i := 0; i < maxIter; i++ {
 		sg.Add(1)
 		go func() {
-			if retRefID := Guardian.GuardIDs(refID, 0, keys...); retRefID != "" {
+			if retRefID, _ := Guardian.GuardIDs(refID, 0, keys...); retRefID != "" {
 				if lkIDs := Guardian.UnguardIDs(refID); !reflect.DeepEqual(keys, lkIDs) {
 					t.Errorf("expecting: %+v, received: %+v", keys, lkIDs)
 				}
@@ -301,7 +304,7 @@ func BenchmarkGuardIDs(b *testing.B) {
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
 		go func(i int) {
-			if refID := Guardian.GuardIDs("", 0, strconv.Itoa(i)); refID != "" {
+			if refID, _ := Guardian.GuardIDs("", 0, strconv.Itoa(i)); refID != "" {
 				time.Sleep(time.Microsecond)
 				Guardian.UnguardIDs(refID)
 			}
@@ -352,3 +355,216 @@ func TestGuardianGuardUnguardIDsCase2(t *testing.T) {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", utils.ErrNotFound, err)
 	}
 }
+
+// testLocker is a DistributedLocker kept in memory, shared by multiple GuardianLockers
+type testLocker struct {
+	mux       sync.Mutex
+	tokens    map[string]int64
+	leases    map[string]int64
+	refreshes int
+	tryErr    error // returned by TryLock when set
+}
+
+func (tl *testLocker) TryLock(lkID string, ttl time.Duration) (int64, bool, error) {
+	tl.mux.Lock()
+	defer tl.mux.Unlock()
+	if tl.tryErr != nil {
+		return 0, false, tl.tryErr
+	}
+	if _, has := tl.leases[lkID]; has {
+		return 0, false, nil
+	}
+	tl.tokens[lkID]++
+	tl.leases[lkID] = tl.tokens[lkID]
+	return tl.tokens[lkID], true, nil
+}
+
+func (tl *testLocker) RefreshLock(lkID string, token int64, ttl time.Duration) (bool, error) {
+	tl.mux.Lock()
+	defer tl.mux.Unlock()
+	tl.refreshes++
+	return tl.leases[lkID] == token, nil
+}
+
+func (tl *testLocker) Unlock(lkID string, token int64) error {
+	tl.mux.Lock()
+	defer tl.mux.Unlock()
+	if tl.leases[lkID] != token {
+		return utils.ErrNotFound
+	}
+	delete(tl.leases, lkID)
+	return nil
+}
+
+func TestGuardianDistributedLocker(t *testing.T) {
+	tl := &testLocker{tokens: make(map[string]int64), leases: make(map[string]int64)}
+	engines := make([]*GuardianLocker, 2)
+	for i := range engines {
+		engines[i] = &GuardianLocker{
+			locks: make(map[string]*itemLock),
+			refs:  make(map[string]*refObj),
+		}
+		engines[i].SetDistributedLocker(tl, 15*time.Millisecond)
+	}
+	var running, maxRunning int
+	var mux sync.Mutex
+	handler := func() error {
+		mux.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mux.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mux.Lock()
+		running--
+		mux.Unlock()
+		return nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(gl *GuardianLocker) {
+			gl.Guard(handler, 0, "account1")
+			wg.Done()
+		}(engines[i%2])
+	}
+	wg.Wait()
+	if maxRunning != 1 {
+		t.Errorf("expected the handlers to run one at a time, received %d in parallel", maxRunning)
+	}
+	tl.mux.Lock()
+	if tl.tokens["account1"] != 6 {
+		t.Errorf("expected token 6, received %d", tl.tokens["account1"])
+	}
+	if len(tl.leases) != 0 {
+		t.Errorf("expected all leases released, received %v", tl.leases)
+	}
+	if tl.refreshes == 0 {
+		t.Error("expected the leases to be refreshed while held")
+	}
+	tl.mux.Unlock()
+
+	// the reference locks are distributed as well
+	refID, err := engines[0].GuardIDs("", 0, "account1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token, acquired, _ := tl.TryLock("account1", time.Second); acquired {
+		t.Errorf("expected account1 locked, acquired with token %d", token)
+	}
+	engines[0].UnguardIDs(refID)
+	if _, acquired, _ := tl.TryLock("account1", time.Second); !acquired {
+		t.Error("expected account1 unlocked")
+	}
+
+	engines[1].SetDistributedLocker(nil, 0)
+	if err := engines[1].Guard(func() error { return nil }, 0, "account1"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGuardianDistributedLockerFailClosed(t *testing.T) {
+	tl := &testLocker{tokens: make(map[string]int64), leases: make(map[string]int64),
+		tryErr: utils.ErrDisconnected}
+	gl := &GuardianLocker{
+		locks: make(map[string]*itemLock),
+		refs:  make(map[string]*refObj),
+	}
+	gl.SetDistributedLocker(tl, 15*time.Millisecond)
+	var ran bool
+	if err := gl.Guard(func() error {
+		ran = true
+		return nil
+	}, 0, "account1", "account2"); err != utils.ErrLockUnavailable {
+		t.Errorf("expected %v, received %v", utils.ErrLockUnavailable, err)
+	}
+	if ran {
+		t.Error("expected the handler not to run without the distributed lock")
+	}
+	if refID, err := gl.GuardIDs("", 0, "account1"); err != utils.ErrLockUnavailable || refID != "" {
+		t.Errorf("expected %v and no reference, received %v, %q", utils.ErrLockUnavailable, err, refID)
+	}
+	gl.lkMux.Lock()
+	if len(gl.locks) != 0 {
+		t.Errorf("expected the local locks released, received %+v", gl.locks)
+	}
+	gl.lkMux.Unlock()
+	gl.refsMux.Lock()
+	if len(gl.refs) != 0 {
+		t.Errorf("expected no references, received %+v", gl.refs)
+	}
+	gl.refsMux.Unlock()
+}
+
+func TestGuardianDistributedLockerTimeout(t *testing.T) {
+	tl := &testLocker{tokens: make(map[string]int64), leases: make(map[string]int64)}
+	gl := &GuardianLocker{
+		locks: make(map[string]*itemLock),
+		refs:  make(map[string]*refObj),
+	}
+	gl.SetDistributedLocker(tl, time.Second)
+	if _, acquired, _ := tl.TryLock("account1", time.Second); !acquired { // held by another engine
+		t.Fatal("expected account1 acquired")
+	}
+	tStart := time.Now()
+	if err := gl.Guard(func() error {
+		t.Error("expected the handler not to run")
+		return nil
+	}, 20*time.Millisecond, "account1"); err != utils.ErrTimedOut {
+		t.Errorf("expected %v, received %v", utils.ErrTimedOut, err)
+	}
+	if waited := time.Since(tStart); waited > 500*time.Millisecond {
+		t.Errorf("expected the locking timeout honoured, waited %v", waited)
+	}
+	gl.lkMux.Lock()
+	if len(gl.locks) != 0 {
+		t.Errorf("expected the local locks released, received %+v", gl.locks)
+	}
+	gl.lkMux.Unlock()
+}
+
+func TestGuardianDistributedLockerLost(t *testing.T) {
+	tl := &testLocker{tokens: make(map[string]int64), leases: make(map[string]int64)}
+	gl := &GuardianLocker{
+		locks: make(map[string]*itemLock),
+		refs:  make(map[string]*refObj),
+	}
+	gl.SetDistributedLocker(tl, 15*time.Millisecond)
+	if err := gl.Guard(func() error {
+		tl.mux.Lock()
+		delete(tl.leases, "account1") // expired and taken over by another engine
+		tl.mux.Unlock()
+		time.Sleep(30 * time.Millisecond)
+		return nil
+	}, 0, "account1"); err != utils.ErrLockLost {
+		t.Errorf("expected %v, received %v", utils.ErrLockLost, err)
+	}
+	if err := gl.Guard(func() error {
+		return utils.ErrNotFound
+	}, 0, "account1"); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestGuardianLockStats(t *testing.T) {
+	gl := &GuardianLocker{
+		locks: make(map[string]*itemLock),
+		refs:  make(map[string]*refObj),
+	}
+	gl.Guard(func() error {
+		time.Sleep(2 * time.Millisecond)
+		return nil
+	}, 0, "test1", "test2")
+	wait, hold := gl.LockStats()
+	if wait.Count != 2 || hold.Count != 2 {
+		t.Errorf("expected 2 observations, received wait: %d, hold: %d", wait.Count, hold.Count)
+	}
+	if hold.Sum < 0.004 {
+		t.Errorf("expected at least 4ms of hold time, received %vs", hold.Sum)
+	}
+	if hold.Buckets[0.001] != 0 || hold.Buckets[5] != 2 {
+		t.Errorf("unexpected hold buckets: %v", hold.Buckets)
+	}
+	if wait.Buckets[5] != 2 {
+		t.Errorf("unexpected wait buckets: %v", wait.Buckets)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package guardian
+
+import (
+	"sync"
+	"time"
+)
+
+// lockDurationBuckets are the upper bounds, in seconds, of the lock duration histograms
+var lockDurationBuckets = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5}
+
+// DurationHistogram is a snapshot of the lock durations observed, in seconds
+type DurationHistogram struct {
+	Count   uint64
+	Sum     float64
+	Buckets map[float64]uint64 // cumulative number of durations lower or equal to each upper bound
+}
+
+// durationHistogram counts the lock durations per bucket
+type durationHistogram struct {
+	mux    sync.Mutex
+	count  uint64
+	sum    float64
+	counts []uint64 // not cumulative, indexed as lockDurationBuckets
+}
+
+func (h *durationHistogram) observe(d time.Duration) {
+	secs := d.Seconds()
+	h.mux.Lock()
+	if h.counts == nil {
+		h.counts = make([]uint64, len(lockDurationBuckets))
+	}
+	h.count++
+	h.sum += secs
+	for i, upperBound := range lockDurationBuckets {
+		if secs <= upperBound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.mux.Unlock()
+}
+
+func (h *durationHistogram) snapshot() (s DurationHistogram) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	s = DurationHistogram{
+		Count:   h.count,
+		Sum:     h.sum,
+		Buckets: make(map[float64]uint64, len(lockDurationBuckets)),
+	}
+	var cumulative uint64
+	for i, upperBound := range lockDurationBuckets {
+		if h.counts != nil {
+			cumulative += h.counts[i]
+		}
+		s.Buckets[upperBound] = cumulative
+	}
+	return
+}
+
+// LockStats returns the time spent waiting for the locks and the time they were held,
+// for all the locks acquired since the engine started
+func (gl *GuardianLocker) LockStats() (wait, hold DurationHistogram) {
+	return gl.wait.snapshot(), gl.hold.snapshot()
+}
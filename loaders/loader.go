@@ -312,7 +312,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, apf.TenantID())
-				if err := ldr.dm.SetAttributeProfile(apf, true); err != nil {
+				if err := ldr.dm.WithAudit(ldr.ldrID, utils.LoaderS).SetAttributeProfile(apf, true); err != nil {
 					return err
 				}
 			}
@@ -403,7 +403,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, fltrPrf.TenantID())
-				if err := ldr.dm.SetFilter(fltrPrf, true); err != nil {
+				if err := ldr.dm.WithAudit(ldr.ldrID, utils.LoaderS).SetFilter(fltrPrf, true); err != nil {
 					return err
 				}
 				cacheArgs[utils.CacheFilters] = ids
@@ -495,6 +495,63 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				cacheArgs[utils.CacheRankingFilterIndexes] = ids
 			}
 		}
+	case utils.MetaDataSets:
+		for _, lDataSet := range lds {
+			dsModels := make(engine.DataSetMdls, len(lDataSet))
+			for i, ld := range lDataSet {
+				dsModels[i] = new(engine.DataSetMdl)
+				if err = utils.UpdateStructWithIfaceMap(dsModels[i], ld); err != nil {
+					return
+				}
+			}
+			var dss []*engine.DataSet
+			if dss, err = dsModels.AsDataSets(); err != nil {
+				return
+			}
+			for _, ds := range dss {
+				if ldr.dryRun {
+					utils.Logger.Info(
+						fmt.Sprintf("<%s-%s> DRY_RUN: DataSet: %s",
+							utils.LoaderS, ldr.ldrID, utils.ToJSON(ds)))
+					continue
+				}
+				// get IDs so we can reload in cache
+				ids = append(ids, ds.TenantID())
+				if err := ldr.dm.SetDataSet(ds); err != nil {
+					return err
+				}
+				cacheArgs[utils.CacheDataSets] = ids
+			}
+		}
+	case utils.MetaTaxProfiles:
+		cacheIDs = []string{utils.CacheTaxProfileFilterIndexes}
+		for _, lDataSet := range lds {
+			txpModels := make(engine.TaxProfileMdls, len(lDataSet))
+			for i, ld := range lDataSet {
+				txpModels[i] = new(engine.TaxProfileMdl)
+				if err = utils.UpdateStructWithIfaceMap(txpModels[i], ld); err != nil {
+					return
+				}
+			}
+			for _, tpTxp := range txpModels.AsTPTaxProfile() {
+				txp, err := engine.APItoTaxProfile(tpTxp, ldr.timezone)
+				if err != nil {
+					return err
+				}
+				if ldr.dryRun {
+					utils.Logger.Info(
+						fmt.Sprintf("<%s-%s> DRY_RUN: TaxProfile: %s",
+							utils.LoaderS, ldr.ldrID, utils.ToJSON(txp)))
+					continue
+				}
+				// get IDs so we can reload in cache
+				ids = append(ids, txp.TenantID())
+				if err := ldr.dm.SetTaxProfile(txp, true); err != nil {
+					return err
+				}
+				cacheArgs[utils.CacheTaxProfiles] = ids
+			}
+		}
 	case utils.MetaThresholds:
 		cacheIDs = []string{utils.CacheThresholdFilterIndexes}
 		for _, lDataSet := range lds {
@@ -549,7 +606,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, spPrf.TenantID())
-				if err := ldr.dm.SetRouteProfile(spPrf, true); err != nil {
+				if err := ldr.dm.WithAudit(ldr.ldrID, utils.LoaderS).SetRouteProfile(spPrf, true); err != nil {
 					return err
 				}
 				cacheArgs[utils.CacheRouteProfiles] = ids
@@ -740,7 +797,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveAttributeProfile(tntIDStruct.Tenant, tntIDStruct.ID,
+				if err := ldr.dm.WithAudit(ldr.ldrID, utils.LoaderS).RemoveAttributeProfile(tntIDStruct.Tenant, tntIDStruct.ID,
 					true); err != nil {
 					return err
 				}
@@ -778,7 +835,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveFilter(tntIDStruct.Tenant, tntIDStruct.ID,
+				if err := ldr.dm.WithAudit(ldr.ldrID, utils.LoaderS).RemoveFilter(tntIDStruct.Tenant, tntIDStruct.ID,
 					true); err != nil {
 					return err
 				}
@@ -822,6 +879,41 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				cacheArgs[utils.CacheRankingProfiles] = ids
 			}
 		}
+	case utils.MetaDataSets:
+		for tntID := range lds {
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: DataSetID: %s",
+						utils.LoaderS, ldr.ldrID, tntID))
+			} else {
+				tntIDStruct := utils.NewTenantID(tntID)
+				// get IDs so we can reload in cache
+				ids = append(ids, tntID)
+				if err := ldr.dm.RemoveDataSet(tntIDStruct.Tenant,
+					tntIDStruct.ID); err != nil {
+					return err
+				}
+				cacheArgs[utils.CacheDataSets] = ids
+			}
+		}
+	case utils.MetaTaxProfiles:
+		cacheIDs = []string{utils.CacheTaxProfileFilterIndexes}
+		for tntID := range lds {
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: TaxProfileID: %s",
+						utils.LoaderS, ldr.ldrID, tntID))
+			} else {
+				tntIDStruct := utils.NewTenantID(tntID)
+				// get IDs so we can reload in cache
+				ids = append(ids, tntID)
+				if err := ldr.dm.RemoveTaxProfile(tntIDStruct.Tenant,
+					tntIDStruct.ID, true); err != nil {
+					return err
+				}
+				cacheArgs[utils.CacheTaxProfiles] = ids
+			}
+		}
 	case utils.MetaThresholds:
 		cacheIDs = []string{utils.CacheThresholdFilterIndexes}
 		for tntID := range lds {
@@ -852,7 +944,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveRouteProfile(tntIDStruct.Tenant,
+				if err := ldr.dm.WithAudit(ldr.ldrID, utils.LoaderS).RemoveRouteProfile(tntIDStruct.Tenant,
 					tntIDStruct.ID, true); err != nil {
 					return err
 				}
@@ -3858,3 +3858,185 @@ func TestLoaderLockFolder(t *testing.T) {
 		t.Errorf("Expected no lock file to be created for empty lockFilepath, but it exists")
 	}
 }
+
+func TestLoaderProcessDataSets(t *testing.T) {
+	engine.Cache.Clear(nil)
+	dataDB, err := engine.NewInternalDB(nil, nil, false, nil, config.CgrConfig().DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ldr := &Loader{
+		ldrID:         "TestLoaderProcessDataSets",
+		bufLoaderData: make(map[string][]LoaderData),
+		dm:            engine.NewDataManager(dataDB, config.CgrConfig().CacheCfg(), nil),
+		timezone:      "UTC",
+	}
+	ldr.dataTpls = map[string][]*config.FCTemplate{
+		utils.MetaDataSets: {
+			{Path: "Tenant",
+				Type:      utils.MetaComposed,
+				Value:     config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep),
+				Mandatory: true},
+			{Path: "ID",
+				Type:      utils.MetaComposed,
+				Value:     config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep),
+				Mandatory: true},
+			{Path: "Value",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.2", utils.InfieldSep)},
+			{Path: "Prefix",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.3", utils.InfieldSep)},
+			{Path: "Metadata",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.4", utils.InfieldSep)},
+		},
+	}
+	dataSetsCSV := `
+#Tenant[0],ID[1],Value[2],Prefix[3],Metadata[4]
+cgrates.org,BLOCKLIST,1001,false,Reason:fraud
+cgrates.org,BLOCKLIST,+4986,true,
+cgrates.org,ALLOWLIST,1002,,
+`
+	newRdrs := func() map[string]map[string]*openedCSVFile {
+		rdr := io.NopCloser(strings.NewReader(dataSetsCSV))
+		csvRdr := csv.NewReader(rdr)
+		csvRdr.Comment = '#'
+		return map[string]map[string]*openedCSVFile{
+			utils.MetaDataSets: {
+				utils.DataSetsCsv: &openedCSVFile{fileName: utils.DataSetsCsv,
+					rdr: rdr, csvRdr: csvRdr}},
+		}
+	}
+	ldr.rdrs = newRdrs()
+	if err := ldr.processContent(utils.MetaDataSets, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if len(ldr.bufLoaderData) != 0 {
+		t.Errorf("wrong buffer content: %+v", ldr.bufLoaderData)
+	}
+	eDs := &engine.DataSet{
+		Tenant:   "cgrates.org",
+		ID:       "BLOCKLIST",
+		Exact:    map[string]map[string]string{"1001": {"Reason": "fraud"}},
+		Prefixes: map[string]map[string]string{"+4986": nil},
+	}
+	if ds, err := ldr.dm.GetDataSet("cgrates.org", "BLOCKLIST",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eDs, ds) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eDs), utils.ToJSON(ds))
+	}
+	if ds, err := ldr.dm.GetDataSet("cgrates.org", "ALLOWLIST",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if _, has := ds.Match("1002"); !has {
+		t.Errorf("expecting 1002 in %s", utils.ToJSON(ds))
+	}
+
+	ldr.rdrs = newRdrs()
+	if err := ldr.removeContent(utils.MetaDataSets, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"BLOCKLIST", "ALLOWLIST"} {
+		if _, err := ldr.dm.GetDataSet("cgrates.org", id,
+			false, false, utils.NonTransactional); err != utils.ErrNotFound {
+			t.Errorf("expected %v for %s, received %v", utils.ErrNotFound, id, err)
+		}
+	}
+}
+
+func TestLoaderProcessTaxProfiles(t *testing.T) {
+	engine.Cache.Clear(nil)
+	dataDB, err := engine.NewInternalDB(nil, nil, false, nil, config.CgrConfig().DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ldr := &Loader{
+		ldrID:         "TestLoaderProcessTaxProfiles",
+		bufLoaderData: make(map[string][]LoaderData),
+		dm:            engine.NewDataManager(dataDB, config.CgrConfig().CacheCfg(), nil),
+		timezone:      "UTC",
+	}
+	ldr.dataTpls = map[string][]*config.FCTemplate{
+		utils.MetaTaxProfiles: {
+			{Path: "Tenant",
+				Type:      utils.MetaComposed,
+				Value:     config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep),
+				Mandatory: true},
+			{Path: "ID",
+				Type:      utils.MetaComposed,
+				Value:     config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep),
+				Mandatory: true},
+			{Path: "FilterIDs",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.2", utils.InfieldSep)},
+			{Path: "Jurisdiction",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.3", utils.InfieldSep)},
+			{Path: "Weight",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.4", utils.InfieldSep)},
+			{Path: "RateID",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.5", utils.InfieldSep)},
+			{Path: "RateType",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.6", utils.InfieldSep)},
+			{Path: "Rate",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.7", utils.InfieldSep)},
+			{Path: "RateAmount",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.8", utils.InfieldSep)},
+			{Path: "RateCompound",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.9", utils.InfieldSep)},
+		},
+	}
+	taxProfilesCSV := `
+#Tenant[0],ID[1],FilterIDs[2],Jurisdiction[3],Weight[4],RateID[5],RateType[6],Rate[7],RateAmount[8],RateCompound[9]
+cgrates.org,TAX_DE,*prefix:~*req.Destination:49,DE,20,VAT,*vat,19,0,false
+cgrates.org,TAX_DE,,,,REG,*regulatory,0,0.01,false
+`
+	newRdrs := func() map[string]map[string]*openedCSVFile {
+		rdr := io.NopCloser(strings.NewReader(taxProfilesCSV))
+		csvRdr := csv.NewReader(rdr)
+		csvRdr.Comment = '#'
+		return map[string]map[string]*openedCSVFile{
+			utils.MetaTaxProfiles: {
+				utils.TaxProfilesCsv: &openedCSVFile{fileName: utils.TaxProfilesCsv,
+					rdr: rdr, csvRdr: csvRdr}},
+		}
+	}
+	ldr.rdrs = newRdrs()
+	if err := ldr.processContent(utils.MetaTaxProfiles, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	eTxp := &engine.TaxProfile{
+		Tenant:       "cgrates.org",
+		ID:           "TAX_DE",
+		FilterIDs:    []string{"*prefix:~*req.Destination:49"},
+		Jurisdiction: "DE",
+		Weight:       20,
+		Rates: []*engine.TaxRate{
+			{ID: "VAT", Type: "*vat", Rate: 19},
+			{ID: "REG", Type: "*regulatory", Amount: 0.01},
+		},
+	}
+	if txp, err := ldr.dm.GetTaxProfile("cgrates.org", "TAX_DE",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eTxp, txp) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eTxp), utils.ToJSON(txp))
+	}
+
+	ldr.rdrs = newRdrs()
+	if err := ldr.removeContent(utils.MetaTaxProfiles, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ldr.dm.GetTaxProfile("cgrates.org", "TAX_DE",
+		false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
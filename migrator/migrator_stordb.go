@@ -31,6 +31,7 @@ type MigratorStorDB interface {
 	getV2SMCost() (v2Cost *v2SessionsCost, err error)
 	setV2SMCost(v2Cost *v2SessionsCost) (err error)
 	remV2SMCost(v2Cost *v2SessionsCost) (err error)
+	addV1TPDestinationRatesUsageCounter() (err error)
 	StorDB() engine.StorDB
 	close()
 }
@@ -69,11 +69,13 @@ func NewMigratorStorDB(db_type, host, port, name, user, pass, marshaler string,
 		db = newMigratorSQL(storDb)
 	case utils.MetaPostgres:
 		db = newMigratorSQL(storDb)
+	case utils.MetaSQLite:
+		db = newMigratorSQL(storDb)
 	case utils.MetaInternal:
 		db = newInternalStorDBMigrator(storDb)
 	default:
-		err = fmt.Errorf("Unknown db '%s' valid options are [%s, %s, %s, %s]",
-			db_type, utils.MetaMySQL, utils.MetaMongo, utils.MetaPostgres, utils.MetaInternal)
+		err = fmt.Errorf("Unknown db '%s' valid options are [%s, %s, %s, %s, %s]",
+			db_type, utils.MetaMySQL, utils.MetaMongo, utils.MetaPostgres, utils.MetaSQLite, utils.MetaInternal)
 	}
 	return
 }
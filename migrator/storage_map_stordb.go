@@ -67,6 +67,11 @@ func (iDBMig *internalStorDBMigrator) createV1SMCosts() (err error) {
 	return utils.ErrNotImplemented
 }
 
+// addV1TPDestinationRatesUsageCounter has nothing to do, the missing UsageCounter is read as empty
+func (iDBMig *internalStorDBMigrator) addV1TPDestinationRatesUsageCounter() (err error) {
+	return
+}
+
 // get
 func (iDBMig *internalStorDBMigrator) getV2SMCost() (v2Cost *v2SessionsCost, err error) {
 	return nil, utils.ErrNotImplemented
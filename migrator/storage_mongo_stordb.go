@@ -97,6 +97,11 @@ func (v1ms *mongoStorDBMigrator) createV1SMCosts() (err error) {
 		bson.D{{Key: "create", Value: utils.OldSMCosts}, {Key: "size", Value: 1024}, {Key: "capped", Value: true}}).Err()
 }
 
+// addV1TPDestinationRatesUsageCounter has nothing to do, the missing usagecounter is read as empty
+func (v1ms *mongoStorDBMigrator) addV1TPDestinationRatesUsageCounter() (err error) {
+	return
+}
+
 // get
 func (v1ms *mongoStorDBMigrator) getV2SMCost() (v2Cost *v2SessionsCost, err error) {
 	if v1ms.cursor == nil {
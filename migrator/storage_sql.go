@@ -97,7 +97,8 @@ func (mgSQL *migratorSQL) remV1CDRs(v1Cdr *v1Cdrs) (err error) {
 
 func (mgSQL *migratorSQL) renameV1SMCosts() (err error) {
 	qry := "RENAME TABLE sm_costs TO session_costs;"
-	if mgSQL.StorDB().GetStorageType() == utils.MetaPostgres {
+	if storType := mgSQL.StorDB().GetStorageType(); storType == utils.MetaPostgres ||
+		storType == utils.MetaSQLite {
 		qry = "ALTER TABLE sm_costs RENAME TO session_costs"
 	}
 	if _, err := mgSQL.sqlStorage.Db.Exec(qry); err != nil {
@@ -108,7 +109,8 @@ func (mgSQL *migratorSQL) renameV1SMCosts() (err error) {
 
 func (mgSQL *migratorSQL) createV1SMCosts() (err error) {
 	qry := "CREATE TABLE sm_costs (  id int(11) NOT NULL AUTO_INCREMENT,  cgrid varchar(40) NOT NULL,  run_id  varchar(64) NOT NULL,  origin_host varchar(64) NOT NULL,  origin_id varchar(128) NOT NULL,  cost_source varchar(64) NOT NULL,  `usage` BIGINT NOT NULL,  cost_details MEDIUMTEXT,  created_at TIMESTAMP NULL,deleted_at TIMESTAMP NULL,  PRIMARY KEY (`id`),UNIQUE KEY costid (cgrid, run_id),KEY origin_idx (origin_host, origin_id),KEY run_origin_idx (run_id, origin_id),KEY deleted_at_idx (deleted_at));"
-	if mgSQL.StorDB().GetStorageType() == utils.MetaPostgres {
+	switch mgSQL.StorDB().GetStorageType() {
+	case utils.MetaPostgres:
 		qry = `
 	CREATE TABLE sm_costs (
 	  id SERIAL PRIMARY KEY,
@@ -124,6 +126,22 @@ func (mgSQL *migratorSQL) createV1SMCosts() (err error) {
 	  UNIQUE (cgrid, run_id)
 	);
 		`
+	case utils.MetaSQLite:
+		qry = `
+	CREATE TABLE sm_costs (
+	  id INTEGER PRIMARY KEY AUTOINCREMENT,
+	  cgrid VARCHAR(40) NOT NULL,
+	  run_id  VARCHAR(64) NOT NULL,
+	  origin_host VARCHAR(64) NOT NULL,
+	  origin_id VARCHAR(128) NOT NULL,
+	  cost_source VARCHAR(64) NOT NULL,
+	  usage BIGINT NOT NULL,
+	  cost_details TEXT,
+	  created_at DATETIME,
+	  deleted_at DATETIME NULL,
+	  UNIQUE (cgrid, run_id)
+	);
+		`
 	}
 	if _, err := mgSQL.sqlStorage.Db.Exec("DROP TABLE IF EXISTS session_costs;"); err != nil {
 		return err
@@ -137,6 +155,17 @@ func (mgSQL *migratorSQL) createV1SMCosts() (err error) {
 	return
 }
 
+// addV1TPDestinationRatesUsageCounter adds the usage_counter column to tp_destination_rates
+func (mgSQL *migratorSQL) addV1TPDestinationRatesUsageCounter() (err error) {
+	qry := "ALTER TABLE tp_destination_rates ADD COLUMN `usage_counter` varchar(64) DEFAULT '';"
+	if storType := mgSQL.StorDB().GetStorageType(); storType == utils.MetaPostgres ||
+		storType == utils.MetaSQLite {
+		qry = "ALTER TABLE tp_destination_rates ADD COLUMN usage_counter VARCHAR(64) DEFAULT ''"
+	}
+	_, err = mgSQL.sqlStorage.Db.Exec(qry)
+	return
+}
+
 func (mgSQL *migratorSQL) getV2SMCost() (v2Cost *v2SessionsCost, err error) {
 	if mgSQL.rowIter == nil {
 		mgSQL.rowIter, err = mgSQL.sqlStorage.Db.Query("SELECT * FROM session_costs")
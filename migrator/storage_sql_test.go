@@ -0,0 +1,50 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+package migrator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestMigratorSQLSQLiteSMCosts(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	storDB, err := NewMigratorStorDB(utils.MetaSQLite, utils.EmptyString, utils.EmptyString,
+		filepath.Join(t.TempDir(), "cgrates.db"), utils.EmptyString, utils.EmptyString,
+		utils.MsgPack, nil, nil, cfg.StorDbCfg().Opts, cfg.StorDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storDB.close()
+	mgSQL, canCast := storDB.(*migratorSQL)
+	if !canCast {
+		t.Fatalf("expected migratorSQL, received %T", storDB)
+	}
+	if err = mgSQL.createV1SMCosts(); err != nil {
+		t.Fatal(err)
+	}
+	if err = mgSQL.renameV1SMCosts(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = mgSQL.sqlStorage.Db.Exec("SELECT cgrid, run_id, cost_details FROM session_costs"); err != nil {
+		t.Error(err)
+	}
+}
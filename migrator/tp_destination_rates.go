@@ -63,6 +63,11 @@ func (m *Migrator) migrateTPdestinationrates() (err error) {
 		return
 	}
 	switch vrs[utils.TpDestinationRates] {
+	case 1:
+		if err = m.migrateV1TPdestinationrates(); err != nil {
+			return
+		}
+		fallthrough // move them to the new StorDB
 	case current[utils.TpDestinationRates]:
 		if m.sameStorDB {
 			break
@@ -73,3 +78,14 @@ func (m *Migrator) migrateTPdestinationrates() (err error) {
 	}
 	return m.ensureIndexesStorDB(utils.TBLTPDestinationRates)
 }
+
+// migrateV1TPdestinationrates adds the UsageCounter to the destination rates
+func (m *Migrator) migrateV1TPdestinationrates() (err error) {
+	if m.dryRun {
+		return
+	}
+	if err = m.storDBIn.addV1TPDestinationRatesUsageCounter(); err != nil {
+		return
+	}
+	return m.setVersions(utils.TpDestinationRates)
+}
@@ -58,6 +58,13 @@ type AnalyzerService struct {
 	anz      *analyzers.AnalyzerService
 	connChan chan birpc.ClientConnector
 	srvDep   map[string]*sync.WaitGroup
+
+	connMgr *engine.ConnManager
+}
+
+// SetConnManager sets the connManager used by AnalyzerS to replay the captured traffic
+func (anz *AnalyzerService) SetConnManager(connMgr *engine.ConnManager) {
+	anz.connMgr = connMgr
 }
 
 // Start should handle the sercive start
@@ -72,6 +79,7 @@ func (anz *AnalyzerService) Start() (err error) {
 		utils.Logger.Crit(fmt.Sprintf("<%s> Could not init, error: %s", utils.AnalyzerS, err.Error()))
 		return
 	}
+	anz.anz.SetConnManager(anz.connMgr)
 	anz.stopChan = make(chan struct{})
 	go func(a *analyzers.AnalyzerService) {
 		if err := a.ListenAndServe(anz.stopChan); err != nil {
@@ -24,6 +24,7 @@ import (
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/guardian"
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -76,6 +77,9 @@ func (db *DataDBService) Start() (err error) {
 	}
 	db.dm = engine.NewDataManager(dbConn, db.cfg.CacheCfg(), db.connMgr)
 	engine.SetDataStorage(db.dm)
+	if err = db.setDistributedLocker(); err != nil {
+		return
+	}
 
 	if db.setVersions {
 		err = engine.OverwriteDBVersions(dbConn)
@@ -100,7 +104,7 @@ func (db *DataDBService) Reload() (err error) {
 			return
 		}
 		db.oldDBCfg = db.cfg.DataDbCfg().Clone()
-		return
+		return db.setDistributedLocker()
 	}
 	if db.cfg.DataDbCfg().Type == utils.MetaMongo {
 		mgo, canCast := db.dm.DataDB().(*engine.MongoStorage)
@@ -117,12 +121,26 @@ func (db *DataDBService) Reload() (err error) {
 func (db *DataDBService) Shutdown() (err error) {
 	db.srvDep[utils.DataDB].Wait()
 	db.Lock()
+	guardian.Guardian.SetDistributedLocker(nil, 0)
 	db.dm.Close()
 	db.dm = nil
 	db.Unlock()
 	return
 }
 
+// setDistributedLocker shares the guardian locks through the DataDB, if enabled
+func (db *DataDBService) setDistributedLocker() error {
+	if !db.cfg.GeneralCfg().DistributedLocking {
+		return nil
+	}
+	dl, err := engine.NewDistributedLocker(db.dm, db.cfg.DataDbCfg().RplConns)
+	if err != nil {
+		return err
+	}
+	guardian.Guardian.SetDistributedLocker(dl, db.cfg.GeneralCfg().LockingLeaseTTL)
+	return nil
+}
+
 // IsRunning returns if the service is running
 func (db *DataDBService) IsRunning() bool {
 	db.RLock()
@@ -0,0 +1,122 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cgrates/birpc"
+	v1 "github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewFraudService returns the FraudS Service
+func NewFraudService(cfg *config.CGRConfig, filterSChan chan *engine.FilterS,
+	server *cores.Server, internalFraudSChan chan birpc.ClientConnector,
+	connMgr *engine.ConnManager, anz *AnalyzerService,
+	srvDep map[string]*sync.WaitGroup) servmanager.Service {
+	return &FraudService{
+		connChan:    internalFraudSChan,
+		cfg:         cfg,
+		filterSChan: filterSChan,
+		server:      server,
+		connMgr:     connMgr,
+		anz:         anz,
+		srvDep:      srvDep,
+	}
+}
+
+// FraudService implements Service interface
+type FraudService struct {
+	sync.RWMutex
+	cfg         *config.CGRConfig
+	filterSChan chan *engine.FilterS
+	server      *cores.Server
+	connMgr     *engine.ConnManager
+
+	fS       *engine.FraudS
+	connChan chan birpc.ClientConnector
+	anz      *AnalyzerService
+	srvDep   map[string]*sync.WaitGroup
+}
+
+// Start should handle the sercive start
+func (fS *FraudService) Start() error {
+	if fS.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+	filterS := <-fS.filterSChan
+	fS.filterSChan <- filterS
+
+	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem",
+		utils.CoreS, utils.FraudS))
+	fS.Lock()
+	defer fS.Unlock()
+	fS.fS = engine.NewFraudS(fS.cfg, filterS, fS.connMgr)
+	fS.fS.StartLoop()
+	srv, err := engine.NewService(v1.NewFraudSv1(fS.fS))
+	if err != nil {
+		return err
+	}
+	if !fS.cfg.DispatcherSCfg().Enabled {
+		fS.server.RpcRegister(srv)
+	}
+	fS.connChan <- fS.anz.GetInternalCodec(srv, utils.FraudS)
+	return nil
+}
+
+// Reload handles the change of config
+func (fS *FraudService) Reload() (err error) {
+	fS.Lock()
+	fS.fS.Reload()
+	fS.Unlock()
+	return
+}
+
+// Shutdown stops the service
+func (fS *FraudService) Shutdown() (err error) {
+	fS.Lock()
+	defer fS.Unlock()
+	fS.fS.Shutdown()
+	fS.fS = nil
+	<-fS.connChan
+	return
+}
+
+// IsRunning returns if the service is running
+func (fS *FraudService) IsRunning() bool {
+	fS.RLock()
+	defer fS.RUnlock()
+	return fS.fS != nil
+}
+
+// ServiceName returns the service name
+func (fS *FraudService) ServiceName() string {
+	return utils.FraudS
+}
+
+// ShouldRun returns if the service should be running
+func (fS *FraudService) ShouldRun() bool {
+	return fS.cfg.FraudSCfg().Enabled
+}
@@ -0,0 +1,62 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestNewFraudService(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	filterSChan := make(chan *engine.FilterS, 1)
+	server := &cores.Server{}
+	internalFraudSChan := make(chan birpc.ClientConnector, 1)
+	connMgr := &engine.ConnManager{}
+	anz := &AnalyzerService{}
+	srvDep := map[string]*sync.WaitGroup{}
+	fS, ok := NewFraudService(cfg, filterSChan, server, internalFraudSChan,
+		connMgr, anz, srvDep).(*FraudService)
+	if !ok {
+		t.Fatalf("expected *FraudService, received %T", fS)
+	}
+	if fS.cfg != cfg || fS.filterSChan != filterSChan || fS.server != server ||
+		fS.connChan != internalFraudSChan || fS.connMgr != connMgr || fS.anz != anz {
+		t.Errorf("unexpected service: %+v", fS)
+	}
+	if fS.ServiceName() != utils.FraudS {
+		t.Errorf("expected %s, received %s", utils.FraudS, fS.ServiceName())
+	}
+	if fS.IsRunning() {
+		t.Error("expected service to not be running")
+	}
+	if fS.ShouldRun() {
+		t.Error("expected service to not run with the default config")
+	}
+	cfg.FraudSCfg().Enabled = true
+	if !fS.ShouldRun() {
+		t.Error("expected service to run once enabled")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cgrates/birpc"
+	v1 "github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewInvoiceService returns the InvoiceS Service
+func NewInvoiceService(cfg *config.CGRConfig, dm *DataDBService,
+	server *cores.Server, internalInvoiceSChan chan birpc.ClientConnector,
+	connMgr *engine.ConnManager, anz *AnalyzerService,
+	srvDep map[string]*sync.WaitGroup) servmanager.Service {
+	return &InvoiceService{
+		connChan: internalInvoiceSChan,
+		cfg:      cfg,
+		dm:       dm,
+		server:   server,
+		connMgr:  connMgr,
+		anz:      anz,
+		srvDep:   srvDep,
+	}
+}
+
+// InvoiceService implements Service interface
+type InvoiceService struct {
+	sync.RWMutex
+	cfg     *config.CGRConfig
+	dm      *DataDBService
+	server  *cores.Server
+	connMgr *engine.ConnManager
+
+	invS     *engine.InvoiceS
+	connChan chan birpc.ClientConnector
+	anz      *AnalyzerService
+	srvDep   map[string]*sync.WaitGroup
+}
+
+// Start should handle the sercive start
+func (invS *InvoiceService) Start() error {
+	if invS.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+	invS.srvDep[utils.DataDB].Add(1)
+	dbchan := invS.dm.GetDMChan()
+	datadb := <-dbchan
+	dbchan <- datadb
+
+	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem",
+		utils.CoreS, utils.InvoiceS))
+	invS.Lock()
+	defer invS.Unlock()
+	invS.invS = engine.NewInvoiceS(datadb, invS.connMgr, invS.cfg)
+	srv, err := engine.NewService(v1.NewInvoiceSv1(invS.invS))
+	if err != nil {
+		return err
+	}
+	if !invS.cfg.DispatcherSCfg().Enabled {
+		invS.server.RpcRegister(srv)
+	}
+	invS.connChan <- invS.anz.GetInternalCodec(srv, utils.InvoiceS)
+	return nil
+}
+
+// Reload handles the change of config
+func (invS *InvoiceService) Reload() (err error) {
+	return // the config is read on each request
+}
+
+// Shutdown stops the service
+func (invS *InvoiceService) Shutdown() (err error) {
+	defer invS.srvDep[utils.DataDB].Done()
+	invS.Lock()
+	defer invS.Unlock()
+	invS.invS = nil
+	<-invS.connChan
+	return
+}
+
+// IsRunning returns if the service is running
+func (invS *InvoiceService) IsRunning() bool {
+	invS.RLock()
+	defer invS.RUnlock()
+	return invS.invS != nil
+}
+
+// ServiceName returns the service name
+func (invS *InvoiceService) ServiceName() string {
+	return utils.InvoiceS
+}
+
+// ShouldRun returns if the service should be running
+func (invS *InvoiceService) ShouldRun() bool {
+	return invS.cfg.InvoiceSCfg().Enabled
+}
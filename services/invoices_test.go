@@ -0,0 +1,65 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestNewInvoiceService(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dm := &DataDBService{}
+	server := &cores.Server{}
+	internalInvoiceSChan := make(chan birpc.ClientConnector, 1)
+	connMgr := &engine.ConnManager{}
+	anz := &AnalyzerService{}
+	srvDep := map[string]*sync.WaitGroup{utils.DataDB: new(sync.WaitGroup)}
+	invS, ok := NewInvoiceService(cfg, dm, server, internalInvoiceSChan,
+		connMgr, anz, srvDep).(*InvoiceService)
+	if !ok {
+		t.Fatalf("expected *InvoiceService, received %T", invS)
+	}
+	if invS.cfg != cfg || invS.dm != dm || invS.server != server ||
+		invS.connChan != internalInvoiceSChan || invS.connMgr != connMgr || invS.anz != anz {
+		t.Errorf("unexpected service: %+v", invS)
+	}
+	if invS.ServiceName() != utils.InvoiceS {
+		t.Errorf("expected %s, received %s", utils.InvoiceS, invS.ServiceName())
+	}
+	if invS.IsRunning() {
+		t.Error("expected service to not be running")
+	}
+	if invS.ShouldRun() {
+		t.Error("expected service to not run with the default config")
+	}
+	cfg.InvoiceSCfg().Enabled = true
+	if !invS.ShouldRun() {
+		t.Error("expected service to run once enabled")
+	}
+	if err := invS.Reload(); err != nil {
+		t.Error(err)
+	}
+}
@@ -112,8 +112,9 @@ func (smg *SessionService) Start() error {
 		}
 		smg.server.RpcRegister(legacySrv)
 	}
-	// Register BiRpc handlers
-	if smg.cfg.SessionSCfg().ListenBiJSON != "" {
+	// Register BiRpc handlers, served on the BiJSON listener and/or over HTTP WebSocket
+	if smg.cfg.SessionSCfg().ListenBiJSON != "" ||
+		smg.cfg.HTTPCfg().BiRPCWSURL != "" {
 		smg.birpcEnabled = true
 		smg.server.BiRPCRegisterName(utils.SessionSv1, srv)
 		// run this in it's own goroutine
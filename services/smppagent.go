@@ -0,0 +1,133 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewSMPPAgent returns the SMPP Agent
+func NewSMPPAgent(cfg *config.CGRConfig, filterSChan chan *engine.FilterS,
+	shdChan *utils.SyncedChan, connMgr *engine.ConnManager, caps *engine.Caps,
+	srvDep map[string]*sync.WaitGroup) servmanager.Service {
+	return &SMPPAgent{
+		cfg:         cfg,
+		filterSChan: filterSChan,
+		shdChan:     shdChan,
+		connMgr:     connMgr,
+		caps:        caps,
+		srvDep:      srvDep,
+	}
+}
+
+// SMPPAgent implements Agent interface
+type SMPPAgent struct {
+	sync.RWMutex
+	cfg         *config.CGRConfig
+	filterSChan chan *engine.FilterS
+	shdChan     *utils.SyncedChan
+
+	stopChan chan struct{}
+
+	smpp    *agents.SMPPAgent
+	connMgr *engine.ConnManager
+	caps    *engine.Caps
+	srvDep  map[string]*sync.WaitGroup
+}
+
+// Start should handle the service start
+func (smpp *SMPPAgent) Start() (err error) {
+	if smpp.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+
+	filterS := <-smpp.filterSChan
+	smpp.filterSChan <- filterS
+
+	smpp.Lock()
+	defer smpp.Unlock()
+	smpp.smpp = agents.NewSMPPAgent(smpp.cfg, filterS, smpp.connMgr, smpp.caps)
+	smpp.stopChan = make(chan struct{})
+	smpp.smpp.RLock() // released once the agent stops listening
+	go smpp.listenAndServe(smpp.smpp, smpp.stopChan)
+	return
+}
+
+// Reload handles the change of config
+func (smpp *SMPPAgent) Reload() (err error) {
+	filterS := <-smpp.filterSChan
+	smpp.filterSChan <- filterS
+
+	smpp.Lock()
+	defer smpp.Unlock()
+	if smpp.smpp != nil {
+		close(smpp.stopChan)
+		smpp.smpp.Lock() // wait for the listener to be released
+		smpp.smpp.Unlock()
+	}
+	smpp.smpp = agents.NewSMPPAgent(smpp.cfg, filterS, smpp.connMgr, smpp.caps)
+	smpp.stopChan = make(chan struct{})
+	smpp.smpp.RLock()
+	go smpp.listenAndServe(smpp.smpp, smpp.stopChan)
+	return
+}
+
+func (smpp *SMPPAgent) listenAndServe(sa *agents.SMPPAgent, stopChan chan struct{}) {
+	defer sa.RUnlock()
+	if err := sa.ListenAndServe(stopChan); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> error: <%s>", utils.SMPPAgent, err.Error()))
+		smpp.shdChan.CloseOnce() // stop the engine here
+	}
+}
+
+// Shutdown stops the service
+func (smpp *SMPPAgent) Shutdown() (err error) {
+	smpp.Lock()
+	defer smpp.Unlock()
+	if smpp.smpp == nil {
+		return
+	}
+	close(smpp.stopChan)
+	smpp.smpp = nil
+	return
+}
+
+// IsRunning returns if the service is running
+func (smpp *SMPPAgent) IsRunning() bool {
+	smpp.RLock()
+	defer smpp.RUnlock()
+	return smpp.smpp != nil
+}
+
+// ServiceName returns the service name
+func (smpp *SMPPAgent) ServiceName() string {
+	return utils.SMPPAgent
+}
+
+// ShouldRun returns if the service should be running
+func (smpp *SMPPAgent) ShouldRun() bool {
+	return smpp.cfg.SMPPAgentCfg().Enabled
+}
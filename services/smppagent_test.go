@@ -0,0 +1,66 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// TestSMPPAgentCoverage for cover testing
+func TestSMPPAgentCoverage(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SMPPAgentCfg().Listen = "127.0.0.1:0"
+	filterSChan := make(chan *engine.FilterS, 1)
+	filterSChan <- nil
+	shdChan := utils.NewSyncedChan()
+	srvDep := map[string]*sync.WaitGroup{utils.DataDB: new(sync.WaitGroup)}
+	srv := NewSMPPAgent(cfg, filterSChan, shdChan, nil, nil, srvDep)
+	if srv.IsRunning() {
+		t.Errorf("Expected service to be down")
+	}
+	if serviceName := srv.ServiceName(); serviceName != utils.SMPPAgent {
+		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.SMPPAgent, serviceName)
+	}
+	if shouldRun := srv.ShouldRun(); shouldRun != false {
+		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", false, shouldRun)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if !srv.IsRunning() {
+		t.Errorf("Expected service to be running")
+	}
+	if err := srv.Start(); err != utils.ErrServiceAlreadyRunning {
+		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ErrServiceAlreadyRunning, err)
+	}
+	if err := srv.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	if srv.IsRunning() {
+		t.Errorf("Expected service to be down")
+	}
+}
@@ -110,7 +110,8 @@ func (db *StorDBService) Reload() (err error) {
 		}
 		mgo.SetTTL(db.cfg.StorDbCfg().Opts.MongoQueryTimeout)
 	} else if db.cfg.StorDbCfg().Type == utils.MetaPostgres ||
-		db.cfg.StorDbCfg().Type == utils.MetaMySQL {
+		db.cfg.StorDbCfg().Type == utils.MetaMySQL ||
+		db.cfg.StorDbCfg().Type == utils.MetaSQLite {
 		msql, canCast := db.db.(*engine.SQLStorage)
 		if !canCast {
 			return fmt.Errorf("can't conver StorDB of type %s to SQLStorage",
@@ -186,7 +186,7 @@ func TestStorDBReloadVersion1(t *testing.T) {
 		utils.CDRs:               1,
 		utils.TpRatingPlans:      1,
 		utils.TpFilters:          1,
-		utils.TpDestinationRates: 1,
+		utils.TpDestinationRates: 2,
 		utils.TpActionTriggers:   1,
 		utils.TpAccountActionsV:  1,
 		utils.TpActionPlans:      1,
@@ -268,7 +268,7 @@ func TestStorDBReloadVersion2(t *testing.T) {
 		utils.CDRs:               1,
 		utils.TpRatingPlans:      1,
 		utils.TpFilters:          1,
-		utils.TpDestinationRates: 1,
+		utils.TpDestinationRates: 2,
 		utils.TpActionTriggers:   1,
 		utils.TpAccountActionsV:  1,
 		utils.TpActionPlans:      1,
@@ -346,7 +346,7 @@ func TestStorDBReloadVersion3(t *testing.T) {
 		utils.CDRs:               1,
 		utils.TpRatingPlans:      1,
 		utils.TpFilters:          1,
-		utils.TpDestinationRates: 1,
+		utils.TpDestinationRates: 2,
 		utils.TpActionTriggers:   1,
 		utils.TpAccountActionsV:  1,
 		utils.TpActionPlans:      1,
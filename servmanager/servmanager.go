@@ -169,6 +169,10 @@ func (srvMngr *ServiceManager) handleReload() {
 			go srvMngr.reloadService(utils.TrendS)
 		case <-srvMngr.GetConfig().GetReloadChan(config.RANKINGS_JSON):
 			go srvMngr.reloadService(utils.RankingS)
+		case <-srvMngr.GetConfig().GetReloadChan(config.InvoiceSJson):
+			go srvMngr.reloadService(utils.InvoiceS)
+		case <-srvMngr.GetConfig().GetReloadChan(config.FraudSJson):
+			go srvMngr.reloadService(utils.FraudS)
 		case <-srvMngr.GetConfig().GetReloadChan(config.RESOURCES_JSON):
 			go srvMngr.reloadService(utils.ResourceS)
 		case <-srvMngr.GetConfig().GetReloadChan(config.RouteSJson):
@@ -190,6 +194,8 @@ func (srvMngr *ServiceManager) handleReload() {
 			go srvMngr.reloadService(utils.ERs)
 		case <-srvMngr.GetConfig().GetReloadChan(config.DNSAgentJson):
 			go srvMngr.reloadService(utils.DNSAgent)
+		case <-srvMngr.GetConfig().GetReloadChan(config.SMPPAgentJson):
+			go srvMngr.reloadService(utils.SMPPAgent)
 		case <-srvMngr.GetConfig().GetReloadChan(config.FreeSWITCHAgentJSN):
 			go srvMngr.reloadService(utils.FreeSWITCHAgent)
 		case <-srvMngr.GetConfig().GetReloadChan(config.KamailioAgentJSN):
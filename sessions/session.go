@@ -117,6 +117,14 @@ func (s *Session) cgrID() (cgrID string) {
 // Clone is a thread safe method to clone the sessions information
 func (s *Session) Clone() (cln *Session) {
 	s.RLock()
+	cln = s.clone()
+	s.RUnlock()
+	return
+}
+
+// clone returns a copy of the session
+// not thread safe
+func (s *Session) clone() (cln *Session) {
 	cln = &Session{
 		CGRID:         s.CGRID,
 		Tenant:        s.Tenant,
@@ -125,6 +133,9 @@ func (s *Session) Clone() (cln *Session) {
 		ClientConnID:  s.ClientConnID,
 		EventStart:    s.EventStart.Clone(),
 		DebitInterval: s.DebitInterval,
+		Chargeable:    s.Chargeable,
+		OptsStart:     s.OptsStart.Clone(),
+		UpdatedAt:     s.UpdatedAt,
 	}
 	if s.SRuns != nil {
 		cln.SRuns = make([]*SRun, len(s.SRuns))
@@ -132,7 +143,6 @@ func (s *Session) Clone() (cln *Session) {
 			cln.SRuns[i] = sR.Clone()
 		}
 	}
-	s.RUnlock()
 	return
 }
 
@@ -152,6 +162,7 @@ func (s *Session) asStoredSession() *engine.StoredSession {
 	}
 
 	return &engine.StoredSession{
+		Version:       engine.SessionSnapshotVersion,
 		CGRID:         s.CGRID,
 		Tenant:        s.Tenant,
 		IPAllocID:     s.IPAllocID,
@@ -81,6 +81,9 @@ func TestSessionClone(t *testing.T) {
 		ClientConnID:  "ClientConnID",
 		EventStart:    engine.NewMapEvent(nil),
 		DebitInterval: 18,
+		Chargeable:    true,
+		OptsStart:     engine.MapEvent{utils.OptsDebitInterval: "5s"},
+		UpdatedAt:     tTime2,
 		SRuns: []*SRun{
 			{Event: engine.NewMapEvent(nil),
 				CD:            &engine.CallDescriptor{Category: "test"},
@@ -107,6 +110,9 @@ func TestSessionClone(t *testing.T) {
 		ClientConnID:  "ClientConnID",
 		EventStart:    engine.NewMapEvent(nil),
 		DebitInterval: 18,
+		Chargeable:    true,
+		OptsStart:     engine.MapEvent{utils.OptsDebitInterval: "5s"},
+		UpdatedAt:     tTime2,
 		SRuns: []*SRun{
 			{Event: engine.NewMapEvent(nil),
 				CD:            &engine.CallDescriptor{Category: "test"},
@@ -130,6 +136,10 @@ func TestSessionClone(t *testing.T) {
 	if !reflect.DeepEqual(eOut, rcv) && session.CGRID == "testID" {
 		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eOut), utils.ToJSON(rcv))
 	}
+	if !rcv.Chargeable || !rcv.UpdatedAt.Equal(tTime2) ||
+		!reflect.DeepEqual(eOut.OptsStart, rcv.OptsStart) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eOut), utils.ToJSON(rcv))
+	}
 	//check clone
 	rcv.CGRID = "newCGRID"
 
@@ -26,6 +26,7 @@ import (
 	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cgrates/birpc"
@@ -95,6 +96,8 @@ type SessionS struct {
 	removeSsCGRIDs    utils.StringSet // keep a record of session cgrids to be removed from dataDB backup
 	removeSsCGRIDsMux sync.RWMutex    // prevent concurrency when adding/deleting CGRIDs from map
 	storeSessMux      sync.RWMutex    // protects storeSessions
+
+	draining atomic.Bool // new sessions are refused while handing over the active ones
 }
 
 // SyncSessions starts the service and binds it to the listen loop
@@ -126,11 +129,14 @@ func (sS *SessionS) Shutdown() (err error) {
 func (sS *SessionS) OnBiJSONConnect(c birpc.ClientConnector) {
 	nodeID := utils.UUIDSha1Prefix() // connection identifier, should be later updated as login procedure
 	sS.biJMux.Lock()
+	defer sS.biJMux.Unlock()
+	if _, has := sS.biJClnts[c]; has { // the connect event is async so the client could have registered already
+		return
+	}
 	sS.biJClnts[c] = nodeID
 	sS.biJIDs[nodeID] = &biJClient{
 		conn:  c,
 		proto: sS.cgrCfg.SessionSCfg().ClientProtocol}
-	sS.biJMux.Unlock()
 }
 
 // OnBiJSONDisconnect handles client disconnects.
@@ -138,7 +144,10 @@ func (sS *SessionS) OnBiJSONDisconnect(c birpc.ClientConnector) {
 	sS.biJMux.Lock()
 	if nodeID, has := sS.biJClnts[c]; has {
 		delete(sS.biJClnts, c)
-		delete(sS.biJIDs, nodeID)
+		// the connection ID could have been taken over by a reconnected client
+		if clnt, has := sS.biJIDs[nodeID]; has && clnt.conn == c {
+			delete(sS.biJIDs, nodeID)
+		}
 	}
 	sS.biJMux.Unlock()
 }
@@ -149,6 +158,11 @@ func (sS *SessionS) RegisterIntBiJConn(c birpc.ClientConnector, nodeID string) {
 		nodeID = sS.cgrCfg.GeneralCfg().NodeID
 	}
 	sS.biJMux.Lock()
+	if oldID, has := sS.biJClnts[c]; has && oldID != nodeID {
+		if clnt, has := sS.biJIDs[oldID]; has && clnt.conn == c {
+			delete(sS.biJIDs, oldID) // the ID generated on connect is replaced
+		}
+	}
 	sS.biJClnts[c] = nodeID
 	sS.biJIDs[nodeID] = &biJClient{
 		conn:  c,
@@ -595,11 +609,11 @@ func (sS *SessionS) pause(sr *SRun, dur time.Duration) {
 // threadSafe since it will run into it's own goroutine
 func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 	dbtIvl time.Duration) (maxDur time.Duration, err error) {
-	// NextAutoDebit works in tandem with session replication
+	// NextAutoDebit works in tandem with session replication, the usage up to it was already debited
 	now := time.Now()
 	if s.SRuns[sRunIdx].NextAutoDebit != nil &&
 		now.Before(*s.SRuns[sRunIdx].NextAutoDebit) {
-		time.Sleep(now.Sub(*s.SRuns[sRunIdx].NextAutoDebit))
+		time.Sleep(s.SRuns[sRunIdx].NextAutoDebit.Sub(now))
 	}
 	for {
 		s.Lock()
@@ -1420,6 +1434,7 @@ func (sS *SessionS) transitSState(cgrID string, psv bool) (s *Session) {
 	sS.registerSession(s, psv)
 	if !psv {
 		sS.initSessionDebitLoops(s)
+		sS.setSTerminator(s, nil)
 	} else { // transit from active with possible STerminator and DebitLoops
 		s.stopSTerminator()
 		s.stopDebitLoops()
@@ -1648,8 +1663,11 @@ func (sS *SessionS) restoreSessions(sessions []*Session) {
 			tor = utils.MetaVoice
 		}
 		if time.Since(s.UpdatedAt) <= sS.cgrCfg.SessionSCfg().DefaultUsage[tor] {
+			s.Lock()
 			sS.initSessionDebitLoops(s)
+			sS.setSTerminator(s, nil)
 			sS.registerSession(s, false)
+			s.Unlock()
 		} else { // remove expired sessions from dataDB
 			sS.removeSsCGRIDsMux.Lock()
 			sS.removeSsCGRIDs.Add(s.CGRID)
@@ -1662,6 +1680,9 @@ func (sS *SessionS) restoreSessions(sessions []*Session) {
 // not thread-safe for Session since it is constructed here
 func (sS *SessionS) initSession(cgrEv *utils.CGREvent, clntConnID, originID string,
 	dbtItval time.Duration, isMsg, forceDuration bool) (s *Session, err error) {
+	if !isMsg && sS.draining.Load() {
+		return nil, utils.ErrSessionsDraining
+	}
 	if s, err = sS.newSession(cgrEv, originID, clntConnID, dbtItval, forceDuration, isMsg); err != nil {
 		return nil, err
 	}
@@ -1844,6 +1865,23 @@ func (sS *SessionS) endSession(s *Session, tUsage, lastUsage *time.Duration,
 			sr.Event[utils.AnswerTime] = *aTime
 		}
 	}
+	if !isMsg && len(sS.cgrCfg.SessionSCfg().FraudSConns) != 0 {
+		fraudEv := &utils.CGREvent{
+			Tenant:  s.Tenant,
+			ID:      utils.GenUUID(),
+			Event:   s.EventStart.Clone(),
+			APIOpts: s.OptsStart.Clone(),
+		}
+		for _, sr := range s.SRuns {
+			if sr.Event.GetStringIgnoreErrors(utils.RunID) == utils.MetaDefault {
+				if cost, has := sr.Event[utils.Cost]; has {
+					fraudEv.Event[utils.Cost] = cost
+				}
+				break
+			}
+		}
+		sS.releaseFraud(fraudEv)
+	}
 	if errCh := engine.Cache.Set(utils.CacheClosedSessions, s.CGRID, s,
 		nil, true, utils.NonTransactional); errCh != nil {
 		return errCh
@@ -2085,6 +2123,7 @@ func (args *V1AuthorizeArgs) ParseFlags(flags, sep string) {
 // V1AuthorizeReply are options available in auth reply
 type V1AuthorizeReply struct {
 	Attributes         *engine.AttrSProcessEventReply `json:",omitempty"`
+	FraudScore         *engine.FraudScore             `json:",omitempty"`
 	AllocatedIP        *engine.AllocatedIP            `json:",omitempty"`
 	ResourceAllocation *string                        `json:",omitempty"`
 	MaxUsage           *time.Duration                 `json:",omitempty"`
@@ -2117,6 +2156,12 @@ func (r *V1AuthorizeReply) AsNavigableMap() map[string]*utils.DataNode {
 		}
 		cgrReply[utils.CapAttributes] = attrs
 	}
+	if r.FraudScore != nil {
+		cgrReply[utils.CapFraudScore] = &utils.DataNode{
+			Type: utils.NMMapType,
+			Map:  r.FraudScore.AsNavigableMap(),
+		}
+	}
 	if r.AllocatedIP != nil {
 		cgrReply[utils.CapAllocatedIP] = &utils.DataNode{
 			Type: utils.NMMapType,
@@ -2173,8 +2218,12 @@ func (sS *SessionS) BiRPCv1AuthorizeEvent(ctx *context.Context,
 	// RPC caching
 	if sS.cgrCfg.CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.SessionSv1AuthorizeEvent, args.CGREvent.ID)
-		refID := guardian.Guardian.GuardIDs("",
-			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 
 		if itm, has := engine.Cache.Get(utils.CacheRPCResponses, cacheKey); has {
@@ -2203,6 +2252,10 @@ func (sS *SessionS) BiRPCv1AuthorizeEvent(ctx *context.Context,
 			return utils.NewErrAttributeS(err)
 		}
 	}
+	if authReply.FraudScore, err = sS.processFraud(args.CGREvent,
+		utils.FraudSv1ScoreEvent); err != nil {
+		return
+	}
 	if args.GetMaxUsage {
 		var sRunsUsage map[string]time.Duration
 		if sRunsUsage, err = sS.authEvent(args.CGREvent, args.ForceDuration); err != nil {
@@ -2411,6 +2464,7 @@ func (args *V1InitSessionArgs) ParseFlags(flags, sep string) {
 // V1InitSessionReply are options for initialization reply
 type V1InitSessionReply struct {
 	Attributes         *engine.AttrSProcessEventReply `json:",omitempty"`
+	FraudScore         *engine.FraudScore             `json:",omitempty"`
 	AllocatedIP        *engine.AllocatedIP            `json:",omitempty"`
 	ResourceAllocation *string                        `json:",omitempty"`
 	MaxUsage           *time.Duration                 `json:",omitempty"`
@@ -2442,6 +2496,12 @@ func (r *V1InitSessionReply) AsNavigableMap() map[string]*utils.DataNode {
 		}
 		cgrReply[utils.CapAttributes] = attrs
 	}
+	if r.FraudScore != nil {
+		cgrReply[utils.CapFraudScore] = &utils.DataNode{
+			Type: utils.NMMapType,
+			Map:  r.FraudScore.AsNavigableMap(),
+		}
+	}
 	if r.AllocatedIP != nil {
 		cgrReply[utils.CapAllocatedIP] = &utils.DataNode{
 			Type: utils.NMMapType,
@@ -2494,8 +2554,12 @@ func (sS *SessionS) BiRPCv1InitiateSession(ctx *context.Context,
 	// RPC caching
 	if sS.cgrCfg.CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.SessionSv1InitiateSession, args.CGREvent.ID)
-		refID := guardian.Guardian.GuardIDs("",
-			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 
 		if itm, has := engine.Cache.Get(utils.CacheRPCResponses, cacheKey); has {
@@ -2524,6 +2588,21 @@ func (sS *SessionS) BiRPCv1InitiateSession(ctx *context.Context,
 			return utils.NewErrAttributeS(err)
 		}
 	}
+	var fraudEv *utils.CGREvent // recorded as active by FraudS until the session is initiated
+	if args.InitSession {
+		if rply.FraudScore, err = sS.processFraud(args.CGREvent,
+			utils.FraudSv1ProcessEvent); err != nil {
+			return // the blocked events are not recorded by FraudS
+		}
+		if rply.FraudScore != nil {
+			fraudEv = args.CGREvent.Clone()
+			defer func() {
+				if err != nil && fraudEv != nil {
+					sS.releaseFraud(fraudEv)
+				}
+			}()
+		}
+	}
 	if args.AllocateResources {
 		if len(sS.cgrCfg.SessionSCfg().ResourceSConns) == 0 {
 			return utils.NewErrNotConnected(utils.ResourceS)
@@ -2575,6 +2654,8 @@ func (sS *SessionS) BiRPCv1InitiateSession(ctx *context.Context,
 		if err != nil {
 			return err
 		}
+		fraudEv = nil // FraudS is released together with the session
+
 		s.RLock() // avoid concurrency with activeDebit
 		hasDebitLoops := s.debitStop != nil
 		s.RUnlock()
@@ -2771,8 +2852,12 @@ func (sS *SessionS) BiRPCv1UpdateSession(ctx *context.Context,
 	// RPC caching
 	if sS.cgrCfg.CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.SessionSv1UpdateSession, args.CGREvent.ID)
-		refID := guardian.Guardian.GuardIDs("",
-			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 
 		if itm, has := engine.Cache.Get(utils.CacheRPCResponses, cacheKey); has {
@@ -2820,6 +2905,8 @@ func (sS *SessionS) BiRPCv1UpdateSession(ctx *context.Context,
 				dbtItvl, false, args.ForceDuration); err != nil {
 				return err
 			}
+		} else {
+			sS.rebindClientConn(s, sS.biJClntID(ctx.Client))
 		}
 		var sRunsUsage map[string]time.Duration
 		if sRunsUsage, err = sS.updateSession(s, ev, args.APIOpts, false); err != nil {
@@ -2939,8 +3026,12 @@ func (sS *SessionS) BiRPCv1TerminateSession(ctx *context.Context,
 	// RPC caching
 	if sS.cgrCfg.CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.SessionSv1TerminateSession, args.CGREvent.ID)
-		refID := guardian.Guardian.GuardIDs("",
-			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 
 		if itm, has := engine.Cache.Get(utils.CacheRPCResponses, cacheKey); has {
@@ -3089,8 +3180,12 @@ func (sS *SessionS) BiRPCv1ProcessCDR(ctx *context.Context,
 	// RPC caching
 	if sS.cgrCfg.CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.SessionSv1ProcessCDR, cgrEv.ID)
-		refID := guardian.Guardian.GuardIDs("",
-			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 
 		if itm, has := engine.Cache.Get(utils.CacheRPCResponses, cacheKey); has {
@@ -3292,8 +3387,12 @@ func (sS *SessionS) BiRPCv1ProcessMessage(ctx *context.Context,
 	// RPC caching
 	if sS.cgrCfg.CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.SessionSv1ProcessMessage, args.CGREvent.ID)
-		refID := guardian.Guardian.GuardIDs("",
-			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 
 		if itm, has := engine.Cache.Get(utils.CacheRPCResponses, cacheKey); has {
@@ -3538,8 +3637,12 @@ func (sS *SessionS) BiRPCv1ProcessEvent(ctx *context.Context,
 	// RPC caching
 	if sS.cgrCfg.CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.SessionSv1ProcessEvent, args.CGREvent.ID)
-		refID := guardian.Guardian.GuardIDs("",
-			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 
 		if itm, has := engine.Cache.Get(utils.CacheRPCResponses, cacheKey); has {
@@ -4031,8 +4134,12 @@ func (sS *SessionS) BiRPCv1GetCost(ctx *context.Context,
 	// RPC caching
 	if sS.cgrCfg.CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
 		cacheKey := utils.ConcatenatedKey(utils.SessionSv1GetCost, args.CGREvent.ID)
-		refID := guardian.Guardian.GuardIDs("",
-			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		// RPC caching needs to be atomic
+		var refID string
+		if refID, err = guardian.Guardian.GuardIDs("",
+			sS.cgrCfg.GeneralCfg().LockingTimeout, cacheKey); err != nil {
+			return
+		}
 		defer guardian.Guardian.UnguardIDs(refID)
 
 		if itm, has := engine.Cache.Get(utils.CacheRPCResponses, cacheKey); has {
@@ -4189,6 +4296,97 @@ func (sS *SessionS) BiRPCv1ActivateSessions(ctx *context.Context,
 	return
 }
 
+// ArgsHandoffSessions used to hand over the active sessions to another SessionS
+type ArgsHandoffSessions struct {
+	IDs     []string // CGRIDs of the sessions, all active ones if empty
+	ConnIDs []string // connections towards the SessionS taking over
+	Drain   bool     // refuse new sessions from now on, ie: before shutting down the engine
+}
+
+// BiRPCv1HandoffSessions hands over the active sessions to the SessionS behind ConnIDs. Each session
+// has its debits stopped, is replicated as passive and activated on the peer, which resumes the
+// debit loops at the NextAutoDebit time. Sessions failing the handoff continue to run locally,
+// the draining being cancelled so the new sessions are accepted again until the next handoff.
+// returns utils.ErrPartiallyExecuted in case of errors
+func (sS *SessionS) BiRPCv1HandoffSessions(ctx *context.Context,
+	args *ArgsHandoffSessions, reply *string) (err error) {
+	if len(args.ConnIDs) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.ConnIDs)
+	}
+	if args.Drain {
+		sS.draining.Store(true)
+		defer func() {
+			if err != nil { // sessions still running here, keep serving them
+				sS.draining.Store(false)
+			}
+		}()
+	}
+	cgrIDs := args.IDs
+	if len(cgrIDs) == 0 {
+		sS.aSsMux.RLock()
+		cgrIDs = make([]string, 0, len(sS.aSessions))
+		for cgrID := range sS.aSessions {
+			cgrIDs = append(cgrIDs, cgrID)
+		}
+		sS.aSsMux.RUnlock()
+	}
+	for _, cgrID := range cgrIDs {
+		if hErr := sS.handoffSession(cgrID, args.ConnIDs); hErr != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> could not hand over session with id: <%s>, err: <%s>",
+					utils.SessionS, cgrID, hErr.Error()))
+			err = utils.ErrPartiallyExecuted
+		}
+	}
+	if err == nil {
+		*reply = utils.OK
+	}
+	return
+}
+
+// handoffSession moves one active session to the SessionS behind connIDs
+func (sS *SessionS) handoffSession(cgrID string, connIDs []string) (err error) {
+	ss := sS.getSessions(cgrID, false)
+	if len(ss) == 0 {
+		return utils.ErrNotFound
+	}
+	s := ss[0]
+	s.Lock()
+	defer s.Unlock()
+	if !sS.isIndexed(s, false) { // terminated meanwhile
+		return utils.ErrNotFound
+	}
+	// no debits after this point so the snapshot stays valid on the peer
+	s.stopSTerminator()
+	s.stopDebitLoops()
+	var rply string
+	if err = sS.connMgr.Call(context.TODO(), connIDs, utils.SessionSv1SetPassiveSession,
+		s.clone(), &rply); err == nil {
+		err = sS.connMgr.Call(context.TODO(), connIDs, utils.SessionSv1ActivateSessions,
+			&utils.SessionIDsWithArgsDispatcher{IDs: []string{cgrID}}, &rply)
+	}
+	if err != nil {
+		sS.initSessionDebitLoops(s)
+		sS.setSTerminator(s, nil)
+		return
+	}
+	sS.unregisterSession(cgrID, false)
+	return
+}
+
+// rebindClientConn points the session towards the client connection it was received on in case
+// the original one is not known, ie: the session was handed over from another engine
+func (sS *SessionS) rebindClientConn(s *Session, clntConnID string) {
+	if clntConnID == utils.EmptyString {
+		return
+	}
+	s.Lock()
+	if sS.biJClnt(s.ClientConnID) == nil {
+		s.ClientConnID = clntConnID
+	}
+	s.Unlock()
+}
+
 // BiRPCv1DeactivateSessions is called to deactivate a list/all active sessios
 // returns utils.ErrPartiallyExecuted in case of errors
 func (sS *SessionS) BiRPCv1DeactivateSessions(ctx *context.Context,
@@ -4276,6 +4474,48 @@ func (sS *SessionS) processCDR(cgrEv *utils.CGREvent, flags []string, rply *stri
 
 }
 
+// processFraud scores the event with FraudS, returning the error if the event is blocked.
+// FraudS failures are only logged so the sessions do not depend on it
+func (sS *SessionS) processFraud(cgrEv *utils.CGREvent, method string) (fScore *engine.FraudScore, err error) {
+	if len(sS.cgrCfg.SessionSCfg().FraudSConns) == 0 {
+		return
+	}
+	if fraudS, errOpt := utils.GetBoolOpts(cgrEv, true, utils.OptsFraudS); errOpt != nil || !fraudS {
+		return
+	}
+	fScore = new(engine.FraudScore)
+	if errFraud := sS.connMgr.Call(context.TODO(), sS.cgrCfg.SessionSCfg().FraudSConns,
+		method, cgrEv, fScore); errFraud != nil {
+		if errFraud.Error() != utils.ErrNotFound.Error() {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s scoring event %+v with FraudS.",
+					utils.SessionS, errFraud.Error(), cgrEv))
+		}
+		return nil, nil
+	}
+	if fScore.Action == utils.MetaBlock {
+		err = utils.NewErrFraudS(utils.ErrFraudDetected)
+	}
+	return
+}
+
+// releaseFraud informs FraudS in the background about the end of the session
+func (sS *SessionS) releaseFraud(cgrEv *utils.CGREvent) {
+	if fraudS, err := utils.GetBoolOpts(cgrEv, true, utils.OptsFraudS); err != nil || !fraudS {
+		return
+	}
+	go func() {
+		var reply string
+		if err := sS.connMgr.Call(context.TODO(), sS.cgrCfg.SessionSCfg().FraudSConns,
+			utils.FraudSv1ReleaseEvent, cgrEv, &reply); err != nil &&
+			err.Error() != utils.ErrNotFound.Error() {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s releasing event %+v with FraudS.",
+					utils.SessionS, err.Error(), cgrEv))
+		}
+	}()
+}
+
 // processThreshold will receive the event and send it to ThresholdS to be processed
 func (sS *SessionS) processThreshold(cgrEv *utils.CGREvent, thIDs []string, clnb bool) (tIDs []string, err error) {
 	if len(sS.cgrCfg.SessionSCfg().ThresholdSConns) == 0 {
@@ -4628,6 +4868,12 @@ func (sS *SessionS) RestoreAndBackupSessions(stopChan chan struct{}) error {
 		return err
 	} else {
 		for _, storSess := range storedSessions {
+			if storSess.Version > engine.SessionSnapshotVersion {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> cannot restore session with CGRID <%s>, unsupported backup version: %d",
+						utils.SessionS, storSess.CGRID, storSess.Version))
+				continue
+			}
 			storSess := newSessionFromStoredSession(storSess)
 			restoredSess = append(restoredSess, storSess)
 		}
@@ -4708,9 +4954,9 @@ func (sS *SessionS) storeSessions() (sessStored int, err error) {
 	}
 	var storedSessions []*engine.StoredSession
 	for _, sess := range activeSess {
-		activeSess[0].lk.RLock()
+		sess.lk.RLock()
 		storedSessions = append(storedSessions, sess.asStoredSession())
-		activeSess[0].lk.RUnlock()
+		sess.lk.RUnlock()
 	}
 	if err := sS.dm.SetBackupSessions(sS.cgrCfg.GeneralCfg().NodeID,
 		sS.cgrCfg.GeneralCfg().DefaultTenant, storedSessions); err != nil {
@@ -138,6 +138,38 @@ func TestBiRPCv1RegisterInternalBiJSONConn(t *testing.T) {
 	}
 }
 
+func TestRegisterIntBiJConnReconnect(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	sessions := NewSessionS(cfg, nil, nil)
+
+	oldClnt := &birpc.Service{Name: "old"}
+	sessions.OnBiJSONConnect(oldClnt)
+	sessions.RegisterIntBiJConn(oldClnt, "AGENT1")
+	if len(sessions.biJIDs) != 1 || sessions.biJIDs["AGENT1"].conn != oldClnt {
+		t.Errorf("expected only AGENT1 registered, received %+v", sessions.biJIDs)
+	}
+
+	// the client reconnects before the old connection is detected as lost
+	newClnt := &birpc.Service{Name: "new"}
+	sessions.RegisterIntBiJConn(newClnt, "AGENT1")
+	sessions.OnBiJSONConnect(newClnt) // connect event received after the registration
+	sessions.OnBiJSONDisconnect(oldClnt)
+	if clnt := sessions.biJClnt("AGENT1"); clnt == nil || clnt.conn != newClnt {
+		t.Errorf("expected AGENT1 on the new connection, received %+v", clnt)
+	}
+	if connID := sessions.biJClntID(newClnt); connID != "AGENT1" {
+		t.Errorf("expected AGENT1, received %q", connID)
+	}
+	if len(sessions.biJIDs) != 1 || len(sessions.biJClnts) != 1 {
+		t.Errorf("unexpected clients: %+v, %+v", sessions.biJIDs, sessions.biJClnts)
+	}
+
+	sessions.OnBiJSONDisconnect(newClnt)
+	if len(sessions.biJIDs) != 0 || len(sessions.biJClnts) != 0 {
+		t.Errorf("unexpected clients: %+v, %+v", sessions.biJIDs, sessions.biJClnts)
+	}
+}
+
 func TestSessionSIndexAndUnindexSessions(t *testing.T) {
 	cfg := config.NewDefaultCGRConfig()
 	cfg.SessionSCfg().SessionIndexes = utils.StringSet{
@@ -3286,3 +3318,379 @@ func TestBiRPCv1ProcessEventNoTenant(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+// newHandoffTestSession returns an active *prepaid session with the debits already done until nextDebit
+func newHandoffTestSession(cgrID string, nextDebit time.Time) *Session {
+	return &Session{
+		CGRID:         cgrID,
+		Tenant:        "cgrates.org",
+		ClientConnID:  "AGENT1",
+		EventStart:    engine.MapEvent{utils.OriginID: cgrID, utils.ToR: utils.MetaVoice},
+		DebitInterval: time.Minute,
+		Chargeable:    true,
+		OptsStart:     engine.MapEvent{utils.OptsDebitInterval: "1m"},
+		SRuns: []*SRun{{
+			Event:         engine.MapEvent{utils.RequestType: utils.MetaPrepaid, utils.RunID: utils.MetaDefault},
+			CD:            &engine.CallDescriptor{Category: "call", LoopIndex: 2, DurationIndex: 2 * time.Minute},
+			ExtraDuration: 10 * time.Second,
+			LastUsage:     time.Minute,
+			TotalUsage:    2 * time.Minute,
+			NextAutoDebit: utils.TimePointer(nextDebit),
+		}},
+	}
+}
+
+func TestSessionSHandoffSessions(t *testing.T) {
+	engine.Cache.Clear(nil)
+	peerCfg := config.NewDefaultCGRConfig()
+	peerCfg.SessionSCfg().RALsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs)}
+	debits := make(chan time.Time, 1)
+	ralsChan := make(chan birpc.ClientConnector, 1)
+	ralsChan <- &testMockClients{calls: map[string]func(args any, reply any) error{
+		utils.ResponderMaxDebit: func(args any, reply any) error {
+			select {
+			case debits <- time.Now():
+			default:
+			}
+			return utils.ErrServerError // stops the debit loop
+		},
+	}}
+	peer := NewSessionS(peerCfg, nil, engine.NewConnManager(peerCfg,
+		map[string]chan birpc.ClientConnector{
+			utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs): ralsChan,
+		}))
+
+	var activateErr error
+	peerConnID := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)
+	peerChan := make(chan birpc.ClientConnector, 1)
+	peerChan <- &testMockClients{calls: map[string]func(args any, reply any) error{
+		utils.SessionSv1SetPassiveSession: func(args any, reply any) error {
+			return peer.BiRPCv1SetPassiveSession(context.Background(), args.(*Session), reply.(*string))
+		},
+		utils.SessionSv1ActivateSessions: func(args any, reply any) error {
+			if activateErr != nil {
+				return activateErr
+			}
+			return peer.BiRPCv1ActivateSessions(context.Background(),
+				args.(*utils.SessionIDsWithArgsDispatcher), reply.(*string))
+		},
+	}}
+	cfg := config.NewDefaultCGRConfig()
+	sS := NewSessionS(cfg, nil, engine.NewConnManager(cfg,
+		map[string]chan birpc.ClientConnector{peerConnID: peerChan}))
+
+	var reply string
+	if err := sS.BiRPCv1HandoffSessions(context.Background(), &ArgsHandoffSessions{},
+		&reply); err == nil || err.Error() != utils.NewErrMandatoryIeMissing(utils.ConnIDs).Error() {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// the peer refusing the activation leaves the session running locally
+	sS.registerSession(newHandoffTestSession("CGRID0", time.Now().Add(time.Hour)), false)
+	activateErr = utils.ErrServerError
+	if err := sS.BiRPCv1HandoffSessions(context.Background(), &ArgsHandoffSessions{
+		ConnIDs: []string{peerConnID},
+		Drain:   true,
+	}, &reply); err != utils.ErrPartiallyExecuted {
+		t.Errorf("expected %v, received %v", utils.ErrPartiallyExecuted, err)
+	}
+	if sS.draining.Load() {
+		t.Error("expected the draining to be cancelled after a failed handoff")
+	}
+	if ss := sS.getSessions("CGRID0", false); len(ss) != 1 {
+		t.Fatal("expected the session to be resumed locally")
+	} else {
+		ss[0].Lock()
+		if ss[0].debitStop == nil {
+			t.Error("expected the debit loop to be restarted")
+		}
+		ss[0].stopDebitLoops()
+		ss[0].Unlock()
+	}
+	sS.unregisterSession("CGRID0", false)
+
+	activateErr = nil
+	nextDebit := time.Now().Add(50 * time.Millisecond)
+	sS.registerSession(newHandoffTestSession("CGRID1", nextDebit), false)
+	if err := sS.BiRPCv1HandoffSessions(context.Background(), &ArgsHandoffSessions{
+		IDs:     []string{"CGRID1"},
+		ConnIDs: []string{peerConnID},
+		Drain:   true,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(sS.getSessions(utils.EmptyString, false)) != 0 {
+		t.Error("expected no active sessions after handoff")
+	}
+	ss := peer.getSessions("CGRID1", false)
+	if len(ss) != 1 {
+		t.Fatal("expected the session to be active on the peer")
+	}
+	ss[0].RLock()
+	if exp := newHandoffTestSession("CGRID1", nextDebit); !ss[0].Chargeable ||
+		!reflect.DeepEqual(exp.OptsStart, ss[0].OptsStart) ||
+		ss[0].SRuns[0].TotalUsage != exp.SRuns[0].TotalUsage ||
+		ss[0].SRuns[0].ExtraDuration != exp.SRuns[0].ExtraDuration ||
+		ss[0].SRuns[0].CD.LoopIndex != exp.SRuns[0].CD.LoopIndex {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(ss[0]))
+	}
+	ss[0].RUnlock()
+	select {
+	case dbtTime := <-debits:
+		if dbtTime.Before(nextDebit) {
+			t.Errorf("debit loop resumed at %v, before %v", dbtTime, nextDebit)
+		}
+	case <-time.After(time.Second):
+		t.Error("debit loop not resumed on the peer")
+	}
+
+	if _, err := sS.initSession(&utils.CGREvent{Tenant: "cgrates.org", ID: "TestHandoff",
+		Event: map[string]any{utils.OriginID: "CGRID2"}}, utils.EmptyString, "CGRID2",
+		0, false, false); err != utils.ErrSessionsDraining {
+		t.Errorf("expected %v, received %v", utils.ErrSessionsDraining, err)
+	}
+}
+
+func TestSessionSRebindClientConn(t *testing.T) {
+	sS := NewSessionS(config.NewDefaultCGRConfig(), nil, nil)
+	sS.RegisterIntBiJConn(new(mockConnWarnDisconnect1), "AGENT2")
+	s := &Session{CGRID: "CGRID1", ClientConnID: "AGENT1"}
+	sS.rebindClientConn(s, utils.EmptyString)
+	if s.ClientConnID != "AGENT1" {
+		t.Errorf("expected AGENT1, received %s", s.ClientConnID)
+	}
+	sS.rebindClientConn(s, "AGENT2")
+	if s.ClientConnID != "AGENT2" {
+		t.Errorf("expected AGENT2, received %s", s.ClientConnID)
+	}
+	// the connection towards a known client is kept
+	sS.rebindClientConn(s, "AGENT3")
+	if s.ClientConnID != "AGENT2" {
+		t.Errorf("expected AGENT2, received %s", s.ClientConnID)
+	}
+}
+
+func TestSessionSRestoreSessionsVersion(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data, err := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	sS := NewSessionS(cfg, dm, nil)
+	stored := []*engine.StoredSession{
+		newHandoffTestSession("CGRID1", time.Now()).asStoredSession(),
+		newHandoffTestSession("CGRID2", time.Now()).asStoredSession(),
+		newHandoffTestSession("CGRID3", time.Now()).asStoredSession(),
+	}
+	stored[1].Version = 0 // made before versioning
+	stored[2].Version = engine.SessionSnapshotVersion + 1
+	for _, s := range stored {
+		s.UpdatedAt = time.Now()
+		s.DebitInterval = 0 // no debit loops without RALs
+	}
+	if stored[0].Version != engine.SessionSnapshotVersion {
+		t.Errorf("expected version %d, received %d", engine.SessionSnapshotVersion, stored[0].Version)
+	}
+	if err = dm.SetBackupSessions(cfg.GeneralCfg().NodeID, cfg.GeneralCfg().DefaultTenant, stored); err != nil {
+		t.Fatal(err)
+	}
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+	if err = sS.RestoreAndBackupSessions(stopChan); err != nil {
+		t.Fatal(err)
+	}
+	var cgrIDs []string
+	for _, s := range sS.getSessions(utils.EmptyString, false) {
+		cgrIDs = append(cgrIDs, s.CGRID)
+	}
+	sort.Strings(cgrIDs)
+	if exp := []string{"CGRID1", "CGRID2"}; !reflect.DeepEqual(exp, cgrIDs) {
+		t.Errorf("expected %v, received %v", exp, cgrIDs)
+	}
+}
+
+func TestSessionSProcessFraud(t *testing.T) {
+	engine.Cache.Clear(nil)
+	relCh := make(chan *utils.CGREvent, 1)
+	testMock := &testMockClients{
+		calls: map[string]func(args any, reply any) error{
+			utils.FraudSv1ScoreEvent: func(args any, reply any) error {
+				switch args.(*utils.CGREvent).Event[utils.AccountField] {
+				case "1001":
+					*reply.(*engine.FraudScore) = engine.FraudScore{Account: "1001", Score: 100, Action: utils.MetaBlock}
+				case "1002":
+					*reply.(*engine.FraudScore) = engine.FraudScore{Account: "1002", Score: 50, Action: utils.MetaFlag}
+				case "1003":
+					return utils.ErrNotFound
+				default:
+					return utils.ErrDisconnected
+				}
+				return nil
+			},
+			utils.FraudSv1ReleaseEvent: func(args any, reply any) error {
+				relCh <- args.(*utils.CGREvent)
+				*reply.(*string) = utils.OK
+				return nil
+			},
+		},
+	}
+	fraudConn := make(chan birpc.ClientConnector, 1)
+	fraudConn <- testMock
+	cfg := config.NewDefaultCGRConfig()
+	sS := NewSessionS(cfg, nil, engine.NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFraud): fraudConn,
+	}))
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "TestSessionSProcessFraud",
+		Event:  map[string]any{utils.AccountField: "1001"},
+	}
+
+	// not scored without connections
+	if fScore, err := sS.processFraud(ev, utils.FraudSv1ScoreEvent); err != nil || fScore != nil {
+		t.Errorf("expected no score, received %s, %v", utils.ToJSON(fScore), err)
+	}
+	cfg.SessionSCfg().FraudSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFraud)}
+	expErr := utils.NewErrFraudS(utils.ErrFraudDetected)
+	if fScore, err := sS.processFraud(ev, utils.FraudSv1ScoreEvent); err == nil || err.Error() != expErr.Error() {
+		t.Errorf("expected %v, received %v", expErr, err)
+	} else if fScore == nil || fScore.Action != utils.MetaBlock {
+		t.Errorf("unexpected score: %s", utils.ToJSON(fScore))
+	}
+	ev.Event[utils.AccountField] = "1002"
+	if fScore, err := sS.processFraud(ev, utils.FraudSv1ScoreEvent); err != nil {
+		t.Error(err)
+	} else if exp := (&engine.FraudScore{Account: "1002", Score: 50, Action: utils.MetaFlag}); !reflect.DeepEqual(exp, fScore) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(fScore))
+	}
+	// FraudS failures do not affect the session
+	for _, acnt := range []string{"1003", "1004"} {
+		ev.Event[utils.AccountField] = acnt
+		if fScore, err := sS.processFraud(ev, utils.FraudSv1ScoreEvent); err != nil || fScore != nil {
+			t.Errorf("expected no score, received %s, %v", utils.ToJSON(fScore), err)
+		}
+	}
+	// disabled from the options
+	ev.Event[utils.AccountField] = "1001"
+	ev.APIOpts = map[string]any{utils.OptsFraudS: false}
+	if fScore, err := sS.processFraud(ev, utils.FraudSv1ScoreEvent); err != nil || fScore != nil {
+		t.Errorf("expected no score, received %s, %v", utils.ToJSON(fScore), err)
+	}
+
+	// the default run cost is released at the end of the session
+	s := &Session{
+		CGRID:      "CGRID",
+		Tenant:     "cgrates.org",
+		EventStart: engine.MapEvent{utils.AccountField: "1001", utils.OriginID: "o1"},
+		SRuns: []*SRun{
+			{Event: engine.MapEvent{utils.RunID: "run1"}, TotalUsage: time.Minute},
+			{Event: engine.MapEvent{utils.RunID: utils.MetaDefault, utils.Cost: 1.5}, TotalUsage: time.Minute},
+		},
+	}
+	if err := sS.endSession(s, nil, nil, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case relEv := <-relCh:
+		exp := map[string]any{utils.AccountField: "1001", utils.OriginID: "o1", utils.Usage: time.Minute, utils.Cost: 1.5}
+		if !reflect.DeepEqual(exp, relEv.Event) {
+			t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(relEv.Event))
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the session to be released within FraudS")
+	}
+}
+
+func TestSessionSInitiateSessionReleaseFraud(t *testing.T) {
+	engine.Cache.Clear(nil)
+	relCh := make(chan *utils.CGREvent, 1)
+	testMock := &testMockClients{
+		calls: map[string]func(args any, reply any) error{
+			utils.FraudSv1ProcessEvent: func(args any, reply any) error {
+				switch args.(*utils.CGREvent).Event[utils.AccountField] {
+				case "1001":
+					*reply.(*engine.FraudScore) = engine.FraudScore{Account: "1001", Score: 100, Action: utils.MetaBlock}
+				default:
+					*reply.(*engine.FraudScore) = engine.FraudScore{Account: "1002"}
+				}
+				return nil
+			},
+			utils.FraudSv1ReleaseEvent: func(args any, reply any) error {
+				relCh <- args.(*utils.CGREvent)
+				*reply.(*string) = utils.OK
+				return nil
+			},
+		},
+	}
+	fraudConn := make(chan birpc.ClientConnector, 1)
+	fraudConn <- testMock
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().FraudSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFraud)}
+	sS := NewSessionS(cfg, nil, engine.NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFraud): fraudConn,
+	}))
+	args := &V1InitSessionArgs{
+		InitSession:       true,
+		AllocateResources: true,
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestSessionSInitiateSessionReleaseFraud",
+			Event: map[string]any{
+				utils.AccountField: "1002",
+				utils.OriginID:     "o1",
+			},
+		},
+	}
+	// the session is not initiated since ResourceS is not connected so FraudS needs to release it
+	var rply V1InitSessionReply
+	expErr := utils.NewErrNotConnected(utils.ResourceS)
+	if err := sS.BiRPCv1InitiateSession(context.Background(), args, &rply); err == nil || err.Error() != expErr.Error() {
+		t.Errorf("expected %v, received %v", expErr, err)
+	}
+	select {
+	case relEv := <-relCh:
+		if relEv.Event[utils.OriginID] != "o1" {
+			t.Errorf("unexpected released event: %s", utils.ToJSON(relEv))
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the session to be released within FraudS")
+	}
+
+	// the blocked events are not recorded so they are not released
+	args.CGREvent.Event[utils.AccountField] = "1001"
+	rply = V1InitSessionReply{}
+	expErr = utils.NewErrFraudS(utils.ErrFraudDetected)
+	if err := sS.BiRPCv1InitiateSession(context.Background(), args, &rply); err == nil || err.Error() != expErr.Error() {
+		t.Errorf("expected %v, received %v", expErr, err)
+	}
+	select {
+	case relEv := <-relCh:
+		t.Errorf("unexpected release: %s", utils.ToJSON(relEv))
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestV1AuthorizeReplyAsNavigableMapFraudScore(t *testing.T) {
+	rply := &V1AuthorizeReply{
+		FraudScore: &engine.FraudScore{
+			ProfileID:  "FRD_DEFAULT",
+			Score:      50,
+			Action:     utils.MetaFlag,
+			Indicators: []*engine.FraudIndicator{{Type: utils.MetaConcurrentCalls}},
+		},
+	}
+	exp := map[string]*utils.DataNode{
+		utils.CapFraudScore: {Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+			utils.FraudProfileID: utils.NewLeafNode("FRD_DEFAULT"),
+			utils.FraudScore:     utils.NewLeafNode(50.),
+			utils.FraudAction:    utils.NewLeafNode(utils.MetaFlag),
+			utils.FraudIndicators: {Type: utils.NMSliceType, Slice: []*utils.DataNode{
+				utils.NewLeafNode(utils.MetaConcurrentCalls),
+			}},
+		}},
+	}
+	if rcv := rply.AsNavigableMap(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
@@ -255,6 +255,7 @@ type DestinationRate struct {
 	RoundingDecimals int
 	MaxCost          float64
 	MaxCostStrategy  string
+	UsageCounter     string // the account usage counter selecting the rate tier
 }
 
 // Clone method for DestinationRate
@@ -269,6 +270,7 @@ func (dr *DestinationRate) Clone() *DestinationRate {
 		RoundingDecimals: dr.RoundingDecimals,
 		MaxCost:          dr.MaxCost,
 		MaxCostStrategy:  dr.MaxCostStrategy,
+		UsageCounter:     dr.UsageCounter,
 	}
 	if dr.Rate != nil {
 		clone.Rate = dr.Rate.Clone()
@@ -1696,6 +1698,64 @@ func (trp *TPRankingProfile) CacheClone() any {
 	return trp.Clone()
 }
 
+// TPTaxProfile is used in APIs to manage remotely offline TaxProfile
+type TPTaxProfile struct {
+	TPid         string
+	Tenant       string
+	ID           string
+	FilterIDs    []string
+	Jurisdiction string
+	Weight       float64
+	Rates        []*TPTaxRate
+}
+
+// TPTaxRate is one of the rates within a TPTaxProfile
+type TPTaxRate struct {
+	ID                 string
+	Type               string
+	Rate               float64
+	Amount             float64
+	Compound           bool
+	ActivationInterval *TPActivationInterval
+}
+
+// Clone method for TPTaxProfile
+func (ttp *TPTaxProfile) Clone() *TPTaxProfile {
+	if ttp == nil {
+		return nil
+	}
+	clone := &TPTaxProfile{
+		TPid:         ttp.TPid,
+		Tenant:       ttp.Tenant,
+		ID:           ttp.ID,
+		Jurisdiction: ttp.Jurisdiction,
+		Weight:       ttp.Weight,
+	}
+	if ttp.FilterIDs != nil {
+		clone.FilterIDs = make([]string, len(ttp.FilterIDs))
+		copy(clone.FilterIDs, ttp.FilterIDs)
+	}
+	if ttp.Rates != nil {
+		clone.Rates = make([]*TPTaxRate, len(ttp.Rates))
+		for i, rate := range ttp.Rates {
+			clone.Rates[i] = &TPTaxRate{
+				ID:                 rate.ID,
+				Type:               rate.Type,
+				Rate:               rate.Rate,
+				Amount:             rate.Amount,
+				Compound:           rate.Compound,
+				ActivationInterval: rate.ActivationInterval.Clone(),
+			}
+		}
+	}
+	return clone
+}
+
+// CacheClone returns a clone of TPTaxProfile used by ltcache CacheCloner
+func (ttp *TPTaxProfile) CacheClone() any {
+	return ttp.Clone()
+}
+
 // MetricWithSettings adds specific settings to the Metric
 type MetricWithSettings struct {
 	MetricID         string
@@ -2234,6 +2294,65 @@ type AttrRemoteLock struct {
 	Timeout     time.Duration // Automatically unlock on timeout
 }
 
+// ArgsDistributedLock is used to manage the distributed locks remotely, through ReplicatorSv1
+type ArgsDistributedLock struct {
+	LockID  string
+	Token   int64         // lease token received when the lock was acquired
+	TTL     time.Duration // lease duration
+	APIOpts map[string]any
+}
+
+// ArgsAuthenticate carries the API key or JWT authenticating the connection
+type ArgsAuthenticate struct {
+	Token string
+}
+
+// ArgsGenerateInvoice selects the account and the billing period of a new invoice.
+// Without PeriodStart and PeriodEnd the previous calendar month is invoiced
+type ArgsGenerateInvoice struct {
+	Tenant      string
+	Account     string
+	PeriodStart string
+	PeriodEnd   string
+	CDRsFilter  *RPCCDRsFilter // extra filters for the invoiced CDRs
+	APIOpts     map[string]any
+}
+
+// ArgsGetInvoices lists the invoices of a tenant, optionally only of one account
+type ArgsGetInvoices struct {
+	Tenant  string
+	Account string
+	APIOpts map[string]any
+}
+
+// ArgsRenderInvoice renders an issued invoice in one of the *json, *csv or *html formats
+type ArgsRenderInvoice struct {
+	*TenantID
+	Format  string
+	APIOpts map[string]any
+}
+
+// ArgsReserveBalance holds an amount on the matching balances of an account
+type ArgsReserveBalance struct {
+	Tenant        string
+	Account       string
+	ReservationID string // generated if missing
+	BalanceType   string
+	Amount        float64
+	Balance       map[string]any // filter for the balances holding the amount
+	ExpiryTime    string         // absolute or relative(ie: +1h) time when the reservation is released
+	APIOpts       map[string]any
+}
+
+// ArgsReservation identifies a reservation to commit or release
+type ArgsReservation struct {
+	Tenant        string
+	Account       string
+	ReservationID string
+	Amount        *float64 // committed amount, the entire reservation if missing
+	APIOpts       map[string]any
+}
+
 type SMCostFilter struct { //id cu litere mare
 	CGRIDs         []string
 	NotCGRIDs      []string
@@ -2389,6 +2508,8 @@ func NewAttrReloadCacheWithOpts() *AttrReloadCacheWithAPIOpts {
 		RankingProfileIDs:        []string{MetaAny},
 		TrendIDs:                 []string{MetaAny},
 		TrendProfileIDs:          []string{MetaAny},
+		DataSetIDs:               []string{MetaAny},
+		TaxProfileIDs:            []string{MetaAny},
 		ThresholdIDs:             []string{MetaAny},
 		ThresholdProfileIDs:      []string{MetaAny},
 		FilterIDs:                []string{MetaAny},
@@ -2406,6 +2527,7 @@ func NewAttrReloadCacheWithOpts() *AttrReloadCacheWithAPIOpts {
 		RouteFilterIndexIDs:      []string{MetaAny},
 		ChargerFilterIndexIDs:    []string{MetaAny},
 		DispatcherFilterIndexIDs: []string{MetaAny},
+		TaxProfileFilterIndexIDs: []string{MetaAny},
 		FilterIndexIDs:           []string{MetaAny},
 		Dispatchers:              []string{MetaAny},
 	}
@@ -2437,6 +2559,8 @@ func NewAttrReloadCacheWithOptsFromMap(arg map[string][]string, tnt string, opts
 		ThresholdProfileIDs:      arg[CacheThresholdProfiles],
 		TrendIDs:                 arg[CacheTrends],
 		TrendProfileIDs:          arg[CacheTrendProfiles],
+		DataSetIDs:               arg[CacheDataSets],
+		TaxProfileIDs:            arg[CacheTaxProfiles],
 		FilterIDs:                arg[CacheFilters],
 		RouteProfileIDs:          arg[CacheRouteProfiles],
 		AttributeProfileIDs:      arg[CacheAttributeProfiles],
@@ -2453,6 +2577,7 @@ func NewAttrReloadCacheWithOptsFromMap(arg map[string][]string, tnt string, opts
 		RouteFilterIndexIDs:      arg[CacheRouteFilterIndexes],
 		ChargerFilterIndexIDs:    arg[CacheChargerFilterIndexes],
 		DispatcherFilterIndexIDs: arg[CacheDispatcherFilterIndexes],
+		TaxProfileFilterIndexIDs: arg[CacheTaxProfileFilterIndexes],
 		FilterIndexIDs:           arg[CacheReverseFilterIndexes],
 	}
 }
@@ -2479,6 +2604,8 @@ type AttrReloadCacheWithAPIOpts struct {
 	RankingProfileIDs        []string       `json:",omitempty"`
 	TrendIDs                 []string       `json:",omitempty"`
 	TrendProfileIDs          []string       `json:",omitempty"`
+	DataSetIDs               []string       `json:",omitempty"`
+	TaxProfileIDs            []string       `json:",omitempty"`
 	ThresholdIDs             []string       `json:",omitempty"`
 	ThresholdProfileIDs      []string       `json:",omitempty"`
 	FilterIDs                []string       `json:",omitempty"`
@@ -2497,6 +2624,7 @@ type AttrReloadCacheWithAPIOpts struct {
 	RouteFilterIndexIDs      []string       `json:",omitempty"`
 	ChargerFilterIndexIDs    []string       `json:",omitempty"`
 	DispatcherFilterIndexIDs []string       `json:",omitempty"`
+	TaxProfileFilterIndexIDs []string       `json:",omitempty"`
 	FilterIndexIDs           []string       `json:",omitempty"`
 }
 
@@ -2523,6 +2651,8 @@ func (a *AttrReloadCacheWithAPIOpts) Map() map[string][]string {
 		CacheRankingProfiles:         a.RankingProfileIDs,
 		CacheTrends:                  a.TrendIDs,
 		CacheTrendProfiles:           a.TrendProfileIDs,
+		CacheDataSets:                a.DataSetIDs,
+		CacheTaxProfiles:             a.TaxProfileIDs,
 		CacheFilters:                 a.FilterIDs,
 		CacheRouteProfiles:           a.RouteProfileIDs,
 		CacheAttributeProfiles:       a.AttributeProfileIDs,
@@ -2539,6 +2669,7 @@ func (a *AttrReloadCacheWithAPIOpts) Map() map[string][]string {
 		CacheRouteFilterIndexes:      a.RouteFilterIndexIDs,
 		CacheChargerFilterIndexes:    a.ChargerFilterIndexIDs,
 		CacheDispatcherFilterIndexes: a.DispatcherFilterIndexIDs,
+		CacheTaxProfileFilterIndexes: a.TaxProfileFilterIndexIDs,
 		CacheReverseFilterIndexes:    a.FilterIndexIDs,
 	}
 }
@@ -2710,3 +2841,17 @@ type ScheduledRanking struct {
 	Next      time.Time
 	Previous  time.Time
 }
+
+// ArgsGetAuditRecords selects the profile out of the audit trail
+type ArgsGetAuditRecords struct {
+	ObjectType string // <*attribute_profiles|*filters|*route_profiles|*rating_profiles>
+	Tenant     string
+	ID         string // the full key for the rating profiles, ie. *out:cgrates.org:call:1001
+	APIOpts    map[string]any
+}
+
+// ArgsRestoreAuditVersion restores the profile to the version active at Time
+type ArgsRestoreAuditVersion struct {
+	ArgsGetAuditRecords
+	Time string
+}
@@ -1001,9 +1001,12 @@ func TestNewAttrReloadCacheWithOpts(t *testing.T) {
 		RouteFilterIndexIDs:      []string{MetaAny},
 		ChargerFilterIndexIDs:    []string{MetaAny},
 		DispatcherFilterIndexIDs: []string{MetaAny},
+		TaxProfileFilterIndexIDs: []string{MetaAny},
 		FilterIndexIDs:           []string{MetaAny},
 		RankingIDs:               []string{MetaAny},
 		RankingProfileIDs:        []string{MetaAny},
+		DataSetIDs:               []string{MetaAny},
+		TaxProfileIDs:            []string{MetaAny},
 	}
 	eMap := NewAttrReloadCacheWithOpts()
 	if !reflect.DeepEqual(eMap, newAttrReloadCache) {
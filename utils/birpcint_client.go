@@ -19,10 +19,17 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package utils
 
 import (
+	stdctx "context"
+	"fmt"
 	"net"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
 	"github.com/cgrates/birpc/jsonrpc"
+	"nhooyr.io/websocket"
 )
 
 // NewBiJSONrpcClient will create a bidirectional JSON client connection
@@ -37,3 +44,159 @@ func NewBiJSONrpcClient(addr string, obj birpc.ClientConnector) (*birpc.BirpcCli
 	}
 	return clnt, nil
 }
+
+// NewBiJSONrpcClientWS will create a bidirectional JSON client connection over WebSocket.
+// The header is sent with the handshake request (eg: the API key), a
+// pingInterval bigger than 0 enables the keepalive pings and readLimit
+// bounds the size in bytes of the messages received
+func NewBiJSONrpcClientWS(url string, header http.Header, pingInterval time.Duration,
+	readLimit int64, obj birpc.ClientConnector) (*birpc.BirpcClient, error) {
+	ws, _, err := websocket.Dial(stdctx.Background(), url, &websocket.DialOptions{HTTPHeader: header})
+	if err != nil {
+		return nil, err
+	}
+	ws.SetReadLimit(readLimit)
+	ctx, cancel := stdctx.WithCancel(stdctx.Background())
+	clnt := birpc.NewBirpcClientWithCodec(jsonrpc.NewJSONBirpcCodec(
+		websocket.NetConn(ctx, ws, websocket.MessageText)))
+	if obj != nil {
+		clnt.Register(obj)
+	}
+	go func() {
+		<-clnt.DisconnectNotify()
+		cancel()
+	}()
+	if pingInterval > 0 {
+		go PingWebSocket(ctx, ws, pingInterval)
+	}
+	return clnt, nil
+}
+
+// PingWebSocket pings the WebSocket connection until the context is canceled,
+// closing the connection when the other side stops answering
+func PingWebSocket(ctx stdctx.Context, ws *websocket.Conn, interval time.Duration) {
+	tkr := time.NewTicker(interval)
+	defer tkr.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tkr.C:
+		}
+		pingCtx, cancel := stdctx.WithTimeout(ctx, interval)
+		err := ws.Ping(pingCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() == nil {
+				Logger.Warning(fmt.Sprintf("<BiRPC> closing the WebSocket connection after failed ping: %v", err))
+			}
+			ws.CloseNow()
+			return
+		}
+	}
+}
+
+// NewBiJSONrpcClientWSReconnect creates a bidirectional JSON client over WebSocket which
+// registers to SessionS under connID. When the connection is lost it will redial the
+// server, register again under the same connID and sync the sessions
+func NewBiJSONrpcClientWSReconnect(url, connID string, header http.Header,
+	pingInterval, reconnectInterval time.Duration, reconnects int, readLimit int64,
+	obj birpc.ClientConnector) (c *BiJSONrpcClientWS, err error) {
+	c = &BiJSONrpcClientWS{
+		url:               url,
+		connID:            connID,
+		header:            header,
+		pingInterval:      pingInterval,
+		reconnectInterval: reconnectInterval,
+		reconnects:        reconnects,
+		readLimit:         readLimit,
+		obj:               obj,
+		stop:              make(chan struct{}),
+	}
+	if err = c.connect(false); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// BiJSONrpcClientWS is a bidirectional JSON client over WebSocket
+// that keeps its SessionS registration across reconnects
+type BiJSONrpcClientWS struct {
+	url               string
+	connID            string
+	header            http.Header
+	pingInterval      time.Duration
+	reconnectInterval time.Duration
+	reconnects        int // -1 for unlimited
+	readLimit         int64
+	obj               birpc.ClientConnector
+
+	sync.RWMutex
+	clnt     *birpc.BirpcClient
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// connect dials the server and registers the connection on it
+func (c *BiJSONrpcClientWS) connect(reconnect bool) (err error) {
+	var clnt *birpc.BirpcClient
+	if clnt, err = NewBiJSONrpcClientWS(c.url, c.header, c.pingInterval, c.readLimit, c.obj); err != nil {
+		return
+	}
+	var reply string
+	if err = clnt.Call(context.Background(), SessionSv1RegisterInternalBiJSONConn,
+		c.connID, &reply); err != nil {
+		clnt.Close()
+		return
+	}
+	if reconnect { // terminate the sessions ended while we were offline
+		if err := clnt.Call(context.Background(), SessionSv1SyncSessions,
+			&TenantWithAPIOpts{}, &reply); err != nil {
+			Logger.Warning(fmt.Sprintf("<BiRPC> failed syncing the sessions after reconnect: %v", err))
+		}
+	}
+	c.Lock()
+	c.clnt = clnt
+	c.Unlock()
+	go c.reconnectOnDisconnect(clnt)
+	return
+}
+
+// reconnectOnDisconnect waits for the connection to be lost and dials again
+func (c *BiJSONrpcClientWS) reconnectOnDisconnect(clnt *birpc.BirpcClient) {
+	select {
+	case <-c.stop:
+		return
+	case <-clnt.DisconnectNotify():
+	}
+	for i := 0; c.reconnects == -1 || i < c.reconnects; i++ {
+		select {
+		case <-c.stop:
+			return
+		case <-time.After(c.reconnectInterval):
+		}
+		err := c.connect(true)
+		if err == nil {
+			return
+		}
+		Logger.Warning(fmt.Sprintf("<BiRPC> failed reconnecting to <%s>: %v", c.url, err))
+	}
+	Logger.Err(fmt.Sprintf("<BiRPC> giving up reconnecting to <%s>", c.url))
+}
+
+// Call sends the request over the current connection
+func (c *BiJSONrpcClientWS) Call(ctx *context.Context, serviceMethod string, args, reply any) error {
+	c.RLock()
+	clnt := c.clnt
+	c.RUnlock()
+	return clnt.Call(ctx, serviceMethod, args, reply)
+}
+
+// Close stops the reconnects and closes the current connection
+func (c *BiJSONrpcClientWS) Close() (err error) {
+	c.stopOnce.Do(func() { close(c.stop) })
+	c.RLock()
+	clnt := c.clnt
+	c.RUnlock()
+	return clnt.Close()
+}
@@ -45,7 +45,8 @@ var (
 		CacheRouteFilterIndexes, CacheAttributeFilterIndexes,
 		CacheChargerFilterIndexes, CacheDispatcherFilterIndexes, CacheLoadIDs,
 		CacheReverseFilterIndexes, CacheActionPlans, CacheAccountActionPlans,
-		CacheAccounts, CacheVersions,
+		CacheAccounts, CacheVersions, CacheDataSets, CacheTaxProfiles,
+		CacheTaxProfileFilterIndexes,
 	})
 
 	DataDBPartitions = NewStringSet([]string{
@@ -61,6 +62,7 @@ var (
 		CacheAttributeFilterIndexes, CacheChargerFilterIndexes,
 		CacheDispatcherFilterIndexes, CacheLoadIDs, CacheReverseFilterIndexes,
 		CacheActionPlans, CacheAccountActionPlans, CacheAccounts, CacheVersions,
+		CacheDataSets, CacheTaxProfiles, CacheTaxProfileFilterIndexes,
 	})
 
 	StorDBPartitions = NewStringSet([]string{
@@ -71,7 +73,7 @@ var (
 		CacheTBLTPIPs, CacheTBLTPStats, CacheTBLTPThresholds, CacheTBLTPRankings,
 		CacheTBLTPFilters, CacheSessionCostsTBL, CacheCDRsTBL, CacheTBLTPRoutes,
 		CacheTBLTPAttributes, CacheTBLTPChargers, CacheTBLTPDispatchers,
-		CacheTBLTPDispatcherHosts, CacheVersions,
+		CacheTBLTPDispatcherHosts, CacheTBLTPTaxProfiles, CacheVersions,
 	})
 
 	// CachePartitions enables creation of cache partitions
@@ -106,6 +108,8 @@ var (
 		CacheChargerProfiles:         ChargerProfilePrefix,
 		CacheDispatcherProfiles:      DispatcherProfilePrefix,
 		CacheDispatcherHosts:         DispatcherHostPrefix,
+		CacheDataSets:                DataSetPrefix,
+		CacheTaxProfiles:             TaxProfilePrefix,
 		CacheResourceFilterIndexes:   ResourceFilterIndexes,
 		CacheIPFilterIndexes:         IPFilterIndexes,
 		CacheStatFilterIndexes:       StatFilterIndexes,
@@ -114,6 +118,7 @@ var (
 		CacheAttributeFilterIndexes:  AttributeFilterIndexes,
 		CacheChargerFilterIndexes:    ChargerFilterIndexes,
 		CacheDispatcherFilterIndexes: DispatcherFilterIndexes,
+		CacheTaxProfileFilterIndexes: TaxProfileFilterIndexes,
 
 		CacheLoadIDs:              LoadIDPrefix,
 		CacheAccounts:             AccountPrefix,
@@ -132,6 +137,7 @@ var (
 		CacheAttributeFilterIndexes:  AttributeProfilePrefix,
 		CacheChargerFilterIndexes:    ChargerProfilePrefix,
 		CacheDispatcherFilterIndexes: DispatcherProfilePrefix,
+		CacheTaxProfileFilterIndexes: TaxProfilePrefix,
 		CacheReverseFilterIndexes:    FilterPrefix,
 	}
 
@@ -144,6 +150,7 @@ var (
 		CacheAttributeProfiles:  CacheAttributeFilterIndexes,
 		CacheChargerProfiles:    CacheChargerFilterIndexes,
 		CacheDispatcherProfiles: CacheDispatcherFilterIndexes,
+		CacheTaxProfiles:        CacheTaxProfileFilterIndexes,
 		CacheFilters:            CacheReverseFilterIndexes,
 	}
 
@@ -179,6 +186,7 @@ var (
 		TBLTPChargers:         CacheTBLTPChargers,
 		TBLTPDispatchers:      CacheTBLTPDispatchers,
 		TBLTPDispatcherHosts:  CacheTBLTPDispatcherHosts,
+		TBLTPTaxProfiles:      CacheTBLTPTaxProfiles,
 	}
 
 	// ProtectedSFlds are the fields that sessions should not alter
@@ -192,6 +200,7 @@ const (
 	CGRateSLwr               = "cgrates"
 	Postgres                 = "postgres"
 	MySQL                    = "mysql"
+	SQLite                   = "sqlite"
 	Mongo                    = "mongo"
 	Redis                    = "redis"
 	Internal                 = "internal"
@@ -205,6 +214,10 @@ const (
 	MetaPseudoPrepaid        = "*pseudoprepaid"
 	MetaRated                = "*rated"
 	MetaNone                 = "*none"
+	MetaRunning              = "*running"
+	MetaDone                 = "*done"
+	MetaFailed               = "*failed"
+	MetaCanceled             = "*canceled"
 	MetaNow                  = "*now"
 	MetaRoundingUp           = "*up"
 	MetaRoundingMiddle       = "*middle"
@@ -266,6 +279,9 @@ const (
 
 	CustomValue             = "CustomValue"
 	Value                   = "Value"
+	Prefix                  = "Prefix"
+	Metadata                = "Metadata"
+	Entries                 = "Entries"
 	Filter                  = "Filter"
 	LastUsed                = "LastUsed"
 	PDD                     = "PDD"
@@ -275,6 +291,8 @@ const (
 	MetaReqRunID            = "*req.RunID"
 	Cost                    = "Cost"
 	CostDetails             = "CostDetails"
+	SimulatedCost           = "SimulatedCost"
+	CostDelta               = "CostDelta"
 	EventCost               = "EventCost"
 	EeIDs                   = "EeIDs"
 	Rated                   = "rated"
@@ -287,6 +305,7 @@ const (
 	MetaMongo               = "*mongo"
 	MetaRedis               = "*redis"
 	MetaPostgres            = "*postgres"
+	MetaSQLite              = "*sqlite"
 	MetaInternal            = "*internal"
 	MetaLocalHost           = "*localhost"
 	MetaBiJSONLocalHost     = "*bijson_localhost"
@@ -345,8 +364,14 @@ const (
 	StatQueuePrefix           = "stq_"
 	RankingsProfilePrefix     = "rgp_"
 	TrendsProfilePrefix       = "trp_"
+	DataSetPrefix             = "dts_"
+	TaxProfilePrefix          = "txp_"
 	LoadIDPrefix              = "lid_"
 	SessionsBackupPrefix      = "sbk_"
+	InvoicePrefix             = "inv_"
+	AuditRecordPrefix         = "adt_"
+	GuardianLockPrefix        = "glk_"
+	GuardianFenceKey          = "gfc_tokens"
 	LoadInstKey               = "load_history"
 	CreateCDRsTablesSQL       = "create_cdrs_tables.sql"
 	CreateTariffPlanTablesSQL = "create_tariffplan_tables.sql"
@@ -366,6 +391,9 @@ const (
 	MetaSQSjsonMap            = "*sqs_json_map"
 	MetaKafkajsonMap          = "*kafka_json_map"
 	MetaNatsjsonMap           = "*nats_json_map"
+	MetaMQTTjsonMap           = "*mqtt_json_map"
+	MetaRedisStreamjsonMap    = "*redis_stream_json_map"
+	MetaWebhook               = "*webhook"
 	MetaSQL                   = "*sql"
 	MetaMySQL                 = "*mysql"
 	MetaS3jsonMap             = "*s3_json_map"
@@ -399,6 +427,7 @@ const (
 	MetaVariable             = "*variable"
 	MetaCCUsage              = "*cc_usage"
 	MetaSIPCID               = "*sipcid"
+	MetaDataSet              = "*data_set"
 	MetaValueExponent        = "*value_exponent"
 	NegativePrefix           = "!"
 	MatchStartPrefix         = "^"
@@ -414,6 +443,8 @@ const (
 	XML                      = "xml"
 	MetaGOB                  = "*gob"
 	MetaJSON                 = "*json"
+	MetaCSV                  = "*csv"
+	MetaHTML                 = "*html"
 	MetaMSGPACK              = "*msgpack"
 	MetaDateTime             = "*datetime"
 	MetaMaskedDestination    = "*masked_destination"
@@ -445,6 +476,12 @@ const (
 	MetaStats                = "*stats"
 	MetaTrends               = "*trends"
 	MetaRankings             = "*rankings"
+	MetaInvoices             = "*invoices"
+	MetaAuditRecords         = "*audit_records"
+	MetaTaxes                = "*taxes"
+	MetaFraud                = "*fraud"
+	MetaFlag                 = "*flag"
+	MetaBlock                = "*block"
 	MetaResponder            = "*responder"
 	MetaCore                 = "*core"
 	MetaServiceManager       = "*servicemanager"
@@ -494,6 +531,7 @@ const (
 	CounterType              = "CounterType"
 	Counters                 = "Counters"
 	UnitCounters             = "UnitCounters"
+	UsageCounters            = "UsageCounters"
 	UpdateTime               = "UpdateTime"
 	SharedGroups             = "SharedGroups"
 	Timings                  = "Timings"
@@ -505,6 +543,7 @@ const (
 	Resources                = "Resources"
 	Stats                    = "Stats"
 	Trends                   = "Trends"
+	TaxProfiles              = "TaxProfiles"
 	Filters                  = "Filters"
 	DispatcherProfiles       = "DispatcherProfiles"
 	DispatcherHosts          = "DispatcherHosts"
@@ -568,6 +607,7 @@ const (
 	Units                 = "Units"
 	CDRs                  = "CDRs"
 	ExpiryTime            = "ExpiryTime"
+	ReservationID         = "ReservationID"
 	AllowNegative         = "AllowNegative"
 	Disabled              = "Disabled"
 	Initial               = "Initial"
@@ -598,6 +638,7 @@ const (
 	MetaQOS              = "*qos"
 	MetaReas             = "*reas"
 	MetaReds             = "*reds"
+	MetaScore            = "*score"
 	Weight               = "Weight"
 	Limit                = "Limit"
 	UsageTTL             = "UsageTTL"
@@ -627,6 +668,8 @@ const (
 	ResourceUpdate              = "ResourceUpdate"
 	StatUpdate                  = "StatUpdate"
 	TrendUpdate                 = "TrendUpdate"
+	InvoiceIssued               = "InvoiceIssued"
+	FraudAlert                  = "FraudAlert"
 	EventPerformanceReport      = "PerformanceReport"
 	EventConnectionStatusReport = "ConnectionStatusReport"
 
@@ -637,6 +680,28 @@ const (
 	ConnStatusUp   = "UP"
 	ConnStatusDown = "DOWN"
 
+	// Invoice event fields.
+	InvoiceID          = "InvoiceID"
+	InvoicePeriodStart = "PeriodStart"
+	InvoicePeriodEnd   = "PeriodEnd"
+	InvoiceIssueTime   = "IssueTime"
+	InvoiceSubtotal    = "Subtotal"
+	InvoiceTaxTotal    = "TaxTotal"
+	InvoiceTotal       = "Total"
+	InvoiceFormat      = "Format"
+	InvoiceDocument    = "Document"
+
+	// Fraud alert fields.
+	FraudProfileID  = "FraudProfileID"
+	FraudScore      = "Score"
+	FraudAction     = "Action"
+	FraudIndicators = "Indicators"
+
+	// Tax charge fields.
+	TaxJurisdiction = "Jurisdiction"
+	TaxRateID       = "TaxRateID"
+	TaxType         = "TaxType"
+
 	// ReplyState error constants
 	ErrReplyStateAuthorize = "ERR_AUTHORIZE"
 	ErrReplyStateInitiate  = "ERR_INITIATE"
@@ -686,9 +751,16 @@ const (
 	RateFixedFee             = "RateFixedFee"
 	RateRecurrentFee         = "RateRecurrentFee"
 	RateBlocker              = "RateBlocker"
+	RateType                 = "RateType"
+	RateValue                = "Rate"
+	RateAmount               = "RateAmount"
+	RateCompound             = "RateCompound"
+	RateActivationInterval   = "RateActivationInterval"
 	TimingID                 = "TimingID"
 	RatesID                  = "RatesID"
 	RatingFiltersID          = "RatingFiltersID"
+	UsageCounter             = "UsageCounter"
+	UsageTier                = "UsageTier"
 	AccountingID             = "AccountingID"
 	MetaSessionS             = "*sessions"
 	MetaDefault              = "*default"
@@ -771,6 +843,8 @@ const (
 	MetaRAThresholds         = "*raThresholds"
 	MetaDNSStats             = "*dnsStats"
 	MetaDNSThresholds        = "*dnsThresholds"
+	MetaSMPPStats            = "*smppStats"
+	MetaSMPPThresholds       = "*smppThresholds"
 	MetaHAStats              = "*haStats"
 	MetaHAThresholds         = "*haThresholds"
 	MetaSAStats              = "*saStats"
@@ -837,7 +911,9 @@ const (
 	MetaLoad                = "*load"
 	MetaFloat64             = "*float64"
 	MetaRemove              = "*remove"
+	MetaSet                 = "*set"
 	MetaRemoveAll           = "*removeall"
+	MetaRemoveGroup         = "*remove_group"
 	MetaStore               = "*store"
 	MetaClear               = "*clear"
 	MetaExport              = "*export"
@@ -863,6 +939,8 @@ const (
 	MetaAnalyzer            = "*analyzer"
 	CGREventString          = "CGREvent"
 	MetaTextPlain           = "*text_plain"
+	MetaHTTPStatusCode      = "*httpStatusCode"
+	MetaHTTPContentType     = "*httpContentType"
 	MetaIgnoreErrors        = "*ignore_errors"
 	MetaRelease             = "*release"
 	MetaAllocate            = "*allocate"
@@ -875,6 +953,8 @@ const (
 	EEs                     = "EEs"
 	Ratio                   = "Ratio"
 	Load                    = "Load"
+	Score                   = "Score"
+	ScoreTerms              = "ScoreTerms"
 	Slash                   = "/"
 	UUID                    = "UUID"
 	Uuid                    = "Uuid"
@@ -918,6 +998,8 @@ const (
 	MetaFileName            = "*fileName"
 	MetaFileLineNumber      = "*fileLineNumber"
 	MetaReaderID            = "*readerID"
+	MetaMQTTTopic           = "*mqttTopic"
+	MetaRedisStreamID       = "*redisStreamID"
 	MetaRadauth             = "*radauth"
 	UserPassword            = "UserPassword"
 	RadauthFailed           = "RADAUTH_FAILED"
@@ -979,6 +1061,8 @@ const (
 	SubjectLowerCase        = "subject"
 	RatingProfileID         = "RatingProfileID"
 	Time                    = "Time"
+	ObjectType              = "ObjectType"
+	APIOpts                 = "APIOpts"
 	TargetIDs               = "TargetIDs"
 	TargetType              = "TargetType"
 	MetaRow                 = "*row"
@@ -994,6 +1078,7 @@ const (
 	MetaExporterIDs         = "*exporterIDs"
 	MetaAsync               = "*async"
 	MetaUsage               = "*usage"
+	MetaRecurring           = "*recurring"
 	Weights                 = "Weights"
 	UnitFactors             = "UnitFactors"
 	CostIncrements          = "CostIncrements"
@@ -1079,6 +1164,7 @@ const (
 	FieldGCDurationStats = "gc_duration_stats"
 	FieldProcStats       = "proc_stats"
 	FieldCapsStats       = "caps_stats"
+	FieldLockStats       = "lock_stats"
 
 	MetricRuntimeGoroutines = "goroutines"
 	MetricRuntimeThreads    = "threads"
@@ -1127,6 +1213,11 @@ const (
 
 	MetricCapsAllocated = "caps_allocated"
 	MetricCapsPeak      = "caps_peak"
+
+	MetricLockWait       = "wait"
+	MetricLockHold       = "hold"
+	MetricLockBuckets    = "buckets"
+	MetricLockUpperBound = "upper_bound"
 )
 
 // Migrator Action
@@ -1149,6 +1240,8 @@ const (
 	MetaStatQueueProfiles   = "*statqueue_profiles"
 	MetaStatQueues          = "*statqueues"
 	MetaRankingProfiles     = "*ranking_profiles"
+	MetaDataSets            = "*data_sets"
+	MetaTaxProfiles         = "*tax_profiles"
 	MetaTrendProfiles       = "*trend_profiles"
 	MetaThresholdProfiles   = "*threshold_profiles"
 	MetaRouteProfiles       = "*route_profiles"
@@ -1172,20 +1265,23 @@ const (
 
 // MetaMetrics
 const (
-	MetaASR      = "*asr"
-	MetaACD      = "*acd"
-	MetaTCD      = "*tcd"
-	MetaACC      = "*acc"
-	MetaTCC      = "*tcc"
-	MetaPDD      = "*pdd"
-	MetaDDC      = "*ddc"
-	MetaSum      = "*sum"
-	MetaREPSC    = "*repsc"
-	MetaREPFC    = "*repfc"
-	MetaAverage  = "*average"
-	MetaDistinct = "*distinct"
-	MetaHighest  = "*highest"
-	MetaLowest   = "*lowest"
+	MetaASR        = "*asr"
+	MetaACD        = "*acd"
+	MetaTCD        = "*tcd"
+	MetaACC        = "*acc"
+	MetaTCC        = "*tcc"
+	MetaPDD        = "*pdd"
+	MetaDDC        = "*ddc"
+	MetaSum        = "*sum"
+	MetaREPSC      = "*repsc"
+	MetaREPFC      = "*repfc"
+	MetaAverage    = "*average"
+	MetaDistinct   = "*distinct"
+	MetaHighest    = "*highest"
+	MetaLowest     = "*lowest"
+	MetaPercentile = "*percentile"
+	MetaMedian     = "*median"
+	MetaStdDev     = "*stddev"
 )
 
 // Diameter/Radius request types
@@ -1220,6 +1316,8 @@ const (
 	StatService = "StatS"
 	TrendS      = "TrendS"
 	RankingS    = "RankingS"
+	InvoiceS    = "InvoiceS"
+	FraudS      = "FraudS"
 	ThresholdS  = "ThresholdS"
 	IPs         = "IPs"
 )
@@ -1260,6 +1358,7 @@ const (
 	MetaDebit                     = "*debit"
 	MetaTransferBalance           = "*transfer_balance"
 	MetaResetCounters             = "*reset_counters"
+	MetaResetUsageCounters        = "*reset_usage_counters"
 	MetaEnableAccount             = "*enable_account"
 	MetaDisableAccount            = "*disable_account"
 	HttpPostAsync                 = "*http_post_async"
@@ -1273,6 +1372,7 @@ const (
 	MetaForceDisconnectSessions   = "*force_disconnect_sessions"
 	TopUpZeroNegative             = "*topup_zero_negative"
 	SetExpiry                     = "*set_expiry"
+	MetaGenerateInvoice           = "*generate_invoice"
 	MetaPublishAccount            = "*publish_account"
 	MetaRemoveSessionCosts        = "*remove_session_costs"
 	MetaRemoveExpired             = "*remove_expired"
@@ -1337,6 +1437,7 @@ const (
 	CapAttributes           = "Attributes"
 	CapResourceAllocation   = "ResourceAllocation"
 	CapAllocatedIP          = "AllocatedIP"
+	CapFraudScore           = "FraudScore"
 	CapMaxUsage             = "MaxUsage"
 	CapRoutes               = "Routes"
 	CapRouteProfiles        = "RouteProfiles"
@@ -1363,6 +1464,7 @@ const (
 	TpStats              = "TpStats"
 	TpTrends             = "TpTrends"
 	TpRankings           = "TpRankings"
+	TpTaxProfiles        = "TpTaxProfiles"
 	TpSharedGroups       = "TpSharedGroups"
 	TpRatingProfiles     = "TpRatingProfiles"
 	TpResources          = "TpResources"
@@ -1387,10 +1489,17 @@ const (
 	MetaRoundRobin     = "*round_robin"
 	MetaRatio          = "*ratio"
 	MetaDefaultRatio   = "*default_ratio"
+	MetaLeastConns     = "*least_conns"
+	MetaLeastLatency   = "*least_latency"
+	MetaMaxFailures    = "*max_failures"
+	MetaEjectCooldown  = "*eject_cooldown"
+	MetaLatencyAlpha   = "*latency_alpha"
 	ThresholdSv1       = "ThresholdSv1"
 	StatSv1            = "StatSv1"
 	TrendSv1           = "TrendSv1"
 	RankingSv1         = "RankingSv1"
+	InvoiceSv1         = "InvoiceSv1"
+	FraudSv1           = "FraudSv1"
 	ResourceSv1        = "ResourceSv1"
 	RouteSv1           = "RouteSv1"
 	AttributeSv1       = "AttributeSv1"
@@ -1428,6 +1537,7 @@ const (
 	MetaNumber             = "*number"
 	MetaActivationInterval = "*ai"
 	MetaRegex              = "*regex"
+	MetaInSet              = "*in_set"
 	MetaContains           = "*contains"
 	MetaHTTP               = "*http"
 
@@ -1447,6 +1557,7 @@ const (
 	MetaNotSentryPeer         = "*notsentrypeer"
 	MetaNotActivationInterval = "*notai"
 	MetaNotRegex              = "*notregex"
+	MetaNotInSet              = "*notin_set"
 	MetaNotContains           = "*notcontains"
 
 	MetaEC = "*ec"
@@ -1471,6 +1582,8 @@ const (
 	ReplicatorSv1GetStatQueueProfile     = "ReplicatorSv1.GetStatQueueProfile"
 	ReplicatorSv1GetRanking              = "ReplicatorSv1.GetRanking"
 	ReplicatorSv1GetRankingProfile       = "ReplicatorSv1.GetRankingProfile"
+	ReplicatorSv1GetDataSet              = "ReplicatorSv1.GetDataSet"
+	ReplicatorSv1GetTaxProfile           = "ReplicatorSv1.GetTaxProfile"
 	ReplicatorSv1GetTrend                = "ReplicatorSv1.GetTrend"
 	ReplicatorSv1GetTrendProfile         = "ReplicatorSv1.GetTrendProfile"
 	ReplicatorSv1GetTiming               = "ReplicatorSv1.GetTiming"
@@ -1502,6 +1615,10 @@ const (
 	ReplicatorSv1SetStatQueueProfile     = "ReplicatorSv1.SetStatQueueProfile"
 	ReplicatorSv1SetRanking              = "ReplicatorSv1.SetRanking"
 	ReplicatorSv1SetRankingProfile       = "ReplicatorSv1.SetRankingProfile"
+	ReplicatorSv1SetDataSet              = "ReplicatorSv1.SetDataSet"
+	ReplicatorSv1AddDataSetEntries       = "ReplicatorSv1.AddDataSetEntries"
+	ReplicatorSv1RemoveDataSetEntries    = "ReplicatorSv1.RemoveDataSetEntries"
+	ReplicatorSv1SetTaxProfile           = "ReplicatorSv1.SetTaxProfile"
 	ReplicatorSv1SetTrend                = "ReplicatorSv1.SetTrend"
 	ReplicatorSv1SetTrendProfile         = "ReplicatorSv1.SetTrendProfile"
 	ReplicatorSv1SetTiming               = "ReplicatorSv1.SetTiming"
@@ -1523,6 +1640,8 @@ const (
 	ReplicatorSv1SetDispatcherHost       = "ReplicatorSv1.SetDispatcherHost"
 	ReplicatorSv1SetLoadIDs              = "ReplicatorSv1.SetLoadIDs"
 	ReplicatorSv1SetBackupSessions       = "ReplicatorSv1.SetBackupSessions"
+	ReplicatorSv1SetInvoice              = "ReplicatorSv1.SetInvoice"
+	ReplicatorSv1SetAuditRecord          = "ReplicatorSv1.SetAuditRecord"
 	ReplicatorSv1RemoveSessionBackup     = "ReplicatorSv1.RemoveSessionBackup"
 	ReplicatorSv1RemoveThreshold         = "ReplicatorSv1.RemoveThreshold"
 	ReplicatorSv1RemoveDestination       = "ReplicatorSv1.RemoveDestination"
@@ -1533,6 +1652,8 @@ const (
 	ReplicatorSv1RemoveStatQueueProfile  = "ReplicatorSv1.RemoveStatQueueProfile"
 	ReplicatorSv1RemoveRanking           = "ReplicatorSv1.RemoveRanking"
 	ReplicatorSv1RemoveRankingProfile    = "ReplicatorSv1.RemoveRankingProfile"
+	ReplicatorSv1RemoveDataSet           = "ReplicatorSv1.RemoveDataSet"
+	ReplicatorSv1RemoveTaxProfile        = "ReplicatorSv1.RemoveTaxProfile"
 	ReplicatorSv1RemoveTrend             = "ReplicatorSv1.RemoveTrend"
 	ReplicatorSv1RemoveTrendProfile      = "ReplicatorSv1.RemoveTrendProfile"
 	ReplicatorSv1RemoveTiming            = "ReplicatorSv1.RemoveTiming"
@@ -1555,6 +1676,9 @@ const (
 	ReplicatorSv1GetIndexes              = "ReplicatorSv1.GetIndexes"
 	ReplicatorSv1SetIndexes              = "ReplicatorSv1.SetIndexes"
 	ReplicatorSv1RemoveIndexes           = "ReplicatorSv1.RemoveIndexes"
+	ReplicatorSv1TryLock                 = "ReplicatorSv1.TryLock"
+	ReplicatorSv1RefreshLock             = "ReplicatorSv1.RefreshLock"
+	ReplicatorSv1Unlock                  = "ReplicatorSv1.Unlock"
 )
 
 // APIerSv1 APIs
@@ -1601,6 +1725,8 @@ const (
 	APIerSv1RemoveRatingProfile               = "APIerSv1.RemoveRatingProfile"
 	APIerSv1SetRatingProfile                  = "APIerSv1.SetRatingProfile"
 	APIerSv1GetRatingProfileIDs               = "APIerSv1.GetRatingProfileIDs"
+	APIerSv1GetAuditRecords                   = "APIerSv1.GetAuditRecords"
+	APIerSv1RestoreAuditVersion               = "APIerSv1.RestoreAuditVersion"
 	APIerSv1SetDataDBVersions                 = "APIerSv1.SetDataDBVersions"
 	APIerSv1SetStorDBVersions                 = "APIerSv1.SetStorDBVersions"
 	APIerSv1GetAccountActionPlan              = "APIerSv1.GetAccountActionPlan"
@@ -1673,6 +1799,10 @@ const (
 	APIerSv1SetTPRanking                      = "APIerSv1.SetTPRanking"
 	APIerSv1GetTPRanking                      = "APIerSv1.GetTPRanking"
 	APIerSv1RemoveTPRanking                   = "APIerSv1.RemoveTPRanking"
+	APIerSv1SetTPTaxProfile                   = "APIerSv1.SetTPTaxProfile"
+	APIerSv1GetTPTaxProfile                   = "APIerSv1.GetTPTaxProfile"
+	APIerSv1GetTPTaxProfileIDs                = "APIerSv1.GetTPTaxProfileIDs"
+	APIerSv1RemoveTPTaxProfile                = "APIerSv1.RemoveTPTaxProfile"
 	APIerSv1SetTPTrend                        = "APIerSv1.SetTPTrend"
 	APIerSv1GetTPTrend                        = "APIerSv1.GetTPTrend"
 	APIerSv1RemoveTPTrend                     = "APIerSv1.RemoveTPTrend"
@@ -1730,6 +1860,9 @@ const (
 	APIerSv1ReplayFailedPosts                 = "APIerSv1.ReplayFailedPosts"
 	APIerSv1RemoveAccount                     = "APIerSv1.RemoveAccount"
 	APIerSv1DebitUsage                        = "APIerSv1.DebitUsage"
+	APIerSv1ReserveBalance                    = "APIerSv1.ReserveBalance"
+	APIerSv1CommitReservation                 = "APIerSv1.CommitReservation"
+	APIerSv1ReleaseReservation                = "APIerSv1.ReleaseReservation"
 	APIerSv1GetCacheStats                     = "APIerSv1.GetCacheStats"
 	APIerSv1ReloadCache                       = "APIerSv1.ReloadCache"
 	APIerSv1GetActionTriggers                 = "APIerSv1.GetActionTriggers"
@@ -1918,6 +2051,24 @@ const (
 	TrendSv1GetTrendSummary    = "TrendSv1.GetTrendSummary"
 )
 
+// FraudS APIs
+const (
+	FraudSv1Ping                = "FraudSv1.Ping"
+	FraudSv1ScoreEvent          = "FraudSv1.ScoreEvent"
+	FraudSv1ProcessEvent        = "FraudSv1.ProcessEvent"
+	FraudSv1ReleaseEvent        = "FraudSv1.ReleaseEvent"
+	FraudSv1GetAccountBaselines = "FraudSv1.GetAccountBaselines"
+)
+
+// InvoiceS APIs
+const (
+	InvoiceSv1Ping            = "InvoiceSv1.Ping"
+	InvoiceSv1GenerateInvoice = "InvoiceSv1.GenerateInvoice"
+	InvoiceSv1GetInvoice      = "InvoiceSv1.GetInvoice"
+	InvoiceSv1GetInvoices     = "InvoiceSv1.GetInvoices"
+	InvoiceSv1RenderInvoice   = "InvoiceSv1.RenderInvoice"
+)
+
 // RankingS APIs
 const (
 	APIerSv1SetRankingProfile    = "APIerSv1.SetRankingProfile"
@@ -1931,6 +2082,24 @@ const (
 	RankingSv1GetRankingSummary  = "RankingSv1.GetRankingSummary"
 )
 
+// TaxProfile APIs
+const (
+	APIerSv1SetTaxProfile    = "APIerSv1.SetTaxProfile"
+	APIerSv1GetTaxProfile    = "APIerSv1.GetTaxProfile"
+	APIerSv1GetTaxProfileIDs = "APIerSv1.GetTaxProfileIDs"
+	APIerSv1RemoveTaxProfile = "APIerSv1.RemoveTaxProfile"
+)
+
+// DataSet APIs
+const (
+	APIerSv1SetDataSet           = "APIerSv1.SetDataSet"
+	APIerSv1GetDataSet           = "APIerSv1.GetDataSet"
+	APIerSv1GetDataSetIDs        = "APIerSv1.GetDataSetIDs"
+	APIerSv1RemoveDataSet        = "APIerSv1.RemoveDataSet"
+	APIerSv1AddDataSetEntries    = "APIerSv1.AddDataSetEntries"
+	APIerSv1RemoveDataSetEntries = "APIerSv1.RemoveDataSetEntries"
+)
+
 // ResourceS APIs
 const (
 	ResourceSv1Ping                  = "ResourceSv1.Ping"
@@ -1993,6 +2162,7 @@ const (
 	SessionSv1Sleep                      = "SessionSv1.Sleep"
 	SessionSv1CapsError                  = "SessionSv1.CapsError"
 	SessionSv1BackupActiveSessions       = "SessionSv1.BackupActiveSessions"
+	SessionSv1HandoffSessions            = "SessionSv1.HandoffSessions"
 )
 
 // Agent APIs
@@ -2030,6 +2200,7 @@ const (
 	DispatcherSv1RemoteStatus        = "DispatcherSv1.RemoteStatus"
 	DispatcherSv1RemoteSleep         = "DispatcherSv1.RemoteSleep"
 	DispatcherSv1RemotePing          = "DispatcherSv1.RemotePing"
+	DispatcherSv1GetHostsStats       = "DispatcherSv1.GetHostsStats"
 )
 
 // RegistrarS APIs
@@ -2041,11 +2212,21 @@ const (
 	RegistrarSv1UnregisterRPCHosts = "RegistrarSv1.UnregisterRPCHosts"
 )
 
+// AuthS APIs
+const (
+	APIAuth             = "APIAuth"
+	AuthSv1             = "AuthSv1"
+	AuthSv1Authenticate = "AuthSv1.Authenticate"
+)
+
 // AnalyzerS APIs
 const (
-	AnalyzerSv1            = "AnalyzerSv1"
-	AnalyzerSv1Ping        = "AnalyzerSv1.Ping"
-	AnalyzerSv1StringQuery = "AnalyzerSv1.StringQuery"
+	AnalyzerSv1             = "AnalyzerSv1"
+	AnalyzerSv1Ping         = "AnalyzerSv1.Ping"
+	AnalyzerSv1StringQuery  = "AnalyzerSv1.StringQuery"
+	AnalyzerSv1Replay       = "AnalyzerSv1.Replay"
+	AnalyzerSv1ReplayStatus = "AnalyzerSv1.ReplayStatus"
+	AnalyzerSv1ReplayCancel = "AnalyzerSv1.ReplayCancel"
 )
 
 // LoaderS APIs
@@ -2093,6 +2274,7 @@ const (
 	CDRsV1                   = "CDRsV1"
 	CDRsV1GetCDRsCount       = "CDRsV1.GetCDRsCount"
 	CDRsV1RateCDRs           = "CDRsV1.RateCDRs"
+	CDRsV1SimulateRateCDRs   = "CDRsV1.SimulateRateCDRs"
 	CDRsV1ReprocessCDRs      = "CDRsV1.ReprocessCDRs"
 	CDRsV1GetCDRs            = "CDRsV1.GetCDRs"
 	CDRsV1ProcessCDR         = "CDRsV1.ProcessCDR"
@@ -2165,6 +2347,8 @@ const (
 	StatsCsv              = "Stats.csv"
 	TrendsCsv             = "Trends.csv"
 	RankingsCsv           = "Rankings.csv"
+	TaxProfilesCsv        = "TaxProfiles.csv"
+	DataSetsCsv           = "DataSets.csv"
 	ThresholdsCsv         = "Thresholds.csv"
 	FiltersCsv            = "Filters.csv"
 	RoutesCsv             = "Routes.csv"
@@ -2191,6 +2375,7 @@ const (
 	TBLTPIPs              = "tp_ips"
 	TBLTPStats            = "tp_stats"
 	TBLTPRankings         = "tp_rankings"
+	TBLTPTaxProfiles      = "tp_tax_profiles"
 	TBLTPTrends           = "tp_trends"
 	TBLTPThresholds       = "tp_thresholds"
 	TBLTPFilters          = "tp_filters"
@@ -2226,6 +2411,8 @@ const (
 	CacheStatQueueProfiles       = "*statqueue_profiles"
 	CacheStatQueues              = "*statqueues"
 	CacheRankingProfiles         = "*ranking_profiles"
+	CacheDataSets                = "*data_sets"
+	CacheTaxProfiles             = "*tax_profiles"
 	CacheTrendProfiles           = "*trend_profiles"
 	CacheTrends                  = "*trends"
 	CacheRankings                = "*rankings"
@@ -2249,6 +2436,7 @@ const (
 	CacheAttributeFilterIndexes  = "*attribute_filter_indexes"
 	CacheChargerFilterIndexes    = "*charger_filter_indexes"
 	CacheDispatcherFilterIndexes = "*dispatcher_filter_indexes"
+	CacheTaxProfileFilterIndexes = "*tax_profile_filter_indexes"
 	CacheDiameterMessages        = "*diameter_messages"
 	CacheRadiusPackets           = "*radius_packets"
 	CacheRPCResponses            = "*rpc_responses"
@@ -2267,6 +2455,8 @@ const (
 	CacheVersions                = "*versions"
 	CacheCapsEvents              = "*caps_events"
 	CacheSessionsBackup          = "*sessions_backup"
+	CacheInvoices                = "*invoices"
+	CacheAuditRecords            = "*audit_records"
 	CacheReplicationHosts        = "*replication_hosts"
 
 	// storDB
@@ -2286,6 +2476,7 @@ const (
 	CacheTBLTPStats            = "*tp_stats"
 	CacheTBLTPTrends           = "*tp_trends"
 	CacheTBLTPRankings         = "*tp_rankings"
+	CacheTBLTPTaxProfiles      = "*tp_tax_profiles"
 	CacheTBLTPThresholds       = "*tp_thresholds"
 	CacheTBLTPFilters          = "*tp_filters"
 	CacheSessionCostsTBL       = "*session_costs"
@@ -2306,6 +2497,7 @@ const (
 	AttributeFilterIndexes  = "afi_"
 	ChargerFilterIndexes    = "cfi_"
 	DispatcherFilterIndexes = "dfi_"
+	TaxProfileFilterIndexes = "xfi_"
 	ActionPlanIndexes       = "api_"
 	RouteFilterIndexes      = "rti_"
 	FilterIndexPrfx         = "fii_"
@@ -2321,6 +2513,7 @@ const (
 	HTTPAgent       = "HTTPAgent"
 	SIPAgent        = "SIPAgent"
 	JanusAgent      = "JanusAgent"
+	SMPPAgent       = "SMPPAgent"
 	PrometheusAgent = "PrometheusAgent"
 )
 
@@ -2364,6 +2557,8 @@ const (
 	ConnectTimeoutCfg       = "connect_timeout"
 	ReplyTimeoutCfg         = "reply_timeout"
 	LockingTimeoutCfg       = "locking_timeout"
+	DistributedLockingCfg   = "distributed_locking"
+	LockingLeaseTTLCfg      = "locking_lease_ttl"
 	DigestSeparatorCfg      = "digest_separator"
 	DigestEqualCfg          = "digest_equal"
 	RSRSepCfg               = "rsr_separator"
@@ -2410,6 +2605,8 @@ const (
 	InternalDBDumpIntervalCfg    = "internalDBDumpInterval"
 	InternalDBRewriteIntervalCfg = "internalDBRewriteInterval"
 	InternalDBFileSizeLimitCfg   = "internalDBFileSizeLimit"
+	InternalDBWALCfg             = "internalDBWAL"
+	InternalDBWALSyncIntervalCfg = "internalDBWALSyncInterval"
 	RedisMaxConnsCfg             = "redisMaxConns"
 	RedisConnectAttemptsCfg      = "redisConnectAttempts"
 	RedisSentinelNameCfg         = "redisSentinel"
@@ -2469,6 +2666,10 @@ const (
 	HTTPJsonRPCURLCfg        = "json_rpc_url"
 	RegistrarSURLCfg         = "registrars_url"
 	HTTPWSURLCfg             = "ws_url"
+	BiRPCWSURLCfg            = "birpc_ws_url"
+	BiRPCWSPingCfg           = "birpc_ws_ping"
+	BiRPCWSOriginsCfg        = "birpc_ws_origins"
+	BiRPCWSReadLimitCfg      = "birpc_ws_read_limit"
 	HTTPFreeswitchCDRsURLCfg = "freeswitch_cdrs_url"
 	HTTPCDRsURLCfg           = "http_cdrs"
 	PprofPathCfg             = "pprof_path"
@@ -2533,6 +2734,7 @@ const (
 	OnlineCDRExportsCfg    = "online_cdr_exports"
 	SessionCostRetires     = "session_cost_retries"
 	RateSConnsCfg          = "rates_conns"
+	ApplyTaxesCfg          = "apply_taxes"
 )
 
 // SessionSCfg
@@ -2541,6 +2743,7 @@ const (
 	ListenBigobCfg            = "listen_bigob"
 	RALsConnsCfg              = "rals_conns"
 	IPsConnsCfg               = "ips_conns"
+	FraudSConnsCfg            = "fraud_conns"
 	ResSConnsCfg              = "resources_conns"
 	ThreshSConnsCfg           = "thresholds_conns"
 	RouteSConnsCfg            = "routes_conns"
@@ -2647,6 +2850,10 @@ const (
 	HostCfg               = "host"
 	PortCfg               = "port"
 
+	// SMPPAgentCfg
+	SystemIDCfg        = "system_id"
+	BindCredentialsCfg = "bind_credentials"
+
 	// PrometheusAgentCfg
 	CoreSConnsCfg            = "cores_conns"
 	CollectGoMetricsCfg      = "collect_go_metrics"
@@ -2879,6 +3086,50 @@ const (
 	KeysCfg = "keys"
 )
 
+// APIAuthCfg
+const (
+	JWTSecretCfg = "jwt_secret"
+	RolesCfg     = "roles"
+	APIKeysCfg   = "api_keys"
+	KeyCfg       = "key"
+	APIKeyHeader = "X-API-Key"
+)
+
+// AuditCfg
+const (
+	ObjectTypesCfg = "object_types"
+)
+
+// InvoiceSCfg
+const (
+	InvoiceSConnsCfg  = "invoices_conns"
+	RunIDsCfg         = "run_ids"
+	NumberPrefixCfg   = "number_prefix"
+	TaxRatesCfg       = "tax_rates"
+	HTMLTemplateCfg   = "html_template"
+	DocumentFormatCfg = "document_format"
+)
+
+// FraudSCfg
+const (
+	WindowCfg       = "window"
+	BaselineCfg     = "baseline"
+	IndicatorsCfg   = "indicators"
+	FactorCfg       = "factor"
+	MinValueCfg     = "min_value"
+	ScoreCfg        = "score"
+	FlagScoreCfg    = "flag_score"
+	BlockScoreCfg   = "block_score"
+	ThresholdIDsCfg = "threshold_ids"
+	ProfilesCfg     = "profiles"
+	WeightCfg       = "weight"
+
+	MetaCostPerHour     = "*cost_per_hour"
+	MetaHighRiskCalls   = "*high_risk_calls"
+	MetaConcurrentCalls = "*concurrent_calls"
+	MetaNewDestinations = "*new_destinations"
+)
+
 // SentryPeerCfg
 const (
 	ClientIdCfg      = "client_id"
@@ -2929,7 +3180,7 @@ var CGROptionsSet = NewStringSet([]string{OptsSessionsTTL,
 	OptsAttributesProfileIgnoreFilters, OptsStatsProfileIDs, OptsStatsProfileIgnoreFilters,
 	OptsThresholdsProfileIDs, OptsThresholdsProfileIgnoreFilters, OptsResourcesUsageID, OptsResourcesUsageTTL,
 	OptsResourcesUnits, OptsIPsAllocationID, OptsIPsTTL, OptsAttributeS, OptsThresholdS, OptsChargerS,
-	OptsStatS, OptsRALs, OptsRerate, OptsRefund, MetaAccountID})
+	OptsStatS, OptsRALs, OptsRerate, OptsRefund, OptsTaxS, MetaAccountID})
 
 // EventExporter metrics
 const (
@@ -2972,6 +3223,8 @@ const (
 	OptsAPIKey                   = "*apiKey"
 	OptsRouteID                  = "*routeID"
 	OptsDispatchersProfilesCount = "*dispatchersProfilesCount"
+	// audit trail
+	OptsActor = "*actor"
 	// EEs
 	OptsEEsVerbose = "*eesVerbose"
 
@@ -3008,6 +3261,8 @@ const (
 	OptsRALs       = "*ralS"
 	OptsRerate     = "*rerate"
 	OptsRefund     = "*refund"
+	OptsTaxS       = "*taxS"
+	OptsFraudS     = "*fraudS"
 	// Others
 	OptsContext                        = "*context"
 	MetaSubsys                         = "*subsys"
@@ -3077,6 +3332,9 @@ const (
 	SQLBatchSize              = "sqlBatchSize"
 	SQLDeleteIndexedFieldsOpt = "sqlDeleteIndexedFields"
 	SQLUpdateIndexedFieldsOpt = "sqlUpdateIndexedFields"
+	SQLBatchIntervalOpt       = "sqlBatchInterval"
+	SQLConflictFieldsOpt      = "sqlConflictFields"
+	SQLCreateTableOpt         = "sqlCreateTable"
 
 	SQLMaxOpenConns    = "sqlMaxOpenConns"
 	SQLConnMaxLifetime = "sqlConnMaxLifetime"
@@ -3168,6 +3426,55 @@ const (
 	NatsJetStream            = "natsJetStream"
 	NatsJetStreamMaxWait     = "natsJetStreamMaxWait"
 
+	// mqtt
+	MQTTDefaultQoS = 1
+
+	MQTTTopic             = "mqttTopic"
+	MQTTQoS               = "mqttQoS"
+	MQTTSharedGroup       = "mqttSharedGroup"
+	MQTTRetain            = "mqttRetain"
+	MQTTClientID          = "mqttClientID"
+	MQTTUsername          = "mqttUsername"
+	MQTTPassword          = "mqttPassword"
+	MQTTTLS               = "mqttTLS"
+	MQTTCAPath            = "mqttCAPath"
+	MQTTSkipTLSVerify     = "mqttSkipTLSVerify"
+	MQTTClientCertificate = "mqttClientCertificate"
+	MQTTClientKey         = "mqttClientKey"
+
+	// redis streams
+	RedisStreamDefaultGroup = "cgrates"
+	RedisStreamDefaultField = "event"
+
+	RedisStreamOpt     = "redisStream"
+	RedisGroup         = "redisGroup"
+	RedisConsumer      = "redisConsumer"
+	RedisField         = "redisField"
+	RedisCount         = "redisCount"
+	RedisBlock         = "redisBlock"
+	RedisClaimMinIdle  = "redisClaimMinIdle"
+	RedisClaimInterval = "redisClaimInterval"
+	RedisMaxDeliveries = "redisMaxDeliveries"
+	RedisMaxLen        = "redisMaxLen"
+	RedisMaxLenApprox  = "redisMaxLenApprox"
+
+	// webhook
+	WebhookDefaultSignatureHeader  = "X-CGR-Signature"
+	WebhookDefaultTimestampHeader  = "X-CGR-Timestamp"
+	WebhookDefaultBackoff          = time.Second
+	WebhookDefaultMaxBackoff       = 30 * time.Second
+	WebhookDefaultBreakerCooldown  = 30 * time.Second
+	WebhookDefaultBreakerThreshold = 5
+
+	WebhookSecret           = "webhookSecret"
+	WebhookSignatureHeader  = "webhookSignatureHeader"
+	WebhookTimestampHeader  = "webhookTimestampHeader"
+	WebhookAuthHeaders      = "webhookAuthHeaders"
+	WebhookBackoff          = "webhookBackoff"
+	WebhookMaxBackoff       = "webhookMaxBackoff"
+	WebhookBreakerThreshold = "webhookBreakerThreshold"
+	WebhookBreakerCooldown  = "webhookBreakerCooldown"
+
 	// rpc
 	RpcCodec        = "rpcCodec"
 	ServiceMethod   = "serviceMethod"
@@ -3190,6 +3497,8 @@ const (
 
 	RequestStartTime = "RequestStartTime"
 	RequestDuration  = "RequestDuration"
+	RequestID        = "RequestID"
+	RequestMethod    = "RequestMethod"
 	RequestParams    = "RequestParams"
 	Reply            = "Reply"
 	ReplyError       = "ReplyError"
@@ -31,6 +31,8 @@ var (
 	ErrDSPHostNotFound                  = errors.New("DSP_HOST_NOT_FOUND")
 	ErrDSPProfileNotFound               = errors.New("DSP_PROFILE_NOT_FOUND")
 	ErrTimedOut                         = errors.New("TIMED_OUT")
+	ErrLockUnavailable                  = errors.New("LOCK_UNAVAILABLE")
+	ErrLockLost                         = errors.New("LOCK_LOST")
 	ErrServerError                      = errors.New("SERVER_ERROR")
 	ErrMaxRecursionDepth                = errors.New("MAX_RECURSION_DEPTH")
 	ErrMandatoryIeMissing               = errors.New("MANDATORY_IE_MISSING")
@@ -51,6 +53,7 @@ var (
 	ErrIPUnavailable                    = errors.New("IP_UNAVAILABLE")
 	ErrIPUnauthorized                   = errors.New("IP_UNAUTHORIZED")
 	ErrIPAlreadyAllocated               = errors.New("IP_ALREADY_ALLOCATED")
+	ErrFraudDetected                    = errors.New("FRAUD_DETECTED")
 	ErrNoActiveSession                  = errors.New("NO_ACTIVE_SESSION")
 	ErrPartiallyExecuted                = errors.New("PARTIALLY_EXECUTED")
 	ErrMaxUsageExceeded                 = errors.New("MAX_USAGE_EXCEEDED")
@@ -68,6 +71,10 @@ var (
 	ErrJsonIncompleteComment            = errors.New("JSON_INCOMPLETE_COMMENT")
 	ErrNotEnoughParameters              = errors.New("NotEnoughParameters")
 	ErrNotConnected                     = errors.New("NOT_CONNECTED")
+	ErrCircuitBreakerOpen               = errors.New("CIRCUIT_BREAKER_OPEN")
+	ErrSessionsDraining                 = errors.New("SESSIONS_DRAINING")
+	ErrUnauthenticated                  = errors.New("UNAUTHENTICATED")
+	ErrUnauthorizedTenant               = errors.New("UNAUTHORIZED_TENANT")
 	RalsErrorPrfx                       = "RALS_ERROR"
 	DispatcherErrorPrefix               = "DISPATCHER_ERROR"
 	RateSErrPrfx                        = "RATES_ERROR"
@@ -94,6 +101,8 @@ var (
 		ErrDSPHostNotFound.Error():                  ErrDSPHostNotFound,
 		ErrNotFound.Error():                         ErrNotFound,
 		ErrTimedOut.Error():                         ErrTimedOut,
+		ErrLockUnavailable.Error():                  ErrLockUnavailable,
+		ErrLockLost.Error():                         ErrLockLost,
 		ErrServerError.Error():                      ErrServerError,
 		ErrMaxRecursionDepth.Error():                ErrMaxRecursionDepth,
 		ErrExists.Error():                           ErrExists,
@@ -123,6 +132,9 @@ var (
 		ErrDisconnected.Error():                     ErrDisconnected,
 		ErrReplyTimeout.Error():                     ErrReplyTimeout,
 		ErrSessionNotFound.Error():                  ErrSessionNotFound,
+		ErrSessionsDraining.Error():                 ErrSessionsDraining,
+		ErrUnauthenticated.Error():                  ErrUnauthenticated,
+		ErrUnauthorizedTenant.Error():               ErrUnauthorizedTenant,
 		ErrJsonIncompleteComment.Error():            ErrJsonIncompleteComment,
 		ErrNotEnoughParameters.Error():              ErrNotEnoughParameters,
 		ErrUnsupportedFormat.Error():                ErrUnsupportedFormat,
@@ -198,6 +210,10 @@ func NewErrIPs(err error) error {
 	return fmt.Errorf("IPS_ERROR:%s", err)
 }
 
+func NewErrFraudS(err error) error {
+	return fmt.Errorf("FRAUDS_ERROR:%s", err)
+}
+
 func NewErrRouteS(err error) error {
 	return fmt.Errorf("ROUTES_ERROR:%s", err)
 }
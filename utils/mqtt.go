@@ -0,0 +1,99 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	mqttDefaultPort    = "1883"
+	mqttDefaultTLSPort = "8883"
+)
+
+// MQTTBrokerURL returns the broker URL out of an address like tcp://host:port,
+// mqtts://host:port or plain host, adding the default port when missing and
+// switching to a secure scheme when TLS is requested
+func MQTTBrokerURL(addr string, useTLS bool) string {
+	scheme, hostPort, has := strings.Cut(addr, "://")
+	if !has {
+		scheme, hostPort = "tcp", addr
+	}
+	switch strings.ToLower(scheme) {
+	case "ssl", "tls", "mqtts":
+		useTLS = true
+	default:
+		if useTLS {
+			scheme = "ssl"
+		}
+	}
+	if _, _, err := net.SplitHostPort(hostPort); err != nil {
+		port := mqttDefaultPort
+		if useTLS {
+			port = mqttDefaultTLSPort
+		}
+		hostPort = net.JoinHostPort(hostPort, port)
+	}
+	return scheme + "://" + hostPort
+}
+
+// NewMQTTTLSConfig builds the TLS configuration out of the CA and client certificate paths,
+// the CA is added to the system pool and the client certificate is optional
+func NewMQTTTLSConfig(caPath, certPath, keyPath string, skipVerify bool) (tlsCfg *tls.Config, err error) {
+	tlsCfg = &tls.Config{InsecureSkipVerify: skipVerify}
+	if caPath != EmptyString {
+		if tlsCfg.RootCAs, err = x509.SystemCertPool(); err != nil {
+			return
+		}
+		var ca []byte
+		if ca, err = os.ReadFile(caPath); err != nil {
+			return
+		}
+		if !tlsCfg.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to append certificates from PEM file: %s", caPath)
+		}
+	}
+	if certPath != EmptyString || keyPath != EmptyString {
+		var cert tls.Certificate
+		if cert, err = tls.LoadX509KeyPair(certPath, keyPath); err != nil {
+			return
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return
+}
+
+// MQTTWait waits for the MQTT operation to complete, returning its error,
+// a timeout lower or equal to 0 waits indefinitely
+func MQTTWait(tkn mqtt.Token, timeout time.Duration) error {
+	if timeout <= 0 {
+		tkn.Wait()
+	} else if !tkn.WaitTimeout(timeout) {
+		return ErrTimedOut
+	}
+	return tkn.Error()
+}
@@ -0,0 +1,94 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMQTTBrokerURL(t *testing.T) {
+	tests := []struct {
+		addr   string
+		useTLS bool
+		exp    string
+	}{
+		{"127.0.0.1:1883", false, "tcp://127.0.0.1:1883"},
+		{"tcp://localhost:1884", false, "tcp://localhost:1884"},
+		{"mqtt://localhost", false, "mqtt://localhost:1883"},
+		{"ssl://broker.example.com", false, "ssl://broker.example.com:8883"},
+		{"mqtts://broker.example.com:8884", false, "mqtts://broker.example.com:8884"},
+		{"broker.example.com", true, "ssl://broker.example.com:8883"},
+		{"tcp://broker.example.com:8884", true, "ssl://broker.example.com:8884"},
+	}
+	for _, tt := range tests {
+		if rcv := MQTTBrokerURL(tt.addr, tt.useTLS); rcv != tt.exp {
+			t.Errorf("<%s>: expected %s, received %s", tt.addr, tt.exp, rcv)
+		}
+	}
+}
+
+func TestNewMQTTTLSConfig(t *testing.T) {
+	tlsCfg, err := NewMQTTTLSConfig(EmptyString, EmptyString, EmptyString, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tlsCfg.InsecureSkipVerify || tlsCfg.RootCAs != nil || len(tlsCfg.Certificates) != 0 {
+		t.Errorf("unexpected TLS config: %+v", tlsCfg)
+	}
+	if _, err = NewMQTTTLSConfig("/tmp/inexistent_ca.pem", EmptyString, EmptyString, false); err == nil {
+		t.Error("expected error for missing CA file")
+	}
+	if _, err = NewMQTTTLSConfig(EmptyString, "/tmp/inexistent.crt", "/tmp/inexistent.key", false); err == nil {
+		t.Error("expected error for missing client certificate")
+	}
+}
+
+type testMQTTToken struct {
+	done chan struct{}
+	err  error
+}
+
+func (tkn *testMQTTToken) Wait() bool { <-tkn.done; return true }
+func (tkn *testMQTTToken) WaitTimeout(d time.Duration) bool {
+	select {
+	case <-tkn.done:
+		return true
+	case <-time.After(d):
+		return false
+	}
+}
+func (tkn *testMQTTToken) Done() <-chan struct{} { return tkn.done }
+func (tkn *testMQTTToken) Error() error          { return tkn.err }
+
+func TestMQTTWait(t *testing.T) {
+	tkn := &testMQTTToken{done: make(chan struct{})}
+	if err := MQTTWait(tkn, 10*time.Millisecond); err != ErrTimedOut {
+		t.Errorf("expected %v, received %v", ErrTimedOut, err)
+	}
+	tkn.err = errors.New("not authorized")
+	close(tkn.done)
+	if err := MQTTWait(tkn, 0); err != tkn.err {
+		t.Errorf("expected %v, received %v", tkn.err, err)
+	}
+	if err := MQTTWait(tkn, time.Second); err != tkn.err {
+		t.Errorf("expected %v, received %v", tkn.err, err)
+	}
+}
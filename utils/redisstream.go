@@ -0,0 +1,62 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"crypto/tls"
+	"strings"
+	"time"
+
+	"github.com/mediocregopher/radix/v3"
+)
+
+// RedisStreamClientOpts the options used when connecting to a Redis server for streams
+type RedisStreamClientOpts struct {
+	Address        string      // host:port or redis:// URL carrying the credentials and db, rediss:// enables TLS
+	TLSConfig      *tls.Config // enables TLS, defaults to an empty config for the rediss:// scheme
+	ConnectTimeout time.Duration
+	ReadTimeout    time.Duration // needs to be over the BLOCK used by the stream reads
+	PoolSize       int
+}
+
+// NewRedisStreamClient returns a connection pool to the Redis server
+func NewRedisStreamClient(opts RedisStreamClientOpts) (*radix.Pool, error) {
+	addr := opts.Address
+	tlsCfg := opts.TLSConfig
+	if strings.HasPrefix(addr, "rediss://") {
+		addr = "redis://" + strings.TrimPrefix(addr, "rediss://")
+		if tlsCfg == nil {
+			tlsCfg = new(tls.Config)
+		}
+	}
+	dialOpts := make([]radix.DialOpt, 0, 3)
+	if opts.ConnectTimeout > 0 {
+		dialOpts = append(dialOpts, radix.DialConnectTimeout(opts.ConnectTimeout))
+	}
+	if opts.ReadTimeout > 0 {
+		dialOpts = append(dialOpts, radix.DialReadTimeout(opts.ReadTimeout))
+	}
+	if tlsCfg != nil {
+		dialOpts = append(dialOpts, radix.DialUseTLS(tlsCfg))
+	}
+	return radix.NewPool(TCP, addr, opts.PoolSize,
+		radix.PoolConnFunc(func(network, addr string) (radix.Conn, error) {
+			return radix.Dial(network, addr, dialOpts...)
+		}))
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -879,3 +880,64 @@ func ReflectFieldMethodInterface(obj any, fldName string) (retIf any, err error)
 	}
 	return field.Interface(), nil
 }
+
+// ValueDiff is a leaf field which differs between two versions of a value
+type ValueDiff struct {
+	Path   string
+	Before any
+	After  any
+}
+
+// DiffJSONValues returns the leaf fields which differ between the two values decoded out
+// of JSON, the maps being compared key by key and the slices item by item
+func DiffJSONValues(before, after any) []*ValueDiff {
+	return diffJSONValues(EmptyString, before, after, nil)
+}
+
+func diffJSONValues(path string, before, after any, diffs []*ValueDiff) []*ValueDiff {
+	switch bVal := before.(type) {
+	case map[string]any:
+		if aVal, isMap := after.(map[string]any); isMap {
+			keys := make([]string, 0, len(bVal)+len(aVal))
+			for k := range bVal {
+				keys = append(keys, k)
+			}
+			for k := range aVal {
+				if _, has := bVal[k]; !has {
+					keys = append(keys, k)
+				}
+			}
+			slices.Sort(keys)
+			for _, k := range keys {
+				fldPath := k
+				if path != EmptyString {
+					fldPath = path + NestingSep + k
+				}
+				diffs = diffJSONValues(fldPath, bVal[k], aVal[k], diffs)
+			}
+			return diffs
+		}
+	case []any:
+		if aVal, isSlice := after.([]any); isSlice {
+			for i := range max(len(bVal), len(aVal)) {
+				var bItm, aItm any // nil for the missing items
+				if i < len(bVal) {
+					bItm = bVal[i]
+				}
+				if i < len(aVal) {
+					aItm = aVal[i]
+				}
+				diffs = diffJSONValues(path+IdxStart+strconv.Itoa(i)+IdxEnd, bItm, aItm, diffs)
+			}
+			return diffs
+		}
+	}
+	if !reflect.DeepEqual(before, after) {
+		diffs = append(diffs, &ValueDiff{
+			Path:   path,
+			Before: before,
+			After:  after,
+		})
+	}
+	return diffs
+}
@@ -2095,3 +2095,30 @@ func TestDifferences(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffJSONValues(t *testing.T) {
+	before := map[string]any{
+		"ID":        "ATTR1",
+		"Weight":    10.,
+		"FilterIDs": []any{"*string:~*req.Account:1001"},
+		"Blocker":   false,
+	}
+	after := map[string]any{
+		"ID":        "ATTR1",
+		"Weight":    20.,
+		"FilterIDs": []any{"*string:~*req.Account:1001", "*prefix:~*req.Destination:10"},
+		"Contexts":  []any{"*any"},
+	}
+	exp := []*ValueDiff{
+		{Path: "Blocker", Before: false},
+		{Path: "Contexts", After: []any{"*any"}},
+		{Path: "FilterIDs[1]", After: "*prefix:~*req.Destination:10"},
+		{Path: "Weight", Before: 10., After: 20.},
+	}
+	if rcv := DiffJSONValues(before, after); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %s, received %s", ToJSON(exp), ToJSON(rcv))
+	}
+	if rcv := DiffJSONValues(before, before); rcv != nil {
+		t.Errorf("expected no diffs, received %s", ToJSON(rcv))
+	}
+}